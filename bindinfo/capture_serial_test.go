@@ -312,6 +312,36 @@ func TestBindingSource(t *testing.T) {
 	require.Equal(t, bindinfo.Capture, bind.Source)
 }
 
+func TestCaptureJoinOrder(t *testing.T) {
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("create table t3(a int, b int)")
+
+	tk.MustExec("select /*+ leading(t3, t2) */ * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b")
+	tk.MustExec("select /*+ leading(t3, t2) */ * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b")
+	tk.MustExec("admin capture bindings")
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "select * from ( `test` . `t1` join `test` . `t2` on `t1` . `a` = `t2` . `a` ) join `test` . `t3` on `t2` . `b` = `t3` . `b`", rows[0][0])
+	require.Contains(t, rows[0][1], "leading(@`sel_1` `test`.`t3`, `test`.`t2`, `test`.`t1`)")
+
+	// The captured binding keeps the join order without the hint in the query.
+	tk.MustExec("select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustQuery("show warnings").Check(testkit.Rows())
+	rows = tk.MustQuery("explain format = 'brief' select /*+ leading(t3, t2) */ * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b").Rows()
+	tk.MustQuery("explain format = 'brief' select * from t1 join t2 on t1.a = t2.a join t3 on t2.b = t3.b").Check(rows)
+}
+
 func TestCapturedBindingCharset(t *testing.T) {
 	store, clean := testkit.CreateMockStore(t)
 	defer clean()
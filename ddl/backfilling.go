@@ -46,15 +46,17 @@ import (
 type backfillWorkerType byte
 
 const (
-	typeAddIndexWorker     backfillWorkerType = 0
-	typeUpdateColumnWorker backfillWorkerType = 1
-	typeCleanUpIndexWorker backfillWorkerType = 2
+	typeAddIndexWorker       backfillWorkerType = 0
+	typeUpdateColumnWorker   backfillWorkerType = 1
+	typeCleanUpIndexWorker   backfillWorkerType = 2
+	typeReorgPartitionWorker backfillWorkerType = 3
 )
 
 // By now the DDL jobs that need backfilling include:
 // 1: add-index
 // 2: modify-column-type
 // 3: clean-up global index
+// 4: reorganize partition
 //
 // They all have a write reorganization state to back fill data into the rows existed.
 // Backfilling is time consuming, to accelerate this process, TiDB has built some sub
@@ -111,6 +113,8 @@ func (bWT backfillWorkerType) String() string {
 		return "update column"
 	case typeCleanUpIndexWorker:
 		return "clean up index"
+	case typeReorgPartitionWorker:
+		return "reorganize partition"
 	default:
 		return "unknown"
 	}
@@ -518,13 +522,25 @@ func makeupDecodeColMap(sessCtx sessionctx.Context, t table.Table) (map[int64]de
 	for _, col := range t.WritableCols() {
 		writableColInfos = append(writableColInfos, col.ColumnInfo)
 	}
-	exprCols, _, err := expression.ColumnInfos2ColumnsAndNames(sessCtx, dbName, t.Meta().Name, writableColInfos, t.Meta())
+	exprCols, names, err := expression.ColumnInfos2ColumnsAndNames(sessCtx, dbName, t.Meta().Name, writableColInfos, t.Meta())
 	if err != nil {
 		return nil, err
 	}
 	mockSchema := expression.NewSchema(exprCols...)
 
 	decodeColMap := decoder.BuildFullDecodeColMap(t.WritableCols(), mockSchema)
+	// The stored generated columns which aren't public are being added or changed from the virtual ones,
+	// their values are evaluated like the virtual generated columns when the rows are backfilled.
+	for _, col := range t.WritableCols() {
+		if !col.IsGenerated() || !col.GeneratedStored || col.State == model.StatePublic {
+			continue
+		}
+		genExpr, err := buildGeneratedColumnExpr(sessCtx, t.Meta(), col.ColumnInfo, mockSchema, names)
+		if err != nil {
+			return nil, err
+		}
+		decodeColMap[col.ID] = decoder.Column{Col: col, GenExpr: genExpr}
+	}
 
 	return decodeColMap, nil
 }
@@ -622,6 +638,14 @@ func (w *worker) writePhysicalTableRecord(t table.PhysicalTable, bfWorkerType ba
 				idxWorker.priority = job.Priority
 				backfillWorkers = append(backfillWorkers, idxWorker.backfillWorker)
 				go idxWorker.backfillWorker.run(reorgInfo.d, idxWorker, job)
+			case typeReorgPartitionWorker:
+				partWorker, err := newReorgPartitionWorker(sessCtx, w, i, t, decodeColMap)
+				if err != nil {
+					return errors.Trace(err)
+				}
+				partWorker.priority = job.Priority
+				backfillWorkers = append(backfillWorkers, partWorker.backfillWorker)
+				go partWorker.backfillWorker.run(reorgInfo.d, partWorker, job)
 			default:
 				return errors.New("unknow backfill type")
 			}
@@ -81,6 +81,62 @@ func adjustColumnInfoInAddColumn(tblInfo *model.TableInfo, offset int) {
 	tblInfo.Columns = newCols
 }
 
+// locateOffsetToMove returns the offset which the column at currentOffset should be moved to by the position.
+func locateOffsetToMove(currentOffset int, pos *ast.ColumnPosition, tblInfo *model.TableInfo) (int, error) {
+	if pos == nil {
+		return currentOffset, nil
+	}
+	switch pos.Tp {
+	case ast.ColumnPositionFirst:
+		return 0, nil
+	case ast.ColumnPositionAfter:
+		c := model.FindColumnInfo(tblInfo.Columns, pos.RelativeColumn.Name.L)
+		if c == nil {
+			return 0, infoschema.ErrColumnNotExists.GenWithStackByArgs(pos.RelativeColumn, tblInfo.Name)
+		}
+		if currentOffset <= c.Offset {
+			return c.Offset, nil
+		}
+		return c.Offset + 1, nil
+	}
+	return currentOffset, nil
+}
+
+// moveColumnInfo moves the column from the offset "from" to the offset "to",
+// the offsets of the shifted columns and the relative index columns are updated as well.
+func moveColumnInfo(tblInfo *model.TableInfo, from, to int) {
+	if from == to {
+		return
+	}
+	offsetChanged := make(map[int]int)
+	cols := tblInfo.Columns
+	src := cols[from]
+	if from < to {
+		for i := from; i < to; i++ {
+			cols[i] = cols[i+1]
+			offsetChanged[i+1] = i
+			cols[i].Offset = i
+		}
+	} else {
+		for i := from; i > to; i-- {
+			cols[i] = cols[i-1]
+			offsetChanged[i-1] = i
+			cols[i].Offset = i
+		}
+	}
+	cols[to] = src
+	src.Offset = to
+	offsetChanged[from] = to
+	// Update index column offset info.
+	for _, idx := range tblInfo.Indices {
+		for _, col := range idx.Columns {
+			if newOffset, ok := offsetChanged[col.Offset]; ok {
+				col.Offset = newOffset
+			}
+		}
+	}
+}
+
 // adjustColumnInfoInDropColumn is used to set the correct position of column info when dropping column.
 // 1. The offset of column should to be set to the last of the columns.
 // 2. The dropped column is moved to the end of tblInfo.Columns, due to it was not public any more.
@@ -171,7 +227,7 @@ func checkAddColumn(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.Colu
 	return tblInfo, columnInfo, col, pos, offset, nil
 }
 
-func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+func (w *worker) onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
 	// Handle the rolling back job.
 	if job.IsRollingback() {
 		ver, err = onDropColumn(t, job)
@@ -234,12 +290,32 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 		if err != nil {
 			return ver, errors.Trace(err)
 		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
 		// Update the job state when all affairs done.
 		job.SchemaState = model.StateWriteReorganization
 	case model.StateWriteReorganization:
+		// The values of a stored generated column are backfilled before it becomes public. The reorganization
+		// of a multi-schema change is done before the job becomes non-revertible.
+		if columnInfo.IsGenerated() && columnInfo.GeneratedStored && (job.MultiSchemaInfo == nil || job.MultiSchemaInfo.Revertible) {
+			var done bool
+			done, ver, err = w.doReorgWorkForAddColumn(d, t, job, tblInfo, columnInfo)
+			if !done {
+				return ver, err
+			}
+		}
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			// The column can't be removed after it becomes public, wait for the other sub-jobs.
+			job.MarkNonRevertible()
+			return ver, nil
+		}
 		// reorganization -> public
 		// Adjust table column offset.
-		adjustColumnInfoInAddColumn(tblInfo, offset)
+		offset, err = locateOffsetToMove(columnInfo.Offset, pos, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		moveColumnInfo(tblInfo, columnInfo.Offset, offset)
 		columnInfo.State = model.StatePublic
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != columnInfo.State)
 		if err != nil {
@@ -256,6 +332,51 @@ func onAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error)
 	return ver, errors.Trace(err)
 }
 
+func (w *worker) doReorgWorkForAddColumn(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo,
+	colInfo *model.ColumnInfo) (done bool, ver int64, err error) {
+	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+	if err != nil {
+		return false, ver, errors.Trace(err)
+	}
+	reorgInfo, err := getReorgInfo(d, t, job, tbl, BuildElements(colInfo, nil))
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addColumnErr error) {
+		defer util.Recover(metrics.LabelDDL, "onAddColumn",
+			func() {
+				addColumnErr = errCancelledDDLJob.GenWithStack("add table `%v` column `%v` panic", tblInfo.Name, colInfo.Name)
+			}, false)
+		return w.updateColumn(tbl, colInfo, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) {
+			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+			w.reorgCtx.cleanNotifyReorgCancel()
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.BgLogger().Warn("[ddl] run add column job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
+				zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.BgLogger().Warn("[ddl] run add column job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+		ver, err = convertAddColumnJob2RollbackJob(t, job, tblInfo, colInfo, err)
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
+
 func checkAddColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.ColumnInfo, []*model.ColumnInfo, []*ast.ColumnPosition, []int, []bool, error) {
 	schemaID := job.SchemaID
 	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
@@ -545,6 +666,8 @@ func checkDropColumns(t *meta.Meta, job *model.Job) (*model.TableInfo, []*model.
 func checkDropColumnForStatePublic(tblInfo *model.TableInfo, colInfo *model.ColumnInfo) (err error) {
 	// Set this column's offset to the last and reset all following columns' offsets.
 	adjustColumnInfoInDropColumn(tblInfo, colInfo.Offset)
+	// The check constraints on the column can't be built once the column isn't public.
+	removeCheckConstraintsOnColumn(tblInfo, colInfo.Name)
 	// When the dropping column has not-null flag and it hasn't the default value, we can backfill the column value like "add column".
 	// NOTE: If the state of StateWriteOnly can be rollbacked, we'd better reconsider the original default value.
 	// And we need consider the column without not-null flag.
@@ -573,6 +696,10 @@ func onDropColumn(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	originalState := colInfo.State
 	switch colInfo.State {
 	case model.StatePublic:
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			job.MarkNonRevertible()
+			return ver, nil
+		}
 		// public -> write only
 		colInfo.State = model.StateWriteOnly
 		setIndicesState(idxInfos, model.StateWriteOnly)
@@ -681,11 +808,19 @@ func onSetDefaultValue(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 
 	return updateColumnDefaultValue(t, job, newCol, &newCol.Name)
 }
 
 func needChangeColumnData(oldCol, newCol *model.ColumnInfo) bool {
+	// The values of a virtual generated column are written into the rows when it becomes a stored one.
+	if isVirtualToStoredColumn(oldCol, newCol) {
+		return true
+	}
 	toUnsigned := mysql.HasUnsignedFlag(newCol.Flag)
 	originUnsigned := mysql.HasUnsignedFlag(oldCol.Flag)
 	needTruncationOrToggleSign := func() bool {
@@ -927,13 +1062,60 @@ func (w *worker) onModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job) (ver in
 		}
 		tblInfo.Indices = append(tblInfo.Indices, jobParam.changingIdxs...)
 	} else {
-		tblInfo.Columns[len(tblInfo.Columns)-1] = jobParam.changingCol
-		copy(tblInfo.Indices[len(tblInfo.Indices)-len(jobParam.changingIdxs):], jobParam.changingIdxs)
+		replaceChangingColumnAndIndexes(tblInfo, jobParam.changingCol, jobParam.changingIdxs)
 	}
 
 	return w.doModifyColumnTypeWithData(d, t, job, dbInfo, tblInfo, jobParam.changingCol, oldCol, jobParam.newCol.Name, jobParam.pos, jobParam.changingIdxs)
 }
 
+// replaceChangingColumnAndIndexes puts the changing column and indexes of the job arguments into the table info.
+// They are located by ID, since the other sub-jobs of a multi-schema change may move them.
+func replaceChangingColumnAndIndexes(tblInfo *model.TableInfo, changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) {
+	for i, col := range tblInfo.Columns {
+		if col.ID == changingCol.ID {
+			changingCol.Offset = i
+			tblInfo.Columns[i] = changingCol
+			break
+		}
+	}
+	for _, cIdx := range changingIdxs {
+		for i, idx := range tblInfo.Indices {
+			if idx.ID != cIdx.ID {
+				continue
+			}
+			for _, idxCol := range cIdx.Columns {
+				if col := model.FindColumnInfo(tblInfo.Columns, idxCol.Name.L); col != nil {
+					idxCol.Offset = col.Offset
+				}
+			}
+			tblInfo.Indices[i] = cIdx
+			break
+		}
+	}
+}
+
+// removeChangingColumnAndIndexes removes the changing column and indexes from the table info.
+func removeChangingColumnAndIndexes(tblInfo *model.TableInfo, changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) {
+	for i, col := range tblInfo.Columns {
+		if col.ID == changingCol.ID {
+			moveColumnInfo(tblInfo, i, len(tblInfo.Columns)-1)
+			tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
+			break
+		}
+	}
+	changingIdxIDs := make(map[int64]struct{}, len(changingIdxs))
+	for _, idx := range changingIdxs {
+		changingIdxIDs[idx.ID] = struct{}{}
+	}
+	indices := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
+	for _, idx := range tblInfo.Indices {
+		if _, ok := changingIdxIDs[idx.ID]; !ok {
+			indices = append(indices, idx)
+		}
+	}
+	tblInfo.Indices = indices
+}
+
 // rollbackModifyColumnJobWithData is used to rollback modify-column job which need to reorg the data.
 func rollbackModifyColumnJobWithData(t *meta.Meta, tblInfo *model.TableInfo, job *model.Job, oldCol *model.ColumnInfo, jobParam *modifyColumnJobParameter) (ver int64, err error) {
 	// If the not-null change is included, we should clean the flag info in oldCol.
@@ -946,8 +1128,7 @@ func rollbackModifyColumnJobWithData(t *meta.Meta, tblInfo *model.TableInfo, job
 	if jobParam.changingCol != nil {
 		// changingCol isn't nil means the job has been in the mid state. These appended changingCol and changingIndex should
 		// be removed from the tableInfo as well.
-		tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
-		tblInfo.Indices = tblInfo.Indices[:len(tblInfo.Indices)-len(jobParam.changingIdxs)]
+		removeChangingColumnAndIndexes(tblInfo, jobParam.changingCol, jobParam.changingIdxs)
 	}
 	ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
 	if err != nil {
@@ -1049,54 +1230,19 @@ func (w *worker) doModifyColumnTypeWithData(
 			return ver, errors.Trace(err)
 		}
 
-		reorgInfo, err := getReorgInfo(d, t, job, tbl, BuildElements(changingCol, changingIdxs))
-		if err != nil || reorgInfo.first {
-			// If we run reorg firstly, we should update the job snapshot version
-			// and then run the reorg next time.
-			return ver, errors.Trace(err)
+		var done bool
+		if job.MultiSchemaInfo != nil {
+			done, ver, err = w.doReorgWorkForModifyColumnMultiSchema(d, t, job, tbl, oldCol, changingCol, changingIdxs)
+		} else {
+			done, ver, err = w.doReorgWorkForModifyColumn(d, t, job, tbl, oldCol, changingCol, changingIdxs)
 		}
-
-		// Inject a failpoint so that we can pause here and do verification on other components.
-		// With a failpoint-enabled version of TiDB, you can trigger this failpoint by the following command:
-		// enable: curl -X PUT -d "pause" "http://127.0.0.1:10080/fail/github.com/pingcap/tidb/ddl/mockDelayInModifyColumnTypeWithData".
-		// disable: curl -X DELETE "http://127.0.0.1:10080/fail/github.com/pingcap/tidb/ddl/mockDelayInModifyColumnTypeWithData"
-		failpoint.Inject("mockDelayInModifyColumnTypeWithData", func() {})
-		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
-			defer util.Recover(metrics.LabelDDL, "onModifyColumn",
-				func() {
-					addIndexErr = errCancelledDDLJob.GenWithStack("modify table `%v` column `%v` panic", tblInfo.Name, oldCol.Name)
-				}, false)
-			// Use old column name to generate less confusing error messages.
-			changingColCpy := changingCol.Clone()
-			changingColCpy.Name = oldCol.Name
-			return w.updateColumnAndIndexes(tbl, oldCol, changingColCpy, changingIdxs, reorgInfo)
-		})
-		if err != nil {
-			if errWaitReorgTimeout.Equal(err) {
-				// If timeout, we should return, check for the owner and re-wait job done.
-				return ver, nil
-			}
-			if kv.IsTxnRetryableError(err) {
-				// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-				w.reorgCtx.cleanNotifyReorgCancel()
-				return ver, errors.Trace(err)
-			}
-			if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
-				logutil.BgLogger().Warn("[ddl] run modify column job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
-					zap.String("job", job.String()), zap.Error(err1))
-			}
-			logutil.BgLogger().Warn("[ddl] run modify column job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
-			job.State = model.JobStateRollingback
-			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-			w.reorgCtx.cleanNotifyReorgCancel()
-			return ver, errors.Trace(err)
+		if !done {
+			return ver, err
 		}
-		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-		w.reorgCtx.cleanNotifyReorgCancel()
 
 		// Remove the old column and indexes. Update the relative column name and index names.
 		oldIdxIDs := make([]int64, 0, len(changingIdxs))
-		tblInfo.Columns = tblInfo.Columns[:len(tblInfo.Columns)-1]
+		removeChangingColumnAndIndexes(tblInfo, changingCol, changingIdxs)
 		for _, cIdx := range changingIdxs {
 			idxName := getChangingIndexOriginName(cIdx)
 			for i, idx := range tblInfo.Indices {
@@ -1118,7 +1264,6 @@ func (w *worker) doModifyColumnTypeWithData(
 		if err = changingCol.SetOriginDefaultValue(nil); err != nil {
 			return ver, errors.Trace(err)
 		}
-		tblInfo.Indices = tblInfo.Indices[:len(tblInfo.Indices)-len(changingIdxs)]
 		// Adjust table column offset.
 		if err = adjustColumnInfoInModifyColumn(job, tblInfo, changingCol, oldCol, pos, changingColumnUniqueName.L); err != nil {
 			// TODO: Do rollback.
@@ -1142,6 +1287,69 @@ func (w *worker) doModifyColumnTypeWithData(
 	return ver, errors.Trace(err)
 }
 
+func (w *worker) doReorgWorkForModifyColumnMultiSchema(d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table,
+	oldCol, changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) (done bool, ver int64, err error) {
+	if job.MultiSchemaInfo.Revertible {
+		done, ver, err = w.doReorgWorkForModifyColumn(d, t, job, tbl, oldCol, changingCol, changingIdxs)
+		if done {
+			// The old column can't be removed after it becomes public, wait for the other sub-jobs.
+			job.MarkNonRevertible()
+		}
+		return false, ver, err
+	}
+	// The reorganization is done before the job becomes non-revertible.
+	return true, ver, nil
+}
+
+func (w *worker) doReorgWorkForModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job, tbl table.Table,
+	oldCol, changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) (done bool, ver int64, err error) {
+	reorgInfo, err := getReorgInfo(d, t, job, tbl, BuildElements(changingCol, changingIdxs))
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	// Inject a failpoint so that we can pause here and do verification on other components.
+	// With a failpoint-enabled version of TiDB, you can trigger this failpoint by the following command:
+	// enable: curl -X PUT -d "pause" "http://127.0.0.1:10080/fail/github.com/pingcap/tidb/ddl/mockDelayInModifyColumnTypeWithData".
+	// disable: curl -X DELETE "http://127.0.0.1:10080/fail/github.com/pingcap/tidb/ddl/mockDelayInModifyColumnTypeWithData"
+	failpoint.Inject("mockDelayInModifyColumnTypeWithData", func() {})
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
+		defer util.Recover(metrics.LabelDDL, "onModifyColumn",
+			func() {
+				addIndexErr = errCancelledDDLJob.GenWithStack("modify table `%v` column `%v` panic", tbl.Meta().Name, oldCol.Name)
+			}, false)
+		// Use old column name to generate less confusing error messages.
+		changingColCpy := changingCol.Clone()
+		changingColCpy.Name = oldCol.Name
+		return w.updateColumnAndIndexes(tbl, oldCol, changingColCpy, changingIdxs, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// If timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.IsTxnRetryableError(err) {
+			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+			w.reorgCtx.cleanNotifyReorgCancel()
+			return false, ver, errors.Trace(err)
+		}
+		if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+			logutil.BgLogger().Warn("[ddl] run modify column job failed, RemoveDDLReorgHandle failed, can't convert job to rollback",
+				zap.String("job", job.String()), zap.Error(err1))
+		}
+		logutil.BgLogger().Warn("[ddl] run modify column job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+		job.State = model.JobStateRollingback
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
+
 // BuildElements is exported for testing.
 func BuildElements(changingCol *model.ColumnInfo, changingIdxs []*model.IndexInfo) []*meta.Element {
 	elements := make([]*meta.Element, 0, len(changingIdxs)+1)
@@ -1157,6 +1365,30 @@ func (w *worker) updatePhysicalTableRow(t table.PhysicalTable, oldColInfo, colIn
 	return w.writePhysicalTableRecord(t, typeUpdateColumnWorker, nil, oldColInfo, colInfo, reorgInfo)
 }
 
+// updateColumn fills the column of the existing rows for a table. For a partitioned table, it's handled
+// partition by partition.
+func (w *worker) updateColumn(t table.Table, col *model.ColumnInfo, reorgInfo *reorgInfo) error {
+	tbl, ok := t.(table.PartitionedTable)
+	if !ok {
+		return errors.Trace(w.updatePhysicalTableRow(t.(table.PhysicalTable), nil, col, reorgInfo))
+	}
+	for finish := false; !finish; {
+		p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return errCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, t.Meta().ID)
+		}
+		if err := w.updatePhysicalTableRow(p, nil, col, reorgInfo); err != nil {
+			return errors.Trace(err)
+		}
+		var err error
+		finish, err = w.updateReorgInfo(tbl, reorgInfo)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
 // TestReorgGoroutineRunning is only used in test to indicate the reorg goroutine has been started.
 var TestReorgGoroutineRunning = make(chan interface{})
 
@@ -1340,36 +1572,44 @@ func (w *updateColumnWorker) getRowRecord(handle kv.Handle, recordKey []byte, ra
 	}
 
 	var recordWarning *terror.Error
-	// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
-	oldWarn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
-	if oldWarn == nil {
-		oldWarn = []stmtctx.SQLWarn{}
-	} else {
-		oldWarn = oldWarn[:0]
-	}
-	w.sessCtx.GetSessionVars().StmtCtx.SetWarnings(oldWarn)
-	newColVal, err := table.CastValue(w.sessCtx, w.rowMap[w.oldColInfo.ID], w.newColInfo, false, false)
-	if err != nil {
-		return w.reformatErrors(err)
-	}
-	if w.sessCtx.GetSessionVars().StmtCtx.GetWarnings() != nil && len(w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()) != 0 {
-		warn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
-		recordWarning = errors.Cause(w.reformatErrors(warn[0].Err)).(*terror.Error)
-	}
+	// The value of a stored generated column is evaluated with the generated columns below.
+	if !w.newColInfo.IsGenerated() {
+		// Since every updateColumnWorker handle their own work individually, we can cache warning in statement context when casting datum.
+		oldWarn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
+		if oldWarn == nil {
+			oldWarn = []stmtctx.SQLWarn{}
+		} else {
+			oldWarn = oldWarn[:0]
+		}
+		w.sessCtx.GetSessionVars().StmtCtx.SetWarnings(oldWarn)
+		newColVal, err := table.CastValue(w.sessCtx, w.rowMap[w.oldColInfo.ID], w.newColInfo, false, false)
+		if err != nil {
+			return w.reformatErrors(err)
+		}
+		if w.sessCtx.GetSessionVars().StmtCtx.GetWarnings() != nil && len(w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()) != 0 {
+			warn := w.sessCtx.GetSessionVars().StmtCtx.GetWarnings()
+			recordWarning = errors.Cause(w.reformatErrors(warn[0].Err)).(*terror.Error)
+		}
 
-	failpoint.Inject("MockReorgTimeoutInOneRegion", func(val failpoint.Value) {
-		if val.(bool) {
-			if handle.IntValue() == 3000 && atomic.CompareAndSwapInt32(&TestCheckReorgTimeout, 0, 1) {
-				failpoint.Return(errors.Trace(errWaitReorgTimeout))
+		failpoint.Inject("MockReorgTimeoutInOneRegion", func(val failpoint.Value) {
+			if val.(bool) {
+				if handle.IntValue() == 3000 && atomic.CompareAndSwapInt32(&TestCheckReorgTimeout, 0, 1) {
+					failpoint.Return(errors.Trace(errWaitReorgTimeout))
+				}
 			}
-		}
-	})
+		})
 
-	w.rowMap[w.newColInfo.ID] = newColVal
+		w.rowMap[w.newColInfo.ID] = newColVal
+	}
 	_, err = w.rowDecoder.EvalRemainedExprColumnMap(w.sessCtx, timeutil.SystemLocation(), w.rowMap)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if w.newColInfo.IsGenerated() && mysql.HasNotNullFlag(w.newColInfo.Flag) {
+		if val := w.rowMap[w.newColInfo.ID]; val.IsNull() {
+			return errors.Trace(ErrColumnBadNull.GenWithStackByArgs(w.newColInfo.Name))
+		}
+	}
 
 	newColumnIDs := make([]int64, 0, len(w.rowMap))
 	newRow := make([]types.Datum, 0, len(w.rowMap))
@@ -1496,6 +1736,11 @@ func (w *worker) doModifyColumn(
 		}
 	}
 
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
 	if err := adjustColumnInfoInModifyColumn(job, tblInfo, newCol, oldCol, pos, ""); err != nil {
 		return ver, errors.Trace(err)
 	}
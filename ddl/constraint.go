@@ -0,0 +1,425 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+func (w *worker) onAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	// Handle the rolling back job.
+	if job.IsRollingback() {
+		return removeAddingCheckConstraint(t, job)
+	}
+
+	schemaID := job.SchemaID
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfoInJob := &model.ConstraintInfo{}
+	err = job.DecodeArgs(constraintInfoInJob)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L)
+	if constraintInfo != nil && constraintInfo.State == model.StatePublic {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintDupName.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	if constraintInfo == nil {
+		constraintInfo = constraintInfoInJob.Clone()
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StateNone
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StateNone:
+		// none -> write only
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		job.SchemaState = model.StateWriteReorganization
+		constraintInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteReorganization:
+		// Every writer checks the new rows now, so only the existing rows need to be verified.
+		// The multi-schema change verifies them before the job becomes non-revertible.
+		if constraintInfo.Enforced && (job.MultiSchemaInfo == nil || job.MultiSchemaInfo.Revertible) {
+			err = w.verifyRemainRecordsForCheckConstraint(job.SchemaName, tblInfo, constraintInfo)
+			if err != nil {
+				if ErrCheckConstraintViolated.Equal(err) {
+					job.State = model.JobStateRollingback
+				}
+				return ver, errors.Trace(err)
+			}
+		}
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			job.MarkNonRevertible()
+			return ver, nil
+		}
+		// write reorganization -> public
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// removeAddingCheckConstraint removes the adding check constraint from the table when the job is rolled back.
+func removeAddingCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfoInJob := &model.ConstraintInfo{}
+	err = job.DecodeArgs(constraintInfoInJob)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintInfoInJob.Name.L)
+	if constraintInfo == nil || constraintInfo.State == model.StatePublic {
+		// The constraint isn't added by this job.
+		job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+		return ver, nil
+	}
+	removeCheckConstraint(tblInfo, constraintInfo.Name)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var constraintName model.CIStr
+	err = job.DecodeArgs(&constraintName)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintNotFound.GenWithStackByArgs(constraintName.O)
+	}
+
+	switch constraintInfo.State {
+	case model.StatePublic:
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			job.MarkNonRevertible()
+			return ver, nil
+		}
+		// The writers don't need the constraint any more, so it is removed in one step.
+		// public -> none
+		removeCheckConstraint(tblInfo, constraintName)
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+		return ver, nil
+	default:
+		return ver, ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+}
+
+func (w *worker) onAlterCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	var (
+		constraintName model.CIStr
+		enforced       bool
+	)
+	err = job.DecodeArgs(&constraintName, &enforced)
+	if err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	constraintInfo := tblInfo.FindConstraintInfoByName(constraintName.L)
+	if constraintInfo == nil {
+		job.State = model.JobStateCancelled
+		return ver, ErrCheckConstraintNotFound.GenWithStackByArgs(constraintName.O)
+	}
+
+	// The existing rows violate the constraint, so it keeps not enforced.
+	if job.IsRollingback() {
+		constraintInfo.Enforced = false
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateRollbackDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	// Only enforcing a not enforced constraint needs to verify the existing rows.
+	if !enforced || (constraintInfo.Enforced && constraintInfo.State == model.StatePublic) {
+		originalEnforced := constraintInfo.Enforced
+		constraintInfo.Enforced = enforced
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalEnforced != enforced)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		return ver, nil
+	}
+
+	originalState := constraintInfo.State
+	switch constraintInfo.State {
+	case model.StatePublic:
+		// public(not enforced) -> write only(enforced)
+		job.SchemaState = model.StateWriteOnly
+		constraintInfo.Enforced = true
+		constraintInfo.State = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteOnly:
+		// write only -> write reorganization
+		job.SchemaState = model.StateWriteReorganization
+		constraintInfo.State = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, originalState != constraintInfo.State)
+	case model.StateWriteReorganization:
+		err = w.verifyRemainRecordsForCheckConstraint(job.SchemaName, tblInfo, constraintInfo)
+		if err != nil {
+			if ErrCheckConstraintViolated.Equal(err) {
+				job.State = model.JobStateRollingback
+			}
+			return ver, errors.Trace(err)
+		}
+		// write reorganization -> public
+		constraintInfo.State = model.StatePublic
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != constraintInfo.State)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("constraint", constraintInfo.State)
+	}
+	return ver, errors.Trace(err)
+}
+
+// verifyRemainRecordsForCheckConstraint checks whether the existing rows of the table satisfy the check constraint.
+func (w *worker) verifyRemainRecordsForCheckConstraint(schemaName string, tblInfo *model.TableInfo, constraintInfo *model.ConstraintInfo) error {
+	var buf strings.Builder
+	// Since the expression string may contain the identifier, which couldn't be escaped in our ParseWithParams(...)
+	// So we write it to the origin sql string here.
+	buf.WriteString("select 1 from %n.%n where not (")
+	buf.WriteString(strings.ReplaceAll(constraintInfo.ExprString, "%", "%%"))
+	buf.WriteString(") limit 1")
+
+	ctx, err := w.sessPool.get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer w.sessPool.put(ctx)
+
+	stmt, err := ctx.(sqlexec.RestrictedSQLExecutor).ParseWithParams(w.ddlJobCtx, buf.String(), schemaName, tblInfo.Name.L)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedStmt(w.ddlJobCtx, stmt)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) != 0 {
+		return ErrCheckConstraintViolated.GenWithStackByArgs(constraintInfo.Name.O)
+	}
+	return nil
+}
+
+func allocateConstraintID(tblInfo *model.TableInfo) int64 {
+	tblInfo.MaxConstraintID++
+	return tblInfo.MaxConstraintID
+}
+
+func removeCheckConstraint(tblInfo *model.TableInfo, name model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constraintInfo := range tblInfo.Constraints {
+		if constraintInfo.Name.L != name.L {
+			constraints = append(constraints, constraintInfo)
+		}
+	}
+	tblInfo.Constraints = constraints
+}
+
+// removeCheckConstraintsOnColumn removes the check constraints which only refer to the dropping column.
+func removeCheckConstraintsOnColumn(tblInfo *model.TableInfo, colName model.CIStr) {
+	constraints := tblInfo.Constraints[:0]
+	for _, constraintInfo := range tblInfo.Constraints {
+		if len(constraintInfo.ConstraintCols) == 1 && constraintInfo.ConstraintCols[0].L == colName.L {
+			continue
+		}
+		constraints = append(constraints, constraintInfo)
+	}
+	tblInfo.Constraints = constraints
+}
+
+// getColumnCheckConstraint returns the check constraint which refers to the column. If multiColOnly is true,
+// only the constraint referring to other columns as well is returned, it prevents the column from being dropped.
+func getColumnCheckConstraint(tblInfo *model.TableInfo, colName model.CIStr, multiColOnly bool) *model.ConstraintInfo {
+	for _, constraintInfo := range tblInfo.Constraints {
+		if multiColOnly && len(constraintInfo.ConstraintCols) == 1 {
+			continue
+		}
+		for _, col := range constraintInfo.ConstraintCols {
+			if col.L == colName.L {
+				return constraintInfo
+			}
+		}
+	}
+	return nil
+}
+
+// setEmptyCheckConstraintName generates the names like `<table>_chk_<n>` for the check constraints without names.
+func setEmptyCheckConstraintName(tblInfo *model.TableInfo, constrs []*ast.Constraint) {
+	existNames := make(map[string]struct{}, len(tblInfo.Constraints)+len(constrs))
+	for _, constraintInfo := range tblInfo.Constraints {
+		existNames[constraintInfo.Name.L] = struct{}{}
+	}
+	for _, constr := range constrs {
+		if constr.Name != "" {
+			existNames[strings.ToLower(constr.Name)] = struct{}{}
+		}
+	}
+	num := 1
+	for _, constr := range constrs {
+		if constr.Name != "" {
+			continue
+		}
+		for {
+			name := fmt.Sprintf("%s_chk_%d", tblInfo.Name.O, num)
+			num++
+			if _, ok := existNames[strings.ToLower(name)]; !ok {
+				constr.Name = name
+				existNames[strings.ToLower(name)] = struct{}{}
+				break
+			}
+		}
+	}
+}
+
+// buildConstraintInfo validates the check constraint and builds its info for the table.
+func buildConstraintInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, constr *ast.Constraint) (*model.ConstraintInfo, error) {
+	if err := checkIllegalFn4Generated(constr.Name, typeCheckConstraint, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	colNames := findColumnNamesInExpr(constr.Expr)
+	dependedCols := make([]model.CIStr, 0, len(colNames))
+	dependedColsMap := make(map[string]struct{}, len(colNames))
+	for _, colName := range colNames {
+		if colName.Table.L != "" && colName.Table.L != tblInfo.Name.L {
+			return nil, ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constr.Name, colName.Name.O)
+		}
+		col := model.FindColumnInfo(tblInfo.Cols(), colName.Name.L)
+		if col == nil {
+			return nil, ErrCheckConstraintRefersUnknownColumn.GenWithStackByArgs(constr.Name, colName.Name.O)
+		}
+		if constr.InColumn && col.Name.L != strings.ToLower(constr.InColumnName) {
+			return nil, ErrColumnCheckConstraintReferencesOtherColumn.GenWithStackByArgs(constr.Name)
+		}
+		if mysql.HasAutoIncrementFlag(col.Flag) {
+			return nil, ErrCheckConstraintRefersAutoIncrementColumn.GenWithStackByArgs(constr.Name)
+		}
+		// The stored expression only refers to the columns of the table.
+		colName.Schema, colName.Table = model.CIStr{}, model.CIStr{}
+		if _, ok := dependedColsMap[col.Name.L]; !ok {
+			dependedColsMap[col.Name.L] = struct{}{}
+			dependedCols = append(dependedCols, col.Name)
+		}
+	}
+
+	// Make sure the expression can be built against the table.
+	if _, err := expression.RewriteSimpleExprWithTableInfo(ctx, tblInfo, constr.Expr); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var sb strings.Builder
+	restoreFlags := format.RestoreStringSingleQuotes | format.RestoreKeyWordLowercase | format.RestoreNameBackQuotes |
+		format.RestoreSpacesAroundBinaryOperation
+	restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
+	if err := constr.Expr.Restore(restoreCtx); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	return &model.ConstraintInfo{
+		Name:           model.NewCIStr(constr.Name),
+		Table:          tblInfo.Name,
+		ConstraintCols: dependedCols,
+		Enforced:       constr.Enforced,
+		InColumn:       constr.InColumn,
+		ExprString:     sb.String(),
+		State:          model.StateNone,
+	}, nil
+}
+
+// buildCheckConstraintsInfo builds the check constraints of the creating table.
+func buildCheckConstraintsInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, constrs []*ast.Constraint) error {
+	setEmptyCheckConstraintName(tblInfo, constrs)
+	for _, constr := range constrs {
+		if tblInfo.FindConstraintInfoByName(constr.Name) != nil {
+			return ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+		}
+		constraintInfo, err := buildConstraintInfo(ctx, tblInfo, constr)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		constraintInfo.ID = allocateConstraintID(tblInfo)
+		constraintInfo.State = model.StatePublic
+		tblInfo.Constraints = append(tblInfo.Constraints, constraintInfo)
+	}
+	return nil
+}
@@ -609,7 +609,7 @@ func (s *testIntegrationSuite5) TestErrnoErrorCode(c *C) {
 	sql = "alter table test_drop_columns drop column c1, drop column c2, drop column c3;"
 	tk.MustGetErrCode(sql, errno.ErrCantRemoveAllFields)
 	sql = "alter table test_drop_columns drop column c1, add column c2 int;"
-	tk.MustGetErrCode(sql, errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode(sql, errno.ErrDupFieldName)
 	sql = "alter table test_drop_columns drop column c1, drop column c1;"
 	tk.MustGetErrCode(sql, errno.ErrCantDropFieldOrKey)
 	// add index
@@ -1858,20 +1858,50 @@ func (s *testIntegrationSuite3) TestAlterTableAddUniqueOnPartionRangeColumn(c *C
 	tk.MustGetErrCode("alter table t add unique index idx_b(b)", errno.ErrUniqueKeyNeedAllFieldsInPf)
 }
 
-func (s *testIntegrationSuite5) TestFulltextIndexIgnore(c *C) {
+func (s *testIntegrationSuite5) TestFulltextIndex(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test")
-	tk.MustExec("drop table if exists t_ft")
-	defer tk.MustExec("drop table if exists t_ft")
-	// Make sure that creating and altering to add a fulltext key gives the correct warning
-	s.assertWarningExec(tk, c, "create table t_ft (a text, fulltext key (a))", ddl.ErrTableCantHandleFt)
-	s.assertWarningExec(tk, c, "alter table t_ft add fulltext key (a)", ddl.ErrTableCantHandleFt)
-
-	// Make sure table t_ft still has no indexes even after it was created and altered
-	r := tk.MustQuery("show index from t_ft")
-	c.Assert(r.Rows(), HasLen, 0)
-	r = tk.MustQuery("select * from information_schema.statistics where table_schema='test' and table_name='t_ft'")
-	c.Assert(r.Rows(), HasLen, 0)
+	tk.MustExec("drop table if exists t_ft, t_ft_part")
+	defer tk.MustExec("drop table if exists t_ft, t_ft_part")
+
+	tk.MustExec("create table t_ft (id int primary key, a varchar(100), b text, c int, d blob, fulltext key ft_a (a))")
+	tk.MustExec("insert into t_ft values (1, 'TiDB is a distributed database', 'MySQL compatible', 1, null)")
+	tk.MustExec("alter table t_ft add fulltext index ft_ab (a, b)")
+	tk.MustExec("create fulltext index ft_b on t_ft (b) with parser ngram")
+	tk.MustQuery("show create table t_ft").Check(testkit.Rows("t_ft CREATE TABLE `t_ft` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `a` varchar(100) DEFAULT NULL,\n" +
+		"  `b` text DEFAULT NULL,\n" +
+		"  `c` int(11) DEFAULT NULL,\n" +
+		"  `d` blob DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  FULLTEXT KEY `ft_a` (`a`),\n" +
+		"  FULLTEXT KEY `ft_ab` (`a`,`b`),\n" +
+		"  FULLTEXT KEY `ft_b` (`b`) /*!50100 WITH PARSER `ngram` */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select index_name, column_name, index_type from information_schema.statistics " +
+		"where table_schema = 'test' and table_name = 't_ft' and index_name like 'ft%' order by index_name, seq_in_index").Check(testkit.Rows(
+		"ft_a a FULLTEXT", "ft_ab a FULLTEXT", "ft_ab b FULLTEXT", "ft_b b FULLTEXT"))
+	tk.MustQuery("show index from t_ft where key_name = 'ft_a'").CheckAt([]int{2, 10}, testkit.Rows("ft_a FULLTEXT"))
+	tk.MustExec("admin check table t_ft")
+
+	// The FULLTEXT index can only be built on the whole values of the non-binary string columns.
+	tk.MustGetErrCode("alter table t_ft add fulltext index (c)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (d)", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (a(10))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("alter table t_ft add fulltext index ((lower(a)))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_ft add fulltext index (a) with parser mecab", errno.ErrFunctionNotDefined)
+	tk.MustGetErrCode("alter table t_ft modify column a int", errno.ErrBadFtColumn)
+	tk.MustGetErrCode("create table t_ft_part (a int, b text, fulltext key (b)) partition by hash(a) partitions 2", errno.ErrTableCantHandleFt)
+	tk.MustExec("create table t_ft_part (a int, b text) partition by hash(a) partitions 2")
+	tk.MustGetErrCode("alter table t_ft_part add fulltext index (b)", errno.ErrTableCantHandleFt)
+	tk.MustGetErrCode("alter table t_ft partition by hash(id) partitions 2", errno.ErrTableCantHandleFt)
+
+	tk.MustExec("alter table t_ft modify column a varchar(200)")
+	tk.MustExec("alter table t_ft drop index ft_ab")
+	tk.MustQuery("select index_name from information_schema.statistics where table_schema = 'test' and table_name = 't_ft' " +
+		"and index_name like 'ft%' order by index_name").Check(testkit.Rows("ft_a", "ft_b"))
+	tk.MustExec("admin check table t_ft")
 }
 
 func (s *testIntegrationSuite1) TestTreatOldVersionUTF8AsUTF8MB4(c *C) {
@@ -244,13 +244,18 @@ func (s *testIntegrationSuite3) TestCreateTableWithPartition(c *C) {
 
 	tk.MustExec("set @@tidb_enable_table_partition = 1")
 	tk.MustExec("set @@tidb_enable_table_partition = 1")
-	tk.MustExec(`create table t30 (
+	tk.MustGetErrCode(`create table t30 (
 		  a int,
 		  b float,
 		  c varchar(30))
 		  partition by range columns (a, b)
-		  (partition p0 values less than (10, 10.0))`)
-	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 8200 Unsupported partition type RANGE, treat as normal table"))
+		  (partition p0 values less than (10, 10.0))`, tmysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustExec(`create table t30 (
+		  a int,
+		  b int,
+		  c varchar(30))
+		  partition by range columns (a, b)
+		  (partition p0 values less than (10, 10))`)
 
 	tk.MustGetErrCode(`create table t31 (a int not null) partition by range( a );`, tmysql.ErrPartitionsMustBeDefined)
 	tk.MustGetErrCode(`create table t32 (a int not null) partition by range columns( a );`, tmysql.ErrPartitionsMustBeDefined)
@@ -356,7 +361,9 @@ func (s *testIntegrationSuite2) TestCreateTableWithHashPartition(c *C) {
                                        PARTITION p0 VALUES LESS THAN (100),
                                        PARTITION p1 VALUES LESS THAN (200),
                                        PARTITION p2 VALUES LESS THAN MAXVALUE)`)
-	tk.MustGetErrCode("select * from t_sub partition (p0)", tmysql.ErrPartitionClauseOnNonpartitioned)
+	tk.MustExec("insert into t_sub values (1, 'a'), (2, 'b'), (150, 'c')")
+	tk.MustQuery("select * from t_sub partition (p0) order by a").Check(testkit.Rows("1 a", "2 b"))
+	tk.MustQuery("select * from t_sub partition (p0sp1)").Check(testkit.Rows("1 a"))
 
 	// Fix create partition table using extract() function as partition key.
 	tk.MustExec("create table t2 (a date, b datetime) partition by hash (EXTRACT(YEAR_MONTH FROM a)) partitions 7")
@@ -1290,6 +1297,209 @@ func (s *testIntegrationSuite5) TestAlterTableTruncatePartitionByListColumns(c *
 	tk.MustQuery("select * from t").Check(testkit.Rows())
 }
 
+func (s *testIntegrationSuite5) TestAlterTableReorganizePartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec(`create table t (a int, b varchar(10), c int, key idx_b(b), unique key uk_a(a)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (30)
+	);`)
+	tk.MustExec(`insert into t values (1, "1", 1), (5, "5", 5), (11, "11", 11), (15, "15", 15), (21, "21", 21), (25, "25", 25)`)
+
+	// Split a partition.
+	tk.MustExec(`alter table t reorganize partition p1 into (
+		partition p1a values less than (15),
+		partition p1b values less than (20)
+	)`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p1a)").Check(testkit.Rows("11 11 11"))
+	tk.MustQuery("select * from t partition (p1b)").Check(testkit.Rows("15 15 15"))
+	tk.MustQuery("select a from t use index(idx_b) where b = '15'").Check(testkit.Rows("15"))
+	tbl := testGetTableByName(c, s.ctx, "test", "t")
+	part := tbl.Meta().Partition
+	c.Assert(part.Definitions, HasLen, 4)
+	c.Assert(part.AddingDefinitions, HasLen, 0)
+	c.Assert(part.DroppingDefinitions, HasLen, 0)
+	c.Assert(part.DDLAction, Equals, model.ActionNone)
+	c.Assert(part.Definitions[1].Name.L, Equals, "p1a")
+	c.Assert(part.Definitions[2].Name.L, Equals, "p1b")
+
+	// Merge partitions, the name of a reorganized partition can be reused.
+	tk.MustExec(`alter table t reorganize partition p0, p1a into (partition p0 values less than (15))`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t partition (p0)").Sort().Check(testkit.Rows("1", "11", "5"))
+	tk.MustGetErrCode("insert into t values (11, '11', 11)", tmysql.ErrDupEntry)
+
+	// Extend the last partition.
+	tk.MustExec(`alter table t reorganize partition p2 into (
+		partition p2 values less than (30),
+		partition p3 values less than (maxvalue)
+	)`)
+	tk.MustExec("insert into t values (100, '100', 100)")
+	tk.MustQuery("select a from t partition (p3)").Check(testkit.Rows("100"))
+	tk.MustExec("admin check table t")
+
+	tk.MustGetErrCode(`alter table t reorganize partition p0, p2 into (partition p0 values less than (30))`, tmysql.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode(`alter table t reorganize partition p0, p0 into (partition p0 values less than (15))`, tmysql.ErrConsecutiveReorgPartitions)
+	tk.MustGetErrCode(`alter table t reorganize partition p10 into (partition p10 values less than (15))`, tmysql.ErrDropPartitionNonExistent)
+	tk.MustGetErrCode(`alter table t reorganize partition p0 into (partition p0 values less than (16))`, tmysql.ErrReorgOutsideRange)
+	tk.MustGetErrCode(`alter table t reorganize partition p3 into (partition p3 values less than (200))`, tmysql.ErrReorgOutsideRange)
+	tk.MustGetErrCode(`alter table t reorganize partition p0 into (
+		partition p00 values less than (10),
+		partition p01 values less than (5))`, tmysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode(`alter table t reorganize partition p0 into (partition p1b values less than (15))`, tmysql.ErrSameNamePartition)
+	tk.MustGetErrCode(`alter table t reorganize partition`, tmysql.ErrReorgNoParam)
+	tk.MustQuery("select a from t").Sort().Check(testkit.Rows("1", "100", "11", "15", "21", "25", "5"))
+}
+
+func (s *testIntegrationSuite5) TestAlterTableReorganizePartitionByList(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec(`create table t (id int primary key, name varchar(10)) partition by list (id) (
+		partition p0 values in (1, 2, 3, 4),
+		partition p1 values in (5, 6)
+	);`)
+	tk.MustExec(`insert into t values (1, "a"), (2, "b"), (3, "c"), (5, "e")`)
+	tk.MustExec(`alter table t reorganize partition p0 into (
+		partition p0a values in (1, 2),
+		partition p0b values in (3, 4, 7)
+	)`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select id from t partition (p0a)").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t partition (p0b)").Check(testkit.Rows("3"))
+	tk.MustExec(`insert into t values (7, "g")`)
+	tk.MustQuery("select id from t partition (p0b)").Sort().Check(testkit.Rows("3", "7"))
+
+	// The records which are not in the new partition definitions make the job rolled back.
+	err := tk.ExecToErr(`alter table t reorganize partition p0a into (partition p0a values in (1))`)
+	c.Assert(table.ErrNoPartitionForGivenValue.Equal(err), IsTrue, Commentf("err %v", err))
+	tbl := testGetTableByName(c, s.ctx, "test", "t")
+	part := tbl.Meta().Partition
+	c.Assert(part.Definitions, HasLen, 3)
+	c.Assert(part.AddingDefinitions, HasLen, 0)
+	c.Assert(part.DroppingDefinitions, HasLen, 0)
+	c.Assert(part.DDLAction, Equals, model.ActionNone)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select id from t partition (p0a)").Sort().Check(testkit.Rows("1", "2"))
+
+	tk.MustExec(`alter table t reorganize partition p0a, p0b, p1 into (partition p0 values in (1, 2, 3, 4, 5, 6, 7))`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select * from t partition (p0)").Sort().Check(testkit.Rows("1 a", "2 b", "3 c", "5 e", "7 g"))
+}
+
+func (s *testIntegrationSuite5) TestAlterTablePartitionBy(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int primary key nonclustered, b varchar(10), c int auto_increment, key idx_b(b), key idx_c(c))")
+	tk.MustExec(`insert into t (a, b) values (1, "1"), (5, "5"), (11, "11"), (15, "15"), (21, "21")`)
+	oldTblID := testGetTableByName(c, s.ctx, "test", "t").Meta().ID
+
+	// Partition a non-partitioned table.
+	tk.MustExec(`alter table t partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20),
+		partition p2 values less than (maxvalue)
+	)`)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t partition (p0)").Sort().Check(testkit.Rows("1", "5"))
+	tk.MustQuery("select a from t partition (p1)").Sort().Check(testkit.Rows("11", "15"))
+	tk.MustQuery("select a, c from t partition (p2)").Check(testkit.Rows("21 5"))
+	tk.MustQuery("select a from t use index(idx_b) where b = '15'").Check(testkit.Rows("15"))
+	tbl := testGetTableByName(c, s.ctx, "test", "t")
+	part := tbl.Meta().Partition
+	c.Assert(tbl.Meta().ID, Equals, oldTblID)
+	c.Assert(part.Type, Equals, model.PartitionTypeRange)
+	c.Assert(part.Definitions, HasLen, 3)
+	c.Assert(part.AddingDefinitions, HasLen, 0)
+	c.Assert(part.DroppingDefinitions, HasLen, 0)
+	c.Assert(part.DDLAction, Equals, model.ActionNone)
+	c.Assert(part.DDLExpr, Equals, "")
+
+	// Change the partitioning of a partitioned table.
+	tk.MustExec("alter table t partition by hash (a) partitions 2")
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select a from t partition (p0)").Sort().Check(testkit.Rows())
+	tk.MustQuery("select a from t partition (p1)").Sort().Check(testkit.Rows("1", "11", "15", "21", "5"))
+	part = testGetTableByName(c, s.ctx, "test", "t").Meta().Partition
+	c.Assert(part.Type, Equals, model.PartitionTypeHash)
+	c.Assert(part.Num, Equals, uint64(2))
+	tk.MustExec(`insert into t (a, b) values (2, "2")`)
+	tk.MustQuery("select a from t partition (p0)").Check(testkit.Rows("2"))
+
+	// The records which are not in the new partitions make the job rolled back.
+	err := tk.ExecToErr("alter table t partition by range (a) (partition p0 values less than (20))")
+	c.Assert(table.ErrNoPartitionForGivenValue.Equal(err), IsTrue, Commentf("err %v", err))
+	part = testGetTableByName(c, s.ctx, "test", "t").Meta().Partition
+	c.Assert(part.Type, Equals, model.PartitionTypeHash)
+	c.Assert(part.Definitions, HasLen, 2)
+	c.Assert(part.AddingDefinitions, HasLen, 0)
+	c.Assert(part.DDLAction, Equals, model.ActionNone)
+	tk.MustGetErrCode("alter table t partition by range (b) (partition p0 values less than (20))", tmysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode("alter table t partition by hash (b) partitions 2", tmysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode("alter table t partition by hash (c) partitions 2", tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+
+	// Remove the partitioning, the table gets a new table ID.
+	tk.MustExec("alter table t remove partitioning")
+	tk.MustExec("admin check table t")
+	tbl = testGetTableByName(c, s.ctx, "test", "t")
+	c.Assert(tbl.Meta().Partition, IsNil)
+	c.Assert(tbl.Meta().ID, Not(Equals), oldTblID)
+	tk.MustQuery("select a, b from t").Sort().Check(testkit.Rows("1 1", "11 11", "15 15", "2 2", "21 21", "5 5"))
+	tk.MustQuery("select a from t use index(idx_b) where b = '11'").Check(testkit.Rows("11"))
+	// The auto IDs are kept.
+	tk.MustExec(`insert into t (a, b) values (3, "3")`)
+	tk.MustQuery("select c > 5 from t where a = 3").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(distinct c) from t").Check(testkit.Rows("7"))
+}
+
+func (s *testIntegrationSuite5) TestAlterTableRebuildPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec("create table t (a int, b int, key idx_b(b)) partition by hash (a) partitions 4")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4), (5, 5)")
+	oldDefs := testGetTableByName(c, s.ctx, "test", "t").Meta().Partition.Definitions
+
+	// The partitions between the given ones are rebuilt too.
+	tk.MustExec("alter table t rebuild partition p2, p0")
+	tk.MustExec("admin check table t")
+	defs := testGetTableByName(c, s.ctx, "test", "t").Meta().Partition.Definitions
+	c.Assert(defs, HasLen, 4)
+	for i := range defs {
+		c.Assert(defs[i].Name.L, Equals, oldDefs[i].Name.L)
+		c.Assert(defs[i].ID == oldDefs[i].ID, Equals, i == 3)
+	}
+	tk.MustQuery("select a from t partition (p1)").Sort().Check(testkit.Rows("1", "5"))
+
+	tk.MustExec("alter table t optimize partition all")
+	tk.MustExec("admin check table t")
+	defs = testGetTableByName(c, s.ctx, "test", "t").Meta().Partition.Definitions
+	c.Assert(defs[3].ID, Not(Equals), oldDefs[3].ID)
+	tk.MustQuery("select a from t use index(idx_b) where b > 2").Sort().Check(testkit.Rows("3", "4", "5"))
+}
+
+func (s *testIntegrationSuite5) TestAlterTableCheckPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+		partition p0 values less than (10),
+		partition p1 values less than (20)
+	)`)
+	tk.MustExec("insert into t values (1, 1), (11, 11)")
+	tk.MustExec("alter table t check partition p0")
+	tk.MustExec("alter table t check partition all")
+
+	tk.MustGetErrCode("alter table t check partition p2", tmysql.ErrUnknownPartition)
+	tk.MustExec("alter table t remove partitioning")
+	tk.MustGetErrCode("alter table t check partition p0", tmysql.ErrPartitionMgmtOnNonpartitioned)
+}
+
 func (s *testIntegrationSuite3) TestCreateTableWithKeyPartition(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test;")
@@ -1301,7 +1511,175 @@ func (s *testIntegrationSuite3) TestCreateTableWithKeyPartition(c *C) {
 	partition by key(s1) partitions 10;`)
 
 	tk.MustExec(`drop table if exists tm2`)
-	tk.MustExec(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`)
+	// The prefix of a unique key can't be used as the partitioning key.
+	tk.MustGetErrCode(`create table tm2 (a char(5), unique key(a(5))) partition by key() partitions 5;`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustExec(`create table tm2 (a char(5), unique key(a)) partition by key() partitions 5;`)
+	tbl := testGetTableByName(c, tk.Se, "test", "tm2")
+	c.Assert(tbl.Meta().Partition.Type, Equals, model.PartitionTypeKey)
+	c.Assert(tbl.Meta().Partition.Columns, DeepEquals, []model.CIStr{model.NewCIStr("a")})
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b varchar(10), primary key (a, b)) partition by key() partitions 4")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) NOT NULL,\n" +
+		"  `b` varchar(10) NOT NULL,\n" +
+		"  PRIMARY KEY (`a`,`b`) /*T![clustered_index] NONCLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY KEY(`a`,`b`)\n" +
+		"PARTITIONS 4"))
+	tk.MustQuery("select partition_method, partition_expression from information_schema.partitions where table_name = 't' and partition_name = 'p0'").Check(
+		testkit.Rows("KEY a,b"))
+
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (a int, b varchar(10)) partition by key(a) partitions 3")
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd'), (null, 'e')")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("5"))
+	// Every row is in the partition located by the key hash.
+	for _, name := range []string{"p0", "p1", "p2"} {
+		rows := tk.MustQuery(fmt.Sprintf("select a from t partition (%s)", name)).Rows()
+		for _, row := range rows {
+			cond := fmt.Sprintf("a = %v", row[0])
+			if row[0] == "<nil>" {
+				cond = "a is null"
+			}
+			tk.MustQuery(fmt.Sprintf("select count(*) from t partition (%s) where %s", name, cond)).Check(testkit.Rows("1"))
+		}
+	}
+	tk.MustExec("update t set a = 10 where a = 1")
+	tk.MustQuery("select b from t where a = 10").Check(testkit.Rows("a"))
+	tk.MustExec("delete from t where a = 2")
+	tk.MustQuery("select b from t order by b").Check(testkit.Rows("a", "c", "d", "e"))
+	tk.MustExec("alter table t truncate partition p0, p1, p2")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	tk.MustExec("drop table if exists t")
+	tk.MustGetErrCode("create table t (a json) partition by key(a) partitions 3", tmysql.ErrFieldTypeNotAllowedAsPartitionField)
+	tk.MustGetErrCode("create table t (a int) partition by key() partitions 3", tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode("create table t (a int) partition by key(b) partitions 3", tmysql.ErrFieldNotFoundPart)
+	tk.MustGetErrCode("create table t (a int, b int, primary key (a)) partition by key(b) partitions 3", tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+
+	// The linear key partition is not supported yet.
+	tk.MustExec("create table t (a int) partition by linear key(a) partitions 3")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
+	tk.MustGetErrCode("select * from t partition (p0)", tmysql.ErrPartitionClauseOnNonpartitioned)
+}
+
+func (s *testIntegrationSuite3) TestCreateTableWithMultiColumnsRangeColumnsPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b int, c varchar(10)) partition by range columns (a, b) (
+		partition p0 values less than (10, 10),
+		partition p1 values less than (10, maxvalue),
+		partition p2 values less than (20, 5),
+		partition p3 values less than (maxvalue, maxvalue))`)
+	tk.MustExec("insert into t values (1, 100, 'a'), (10, 9, 'b'), (10, 10, 'c'), (20, 4, 'd'), (20, 5, 'e'), (null, 1, 'f'), (10, null, 'g')")
+	tk.MustQuery("select c from t partition (p0) order by c").Check(testkit.Rows("a", "b", "f", "g"))
+	tk.MustQuery("select c from t partition (p1)").Check(testkit.Rows("c"))
+	tk.MustQuery("select c from t partition (p2)").Check(testkit.Rows("d"))
+	tk.MustQuery("select c from t partition (p3)").Check(testkit.Rows("e"))
+
+	// The partitions are pruned by the first column.
+	tk.MustQuery("select c from t where a = 10 order by c").Check(testkit.Rows("b", "c", "g"))
+	tk.UsedPartitions("select * from t where a = 10").Check(testkit.Rows("p0 p1 p2"))
+	tk.MustQuery("select c from t where a >= 20 order by c").Check(testkit.Rows("d", "e"))
+	tk.UsedPartitions("select * from t where a > 20").Check(testkit.Rows("p3"))
+	tk.MustQuery("select c from t where a < 10 order by c").Check(testkit.Rows("a"))
+	tk.MustQuery("select c from t where a <= 10 order by c").Check(testkit.Rows("a", "b", "c", "g"))
+	tk.MustQuery("select c from t where a is null").Check(testkit.Rows("f"))
+
+	tk.MustQuery("select partition_method, partition_expression, partition_description from information_schema.partitions where table_name = 't' and partition_name = 'p1'").Check(
+		testkit.Rows("RANGE COLUMNS a,b 10,MAXVALUE"))
+
+	tk.MustExec("drop table if exists t")
+	tk.MustGetErrCode(`create table t (a int, b int) partition by range columns (a, b) (
+		partition p0 values less than (10, 10),
+		partition p1 values less than (10, 5))`, tmysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode(`create table t (a int, b int) partition by range columns (a, b) (
+		partition p0 values less than (10, 10),
+		partition p1 values less than (9, 20))`, tmysql.ErrRangeNotIncreasing)
+	tk.MustGetErrCode(`create table t (a int, b int) partition by range columns (a, b) (
+		partition p0 values less than (10, maxvalue),
+		partition p1 values less than (10, maxvalue))`, tmysql.ErrRangeNotIncreasing)
+}
+
+func (s *testIntegrationSuite3) TestCreateTableWithSubPartition(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test;")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec(`create table t (a int, b int) partition by range (a) subpartition by hash (b) subpartitions 2 (
+		partition p0 values less than (10),
+		partition p1 values less than (20))`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin\n" +
+		"PARTITION BY RANGE ( `a` )\n" +
+		"SUBPARTITION BY HASH( `b` )\n" +
+		"SUBPARTITIONS 2 (\n" +
+		"  PARTITION `p0` VALUES LESS THAN (10)\n" +
+		"   (SUBPARTITION `p0sp0`,\n" +
+		"    SUBPARTITION `p0sp1`),\n" +
+		"  PARTITION `p1` VALUES LESS THAN (20)\n" +
+		"   (SUBPARTITION `p1sp0`,\n" +
+		"    SUBPARTITION `p1sp1`)\n" +
+		")"))
+	tbl := testGetTableByName(c, tk.Se, "test", "t")
+	c.Assert(tbl.Meta().Partition.Definitions, HasLen, 4)
+
+	tk.MustExec("insert into t values (1, 1), (2, 2), (11, 1), (12, 2), (null, 3)")
+	tk.MustQuery("select * from t partition (p0) order by b").Check(testkit.Rows("1 1", "2 2", "<nil> 3"))
+	tk.MustQuery("select * from t partition (p0sp0) order by b").Check(testkit.Rows("2 2"))
+	tk.MustQuery("select * from t partition (p0sp1) order by b").Check(testkit.Rows("1 1", "<nil> 3"))
+	tk.MustQuery("select * from t partition (p1sp1, p0sp0) order by a").Check(testkit.Rows("2 2", "11 1"))
+	tk.MustGetErrCode("insert into t partition (p0sp0) values (1, 1)", tmysql.ErrRowDoesNotMatchGivenPartitionSet)
+	tk.MustExec("insert into t partition (p1) values (13, 3)")
+	tk.MustGetErrCode("insert into t values (20, 1)", tmysql.ErrNoPartitionForGivenValue)
+
+	// Both the logical partitions and the subpartitions are pruned.
+	tk.MustQuery("select * from t where a = 11 and b = 1").Check(testkit.Rows("11 1"))
+	tk.UsedPartitions("select * from t where a = 11 and b = 1").Check(testkit.Rows("p1sp1"))
+	tk.MustQuery("select * from t where b = 2 order by a").Check(testkit.Rows("2 2", "12 2"))
+	tk.UsedPartitions("select * from t where b = 2").Check(testkit.Rows("p0sp0 p1sp0"))
+	tk.MustQuery("select * from t where a < 10 order by b").Check(testkit.Rows("1 1", "2 2"))
+
+	tk.MustQuery("select partition_name, subpartition_name, partition_ordinal_position, subpartition_ordinal_position, subpartition_method, subpartition_expression " +
+		"from information_schema.partitions where table_name = 't' order by partition_ordinal_position, subpartition_ordinal_position").Check(testkit.Rows(
+		"p0 p0sp0 1 1 HASH `b`",
+		"p0 p0sp1 1 2 HASH `b`",
+		"p1 p1sp0 2 1 HASH `b`",
+		"p1 p1sp1 2 2 HASH `b`"))
+
+	tk.MustExec("alter table t truncate partition p0")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("11 1", "12 2", "13 3"))
+	tk.MustExec("alter table t truncate partition p1sp0")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("11 1", "13 3"))
+
+	tk.MustGetErrCode("alter table t add partition (partition p2 values less than (30))", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t drop partition p0", tmysql.ErrUnsupportedDDLOperation)
+
+	// List partitions and key subpartitions with the given names.
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("set @@session.tidb_enable_list_partition = ON")
+	tk.MustExec(`create table t (a int, b varchar(10)) partition by list (a) subpartition by key (b) (
+		partition p0 values in (1, 2) (subpartition s0, subpartition s1, subpartition s2),
+		partition p1 values in (3, 4) (subpartition s3, subpartition s4, subpartition s5))`)
+	tk.MustExec("insert into t values (1, 'a'), (2, 'b'), (3, 'c'), (4, 'd')")
+	tk.MustQuery("select * from t partition (p1) order by a").Check(testkit.Rows("3 c", "4 d"))
+	tk.MustQuery("select * from t where a = 3 and b = 'c'").Check(testkit.Rows("3 c"))
+	tk.MustQuery("select count(*) from t partition (s0, s1, s2, s3, s4, s5)").Check(testkit.Rows("4"))
+	// The result of show create table can be executed again.
+	createSQL := tk.MustQuery("show create table t").Rows()[0][1].(string)
+	tk.MustExec("drop table t")
+	tk.MustExec(createSQL)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t " + createSQL))
+
+	tk.MustGetErrCode(`create table t1 (a int, b int) partition by hash (a) partitions 2 subpartition by hash (b) subpartitions 2`, tmysql.ErrSubpartition)
+	tk.MustGetErrCode(`create table t1 (a int, b int, primary key (a)) partition by range (a) subpartition by hash (b) subpartitions 2 (
+		partition p0 values less than (10))`, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
+	tk.MustGetErrCode(`create table t1 (a int, b int) partition by range (a) subpartition by hash (b) (
+		partition p0 values less than (10) (subpartition p0),
+		partition p1 values less than (20) (subpartition s1))`, tmysql.ErrSameNamePartition)
 }
 
 func (s *testIntegrationSuite5) TestAlterTableAddPartition(c *C) {
@@ -2571,7 +2949,6 @@ func (s *testIntegrationSuite5) TestPartitionUniqueKeyNeedAllFieldsInPf(c *C) {
         )`
 	tk.MustGetErrCode(sql10, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
 
-	// after we support multiple columns partition, this sql should fail. For now, it will be a normal table.
 	sql11 := `create table part9 (
                  a int not null,
                  b int not null,
@@ -2586,7 +2963,7 @@ func (s *testIntegrationSuite5) TestPartitionUniqueKeyNeedAllFieldsInPf(c *C) {
                partition p1 values less than (7, 9),
                partition p2 values less than (11, 22)
         )`
-	tk.MustExec(sql11)
+	tk.MustGetErrCode(sql11, tmysql.ErrUniqueKeyNeedAllFieldsInPf)
 
 	sql12 := `create table part12 (a varchar(20), b binary, unique index (a(5))) partition by range columns (a) (
 			partition p0 values less than ('aaaaa'),
@@ -3051,13 +3428,14 @@ func (s *testIntegrationSuite3) TestPartitionErrorCode(c *C) {
 	_, err = tk.Exec("alter table t_part coalesce partition 4;")
 	c.Assert(ddl.ErrCoalesceOnlyOnHashPartition.Equal(err), IsTrue)
 
-	tk.MustGetErrCode(`alter table t_part reorganize partition p0, p1 into (
+	tk.MustGetErrCode(`alter table t_part reorganize partition p1 into (
+			partition p1 values less than (15));`, tmysql.ErrReorgOutsideRange)
+	tk.MustGetErrCode(`alter table clients reorganize partition p0 into (
 			partition p0 values less than (1980));`, tmysql.ErrUnsupportedDDLOperation)
 
-	tk.MustGetErrCode("alter table t_part check partition p0, p1;", tmysql.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part optimize partition p0,p1;", tmysql.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part rebuild partition p0,p1;", tmysql.ErrUnsupportedDDLOperation)
-	tk.MustGetErrCode("alter table t_part remove partitioning;", tmysql.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t_part check partition p0, p5;", tmysql.ErrUnknownPartition)
+	tk.MustGetErrCode("alter table t_part optimize partition p0,p5;", tmysql.ErrUnknownPartition)
+	tk.MustGetErrCode("alter table t_part rebuild partition p0,p5;", tmysql.ErrUnknownPartition)
 	tk.MustGetErrCode("alter table t_part repair partition p1;", tmysql.ErrUnsupportedDDLOperation)
 
 	// Reduce the impact on DML when executing partition DDL
@@ -3208,8 +3586,13 @@ func (s *testIntegrationSuite3) TestUnsupportedPartitionManagementDDLs(c *C) {
 		);
 	`)
 
-	_, err := tk.Exec("alter table test_1465 partition by hash(a)")
-	c.Assert(err, ErrorMatches, ".*alter table partition is unsupported")
+	tk.MustGetErrCode(`alter table test_1465 partition by range(a) subpartition by hash(a) subpartitions 2 (
+		partition p1 values less than (10))`, tmysql.ErrUnsupportedDDLOperation)
+	tk.MustExec("create table test_1465_1 (a int)")
+	tk.MustGetErrCode("alter table test_1465_1 remove partitioning", tmysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table test_1465_1 rebuild partition p1", tmysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustGetErrCode("alter table test_1465_1 check partition p1", tmysql.ErrPartitionMgmtOnNonpartitioned)
+	tk.MustExec("drop table test_1465_1")
 }
 
 func (s *testSerialDBSuite1) TestCommitWhenSchemaChange(c *C) {
@@ -3482,6 +3865,118 @@ func (s *testSerialDBSuite1) TestTruncatePartitionMultipleTimes(c *C) {
 	c.Assert(errCount, LessEqual, int32(1))
 }
 
+func (s *testSerialDBSuite1) TestReorganizePartitionWithDML(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+		partition p0 values less than (100),
+		partition p1 values less than (200))`)
+	for i := 0; i < 100; i++ {
+		tk.MustExec("insert into t values (?, ?)", i, i)
+	}
+
+	tk1 := testkit.NewTestKitWithInit(c, s.store)
+	dom := domain.GetDomain(tk.Se)
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{}
+	var checkErr error
+	states := make(map[model.SchemaState]struct{})
+	hook.OnJobUpdatedExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || checkErr != nil {
+			return
+		}
+		if _, ok := states[job.SchemaState]; ok {
+			return
+		}
+		states[job.SchemaState] = struct{}{}
+		// Insert, update and delete the records on both sides of the split point in every state.
+		n := 100 + len(states)*10
+		for _, sql := range []string{
+			fmt.Sprintf("insert into t values (%d, %d), (%d, %d)", n-100, n, n+1-100+50, n+1),
+			fmt.Sprintf("update t set b = b + 1 where a in (%d, %d)", len(states), len(states)+50),
+			fmt.Sprintf("update t set a = a + 50 where a = %d", len(states)+10),
+			fmt.Sprintf("delete from t where a = %d", len(states)+20),
+		} {
+			if _, checkErr = tk1.Exec(sql); checkErr != nil {
+				return
+			}
+		}
+	}
+	dom.DDL().SetHook(hook)
+	tk.MustExec(`alter table t reorganize partition p0 into (
+		partition p0a values less than (50),
+		partition p0b values less than (100))`)
+	c.Assert(checkErr, IsNil)
+	c.Assert(len(states), Greater, 3)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select count(*) from t partition (p0a) where a >= 50").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t partition (p0b) where a < 50").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t partition (p0a, p0b, p1)").Check(tk.MustQuery("select count(*) from t").Rows())
+}
+
+func (s *testSerialDBSuite1) TestCancelReorganizePartition(c *C) {
+	tk := testkit.NewTestKitWithInit(c, s.store)
+	tk.MustExec("drop table if exists t;")
+	tk.MustExec(`create table t (a int, b int, key idx_b(b)) partition by range (a) (
+		partition p0 values less than (100),
+		partition p1 values less than (200))`)
+	for i := 0; i < 100; i++ {
+		tk.MustExec("insert into t values (?, ?)", i, i)
+	}
+
+	dom := domain.GetDomain(tk.Se)
+	originHook := dom.DDL().GetHook()
+	defer dom.DDL().SetHook(originHook)
+	hook := &ddl.TestDDLCallback{}
+	var (
+		checkErr  error
+		cancelled bool
+	)
+	hook.OnJobUpdatedExported = func(job *model.Job) {
+		if job.Type != model.ActionReorganizePartition || job.SchemaState != model.StateWriteReorganization || cancelled {
+			return
+		}
+		cancelled = true
+		hookCtx := mock.NewContext()
+		hookCtx.Store = s.store
+		checkErr = hookCtx.NewTxn(context.Background())
+		if checkErr != nil {
+			return
+		}
+		txn, err := hookCtx.Txn(true)
+		if err != nil {
+			checkErr = errors.Trace(err)
+			return
+		}
+		errs, err := admin.CancelJobs(txn, []int64{job.ID})
+		if err != nil {
+			checkErr = errors.Trace(err)
+			return
+		}
+		if errs[0] != nil {
+			checkErr = errors.Trace(errs[0])
+			return
+		}
+		checkErr = txn.Commit(context.Background())
+	}
+	dom.DDL().SetHook(hook)
+	err := tk.ExecToErr(`alter table t reorganize partition p0 into (
+		partition p0a values less than (50),
+		partition p0b values less than (100))`)
+	c.Assert(checkErr, IsNil)
+	c.Assert(err, NotNil)
+	c.Assert(err.Error(), Equals, "[ddl:8214]Cancelled DDL job")
+	tbl := testGetTableByName(c, s.s, "test", "t")
+	part := tbl.Meta().Partition
+	c.Assert(part.Definitions, HasLen, 2)
+	c.Assert(part.AddingDefinitions, HasLen, 0)
+	c.Assert(part.DroppingDefinitions, HasLen, 0)
+	c.Assert(part.DDLAction, Equals, model.ActionNone)
+	tk.MustExec("admin check table t")
+	tk.MustQuery("select count(*) from t partition (p0)").Check(testkit.Rows("100"))
+}
+
 func (s *testSerialDBSuite1) TestAddPartitionReplicaBiggerThanTiFlashStores(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("create database if not exists test_partition2")
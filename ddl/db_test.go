@@ -2032,7 +2032,8 @@ func (s *testDBSuite5) TestAlterPrimaryKey(c *C) {
 	defer tk.MustExec("drop table test_add_pk")
 
 	// for generated columns
-	tk.MustGetErrCode("alter table test_add_pk add primary key(d);", errno.ErrUnsupportedOnGeneratedColumn)
+	tk.MustExec("alter table test_add_pk add primary key(d);")
+	tk.MustExec("drop index `primary` on test_add_pk")
 	// The primary key name is the same as the existing index name.
 	tk.MustExec("alter table test_add_pk add primary key idx(e)")
 	tk.MustExec("drop index `primary` on test_add_pk")
@@ -3901,7 +3902,7 @@ func (s *testDBSuite3) TestGeneratedColumnDDL(c *C) {
 
 		// Modify/change stored status of generated columns.
 		{`alter table test_gv_ddl modify column b bigint`, errno.ErrUnsupportedOnGeneratedColumn},
-		{`alter table test_gv_ddl change column c cnew bigint as (a+100)`, errno.ErrUnsupportedOnGeneratedColumn},
+		{`alter table test_gv_ddl modify column a int as (8)`, errno.ErrUnsupportedOnGeneratedColumn},
 
 		// Modify/change generated columns breaking prior.
 		{`alter table test_gv_ddl modify column b int as (c+100)`, errno.ErrGeneratedColumnNonPrior},
@@ -3913,14 +3914,13 @@ func (s *testDBSuite3) TestGeneratedColumnDDL(c *C) {
 		// Refer generated columns non prior.
 		{`create table test_gv_ddl_bad (a int, b int as (c+1), c int as (a+1))`, errno.ErrGeneratedColumnNonPrior},
 
-		// Virtual generated columns cannot be primary key.
-		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b) primary key)`, errno.ErrUnsupportedOnGeneratedColumn},
-		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b), primary key(c))`, errno.ErrUnsupportedOnGeneratedColumn},
-		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b), primary key(a, c))`, errno.ErrUnsupportedOnGeneratedColumn},
+		// Virtual generated columns cannot be clustered primary key.
+		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b) primary key clustered)`, errno.ErrUnsupportedOnGeneratedColumn},
+		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b), primary key(c) clustered)`, errno.ErrUnsupportedOnGeneratedColumn},
+		{`create table test_gv_ddl_bad (a int, b int, c int as (a+b), primary key(a, c) clustered)`, errno.ErrUnsupportedOnGeneratedColumn},
 
-		// Add stored generated column through alter table.
-		{`alter table test_gv_ddl add column d int as (b+2) stored`, errno.ErrUnsupportedOnGeneratedColumn},
-		{`alter table test_gv_ddl modify column b int as (a + 8) stored`, errno.ErrUnsupportedOnGeneratedColumn},
+		// Add stored generated columns with other columns through alter table.
+		{`alter table test_gv_ddl add column (d int, e int as (b+2) stored)`, errno.ErrUnsupportedOnGeneratedColumn},
 
 		// Add generated column with incorrect parameter count.
 		{`alter table test_gv_ddl add column z int as (lower(a, 2))`, errno.ErrWrongParamcountToNativeFct},
@@ -5022,6 +5022,123 @@ func (s *testDBSuite5) TestModifyGeneratedColumn(c *C) {
 	tk.MustQuery("select * from t1").Check(testkit.Rows("1 2"))
 }
 
+func (s *testDBSuite5) TestAddStoredGeneratedColumn(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (a int, b int)")
+	tk.MustExec("insert into t1 values (1, 10), (2, 20), (3, null)")
+
+	// The existing rows are backfilled.
+	tk.MustExec("alter table t1 add column c int as (a + b) stored")
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 10 11", "2 20 22", "3 <nil> <nil>"))
+	tk.MustExec("alter table t1 add index idx_c(c)")
+	tk.MustQuery("select a from t1 use index(idx_c) where c = 22").Check(testkit.Rows("2"))
+	tk.MustExec("admin check table t1")
+
+	// The adding column is rolled back if a value violates the NOT NULL constraint.
+	tk.MustGetErrCode("alter table t1 add column d int as (b * 2) stored not null", errno.ErrBadNull)
+	tk.MustQuery("select column_name from information_schema.columns where table_schema = 'test' and table_name = 't1' order by ordinal_position").Check(testkit.Rows(
+		"a", "b", "c"))
+	tk.MustExec("admin check table t1")
+
+	// Adding a stored generated column with other columns isn't supported.
+	tk.MustGetErrCode("alter table t1 add column (e int, f int as (a + 1) stored)", errno.ErrUnsupportedOnGeneratedColumn)
+
+	tk.MustExec("create table t2 (a int, b int) partition by range (a) (partition p0 values less than (10), partition p1 values less than maxvalue)")
+	tk.MustExec("insert into t2 values (1, 1), (11, 11)")
+	tk.MustExec("alter table t2 add column c int as (a * b) stored")
+	tk.MustQuery("select * from t2 order by a").Check(testkit.Rows("1 1 1", "11 11 121"))
+	tk.MustQuery("select * from t2 partition (p1)").Check(testkit.Rows("11 11 121"))
+	tk.MustExec("admin check table t2")
+}
+
+func (s *testDBSuite5) TestDMLOnAddingStoredGeneratedColumn(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1 (a int primary key, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2)")
+
+	var checkErr error
+	tk1 := testkit.NewTestKit(c, s.store)
+	tk1.MustExec("use test")
+	hook := &ddl.TestDDLCallback{Do: s.dom}
+	hook.OnJobRunBeforeExported = func(job *model.Job) {
+		if checkErr != nil || job.Type != model.ActionAddColumn {
+			return
+		}
+		switch job.SchemaState {
+		case model.StateDeleteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (3, 3)")
+		case model.StateWriteOnly:
+			_, checkErr = tk1.Exec("insert into t1 values (4, 4)")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("update t1 set b = 10 where a = 1")
+			}
+		case model.StateWriteReorganization:
+			_, checkErr = tk1.Exec("insert ignore into t1 values (5, 5)")
+			if checkErr == nil {
+				_, checkErr = tk1.Exec("update t1 set b = 20 where a = 2")
+			}
+		}
+	}
+	originalHook := s.dom.DDL().GetHook()
+	defer s.dom.DDL().(ddl.DDLForTest).SetHook(originalHook)
+	s.dom.DDL().(ddl.DDLForTest).SetHook(hook)
+
+	tk.MustExec("alter table t1 add column c int as (b + 100) stored")
+	c.Assert(checkErr, IsNil)
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 10 110", "2 20 120", "3 3 103", "4 4 104", "5 5 105"))
+	tk.MustExec("admin check table t1")
+}
+
+func (s *testDBSuite5) TestToggleGeneratedColumnStoredStatus(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1")
+	tk.MustExec("create table t1 (a int, b int as (a + 1) virtual, index idx_b(b))")
+	tk.MustExec("insert into t1 (a) values (1), (2)")
+
+	// Virtual to stored materializes the values.
+	tk.MustExec("alter table t1 modify column b int as (a + 1) stored")
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 2", "2 3"))
+	tk.MustQuery("select a from t1 use index(idx_b) where b = 3").Check(testkit.Rows("2"))
+	tk.MustQuery("select generation_expression, extra from information_schema.columns where table_schema = 'test' and table_name = 't1' and column_name = 'b'").Check(testkit.Rows(
+		"`a` + 1 STORED GENERATED"))
+	tk.MustExec("insert into t1 (a) values (3)")
+	tk.MustExec("admin check table t1")
+
+	// Stored to virtual only changes the metadata.
+	tk.MustExec("alter table t1 modify column b int as (a + 1) virtual")
+	tk.MustQuery("select * from t1 order by a").Check(testkit.Rows("1 2", "2 3", "3 4"))
+	tk.MustQuery("select generation_expression, extra from information_schema.columns where table_schema = 'test' and table_name = 't1' and column_name = 'b'").Check(testkit.Rows(
+		"`a` + 1 VIRTUAL GENERATED"))
+	tk.MustExec("admin check table t1")
+
+	// The expression can't be changed together with the stored status.
+	tk.MustGetErrCode("alter table t1 modify column b int as (a + 2) stored", errno.ErrUnsupportedOnGeneratedColumn)
+}
+
+func (s *testDBSuite5) TestVirtualGeneratedColumnAsPrimaryKey(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+
+	tk.MustExec("create table t1 (a int, b int as (a + 1) virtual primary key)")
+	tk.MustExec("insert into t1 (a) values (1), (2)")
+	tk.MustGetErrCode("insert into t1 (a) values (1)", errno.ErrDupEntry)
+	tk.MustQuery("select * from t1 where b = 3").Check(testkit.Rows("2 3"))
+	tk.MustQuery("select tidb_pk_type from information_schema.tables where table_schema = 'test' and table_name = 't1'").Check(testkit.Rows("NONCLUSTERED"))
+	tk.MustExec("admin check table t1")
+
+	tk.MustGetErrCode("create table t2 (a int, b int as (a + 1) virtual, primary key (b) clustered)", errno.ErrUnsupportedOnGeneratedColumn)
+
+	// A primary key column of a clustered table can't become virtual.
+	tk.MustExec("create table t3 (a int, b int as (a + 1) stored, primary key (b) clustered)")
+	tk.MustGetErrCode("alter table t3 modify column b int as (a + 1) virtual", errno.ErrUnsupportedOnGeneratedColumn)
+}
+
 func (s *testDBSuite5) TestDefaultSQLFunction(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("create database if not exists test;")
@@ -6467,30 +6584,56 @@ func (s *testDBSuite4) TestColumnCheck(c *C) {
 	tk.MustExec("drop table if exists column_check")
 	tk.MustExec("create table column_check (pk int primary key, a int check (a > 1))")
 	defer tk.MustExec("drop table if exists column_check")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
+	tk.MustQuery("show create table column_check").Check(testutil.RowsWithSep("|", ""+
+		"column_check CREATE TABLE `column_check` (\n"+
+		"  `pk` int(11) NOT NULL,\n"+
+		"  `a` int(11) DEFAULT NULL,\n"+
+		"  PRIMARY KEY (`pk`) /*T![clustered_index] CLUSTERED */,\n"+
+		"  CONSTRAINT `column_check_chk_1` CHECK ((`a` > 1))\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustExec("drop table if exists column_check_other")
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, a int check (pk > 1))", errno.ErrColumnCheckConstraintReferencesOtherColumn)
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, a int check (b > 1))", errno.ErrCheckConstraintRefersUnknownColumn)
+	tk.MustGetErrCode("create table column_check_other (pk int auto_increment primary key, check (pk > 1))", errno.ErrCheckConstraintRefersAutoIncrementColumn)
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, check (pk > rand()))", errno.ErrCheckConstraintFunctionIsNotAllowed)
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, check (pk > @a))", errno.ErrCheckConstraintVariables)
+	tk.MustGetErrCode("create table column_check_other (pk int primary key, constraint c1 check (pk > 1), constraint c1 check (pk < 10))", errno.ErrCheckConstraintDupName)
 }
 
 func (s *testDBSuite5) TestAlterCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists alter_check")
-	tk.MustExec("create table alter_check (pk int primary key)")
+	tk.MustExec("create table alter_check (pk int primary key, constraint crcn check (pk > 0))")
 	defer tk.MustExec("drop table if exists alter_check")
-	tk.MustExec("alter table alter_check alter check crcn ENFORCED")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ALTER CHECK is not supported"))
+	tk.MustExec("alter table alter_check alter check crcn not enforced")
+	tk.MustExec("insert into alter_check values (-1)")
+	tk.MustQuery("show create table alter_check").Check(testutil.RowsWithSep("|", ""+
+		"alter_check CREATE TABLE `alter_check` (\n"+
+		"  `pk` int(11) NOT NULL,\n"+
+		"  PRIMARY KEY (`pk`) /*T![clustered_index] CLUSTERED */,\n"+
+		"  CONSTRAINT `crcn` CHECK ((`pk` > 0)) /*!80016 NOT ENFORCED */\n"+
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	// The existing row violates the constraint.
+	tk.MustGetErrCode("alter table alter_check alter check crcn enforced", errno.ErrCheckConstraintViolated)
+	tk.MustExec("delete from alter_check")
+	tk.MustExec("alter table alter_check alter check crcn enforced")
+	tk.MustGetErrCode("insert into alter_check values (-1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("alter table alter_check alter check crcn_not_exist enforced", errno.ErrCheckConstraintNotFound)
 }
 
 func (s *testDBSuite6) TestDropCheck(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
 	tk.MustExec("drop table if exists drop_check")
-	tk.MustExec("create table drop_check (pk int primary key)")
+	tk.MustExec("create table drop_check (pk int primary key, constraint crcn check (pk > 0))")
 	defer tk.MustExec("drop table if exists drop_check")
+	tk.MustGetErrCode("insert into drop_check values (-1)", errno.ErrCheckConstraintViolated)
 	tk.MustExec("alter table drop_check drop check crcn")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|DROP CHECK is not supported"))
+	tk.MustExec("insert into drop_check values (-1)")
+	tk.MustGetErrCode("alter table drop_check drop check crcn", errno.ErrCheckConstraintNotFound)
 }
 
 func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
@@ -6499,22 +6642,43 @@ func (s *testDBSuite7) TestAddConstraintCheck(c *C) {
 	tk.MustExec("drop table if exists add_constraint_check")
 	tk.MustExec("create table add_constraint_check (pk int primary key, a int)")
 	defer tk.MustExec("drop table if exists add_constraint_check")
+	tk.MustExec("insert into add_constraint_check values (1, 1)")
+	// The existing row violates the constraint, so the job is rolled back.
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn check (a > 1)", errno.ErrCheckConstraintViolated)
+	tk.MustQuery("select count(*) from information_schema.check_constraints where constraint_name = 'crn'").Check(testkit.Rows("0"))
+	tk.MustExec("update add_constraint_check set a = 2")
 	tk.MustExec("alter table add_constraint_check add constraint crn check (a > 1)")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|ADD CONSTRAINT CHECK is not supported"))
-}
-
-func (s *testDBSuite7) TestCreateTableIngoreCheckConstraint(c *C) {
+	tk.MustGetErrCode("alter table add_constraint_check add constraint crn check (a > 0)", errno.ErrCheckConstraintDupName)
+	tk.MustGetErrCode("update add_constraint_check set a = 1", errno.ErrCheckConstraintViolated)
+	// A not enforced constraint doesn't verify the existing rows.
+	tk.MustExec("alter table add_constraint_check add constraint crn2 check (a > 2) not enforced")
+	tk.MustExec("alter table add_constraint_check add check (a < 10)")
+	tk.MustQuery("select constraint_name, check_clause from information_schema.check_constraints where constraint_schema = '" + s.schemaName + "' and (constraint_name like 'crn%' or constraint_name like 'add_constraint_check%') order by constraint_name").Check(testkit.Rows(
+		"add_constraint_check_chk_1 (`a` < 10)", "crn (`a` > 1)", "crn2 (`a` > 2)"))
+	tk.MustExec("alter table add_constraint_check add constraint multi_cols check (a < pk + 10)")
+	tk.MustGetErrCode("alter table add_constraint_check drop column a", errno.ErrDependentByCheckConstraint)
+	tk.MustGetErrCode("alter table add_constraint_check rename column a to b", errno.ErrDependentByCheckConstraint)
+}
+
+func (s *testDBSuite7) TestCreateTableWithCheckConstraint(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use " + s.schemaName)
-	tk.MustExec("drop table if exists table_constraint_check")
-	tk.MustExec("CREATE TABLE admin_user (enable bool, CHECK (enable IN (0, 1)));")
-	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(1))
-	tk.MustQuery("show warnings").Check(testutil.RowsWithSep("|", "Warning|8231|CONSTRAINT CHECK is not supported"))
+	tk.MustExec("drop table if exists admin_user")
+	tk.MustExec("CREATE TABLE admin_user (enable bool, b int, CHECK (enable IN (0, 1)), CONSTRAINT b_positive CHECK (b > 0) NOT ENFORCED);")
+	defer tk.MustExec("drop table if exists admin_user")
+	c.Assert(tk.Se.GetSessionVars().StmtCtx.WarningCount(), Equals, uint16(0))
 	tk.MustQuery("show create table admin_user").Check(testutil.RowsWithSep("|", ""+
 		"admin_user CREATE TABLE `admin_user` (\n"+
-		"  `enable` tinyint(1) DEFAULT NULL\n"+
+		"  `enable` tinyint(1) DEFAULT NULL,\n"+
+		"  `b` int(11) DEFAULT NULL,\n"+
+		"  CONSTRAINT `admin_user_chk_1` CHECK ((`enable` in (0,1))),\n"+
+		"  CONSTRAINT `b_positive` CHECK ((`b` > 0)) /*!80016 NOT ENFORCED */\n"+
 		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select constraint_name, constraint_type from information_schema.table_constraints where table_name = 'admin_user' order by constraint_name").Check(testkit.Rows(
+		"admin_user_chk_1 CHECK", "b_positive CHECK"))
+	// The single column constraint is dropped along with the column.
+	tk.MustExec("alter table admin_user drop column enable")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_name like 'admin_user%'").Check(testkit.Rows())
 }
 
 func (s *testDBSuite6) TestAlterOrderBy(c *C) {
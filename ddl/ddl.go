@@ -119,6 +119,10 @@ type DDL interface {
 	CreatePlacementPolicy(ctx sessionctx.Context, stmt *ast.CreatePlacementPolicyStmt) error
 	DropPlacementPolicy(ctx sessionctx.Context, stmt *ast.DropPlacementPolicyStmt) error
 	AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacementPolicyStmt) error
+	CreateRoutine(ctx sessionctx.Context, stmt *ast.CreateRoutineStmt) error
+	DropRoutine(ctx sessionctx.Context, stmt *ast.DropRoutineStmt) error
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
 
 	// CreateSchemaWithInfo creates a database (schema) given its database info.
 	//
@@ -539,6 +543,10 @@ func updateTickerInterval(ticker *time.Ticker, lease time.Duration, job *model.J
 // - context.Cancel: job has been sent to worker, but not found in history DDL job before cancel
 // - other: found in history DDL job and return that job error
 func (d *ddl) doDDLJob(ctx sessionctx.Context, job *model.Job) error {
+	if mci := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo; mci != nil {
+		// The job is merged into the multi-schema change job instead of running.
+		return appendToSubJobs(mci, job)
+	}
 	// Get a global job ID and put the DDL job in the queue.
 	job.Query, _ = ctx.Value(sessionctx.QueryString).(string)
 	task := &limitJobTask{job, make(chan error)}
@@ -597,9 +597,13 @@ func buildColumnAndConstraint(
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
 // In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// GEOMETRY can't have not null default values in any SQL mode.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value interface{}) (bool, interface{}, error) {
 	hasDefaultValue := true
+	if value != nil && col.Tp == mysql.TypeGeometry {
+		return hasDefaultValue, value, errBlobCantHaveDefault.GenWithStackByArgs(col.Name.O)
+	}
 	if value != nil && (col.Tp == mysql.TypeJSON ||
 		col.Tp == mysql.TypeTinyBlob || col.Tp == mysql.TypeMediumBlob ||
 		col.Tp == mysql.TypeLongBlob || col.Tp == mysql.TypeBlob) {
@@ -830,7 +834,9 @@ func columnDefToCol(ctx sessionctx.Context, offset int, colDef *ast.ColumnDef, o
 			case ast.ColumnOptionFulltext:
 				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt.GenWithStackByArgs())
 			case ast.ColumnOptionCheck:
-				ctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+				constraint := &ast.Constraint{Tp: ast.ConstraintCheck, Name: v.ConstraintName, Expr: v.Expr,
+					Enforced: v.Enforced, InColumn: true, InColumnName: colDef.Name.Name.O}
+				constraints = append(constraints, constraint)
 			}
 		}
 	}
@@ -1357,6 +1363,7 @@ func setEmptyConstraintName(namesMap map[string]bool, constr *ast.Constraint, fo
 func checkConstraintNames(constraints []*ast.Constraint) error {
 	constrNames := map[string]bool{}
 	fkNames := map[string]bool{}
+	checkNames := map[string]bool{}
 
 	// Check not empty constraint name whether is duplicated.
 	for _, constr := range constraints {
@@ -1365,6 +1372,16 @@ func checkConstraintNames(constraints []*ast.Constraint) error {
 			if err != nil {
 				return errors.Trace(err)
 			}
+		} else if constr.Tp == ast.ConstraintCheck {
+			// Check constraints don't share the names with indexes.
+			if constr.Name == "" {
+				continue
+			}
+			nameLower := strings.ToLower(constr.Name)
+			if checkNames[nameLower] {
+				return ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+			}
+			checkNames[nameLower] = true
 		} else {
 			err := checkDuplicateConstraint(constrNames, constr.Name, false)
 			if err != nil {
@@ -1526,6 +1543,7 @@ func buildTableInfo(
 		tbInfo.Columns = append(tbInfo.Columns, v.ToInfo())
 		tblColumns = append(tblColumns, table.ToColumn(v.ToInfo()))
 	}
+	var checkConstraints []*ast.Constraint
 	for _, constr := range constraints {
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfo(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
@@ -1564,8 +1582,13 @@ func buildTableInfo(
 			if err != nil {
 				return nil, err
 			}
+			// The primary key on virtual generated columns is always nonclustered.
+			hasVirtualCol := hasVirtualGeneratedColumn(tbInfo, constr.Keys)
+			if hasVirtualCol && constr.Option != nil && constr.Option.PrimaryKeyTp == model.PrimaryKeyTypeClustered {
+				return nil, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Defining a virtual generated column as clustered primary key")
+			}
 			isSingleIntPK := isSingleIntPK(constr, lastCol)
-			if ShouldBuildClusteredIndex(ctx, constr.Option, isSingleIntPK) {
+			if !hasVirtualCol && ShouldBuildClusteredIndex(ctx, constr.Option, isSingleIntPK) {
 				if isSingleIntPK {
 					tbInfo.PKIsHandle = true
 				} else {
@@ -1585,11 +1608,50 @@ func buildTableInfo(
 		}
 
 		if constr.Tp == ast.ConstraintFulltext {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt.GenWithStackByArgs())
+			if err := checkFulltextIndex(tbInfo, constr.Option); err != nil {
+				return nil, errors.Trace(err)
+			}
+			var parserName string
+			if constr.Option != nil {
+				parserName = constr.Option.ParserName.L
+			}
+			idxInfo, err := buildFulltextIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, parserName, model.StatePublic)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if constr.Option != nil {
+				idxInfo.Comment, err = validateCommentLength(ctx.GetSessionVars(), idxInfo.Name.String(), constr.Option)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				idxInfo.Invisible = constr.Option.Visibility == ast.IndexVisibilityInvisible
+			}
+			idxInfo.ID = allocateIndexID(tbInfo)
+			tbInfo.Indices = append(tbInfo.Indices, idxInfo)
+			continue
+		}
+		if constr.Tp == ast.ConstraintSpatial {
+			if err := checkSpatialIndex(tbInfo); err != nil {
+				return nil, errors.Trace(err)
+			}
+			idxInfo, err := buildSpatialIndexInfo(tbInfo, model.NewCIStr(constr.Name), constr.Keys, model.StatePublic)
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			if constr.Option != nil {
+				idxInfo.Comment, err = validateCommentLength(ctx.GetSessionVars(), idxInfo.Name.String(), constr.Option)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+				idxInfo.Invisible = constr.Option.Visibility == ast.IndexVisibilityInvisible
+			}
+			idxInfo.ID = allocateIndexID(tbInfo)
+			tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 			continue
 		}
 		if constr.Tp == ast.ConstraintCheck {
-			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("CONSTRAINT CHECK"))
+			// The check constraints are built after all the columns are added.
+			checkConstraints = append(checkConstraints, constr)
 			continue
 		}
 		// build index info.
@@ -1609,6 +1671,9 @@ func buildTableInfo(
 		case ast.ConstraintUniq, ast.ConstraintUniqKey, ast.ConstraintUniqIndex:
 			idxInfo.Unique = true
 		}
+		if idxInfo.Unique && idxInfo.MVIndex {
+			return nil, errUnsupportedIndexType.GenWithStack("unique multi-valued index is not supported")
+		}
 		// set index type.
 		if constr.Option != nil {
 			idxInfo.Comment, err = validateCommentLength(ctx.GetSessionVars(), idxInfo.Name.String(), constr.Option)
@@ -1631,6 +1696,9 @@ func buildTableInfo(
 		idxInfo.ID = allocateIndexID(tbInfo)
 		tbInfo.Indices = append(tbInfo.Indices, idxInfo)
 	}
+	if err = buildCheckConstraintsInfo(ctx, tbInfo, checkConstraints); err != nil {
+		return nil, errors.Trace(err)
+	}
 	if tbInfo.IsCommonHandle {
 		// Ensure tblInfo's each non-unique secondary-index's len + primary-key's len <= MaxIndexLength for clustered index table.
 		var pkLen, idxLen int
@@ -1721,6 +1789,16 @@ func checkTableInfoValidExtra(tbInfo *model.TableInfo) error {
 	if err := checkTooManyIndexes(tbInfo.Indices); err != nil {
 		return errors.Trace(err)
 	}
+	if hasFulltextIndex(tbInfo) {
+		if err := checkFulltextIndex(tbInfo, nil); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if hasSpatialIndex(tbInfo) {
+		if err := checkSpatialIndex(tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
 	if err := checkColumnsAttributes(tbInfo.Columns); err != nil {
 		return errors.Trace(err)
 	}
@@ -1751,7 +1829,12 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			if err := checkPartitionFuncType(ctx, s.Partition.Expr, tbInfo); err != nil {
 				return errors.Trace(err)
 			}
-			if err := checkPartitioningKeysConstraints(ctx, s, tbInfo); err != nil {
+			if s.Partition.Sub != nil {
+				if err := checkPartitionFuncType(ctx, s.Partition.Sub.Expr, tbInfo); err != nil {
+					return errors.Trace(err)
+				}
+			}
+			if err := checkPartitioningKeysConstraints(tbInfo); err != nil {
 				return errors.Trace(err)
 			}
 		}
@@ -1771,11 +1854,18 @@ func checkPartitionDefinitionConstraints(ctx sessionctx.Context, tbInfo *model.T
 	if err = checkAddPartitionOnTemporaryMode(tbInfo); err != nil {
 		return err
 	}
+	if tbInfo.Partition.Sub != nil {
+		// The partition values of a subpartitioned table are checked on its logical partitions,
+		// the subpartitions share the same values with their partitions.
+		parentTbInfo := *tbInfo
+		parentTbInfo.Partition = tbInfo.Partition.ParentView()
+		tbInfo = &parentTbInfo
+	}
 
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		err = checkPartitionByRange(ctx, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		err = checkPartitionByHash(ctx, tbInfo)
 	case model.PartitionTypeList:
 		err = checkPartitionByList(ctx, tbInfo)
@@ -1814,6 +1904,7 @@ func buildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
+	tblInfo.Triggers = nil
 	// Ignore TiFlash replicas for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
@@ -2327,6 +2418,10 @@ func checkTwoRangeColumns(ctx sessionctx.Context, curr, prev *model.PartitionDef
 	}
 	for i := 0; i < len(pi.Columns); i++ {
 		// Special handling for MAXVALUE.
+		if strings.EqualFold(curr.LessThan[i], partitionMaxValue) && strings.EqualFold(prev.LessThan[i], partitionMaxValue) {
+			// Both are maxvalue, compare the next column.
+			continue
+		}
 		if strings.EqualFold(curr.LessThan[i], partitionMaxValue) {
 			// If current is maxvalue, it certainly >= previous.
 			return true, nil
@@ -2354,6 +2449,12 @@ func checkTwoRangeColumns(ctx sessionctx.Context, curr, prev *model.PartitionDef
 		if succ {
 			return true, nil
 		}
+		// Current is not greater than previous. It's invalid if current is less than previous,
+		// otherwise they are the same value in different literals, compare the next column.
+		less, err := parseAndEvalBoolExpr(ctx, prev.LessThan[i], curr.LessThan[i], colInfo, tbInfo)
+		if err != nil || less {
+			return false, err
+		}
 	}
 	return false, nil
 }
@@ -2642,14 +2743,40 @@ func checkMultiSpecs(sctx sessionctx.Context, specs []*ast.AlterTableSpec) error
 		if len(specs) == 1 && len(specs[0].NewColumns) > 1 && specs[0].Tp == ast.AlterTableAddColumns {
 			return errRunMultiSchemaChanges
 		}
-	} else {
-		if len(specs) > 1 && !isSameTypeMultiSpecs(specs) {
-			return errRunMultiSchemaChanges
+	} else if len(specs) > 1 {
+		for _, spec := range specs {
+			if !isMultiSchemaChangeSpec(spec) {
+				return errRunMultiSchemaChanges
+			}
 		}
 	}
 	return nil
 }
 
+// isMultiSchemaChangeSpec checks whether the spec can be a sub-job of the multi-schema change.
+func isMultiSchemaChangeSpec(spec *ast.AlterTableSpec) bool {
+	switch spec.Tp {
+	case ast.AlterTableAddColumns, ast.AlterTableDropColumn, ast.AlterTableDropIndex, ast.AlterTableDropPrimaryKey,
+		ast.AlterTableRenameIndex, ast.AlterTableModifyColumn, ast.AlterTableChangeColumn, ast.AlterTableRenameColumn,
+		ast.AlterTableAlterColumn, ast.AlterTableIndexInvisible, ast.AlterTableDropCheck:
+		return true
+	case ast.AlterTableAddConstraint:
+		switch spec.Constraint.Tp {
+		case ast.ConstraintKey, ast.ConstraintIndex, ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey,
+			ast.ConstraintPrimaryKey, ast.ConstraintCheck:
+			return true
+		}
+	case ast.AlterTableOption:
+		for _, opt := range spec.Options {
+			if opt.Tp != ast.TableOptionComment {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
 func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast.Ident, specs []*ast.AlterTableSpec) (err error) {
 	validSpecs, err := resolveAlterTableSpec(sctx, specs)
 	if err != nil {
@@ -2667,45 +2794,54 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 	}
 
 	if len(validSpecs) > 1 {
-		switch validSpecs[0].Tp {
-		case ast.AlterTableAddColumns:
-			err = d.AddColumns(sctx, ident, validSpecs)
-		case ast.AlterTableDropColumn:
-			err = d.DropColumns(sctx, ident, validSpecs)
-		case ast.AlterTableDropPrimaryKey, ast.AlterTableDropIndex:
-			err = d.DropIndexes(sctx, ident, validSpecs)
-		default:
-			return errRunMultiSchemaChanges
-		}
-		if err != nil {
-			return errors.Trace(err)
+		if isSameTypeMultiSpecs(validSpecs) {
+			switch validSpecs[0].Tp {
+			case ast.AlterTableAddColumns:
+				return errors.Trace(d.AddColumns(sctx, ident, validSpecs))
+			case ast.AlterTableDropColumn:
+				return errors.Trace(d.DropColumns(sctx, ident, validSpecs))
+			case ast.AlterTableDropPrimaryKey, ast.AlterTableDropIndex:
+				return errors.Trace(d.DropIndexes(sctx, ident, validSpecs))
+			}
 		}
-		return nil
+		// The jobs of the specs are collected as the sub-jobs of one multi-schema change job.
+		sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = model.NewMultiSchemaInfo()
+		defer func() {
+			sctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+		}()
 	}
 
 	for _, spec := range validSpecs {
 		var handledCharsetOrCollate bool
 		switch spec.Tp {
 		case ast.AlterTableAddColumns:
-			if len(spec.NewColumns) != 1 {
-				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
-			} else {
+			if len(spec.NewColumns) == 1 {
 				err = d.AddColumn(sctx, ident, spec)
+			} else if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+				// Each column is added by its own sub-job.
+				for _, newCol := range spec.NewColumns {
+					colSpec := *spec
+					colSpec.NewColumns = []*ast.ColumnDef{newCol}
+					if err = d.AddColumn(sctx, ident, &colSpec); err != nil {
+						break
+					}
+				}
+			} else {
+				err = d.AddColumns(sctx, ident, []*ast.AlterTableSpec{spec})
 			}
 		case ast.AlterTableAddPartitions:
 			err = d.AddTablePartitions(sctx, ident, spec)
 		case ast.AlterTableCoalescePartitions:
 			err = d.CoalescePartitions(sctx, ident, spec)
 		case ast.AlterTableReorganizePartition:
-			err = errors.Trace(errUnsupportedReorganizePartition)
+			err = d.ReorganizePartitions(sctx, ident, spec)
 		case ast.AlterTableCheckPartitions:
+			// CHECK PARTITION is planned as ADMIN CHECK TABLE, it can't be combined with other specs.
 			err = errors.Trace(errUnsupportedCheckPartition)
-		case ast.AlterTableRebuildPartition:
-			err = errors.Trace(errUnsupportedRebuildPartition)
-		case ast.AlterTableOptimizePartition:
-			err = errors.Trace(errUnsupportedOptimizePartition)
+		case ast.AlterTableRebuildPartition, ast.AlterTableOptimizePartition:
+			err = d.RebuildPartitions(sctx, ident, spec)
 		case ast.AlterTableRemovePartitioning:
-			err = errors.Trace(errUnsupportedRemovePartition)
+			err = d.RemovePartitioning(sctx, ident, spec)
 		case ast.AlterTableRepairPartition:
 			err = errors.Trace(errUnsupportedRepairPartition)
 		case ast.AlterTableDropColumn:
@@ -2756,9 +2892,13 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			case ast.ConstraintPrimaryKey:
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrTableCantHandleFt)
+				err = d.CreateIndex(sctx, ident, ast.IndexKeyTypeFullText, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
+			case ast.ConstraintSpatial:
+				err = d.CreateIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, false)
 			case ast.ConstraintCheck:
-				sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD CONSTRAINT CHECK"))
+				err = d.CreateCheckConstraint(sctx, ident, constr)
 			default:
 				// Nothing to do now.
 			}
@@ -2778,8 +2918,7 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 			isAlterTable := true
 			err = d.RenameTable(sctx, ident, newIdent, isAlterTable)
 		case ast.AlterTablePartition:
-			// Prevent silent succeed if user executes ALTER TABLE x PARTITION BY ...
-			err = errors.New("alter table partition is unsupported")
+			err = d.AlterTablePartitioning(sctx, ident, spec)
 		case ast.AlterTableOption:
 			var placementSettings *model.PlacementSettings
 			var placementPolicyRef *model.PolicyRefInfo
@@ -2851,9 +2990,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		case ast.AlterTableIndexInvisible:
 			err = d.AlterIndexVisibility(sctx, ident, spec.IndexName, spec.Visibility)
 		case ast.AlterTableAlterCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ALTER CHECK"))
+			err = d.AlterCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name), spec.Constraint.Enforced)
 		case ast.AlterTableDropCheck:
-			sctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("DROP CHECK"))
+			err = d.DropCheckConstraint(sctx, ident, model.NewCIStr(spec.Constraint.Name))
 		case ast.AlterTableWithValidation:
 			sctx.GetSessionVars().StmtCtx.AppendWarning(errUnsupportedAlterTableWithValidation)
 		case ast.AlterTableWithoutValidation:
@@ -2881,6 +3020,12 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, ident ast
 		}
 	}
 
+	if sctx.GetSessionVars().StmtCtx.MultiSchemaInfo != nil {
+		if len(sctx.GetSessionVars().StmtCtx.MultiSchemaInfo.SubJobs) == 0 {
+			return nil
+		}
+		return d.MultiSchemaChange(sctx, ident)
+	}
 	return nil
 }
 
@@ -3046,10 +3191,6 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 				return nil, errors.Trace(err)
 			}
 
-			if option.Stored {
-				return nil, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column through ALTER TABLE")
-			}
-
 			_, dependColNames := findDependedColumnNames(specNewColumn)
 			if !ctx.GetSessionVars().EnableAutoIncrementInGenerated {
 				if err = checkAutoIncrementRef(specNewColumn.Name.Name.L, dependColNames, t.Meta()); err != nil {
@@ -3094,7 +3235,7 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 	}
 	// Ignore table constraints now, they will be checked later.
 	// We use length(t.Cols()) as the default offset firstly, we will change the column's offset later.
-	col, _, err = buildColumnAndConstraint(
+	col, cts, err := buildColumnAndConstraint(
 		ctx,
 		len(t.Cols()),
 		specNewColumn,
@@ -3105,6 +3246,11 @@ func checkAndCreateNewColumn(ctx sessionctx.Context, ti ast.Ident, schema *model
 	if err != nil {
 		return nil, errors.Trace(err)
 	}
+	for _, constr := range cts {
+		if constr.Tp == ast.ConstraintCheck {
+			ctx.GetSessionVars().StmtCtx.AppendWarning(ErrUnsupportedConstraintCheck.GenWithStackByArgs("ADD COLUMN with CONSTRAINT CHECK"))
+		}
+	}
 
 	originDefVal, err := generateOriginDefaultValue(col.ToInfo())
 	if err != nil {
@@ -3140,7 +3286,13 @@ func (d *ddl) AddColumn(ctx sessionctx.Context, ti ast.Ident, spec *ast.AlterTab
 		SchemaName: schema.Name.L,
 		Type:       model.ActionAddColumn,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{col, spec.Position, 0},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args:     []interface{}{col, spec.Position, 0},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
 	}
 
 	err = d.doDDLJob(ctx, job)
@@ -3196,6 +3348,10 @@ func (d *ddl) AddColumns(ctx sessionctx.Context, ti ast.Ident, specs []*ast.Alte
 			if col == nil && spec.IfNotExists {
 				continue
 			}
+			// The values of a stored generated column are backfilled by the job of adding a single column.
+			if col.IsGenerated() && col.GeneratedStored {
+				return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Adding generated stored column with other columns through ALTER TABLE")
+			}
 			columns = append(columns, col)
 			positions = append(positions, spec.Position)
 			offsets = append(offsets, 0)
@@ -3244,6 +3400,9 @@ func (d *ddl) AddTablePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	if pi == nil {
 		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
+	if pi.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("add partition"))
+	}
 
 	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
 	if err != nil {
@@ -3306,21 +3465,337 @@ func (d *ddl) CoalescePartitions(ctx sessionctx.Context, ident ast.Ident, spec *
 	}
 
 	switch meta.Partition.Type {
-	// We don't support coalesce partitions hash type partition now.
-	case model.PartitionTypeHash:
+	// We don't support coalesce partitions hash and key type partition now.
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		return errors.Trace(ErrUnsupportedCoalescePartition)
-
-	// Key type partition cannot be constructed currently, ignoring it for now.
-	case model.PartitionTypeKey:
+	}
 
 	// Coalesce partition can only be used on hash/key partitions.
+	return errors.Trace(ErrCoalesceOnlyOnHashPartition)
+}
+
+// ReorganizePartitions splits or merges the consecutive partitions of a range or list partitioned table
+// into the new partition definitions, the records are moved into the new partitions online.
+func (d *ddl) ReorganizePartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("reorganize partition"))
+	}
+	switch pi.Type {
+	case model.PartitionTypeRange, model.PartitionTypeList:
 	default:
-		return errors.Trace(ErrCoalesceOnlyOnHashPartition)
+		return errors.Trace(errUnsupportedReorganizePartition)
+	}
+	if spec.OnAllPartitions {
+		return errors.Trace(ErrReorgNoParam)
+	}
+	if hasGlobalIndex(meta) || meta.TiFlashReplica != nil {
+		return errors.Trace(errUnsupportedReorganizePartition)
+	}
+
+	partNames := make([]string, 0, len(spec.PartitionNames))
+	for _, name := range spec.PartitionNames {
+		partNames = append(partNames, name.L)
+	}
+	partInfo, err := buildAddedPartitionInfo(ctx, meta, spec)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+		return errors.Trace(err)
+	}
+	if err := checkReorganizePartitions(ctx, meta, partNames, partInfo); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args: []interface{}{partNames, partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// checkReorganizePartitions checks the reorganized partitions exist and are consecutive, and the new partition
+// definitions combined with the rest partitions are valid.
+// For range partitions, the new partitions must cover the same range as the reorganized partitions,
+// except that the last partition of the table can be extended.
+func checkReorganizePartitions(ctx sessionctx.Context, meta *model.TableInfo, partNames []string, partInfo *model.PartitionInfo) error {
+	pi := meta.Partition
+	first, last, err := getReorganizedPartitionRange(meta, partNames)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	// partInfo contains only the new partitions, we have to combine it with the rest
+	// partitions to check all partitions are valid.
+	clonedMeta := meta.Clone()
+	tmp := *partInfo
+	tmp.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, pi.Definitions[first:last+1], partInfo.Definitions)
+	clonedMeta.Partition = &tmp
+	if err = checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return errors.Trace(err)
+	}
+
+	if pi.Type != model.PartitionTypeRange {
+		return nil
+	}
+	oldLast, newLast := &pi.Definitions[last], &partInfo.Definitions[len(partInfo.Definitions)-1]
+	shrunk, err := isRangePartitionBoundGreater(ctx, clonedMeta, oldLast, newLast)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if shrunk {
+		return errors.Trace(ErrReorgOutsideRange)
+	}
+	if last == len(pi.Definitions)-1 {
+		// The last partition of the table can be extended.
+		return nil
+	}
+	extended, err := isRangePartitionBoundGreater(ctx, clonedMeta, newLast, oldLast)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if extended {
+		return errors.Trace(ErrReorgOutsideRange)
+	}
+	return nil
+}
+
+// isRangePartitionBoundGreater returns whether the VALUES LESS THAN bound of curr is greater than that of prev.
+func isRangePartitionBoundGreater(ctx sessionctx.Context, tbInfo *model.TableInfo, curr, prev *model.PartitionDefinition) (bool, error) {
+	pi := tbInfo.Partition
+	if len(pi.Columns) > 0 {
+		return checkTwoRangeColumns(ctx, curr, prev, pi, tbInfo)
+	}
+	currMax, prevMax := strings.EqualFold(curr.LessThan[0], partitionMaxValue), strings.EqualFold(prev.LessThan[0], partitionMaxValue)
+	if currMax || prevMax {
+		return currMax && !prevMax, nil
+	}
+	isUnsigned := isColUnsigned(tbInfo.Columns, pi)
+	currValue, _, err := getRangeValue(ctx, curr.LessThan[0], isUnsigned)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	prevValue, _, err := getRangeValue(ctx, prev.LessThan[0], isUnsigned)
+	if err != nil {
+		return false, errors.Trace(err)
+	}
+	if isUnsigned {
+		return currValue.(uint64) > prevValue.(uint64), nil
+	}
+	return currValue.(int64) > prevValue.(int64), nil
+}
+
+// RebuildPartitions rebuilds the partitions by reorganizing them into the same partition definitions with new
+// physical IDs, it is also used by OPTIMIZE PARTITION. Only consecutive partitions can be reorganized by a job,
+// so the partitions between the first and the last given ones are rebuilt too.
+func (d *ddl) RebuildPartitions(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	pi := meta.GetPartitionInfo()
+	if pi == nil {
+		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if pi.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("rebuild partition"))
+	}
+	if hasGlobalIndex(meta) || meta.TiFlashReplica != nil {
+		if spec.Tp == ast.AlterTableOptimizePartition {
+			return errors.Trace(errUnsupportedOptimizePartition)
+		}
+		return errors.Trace(errUnsupportedRebuildPartition)
+	}
+
+	first, last := 0, len(pi.Definitions)-1
+	if !spec.OnAllPartitions {
+		first, last = len(pi.Definitions), -1
+		for _, name := range spec.PartitionNames {
+			idx, _, err := getPartitionDef(meta, name.L)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			first = mathutil.Min(first, idx)
+			last = mathutil.Max(last, idx)
+		}
+	}
+	partNames := make([]string, 0, last-first+1)
+	defs := make([]model.PartitionDefinition, 0, last-first+1)
+	for _, def := range pi.Definitions[first : last+1] {
+		partNames = append(partNames, def.Name.L)
+		defs = append(defs, def.Clone())
+	}
+	if err := d.assignPartitionIDs(defs); err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionReorganizePartition,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Args: []interface{}{partNames, &model.PartitionInfo{Definitions: defs}},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// AlterTablePartitioning changes the partitioning of a table by ALTER TABLE ... PARTITION BY, the table
+// may be non-partitioned. The records are copied online into the new partitions, which is done by the same
+// steps as REORGANIZE PARTITION.
+func (d *ddl) AlterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	return d.alterTablePartitioning(ctx, ident, spec, model.ActionAlterTablePartitioning)
+}
+
+// RemovePartitioning turns a partitioned table into a non-partitioned table with a new table ID,
+// see AlterTablePartitioning.
+func (d *ddl) RemovePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
+	return d.alterTablePartitioning(ctx, ident, spec, model.ActionRemovePartitioning)
+}
+
+func (d *ddl) alterTablePartitioning(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec, tp model.ActionType) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ident.Schema)
+	if !ok {
+		return errors.Trace(infoschema.ErrDatabaseNotExists.GenWithStackByArgs(schema))
+	}
+	t, err := is.TableByName(ident.Schema, ident.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ident.Schema, ident.Name))
+	}
+
+	meta := t.Meta()
+	unsupportedErr := errUnsupportedPartitionBy
+	if tp == model.ActionRemovePartitioning {
+		unsupportedErr = errUnsupportedRemovePartition
+		if meta.GetPartitionInfo() == nil {
+			return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+		}
+	}
+	if pi := meta.GetPartitionInfo(); pi != nil && pi.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs(tp.String()))
+	}
+	if hasGlobalIndex(meta) || meta.TiFlashReplica != nil || meta.TableCacheStatusType != model.TableCacheStatusDisable {
+		return errors.Trace(unsupportedErr)
+	}
+	if tp == model.ActionAlterTablePartitioning && hasFulltextIndex(meta) {
+		return errors.Trace(ErrTableCantHandleFt.GenWithStackByArgs())
+	}
+	if tp == model.ActionAlterTablePartitioning && hasSpatialIndex(meta) {
+		return errors.Trace(ErrTableCantHandleSpkeys.GenWithStackByArgs())
+	}
+
+	var partInfo *model.PartitionInfo
+	if tp == model.ActionRemovePartitioning {
+		newTableIDs, err := d.genGlobalIDs(1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		partInfo = buildFullTablePartitionInfo(newTableIDs[0])
+	} else {
+		partInfo, err = buildAlteredPartitionInfo(ctx, meta, spec.Partition)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if partInfo == nil {
+			return errors.Trace(unsupportedErr)
+		}
+		if err := d.assignPartitionIDs(partInfo.Definitions); err != nil {
+			return errors.Trace(err)
+		}
 	}
 
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    meta.ID,
+		SchemaName: schema.Name.L,
+		Type:       tp,
+		BinlogInfo: &model.HistoryInfo{},
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		// All the partitions are reorganized, so the partition names are not needed.
+		Args: []interface{}{[]string(nil), partInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
 	return errors.Trace(err)
 }
 
+// buildAlteredPartitionInfo builds the partition info of ALTER TABLE ... PARTITION BY, and checks it like
+// CREATE TABLE does. It returns nil if the partitioning is not supported.
+func buildAlteredPartitionInfo(ctx sessionctx.Context, meta *model.TableInfo, s *ast.PartitionOptions) (*model.PartitionInfo, error) {
+	clonedMeta := meta.Clone()
+	clonedMeta.Partition = nil
+	if err := buildTablePartitionInfo(ctx, s, clonedMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	pi := clonedMeta.Partition
+	if pi == nil {
+		return nil, nil
+	}
+	if pi.Sub != nil {
+		return nil, errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("partition by"))
+	}
+	if err := checkPartitionDefinitionConstraints(ctx, clonedMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if s.Expr != nil {
+		if err := checkPartitionFuncType(ctx, s.Expr, clonedMeta); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if err := checkPartitioningKeysConstraints(clonedMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return pi, nil
+}
+
 func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *ast.AlterTableSpec) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
@@ -3336,19 +3811,19 @@ func (d *ddl) TruncateTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
 
-	pids := make([]int64, len(spec.PartitionNames))
+	pids := make([]int64, 0, len(spec.PartitionNames))
 	if spec.OnAllPartitions {
 		pids = make([]int64, len(meta.GetPartitionInfo().Definitions))
 		for i, def := range meta.GetPartitionInfo().Definitions {
 			pids[i] = def.ID
 		}
 	} else {
-		for i, name := range spec.PartitionNames {
-			pid, err := tables.FindPartitionByName(meta, name.L)
+		for _, name := range spec.PartitionNames {
+			partIDs, err := tables.FindPartitionIDsByName(meta, name.L)
 			if err != nil {
 				return errors.Trace(err)
 			}
-			pids[i] = pid
+			pids = append(pids, partIDs...)
 		}
 	}
 
@@ -3383,6 +3858,9 @@ func (d *ddl) DropTablePartition(ctx sessionctx.Context, ident ast.Ident, spec *
 	if meta.GetPartitionInfo() == nil {
 		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
+	if meta.Partition.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("drop partition"))
+	}
 
 	partNames := make([]string, len(spec.PartitionNames))
 	for i, partCIName := range spec.PartitionNames {
@@ -3541,6 +4019,12 @@ func checkExchangePartition(pt *model.TableInfo, nt *model.TableInfo) error {
 	if pt.GetPartitionInfo() == nil {
 		return errors.Trace(ErrPartitionMgmtOnNonpartitioned)
 	}
+	if pt.Partition.Sub != nil {
+		return errors.Trace(errUnsupportedOnSubpartitions.GenWithStackByArgs("exchange partition"))
+	}
+	if pt.Partition.Type == model.PartitionTypeKey || (pt.Partition.Type == model.PartitionTypeRange && len(pt.Partition.Columns) > 1) {
+		return errors.Trace(errUnsupportedPartitionType.GenWithStackByArgs(pt.Name.O))
+	}
 	if nt.GetPartitionInfo() != nil {
 		return errors.Trace(ErrPartitionExchangePartTable.GenWithStackByArgs(nt.Name))
 	}
@@ -3580,8 +4064,6 @@ func (d *ddl) ExchangeTablePartition(ctx sessionctx.Context, ident ast.Ident, sp
 
 	partName := spec.PartitionNames[0].L
 
-	// NOTE: if pt is subPartitioned, it should be checked
-
 	defID, err := tables.FindPartitionByName(ptMeta, partName)
 	if err != nil {
 		return errors.Trace(err)
@@ -4124,6 +4606,9 @@ func (d *ddl) getModifiableColumnJob(ctx context.Context, sctx sessionctx.Contex
 		if c != nil {
 			return nil, infoschema.ErrColumnExists.GenWithStackByArgs(newColName)
 		}
+		if constraintInfo := getColumnCheckConstraint(t.Meta(), originalColName, false); constraintInfo != nil {
+			return nil, errDependentByCheckConstraint.GenWithStackByArgs(constraintInfo.Name, originalColName)
+		}
 	}
 
 	// Constraints in the new column means adding new constraints. Errors should thrown,
@@ -4308,6 +4793,18 @@ func checkColumnWithIndexConstraint(tbInfo *model.TableInfo, originalCol, newCol
 		if skipCheckIfNotModify && !modified {
 			return
 		}
+		if indexInfo.IsFulltext() {
+			if modified {
+				err = checkFulltextIndexColumn(newCol)
+			}
+			return
+		}
+		if indexInfo.IsSpatial() {
+			if modified {
+				err = checkSpatialIndexColumn(newCol)
+			}
+			return
+		}
 		err = checkIndexInModifiableColumns(columns, indexInfo.Columns)
 		if err != nil {
 			return
@@ -4487,6 +4984,11 @@ func (d *ddl) RenameColumn(ctx sessionctx.Context, ident ast.Ident, spec *ast.Al
 	if fkInfo := getColumnForeignKeyInfo(oldColName.L, tbl.Meta().ForeignKeys); fkInfo != nil {
 		return errFKIncompatibleColumns.GenWithStackByArgs(oldColName, fkInfo.Name)
 	}
+	if oldColName.L != newColName.L {
+		if constraintInfo := getColumnCheckConstraint(tbl.Meta(), oldColName, false); constraintInfo != nil {
+			return errDependentByCheckConstraint.GenWithStackByArgs(constraintInfo.Name, oldColName)
+		}
+	}
 
 	// Check generated expression.
 	for _, col := range allCols {
@@ -5406,10 +5908,6 @@ func buildHiddenColumnInfo(ctx sessionctx.Context, indexPartSpecifications []*as
 
 func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return errUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
-	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
@@ -5419,6 +5917,34 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
 	}
+	if keyType == ast.IndexKeyTypeFullText {
+		if err = checkFulltextIndex(t.Meta(), indexOption); err != nil {
+			return errors.Trace(err)
+		}
+		for _, ip := range indexPartSpecifications {
+			if ip.Expr != nil {
+				return errUnsupportedIndexType.GenWithStack("FULLTEXT index on expression is not supported")
+			}
+		}
+		// The index type is passed to the job by the index option.
+		ftOption := &ast.IndexOption{Tp: model.IndexTypeFulltext}
+		if indexOption != nil {
+			*ftOption = *indexOption
+			ftOption.Tp = model.IndexTypeFulltext
+		}
+		indexOption = ftOption
+	}
+	if keyType == ast.IndexKeyTypeSpatial {
+		if err = checkSpatialIndex(t.Meta()); err != nil {
+			return errors.Trace(err)
+		}
+		spOption := &ast.IndexOption{Tp: model.IndexTypeSpatial}
+		if indexOption != nil {
+			*spOption = *indexOption
+			spOption.Tp = model.IndexTypeSpatial
+		}
+		indexOption = spOption
+	}
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -5461,6 +5987,10 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 
 	finalColumns := make([]*model.ColumnInfo, len(tblInfo.Columns), len(tblInfo.Columns)+len(hiddenCols))
 	copy(finalColumns, tblInfo.Columns)
+	if mci := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo; mci != nil {
+		// The columns added by the other sub-jobs of the multi-schema change can be indexed.
+		finalColumns = append(finalColumns, getAddedColumnsOfSubJobs(mci)...)
+	}
 	finalColumns = append(finalColumns, hiddenCols...)
 	// Check before the job is put to the queue.
 	// This check is redundant, but useful. If DDL check fail before the job is put
@@ -5468,12 +5998,27 @@ func (d *ddl) CreateIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, err := buildIndexColumns(finalColumns, indexPartSpecifications)
+	var indexColumns []*model.IndexColumn
+	switch keyType {
+	case ast.IndexKeyTypeFullText:
+		indexColumns, err = buildFulltextIndexColumns(finalColumns, indexPartSpecifications)
+	case ast.IndexKeyTypeSpatial:
+		indexColumns, err = buildSpatialIndexColumns(finalColumns, indexPartSpecifications)
+	default:
+		indexColumns, err = buildIndexColumns(finalColumns, indexPartSpecifications)
+	}
+	if err != nil {
+		return errors.Trace(err)
+	}
+	mvIndex, err := checkMVIndexColumns(finalColumns, indexColumns)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if mvIndex && unique {
+		return errUnsupportedIndexType.GenWithStack("unique multi-valued index is not supported")
+	}
 
-	if !unique && tblInfo.IsCommonHandle {
+	if !unique && tblInfo.IsCommonHandle && keyType != ast.IndexKeyTypeFullText && keyType != ast.IndexKeyTypeSpatial {
 		// Ensure new created non-unique secondary-index's len + primary-key's len <= MaxIndexLength in clustered index table.
 		var pkLen, idxLen int
 		pkLen, err = indexColumnsLen(tblInfo.Columns, tables.FindPrimaryIndex(tblInfo).Columns)
@@ -5673,6 +6218,100 @@ func (d *ddl) DropForeignKey(ctx sessionctx.Context, ti ast.Ident, fkName model.
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constr *ast.Constraint) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	tblInfo := t.Meta()
+	if constr.Name != "" && tblInfo.FindConstraintInfoByName(constr.Name) != nil {
+		return ErrCheckConstraintDupName.GenWithStackByArgs(constr.Name)
+	}
+	setEmptyCheckConstraintName(tblInfo, []*ast.Constraint{constr})
+
+	constraintInfo, err := buildConstraintInfo(ctx, tblInfo, constr)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAddCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constraintInfo},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) AlterCheckConstraint(ctx sessionctx.Context, ti ast.Ident, constrName model.CIStr, enforced bool) error {
+	is := d.infoCache.GetLatest()
+	schema, ok := is.SchemaByName(ti.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(ti.Schema)
+	}
+
+	t, err := is.TableByName(ti.Schema, ti.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(ti.Schema, ti.Name))
+	}
+	if t.Meta().FindConstraintInfoByName(constrName.L) == nil {
+		return ErrCheckConstraintNotFound.GenWithStackByArgs(constrName.O)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    t.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionAlterCheckConstraint,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{constrName, enforced},
+	}
+
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) DropIndex(ctx sessionctx.Context, ti ast.Ident, indexName model.CIStr, ifExists bool) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ti.Schema)
@@ -5824,6 +6463,10 @@ func isDroppableColumn(multiSchemaChange bool, tblInfo *model.TableInfo, colName
 	if fkInfo := getColumnForeignKeyInfo(colName.L, tblInfo.ForeignKeys); fkInfo != nil {
 		return errFkColumnCannotDrop.GenWithStackByArgs(colName, fkInfo.Name)
 	}
+	// The check constraints only referring to the column are dropped along with it.
+	if constraintInfo := getColumnCheckConstraint(tblInfo, colName, true); constraintInfo != nil {
+		return errDependentByCheckConstraint.GenWithStackByArgs(constraintInfo.Name, colName)
+	}
 	return nil
 }
 
@@ -6596,6 +7239,149 @@ func (d *ddl) AlterPlacementPolicy(ctx sessionctx.Context, stmt *ast.AlterPlacem
 	return errors.Trace(err)
 }
 
+func (d *ddl) CreateRoutine(ctx sessionctx.Context, stmt *ast.CreateRoutineStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	// Check routine existence.
+	if _, ok = is.RoutineByName(stmt.Name.Schema, stmt.Name.Name, stmt.Tp); ok {
+		err = infoschema.ErrRoutineExists.GenWithStackByArgs(stmt.Tp.String(), stmt.Name.Name)
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	routineInfo, err := buildRoutineInfo(ctx, schema, stmt)
+	if err != nil {
+		return err
+	}
+	genIDs, err := d.genGlobalIDs(1)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	routineInfo.ID = genIDs[0]
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateRoutine,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{routineInfo},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropRoutine(ctx sessionctx.Context, stmt *ast.DropRoutineStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	// Check routine existence.
+	routine, ok := is.RoutineByName(stmt.Name.Schema, stmt.Name.Name, stmt.Tp)
+	if !ok {
+		err = infoschema.ErrRoutineNotExists.GenWithStackByArgs(stmt.Tp.String(), stmt.Name.Schema.O+"."+stmt.Name.Name.O)
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropRoutine,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{routine.ID},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) (err error) {
+	if stmt.Name.Schema.L != stmt.Table.Schema.L {
+		return ErrTrgInWrongSchema
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Table.Schema)
+	}
+	if util.IsMemOrSysDB(schema.Name.L) {
+		return ErrNoTriggersOnSystemSchema
+	}
+	tb, err := is.TableByName(stmt.Table.Schema, stmt.Table.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(stmt.Table.Schema, stmt.Table.Name))
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return ErrTrgOnViewOrTempTable.GenWithStackByArgs(stmt.Table.Name.O)
+	}
+	// Check trigger existence.
+	if _, _, ok = infoschema.TriggerByName(is, stmt.Name.Schema, stmt.Name.Name); ok {
+		err = infoschema.ErrTriggerExists.GenWithStackByArgs()
+		if stmt.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	triggerInfo, err := buildTriggerInfo(ctx, tblInfo, stmt)
+	if err != nil {
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionCreateTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{triggerInfo, stmt.Order, stmt.OrderTrigger},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+func (d *ddl) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) (err error) {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(stmt.Name.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(stmt.Name.Schema)
+	}
+	// Check trigger existence.
+	tb, _, ok := infoschema.TriggerByName(is, stmt.Name.Schema, stmt.Name.Name)
+	if !ok {
+		err = infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+		if stmt.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tb.Meta().ID,
+		SchemaName: schema.Name.L,
+		Type:       model.ActionDropTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{stmt.Name.Name},
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) AlterTableCache(ctx sessionctx.Context, ti ast.Ident) (err error) {
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
@@ -362,7 +362,8 @@ func (w *worker) updateDDLJob(t *meta.Meta, job *model.Job, meetErr bool) error
 	updateRawArgs := true
 	// If there is an error when running job and the RawArgs hasn't been decoded by DecodeArgs,
 	// so we shouldn't replace RawArgs with the marshaling Args.
-	if meetErr && (job.RawArgs != nil && job.Args == nil) {
+	// The args of the multi-schema change job are kept in its sub-jobs, which are only updated once decoded.
+	if meetErr && (job.RawArgs != nil && job.Args == nil) && job.Type != model.ActionMultiSchemaChange {
 		logutil.Logger(w.logCtx).Info("[ddl] meet something wrong before update DDL job, shouldn't update raw args",
 			zap.String("job", job.String()))
 		updateRawArgs = false
@@ -398,7 +399,8 @@ func (w *worker) finishDDLJob(t *meta.Meta, job *model.Job) (err error) {
 			// After rolling back an AddIndex operation, we need to use delete-range to delete the half-done index data.
 			err = w.deleteRange(w.ddlJobCtx, job)
 		case model.ActionDropSchema, model.ActionDropTable, model.ActionTruncateTable, model.ActionDropIndex, model.ActionDropPrimaryKey,
-			model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes:
+			model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionDropColumn, model.ActionDropColumns, model.ActionModifyColumn, model.ActionDropIndexes,
+			model.ActionReorganizePartition, model.ActionMultiSchemaChange, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 			err = w.deleteRange(w.ddlJobCtx, job)
 		}
 	}
@@ -757,8 +759,10 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onTruncateTablePartition(d, t, job)
 	case model.ActionExchangeTablePartition:
 		ver, err = w.onExchangeTablePartition(d, t, job)
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		ver, err = w.onReorganizePartition(d, t, job)
 	case model.ActionAddColumn:
-		ver, err = onAddColumn(d, t, job)
+		ver, err = w.onAddColumn(d, t, job)
 	case model.ActionAddColumns:
 		ver, err = onAddColumns(d, t, job)
 	case model.ActionDropColumn:
@@ -777,12 +781,20 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onDropIndex(t, job)
 	case model.ActionDropIndexes:
 		ver, err = onDropIndexes(t, job)
+	case model.ActionMultiSchemaChange:
+		ver, err = onMultiSchemaChange(w, d, t, job)
 	case model.ActionRenameIndex:
 		ver, err = onRenameIndex(t, job)
 	case model.ActionAddForeignKey:
 		ver, err = onCreateForeignKey(t, job)
 	case model.ActionDropForeignKey:
 		ver, err = onDropForeignKey(t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = w.onAddCheckConstraint(t, job)
+	case model.ActionDropCheckConstraint:
+		ver, err = onDropCheckConstraint(t, job)
+	case model.ActionAlterCheckConstraint:
+		ver, err = w.onAlterCheckConstraint(t, job)
 	case model.ActionTruncateTable:
 		ver, err = onTruncateTable(d, t, job)
 	case model.ActionRebaseAutoID:
@@ -829,6 +841,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onDropPlacementPolicy(d, t, job)
 	case model.ActionAlterPlacementPolicy:
 		ver, err = onAlterPlacementPolicy(d, t, job)
+	case model.ActionCreateRoutine:
+		ver, err = onCreateRoutine(t, job)
+	case model.ActionDropRoutine:
+		ver, err = onDropRoutine(t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(t, job)
 	case model.ActionAlterTablePartitionPolicy:
 		ver, err = onAlterTablePartitionOptions(d, t, job)
 	case model.ActionAlterTablePlacement:
@@ -1049,7 +1069,8 @@ func updateSchemaVersion(t *meta.Meta, job *model.Job) (int64, error) {
 			newIDs := job.CtxVars[1].([]int64)
 			diff.AffectedOpts = buildPlacementAffects(oldIDs, newIDs)
 		}
-	case model.ActionDropTablePartition, model.ActionRecoverTable, model.ActionDropTable:
+	case model.ActionDropTablePartition, model.ActionRecoverTable, model.ActionDropTable, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		// affects are used to update placement rule cache
 		diff.TableID = job.TableID
 		if len(job.CtxVars) > 0 {
@@ -1057,6 +1078,13 @@ func updateSchemaVersion(t *meta.Meta, job *model.Job) (int64, error) {
 				diff.AffectedOpts = buildPlacementAffects(oldIDs, oldIDs)
 			}
 		}
+		if job.Type == model.ActionRemovePartitioning {
+			// The table ID is changed when the job is done, see removeTablePartitioning.
+			diff.OldTableID = job.TableID
+			if len(job.CtxVars) > 1 {
+				diff.TableID = job.CtxVars[1].(int64)
+			}
+		}
 	default:
 		diff.TableID = job.TableID
 	}
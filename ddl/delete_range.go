@@ -297,7 +297,8 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 		startKey = tablecodec.EncodeTablePrefix(tableID)
 		endKey := tablecodec.EncodeTablePrefix(tableID + 1)
 		return doInsert(ctx, s, job.ID, tableID, startKey, endKey, now)
-	case model.ActionDropTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		var physicalTableIDs []int64
 		if err := job.DecodeArgs(&physicalTableIDs); err != nil {
 			return errors.Trace(err)
@@ -423,10 +424,32 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 				return errors.Trace(err)
 			}
 		}
+	case model.ActionMultiSchemaChange:
+		for _, sub := range job.MultiSchemaInfo.SubJobs {
+			if !needDeleteRangeForSubJob(sub) {
+				continue
+			}
+			if err := insertJobIntoDeleteRangeTable(ctx, sctx, sub.ToProxyJob(job)); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	}
 	return nil
 }
 
+// needDeleteRangeForSubJob checks whether the data of the sub-job should be deleted after the multi-schema change finishes.
+func needDeleteRangeForSubJob(sub *model.SubJob) bool {
+	switch sub.Type {
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		return sub.State == model.JobStateRollbackDone
+	case model.ActionDropIndex, model.ActionDropPrimaryKey, model.ActionDropColumn:
+		return sub.State == model.JobStateDone
+	case model.ActionModifyColumn:
+		return sub.State == model.JobStateDone || sub.State == model.JobStateRollbackDone
+	}
+	return false
+}
+
 func doBatchDeleteIndiceRange(ctx context.Context, s sqlexec.SQLExecutor, jobID, tableID int64, indexIDs []int64, ts uint64) error {
 	logutil.BgLogger().Info("[ddl] batch insert into delete-range indices", zap.Int64("jobID", jobID), zap.Int64s("elementIDs", indexIDs))
 	paramsList := make([]interface{}, 0, len(indexIDs)*5)
@@ -26,14 +26,15 @@ var (
 	// errWorkerClosed means we have already closed the DDL worker.
 	errInvalidWorker = dbterror.ClassDDL.NewStd(mysql.ErrInvalidDDLWorker)
 	// errNotOwner means we are not owner and can't handle DDL jobs.
-	errNotOwner              = dbterror.ClassDDL.NewStd(mysql.ErrNotOwner)
-	errCantDecodeRecord      = dbterror.ClassDDL.NewStd(mysql.ErrCantDecodeRecord)
-	errInvalidDDLJob         = dbterror.ClassDDL.NewStd(mysql.ErrInvalidDDLJob)
-	errCancelledDDLJob       = dbterror.ClassDDL.NewStd(mysql.ErrCancelledDDLJob)
-	errFileNotFound          = dbterror.ClassDDL.NewStd(mysql.ErrFileNotFound)
-	errRunMultiSchemaChanges = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change"), nil))
-	errWaitReorgTimeout      = dbterror.ClassDDL.NewStdErr(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrWaitReorgTimeout])
-	errInvalidStoreVer       = dbterror.ClassDDL.NewStd(mysql.ErrInvalidStoreVersion)
+	errNotOwner                     = dbterror.ClassDDL.NewStd(mysql.ErrNotOwner)
+	errCantDecodeRecord             = dbterror.ClassDDL.NewStd(mysql.ErrCantDecodeRecord)
+	errInvalidDDLJob                = dbterror.ClassDDL.NewStd(mysql.ErrInvalidDDLJob)
+	errCancelledDDLJob              = dbterror.ClassDDL.NewStd(mysql.ErrCancelledDDLJob)
+	errFileNotFound                 = dbterror.ClassDDL.NewStd(mysql.ErrFileNotFound)
+	errRunMultiSchemaChanges        = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change"), nil))
+	errUnsupportedMultiSchemaChange = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "multi schema change for %s"), nil))
+	errWaitReorgTimeout             = dbterror.ClassDDL.NewStdErr(mysql.ErrLockWaitTimeout, mysql.MySQLErrName[mysql.ErrWaitReorgTimeout])
+	errInvalidStoreVer              = dbterror.ClassDDL.NewStd(mysql.ErrInvalidStoreVersion)
 	// ErrRepairTableFail is used to repair tableInfo in repair mode.
 	ErrRepairTableFail = dbterror.ClassDDL.NewStd(mysql.ErrRepairTable)
 
@@ -97,7 +98,9 @@ var (
 	errUnsupportedOptimizePartition   = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "optimize partition"), nil))
 	errUnsupportedRebuildPartition    = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "rebuild partition"), nil))
 	errUnsupportedRemovePartition     = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "remove partitioning"), nil))
+	errUnsupportedPartitionBy         = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "partition by"), nil))
 	errUnsupportedRepairPartition     = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "repair partition"), nil))
+	errUnsupportedOnSubpartitions     = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "%s on subpartitioned table"), nil))
 	// ErrGeneratedColumnFunctionIsNotAllowed returns for unsupported functions for generated columns.
 	ErrGeneratedColumnFunctionIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrGeneratedColumnFunctionIsNotAllowed)
 	// ErrGeneratedColumnRowValueIsNotAllowed returns for generated columns referring to row values.
@@ -180,6 +183,12 @@ var (
 	ErrPartitionMaxvalue = dbterror.ClassDDL.NewStd(mysql.ErrPartitionMaxvalue)
 	// ErrDropLastPartition returns cannot remove all partitions, use drop table instead.
 	ErrDropLastPartition = dbterror.ClassDDL.NewStd(mysql.ErrDropLastPartition)
+	// ErrReorgNoParam returns REORGANIZE PARTITION without parameters can only be used on auto-partitioned tables using HASH PARTITIONs.
+	ErrReorgNoParam = dbterror.ClassDDL.NewStd(mysql.ErrReorgNoParam)
+	// ErrConsecutiveReorgPartitions returns the reorganized partitions must be in consecutive order.
+	ErrConsecutiveReorgPartitions = dbterror.ClassDDL.NewStd(mysql.ErrConsecutiveReorgPartitions)
+	// ErrReorgOutsideRange returns the reorganized range partitions cannot change the total range except for extending the last partition.
+	ErrReorgOutsideRange = dbterror.ClassDDL.NewStd(mysql.ErrReorgOutsideRange)
 	// ErrTooManyPartitions returns too many partitions were defined.
 	ErrTooManyPartitions = dbterror.ClassDDL.NewStd(mysql.ErrTooManyPartitions)
 	// ErrPartitionConstDomain returns partition constant is out of partition function domain.
@@ -204,8 +213,22 @@ var (
 	ErrWrongObject = dbterror.ClassDDL.NewStd(mysql.ErrWrongObject)
 	// ErrTableCantHandleFt returns FULLTEXT keys are not supported by table type
 	ErrTableCantHandleFt = dbterror.ClassDDL.NewStd(mysql.ErrTableCantHandleFt)
+	// ErrBadFtColumn returns when the column type can't be part of a FULLTEXT index.
+	ErrBadFtColumn = dbterror.ClassDDL.NewStd(mysql.ErrBadFtColumn)
+	// ErrFtParserNotDefined returns when the full-text parser of a FULLTEXT index doesn't exist.
+	ErrFtParserNotDefined = dbterror.ClassDDL.NewStd(mysql.ErrFunctionNotDefined)
+	// ErrTableCantHandleSpkeys returns when SPATIAL indexes are not supported by the table.
+	ErrTableCantHandleSpkeys = dbterror.ClassDDL.NewStd(mysql.ErrTableCantHandleSpkeys)
+	// ErrSpatialCantHaveNull returns when the column of a SPATIAL index is nullable.
+	ErrSpatialCantHaveNull = dbterror.ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrSpatialMustHaveGeomCol returns when the column of a SPATIAL index isn't a spatial column.
+	ErrSpatialMustHaveGeomCol = dbterror.ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrTooManyKeyParts returns when the index has more columns than allowed.
+	ErrTooManyKeyParts = dbterror.ClassDDL.NewStd(mysql.ErrTooManyKeyParts)
 	// ErrFieldNotFoundPart returns an error when 'partition by columns' are not found in table columns.
 	ErrFieldNotFoundPart = dbterror.ClassDDL.NewStd(mysql.ErrFieldNotFoundPart)
+	// ErrSubpartition returns an error when subpartitions are used with a partition type other than RANGE and LIST.
+	ErrSubpartition = dbterror.ClassDDL.NewStd(mysql.ErrSubpartition)
 	// ErrWrongTypeColumnValue returns 'Partition column values of incorrect type'
 	ErrWrongTypeColumnValue = dbterror.ClassDDL.NewStd(mysql.ErrWrongTypeColumnValue)
 	// ErrValuesIsNotIntType returns 'VALUES value for partition '%-.64s' must have type INT'
@@ -307,4 +330,68 @@ var (
 	errDependentByFunctionalIndex = dbterror.ClassDDL.NewStd(mysql.ErrDependentByFunctionalIndex)
 	// errFunctionalIndexOnBlob when the expression of expression index returns blob or text.
 	errFunctionalIndexOnBlob = dbterror.ClassDDL.NewStd(mysql.ErrFunctionalIndexOnBlob)
+
+	// ErrColumnCheckConstraintReferencesOtherColumn returns when a column check constraint refers to other columns.
+	ErrColumnCheckConstraintReferencesOtherColumn = dbterror.ClassDDL.NewStd(mysql.ErrColumnCheckConstraintReferencesOtherColumn)
+	// ErrCheckConstraintFunctionIsNotAllowed returns for unsupported functions for check constraints.
+	ErrCheckConstraintFunctionIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintFunctionIsNotAllowed)
+	// ErrCheckConstraintVariables returns when a check constraint refers to user or system variables.
+	ErrCheckConstraintVariables = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintVariables)
+	// ErrCheckConstraintRowValueIsNotAllowed returns when a check constraint refers to row values.
+	ErrCheckConstraintRowValueIsNotAllowed = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRowValueIsNotAllowed)
+	// ErrCheckConstraintRefersAutoIncrementColumn returns when a check constraint refers to an auto-increment column.
+	ErrCheckConstraintRefersAutoIncrementColumn = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRefersAutoIncrementColumn)
+	// ErrCheckConstraintViolated returns when the existing rows violate the added check constraint.
+	ErrCheckConstraintViolated = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrCheckConstraintRefersUnknownColumn returns when a check constraint refers to a non-existing column.
+	ErrCheckConstraintRefersUnknownColumn = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintRefersUnknownColumn)
+	// ErrCheckConstraintNotFound returns when the check constraint doesn't exist.
+	ErrCheckConstraintNotFound = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintNotFound)
+	// ErrCheckConstraintDupName returns when the check constraint name is duplicated.
+	ErrCheckConstraintDupName = dbterror.ClassDDL.NewStd(mysql.ErrCheckConstraintDupName)
+	// errDependentByCheckConstraint returns when the dropped or renamed column is used by a check constraint.
+	errDependentByCheckConstraint = dbterror.ClassDDL.NewStd(mysql.ErrDependentByCheckConstraint)
+
+	// ErrOperateSameColumn returns when the multi-schema change operates the same column more than once.
+	ErrOperateSameColumn = dbterror.ClassDDL.NewStd(mysql.ErrOperateSameColumn)
+	// ErrOperateSameIndex returns when the multi-schema change operates the same index more than once.
+	ErrOperateSameIndex = dbterror.ClassDDL.NewStd(mysql.ErrOperateSameIndex)
+
+	// ErrSpDupParam returns when a stored routine has duplicate parameters.
+	ErrSpDupParam = dbterror.ClassDDL.NewStd(mysql.ErrSpDupParam)
+	// ErrSpDupVar returns when a variable is declared twice in the same block.
+	ErrSpDupVar = dbterror.ClassDDL.NewStd(mysql.ErrSpDupVar)
+	// ErrSpDupCurs returns when a cursor is declared twice in the same block.
+	ErrSpDupCurs = dbterror.ClassDDL.NewStd(mysql.ErrSpDupCurs)
+	// ErrSpCursorMismatch returns when a stored routine refers to an undeclared cursor.
+	ErrSpCursorMismatch = dbterror.ClassDDL.NewStd(mysql.ErrSpCursorMismatch)
+	// ErrSpBadCursorSelect returns when the SELECT of a cursor has an INTO clause.
+	ErrSpBadCursorSelect = dbterror.ClassDDL.NewStd(mysql.ErrSpBadCursorSelect)
+	// ErrSpLilabelMismatch returns when LEAVE or ITERATE refers to an unknown label.
+	ErrSpLilabelMismatch = dbterror.ClassDDL.NewStd(mysql.ErrSpLilabelMismatch)
+	// ErrSpLabelRedefine returns when a label is redefined in a nested block.
+	ErrSpLabelRedefine = dbterror.ClassDDL.NewStd(mysql.ErrSpLabelRedefine)
+	// ErrSpBadreturn returns when RETURN is used in a stored procedure.
+	ErrSpBadreturn = dbterror.ClassDDL.NewStd(mysql.ErrSpBadreturn)
+	// ErrSpNoreturn returns when a stored function has no RETURN statement.
+	ErrSpNoreturn = dbterror.ClassDDL.NewStd(mysql.ErrSpNoreturn)
+	// errUnsupportedRoutine returns for the stored routine features that are not supported yet.
+	errUnsupportedRoutine = dbterror.ClassDDL.NewStd(mysql.ErrNotSupportedYet)
+
+	// ErrTrgOnViewOrTempTable returns when a trigger is created on a view, a sequence or a temporary table.
+	ErrTrgOnViewOrTempTable = dbterror.ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgCantChangeRow returns when a trigger assigns the OLD row, or the NEW row in an AFTER trigger.
+	ErrTrgCantChangeRow = dbterror.ClassDDL.NewStd(mysql.ErrTrgCantChangeRow)
+	// ErrTrgNoSuchRowInTrg returns when a trigger refers to the OLD row of INSERT or the NEW row of DELETE.
+	ErrTrgNoSuchRowInTrg = dbterror.ClassDDL.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	// ErrTrgInWrongSchema returns when a trigger is created in another schema than its table.
+	ErrTrgInWrongSchema = dbterror.ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema returns when a trigger is created on a system table.
+	ErrNoTriggersOnSystemSchema = dbterror.ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrReferencedTrgDoesNotExist returns when FOLLOWS or PRECEDES refers to a trigger with another timing or event.
+	ErrReferencedTrgDoesNotExist = dbterror.ClassDDL.NewStd(mysql.ErrReferencedTrgDoesNotExist)
+	// ErrSpNoRetset returns when a trigger returns a result set.
+	ErrSpNoRetset = dbterror.ClassDDL.NewStd(mysql.ErrSpNoRetset)
+	// ErrCommitNotAllowedInSfOrTrg returns when a trigger commits the transaction explicitly or implicitly.
+	ErrCommitNotAllowedInSfOrTrg = dbterror.ClassDDL.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
 )
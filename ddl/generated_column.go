@@ -23,9 +23,12 @@ import (
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/generatedexpr"
 )
 
 // columnGenerationInDDL is a struct for validating generated columns in DDL.
@@ -156,7 +159,7 @@ func hasDependentByGeneratedColumn(tblInfo *model.TableInfo, colName model.CIStr
 }
 
 func isGeneratedRelatedColumn(tblInfo *model.TableInfo, newCol, col *model.ColumnInfo) error {
-	if newCol.IsGenerated() || col.IsGenerated() {
+	if (newCol.IsGenerated() || col.IsGenerated()) && !isVirtualToStoredColumn(col, newCol) {
 		// TODO: Make it compatible with MySQL error.
 		msg := fmt.Sprintf("newCol IsGenerated %v, oldCol IsGenerated %v", newCol.IsGenerated(), col.IsGenerated())
 		return errUnsupportedModifyColumn.GenWithStackByArgs(msg)
@@ -184,20 +187,28 @@ func (c *generatedColumnChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 	return inNode, true
 }
 
+// isVirtualToStoredColumn checks whether a virtual generated column is changed to a stored one.
+func isVirtualToStoredColumn(oldCol, newCol *model.ColumnInfo) bool {
+	return isVirtualGeneratedColumn(oldCol) && newCol.IsGenerated() && newCol.GeneratedStored
+}
+
 // checkModifyGeneratedColumn checks the modification between
 // old and new is valid or not by such rules:
-//  1. the modification can't change stored status;
+//  1. the modification can't change a normal column to a virtual generated column or vice versa,
+//     and a column in the clustered index can't be virtual;
 //  2. if the new is generated, check its refer rules.
 //  3. check if the modified expr contains non-deterministic functions
 //  4. check whether new column refers to any auto-increment columns.
 //  5. check if the new column is indexed or stored
 func checkModifyGeneratedColumn(sctx sessionctx.Context, tbl table.Table, oldCol, newCol *table.Column, newColDef *ast.ColumnDef, pos *ast.ColumnPosition) error {
 	// rule 1.
-	oldColIsStored := !oldCol.IsGenerated() || oldCol.GeneratedStored
-	newColIsStored := !newCol.IsGenerated() || newCol.GeneratedStored
-	if oldColIsStored != newColIsStored {
+	oldColIsVirtual, newColIsVirtual := isVirtualGeneratedColumn(oldCol.ColumnInfo), isVirtualGeneratedColumn(newCol.ColumnInfo)
+	if (oldColIsVirtual && !newCol.IsGenerated()) || (newColIsVirtual && !oldCol.IsGenerated()) {
 		return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Changing the STORED status")
 	}
+	if newColIsVirtual && mysql.HasPriKeyFlag(oldCol.Flag) && (tbl.Meta().PKIsHandle || tbl.Meta().IsCommonHandle) {
+		return ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Defining a virtual generated column as clustered primary key")
+	}
 
 	// rule 2.
 	originCols := tbl.Cols()
@@ -272,6 +283,7 @@ type illegalFunctionChecker struct {
 	hasRowVal            bool // hasRowVal checks whether the functional index refers to a row value
 	hasWindowFunc        bool
 	hasNotGAFunc4ExprIdx bool
+	hasVariable          bool
 	otherErr             error
 }
 
@@ -280,7 +292,7 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 	case *ast.FuncCallExpr:
 		// Blocked functions & non-builtin functions is not allowed
 		_, IsFunctionBlocked := expression.IllegalFunctions4GeneratedColumns[node.FnName.L]
-		if IsFunctionBlocked || !expression.IsFunctionSupported(node.FnName.L) {
+		if IsFunctionBlocked || node.Tp == ast.FuncCallExprTypeGeneric || !expression.IsFunctionSupported(node.FnName.L) {
 			c.hasIllegalFunc = true
 			return inNode, true
 		}
@@ -293,10 +305,15 @@ func (c *illegalFunctionChecker) Enter(inNode ast.Node) (outNode ast.Node, skipC
 		if !isFuncGA {
 			c.hasNotGAFunc4ExprIdx = true
 		}
-	case *ast.SubqueryExpr, *ast.ValuesExpr, *ast.VariableExpr:
-		// Subquery & `values(x)` & variable is not allowed
+	case *ast.SubqueryExpr, *ast.ValuesExpr:
+		// Subquery & `values(x)` is not allowed
 		c.hasIllegalFunc = true
 		return inNode, true
+	case *ast.VariableExpr:
+		// Variable is not allowed
+		c.hasIllegalFunc = true
+		c.hasVariable = true
+		return inNode, true
 	case *ast.AggregateFuncExpr:
 		// Aggregate function is not allowed
 		c.hasAggFunc = true
@@ -318,6 +335,7 @@ func (c *illegalFunctionChecker) Leave(inNode ast.Node) (node ast.Node, ok bool)
 const (
 	typeColumn = iota
 	typeIndex
+	typeCheckConstraint
 )
 
 func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error {
@@ -326,12 +344,17 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 	}
 	var c illegalFunctionChecker
 	expr.Accept(&c)
+	if c.hasVariable && genType == typeCheckConstraint {
+		return ErrCheckConstraintVariables.GenWithStackByArgs(name)
+	}
 	if c.hasIllegalFunc {
 		switch genType {
 		case typeColumn:
 			return ErrGeneratedColumnFunctionIsNotAllowed.GenWithStackByArgs(name)
 		case typeIndex:
 			return ErrFunctionalIndexFunctionIsNotAllowed.GenWithStackByArgs(name)
+		case typeCheckConstraint:
+			return ErrCheckConstraintFunctionIsNotAllowed.GenWithStackByArgs(name)
 		}
 	}
 	if c.hasAggFunc {
@@ -343,6 +366,8 @@ func checkIllegalFn4Generated(name string, genType int, expr ast.ExprNode) error
 			return ErrGeneratedColumnRowValueIsNotAllowed.GenWithStackByArgs(name)
 		case typeIndex:
 			return ErrFunctionalIndexRowValueIsNotAllowed.GenWithStackByArgs(name)
+		case typeCheckConstraint:
+			return ErrCheckConstraintRowValueIsNotAllowed.GenWithStackByArgs(name)
 		}
 	}
 	if c.hasWindowFunc {
@@ -376,6 +401,25 @@ func checkIndexOrStored(tbl table.Table, oldCol, newCol *table.Column) error {
 	return nil
 }
 
+// buildGeneratedColumnExpr builds the expression evaluating the generated column with the columns in the schema.
+func buildGeneratedColumnExpr(sctx sessionctx.Context, tblInfo *model.TableInfo, col *model.ColumnInfo,
+	schema *expression.Schema, names types.NameSlice) (expression.Expression, error) {
+	exprNode, err := generatedexpr.ParseExpression(col.GeneratedExprString)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	exprNode, err = generatedexpr.SimpleResolveName(exprNode, tblInfo)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	expr, err := expression.RewriteAstExpr(sctx, exprNode, schema, names)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	expr, err = expr.ResolveIndices(schema)
+	return expr, errors.Trace(err)
+}
+
 // checkAutoIncrementRef checks if an generated column depends on an auto-increment column and raises an error if so.
 // See https://dev.mysql.com/doc/refman/5.7/en/create-table-generated-columns.html for details.
 func checkAutoIncrementRef(name string, dependencies map[string]struct{}, tbInfo *model.TableInfo) error {
@@ -39,6 +39,7 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/logutil"
 	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/pingcap/tidb/util/timeutil"
@@ -98,18 +99,27 @@ func checkPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications
 		if lastCol == nil {
 			return nil, errKeyColumnDoesNotExits.GenWithStackByArgs(colName.Column.Name)
 		}
-		// Virtual columns cannot be used in primary key.
-		if lastCol.IsGenerated() && !lastCol.GeneratedStored {
-			if lastCol.Hidden {
-				return nil, ErrFunctionalIndexPrimaryKey
-			}
-			return nil, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Defining a virtual generated column as primary key")
+		// Expression index parts cannot be used in primary key.
+		if isVirtualGeneratedColumn(lastCol) && lastCol.Hidden {
+			return nil, ErrFunctionalIndexPrimaryKey
 		}
 	}
 
 	return lastCol, nil
 }
 
+// hasVirtualGeneratedColumn checks whether the index parts refer to a virtual generated column.
+// Such a primary key can't be the clustered index, since the values of the index columns can't be
+// encoded in the row key before they are evaluated.
+func hasVirtualGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) bool {
+	for _, ip := range indexPartSpecifications {
+		if col := getColumnInfoByName(tblInfo, ip.Column.Name.L); col != nil && isVirtualGeneratedColumn(col) {
+			return true
+		}
+	}
+	return false
+}
+
 func checkIndexPrefixLength(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn, pkLenAppendToKey int) error {
 	idxLen, err := indexColumnsLen(columns, idxColumns)
 	if err != nil {
@@ -231,15 +241,192 @@ func buildIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSp
 		return nil, errors.Trace(err)
 	}
 
+	mvIndex, err := checkMVIndexColumns(tblInfo.Columns, idxColumns)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
 	// Create index info.
 	idxInfo := &model.IndexInfo{
 		Name:    indexName,
 		Columns: idxColumns,
 		State:   state,
+		MVIndex: mvIndex,
+	}
+	return idxInfo, nil
+}
+
+// checkMVIndexColumns returns whether the index is a multi-valued index, which has a `CAST(... AS ... ARRAY)`
+// expression index part. Only one array part is allowed in an index.
+func checkMVIndexColumns(columns []*model.ColumnInfo, idxColumns []*model.IndexColumn) (bool, error) {
+	cnt := 0
+	for _, ic := range idxColumns {
+		if columns[ic.Offset].FieldType.Array {
+			cnt++
+		}
+	}
+	if cnt > 1 {
+		return false, errUnsupportedIndexType.GenWithStack("more than one multi-valued key part per index is not supported")
+	}
+	return cnt == 1, nil
+}
+
+// buildFulltextIndexInfo builds the FULLTEXT index, the indexed values are split into tokens by the parser.
+func buildFulltextIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSpecifications []*ast.IndexPartSpecification,
+	parserName string, state model.SchemaState) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	idxColumns, err := buildFulltextIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	idxInfo := &model.IndexInfo{
+		Name:       indexName,
+		Columns:    idxColumns,
+		State:      state,
+		Tp:         model.IndexTypeFulltext,
+		ParserName: parserName,
+	}
+	return idxInfo, nil
+}
+
+// buildFulltextIndexColumns builds the columns of the FULLTEXT index, only the whole values of
+// the CHAR, VARCHAR and TEXT columns can be indexed.
+func buildFulltextIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	idxParts := make([]*model.IndexColumn, 0, len(indexPartSpecifications))
+	for _, ip := range indexPartSpecifications {
+		if ip.Column == nil {
+			return nil, errUnsupportedIndexType.GenWithStack("FULLTEXT index on expression is not supported")
+		}
+		col := model.FindColumnInfo(columns, ip.Column.Name.L)
+		if col == nil {
+			return nil, errKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+		}
+		if err := checkFulltextIndexColumn(col); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if ip.Length != types.UnspecifiedLength {
+			return nil, errors.Trace(errIncorrectPrefixKey)
+		}
+		idxParts = append(idxParts, &model.IndexColumn{
+			Name:   col.Name,
+			Offset: col.Offset,
+			Length: types.UnspecifiedLength,
+		})
+	}
+	return idxParts, nil
+}
+
+// checkFulltextIndexColumn checks whether the column can be a part of the FULLTEXT index.
+func checkFulltextIndexColumn(col *model.ColumnInfo) error {
+	switch col.Tp {
+	case mysql.TypeString, mysql.TypeVarchar, mysql.TypeVarString, mysql.TypeTinyBlob, mysql.TypeBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+		if col.Charset != charset.CharsetBin {
+			return nil
+		}
+	}
+	return ErrBadFtColumn.GenWithStackByArgs(col.Name.O)
+}
+
+// checkFulltextIndex checks whether the FULLTEXT index with the index option can be built on the table.
+func checkFulltextIndex(tblInfo *model.TableInfo, indexOption *ast.IndexOption) error {
+	if tblInfo.GetPartitionInfo() != nil || tblInfo.TempTableType != model.TempTableNone {
+		return ErrTableCantHandleFt.GenWithStackByArgs()
+	}
+	if indexOption != nil && !fulltext.IsSupportedParser(indexOption.ParserName.L) {
+		return ErrFtParserNotDefined.GenWithStackByArgs(indexOption.ParserName.O)
+	}
+	return nil
+}
+
+func hasFulltextIndex(tblInfo *model.TableInfo) bool {
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.IsFulltext() {
+			return true
+		}
+	}
+	return false
+}
+
+// buildSpatialIndexInfo builds the SPATIAL index, the indexed values are the cells of the space-filling curve
+// which contain the geometries.
+func buildSpatialIndexInfo(tblInfo *model.TableInfo, indexName model.CIStr, indexPartSpecifications []*ast.IndexPartSpecification,
+	state model.SchemaState) (*model.IndexInfo, error) {
+	if err := checkTooLongIndex(indexName); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	idxColumns, err := buildSpatialIndexColumns(tblInfo.Columns, indexPartSpecifications)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	idxInfo := &model.IndexInfo{
+		Name:    indexName,
+		Columns: idxColumns,
+		State:   state,
+		Tp:      model.IndexTypeSpatial,
 	}
 	return idxInfo, nil
 }
 
+// buildSpatialIndexColumns builds the column of the SPATIAL index, which must be a single NOT NULL spatial column.
+func buildSpatialIndexColumns(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) ([]*model.IndexColumn, error) {
+	if len(indexPartSpecifications) != 1 {
+		return nil, ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Column == nil {
+		return nil, errUnsupportedIndexType.GenWithStack("SPATIAL index on expression is not supported")
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return nil, errKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if err := checkSpatialIndexColumn(col); err != nil {
+		return nil, errors.Trace(err)
+	}
+	if ip.Length != types.UnspecifiedLength {
+		return nil, errors.Trace(errIncorrectPrefixKey)
+	}
+	return []*model.IndexColumn{{
+		Name:   col.Name,
+		Offset: col.Offset,
+		Length: types.UnspecifiedLength,
+	}}, nil
+}
+
+// checkSpatialIndexColumn checks whether the column can be the column of the SPATIAL index.
+func checkSpatialIndexColumn(col *model.ColumnInfo) error {
+	if col.Tp != mysql.TypeGeometry {
+		return ErrSpatialMustHaveGeomCol.GenWithStackByArgs()
+	}
+	if !mysql.HasNotNullFlag(col.Flag) {
+		return ErrSpatialCantHaveNull.GenWithStackByArgs()
+	}
+	return nil
+}
+
+// checkSpatialIndex checks whether the SPATIAL index can be built on the table.
+func checkSpatialIndex(tblInfo *model.TableInfo) error {
+	if tblInfo.GetPartitionInfo() != nil || tblInfo.TempTableType != model.TempTableNone {
+		return ErrTableCantHandleSpkeys.GenWithStackByArgs()
+	}
+	return nil
+}
+
+func hasSpatialIndex(tblInfo *model.TableInfo) bool {
+	for _, idxInfo := range tblInfo.Indices {
+		if idxInfo.IsSpatial() {
+			return true
+		}
+	}
+	return false
+}
+
 func addIndexColumnFlag(tblInfo *model.TableInfo, indexInfo *model.IndexInfo) {
 	if indexInfo.Primary {
 		for _, col := range indexInfo.Columns {
@@ -307,6 +494,10 @@ func onRenameIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if tblInfo.TableCacheStatusType != model.TableCacheStatusDisable {
 		return ver, errors.Trace(ErrOptOnCacheTable.GenWithStackByArgs("Rename Index"))
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
 
 	idx := tblInfo.FindIndexByName(from.L)
 	idx.Name = to
@@ -332,6 +523,11 @@ func onAlterIndexVisibility(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	if err != nil || tblInfo == nil {
 		return ver, errors.Trace(err)
 	}
+	if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
 	idx := tblInfo.FindIndexByName(from.L)
 	idx.Invisible = invisible
 	if ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true); err != nil {
@@ -467,7 +663,13 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
 		}
-		indexInfo, err = buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		if indexOption != nil && indexOption.Tp == model.IndexTypeFulltext {
+			indexInfo, err = buildFulltextIndexInfo(tblInfo, indexName, indexPartSpecifications, indexOption.ParserName.L, model.StateNone)
+		} else if indexOption != nil && indexOption.Tp == model.IndexTypeSpatial {
+			indexInfo, err = buildSpatialIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		} else {
+			indexInfo, err = buildIndexInfo(tblInfo, indexName, indexPartSpecifications, model.StateNone)
+		}
 		if err != nil {
 			job.State = model.JobStateCancelled
 			return ver, errors.Trace(err)
@@ -557,39 +759,15 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 			return ver, errors.Trace(err)
 		}
 
-		elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
-		reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
-		if err != nil || reorgInfo.first {
-			// If we run reorg firstly, we should update the job snapshot version
-			// and then run the reorg next time.
-			return ver, errors.Trace(err)
+		var done bool
+		if job.MultiSchemaInfo != nil {
+			done, ver, err = w.doReorgWorkForCreateIndexMultiSchema(d, t, job, tbl, indexInfo)
+		} else {
+			done, ver, err = w.doReorgWorkForCreateIndex(d, t, job, tbl, indexInfo)
 		}
-
-		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
-			defer util.Recover(metrics.LabelDDL, "onCreateIndex",
-				func() {
-					addIndexErr = errCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
-				}, false)
-			return w.addTableIndex(tbl, indexInfo, reorgInfo)
-		})
-		if err != nil {
-			if errWaitReorgTimeout.Equal(err) {
-				// if timeout, we should return, check for the owner and re-wait job done.
-				return ver, nil
-			}
-			if kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
-				logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
-				ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
-				if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
-					logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
-				}
-			}
-			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-			w.reorgCtx.cleanNotifyReorgCancel()
-			return ver, errors.Trace(err)
+		if !done {
+			return ver, err
 		}
-		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
-		w.reorgCtx.cleanNotifyReorgCancel()
 
 		indexInfo.State = model.StatePublic
 		// Set column index flag.
@@ -612,6 +790,59 @@ func (w *worker) onCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job, isPK boo
 	return ver, errors.Trace(err)
 }
 
+func (w *worker) doReorgWorkForCreateIndexMultiSchema(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tbl table.Table, indexInfo *model.IndexInfo) (done bool, ver int64, err error) {
+	if job.MultiSchemaInfo.Revertible {
+		done, ver, err = w.doReorgWorkForCreateIndex(d, t, job, tbl, indexInfo)
+		if done {
+			// The index can't be removed after it becomes public, wait for the other sub-jobs.
+			job.MarkNonRevertible()
+		}
+		return false, ver, err
+	}
+	// The backfilling is done before the job becomes non-revertible.
+	return true, ver, nil
+}
+
+func (w *worker) doReorgWorkForCreateIndex(d *ddlCtx, t *meta.Meta, job *model.Job,
+	tbl table.Table, indexInfo *model.IndexInfo) (done bool, ver int64, err error) {
+	tblInfo := tbl.Meta()
+	elements := []*meta.Element{{ID: indexInfo.ID, TypeKey: meta.IndexElementKey}}
+	reorgInfo, err := getReorgInfo(d, t, job, tbl, elements)
+	if err != nil || reorgInfo.first {
+		// If we run reorg firstly, we should update the job snapshot version
+		// and then run the reorg next time.
+		return false, ver, errors.Trace(err)
+	}
+
+	err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (addIndexErr error) {
+		defer util.Recover(metrics.LabelDDL, "onCreateIndex",
+			func() {
+				addIndexErr = errCancelledDDLJob.GenWithStack("add table `%v` index `%v` panic", tblInfo.Name, indexInfo.Name)
+			}, false)
+		return w.addTableIndex(tbl, indexInfo, reorgInfo)
+	})
+	if err != nil {
+		if errWaitReorgTimeout.Equal(err) {
+			// if timeout, we should return, check for the owner and re-wait job done.
+			return false, ver, nil
+		}
+		if kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) {
+			logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+			ver, err = convertAddIdxJob2RollbackJob(t, job, tblInfo, indexInfo, err)
+			if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+				logutil.BgLogger().Warn("[ddl] run add index job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+			}
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+		return false, ver, errors.Trace(err)
+	}
+	// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+	w.reorgCtx.cleanNotifyReorgCancel()
+	return true, ver, nil
+}
+
 func onDropIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	tblInfo, indexInfo, err := checkDropIndex(t, job)
 	if err != nil {
@@ -631,6 +862,10 @@ func onDropIndex(t *meta.Meta, job *model.Job) (ver int64, _ error) {
 	originalState := indexInfo.State
 	switch indexInfo.State {
 	case model.StatePublic:
+		if job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible {
+			job.MarkNonRevertible()
+			return ver, nil
+		}
 		// public -> write only
 		indexInfo.State = model.StateWriteOnly
 		ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != indexInfo.State)
@@ -1591,8 +1826,12 @@ func (w *worker) updateReorgInfoForPartitions(t table.PartitionedTable, reorg *r
 			if i == len(partitionIDs)-1 {
 				return true, nil
 			}
+			pid = partitionIDs[i+1]
+			break
 		}
-		pid = partitionIDs[i+1]
+	}
+	if pid == 0 {
+		return false, errors.Errorf("partition id not found %d", reorg.PhysicalTableID)
 	}
 
 	currentVer, err := getValidCurrentVersion(reorg.d.store)
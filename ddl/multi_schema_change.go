@@ -0,0 +1,356 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+)
+
+// MultiSchemaChange submits the sub-jobs collected by the ALTER TABLE statement as one DDL job.
+func (d *ddl) MultiSchemaChange(ctx sessionctx.Context, ti ast.Ident) error {
+	info := ctx.GetSessionVars().StmtCtx.MultiSchemaInfo
+	// Clean the sub-jobs, the job below should be submitted as a normal one.
+	ctx.GetSessionVars().StmtCtx.MultiSchemaInfo = nil
+	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = checkMultiSchemaInfo(info, t); err != nil {
+		return errors.Trace(err)
+	}
+	job := &model.Job{
+		SchemaID:        schema.ID,
+		TableID:         t.Meta().ID,
+		SchemaName:      schema.Name.L,
+		Type:            model.ActionMultiSchemaChange,
+		BinlogInfo:      &model.HistoryInfo{},
+		Args:            nil,
+		MultiSchemaInfo: info,
+		ReorgMeta: &model.DDLReorgMeta{
+			SQLMode:       ctx.GetSessionVars().SQLMode,
+			Warnings:      make(map[errors.ErrorID]*terror.Error),
+			WarningsCount: make(map[errors.ErrorID]int64),
+		},
+		Priority: ctx.GetSessionVars().DDLReorgPriority,
+	}
+	err = d.doDDLJob(ctx, job)
+	err = d.callHookOnChanged(err)
+	return errors.Trace(err)
+}
+
+// appendToSubJobs collects the job as a sub-job of the multi-schema change instead of submitting it.
+func appendToSubJobs(m *model.MultiSchemaInfo, job *model.Job) error {
+	err := fillMultiSchemaInfo(m, job)
+	if err != nil {
+		return err
+	}
+	m.SubJobs = append(m.SubJobs, &model.SubJob{
+		Type:       job.Type,
+		Args:       job.Args,
+		RawArgs:    job.RawArgs,
+		Revertible: true,
+		CtxVars:    job.CtxVars,
+	})
+	return nil
+}
+
+func fillMultiSchemaInfo(info *model.MultiSchemaInfo, job *model.Job) error {
+	switch job.Type {
+	case model.ActionAddColumn:
+		col := job.Args[0].(*table.Column)
+		info.AddColumns = append(info.AddColumns, col.Name)
+		for name := range col.Dependences {
+			info.RelativeColumns = append(info.RelativeColumns, model.NewCIStr(name))
+		}
+		if pos := job.Args[1].(*ast.ColumnPosition); pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.PositionColumns = append(info.PositionColumns, pos.RelativeColumn.Name)
+		}
+	case model.ActionDropColumn:
+		colName := job.Args[0].(model.CIStr)
+		info.DropColumns = append(info.DropColumns, colName)
+	case model.ActionAddIndex, model.ActionAddPrimaryKey:
+		indexName := job.Args[1].(model.CIStr)
+		info.AddIndexes = append(info.AddIndexes, indexName)
+		indexPartSpecifications := job.Args[2].([]*ast.IndexPartSpecification)
+		for _, indexPartSpecification := range indexPartSpecifications {
+			if indexPartSpecification.Expr != nil {
+				for _, name := range findColumnNamesInExpr(indexPartSpecification.Expr) {
+					info.RelativeColumns = append(info.RelativeColumns, name.Name)
+				}
+				continue
+			}
+			info.RelativeColumns = append(info.RelativeColumns, indexPartSpecification.Column.Name)
+		}
+	case model.ActionDropIndex, model.ActionDropPrimaryKey:
+		indexName := job.Args[0].(model.CIStr)
+		info.DropIndexes = append(info.DropIndexes, indexName)
+	case model.ActionRenameIndex:
+		from := job.Args[0].(model.CIStr)
+		to := job.Args[1].(model.CIStr)
+		info.AddIndexes = append(info.AddIndexes, to)
+		info.DropIndexes = append(info.DropIndexes, from)
+	case model.ActionModifyColumn:
+		newCol := *job.Args[0].(**table.Column)
+		oldColName := job.Args[1].(model.CIStr)
+		if newCol.Name.L != oldColName.L {
+			info.AddColumns = append(info.AddColumns, newCol.Name)
+			info.DropColumns = append(info.DropColumns, oldColName)
+		} else {
+			info.ModifyColumns = append(info.ModifyColumns, newCol.Name)
+		}
+		if pos := job.Args[2].(*ast.ColumnPosition); pos != nil && pos.Tp == ast.ColumnPositionAfter {
+			info.PositionColumns = append(info.PositionColumns, pos.RelativeColumn.Name)
+		}
+	case model.ActionSetDefaultValue:
+		col := job.Args[0].(*table.Column)
+		info.ModifyColumns = append(info.ModifyColumns, col.Name)
+	case model.ActionAlterIndexVisibility:
+		indexName := job.Args[0].(model.CIStr)
+		info.AlterIndexes = append(info.AlterIndexes, indexName)
+	case model.ActionModifyTableComment, model.ActionAddCheckConstraint, model.ActionDropCheckConstraint:
+	default:
+		return errUnsupportedMultiSchemaChange.GenWithStackByArgs(job.Type.String())
+	}
+	return nil
+}
+
+// getAddedColumnsOfSubJobs returns the columns added by the collected sub-jobs.
+func getAddedColumnsOfSubJobs(info *model.MultiSchemaInfo) []*model.ColumnInfo {
+	var cols []*model.ColumnInfo
+	for _, sub := range info.SubJobs {
+		if sub.Type == model.ActionAddColumn {
+			cols = append(cols, sub.Args[0].(*table.Column).ColumnInfo)
+		}
+	}
+	return cols
+}
+
+// checkMultiSchemaInfo checks the conflicts between the sub-jobs of the multi-schema change.
+func checkMultiSchemaInfo(info *model.MultiSchemaInfo, t table.Table) error {
+	err := checkOperateSameColumn(info)
+	if err != nil {
+		return err
+	}
+	err = checkOperateSameIndex(info)
+	if err != nil {
+		return err
+	}
+	err = checkIndexesOfDroppedColumns(info, t.Meta())
+	if err != nil {
+		return err
+	}
+	err = checkAddColumnTooManyColumns(len(t.Meta().Columns) + len(info.AddColumns) - len(info.DropColumns))
+	if err != nil {
+		return err
+	}
+	return checkVisibleColumnCnt(t, len(info.AddColumns), len(info.DropColumns))
+}
+
+func checkOperateSameColumn(info *model.MultiSchemaInfo) error {
+	modifyCols := make(map[string]struct{})
+	for _, cols := range [][]model.CIStr{info.AddColumns, info.DropColumns, info.ModifyColumns} {
+		for _, col := range cols {
+			if _, ok := modifyCols[col.L]; ok {
+				return ErrOperateSameColumn.GenWithStackByArgs(col.O)
+			}
+			modifyCols[col.L] = struct{}{}
+		}
+	}
+	// The columns which the other sub-jobs rely on can't be dropped or modified.
+	for _, cols := range [][]model.CIStr{info.DropColumns, info.ModifyColumns} {
+		for _, col := range cols {
+			for _, relative := range info.RelativeColumns {
+				if col.L == relative.L {
+					return ErrOperateSameColumn.GenWithStackByArgs(col.O)
+				}
+			}
+			for _, relative := range info.PositionColumns {
+				if col.L == relative.L {
+					return ErrOperateSameColumn.GenWithStackByArgs(col.O)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkOperateSameIndex(info *model.MultiSchemaInfo) error {
+	modifyIdx := make(map[string]struct{})
+	for _, indexes := range [][]model.CIStr{info.AddIndexes, info.DropIndexes, info.AlterIndexes} {
+		for _, index := range indexes {
+			if _, ok := modifyIdx[index.L]; ok {
+				return ErrOperateSameIndex.GenWithStackByArgs(index.O)
+			}
+			modifyIdx[index.L] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// checkIndexesOfDroppedColumns checks the dropped or altered indexes aren't removed by dropping their columns.
+func checkIndexesOfDroppedColumns(info *model.MultiSchemaInfo, tblInfo *model.TableInfo) error {
+	for _, indexes := range [][]model.CIStr{info.DropIndexes, info.AlterIndexes} {
+		for _, index := range indexes {
+			idxInfo := tblInfo.FindIndexByName(index.L)
+			if idxInfo == nil {
+				continue
+			}
+			for _, idxCol := range idxInfo.Columns {
+				for _, col := range info.DropColumns {
+					if idxCol.Name.L == col.L {
+						return ErrOperateSameColumn.GenWithStackByArgs(col.O)
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func checkVisibleColumnCnt(t table.Table, addCount, dropCount int) error {
+	tblInfo := t.Meta()
+	if len(tblInfo.Columns)+addCount == dropCount {
+		return ErrCantRemoveAllFields.GenWithStack("can't drop all columns in table %s", tblInfo.Name)
+	}
+	if dropCount > addCount {
+		return checkDropVisibleColumnCnt(t, dropCount-addCount)
+	}
+	return nil
+}
+
+func onMultiSchemaChange(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	subJobs := job.MultiSchemaInfo.SubJobs
+	if job.MultiSchemaInfo.Revertible {
+		// Handle the rolling back job.
+		if job.IsRollingback() {
+			// Cancel the sub-jobs in reverse order.
+			for i := len(subJobs) - 1; i >= 0; i-- {
+				sub := subJobs[i]
+				if sub.IsFinished() {
+					continue
+				}
+				ver, err = runSubJob(w, d, t, job, sub)
+				if errCancelledDDLJob.Equal(err) {
+					// The sub-job is cancelled because of the failure of the others, keep the original error.
+					err = nil
+				}
+				break
+			}
+			if err != nil {
+				return ver, errors.Trace(err)
+			}
+			return finishMultiSchemaChange(t, job, ver)
+		}
+
+		// Run the revertible steps of the sub-jobs one by one.
+		for _, sub := range subJobs {
+			if !sub.Revertible || sub.IsFinished() {
+				continue
+			}
+			proxyJob := sub.ToProxyJob(job)
+			ver, err = w.runDDLJob(d, t, proxyJob)
+			sub.FromProxyJob(proxyJob)
+			handleRevertibleException(job, sub, proxyJob.Error)
+			if err == nil && job.IsRollingback() {
+				return finishMultiSchemaChange(t, job, ver)
+			}
+			return ver, errors.Trace(err)
+		}
+		// All the sub-jobs reach the non-revertible states, the rest steps can't be rolled back.
+		job.MarkNonRevertible()
+		return ver, nil
+	}
+
+	// Run the rest steps of the sub-jobs in order.
+	for _, sub := range subJobs {
+		if sub.IsFinished() {
+			continue
+		}
+		ver, err = runSubJob(w, d, t, job, sub)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		break
+	}
+	return finishMultiSchemaChange(t, job, ver)
+}
+
+func runSubJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, sub *model.SubJob) (ver int64, err error) {
+	proxyJob := sub.ToProxyJob(job)
+	ver, err = w.runDDLJob(d, t, proxyJob)
+	sub.FromProxyJob(proxyJob)
+	return ver, err
+}
+
+// finishMultiSchemaChange finishes the job once all of its sub-jobs are finished.
+func finishMultiSchemaChange(t *meta.Meta, job *model.Job, ver int64) (int64, error) {
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		if !sub.IsFinished() {
+			return ver, nil
+		}
+	}
+	tblInfo, err := getTableInfo(t, job.TableID, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if job.IsRollingback() {
+		job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	} else {
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	}
+	return ver, nil
+}
+
+// handleRevertibleException rolls back the whole multi-schema change job
+// when one of its revertible sub-jobs fails.
+func handleRevertibleException(job *model.Job, sub *model.SubJob, err *terror.Error) {
+	switch sub.State {
+	case model.JobStateCancelling, model.JobStateCancelled, model.JobStateRollingback, model.JobStateRollbackDone:
+	default:
+		return
+	}
+	job.State = model.JobStateRollingback
+	job.Error = err
+	cancelSubJobs(job)
+}
+
+// cancelSubJobs marks the running sub-jobs as cancelling and the unstarted sub-jobs as cancelled.
+func cancelSubJobs(job *model.Job) {
+	for _, sub := range job.MultiSchemaInfo.SubJobs {
+		switch sub.State {
+		case model.JobStateRunning:
+			sub.State = model.JobStateCancelling
+		case model.JobStateNone:
+			sub.State = model.JobStateCancelled
+		}
+	}
+}
+
+func rollingbackMultiSchemaChange(job *model.Job) (ver int64, err error) {
+	if !job.MultiSchemaInfo.Revertible {
+		// The sub-jobs can't be rolled back anymore, keep running the job.
+		job.State = model.JobStateRunning
+		return ver, nil
+	}
+	cancelSubJobs(job)
+	job.State = model.JobStateRollingback
+	return ver, errCancelledDDLJob
+}
@@ -0,0 +1,104 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl_test
+
+import (
+	. "github.com/pingcap/check"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/util/testkit"
+)
+
+var _ = Suite(&testMultiSchemaChangeSuite{&testIntegrationSuite{}})
+
+type testMultiSchemaChangeSuite struct{ *testIntegrationSuite }
+
+func (s *testMultiSchemaChangeSuite) SetUpSuite(c *C) {
+	setupIntegrationSuite(s.testIntegrationSuite, c)
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("set global tidb_enable_change_multi_schema = 1")
+}
+
+func (s *testMultiSchemaChangeSuite) TearDownTest(c *C) {
+	tearDownIntegrationSuiteTest(s.testIntegrationSuite, c)
+}
+
+func (s *testMultiSchemaChangeSuite) TestMultiSchemaChangeMixedSpecs(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c varchar(10), index i_c(c))")
+	tk.MustExec("insert into t values (1, 10, 'x'), (2, 20, 'y')")
+
+	tk.MustExec("alter table t add column d int default 5 after a, add index i_d(d), modify column b bigint, " +
+		"change column c e varchar(20), comment = 'multi'")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 5 10 x", "2 5 20 y"))
+	tk.MustQuery("select column_name, data_type from information_schema.columns where table_schema = 'test' and table_name = 't' order by ordinal_position").Check(testkit.Rows(
+		"a int", "d int", "b bigint", "e varchar"))
+	tk.MustQuery("select a from t use index(i_d) where d = 5 order by a").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a from t use index(i_c) where e = 'y'").Check(testkit.Rows("2"))
+	tk.MustQuery("select table_comment from information_schema.tables where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("multi"))
+	tk.MustExec("admin check table t")
+	c.Assert(tk.MustQuery("admin show ddl jobs 1").Rows()[0][3], Equals, "alter table multi-schema change")
+
+	// The columns of a multi-column spec are added by separated sub-jobs.
+	tk.MustExec("alter table t add column (f int, g int default 1), add index i_fg(f, g), drop index i_d")
+	tk.MustQuery("select a, f, g from t use index(i_fg) where g = 1 order by a").Check(testkit.Rows("1 <nil> 1", "2 <nil> 1"))
+	tk.MustExec("admin check table t")
+}
+
+func (s *testMultiSchemaChangeSuite) TestMultiSchemaChangeRollback(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, index i_a(a))")
+	tk.MustExec("insert into t values (1, 1), (2, 1)")
+
+	// All the sub-jobs are rolled back if the unique index can't be built.
+	tk.MustGetErrCode("alter table t add column c int default 3 first, drop index i_a, modify column a bigint, add unique index u_b(b)", errno.ErrDupEntry)
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("1 1", "2 1"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` int(11) DEFAULT NULL,\n" +
+		"  KEY `i_a` (`a`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("update t set b = a")
+	tk.MustExec("alter table t add column c int default 3 first, drop index i_a, modify column a bigint, add unique index u_b(b)")
+	tk.MustQuery("select * from t order by a").Check(testkit.Rows("3 1 1", "3 2 2"))
+	tk.MustExec("admin check table t")
+}
+
+func (s *testMultiSchemaChangeSuite) TestMultiSchemaChangeConflicts(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int, index i_a(a))")
+
+	tk.MustGetErrCode("alter table t modify column a bigint, drop column a", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t add index i_b(b), drop column b", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t add column d int after c, drop column c", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t drop index i_a, alter index i_a invisible", errno.ErrOperateSameIndex)
+	tk.MustGetErrCode("alter table t drop column a, alter index i_a invisible", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t add index i_b(b), rename index i_a to i_b", errno.ErrOperateSameIndex)
+	tk.MustGetErrCode("alter table t drop column a, drop column b, drop column c, add index i_b(b)", errno.ErrOperateSameColumn)
+	tk.MustGetErrCode("alter table t add column d int, shard_row_id_bits = 2", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("alter table t add column d int, add foreign key (a) references t(a)", errno.ErrUnsupportedDDLOperation)
+
+	// Nothing is changed by the failed statements.
+	tk.MustQuery("select column_name from information_schema.columns where table_schema = 'test' and table_name = 't' order by ordinal_position").Check(testkit.Rows(
+		"a", "b", "c"))
+	tk.MustExec("alter table t add column d int, drop column c, alter index i_a invisible")
+	tk.MustQuery("select column_name from information_schema.columns where table_schema = 'test' and table_name = 't' order by ordinal_position").Check(testkit.Rows(
+		"a", "b", "d"))
+	tk.MustQuery("select key_name, is_visible from information_schema.tidb_indexes where table_schema = 'test' and table_name = 't'").Check(testkit.Rows("i_a NO"))
+}
@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
@@ -42,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	driver "github.com/pingcap/tidb/types/parser_driver"
@@ -50,14 +52,19 @@ import (
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
+	decoder "github.com/pingcap/tidb/util/rowDecoder"
 	"github.com/pingcap/tidb/util/slice"
 	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
 
 const (
 	partitionMaxValue = "MAXVALUE"
+	// fullTablePartitionName is the name of the single partition which describes a non-partitioned table,
+	// see buildFullTablePartitionInfo.
+	fullTablePartitionName = "pFullTable"
 )
 
 func checkAddPartition(t *meta.Meta, job *model.Job) (*model.TableInfo, *model.PartitionInfo, []model.PartitionDefinition, error) {
@@ -359,26 +366,22 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	switch s.Tp {
 	case model.PartitionTypeRange:
 		// When tidb_enable_table_partition is 'on' or 'auto'.
-		if s.Sub == nil {
-			// Partition by range expression is enabled by default.
-			if s.ColumnNames == nil {
-				enable = true
-			}
-			// Partition by range columns and just one column.
-			if len(s.ColumnNames) == 1 {
-				enable = true
-			}
-		}
-	case model.PartitionTypeHash:
-		// Partition by hash is enabled by default.
-		// Note that linear hash is not enabled.
-		if !s.Linear && s.Sub == nil {
-			enable = true
-		}
+		// Partition by range expression and partition by range columns are enabled by default.
+		enable = true
+	case model.PartitionTypeHash, model.PartitionTypeKey:
+		// Partition by hash and partition by key are enabled by default.
+		// Note that linear hash, linear key and the key algorithm of MySQL 5.1 are not enabled.
+		enable = isPartitionMethodSupported(&s.PartitionMethod)
 	case model.PartitionTypeList:
 		// Partition by list is enabled only when tidb_enable_list_partition is 'ON'.
 		enable = ctx.GetSessionVars().EnableListTablePartition
 	}
+	if enable && s.Sub != nil {
+		if s.Tp != model.PartitionTypeRange && s.Tp != model.PartitionTypeList {
+			return errors.Trace(ErrSubpartition)
+		}
+		enable = isPartitionMethodSupported(s.Sub)
+	}
 
 	if !enable {
 		ctx.GetSessionVars().StmtCtx.AppendWarning(errUnsupportedCreatePartition.GenWithStack(fmt.Sprintf("Unsupported partition type %v, treat as normal table", s.Tp)))
@@ -401,6 +404,12 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 			return err
 		}
 		pi.Expr = buf.String()
+	} else if s.Tp == model.PartitionTypeKey {
+		cols, err := buildKeyPartitionColumns(tbInfo, s.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		pi.Columns = cols
 	} else if s.ColumnNames != nil {
 		pi.Columns = make([]model.CIStr, 0, len(s.ColumnNames))
 		for _, cn := range s.ColumnNames {
@@ -417,6 +426,129 @@ func buildTablePartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tb
 	}
 
 	tbInfo.Partition.Definitions = defs
+	if s.Sub != nil {
+		return errors.Trace(buildSubPartitionInfo(ctx, s, tbInfo))
+	}
+	return nil
+}
+
+// isPartitionMethodSupported checks whether the hash or key partitioning method is supported.
+func isPartitionMethodSupported(m *ast.PartitionMethod) bool {
+	if m.Linear {
+		return false
+	}
+	// ALGORITHM=1 is the key hashing of MySQL 5.1, only the default ALGORITHM=2 is supported.
+	return m.KeyAlgorithm == nil || m.KeyAlgorithm.Type == 2
+}
+
+// buildKeyPartitionColumns returns the columns of a "BY KEY" partition, the primary key
+// is used when the column list is empty.
+func buildKeyPartitionColumns(tbInfo *model.TableInfo, colNames []*ast.ColumnName) ([]model.CIStr, error) {
+	cols := make([]model.CIStr, 0, len(colNames))
+	for _, cn := range colNames {
+		cols = append(cols, cn.Name)
+	}
+	if len(cols) == 0 {
+		// Like MySQL, the primary key is used when no column is given, or the first unique key
+		// if the table has no primary key.
+		if tbInfo.PKIsHandle {
+			cols = append(cols, tbInfo.GetPkColInfo().Name)
+		} else if idx := findKeyPartitionIndex(tbInfo); idx != nil {
+			for _, idxCol := range idx.Columns {
+				cols = append(cols, idxCol.Name)
+			}
+		}
+	}
+	if len(cols) == 0 {
+		return nil, errors.Trace(ErrFieldNotFoundPart)
+	}
+	for _, col := range cols {
+		colInfo := getColumnInfoByName(tbInfo, col.L)
+		if colInfo == nil {
+			return nil, errors.Trace(ErrFieldNotFoundPart)
+		}
+		if !tables.IsKeyPartitionColumnType(&colInfo.FieldType) {
+			return nil, errors.Trace(ErrNotAllowedTypeInPartition.GenWithStackByArgs(col.O))
+		}
+	}
+	return cols, nil
+}
+
+func findKeyPartitionIndex(tbInfo *model.TableInfo) *model.IndexInfo {
+	if pkIdx := tables.FindPrimaryIndex(tbInfo); pkIdx != nil {
+		return pkIdx
+	}
+	for _, idx := range tbInfo.Indices {
+		if idx.Unique {
+			return idx
+		}
+	}
+	return nil
+}
+
+// buildSubPartitionInfo builds the subpartitions of a range or list partitioned table.
+// The partition definitions built by buildPartitionDefinitionsInfo become the logical
+// partitions, and they are replaced by the subpartitions, which are the physical ones.
+func buildSubPartitionInfo(ctx sessionctx.Context, s *ast.PartitionOptions, tbInfo *model.TableInfo) error {
+	pi := tbInfo.Partition
+	sub := &model.SubPartitionInfo{
+		Type: s.Sub.Tp,
+		Num:  s.Sub.Num,
+	}
+	if sub.Num == 0 {
+		sub.Num = 1
+	}
+	if s.Sub.Expr != nil {
+		if err := checkPartitionFuncValid(ctx, tbInfo, s.Sub.Expr); err != nil {
+			return errors.Trace(err)
+		}
+		buf := new(bytes.Buffer)
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags|format.RestoreBracketAroundBinaryOperation, buf)
+		if err := s.Sub.Expr.Restore(restoreCtx); err != nil {
+			return err
+		}
+		sub.Expr = buf.String()
+	}
+	if s.Sub.Tp == model.PartitionTypeKey {
+		cols, err := buildKeyPartitionColumns(tbInfo, s.Sub.ColumnNames)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		sub.Columns = cols
+	}
+	if err := checkAddPartitionTooManyPartitions(uint64(len(pi.Definitions)) * sub.Num); err != nil {
+		return err
+	}
+
+	sub.Parents = pi.Definitions
+	definitions := make([]model.PartitionDefinition, 0, len(pi.Definitions)*int(sub.Num))
+	for i, parent := range sub.Parents {
+		for j := 0; j < int(sub.Num); j++ {
+			def := model.PartitionDefinition{
+				Name:                model.NewCIStr(fmt.Sprintf("%ssp%d", parent.Name.O, j)),
+				LessThan:            parent.LessThan,
+				InValues:            parent.InValues,
+				PlacementPolicyRef:  parent.PlacementPolicyRef,
+				DirectPlacementOpts: parent.DirectPlacementOpts,
+				Comment:             parent.Comment,
+			}
+			if len(s.Definitions) > i && len(s.Definitions[i].Sub) > 0 {
+				subDef := s.Definitions[i].Sub[j]
+				if err := checkTooLongTable(subDef.Name); err != nil {
+					return err
+				}
+				def.Name = subDef.Name
+				for _, opt := range subDef.Options {
+					if opt.Tp == ast.TableOptionComment {
+						def.Comment = opt.StrValue
+					}
+				}
+			}
+			definitions = append(definitions, def)
+		}
+	}
+	pi.Definitions = definitions
+	pi.Sub = sub
 	return nil
 }
 
@@ -425,7 +557,7 @@ func buildPartitionDefinitionsInfo(ctx sessionctx.Context, defs []*ast.Partition
 	switch tbInfo.Partition.Type {
 	case model.PartitionTypeRange:
 		partitions, err = buildRangePartitionDefinitions(ctx, defs, tbInfo)
-	case model.PartitionTypeHash:
+	case model.PartitionTypeHash, model.PartitionTypeKey:
 		partitions, err = buildHashPartitionDefinitions(ctx, defs, tbInfo)
 	case model.PartitionTypeList:
 		partitions, err = buildListPartitionDefinitions(ctx, defs, tbInfo)
@@ -645,6 +777,10 @@ func checkPartitionValuesIsInt(ctx sessionctx.Context, def *ast.PartitionDefinit
 
 func checkPartitionNameUnique(pi *model.PartitionInfo) error {
 	newPars := pi.Definitions
+	if pi.Sub != nil {
+		// The names of partitions and subpartitions share the same namespace.
+		newPars = append(append([]model.PartitionDefinition{}, pi.Sub.Parents...), newPars...)
+	}
 	partNames := make(map[string]struct{}, len(newPars))
 	for _, newPar := range newPars {
 		if _, ok := partNames[newPar.Name.L]; ok {
@@ -684,8 +820,8 @@ func checkAndOverridePartitionID(newTableInfo, oldTableInfo *model.TableInfo) er
 	if newTableInfo.Partition.Type != oldTableInfo.Partition.Type {
 		return ErrRepairTableFail.GenWithStackByArgs("Partition type should be the same")
 	}
-	// Check whether partitionType is hash partition.
-	if newTableInfo.Partition.Type == model.PartitionTypeHash {
+	// Check whether partitionType is hash or key partition.
+	if newTableInfo.Partition.Type == model.PartitionTypeHash || newTableInfo.Partition.Type == model.PartitionTypeKey {
 		if newTableInfo.Partition.Num != oldTableInfo.Partition.Num {
 			return ErrRepairTableFail.GenWithStackByArgs("Hash partition num should be the same")
 		}
@@ -943,6 +1079,35 @@ func checkDropTablePartition(meta *model.TableInfo, partLowerNames []string) err
 	return nil
 }
 
+// getReorganizedPartitionRange returns the offsets of the first and the last reorganized partitions in the partition definitions.
+// The reorganized partitions must exist and be consecutive.
+func getReorganizedPartitionRange(tblInfo *model.TableInfo, partLowerNames []string) (first, last int, _ error) {
+	first, last = -1, -1
+	for _, pn := range partLowerNames {
+		idx := -1
+		for i, def := range tblInfo.Partition.Definitions {
+			if def.Name.L == pn {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return 0, 0, errors.Trace(ErrDropPartitionNonExistent.GenWithStackByArgs("REORGANIZE PARTITION"))
+		}
+		if first == -1 || idx < first {
+			first = idx
+		}
+		if idx > last {
+			last = idx
+		}
+	}
+	if first == -1 || last-first+1 != len(partLowerNames) {
+		// Either some partitions are missing in the middle, or some names are duplicated.
+		return 0, 0, errors.Trace(ErrConsecutiveReorgPartitions)
+	}
+	return first, last, nil
+}
+
 // updateDroppingPartitionInfo move dropping partitions to DroppingDefinitions, and return partitionIDs
 func updateDroppingPartitionInfo(tblInfo *model.TableInfo, partLowerNames []string) []int64 {
 	oldDefs := tblInfo.Partition.Definitions
@@ -1145,6 +1310,531 @@ func (w *worker) onDropTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (
 	return ver, errors.Trace(err)
 }
 
+// getTableInfoWithReorganizedPartitions builds the tableInfo as it will be after the partitions are reorganized,
+// only used by onReorganizePartition.
+func getTableInfoWithReorganizedPartitions(t *model.TableInfo) *model.TableInfo {
+	p := t.Partition
+	nt := t.Clone()
+	np := *p
+	np.Definitions = tables.ReplacePartitionDefinitions(p.Definitions, p.DroppingDefinitions, p.AddingDefinitions)
+	tables.SwapPartitionScheme(&np)
+	np.AddingDefinitions = nil
+	np.DroppingDefinitions = nil
+	np.DDLAction = model.ActionNone
+	np.DDLState = model.StateNone
+	nt.Partition = &np
+	return nt
+}
+
+// buildFullTablePartitionInfo describes a non-partitioned table as a table with a single hash partition,
+// whose physical ID is the table ID, so the records of the table can be reorganized like the ones of a partition.
+func buildFullTablePartitionInfo(physicalID int64) *model.PartitionInfo {
+	return &model.PartitionInfo{
+		Type:        model.PartitionTypeHash,
+		Expr:        "0",
+		Enable:      true,
+		Num:         1,
+		Definitions: []model.PartitionDefinition{{ID: physicalID, Name: model.NewCIStr(fullTablePartitionName)}},
+	}
+}
+
+// isFullTablePartitionInfo returns whether the partition info is built by buildFullTablePartitionInfo for the table.
+func isFullTablePartitionInfo(tblInfo *model.TableInfo) bool {
+	pi := tblInfo.Partition
+	return pi != nil && len(pi.Definitions) == 1 && pi.Definitions[0].ID == tblInfo.ID
+}
+
+// onReorganizePartition reorganizes the consecutive partitions into the new partition definitions.
+// The new partitions are added as the AddingDefinitions, and the reorganized ones are kept as the DroppingDefinitions.
+// The DML keeps both sides in sync while the records are copied in the write reorganization state,
+// then the partition definitions are switched and the old partitions are dropped.
+// ALTER TABLE ... PARTITION BY and REMOVE PARTITIONING are handled in the same way, all the partitions are
+// reorganized into the partitions of the new partitioning scheme.
+func (w *worker) onReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var partNames []string
+	partInfo := &model.PartitionInfo{}
+	if err := job.DecodeArgs(&partNames, &partInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if job.Type == model.ActionAlterTablePartitioning && job.SchemaState == model.StateNone && tblInfo.Partition == nil {
+		tblInfo.Partition = buildFullTablePartitionInfo(tblInfo.ID)
+	}
+	pi := tblInfo.GetPartitionInfo()
+	if pi == nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(ErrPartitionMgmtOnNonpartitioned)
+	}
+	if job.IsRollingback() {
+		return w.rollbackReorganizePartition(d, t, job, tblInfo)
+	}
+
+	switch job.SchemaState {
+	case model.StateNone:
+		first, last := 0, len(pi.Definitions)-1
+		if job.Type == model.ActionReorganizePartition {
+			first, last, err = getReorganizedPartitionRange(tblInfo, partNames)
+			if err != nil {
+				job.State = model.JobStateCancelled
+				return ver, errors.Trace(err)
+			}
+		}
+		newDefs := tables.ReplacePartitionDefinitions(pi.Definitions, pi.Definitions[first:last+1], partInfo.Definitions)
+		err = checkAddPartitionTooManyPartitions(uint64(len(newDefs)))
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		err = checkPartitionNameUnique(&model.PartitionInfo{Definitions: newDefs})
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+
+		// modify placement settings
+		for _, def := range partInfo.Definitions {
+			if _, err = checkPlacementPolicyRefValidAndCanNonValidJob(t, job, def.PlacementPolicyRef); err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		bundles, err := alterTablePartitionBundles(t, tblInfo, partInfo.Definitions)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+		if err = infosync.PutRuleBundles(context.TODO(), bundles); err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+		}
+
+		updateAddingPartitionInfo(partInfo, tblInfo)
+		pi.DroppingDefinitions = make([]model.PartitionDefinition, 0, last-first+1)
+		pi.DroppingDefinitions = append(pi.DroppingDefinitions, pi.Definitions[first:last+1]...)
+		pi.DDLAction = job.Type
+		if job.Type != model.ActionReorganizePartition {
+			pi.DDLType, pi.DDLExpr, pi.DDLColumns = partInfo.Type, partInfo.Expr, partInfo.Columns
+		}
+		// none -> delete only
+		pi.DDLState = model.StateDeleteOnly
+		job.SchemaState = model.StateDeleteOnly
+		ver, err = updateVersionAndTableInfoWithCheck(t, job, tblInfo, true)
+	case model.StateDeleteOnly:
+		// delete only -> write only
+		pi.DDLState = model.StateWriteOnly
+		job.SchemaState = model.StateWriteOnly
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	case model.StateWriteOnly:
+		// write only -> reorganization
+		pi.DDLState = model.StateWriteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Initialize SnapshotVer to 0 for later reorganization check.
+		job.SnapshotVer = 0
+		job.SchemaState = model.StateWriteReorganization
+	case model.StateWriteReorganization:
+		// reorganization -> delete reorganization
+		tbl, err := getTable(d.store, job.SchemaID, tblInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		elements := []*meta.Element{{ID: tblInfo.ID, TypeKey: meta.TableElementKey}}
+		reorgInfo, err := getReorgInfoFromPartitions(d, t, job, tbl, physicalTableIDs, elements)
+		if err != nil || reorgInfo.first {
+			// If we run reorg firstly, we should update the job snapshot version
+			// and then run the reorg next time.
+			return ver, errors.Trace(err)
+		}
+		err = w.runReorgJob(t, reorgInfo, tbl.Meta(), d.lease, func() (reorgErr error) {
+			defer tidbutil.Recover(metrics.LabelDDL, "onReorganizePartition",
+				func() {
+					reorgErr = errCancelledDDLJob.GenWithStack("reorganize table `%v` partition panic", tblInfo.Name)
+				}, false)
+			return w.reorgPartitionRecords(tbl.(table.PartitionedTable), physicalTableIDs, reorgInfo)
+		})
+		if err != nil {
+			if errWaitReorgTimeout.Equal(err) {
+				// if timeout, we should return, check for the owner and re-wait job done.
+				return ver, nil
+			}
+			if kv.ErrKeyExists.Equal(err) || errCancelledDDLJob.Equal(err) || errCantDecodeRecord.Equal(err) ||
+				table.ErrNoPartitionForGivenValue.Equal(err) {
+				logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback", zap.String("job", job.String()), zap.Error(err))
+				ver, err = convertReorgPartitionJob2RollbackJob(t, job, tblInfo, err)
+				if err1 := t.RemoveDDLReorgHandle(job, reorgInfo.elements); err1 != nil {
+					logutil.BgLogger().Warn("[ddl] run reorganize partition job failed, convert job to rollback, RemoveDDLReorgHandle failed", zap.String("job", job.String()), zap.Error(err1))
+				}
+			}
+			// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+			w.reorgCtx.cleanNotifyReorgCancel()
+			return ver, errors.Trace(err)
+		}
+		// Clean up the channel of notifyCancelReorgJob. Make sure it can't affect other jobs.
+		w.reorgCtx.cleanNotifyReorgCancel()
+
+		// Switch the partition definitions. The dropping partitions are still maintained by the DML,
+		// because the TiDB servers on the previous schema version may read them.
+		pi.Definitions = tables.ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+		tables.SwapPartitionScheme(pi)
+		pi.DDLState = model.StateDeleteReorganization
+		job.SchemaState = model.StateDeleteReorganization
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	case model.StateDeleteReorganization:
+		// delete reorganization -> public
+		physicalTableIDs := getPartitionIDsFromDefinitions(pi.DroppingDefinitions)
+		droppedNames := make([]string, 0, len(pi.DroppingDefinitions))
+		for _, def := range pi.DroppingDefinitions {
+			if _, _, err := getPartitionDef(tblInfo, def.Name.L); err != nil {
+				droppedNames = append(droppedNames, def.Name.L)
+			}
+		}
+		err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, droppedNames)
+		if err != nil {
+			return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+		}
+		pi.AddingDefinitions = nil
+		pi.DroppingDefinitions = nil
+		pi.DDLAction = model.ActionNone
+		pi.DDLState = model.StateNone
+		pi.DDLType, pi.DDLExpr, pi.DDLColumns = 0, "", nil
+		// used by ApplyDiff in updateSchemaVersion
+		job.CtxVars = []interface{}{physicalTableIDs}
+		if job.Type == model.ActionRemovePartitioning {
+			if err = removeTablePartitioning(t, job, tblInfo); err != nil {
+				return ver, errors.Trace(err)
+			}
+		}
+		ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+		asyncNotifyEvent(d, &util.Event{Tp: job.Type, TableInfo: tblInfo, PartInfo: partInfo})
+		// A background job will be created to delete old partition data.
+		job.Args = []interface{}{physicalTableIDs}
+	default:
+		err = ErrInvalidDDLState.GenWithStackByArgs("partition", job.SchemaState)
+	}
+	return ver, errors.Trace(err)
+}
+
+// removeTablePartitioning turns the table into a non-partitioned table. The records are already reorganized
+// into the single partition, so its physical ID becomes the new table ID, and the table meta is recreated
+// with the new ID like TRUNCATE TABLE.
+func removeTablePartitioning(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) error {
+	oldTableID, newTableID := tblInfo.ID, tblInfo.Partition.Definitions[0].ID
+	autoIDs, err := t.GetAutoIDAccessors(job.SchemaID, oldTableID).Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = t.DropTableOrView(job.SchemaID, oldTableID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = t.GetAutoIDAccessors(job.SchemaID, oldTableID).Del()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	tblInfo.Partition = nil
+	tableRuleID, partRuleIDs, _, oldRules, err := getOldLabelRules(tblInfo, job.SchemaName, tblInfo.Name.L)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get old label rules from PD")
+	}
+	err = updateLabelRules(job, tblInfo, oldRules, tableRuleID, partRuleIDs, []string{}, newTableID)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the label rule to PD")
+	}
+
+	tblInfo.ID = newTableID
+	bundles, err := placement.NewFullTableBundles(t, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if err = infosync.PutRuleBundles(context.TODO(), bundles); err != nil {
+		return errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	err = t.CreateTableOrView(job.SchemaID, tblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	err = t.GetAutoIDAccessors(job.SchemaID, newTableID).Put(autoIDs)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	// used by ApplyDiff in updateSchemaVersion
+	job.CtxVars = append(job.CtxVars, newTableID)
+	return nil
+}
+
+// rollbackReorganizePartition removes the adding partitions of a rolling back reorganize partition job.
+func (w *worker) rollbackReorganizePartition(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo) (ver int64, err error) {
+	pi := tblInfo.Partition
+	if pi.DDLState != model.StateDeleteOnly {
+		return convertReorgPartitionJob2RollbackJob(t, job, tblInfo, nil)
+	}
+	addingNames := make([]string, 0, len(pi.AddingDefinitions))
+	for _, def := range pi.AddingDefinitions {
+		if _, _, err := getPartitionDef(tblInfo, def.Name.L); err != nil {
+			addingNames = append(addingNames, def.Name.L)
+		}
+	}
+	physicalTableIDs, _, rollbackBundles := rollbackAddingPartitionInfo(tblInfo)
+	err = infosync.PutRuleBundles(context.TODO(), rollbackBundles)
+	if err != nil {
+		return ver, errors.Wrapf(err, "failed to notify PD the placement rules")
+	}
+	err = dropLabelRules(d, job.SchemaName, tblInfo.Name.L, addingNames)
+	if err != nil {
+		return ver, errors.Wrapf(err, "failed to notify PD the label rules")
+	}
+	pi.DroppingDefinitions = nil
+	pi.DDLAction = model.ActionNone
+	pi.DDLState = model.StateNone
+	pi.DDLType, pi.DDLExpr, pi.DDLColumns = 0, "", nil
+	if job.Type == model.ActionAlterTablePartitioning && isFullTablePartitionInfo(tblInfo) {
+		tblInfo.Partition = nil
+	}
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateRollbackDone, model.StateNone, ver, tblInfo)
+	job.Args = []interface{}{physicalTableIDs}
+	return ver, nil
+}
+
+// reorgPartitionRecords handles the write reorganization state of reorganize partition,
+// it copies the records of the reorganized partitions into the new partitions one by one.
+func (w *worker) reorgPartitionRecords(tbl table.PartitionedTable, partitionIDs []int64, reorgInfo *reorgInfo) error {
+	var err error
+	var finish bool
+	for !finish {
+		p := tbl.GetPartition(reorgInfo.PhysicalTableID)
+		if p == nil {
+			return errCancelledDDLJob.GenWithStack("Can not find partition id %d for table %d", reorgInfo.PhysicalTableID, tbl.Meta().ID)
+		}
+		logutil.BgLogger().Info("[ddl] start to reorganize partition records", zap.String("job", reorgInfo.Job.String()), zap.String("reorgInfo", reorgInfo.String()))
+		err = w.writePhysicalTableRecord(p, typeReorgPartitionWorker, nil, nil, nil, reorgInfo)
+		if err != nil {
+			break
+		}
+		finish, err = w.updateReorgInfoForPartitions(tbl, reorgInfo, partitionIDs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return errors.Trace(err)
+}
+
+type reorgPartitionRecord struct {
+	key    []byte        // It's the record key in the new partition.
+	oldKey []byte        // It's used to lock the record in the reorganized partition.
+	vals   []byte        // It's the raw record, it is copied as is.
+	handle kv.Handle     // It's the handle of the record, it is kept in the new partition.
+	row    []types.Datum // It's used to build the index values.
+	pid    int64         // It's the ID of the new partition.
+}
+
+type reorgPartitionWorker struct {
+	*backfillWorker
+	metricCounter prometheus.Counter
+
+	// reorgedTbl is the table with the reorganized partition definitions, it locates the records.
+	reorgedTbl table.PartitionedTable
+
+	// The following attributes are used to reduce memory allocation.
+	rowRecords  []*reorgPartitionRecord
+	rowDecoder  *decoder.RowDecoder
+	rowMap      map[int64]types.Datum
+	defaultVals []types.Datum
+}
+
+func newReorgPartitionWorker(sessCtx sessionctx.Context, worker *worker, id int, t table.PhysicalTable, decodeColMap map[int64]decoder.Column) (*reorgPartitionWorker, error) {
+	reorgedTbl, err := tables.TableFromMeta(nil, getTableInfoWithReorganizedPartitions(t.Meta()))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	// The records are decoded in UTC, so the partition expressions and the default values are evaluated in UTC too.
+	sessCtx.GetSessionVars().TimeZone = time.UTC
+	return &reorgPartitionWorker{
+		backfillWorker: newBackfillWorker(sessCtx, worker, id, t),
+		metricCounter:  metrics.BackfillTotalCounter.WithLabelValues("reorg_partition_speed"),
+		reorgedTbl:     reorgedTbl.(table.PartitionedTable),
+		rowDecoder:     decoder.NewRowDecoder(t, t.WritableCols(), decodeColMap),
+		rowMap:         make(map[int64]types.Datum, len(decodeColMap)),
+		defaultVals:    make([]types.Datum, len(t.WritableCols())),
+	}, nil
+}
+
+func (w *reorgPartitionWorker) AddMetricInfo(cnt float64) {
+	w.metricCounter.Add(cnt)
+}
+
+// getNextKey gets next handle of entry that we are going to process.
+func (w *reorgPartitionWorker) getNextKey(taskRange reorgBackfillTask, taskDone bool, lastAccessedHandle kv.Key) (nextHandle kv.Key) {
+	if !taskDone {
+		// The task is not done. So we need to pick the last processed entry's handle and add one.
+		return lastAccessedHandle.Next()
+	}
+	return taskRange.endKey.Next()
+}
+
+func (w *reorgPartitionWorker) fetchRowColVals(txn kv.Transaction, taskRange reorgBackfillTask) ([]*reorgPartitionRecord, kv.Key, bool, error) {
+	w.rowRecords = w.rowRecords[:0]
+	startTime := time.Now()
+
+	// taskDone means that the added handle is out of taskRange.endHandle.
+	taskDone := false
+	var lastAccessedHandle kv.Key
+	oprStartTime := startTime
+	err := iterateSnapshotRows(w.sessCtx.GetStore(), w.priority, w.table, txn.StartTS(), taskRange.startKey, taskRange.endKey,
+		func(handle kv.Handle, recordKey kv.Key, rawRow []byte) (bool, error) {
+			oprEndTime := time.Now()
+			logSlowOperations(oprEndTime.Sub(oprStartTime), "iterateSnapshotRows in reorgPartitionWorker fetchRowColVals", 0)
+			oprStartTime = oprEndTime
+
+			taskDone = recordKey.Cmp(taskRange.endKey) > 0
+
+			if taskDone || len(w.rowRecords) >= w.batchCnt {
+				return false, nil
+			}
+
+			if err1 := w.getRowRecord(handle, recordKey, rawRow); err1 != nil {
+				return false, errors.Trace(err1)
+			}
+			lastAccessedHandle = recordKey
+			if recordKey.Cmp(taskRange.endKey) == 0 {
+				// If taskRange.endIncluded == false, we will not reach here when handle == taskRange.endHandle.
+				taskDone = true
+				return false, nil
+			}
+			return true, nil
+		})
+
+	if len(w.rowRecords) == 0 {
+		taskDone = true
+	}
+
+	logutil.BgLogger().Debug("[ddl] txn fetches handle info", zap.Uint64("txnStartTS", txn.StartTS()), zap.String("taskRange", taskRange.String()), zap.Duration("takeTime", time.Since(startTime)))
+	return w.rowRecords, w.getNextKey(taskRange, taskDone, lastAccessedHandle), taskDone, errors.Trace(err)
+}
+
+func (w *reorgPartitionWorker) getRowRecord(handle kv.Handle, recordKey []byte, rawRow []byte) error {
+	_, err := w.rowDecoder.DecodeAndEvalRowWithMap(w.sessCtx, handle, rawRow, time.UTC, time.UTC, w.rowMap)
+	if err != nil {
+		return errors.Trace(errCantDecodeRecord.GenWithStackByArgs("partition", err))
+	}
+
+	cols := w.table.WritableCols()
+	row := make([]types.Datum, len(cols))
+	for i, col := range cols {
+		val, ok := w.rowMap[col.ID]
+		if !ok {
+			val, err = tables.GetColDefaultValue(w.sessCtx, col, w.defaultVals)
+			if err != nil {
+				return errors.Trace(err)
+			}
+		}
+		row[i] = val
+	}
+	w.cleanRowMap()
+
+	p, err := w.reorgedTbl.GetPartitionByRow(w.sessCtx, row)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	w.rowRecords = append(w.rowRecords, &reorgPartitionRecord{
+		key:    tablecodec.EncodeRecordKey(p.RecordPrefix(), handle),
+		oldKey: recordKey,
+		vals:   rawRow,
+		handle: handle,
+		row:    row,
+		pid:    p.GetPhysicalID(),
+	})
+	return nil
+}
+
+func (w *reorgPartitionWorker) cleanRowMap() {
+	for id := range w.rowMap {
+		delete(w.rowMap, id)
+	}
+}
+
+// BackfillDataInTxn will copy the records into the new partitions in a transaction, and lock the records in the
+// reorganized partition, in case that the value of them are changed.
+func (w *reorgPartitionWorker) BackfillDataInTxn(handleRange reorgBackfillTask) (taskCtx backfillTaskContext, errInTxn error) {
+	oprStartTime := time.Now()
+	errInTxn = kv.RunInNewTxn(context.Background(), w.sessCtx.GetStore(), true, func(ctx context.Context, txn kv.Transaction) error {
+		taskCtx.addedCount = 0
+		taskCtx.scanCount = 0
+		txn.SetOption(kv.Priority, w.priority)
+
+		rowRecords, nextKey, taskDone, err := w.fetchRowColVals(txn, handleRange)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		taskCtx.nextKey = nextKey
+		taskCtx.done = taskDone
+
+		keys := make([]kv.Key, 0, len(rowRecords))
+		for _, record := range rowRecords {
+			keys = append(keys, record.key)
+		}
+		existedRecords, err := txn.BatchGet(ctx, keys)
+		if err != nil {
+			return errors.Trace(err)
+		}
+
+		for _, record := range rowRecords {
+			taskCtx.scanCount++
+			// The record is already written into the new partition by the DML, skip it.
+			if _, ok := existedRecords[string(record.key)]; ok {
+				continue
+			}
+
+			// Lock the record key to notify us that someone delete or update the record,
+			// then we should not copy it, otherwise the copied record is stale.
+			err = txn.LockKeys(context.Background(), new(kv.LockCtx), record.oldKey)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			err = txn.Set(record.key, record.vals)
+			if err != nil {
+				return errors.Trace(err)
+			}
+
+			p := w.reorgedTbl.GetPartition(record.pid)
+			for _, index := range p.Indices() {
+				idxInfo := index.Meta()
+				if idxInfo.Global || (p.Meta().IsCommonHandle && idxInfo.Primary) {
+					continue
+				}
+				idxVals := make([]types.Datum, 0, len(idxInfo.Columns))
+				for _, col := range idxInfo.Columns {
+					idxVals = append(idxVals, record.row[col.Offset])
+				}
+				rsData := tables.TryGetHandleRestoredDataWrapper(p, record.row, nil, idxInfo)
+				_, err = index.Create(w.sessCtx, txn, idxVals, record.handle, rsData)
+				if err != nil {
+					return errors.Trace(err)
+				}
+			}
+			taskCtx.addedCount++
+		}
+		return nil
+	})
+	logSlowOperations(time.Since(oprStartTime), "ReorgPartitionBackfillDataInTxn", 3000)
+
+	return
+}
+
 // onTruncateTablePartition truncates old partition meta.
 func onTruncateTablePartition(d *ddlCtx, t *meta.Meta, job *model.Job) (int64, error) {
 	var ver int64
@@ -1683,27 +2373,17 @@ func getPartitionRuleIDs(dbName string, table *model.TableInfo) []string {
 }
 
 // checkPartitioningKeysConstraints checks that the range partitioning key is included in the table constraint.
-func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTableStmt, tblInfo *model.TableInfo) error {
+func checkPartitioningKeysConstraints(tblInfo *model.TableInfo) error {
 	// Returns directly if there are no unique keys in the table.
 	if len(tblInfo.Indices) == 0 && !tblInfo.PKIsHandle {
 		return nil
 	}
 
-	var partCols stringSlice
-	if s.Partition.Expr != nil {
-		extractCols := newPartitionExprChecker(sctx, tblInfo)
-		s.Partition.Expr.Accept(extractCols)
-		partColumns, err := extractCols.columns, extractCols.err
-		if err != nil {
-			return err
-		}
-		partCols = columnInfoSlice(partColumns)
-	} else if len(s.Partition.ColumnNames) > 0 {
-		partCols = columnNameSlice(s.Partition.ColumnNames)
-	} else {
-		// TODO: Check keys constraints for list, key partition type and so on.
-		return nil
+	partColumns, err := extractPartitionInfoColumns(tblInfo.Partition, tblInfo)
+	if err != nil {
+		return err
 	}
+	partCols := columnInfoSlice(partColumns)
 
 	// Checks that the partitioning key is included in the constraint.
 	// Every unique key on the table must use every column in the table's partitioning expression.
@@ -1732,27 +2412,9 @@ func checkPartitioningKeysConstraints(sctx sessionctx.Context, s *ast.CreateTabl
 }
 
 func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model.IndexColumn, tblInfo *model.TableInfo) (bool, error) {
-	var (
-		partCols []*model.ColumnInfo
-		err      error
-	)
-	// The expr will be an empty string if the partition is defined by:
-	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
-	if partExpr := pi.Expr; partExpr != "" {
-		// Parse partitioning key, extract the column names in the partitioning key to slice.
-		partCols, err = extractPartitionColumns(partExpr, tblInfo)
-		if err != nil {
-			return false, err
-		}
-	} else {
-		partCols = make([]*model.ColumnInfo, 0, len(pi.Columns))
-		for _, col := range pi.Columns {
-			colInfo := getColumnInfoByName(tblInfo, col.L)
-			if colInfo == nil {
-				return false, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
-			}
-			partCols = append(partCols, colInfo)
-		}
+	partCols, err := extractPartitionInfoColumns(pi, tblInfo)
+	if err != nil {
+		return false, err
 	}
 
 	// In MySQL, every unique key on the table must use every column in the table's partitioning expression.(This
@@ -1762,6 +2424,38 @@ func checkPartitionKeysConstraint(pi *model.PartitionInfo, indexColumns []*model
 	return checkUniqueKeyIncludePartKey(columnInfoSlice(partCols), indexColumns), nil
 }
 
+// extractPartitionInfoColumns extracts the columns used by the partitioning expression, or the partitioning
+// columns, of the table. The columns used by the subpartitioning are included too.
+func extractPartitionInfoColumns(pi *model.PartitionInfo, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	partCols, err := extractPartitionMethodColumns(pi.Expr, pi.Columns, tblInfo)
+	if err != nil || pi.Sub == nil {
+		return partCols, err
+	}
+	subCols, err := extractPartitionMethodColumns(pi.Sub.Expr, pi.Sub.Columns, tblInfo)
+	if err != nil {
+		return nil, err
+	}
+	return append(partCols, subCols...), nil
+}
+
+func extractPartitionMethodColumns(partExpr string, columns []model.CIStr, tblInfo *model.TableInfo) ([]*model.ColumnInfo, error) {
+	// The expr will be an empty string if the partition is defined by:
+	// CREATE TABLE t (...) PARTITION BY RANGE COLUMNS(...)
+	if partExpr != "" {
+		// Parse partitioning key, extract the column names in the partitioning key to slice.
+		return extractPartitionColumns(partExpr, tblInfo)
+	}
+	partCols := make([]*model.ColumnInfo, 0, len(columns))
+	for _, col := range columns {
+		colInfo := getColumnInfoByName(tblInfo, col.L)
+		if colInfo == nil {
+			return nil, infoschema.ErrColumnNotExists.GenWithStackByArgs(col, tblInfo.Name)
+		}
+		partCols = append(partCols, colInfo)
+	}
+	return partCols, nil
+}
+
 type columnNameExtractor struct {
 	extractedColumns []*model.ColumnInfo
 	tblInfo          *model.TableInfo
@@ -1849,17 +2543,6 @@ func (cis columnInfoSlice) At(i int) string {
 	return cis[i].Name.L
 }
 
-// columnNameSlice implements the stringSlice interface.
-type columnNameSlice []*ast.ColumnName
-
-func (cns columnNameSlice) Len() int {
-	return len(cns)
-}
-
-func (cns columnNameSlice) At(i int) string {
-	return cns[i].Name.L
-}
-
 // isColUnsigned returns true if the partitioning key column is unsigned.
 func isColUnsigned(cols []*model.ColumnInfo, pi *model.PartitionInfo) bool {
 	for _, col := range cols {
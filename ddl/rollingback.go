@@ -110,6 +110,11 @@ func convertNotStartAddIdxJob2RollbackJob(t *meta.Meta, job *model.Job, occuredE
 // normal-type has only two states:    None -> Public
 // reorg-type has five states:         None -> Delete-only -> Write-only -> Write-org -> Public
 func rollingbackModifyColumn(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible && job.SchemaState == model.StateWriteReorganization {
+		// The reorganization of the sub-job is done, the other sub-job of the multi-schema change fails.
+		job.State = model.JobStateRollingback
+		return ver, errCancelledDDLJob
+	}
 	// If the value of SnapshotVer isn't zero, it means the reorg workers have been started.
 	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
 		// column type change workers are started. we have to ask them to exit.
@@ -174,6 +179,21 @@ func rollingbackAddColumn(t *meta.Meta, job *model.Job) (ver int64, err error) {
 	return ver, errCancelledDDLJob
 }
 
+// convertAddColumnJob2RollbackJob converts the add column job that fails to backfill a stored generated column
+// to a rollingback job. Its work is the same as drop column job do.
+func convertAddColumnJob2RollbackJob(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, colInfo *model.ColumnInfo, err error) (int64, error) {
+	job.Args = []interface{}{colInfo.Name}
+	originalState := colInfo.State
+	colInfo.State = model.StateDeleteOnly
+	job.SchemaState = model.StateDeleteOnly
+	ver, err1 := updateVersionAndTableInfo(t, job, tblInfo, originalState != colInfo.State)
+	if err1 != nil {
+		return ver, errors.Trace(err1)
+	}
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(err)
+}
+
 func rollingbackAddColumns(t *meta.Meta, job *model.Job) (ver int64, err error) {
 	tblInfo, columnInfos, _, _, _, _, err := checkAddColumns(t, job)
 	if err != nil {
@@ -324,6 +344,10 @@ func rollingbackDropIndexes(t *meta.Meta, job *model.Job) (ver int64, err error)
 }
 
 func rollingbackAddIndex(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job, isPK bool) (ver int64, err error) {
+	if job.MultiSchemaInfo != nil && !job.MultiSchemaInfo.Revertible {
+		// The backfilling of the sub-job is done, the other sub-job of the multi-schema change fails.
+		return convertNotStartAddIdxJob2RollbackJob(t, job, errCancelledDDLJob)
+	}
 	// If the value of SnapshotVer isn't zero, it means the work is backfilling the indexes.
 	if job.SchemaState == model.StateWriteReorganization && job.SnapshotVer != 0 {
 		// add index workers are started. need to ask them to exit.
@@ -366,6 +390,45 @@ func rollingbackAddTablePartition(t *meta.Meta, job *model.Job) (ver int64, err
 	return convertAddTablePartitionJob2RollbackJob(t, job, errCancelledDDLJob, tblInfo)
 }
 
+func convertReorgPartitionJob2RollbackJob(t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, otherwiseErr error) (ver int64, err error) {
+	// The adding partitions need to be removed from the DML path before they are dropped,
+	// so the next state is delete only state.
+	pi := tblInfo.Partition
+	originalState := pi.DDLState
+	pi.DDLState = model.StateDeleteOnly
+	job.SchemaState = model.StateDeleteOnly
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, originalState != pi.DDLState)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.State = model.JobStateRollingback
+	return ver, errors.Trace(otherwiseErr)
+}
+
+func rollingbackReorganizePartition(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	switch job.SchemaState {
+	case model.StateNone:
+		job.State = model.JobStateCancelled
+		return ver, errCancelledDDLJob
+	case model.StateDeleteReorganization:
+		// The partition definitions are already switched, the job can't be rolled back.
+		job.State = model.JobStateRunning
+		return ver, nil
+	case model.StateWriteReorganization:
+		// If the value of SnapshotVer isn't zero, it means the work is backfilling the records.
+		if job.SnapshotVer != 0 {
+			logutil.Logger(w.logCtx).Info("[ddl] run the cancelling DDL job", zap.String("job", job.String()))
+			w.reorgCtx.notifyReorgCancel()
+			return w.onReorganizePartition(d, t, job)
+		}
+	}
+	return convertReorgPartitionJob2RollbackJob(t, job, tblInfo, errCancelledDDLJob)
+}
+
 func rollingbackDropTableOrView(t *meta.Meta, job *model.Job) error {
 	tblInfo, err := checkTableExistAndCancelNonExistJob(t, job, job.SchemaID)
 	if err != nil {
@@ -419,6 +482,16 @@ func rollingbackRenameIndex(t *meta.Meta, job *model.Job) (ver int64, err error)
 	return ver, errors.Trace(err)
 }
 
+func rollingbackAddCheckConstraint(t *meta.Meta, job *model.Job) (ver int64, err error) {
+	_, err = getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// The adding constraint is removed by onAddCheckConstraint in the rolling back state.
+	job.State = model.JobStateRollingback
+	return ver, errCancelledDDLJob
+}
+
 func cancelOnlyNotHandledJob(job *model.Job) (ver int64, err error) {
 	// We can only cancel the not handled job.
 	if job.SchemaState == model.StateNone {
@@ -451,6 +524,8 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackAddIndex(w, d, t, job, true)
 	case model.ActionAddTablePartition:
 		ver, err = rollingbackAddTablePartition(t, job)
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		ver, err = rollingbackReorganizePartition(w, d, t, job)
 	case model.ActionDropColumn:
 		ver, err = rollingbackDropColumn(t, job)
 	case model.ActionDropColumns:
@@ -471,12 +546,17 @@ func convertJob2RollbackJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job)
 		ver, err = rollingbackTruncateTable(t, job)
 	case model.ActionModifyColumn:
 		ver, err = rollingbackModifyColumn(w, d, t, job)
+	case model.ActionAddCheckConstraint:
+		ver, err = rollingbackAddCheckConstraint(t, job)
+	case model.ActionMultiSchemaChange:
+		ver, err = rollingbackMultiSchemaChange(job)
 	case model.ActionRebaseAutoID, model.ActionShardRowID, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable, model.ActionRenameTables,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
 		model.ActionModifyTableAutoIdCache, model.ActionAlterIndexVisibility,
-		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement:
+		model.ActionExchangeTablePartition, model.ActionModifySchemaDefaultPlacement,
+		model.ActionDropCheckConstraint, model.ActionAlterCheckConstraint:
 		ver, err = cancelOnlyNotHandledJob(job)
 	default:
 		job.State = model.JobStateCancelled
@@ -0,0 +1,447 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+)
+
+func onCreateRoutine(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	routineInfo := &model.RoutineInfo{}
+	if err := job.DecodeArgs(routineInfo); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	routineInfo.State = model.StateNone
+	err := checkRoutineNotExists(t, job, schemaID, routineInfo)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	switch routineInfo.State {
+	case model.StateNone:
+		// none -> public
+		routineInfo.State = model.StatePublic
+		err = t.CreateRoutine(schemaID, routineInfo)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		ver, err = updateSchemaVersion(t, job)
+		if err != nil {
+			return ver, errors.Trace(err)
+		}
+		// Finish this job.
+		job.FinishDBJob(model.JobStateDone, model.StatePublic, ver, nil)
+		return ver, nil
+	default:
+		return ver, ErrInvalidDDLState.GenWithStackByArgs("routine", routineInfo.State)
+	}
+}
+
+func onDropRoutine(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	schemaID := job.SchemaID
+	var routineID int64
+	if err := job.DecodeArgs(&routineID); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if err := t.DropRoutine(schemaID, routineID); err != nil {
+		if meta.ErrDBNotExists.Equal(err) || meta.ErrRoutineNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	ver, err := updateSchemaVersion(t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	// Finish this job.
+	job.FinishDBJob(model.JobStateDone, model.StateNone, ver, nil)
+	return ver, nil
+}
+
+// checkRoutineNotExists checks the meta directly, because the routine may be
+// created by a job which is not yet reflected in the cached information schema.
+func checkRoutineNotExists(t *meta.Meta, job *model.Job, schemaID int64, info *model.RoutineInfo) error {
+	routines, err := t.ListRoutines(schemaID)
+	if err != nil {
+		if meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs("")
+		}
+		return errors.Trace(err)
+	}
+	for _, routine := range routines {
+		if routine.Type == info.Type && routine.Name.L == info.Name.L {
+			job.State = model.JobStateCancelled
+			return infoschema.ErrRoutineExists.GenWithStackByArgs(info.Type.String(), info.Name)
+		}
+	}
+	return nil
+}
+
+func buildRoutineInfo(ctx sessionctx.Context, dbInfo *model.DBInfo, s *ast.CreateRoutineStmt) (*model.RoutineInfo, error) {
+	if err := checkRoutine(s); err != nil {
+		return nil, err
+	}
+	vars := ctx.GetSessionVars()
+	info := &model.RoutineInfo{
+		Name:          s.Name.Name,
+		Type:          s.Tp,
+		Params:        make([]*model.RoutineParam, 0, len(s.Params)),
+		Body:          s.Body.Text(),
+		Definition:    strings.TrimSuffix(strings.TrimSpace(s.Text()), ";"),
+		Definer:       s.Definer,
+		Security:      s.Security,
+		DataAccess:    s.DataAccess,
+		Deterministic: s.Deterministic,
+		Comment:       s.Comment,
+		SQLMode:       vars.SQLMode,
+		Created:       time.Now(),
+	}
+	info.Charset, _ = vars.GetSystemVar(variable.CharacterSetClient)
+	info.Collate, _ = vars.GetSystemVar(variable.CollationConnection)
+	var err error
+	if info.Definition == "" {
+		// The statement may be built by hand instead of parsed from text.
+		if info.Definition, err = restoreRoutineText(s); err != nil {
+			return nil, err
+		}
+	}
+	if info.Body == "" {
+		if info.Body, err = restoreRoutineText(s.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	// Parameters and the return value use the character set of the database by default.
+	chs, coll := dbInfo.Charset, dbInfo.Collate
+	if chs == "" {
+		chs, coll = info.Charset, info.Collate
+	}
+	for _, param := range s.Params {
+		tp := param.Tp.Clone()
+		if err := SetRoutineFieldType(tp, chs, coll); err != nil {
+			return nil, errors.Trace(err)
+		}
+		info.Params = append(info.Params, &model.RoutineParam{Name: model.NewCIStr(param.Name), Mode: param.Mode, Tp: tp})
+	}
+	if s.Returns != nil {
+		info.Returns = s.Returns.Clone()
+		if err := SetRoutineFieldType(info.Returns, chs, coll); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return info, nil
+}
+
+// restoreRoutineText restores the text of a stored program statement which isn't parsed from text.
+func restoreRoutineText(node ast.Node) (string, error) {
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// SetRoutineFieldType fills the unspecified attributes of the type of a routine parameter or variable.
+func SetRoutineFieldType(tp *types.FieldType, chs, coll string) error {
+	if tp.Charset != "" {
+		chs, coll = tp.Charset, tp.Collate
+	}
+	if err := setCharsetCollationFlenDecimal(tp, chs, coll); err != nil {
+		return errors.Trace(err)
+	}
+	return checkColumnAttributes("", tp)
+}
+
+type routineLabel struct {
+	name   string
+	isLoop bool
+}
+
+// routineChecker checks a stored routine or a trigger statically when it is created.
+type routineChecker struct {
+	stmt *ast.CreateRoutineStmt
+	// trigger and table are set when the body of a trigger is checked.
+	trigger   *ast.CreateTriggerStmt
+	table     *model.TableInfo
+	labels    []routineLabel
+	vars      []map[string]struct{}
+	cursors   []map[string]struct{}
+	hasReturn bool
+}
+
+func checkRoutine(s *ast.CreateRoutineStmt) error {
+	c := &routineChecker{stmt: s}
+	params := make(map[string]struct{}, len(s.Params))
+	for _, param := range s.Params {
+		name := strings.ToLower(param.Name)
+		if _, ok := params[name]; ok {
+			return ErrSpDupParam.GenWithStackByArgs(param.Name)
+		}
+		params[name] = struct{}{}
+	}
+	c.vars = append(c.vars, params)
+	if s.Tp == model.RoutineTypeFunction && !s.Deterministic {
+		return errUnsupportedRoutine.GenWithStackByArgs("NOT DETERMINISTIC stored functions")
+	}
+	if err := c.checkStmt(s.Body); err != nil {
+		return err
+	}
+	if s.Tp == model.RoutineTypeFunction && !c.hasReturn {
+		return ErrSpNoreturn.GenWithStackByArgs(s.Name.Name.O)
+	}
+	return nil
+}
+
+func (c *routineChecker) isFunction() bool {
+	return c.stmt != nil && c.stmt.Tp == model.RoutineTypeFunction
+}
+
+func (c *routineChecker) pushLabel(name string, isLoop bool) error {
+	if name == "" {
+		return nil
+	}
+	for _, label := range c.labels {
+		if strings.EqualFold(label.name, name) {
+			return ErrSpLabelRedefine.GenWithStackByArgs(name)
+		}
+	}
+	c.labels = append(c.labels, routineLabel{name: name, isLoop: isLoop})
+	return nil
+}
+
+func (c *routineChecker) popLabel(name string) {
+	if name != "" {
+		c.labels = c.labels[:len(c.labels)-1]
+	}
+}
+
+func (c *routineChecker) hasVar(name string) bool {
+	for _, vars := range c.vars {
+		if _, ok := vars[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *routineChecker) hasCursor(name string) bool {
+	for _, cursors := range c.cursors {
+		if _, ok := cursors[strings.ToLower(name)]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *routineChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *routineChecker) checkExpr(expr ast.ExprNode) error {
+	if expr == nil || !c.isFunction() {
+		return nil
+	}
+	checker := &subqueryChecker{}
+	expr.Accept(checker)
+	if checker.hasSubquery {
+		return errUnsupportedRoutine.GenWithStackByArgs("subqueries in stored functions")
+	}
+	return nil
+}
+
+func (c *routineChecker) checkBlock(x *ast.ProcedureBlock) error {
+	if err := c.pushLabel(x.Label, false); err != nil {
+		return err
+	}
+	vars, cursors := make(map[string]struct{}), make(map[string]struct{})
+	c.vars = append(c.vars, vars)
+	c.cursors = append(c.cursors, cursors)
+	for _, decl := range x.Decls {
+		switch d := decl.(type) {
+		case *ast.ProcedureDecl:
+			if err := c.checkExpr(d.Default); err != nil {
+				return err
+			}
+			for _, name := range d.Names {
+				if _, ok := vars[strings.ToLower(name)]; ok {
+					return ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				vars[strings.ToLower(name)] = struct{}{}
+			}
+		case *ast.ProcedureCursorDecl:
+			if c.isFunction() {
+				return errUnsupportedRoutine.GenWithStackByArgs("cursors in stored functions")
+			}
+			if _, ok := cursors[strings.ToLower(d.Name)]; ok {
+				return ErrSpDupCurs.GenWithStackByArgs(d.Name)
+			}
+			if sel, ok := d.Query.(*ast.SelectStmt); ok && sel.SelectIntoOpt != nil {
+				return ErrSpBadCursorSelect
+			}
+			cursors[strings.ToLower(d.Name)] = struct{}{}
+		case *ast.ProcedureHandlerDecl:
+			if c.isFunction() {
+				return errUnsupportedRoutine.GenWithStackByArgs("handlers in stored functions")
+			}
+			// The handler runs outside of the labels of the block.
+			labels := c.labels
+			c.labels = nil
+			err := c.checkStmt(d.Stmt)
+			c.labels = labels
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.checkStmts(x.Stmts); err != nil {
+		return err
+	}
+	c.vars = c.vars[:len(c.vars)-1]
+	c.cursors = c.cursors[:len(c.cursors)-1]
+	c.popLabel(x.Label)
+	return nil
+}
+
+func (c *routineChecker) checkStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureIfStmt:
+		for _, block := range x.Blocks {
+			if err := c.checkExpr(block.Cond); err != nil {
+				return err
+			}
+			if err := c.checkStmts(block.Stmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.Else)
+	case *ast.ProcedureLoopStmt:
+		if err := c.checkExpr(x.Cond); err != nil {
+			return err
+		}
+		if err := c.pushLabel(x.Label, true); err != nil {
+			return err
+		}
+		if err := c.checkStmts(x.Stmts); err != nil {
+			return err
+		}
+		c.popLabel(x.Label)
+	case *ast.ProcedureJumpStmt:
+		for i := len(c.labels) - 1; i >= 0; i-- {
+			label := c.labels[i]
+			if strings.EqualFold(label.name, x.Label) && (label.isLoop || x.Tp == ast.JumpTypeLeave) {
+				return nil
+			}
+		}
+		tp := "LEAVE"
+		if x.Tp == ast.JumpTypeIterate {
+			tp = "ITERATE"
+		}
+		return ErrSpLilabelMismatch.GenWithStackByArgs(tp, x.Label)
+	case *ast.ProcedureOpenCursorStmt:
+		if !c.hasCursor(x.Name) {
+			return ErrSpCursorMismatch.GenWithStackByArgs(x.Name)
+		}
+	case *ast.ProcedureFetchCursorStmt:
+		if !c.hasCursor(x.Name) {
+			return ErrSpCursorMismatch.GenWithStackByArgs(x.Name)
+		}
+	case *ast.ProcedureCloseCursorStmt:
+		if !c.hasCursor(x.Name) {
+			return ErrSpCursorMismatch.GenWithStackByArgs(x.Name)
+		}
+	case *ast.ProcedureReturnStmt:
+		if !c.isFunction() {
+			return ErrSpBadreturn
+		}
+		c.hasReturn = true
+		return c.checkExpr(x.Expr)
+	case *ast.SignalStmt:
+		for _, item := range x.Items {
+			if err := c.checkExpr(item.Value); err != nil {
+				return err
+			}
+		}
+	case *ast.SetStmt:
+		if c.trigger != nil {
+			return c.checkTriggerSet(x)
+		}
+		if !c.isFunction() {
+			return nil
+		}
+		// Only the local variables can be assigned in a stored function.
+		for _, v := range x.Variables {
+			if !v.IsSystem || v.IsGlobal || !c.hasVar(v.Name) {
+				return errUnsupportedRoutine.GenWithStackByArgs("assigning non-local variables in stored functions")
+			}
+			if err := c.checkExpr(v.Value); err != nil {
+				return err
+			}
+		}
+	default:
+		if c.isFunction() {
+			return errUnsupportedRoutine.GenWithStackByArgs("SQL statements in stored functions")
+		}
+		if c.trigger != nil {
+			return checkTriggerStmt(stmt)
+		}
+	}
+	return nil
+}
+
+type subqueryChecker struct {
+	hasSubquery bool
+}
+
+// Enter implements ast.Visitor interface.
+func (c *subqueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.SubqueryExpr, *ast.ExistsSubqueryExpr:
+		c.hasSubquery = true
+		return in, true
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (c *subqueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
@@ -0,0 +1,263 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+)
+
+func onCreateTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	triggerInfo := &model.TriggerInfo{}
+	var order ast.TriggerOrderType
+	var orderTrigger string
+	if err := job.DecodeArgs(triggerInfo, &order, &orderTrigger); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	if err := checkTriggerNotExists(t, job, triggerInfo.Name); err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pos := len(tblInfo.Triggers)
+	if order != ast.TriggerOrderNone {
+		pos = -1
+		for i, trigger := range tblInfo.Triggers {
+			if strings.EqualFold(trigger.Name.O, orderTrigger) && trigger.Timing == triggerInfo.Timing && trigger.Event == triggerInfo.Event {
+				pos = i
+				if order == ast.TriggerOrderFollows {
+					pos++
+				}
+				break
+			}
+		}
+		if pos < 0 {
+			job.State = model.JobStateCancelled
+			return ver, ErrReferencedTrgDoesNotExist.GenWithStackByArgs(orderTrigger)
+		}
+	}
+
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers)+1)
+	triggers = append(triggers, tblInfo.Triggers[:pos]...)
+	triggers = append(triggers, triggerInfo)
+	tblInfo.Triggers = append(triggers, tblInfo.Triggers[pos:]...)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(t *meta.Meta, job *model.Job) (ver int64, _ error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		// Invalid arguments, cancel this job.
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := getTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	pos := -1
+	for i, trigger := range tblInfo.Triggers {
+		if trigger.Name.L == name.L {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		job.State = model.JobStateCancelled
+		return ver, infoschema.ErrTriggerNotExists.GenWithStackByArgs()
+	}
+
+	triggers := make([]*model.TriggerInfo, 0, len(tblInfo.Triggers)-1)
+	triggers = append(triggers, tblInfo.Triggers[:pos]...)
+	tblInfo.Triggers = append(triggers, tblInfo.Triggers[pos+1:]...)
+	ver, err = updateVersionAndTableInfo(t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+// checkTriggerNotExists checks the meta directly, because the trigger may be created
+// on another table by a job which is not yet reflected in the cached information schema.
+func checkTriggerNotExists(t *meta.Meta, job *model.Job, name model.CIStr) error {
+	tables, err := t.ListTables(job.SchemaID)
+	if err != nil {
+		if meta.ErrDBNotExists.Equal(err) {
+			job.State = model.JobStateCancelled
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs("")
+		}
+		return errors.Trace(err)
+	}
+	for _, tbl := range tables {
+		for _, trigger := range tbl.Triggers {
+			if trigger.Name.L == name.L {
+				job.State = model.JobStateCancelled
+				return infoschema.ErrTriggerExists.GenWithStackByArgs()
+			}
+		}
+	}
+	return nil
+}
+
+func buildTriggerInfo(ctx sessionctx.Context, tblInfo *model.TableInfo, s *ast.CreateTriggerStmt) (*model.TriggerInfo, error) {
+	if err := checkTrigger(s, tblInfo); err != nil {
+		return nil, err
+	}
+	vars := ctx.GetSessionVars()
+	info := &model.TriggerInfo{
+		Name:       s.Name.Name,
+		Timing:     s.Timing,
+		Event:      s.Event,
+		Body:       s.Body.Text(),
+		Definition: strings.TrimSuffix(strings.TrimSpace(s.Text()), ";"),
+		Definer:    s.Definer,
+		SQLMode:    vars.SQLMode,
+		Created:    time.Now(),
+	}
+	info.Charset, _ = vars.GetSystemVar(variable.CharacterSetClient)
+	info.Collate, _ = vars.GetSystemVar(variable.CollationConnection)
+	var err error
+	if info.Definition == "" {
+		// The statement may be built by hand instead of parsed from text.
+		if info.Definition, err = restoreRoutineText(s); err != nil {
+			return nil, err
+		}
+	}
+	if info.Body == "" {
+		if info.Body, err = restoreRoutineText(s.Body); err != nil {
+			return nil, err
+		}
+	}
+	return info, nil
+}
+
+func checkTrigger(s *ast.CreateTriggerStmt, tblInfo *model.TableInfo) error {
+	c := &routineChecker{trigger: s, table: tblInfo}
+	if err := c.checkStmt(s.Body); err != nil {
+		return err
+	}
+	checker := &triggerRowChecker{c: c}
+	s.Body.Accept(checker)
+	return checker.err
+}
+
+// triggerRowName returns the row referred by the name qualified by NEW or OLD.
+func triggerRowName(qualifier string) (string, bool) {
+	switch strings.ToLower(qualifier) {
+	case "new":
+		return "NEW", true
+	case "old":
+		return "OLD", true
+	}
+	return "", false
+}
+
+// checkTriggerRow checks the reference to a column of the NEW or OLD row.
+func (c *routineChecker) checkTriggerRow(row, column string) error {
+	if (row == "NEW" && c.trigger.Event == model.TriggerEventDelete) || (row == "OLD" && c.trigger.Event == model.TriggerEventInsert) {
+		return ErrTrgNoSuchRowInTrg.GenWithStackByArgs(row, c.trigger.Event.String())
+	}
+	if model.FindColumnInfo(c.table.Columns, strings.ToLower(column)) == nil {
+		return ErrBadField.GenWithStackByArgs(column, row)
+	}
+	return nil
+}
+
+// checkTriggerSet checks the assignments of a trigger, the columns of the NEW row can be
+// assigned in a BEFORE trigger only.
+func (c *routineChecker) checkTriggerSet(s *ast.SetStmt) error {
+	for _, v := range s.Variables {
+		if !v.IsSystem || v.IsGlobal {
+			continue
+		}
+		sep := strings.IndexByte(v.Name, '.')
+		if sep < 0 {
+			continue
+		}
+		row, ok := triggerRowName(v.Name[:sep])
+		if !ok {
+			continue
+		}
+		if err := c.checkTriggerRow(row, v.Name[sep+1:]); err != nil {
+			return err
+		}
+		if row == "OLD" {
+			return ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+		}
+		if c.trigger.Timing == model.TriggerTimingAfter {
+			return ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		}
+	}
+	return nil
+}
+
+// checkTriggerStmt checks the SQL statement in a trigger, which can neither return a
+// result set nor commit the transaction.
+func checkTriggerStmt(stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt == nil {
+			return ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+	case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+		return ErrCommitNotAllowedInSfOrTrg
+	}
+	return nil
+}
+
+// triggerRowChecker checks the references to the NEW and OLD rows in the body of a trigger.
+type triggerRowChecker struct {
+	c   *routineChecker
+	err error
+}
+
+// Enter implements ast.Visitor interface.
+func (v *triggerRowChecker) Enter(in ast.Node) (ast.Node, bool) {
+	return in, v.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (v *triggerRowChecker) Leave(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok || v.err != nil || col.Name.Schema.L != "" {
+		return in, true
+	}
+	if row, ok := triggerRowName(col.Name.Table.L); ok {
+		v.err = v.c.checkTriggerRow(row, col.Name.Name.O)
+	}
+	return in, true
+}
@@ -249,6 +249,11 @@ func (do *Domain) fetchSchemasWithTables(schemas []*model.DBInfo, m *meta.Meta,
 			}
 			di.Tables = append(di.Tables, tbl)
 		}
+		di.Routines, err = m.ListRoutines(di.ID)
+		if err != nil {
+			done <- err
+			return
+		}
 	}
 	done <- nil
 }
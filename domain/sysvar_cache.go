@@ -185,6 +185,15 @@ func (do *Domain) checkEnableServerGlobalVar(name, sVal string) {
 			break
 		}
 		variable.EnableTSOFollowerProxy.Store(val)
+	case variable.TiDBEnableInstancePlanCache:
+		variable.EnableInstancePlanCache.Store(variable.TiDBOptOn(sVal))
+	case variable.TiDBInstancePlanCacheMaxMemSize:
+		var val int64
+		val, err = strconv.ParseInt(sVal, 10, 64)
+		if err != nil {
+			break
+		}
+		variable.InstancePlanCacheMaxMemSize.Store(val)
 	case variable.TiDBEnableLocalTxn:
 		variable.EnableLocalTxn.Store(variable.TiDBOptOn(sVal))
 	case variable.TiDBEnableStmtSummary:
@@ -822,13 +822,19 @@ const (
 	ErrRowInWrongPartition                                   = 1863
 	ErrErrorLast                                             = 1863
 	ErrMaxExecTimeExceeded                                   = 1907
+	ErrFKDepthExceeded                                       = 3008
 	ErrInvalidFieldSize                                      = 3013
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
+	ErrUserLockWrongName                                     = 3057
+	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
 	ErrFieldInOrderNotSelect                                 = 3065
 	ErrAggregateInOrderNotSelect                             = 3066
 	ErrInvalidJSONData                                       = 3069
+	ErrReferencedTrgDoesNotExist                             = 3076
 	ErrGeneratedColumnFunctionIsNotAllowed                   = 3102
 	ErrUnsupportedAlterInplaceOnVirtualColumn                = 3103
 	ErrWrongFKOptionForGeneratedColumn                       = 3104
@@ -885,6 +891,12 @@ const (
 	ErrIllegalPrivilegeLevel                                 = 3619
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTFMustHaveAlias                                       = 3667
+	ErrTFForbiddenReference                                  = 3668
+	ErrJTValueOutOfRange                                     = 3669
+	ErrRegexpIndexOutOfBounds                                = 3686
 	ErrDataTruncatedFunctionalIndex                          = 3751
 	ErrDataOutOfRangeFunctionalIndex                         = 3752
 	ErrFunctionalIndexOnJSONOrGeometryFunction               = 3753
@@ -900,12 +912,26 @@ const (
 	ErrGeneratedColumnRowValueIsNotAllowed                   = 3764
 	ErrFKIncompatibleColumns                                 = 3780
 	ErrFunctionalIndexRowValueIsNotAllowed                   = 3800
+	ErrColumnCheckConstraintReferencesOtherColumn            = 3813
+	ErrCheckConstraintNamedFunctionIsNotAllowed              = 3814
+	ErrCheckConstraintFunctionIsNotAllowed                   = 3815
+	ErrCheckConstraintVariables                              = 3816
+	ErrCheckConstraintRowValueIsNotAllowed                   = 3817
+	ErrCheckConstraintRefersAutoIncrementColumn              = 3818
+	ErrCheckConstraintViolated                               = 3819
+	ErrCheckConstraintRefersUnknownColumn                    = 3820
+	ErrCheckConstraintNotFound                               = 3821
+	ErrCheckConstraintDupName                                = 3822
 	ErrDependentByFunctionalIndex                            = 3837
+	ErrInvalidJSONType                                       = 3853
 	ErrInvalidJSONValueForFuncIndex                          = 3903
 	ErrJSONValueOutOfRangeForFuncIndex                       = 3904
 	ErrFunctionalIndexDataIsTooLong                          = 3907
 	ErrFunctionalIndexNotApplicable                          = 3909
 	ErrDynamicPrivilegeNotRegistered                         = 3929
+	ErrDependentByCheckConstraint                            = 3959
+	ErrMissingJSONValue                                      = 3966
+	ErrMultipleJSONValues                                    = 3967
 	// MariaDB errors.
 	ErrOnlyOneDefaultPartionAllowed         = 4030
 	ErrWrongPartitionTypeExpectedSystemTime = 4113
@@ -1010,6 +1036,7 @@ const (
 	ErrAsOf                                = 8135
 	ErrVariableNoLongerSupported           = 8136
 	ErrAnalyzeMissColumn                   = 8137
+	ErrInvalidJSONSchema                   = 8138
 
 	// Error codes used by TiDB ddl package
 	ErrUnsupportedDDLOperation            = 8200
@@ -1054,6 +1081,8 @@ const (
 	ErrPlacementPolicyWithDirectOption    = 8240
 	ErrPlacementPolicyInUse               = 8241
 	ErrOptOnCacheTable                    = 8242
+	ErrOperateSameColumn                  = 8243
+	ErrOperateSameIndex                   = 8244
 	// TiKV/PD/TiFlash errors.
 	ErrPDServerTimeout           = 9001
 	ErrTiKVServerTimeout         = 9002
@@ -835,13 +835,19 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrGeneratedColumnRefAutoInc:                             mysql.Message("Generated column '%s' cannot refer to auto-increment column.", nil),
 	ErrWarnConflictingHint:                                   mysql.Message("Hint %s is ignored as conflicting/duplicated.", nil),
 	ErrUnresolvedHintName:                                    mysql.Message("Unresolved name '%s' for %s hint", nil),
+	ErrFKDepthExceeded:                                       mysql.Message("Foreign key cascade delete/update exceeds max depth of %v.", nil),
 	ErrInvalidFieldSize:                                      mysql.Message("Invalid size for column '%s'.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
+	ErrUserLockWrongName:                                     mysql.Message("Incorrect user-level lock name '%-.192s'.", nil),
+	ErrUserLockDeadlock:                                      mysql.Message("Deadlock found when trying to get user-level lock; try rolling back transaction/releasing locks and restarting lock acquisition.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
 	ErrInvalidJSONData:                                       mysql.Message("Invalid JSON data provided to function %s: %s", nil),
+	ErrReferencedTrgDoesNotExist:                             mysql.Message("Referenced trigger '%s' for the given action time and event type does not exist.", nil),
 	ErrInvalidJSONText:                                       mysql.Message("Invalid JSON text: %-.192s", []int{0}),
 	ErrInvalidJSONPath:                                       mysql.Message("Invalid JSON path expression %s.", nil),
 	ErrInvalidTypeForJSON:                                    mysql.Message("Invalid data type for JSON data in argument %d to function %s; a JSON string or JSON type is required.", nil),
@@ -881,6 +887,12 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrMaxExecTimeExceeded:                                   mysql.Message("Query execution was interrupted, max_execution_time exceeded.", nil),
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar column '%s' of JSON_TABLE '%s'.", nil),
+	ErrTFMustHaveAlias:                                       mysql.Message("Every table function must have an alias.", nil),
+	ErrTFForbiddenReference:                                  mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
+	ErrJTValueOutOfRange:                                     mysql.Message("Value is out of range for JSON_TABLE's column '%s'", nil),
+	ErrRegexpIndexOutOfBounds:                                mysql.Message("Index out of bounds in regular expression search.", nil),
 	ErrDataTruncatedFunctionalIndex:                          mysql.Message("Data truncated for expression index '%s' at row %d", nil),
 	ErrDataOutOfRangeFunctionalIndex:                         mysql.Message("Value is out of range for expression index '%s' at row %d", nil),
 	ErrFunctionalIndexOnJSONOrGeometryFunction:               mysql.Message("Cannot create an expression index on a function that returns a JSON or GEOMETRY value", nil),
@@ -895,13 +907,27 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrFunctionalIndexOnField:                                mysql.Message("Expression index on a column is not supported. Consider using a regular index instead", nil),
 	ErrFKIncompatibleColumns:                                 mysql.Message("Referencing column '%s' in foreign key constraint '%s' are incompatible", nil),
 	ErrFunctionalIndexRowValueIsNotAllowed:                   mysql.Message("Expression of expression index '%s' cannot refer to a row value", nil),
+	ErrColumnCheckConstraintReferencesOtherColumn:            mysql.Message("Column check constraint '%s' references other column.", nil),
+	ErrCheckConstraintNamedFunctionIsNotAllowed:              mysql.Message("An expression of a check constraint '%s' contains disallowed function: %s.", nil),
+	ErrCheckConstraintFunctionIsNotAllowed:                   mysql.Message("An expression of a check constraint '%s' contains disallowed function.", nil),
+	ErrCheckConstraintVariables:                              mysql.Message("An expression of a check constraint '%s' cannot refer to a user or system variable.", nil),
+	ErrCheckConstraintRowValueIsNotAllowed:                   mysql.Message("Check constraint '%s' cannot refer to a row value.", nil),
+	ErrCheckConstraintRefersAutoIncrementColumn:              mysql.Message("Check constraint '%s' cannot refer to an auto-increment column.", nil),
+	ErrCheckConstraintViolated:                               mysql.Message("Check constraint '%s' is violated.", nil),
+	ErrCheckConstraintRefersUnknownColumn:                    mysql.Message("Check constraint '%s' refers to non-existing column '%s'.", nil),
+	ErrCheckConstraintNotFound:                               mysql.Message("Check constraint '%s' is not found in the table.", nil),
+	ErrCheckConstraintDupName:                                mysql.Message("Duplicate check constraint name '%s'.", nil),
 	ErrDependentByFunctionalIndex:                            mysql.Message("Column '%s' has an expression index dependency and cannot be dropped or renamed", nil),
+	ErrInvalidJSONType:                                       mysql.Message("Invalid JSON type in argument %d to function %s; an %s is required.", nil),
 	ErrInvalidJSONValueForFuncIndex:                          mysql.Message("Invalid JSON value for CAST for expression index '%s'", nil),
 	ErrJSONValueOutOfRangeForFuncIndex:                       mysql.Message("Out of range JSON value for CAST for expression index '%s'", nil),
 	ErrFunctionalIndexDataIsTooLong:                          mysql.Message("Data too long for expression index '%s'", nil),
 	ErrFunctionalIndexNotApplicable:                          mysql.Message("Cannot use expression index '%s' due to type or collation conversion", nil),
 	ErrUnsupportedConstraintCheck:                            mysql.Message("%s is not supported", nil),
 	ErrDynamicPrivilegeNotRegistered:                         mysql.Message("Dynamic privilege '%s' is not registered with the server.", nil),
+	ErrDependentByCheckConstraint:                            mysql.Message("Check constraint '%s' uses column '%s', hence column cannot be dropped or renamed.", nil),
+	ErrMissingJSONValue:                                      mysql.Message("No value was found by '%s' on the specified path.", nil),
+	ErrMultipleJSONValues:                                    mysql.Message("More than one value was found by '%s' on the specified path.", nil),
 	ErrIllegalPrivilegeLevel:                                 mysql.Message("Illegal privilege level specified for %s", nil),
 	ErrCTERecursiveRequiresUnion:                             mysql.Message("Recursive Common Table Expression '%s' should contain a UNION", nil),
 	ErrCTERecursiveRequiresNonRecursiveFirst:                 mysql.Message("Recursive Common Table Expression '%s' should have one or more non-recursive query blocks followed by one or more recursive ones", nil),
@@ -1021,6 +1047,7 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrUnsupportedType:                     mysql.Message("Unsupported type %T", nil),
 	ErrAnalyzeMissIndex:                    mysql.Message("Index '%s' in field list does not exist in table '%s'", nil),
 	ErrAnalyzeMissColumn:                   mysql.Message("Column '%s' in ANALYZE column option does not exist in table '%s'", nil),
+	ErrInvalidJSONSchema:                   mysql.Message("Invalid JSON schema: %s", nil),
 	ErrCartesianProductUnsupported:         mysql.Message("Cartesian product is unsupported", nil),
 	ErrPreparedStmtNotFound:                mysql.Message("Prepared statement not found", nil),
 	ErrWrongParamCount:                     mysql.Message("Wrong parameter count", nil),
@@ -1061,6 +1088,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPlacementPolicyWithDirectOption: mysql.Message("Placement policy '%s' can't co-exist with direct placement options", nil),
 	ErrPlacementPolicyInUse:            mysql.Message("Placement policy '%-.192s' is still in use", nil),
 	ErrOptOnCacheTable:                 mysql.Message("'%s' is unsupported on cache tables.", nil),
+	ErrOperateSameColumn:               mysql.Message("Unsupported operate same column '%s'", nil),
+	ErrOperateSameIndex:                mysql.Message("Unsupported operate same index '%s'", nil),
 	// TiKV/PD errors.
 	ErrPDServerTimeout:           mysql.Message("PD server timeout", nil),
 	ErrTiKVServerTimeout:         mysql.Message("TiKV server timeout", nil),
@@ -696,6 +696,10 @@ func (a *ExecStmt) handlePessimisticLockError(ctx context.Context, err error) (E
 	if err != nil && sessVars.IsIsolation(ast.Serializable) {
 		return nil, err
 	}
+	if err != nil && sessVars.StmtCtx.InTrigger {
+		// The statement of a trigger is retried with the triggering statement.
+		return nil, err
+	}
 	txnCtx := sessVars.TxnCtx
 	var newForUpdateTS uint64
 	if deadlock, ok := errors.Cause(err).(*tikverr.ErrDeadlock); ok {
@@ -1218,6 +1222,7 @@ func (a *ExecStmt) SummaryStmt(succ bool) {
 		IsInternal:      sessVars.InRestrictedSQL,
 		Succeed:         succ,
 		PlanInCache:     sessVars.FoundInPlanCache,
+		PlanCacheMiss:   stmtCtx.UseCache && !sessVars.FoundInPlanCache,
 		PlanInBinding:   sessVars.FoundInBinding,
 		ExecRetryCount:  a.retryCount,
 		StmtExecDetails: stmtDetail,
@@ -1239,6 +1244,9 @@ func (a *ExecStmt) GetTextToLog() string {
 		sql, _ = sessVars.StmtCtx.SQLDigest()
 	} else if sensitiveStmt, ok := a.StmtNode.(ast.SensitiveStmtNode); ok {
 		sql = sensitiveStmt.SecureText()
+	} else if sessVars.StmtCtx.UseGeneralPlanCache {
+		// The parameters of the general plan cache are the literals of the original SQL.
+		sql = sessVars.StmtCtx.OriginalSQL
 	} else {
 		sql = sessVars.StmtCtx.OriginalSQL + sessVars.PreparedParams.String()
 	}
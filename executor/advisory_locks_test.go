@@ -0,0 +1,140 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisoryLocks(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk2 := testkit.NewTestKit(t, store)
+	connID1 := tk1.Session().GetSessionVars().ConnectionID
+	connID2 := tk2.Session().GetSessionVars().ConnectionID
+
+	// The lock names are case-insensitive, and a lock can be acquired multiple times by the same session.
+	tk1.MustQuery("select get_lock('adv_lock1', 1), get_lock('ADV_LOCK1', 1), is_free_lock('adv_lock1'), is_used_lock('adv_lock1')").
+		Check(testkit.Rows(fmt.Sprintf("1 1 0 %d", connID1)))
+	tk2.MustQuery("select is_free_lock('adv_lock1'), is_used_lock('adv_lock1'), is_free_lock('adv_lock2'), is_used_lock('adv_lock2')").
+		Check(testkit.Rows(fmt.Sprintf("0 %d 1 <nil>", connID1)))
+	// The lock held by another session can't be acquired or released.
+	tk2.MustQuery("select get_lock('adv_lock1', 0), get_lock('adv_lock1', 1)").Check(testkit.Rows("0 0"))
+	tk2.MustQuery("select release_lock('adv_lock1'), release_lock('adv_lock2')").Check(testkit.Rows("0 <nil>"))
+	tk2.MustQuery("select lock_name, session_id, state from information_schema.advisory_locks where lock_name like 'adv_lock%'").
+		Check(testkit.Rows(fmt.Sprintf("adv_lock1 %d GRANTED", connID1)))
+	tk2.MustQuery("select object_type, object_name, lock_type, lock_status, owner_thread_id from performance_schema.metadata_locks where object_name like 'adv_lock%'").
+		Check(testkit.Rows(fmt.Sprintf("USER LEVEL LOCK adv_lock1 EXCLUSIVE GRANTED %d", connID1)))
+	tk1.MustQuery("select release_lock('adv_lock1'), is_free_lock('adv_lock1'), release_lock('adv_lock1'), is_free_lock('adv_lock1'), release_lock('adv_lock1')").
+		Check(testkit.Rows("1 0 1 1 <nil>"))
+
+	// The waiting session acquires the lock once it's released.
+	tk1.MustQuery("select get_lock('adv_lock1', 1)").Check(testkit.Rows("1"))
+	done := make(chan struct{})
+	go func() {
+		tk2.MustQuery("select get_lock('adv_lock1', 10)").Check(testkit.Rows("1"))
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		rows := tk1.MustQuery("select session_id from information_schema.advisory_locks where lock_name = 'adv_lock1' and state = 'PENDING'").Rows()
+		return len(rows) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	tk1.MustQuery("select release_all_locks()").Check(testkit.Rows("1"))
+	<-done
+	tk1.MustQuery("select is_used_lock('adv_lock1')").Check(testkit.Rows(fmt.Sprint(connID2)))
+	tk2.MustQuery("select get_lock('adv_lock2', 1), get_lock('adv_lock2', 1), release_all_locks(), release_all_locks()").Check(testkit.Rows("1 1 3 0"))
+
+	// Waiting for the lock of a session which is waiting for the lock of the current session causes a deadlock.
+	tk1.MustQuery("select get_lock('adv_lock1', 1)").Check(testkit.Rows("1"))
+	tk2.MustQuery("select get_lock('adv_lock2', 1)").Check(testkit.Rows("1"))
+	done = make(chan struct{})
+	go func() {
+		tk2.MustQuery("select get_lock('adv_lock1', 10)").Check(testkit.Rows("1"))
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		rows := tk1.MustQuery("select session_id from information_schema.advisory_locks where lock_name = 'adv_lock1' and state = 'PENDING'").Rows()
+		return len(rows) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.EqualError(t, tk1.QueryToErr("select get_lock('adv_lock2', 10)"),
+		"[expression:3058]Deadlock found when trying to get user-level lock; try rolling back transaction/releasing locks and restarting lock acquisition.")
+	tk1.MustQuery("select release_lock('adv_lock1')").Check(testkit.Rows("1"))
+	<-done
+	tk2.MustQuery("select release_all_locks()").Check(testkit.Rows("2"))
+
+	// Killing the session interrupts the waiting.
+	tk1.MustQuery("select get_lock('adv_lock1', 1)").Check(testkit.Rows("1"))
+	done = make(chan struct{})
+	go func() {
+		require.Error(t, tk2.QueryToErr("select get_lock('adv_lock1', -1)"))
+		close(done)
+	}()
+	require.Eventually(t, func() bool {
+		rows := tk1.MustQuery("select session_id from information_schema.advisory_locks where lock_name = 'adv_lock1' and state = 'PENDING'").Rows()
+		return len(rows) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	atomic.StoreUint32(&tk2.Session().GetSessionVars().Killed, 1)
+	<-done
+	atomic.StoreUint32(&tk2.Session().GetSessionVars().Killed, 0)
+	tk2.MustQuery("select is_used_lock('adv_lock1')").Check(testkit.Rows(fmt.Sprint(connID1)))
+
+	// The locks are released when the session is closed.
+	tk1.Session().Close()
+	tk2.MustQuery("select is_free_lock('adv_lock1')").Check(testkit.Rows("1"))
+	tk2.MustQuery("select count(*) from information_schema.advisory_locks where lock_name like 'adv_lock%'").Check(testkit.Rows("0"))
+
+	for _, lockName := range []string{"''", "null", "'" + strings.Repeat("a", 65) + "'"} {
+		require.Error(t, tk2.QueryToErr(fmt.Sprintf("select get_lock(%s, 1)", lockName)))
+		require.Error(t, tk2.QueryToErr(fmt.Sprintf("select is_free_lock(%s)", lockName)))
+	}
+	require.EqualError(t, tk2.QueryToErr("select release_lock('')"), "[expression:3057]Incorrect user-level lock name ''.")
+}
+
+func TestAdvisoryLocksNotFolded(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	tk2 := testkit.NewTestKit(t, store)
+
+	// EXPLAIN doesn't evaluate the lock functions, so the lock isn't acquired.
+	tk1.MustQuery("explain format = 'brief' select get_lock('adv_lock3', 0), is_free_lock('adv_lock3')").Check(testkit.Rows(
+		"Projection 1.00 root  get_lock(adv_lock3, 0)->Column#1, is_free_lock(adv_lock3)->Column#2",
+		"└─TableDual 1.00 root  rows:1"))
+	tk2.MustQuery("select is_free_lock('adv_lock3')").Check(testkit.Rows("1"))
+	tk1.MustQuery("select release_all_locks()").Check(testkit.Rows("0"))
+
+	// The lock functions are evaluated once per row.
+	tk1.MustExec("create table t_adv_lock (a int)")
+	tk1.MustExec("insert into t_adv_lock values (1), (2), (3)")
+	tk1.MustQuery("select get_lock('adv_lock3', 0) from t_adv_lock").Check(testkit.Rows("1", "1", "1"))
+	tk1.MustQuery("select release_lock('adv_lock3') from t_adv_lock limit 2").Check(testkit.Rows("1", "1"))
+	tk1.MustQuery("select release_all_locks()").Check(testkit.Rows("1"))
+	tk2.MustQuery("select is_free_lock('adv_lock3')").Check(testkit.Rows("1"))
+}
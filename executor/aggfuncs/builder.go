@@ -76,7 +76,7 @@ func Build(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal
 }
 
 // BuildWindowFunctions builds specific window function according to function description and order by columns.
-func BuildWindowFunctions(ctx sessionctx.Context, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column) AggFunc {
+func BuildWindowFunctions(ctx sessionctx.Context, windowFuncDesc *aggregation.AggFuncDesc, ordinal int, orderByCols []*expression.Column, ignoreNull, fromLast bool) AggFunc {
 	switch windowFuncDesc.Name {
 	case ast.WindowFuncRank:
 		return buildRank(ordinal, orderByCols, false)
@@ -85,31 +85,53 @@ func BuildWindowFunctions(ctx sessionctx.Context, windowFuncDesc *aggregation.Ag
 	case ast.WindowFuncRowNumber:
 		return buildRowNumber(windowFuncDesc, ordinal)
 	case ast.WindowFuncFirstValue:
-		return buildFirstValue(windowFuncDesc, ordinal)
+		return buildFirstValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLastValue:
-		return buildLastValue(windowFuncDesc, ordinal)
+		return buildLastValue(windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncCumeDist:
 		return buildCumeDist(ordinal, orderByCols)
 	case ast.WindowFuncNthValue:
-		return buildNthValue(windowFuncDesc, ordinal)
+		return buildNthValue(windowFuncDesc, ordinal, ignoreNull, fromLast)
 	case ast.WindowFuncNtile:
 		return buildNtile(windowFuncDesc, ordinal)
 	case ast.WindowFuncPercentRank:
 		return buildPercentRank(ordinal, orderByCols)
 	case ast.WindowFuncLead:
-		return buildLead(ctx, windowFuncDesc, ordinal)
+		return buildLead(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.WindowFuncLag:
-		return buildLag(ctx, windowFuncDesc, ordinal)
+		return buildLag(ctx, windowFuncDesc, ordinal, ignoreNull)
 	case ast.AggFuncMax:
 		// The max/min aggFunc using in the window function will using the sliding window algo.
 		return buildMaxMinInWindowFunction(windowFuncDesc, ordinal, true)
 	case ast.AggFuncMin:
 		return buildMaxMinInWindowFunction(windowFuncDesc, ordinal, false)
+	case ast.AggFuncCount, ast.AggFuncSum, ast.AggFuncAvg:
+		if windowFuncDesc.HasDistinct {
+			return buildDistinctInWindowFunction(ctx, windowFuncDesc, ordinal)
+		}
+		return Build(ctx, windowFuncDesc, ordinal)
 	default:
 		return Build(ctx, windowFuncDesc, ordinal)
 	}
 }
 
+// buildDistinctInWindowFunction builds the aggregate function with DISTINCT
+// for window function. It uses the sliding window algo if the non-distinct
+// version of the function supports it.
+func buildDistinctInWindowFunction(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	nonDistinctDesc := aggFuncDesc.Clone()
+	nonDistinctDesc.HasDistinct = false
+	inner := Build(ctx, nonDistinctDesc, ordinal)
+	if _, ok := inner.(SlidingWindowAggFunc); !ok {
+		return Build(ctx, aggFuncDesc, ordinal)
+	}
+	base := baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}
+	return newSlidingWindowDistinct(base, inner)
+}
+
 func buildApproxCountDistinct(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	base := baseApproxCountDistinct{baseAggFunc{
 		args:    aggFuncDesc.Args,
@@ -663,20 +685,20 @@ func buildRank(ordinal int, orderByCols []*expression.Column, isDense bool) AggF
 	return r
 }
 
-func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildFirstValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &firstValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
-func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildLastValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
-	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp}
+	return &lastValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, ignoreNull: ignoreNull}
 }
 
 func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
@@ -687,14 +709,14 @@ func buildCumeDist(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return r
 }
 
-func buildNthValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+func buildNthValue(aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull, fromLast bool) AggFunc {
 	base := baseAggFunc{
 		args:    aggFuncDesc.Args,
 		ordinal: ordinal,
 	}
 	// Already checked when building the function description.
 	nth, _, _ := expression.GetUint64FromConstant(aggFuncDesc.Args[1])
-	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth}
+	return &nthValue{baseAggFunc: base, tp: aggFuncDesc.RetTp, nth: nth, ignoreNull: ignoreNull, fromLast: fromLast}
 }
 
 func buildNtile(aggFuncDes *aggregation.AggFuncDesc, ordinal int) AggFunc {
@@ -713,7 +735,7 @@ func buildPercentRank(ordinal int, orderByCols []*expression.Column) AggFunc {
 	return &percentRank{baseAggFunc: base, rowComparer: buildRowComparer(orderByCols)}
 }
 
-func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) baseLeadLag {
+func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) baseLeadLag {
 	offset := uint64(1)
 	if len(aggFuncDesc.Args) >= 2 {
 		offset, _, _ = expression.GetUint64FromConstant(aggFuncDesc.Args[1])
@@ -735,13 +757,13 @@ func buildLeadLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc,
 		ordinal: ordinal,
 	}
 	ve, _ := buildValueEvaluator(aggFuncDesc.RetTp)
-	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve}
+	return baseLeadLag{baseAggFunc: base, offset: offset, defaultExpr: defaultExpr, valueEvaluator: ve, ignoreNull: ignoreNull}
 }
 
-func buildLead(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLead(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lead{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
 
-func buildLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
-	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal)}
+func buildLag(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int, ignoreNull bool) AggFunc {
+	return &lag{buildLeadLag(ctx, aggFuncDesc, ordinal, ignoreNull)}
 }
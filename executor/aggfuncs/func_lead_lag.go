@@ -15,6 +15,7 @@
 package aggfuncs
 
 import (
+	"sort"
 	"unsafe"
 
 	"github.com/pingcap/tidb/expression"
@@ -33,11 +34,17 @@ type baseLeadLag struct {
 
 	defaultExpr expression.Expression
 	offset      uint64
+	ignoreNull  bool
 }
 
 type partialResult4LeadLag struct {
 	rows   []chunk.Row
 	curIdx uint64
+	// nonNullIdx keeps the indexes of the rows whose value is not NULL, and
+	// checkedRows is the number of rows which have been checked. They are
+	// only used for IGNORE NULLS.
+	nonNullIdx  []uint64
+	checkedRows uint64
 }
 
 func (v *baseLeadLag) AllocPartialResult() (pr PartialResult, memDelta int64) {
@@ -48,6 +55,8 @@ func (v *baseLeadLag) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4LeadLag)(pr)
 	p.rows = p.rows[:0]
 	p.curIdx = 0
+	p.nonNullIdx = p.nonNullIdx[:0]
+	p.checkedRows = 0
 }
 
 func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -57,12 +66,52 @@ func (v *baseLeadLag) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup [
 	return memDelta, nil
 }
 
+// collectNonNullRows records the indexes of the rows not checked yet whose value is not NULL.
+func (v *baseLeadLag) collectNonNullRows(p *partialResult4LeadLag) error {
+	for ; p.checkedRows < uint64(len(p.rows)); p.checkedRows++ {
+		isNull, err := isNullValue(v.args[0], p.rows[p.checkedRows])
+		if err != nil {
+			return err
+		}
+		if !isNull {
+			p.nonNullIdx = append(p.nonNullIdx, p.checkedRows)
+		}
+	}
+	return nil
+}
+
+// appendIgnoreNullResult appends the value of the target row, which is found
+// among the not NULL rows, or the default value if there is no such row.
+func (v *baseLeadLag) appendIgnoreNullResult(sctx sessionctx.Context, p *partialResult4LeadLag, chk *chunk.Chunk, target uint64, found bool) error {
+	var err error
+	if found {
+		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.nonNullIdx[target]])
+	} else {
+		_, err = v.evaluateRow(sctx, v.defaultExpr, p.rows[p.curIdx])
+	}
+	if err != nil {
+		return err
+	}
+	v.appendResult(chk, v.ordinal)
+	p.curIdx++
+	return nil
+}
+
 type lead struct {
 	baseLeadLag
 }
 
 func (v *lead) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
+	if v.ignoreNull && v.offset > 0 {
+		if err := v.collectNonNullRows(p); err != nil {
+			return err
+		}
+		// The first not NULL row after the current one.
+		first := uint64(sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] > p.curIdx }))
+		target := first + v.offset - 1
+		return v.appendIgnoreNullResult(sctx, p, chk, target, target < uint64(len(p.nonNullIdx)))
+	}
 	var err error
 	if p.curIdx+v.offset < uint64(len(p.rows)) {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx+v.offset])
@@ -83,6 +132,14 @@ type lag struct {
 
 func (v *lag) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
 	p := (*partialResult4LeadLag)(pr)
+	if v.ignoreNull && v.offset > 0 {
+		if err := v.collectNonNullRows(p); err != nil {
+			return err
+		}
+		// The number of not NULL rows before the current one.
+		before := uint64(sort.Search(len(p.nonNullIdx), func(i int) bool { return p.nonNullIdx[i] >= p.curIdx }))
+		return v.appendIgnoreNullResult(sctx, p, chk, before-v.offset, before >= v.offset)
+	}
 	var err error
 	if p.curIdx >= v.offset {
 		_, err = v.evaluateRow(sctx, v.args[0], p.rows[p.curIdx-v.offset])
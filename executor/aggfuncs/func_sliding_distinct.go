@@ -0,0 +1,168 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"unsafe"
+
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+)
+
+const (
+	// DefPartialResult4SlidingDistinctSize is the size of partialResult4SlidingDistinct
+	DefPartialResult4SlidingDistinctSize = int64(unsafe.Sizeof(partialResult4SlidingDistinct{}))
+)
+
+// slidingWindowDistinct evaluates an aggregate function with DISTINCT using a
+// sliding window. It counts the occurrences of every distinct value in the
+// current frame, and only passes a value to the underlying non-distinct
+// function when it enters or leaves the frame for the first time.
+type slidingWindowDistinct struct {
+	baseAggFunc
+	// inner is the non-distinct function, it must implement SlidingWindowAggFunc.
+	inner      AggFunc
+	collators  []collate.Collator
+	encodedBuf []byte
+	// buf is used to encode the values, Decimal struct is the biggest type we will use.
+	buf []byte
+}
+
+type partialResult4SlidingDistinct struct {
+	inner  PartialResult
+	counts map[string]int
+}
+
+func newSlidingWindowDistinct(base baseAggFunc, inner AggFunc) *slidingWindowDistinct {
+	collators := make([]collate.Collator, 0, len(base.args))
+	for _, arg := range base.args {
+		collators = append(collators, collate.GetCollator(arg.GetType().Collate))
+	}
+	return &slidingWindowDistinct{
+		baseAggFunc: base,
+		inner:       inner,
+		collators:   collators,
+		buf:         make([]byte, types.MyDecimalStructSize),
+	}
+}
+
+func (e *slidingWindowDistinct) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	innerPr, memDelta := e.inner.AllocPartialResult()
+	p := &partialResult4SlidingDistinct{inner: innerPr, counts: make(map[string]int)}
+	return PartialResult(p), DefPartialResult4SlidingDistinctSize + memDelta
+}
+
+func (e *slidingWindowDistinct) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4SlidingDistinct)(pr)
+	e.inner.ResetPartialResult(p.inner)
+	p.counts = make(map[string]int)
+}
+
+// encodeRow encodes the arguments of a row, hasNull is true if any of them is NULL.
+func (e *slidingWindowDistinct) encodeRow(sctx sessionctx.Context, row chunk.Row) (key string, hasNull bool, err error) {
+	e.encodedBuf = e.encodedBuf[:0]
+	for i, arg := range e.args {
+		e.encodedBuf, hasNull, err = evalAndEncode(sctx, arg, e.collators[i], row, e.buf, e.encodedBuf)
+		if err != nil || hasNull {
+			return "", hasNull, err
+		}
+	}
+	return string(e.encodedBuf), false, nil
+}
+
+// add counts the row and reports whether its value is new to the frame.
+func (e *slidingWindowDistinct) add(sctx sessionctx.Context, row chunk.Row, p *partialResult4SlidingDistinct) (bool, int64, error) {
+	key, hasNull, err := e.encodeRow(sctx, row)
+	if err != nil || hasNull {
+		return false, 0, err
+	}
+	p.counts[key]++
+	if p.counts[key] == 1 {
+		return true, int64(len(key)), nil
+	}
+	return false, 0, nil
+}
+
+// remove uncounts the row and reports whether its value leaves the frame.
+func (e *slidingWindowDistinct) remove(sctx sessionctx.Context, row chunk.Row, p *partialResult4SlidingDistinct) (bool, error) {
+	key, hasNull, err := e.encodeRow(sctx, row)
+	if err != nil || hasNull {
+		return false, err
+	}
+	p.counts[key]--
+	if p.counts[key] == 0 {
+		delete(p.counts, key)
+		return true, nil
+	}
+	return false, nil
+}
+
+func (e *slidingWindowDistinct) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4SlidingDistinct)(pr)
+	newRows := make([]chunk.Row, 0, len(rowsInGroup))
+	for _, row := range rowsInGroup {
+		isNew, delta, err := e.add(sctx, row, p)
+		if err != nil {
+			return memDelta, err
+		}
+		if isNew {
+			newRows = append(newRows, row)
+			memDelta += delta
+		}
+	}
+	delta, err := e.inner.UpdatePartialResult(sctx, newRows, p.inner)
+	return memDelta + delta, err
+}
+
+// Slide implements the SlidingWindowAggFunc interface.
+func (e *slidingWindowDistinct) Slide(sctx sessionctx.Context, getRow func(uint64) chunk.Row, lastStart, lastEnd uint64, shiftStart, shiftEnd uint64, pr PartialResult) error {
+	p := (*partialResult4SlidingDistinct)(pr)
+	// The rows entering the frame are counted before the leaving ones, so the
+	// counts never go below zero even if the two ranges overlap.
+	added := make([]chunk.Row, 0, shiftEnd)
+	for i := uint64(0); i < shiftEnd; i++ {
+		row := getRow(lastEnd + i)
+		isNew, _, err := e.add(sctx, row, p)
+		if err != nil {
+			return err
+		}
+		if isNew {
+			added = append(added, row)
+		}
+	}
+	removed := make([]chunk.Row, 0, shiftStart+uint64(len(added)))
+	for i := uint64(0); i < shiftStart; i++ {
+		row := getRow(lastStart + i)
+		isGone, err := e.remove(sctx, row, p)
+		if err != nil {
+			return err
+		}
+		if isGone {
+			removed = append(removed, row)
+		}
+	}
+	numRemoved := uint64(len(removed))
+	rows := append(removed, added...)
+	return e.inner.(SlidingWindowAggFunc).Slide(sctx, func(u uint64) chunk.Row {
+		return rows[u]
+	}, 0, numRemoved, numRemoved, uint64(len(added)), p.inner)
+}
+
+func (e *slidingWindowDistinct) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4SlidingDistinct)(pr)
+	return e.inner.AppendFinalResult2Chunk(sctx, p.inner, chk)
+}
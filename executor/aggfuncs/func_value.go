@@ -238,10 +238,21 @@ func buildValueEvaluator(tp *types.FieldType) (ve valueEvaluator, memDelta int64
 	return nil, 0
 }
 
+// isNullValue reports whether the value of expr on the row is NULL, it is used
+// to skip the NULL values when IGNORE NULLS is specified.
+func isNullValue(expr expression.Expression, row chunk.Row) (bool, error) {
+	d, err := expr.Eval(row)
+	if err != nil {
+		return false, err
+	}
+	return d.IsNull(), nil
+}
+
 type firstValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4FirstValue struct {
@@ -265,12 +276,22 @@ func (v *firstValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []
 	if p.gotFirstValue {
 		return 0, nil
 	}
-	if len(rowsInGroup) > 0 {
+	for _, row := range rowsInGroup {
+		if v.ignoreNull {
+			isNull, err := isNullValue(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
 		p.gotFirstValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[0])
+		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], row)
 		if err != nil {
 			return 0, err
 		}
+		break
 	}
 	return memDelta, nil
 }
@@ -288,7 +309,8 @@ func (v *firstValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr Partial
 type lastValue struct {
 	baseAggFunc
 
-	tp *types.FieldType
+	tp         *types.FieldType
+	ignoreNull bool
 }
 
 type partialResult4LastValue struct {
@@ -298,7 +320,7 @@ type partialResult4LastValue struct {
 
 func (v *lastValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
+	p := &partialResult4LastValue{evaluator: ve}
 	return PartialResult(p), DefPartialResult4LastValueSize + veMemDelta
 }
 
@@ -309,12 +331,22 @@ func (v *lastValue) ResetPartialResult(pr PartialResult) {
 
 func (v *lastValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
 	p := (*partialResult4LastValue)(pr)
-	if len(rowsInGroup) > 0 {
+	for i := len(rowsInGroup) - 1; i >= 0; i-- {
+		if v.ignoreNull {
+			isNull, err := isNullValue(v.args[0], rowsInGroup[i])
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
 		p.gotLastValue = true
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[len(rowsInGroup)-1])
+		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[i])
 		if err != nil {
 			return 0, err
 		}
+		break
 	}
 	return memDelta, nil
 }
@@ -332,24 +364,29 @@ func (v *lastValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialR
 type nthValue struct {
 	baseAggFunc
 
-	tp  *types.FieldType
-	nth uint64
+	tp         *types.FieldType
+	nth        uint64
+	ignoreNull bool
+	fromLast   bool
 }
 
 type partialResult4NthValue struct {
 	seenRows  uint64
 	evaluator valueEvaluator
+	// lastRows keeps the last nth rows seen, it is only used for FROM LAST.
+	lastRows []chunk.Row
 }
 
 func (v *nthValue) AllocPartialResult() (pr PartialResult, memDelta int64) {
 	ve, veMemDelta := buildValueEvaluator(v.tp)
-	p := &partialResult4FirstValue{evaluator: ve}
+	p := &partialResult4NthValue{evaluator: ve}
 	return PartialResult(p), DefPartialResult4NthValueSize + veMemDelta
 }
 
 func (v *nthValue) ResetPartialResult(pr PartialResult) {
 	p := (*partialResult4NthValue)(pr)
 	p.seenRows = 0
+	p.lastRows = p.lastRows[:0]
 }
 
 func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
@@ -357,14 +394,42 @@ func (v *nthValue) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []ch
 		return 0, nil
 	}
 	p := (*partialResult4NthValue)(pr)
-	numRows := uint64(len(rowsInGroup))
-	if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
-		memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
-		if err != nil {
-			return 0, err
+	if !v.ignoreNull && !v.fromLast {
+		numRows := uint64(len(rowsInGroup))
+		if v.nth > p.seenRows && v.nth-p.seenRows <= numRows {
+			memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], rowsInGroup[v.nth-p.seenRows-1])
+			if err != nil {
+				return 0, err
+			}
+		}
+		p.seenRows += numRows
+		return memDelta, nil
+	}
+	for _, row := range rowsInGroup {
+		if v.ignoreNull {
+			isNull, err := isNullValue(v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
+			if isNull {
+				continue
+			}
+		}
+		p.seenRows++
+		if v.fromLast {
+			if uint64(len(p.lastRows)) == v.nth {
+				p.lastRows = p.lastRows[1:]
+			} else {
+				memDelta += DefRowSize
+			}
+			p.lastRows = append(p.lastRows, row)
+		} else if p.seenRows == v.nth {
+			memDelta, err = p.evaluator.evaluateRow(sctx, v.args[0], row)
+			if err != nil {
+				return 0, err
+			}
 		}
 	}
-	p.seenRows += numRows
 	return memDelta, nil
 }
 
@@ -372,8 +437,14 @@ func (v *nthValue) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialRe
 	p := (*partialResult4NthValue)(pr)
 	if v.nth == 0 || p.seenRows < v.nth {
 		chk.AppendNull(v.ordinal)
-	} else {
-		p.evaluator.appendResult(chk, v.ordinal)
+		return nil
+	}
+	if v.fromLast {
+		// The nth row from the last is the first one of the last nth rows.
+		if _, err := p.evaluator.evaluateRow(sctx, v.args[0], p.lastRows[0]); err != nil {
+			return err
+		}
 	}
+	p.evaluator.appendResult(chk, v.ordinal)
 	return nil
 }
@@ -97,6 +97,14 @@ type HashAggPartialWorker struct {
 	// chk stores the input data from child,
 	// and is reused by childExec and partial worker.
 	chk *chunk.Chunk
+	// inSpillMode indicates whether the worker is in `spill mode`.
+	// When the worker is in `spill mode`, the size of `partialResultsMap` is no longer growing and the rows
+	// of new groups are spilled to the partitions of the final workers which the groups belong to.
+	inSpillMode uint32
+	// spilledPartitions are the partitions to spill rows, the i-th one is aggregated by the i-th final worker.
+	spilledPartitions []*aggSpilledPartition
+	// tmpChksForSpill are the temp chunks for spilling, one for each partition.
+	tmpChksForSpill []*chunk.Chunk
 }
 
 // HashAggFinalWorker indicates the final workers of parallel hash agg execution,
@@ -112,6 +120,33 @@ type HashAggFinalWorker struct {
 	outputCh            chan *AfFinalResult
 	finalResultHolderCh chan *chunk.Chunk
 	groupKeys           [][]byte
+
+	// partialAggFuncs and groupByItems are used to aggregate the spilled rows.
+	partialAggFuncs []aggfuncs.AggFunc
+	groupByItems    []expression.Expression
+	// spilledPartition stores the rows spilled by the partial workers and this worker.
+	spilledPartition *aggSpilledPartition
+	// numOfSpilledChks and offsetOfSpilledChks have the same meaning as the ones in HashAggExec.
+	numOfSpilledChks    int
+	offsetOfSpilledChks int
+	// inSpillMode indicates whether the worker is in `spill mode`, the rows of new groups are spilled
+	// again and aggregated in the next round when the worker is in `spill mode`.
+	inSpillMode    uint32
+	tmpChkForSpill *chunk.Chunk
+}
+
+// aggSpilledPartition stores the rows spilled by the parallel HashAgg. The rows are
+// partitioned by the group keys in the same way as the intermediate data, so that
+// the i-th partition is aggregated by the i-th final worker.
+type aggSpilledPartition struct {
+	sync.Mutex
+	listInDisk *chunk.ListInDisk
+}
+
+func (p *aggSpilledPartition) add(chk *chunk.Chunk) error {
+	p.Lock()
+	defer p.Unlock()
+	return p.listInDisk.Add(chk)
 }
 
 // AfFinalResult indicates aggregation functions final result.
@@ -216,6 +251,8 @@ type HashAggExec struct {
 	tmpChkForSpill *chunk.Chunk
 	// spillAction save the Action for spilling.
 	spillAction *AggSpillDiskAction
+	// spilledPartitions store the rows spilled by the workers of parallel execution.
+	spilledPartitions []*aggSpilledPartition
 	// isChildDrained indicates whether the all data from child has been taken out.
 	isChildDrained bool
 }
@@ -295,7 +332,17 @@ func (e *HashAggExec) Close() error {
 			e.memTracker.ReplaceBytesUsed(0)
 		}
 	}
-	return e.baseExecutor.Close()
+	var firstErr error
+	for _, p := range e.spilledPartitions {
+		if err := p.listInDisk.Close(); firstErr == nil {
+			firstErr = err
+		}
+	}
+	e.spilledPartitions, e.spillAction = nil, nil
+	if err := e.baseExecutor.Close(); firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
 }
 
 // Open implements the Executor Open interface.
@@ -380,6 +427,17 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 	e.finalWorkers = make([]HashAggFinalWorker, finalConcurrency)
 	e.initRuntimeStats()
 
+	spillEnabled := sessionVars.TrackAggregateMemoryUsage && config.GetGlobalConfig().OOMUseTmpStorage
+	if spillEnabled {
+		e.diskTracker = disk.NewTracker(e.id, -1)
+		e.diskTracker.AttachTo(sessionVars.StmtCtx.DiskTracker)
+		e.spilledPartitions = make([]*aggSpilledPartition, finalConcurrency)
+		for i := range e.spilledPartitions {
+			e.spilledPartitions[i] = &aggSpilledPartition{listInDisk: chunk.NewListInDisk(retTypes(e.children[0]))}
+			e.spilledPartitions[i].listInDisk.GetDiskTracker().AttachTo(e.diskTracker)
+		}
+	}
+
 	// Init partial workers.
 	for i := 0; i < partialConcurrency; i++ {
 		w := HashAggPartialWorker{
@@ -392,6 +450,13 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			groupByItems:      e.GroupByItems,
 			chk:               newFirstChunk(e.children[0]),
 			groupKey:          make([][]byte, 0, 8),
+			spilledPartitions: e.spilledPartitions,
+		}
+		if spillEnabled {
+			w.tmpChksForSpill = make([]*chunk.Chunk, finalConcurrency)
+			for j := range w.tmpChksForSpill {
+				w.tmpChksForSpill[j] = newFirstChunk(e.children[0])
+			}
 		}
 		// There is a bucket in the empty partialResultsMap.
 		failpoint.Inject("ConsumeRandomPanic", nil)
@@ -413,8 +478,12 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 	// Init final workers.
 	for i := 0; i < finalConcurrency; i++ {
 		groupSet, setSize := set.NewStringSetWithMemoryUsage()
+		// Each final worker tracks its memory usage separately, so that the memory of
+		// a round of aggregating the spilled rows can be released.
+		memTracker := memory.NewTracker(e.id, -1)
+		memTracker.AttachTo(e.memTracker)
 		w := HashAggFinalWorker{
-			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, e.memTracker),
+			baseHashAggWorker:   newBaseHashAggWorker(e.ctx, e.finishCh, e.FinalAggFuncs, e.maxChunkSize, memTracker),
 			partialResultMap:    make(aggPartialResultMapper),
 			groupSet:            groupSet,
 			inputCh:             e.partialOutputChs[i],
@@ -423,9 +492,15 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 			rowBuffer:           make([]types.Datum, 0, e.Schema().Len()),
 			mutableRow:          chunk.MutRowFromTypes(retTypes(e)),
 			groupKeys:           make([][]byte, 0, 8),
+			partialAggFuncs:     e.PartialAggFuncs,
+			groupByItems:        e.GroupByItems,
+		}
+		if spillEnabled {
+			w.spilledPartition = e.spilledPartitions[i]
+			w.tmpChkForSpill = newFirstChunk(e.children[0])
 		}
 		// There is a bucket in the empty partialResultsMap.
-		e.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
+		w.memTracker.Consume(defBucketMemoryUsage*(1<<w.BInMap) + setSize)
 		if e.stats != nil {
 			w.stats = &AggWorkerStat{}
 			e.stats.FinalStats = append(e.stats.FinalStats, w.stats)
@@ -434,6 +509,9 @@ func (e *HashAggExec) initForParallelExec(ctx sessionctx.Context) {
 		e.finalWorkers[i].finalResultHolderCh <- newFirstChunk(e)
 	}
 
+	if spillEnabled {
+		sessionVars.StmtCtx.MemTracker.FallbackOldAndSetNewActionForSoftLimit(e.ActionSpill())
+	}
 	e.parallelExecInitialized = true
 }
 
@@ -470,6 +548,9 @@ func (w *HashAggPartialWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitG
 		if needShuffle {
 			w.shuffleIntermData(sc, finalConcurrency)
 		}
+		if err := w.flushSpilledRows(); err != nil {
+			w.globalOutputCh <- &AfFinalResult{err: err}
+		}
 		w.memTracker.Consume(-w.chk.MemoryUsage())
 		if w.stats != nil {
 			w.stats.WorkerTime += int64(time.Since(start))
@@ -518,13 +599,26 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 		return err
 	}
 
-	partialResults := w.getPartialResult(sc, w.groupKey, w.partialResultsMap)
+	groupKey, rowIdx := w.groupKey, []int(nil)
+	if atomic.LoadUint32(&w.inSpillMode) == 1 {
+		groupKey, rowIdx, err = w.spillNewGroups(chk)
+		if err != nil {
+			return err
+		}
+	}
+	partialResults := w.getPartialResult(sc, groupKey, w.partialResultsMap)
 	numRows := chk.NumRows()
+	if rowIdx != nil {
+		numRows = len(rowIdx)
+	}
 	rows := make([]chunk.Row, 1)
 	allMemDelta := int64(0)
 	for i := 0; i < numRows; i++ {
 		for j, af := range w.aggFuncs {
 			rows[0] = chk.GetRow(i)
+			if rowIdx != nil {
+				rows[0] = chk.GetRow(rowIdx[i])
+			}
 			memDelta, err := af.UpdatePartialResult(ctx, rows, partialResults[i][j])
 			if err != nil {
 				return err
@@ -536,6 +630,44 @@ func (w *HashAggPartialWorker) updatePartialResult(ctx sessionctx.Context, sc *s
 	return nil
 }
 
+// spillNewGroups spills the rows whose groups are not in `partialResultsMap` yet,
+// and returns the group keys and the indexes of the rows left.
+func (w *HashAggPartialWorker) spillNewGroups(chk *chunk.Chunk) (groupKey [][]byte, rowIdx []int, err error) {
+	numRows := chk.NumRows()
+	groupKey, rowIdx = make([][]byte, 0, numRows), make([]int, 0, numRows)
+	for i := 0; i < numRows; i++ {
+		if _, ok := w.partialResultsMap[string(w.groupKey[i])]; ok {
+			groupKey = append(groupKey, w.groupKey[i])
+			rowIdx = append(rowIdx, i)
+			continue
+		}
+		partitionIdx := int(murmur3.Sum32(w.groupKey[i])) % len(w.spilledPartitions)
+		tmpChk := w.tmpChksForSpill[partitionIdx]
+		tmpChk.AppendRow(chk.GetRow(i))
+		if tmpChk.IsFull() {
+			if err = w.spilledPartitions[partitionIdx].add(tmpChk); err != nil {
+				return nil, nil, err
+			}
+			tmpChk.Reset()
+		}
+	}
+	return groupKey, rowIdx, nil
+}
+
+// flushSpilledRows spills the rows left in the temp chunks.
+func (w *HashAggPartialWorker) flushSpilledRows() error {
+	for i, tmpChk := range w.tmpChksForSpill {
+		if tmpChk.NumRows() == 0 {
+			continue
+		}
+		if err := w.spilledPartitions[i].add(tmpChk); err != nil {
+			return err
+		}
+		tmpChk.Reset()
+	}
+	return nil
+}
+
 // shuffleIntermData shuffles the intermediate data of partial workers to corresponded final workers.
 // We only support parallel execution for single-machine, so process of encode and decode can be skipped.
 func (w *HashAggPartialWorker) shuffleIntermData(sc *stmtctx.StatementContext, finalConcurrency int) {
@@ -756,7 +888,127 @@ func (w *HashAggFinalWorker) run(ctx sessionctx.Context, waitGroup *sync.WaitGro
 	if err := w.consumeIntermData(ctx); err != nil {
 		w.outputCh <- &AfFinalResult{err: err}
 	}
-	w.getFinalResult(ctx)
+	for {
+		if err := w.consumeSpilledRows(ctx); err != nil {
+			w.outputCh <- &AfFinalResult{err: err}
+			return
+		}
+		w.getFinalResult(ctx)
+		if !w.resetSpillMode() {
+			return
+		}
+	}
+}
+
+// consumeSpilledRows aggregates the spilled rows of the current round into the final results.
+// If the worker is in `spill mode`, the rows of new groups are spilled again and aggregated in the next round.
+func (w *HashAggFinalWorker) consumeSpilledRows(sctx sessionctx.Context) (err error) {
+	if w.spilledPartition == nil {
+		return nil
+	}
+	listInDisk := w.spilledPartition.listInDisk
+	defer func() {
+		if w.tmpChkForSpill.NumRows() > 0 && err == nil {
+			err = listInDisk.Add(w.tmpChkForSpill)
+			w.tmpChkForSpill.Reset()
+		}
+	}()
+	execStart := time.Now()
+	w.numOfSpilledChks = listInDisk.NumChunks()
+	for ; w.offsetOfSpilledChks < w.numOfSpilledChks; w.offsetOfSpilledChks++ {
+		chk, err := listInDisk.GetChunk(w.offsetOfSpilledChks)
+		if err != nil {
+			return err
+		}
+		if err = w.consumeSpilledChunk(sctx, chk); err != nil {
+			return err
+		}
+	}
+	if w.stats != nil {
+		w.stats.ExecTime += int64(time.Since(execStart))
+	}
+	return nil
+}
+
+// consumeSpilledChunk aggregates the rows of a spilled chunk into the partial results by
+// `partialAggFuncs` first, then merges them into the final results.
+func (w *HashAggFinalWorker) consumeSpilledChunk(sctx sessionctx.Context, chk *chunk.Chunk) (err error) {
+	memSize := getGroupKeyMemUsage(w.groupKeys)
+	w.groupKeys, err = getGroupKey(sctx, chk, w.groupKeys, w.groupByItems)
+	w.memTracker.Consume(getGroupKeyMemUsage(w.groupKeys) - memSize)
+	if err != nil {
+		return err
+	}
+	numRows := chk.NumRows()
+	groupKeys := make([][]byte, 0, numRows)
+	partialResultMap := make(aggPartialResultMapper)
+	rows := make([]chunk.Row, 1)
+	allMemDelta := int64(0)
+	for i := 0; i < numRows; i++ {
+		groupKey := string(w.groupKeys[i])
+		if !w.groupSet.Exist(groupKey) {
+			if atomic.LoadUint32(&w.inSpillMode) == 1 && w.groupSet.Count() > 0 {
+				w.tmpChkForSpill.AppendRow(chk.GetRow(i))
+				if w.tmpChkForSpill.IsFull() {
+					if err = w.spilledPartition.listInDisk.Add(w.tmpChkForSpill); err != nil {
+						return err
+					}
+					w.tmpChkForSpill.Reset()
+				}
+				continue
+			}
+			allMemDelta += w.groupSet.Insert(groupKey)
+		}
+		partialResults, ok := partialResultMap[groupKey]
+		if !ok {
+			partialResults = make([]aggfuncs.PartialResult, 0, len(w.partialAggFuncs))
+			for _, af := range w.partialAggFuncs {
+				partialResult, _ := af.AllocPartialResult()
+				partialResults = append(partialResults, partialResult)
+			}
+			partialResultMap[groupKey] = partialResults
+			groupKeys = append(groupKeys, w.groupKeys[i])
+		}
+		rows[0] = chk.GetRow(i)
+		for j, af := range w.partialAggFuncs {
+			if _, err = af.UpdatePartialResult(sctx, rows, partialResults[j]); err != nil {
+				return err
+			}
+		}
+	}
+	finalPartialResults := w.getPartialResult(sctx.GetSessionVars().StmtCtx, groupKeys, w.partialResultMap)
+	for i, groupKey := range groupKeys {
+		partialResults := partialResultMap[string(groupKey)]
+		for j, af := range w.aggFuncs {
+			memDelta, err := af.MergePartialResult(sctx, partialResults[j], finalPartialResults[i][j])
+			if err != nil {
+				return err
+			}
+			allMemDelta += memDelta
+		}
+	}
+	w.memTracker.Consume(allMemDelta)
+	return nil
+}
+
+// resetSpillMode resets the worker for the next round, it returns false if there
+// is no rows spilled in the current round.
+func (w *HashAggFinalWorker) resetSpillMode() bool {
+	if w.spilledPartition == nil || w.numOfSpilledChks == w.spilledPartition.listInDisk.NumChunks() {
+		return false
+	}
+	select {
+	case <-w.finishCh:
+		return false
+	default:
+	}
+	var setSize int64
+	w.groupSet, setSize = set.NewStringSetWithMemoryUsage()
+	w.partialResultMap = make(aggPartialResultMapper)
+	w.BInMap = 0
+	w.memTracker.ReplaceBytesUsed(defBucketMemoryUsage + setSize)
+	atomic.StoreUint32(&w.inSpillMode, 0)
+	return true
 }
 
 // Next implements the Executor Next interface.
@@ -1890,10 +2142,26 @@ func (e *HashAggExec) ActionSpill() *AggSpillDiskAction {
 	return e.spillAction
 }
 
+// setSpillMode sets HashAggExec, or all the workers of the parallel execution, to `spill mode`.
+// It returns false if they are in `spill mode` already.
+func (e *HashAggExec) setSpillMode() bool {
+	if e.isUnparallelExec {
+		return atomic.CompareAndSwapUint32(&e.inSpillMode, 0, 1)
+	}
+	changed := false
+	for i := range e.partialWorkers {
+		changed = atomic.CompareAndSwapUint32(&e.partialWorkers[i].inSpillMode, 0, 1) || changed
+	}
+	for i := range e.finalWorkers {
+		changed = atomic.CompareAndSwapUint32(&e.finalWorkers[i].inSpillMode, 0, 1) || changed
+	}
+	return changed
+}
+
 // maxSpillTimes indicates how many times the data can spill at most.
 const maxSpillTimes = 10
 
-// AggSpillDiskAction implements memory.ActionOnExceed for unparalleled HashAgg.
+// AggSpillDiskAction implements memory.ActionOnExceed for HashAgg.
 // If the memory quota of a query is exceeded, AggSpillDiskAction.Action is
 // triggered.
 type AggSpillDiskAction struct {
@@ -1905,13 +2173,12 @@ type AggSpillDiskAction struct {
 // Action set HashAggExec spill mode.
 func (a *AggSpillDiskAction) Action(t *memory.Tracker) {
 	// Guarantee that processed data is at least 20% of the threshold, to avoid spilling too frequently.
-	if atomic.LoadUint32(&a.e.inSpillMode) == 0 && a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 {
+	if a.spillTimes < maxSpillTimes && a.e.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 && a.e.setSpillMode() {
 		a.spillTimes++
 		logutil.BgLogger().Info("memory exceeds quota, set aggregate mode to spill-mode",
 			zap.Uint32("spillTimes", a.spillTimes),
 			zap.Int64("consumed", t.BytesConsumed()),
 			zap.Int64("quota", t.GetBytesLimit()))
-		atomic.StoreUint32(&a.e.inSpillMode, 1)
 		return
 	}
 	if fallback := a.GetFallback(); fallback != nil {
@@ -1001,7 +1001,7 @@ func benchmarkHashJoinExec(b *testing.B, casTest *hashJoinTestCase, opt1, opt2 *
 
 	if testResult {
 		time.Sleep(200 * time.Millisecond)
-		if spilled := exec.rowContainer.alreadySpilledSafeForTest(); spilled != casTest.disk {
+		if spilled := exec.rowContainer.alreadySpilledSafeForTest() || exec.spilledPartitions != nil; spilled != casTest.disk {
 			b.Fatal("wrong usage with disk:", spilled, casTest.disk)
 		}
 	}
@@ -1195,7 +1195,7 @@ func benchmarkBuildHashTable(b *testing.B, casTest *hashJoinTestCase, dataSource
 
 	if testResult {
 		time.Sleep(200 * time.Millisecond)
-		if exec.rowContainer.alreadySpilledSafeForTest() != casTest.disk {
+		if spilled := exec.rowContainer.alreadySpilledSafeForTest() || exec.spilledPartitions != nil; spilled != casTest.disk {
 			b.Fatal("wrong usage with disk")
 		}
 	}
@@ -228,6 +228,8 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -238,6 +240,12 @@ func (b *executorBuilder) build(p plannercore.Plan) Executor {
 		return b.buildTableReader(v)
 	case *plannercore.PhysicalTableSample:
 		return b.buildTableSample(v)
+	case *plannercore.PhysicalFullTextIndexLookUp:
+		return b.buildFullTextIndexLookUp(v)
+	case *plannercore.PhysicalSpatialIndexLookUp:
+		return b.buildSpatialIndexLookUp(v)
+	case *plannercore.PhysicalMVIndexLookUp:
+		return b.buildMVIndexLookUp(v)
 	case *plannercore.PhysicalIndexReader:
 		return b.buildIndexReader(v)
 	case *plannercore.PhysicalIndexLookUpReader:
@@ -429,6 +437,9 @@ func (b *executorBuilder) buildCheckTable(v *plannercore.CheckTable) Executor {
 		retCh:        make(chan error, len(readerExecs)),
 		checkIndex:   v.CheckIndex,
 	}
+	for _, name := range v.Partitions {
+		e.partitions = append(e.partitions, name.O)
+	}
 	return e
 }
 
@@ -474,6 +485,14 @@ func (b *executorBuilder) buildRecoverIndex(v *plannercore.RecoverIndex) Executo
 		b.err = errors.Errorf("index `%v` is not found in table `%v`.", v.IndexName, v.Table.Name.O)
 		return nil
 	}
+	if index.Meta().IsFulltext() || index.Meta().IsSpatial() {
+		b.err = errors.Errorf("%s index `%v` can't be recovered.", index.Meta().Tp, v.IndexName)
+		return nil
+	}
+	if index.Meta().MVIndex {
+		b.err = errors.Errorf("multi-valued index `%v` can't be recovered.", v.IndexName)
+		return nil
+	}
 	e := &RecoverIndexExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
 		columns:      buildIdxColsConcatHandleCols(tblInfo, index.Meta()),
@@ -534,6 +553,14 @@ func (b *executorBuilder) buildCleanupIndex(v *plannercore.CleanupIndex) Executo
 		b.err = errors.Errorf("index `%v` is not found in table `%v`.", v.IndexName, v.Table.Name.O)
 		return nil
 	}
+	if index.Meta().IsFulltext() || index.Meta().IsSpatial() {
+		b.err = errors.Errorf("%s index `%v` can't be cleaned up.", index.Meta().Tp, v.IndexName)
+		return nil
+	}
+	if index.Meta().MVIndex {
+		b.err = errors.Errorf("multi-valued index `%v` can't be cleaned up.", v.IndexName)
+		return nil
+	}
 	e := &CleanupIndexExec{
 		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
 		columns:      buildIdxColsConcatHandleCols(tblInfo, index.Meta()),
@@ -824,12 +851,23 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) Executor {
 		hasRefCols:                v.NeedFillDefaultValue,
 		SelectExec:                selectExec,
 		rowLen:                    v.RowLen,
+		fkChecks:                  b.buildFKCheckExecs(v.FKChecks),
+		fkCascades:                b.buildFKCascadeExecs(v.FKCascades),
 	}
 	err := ivs.initInsertColumns()
 	if err != nil {
 		b.err = err
 		return nil
 	}
+	ivs.checkConstraints, b.err = table.BuildWritableConstraints(b.ctx, v.Table.Meta())
+	if b.err != nil {
+		return nil
+	}
+	ivs.viewChecks = v.ViewChecks
+	ivs.triggers, b.err = b.buildTriggerExec(v.Table)
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -873,6 +911,10 @@ func (b *executorBuilder) buildLoadData(v *plannercore.LoadData) Executor {
 		b.err = err
 		return nil
 	}
+	insertVal.checkConstraints, b.err = table.BuildWritableConstraints(b.ctx, tbl.Meta())
+	if b.err != nil {
+		return nil
+	}
 	loadDataExec := &LoadDataExec{
 		baseExecutor: newBaseExecutor(b.ctx, nil, v.ID()),
 		IsLocal:      v.IsLocal,
@@ -1088,6 +1130,9 @@ func (b *executorBuilder) buildUnionScanFromReader(reader Executor, v *plannerco
 		us.columns = x.columns
 		us.table = x.table
 		us.virtualColumnIndex = buildVirtualColumnIndex(us.Schema(), us.columns)
+	case *FullTextIndexLookUpExec, *SpatialIndexLookUpExec, *MVIndexLookUpExec:
+		// The rows written by the transaction are read by the KV index lookup executors themselves.
+		return originReader
 	default:
 		// The mem table will not be written by sql directly, so we can omit the union scan to avoid err reporting.
 		return originReader
@@ -1434,6 +1479,15 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) Execu
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) Executor {
+	return &JSONTableExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		root:         v.Root,
+		asName:       v.AsName,
+	}
+}
+
 // `getSnapshotTS` returns the timestamp of the snapshot that a reader should read.
 func (b *executorBuilder) getSnapshotTS() (uint64, error) {
 	// `refreshForUpdateTSForRC` should always be invoked before returning the cached value to
@@ -1580,6 +1634,8 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableTriggers),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -1610,7 +1666,12 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) Executo
 			strings.ToLower(infoschema.TableClientErrorsSummaryByUser),
 			strings.ToLower(infoschema.TableClientErrorsSummaryByHost),
 			strings.ToLower(infoschema.TableAttributes),
-			strings.ToLower(infoschema.TablePlacementRules):
+			strings.ToLower(infoschema.TablePlacementRules),
+			strings.ToLower(infoschema.TableCheckConstraints),
+			strings.ToLower(infoschema.TableAdvisoryLocks),
+			strings.ToLower(infoschema.ClusterTableAdvisoryLocks),
+			strings.ToLower(infoschema.TableInstancePlanCache),
+			strings.ToLower(infoschema.ClusterTableInstancePlanCache):
 			return &MemTableReaderExec{
 				baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -1965,6 +2026,18 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 	if b.err != nil {
 		return nil
 	}
+	tblID2Constraints := make(map[int64][]*table.Constraint, len(tblID2table))
+	for tblID, tbl := range tblID2table {
+		tblID2Constraints[tblID], b.err = table.BuildWritableConstraints(b.ctx, tbl.Meta())
+		if b.err != nil {
+			return nil
+		}
+	}
+	triggers, err := b.buildTblID2TriggerExecs(tblID2table)
+	if err != nil {
+		b.err = err
+		return nil
+	}
 	b.inUpdateStmt = true
 	updateExec := &UpdateExec{
 		baseExecutor:              base,
@@ -1973,8 +2046,13 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) Executor {
 		virtualAssignmentsOffset:  v.VirtualAssignmentsOffset,
 		multiUpdateOnSameTable:    multiUpdateOnSameTable,
 		tblID2table:               tblID2table,
+		tblID2Constraints:         tblID2Constraints,
+		viewChecks:                v.ViewChecks,
 		tblColPosInfos:            v.TblColPosInfos,
 		assignFlag:                assignFlag,
+		fkChecks:                  b.buildTblID2FKCheckExecs(v.FKChecks),
+		fkCascades:                b.buildTblID2FKCascadeExecs(v.FKCascades),
+		triggers:                  triggers,
 	}
 	return updateExec
 }
@@ -2010,6 +2088,11 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 	if b.err != nil {
 		return nil
 	}
+	triggers, err := b.buildTblID2TriggerExecs(tblID2table)
+	if err != nil {
+		b.err = err
+		return nil
+	}
 	b.inDeleteStmt = true
 	base := newBaseExecutor(b.ctx, v.Schema(), v.ID(), selExec)
 	base.initCap = chunk.ZeroCapacity
@@ -2018,6 +2101,9 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) Executor {
 		tblID2Table:    tblID2table,
 		IsMultiTable:   v.IsMultiTable,
 		tblColPosInfos: v.TblColPosInfos,
+		fkChecks:       b.buildTblID2FKCheckExecs(v.FKChecks),
+		fkCascades:     b.buildTblID2FKCascadeExecs(v.FKCascades),
+		triggers:       triggers,
 	}
 	return deleteExec
 }
@@ -4127,12 +4213,12 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 	partialResults := make([]aggfuncs.PartialResult, 0, len(v.WindowFuncDescs))
 	resultColIdx := v.Schema().Len() - len(v.WindowFuncDescs)
 	for _, desc := range v.WindowFuncDescs {
-		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, desc.Name, desc.Args, false)
+		aggDesc, err := aggregation.NewAggFuncDesc(b.ctx, desc.Name, desc.Args, desc.HasDistinct)
 		if err != nil {
 			b.err = err
 			return nil
 		}
-		agg := aggfuncs.BuildWindowFunctions(b.ctx, aggDesc, resultColIdx, orderByCols)
+		agg := aggfuncs.BuildWindowFunctions(b.ctx, aggDesc, resultColIdx, orderByCols, desc.IgnoreNull, desc.FromLast)
 		windowFuncs = append(windowFuncs, agg)
 		partialResult, _ := agg.AllocPartialResult()
 		partialResults = append(partialResults, partialResult)
@@ -4168,6 +4254,8 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 				exec.orderByCols = orderByCols
 				exec.expectedCmpResult = cmpResult
 				exec.isRangeFrame = true
+			} else if v.Frame.Type == ast.Groups {
+				exec.groups = newPeerGroups(orderByCols)
 			}
 		}
 		return exec
@@ -4185,6 +4273,14 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) Executor {
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
 		}
+	} else if v.Frame.Type == ast.Groups {
+		processor = &groupFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			groups:         newPeerGroups(orderByCols),
+		}
 	} else {
 		cmpResult := int64(-1)
 		if len(v.OrderBy) > 0 && v.OrderBy[0].Desc {
@@ -4524,6 +4620,61 @@ func (b *executorBuilder) buildTableSample(v *plannercore.PhysicalTableSample) *
 	return e
 }
 
+func (b *executorBuilder) buildFullTextIndexLookUp(v *plannercore.PhysicalFullTextIndexLookUp) Executor {
+	startTS, err := b.getSnapshotTS()
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	e := &FullTextIndexLookUpExec{
+		kvIndexLookUpExec: b.newKVIndexLookUpExec(v, v.Table, v.Index, v.Columns, startTS),
+		against:           v.Against,
+		modifier:          v.Modifier,
+	}
+	e.buildVirtualColumnInfo()
+	return e
+}
+
+func (b *executorBuilder) buildSpatialIndexLookUp(v *plannercore.PhysicalSpatialIndexLookUp) Executor {
+	startTS, err := b.getSnapshotTS()
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	e := &SpatialIndexLookUpExec{
+		kvIndexLookUpExec: b.newKVIndexLookUpExec(v, v.Table, v.Index, v.Columns, startTS),
+		shape:             v.Shape,
+	}
+	e.buildVirtualColumnInfo()
+	return e
+}
+
+func (b *executorBuilder) buildMVIndexLookUp(v *plannercore.PhysicalMVIndexLookUp) Executor {
+	startTS, err := b.getSnapshotTS()
+	if err != nil {
+		b.err = err
+		return nil
+	}
+	e := &MVIndexLookUpExec{
+		kvIndexLookUpExec: b.newKVIndexLookUpExec(v, v.Table, v.Index, v.Columns, startTS),
+		values:            v.Values,
+	}
+	e.buildVirtualColumnInfo()
+	return e
+}
+
+func (b *executorBuilder) newKVIndexLookUpExec(v plannercore.PhysicalPlan, tblInfo *model.TableInfo, idxInfo *model.IndexInfo,
+	columns []*model.ColumnInfo, startTS uint64) kvIndexLookUpExec {
+	return kvIndexLookUpExec{
+		baseExecutor: newBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		tblInfo:      tblInfo,
+		idxInfo:      idxInfo,
+		startTS:      startTS,
+		columns:      columns,
+		rowDecoder:   NewRowDecoder(b.ctx, v.Schema(), tblInfo),
+	}
+}
+
 func (b *executorBuilder) buildCTE(v *plannercore.PhysicalCTE) Executor {
 	// 1. Build seedPlan.
 	if b.Ti != nil {
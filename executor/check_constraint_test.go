@@ -0,0 +1,103 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestCheckConstraintOnInsert(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int check (b > 0), c int as (a + b), constraint c_lt_10 check (c < 10))")
+
+	tk.MustExec("insert into t (a, b) values (1, 1), (2, null)")
+	tk.MustGetErrMsg("insert into t (a, b) values (1, 0)", "[table:3819]Check constraint 't_chk_1' is violated.")
+	tk.MustGetErrMsg("insert into t (a, b) values (5, 5)", "[table:3819]Check constraint 'c_lt_10' is violated.")
+	tk.MustGetErrCode("replace into t (a, b) values (1, -1)", errno.ErrCheckConstraintViolated)
+	tk.MustGetErrCode("insert into t (a, b) select 1, -1", errno.ErrCheckConstraintViolated)
+
+	// INSERT IGNORE skips the violating rows.
+	tk.MustExec("insert ignore into t (a, b) values (3, 1), (4, -1)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_chk_1' is violated."))
+	tk.MustQuery("select a, b, c from t order by a").Check(testkit.Rows("1 1 2", "2 <nil> <nil>", "3 1 4"))
+
+	// The not enforced constraint isn't checked.
+	tk.MustExec("alter table t alter check c_lt_10 not enforced")
+	tk.MustExec("insert into t (a, b) values (5, 5)")
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("4"))
+}
+
+func TestCheckConstraintOnUpdate(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int, check (a < b))")
+	tk.MustExec("create table t2 (id int primary key, a int check (a >= 0))")
+	tk.MustExec("insert into t values (1, 1, 2), (2, 2, 3)")
+	tk.MustExec("insert into t2 values (1, 1)")
+
+	tk.MustGetErrCode("update t set a = 5 where id = 1", errno.ErrCheckConstraintViolated)
+	tk.MustExec("update t set a = a + 1, b = b + 2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 2 4", "2 3 5"))
+	tk.MustGetErrCode("update t, t2 set t.a = 0, t2.a = -1 where t.id = t2.id", errno.ErrCheckConstraintViolated)
+
+	// UPDATE IGNORE skips the violating rows.
+	tk.MustExec("update ignore t set a = 4")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 3819 Check constraint 't_chk_1' is violated."))
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 2 4", "2 4 5"))
+
+	// The updated row of ON DUPLICATE KEY UPDATE is checked as well.
+	tk.MustGetErrCode("insert into t values (1, 0, 1) on duplicate key update a = 10", errno.ErrCheckConstraintViolated)
+	tk.MustExec("insert into t values (1, 0, 1) on duplicate key update a = 3")
+	tk.MustQuery("select * from t where id = 1").Check(testkit.Rows("1 3 4"))
+}
+
+func TestCheckConstraintsInfoSchema(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database check_constraint_is")
+	tk.MustExec("use check_constraint_is")
+	tk.MustExec("create table t (a int, b varchar(10) check (b like 'a%'), constraint a_b check (a > 0 and length(b) < 5))")
+	tk.MustQuery("select * from information_schema.check_constraints where constraint_schema = 'check_constraint_is' order by constraint_name").Check(testkit.Rows(
+		"def check_constraint_is a_b (`a` > 0 and length(`b`) < 5)",
+		"def check_constraint_is t_chk_1 (`b` like _utf8mb4'a%')"))
+	tk.MustQuery("select constraint_name, constraint_type from information_schema.table_constraints where table_schema = 'check_constraint_is'").Sort().Check(testkit.Rows(
+		"a_b CHECK", "t_chk_1 CHECK"))
+
+	// The expression with '%' is verified against the existing rows as well.
+	tk.MustExec("insert into t values (1, 'ab')")
+	tk.MustGetErrCode("alter table t add constraint b_mod check (a % 2 = 0)", errno.ErrCheckConstraintViolated)
+	tk.MustExec("alter table t add constraint b_mod check (a % 2 = 1)")
+	tk.MustExec("alter table t drop check a_b")
+	tk.MustQuery("select constraint_name from information_schema.check_constraints where constraint_schema = 'check_constraint_is' order by constraint_name").Check(testkit.Rows(
+		"b_mod", "t_chk_1"))
+}
@@ -201,6 +201,14 @@ func (e *DDLExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeDropPlacementPolicy(x)
 	case *ast.AlterPlacementPolicyStmt:
 		err = e.executeAlterPlacementPolicy(x)
+	case *ast.CreateRoutineStmt:
+		err = e.executeCreateRoutine(x)
+	case *ast.DropRoutineStmt:
+		err = e.executeDropRoutine(x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
 	}
 	if err != nil {
 		// If the owner return ErrTableNotExists error when running this DDL, it may be caused by schema changed,
@@ -857,3 +865,19 @@ func (e *DDLExec) executeDropPlacementPolicy(s *ast.DropPlacementPolicyStmt) err
 func (e *DDLExec) executeAlterPlacementPolicy(s *ast.AlterPlacementPolicyStmt) error {
 	return domain.GetDomain(e.ctx).DDL().AlterPlacementPolicy(e.ctx, s)
 }
+
+func (e *DDLExec) executeCreateRoutine(s *ast.CreateRoutineStmt) error {
+	return domain.GetDomain(e.ctx).DDL().CreateRoutine(e.ctx, s)
+}
+
+func (e *DDLExec) executeDropRoutine(s *ast.DropRoutineStmt) error {
+	return domain.GetDomain(e.ctx).DDL().DropRoutine(e.ctx, s)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	return domain.GetDomain(e.ctx).DDL().CreateTrigger(e.ctx, s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.ctx).DDL().DropTrigger(e.ctx, s)
+}
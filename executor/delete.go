@@ -43,18 +43,28 @@ type DeleteExec struct {
 	// the columns ordinals is present in ordinal range format, @see plannercore.TblColPosInfos
 	tblColPosInfos plannercore.TblColPosInfoSlice
 	memTracker     *memory.Tracker
+
+	fkChecks   map[int64][]*FKCheckExec
+	fkCascades map[int64][]*FKCascadeExec
+	triggers   map[int64]*triggerExec
 }
 
 // Next implements the Executor Next interface.
 func (e *DeleteExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	req.Reset()
+	var err error
 	if e.IsMultiTable {
-		return e.deleteMultiTablesByChunk(ctx)
+		err = e.deleteMultiTablesByChunk(ctx)
+	} else {
+		err = e.deleteSingleTableByChunk(ctx)
+	}
+	if err != nil {
+		return err
 	}
-	return e.deleteSingleTableByChunk(ctx)
+	return e.executeFKCascades(ctx)
 }
 
-func (e *DeleteExec) deleteOneRow(tbl table.Table, handleCols plannercore.HandleCols, isExtraHandle bool, row []types.Datum) error {
+func (e *DeleteExec) deleteOneRow(ctx context.Context, tbl table.Table, handleCols plannercore.HandleCols, isExtraHandle bool, row []types.Datum) error {
 	end := len(row)
 	if isExtraHandle {
 		end--
@@ -63,7 +73,7 @@ func (e *DeleteExec) deleteOneRow(tbl table.Table, handleCols plannercore.Handle
 	if err != nil {
 		return err
 	}
-	err = e.removeRow(e.ctx, tbl, handle, row[:end])
+	err = e.removeRow(ctx, tbl, handle, row[:end])
 	if err != nil {
 		return err
 	}
@@ -130,7 +140,7 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 				datumRow = append(datumRow, datum)
 			}
 
-			err = e.deleteOneRow(tbl, handleCols, isExtrahandle, datumRow)
+			err = e.deleteOneRow(ctx, tbl, handleCols, isExtrahandle, datumRow)
 			if err != nil {
 				return err
 			}
@@ -143,6 +153,9 @@ func (e *DeleteExec) deleteSingleTableByChunk(ctx context.Context) error {
 }
 
 func (e *DeleteExec) doBatchDelete(ctx context.Context) error {
+	if err := e.executeFKCascades(ctx); err != nil {
+		return err
+	}
 	txn, err := e.ctx.Txn(false)
 	if err != nil {
 		return ErrBatchInsertFail.GenWithStack("BatchDelete failed with error: %v", err)
@@ -201,14 +214,14 @@ func (e *DeleteExec) deleteMultiTablesByChunk(ctx context.Context) error {
 		chk = chunk.Renew(chk, e.maxChunkSize)
 	}
 
-	return e.removeRowsInTblRowMap(tblRowMap)
+	return e.removeRowsInTblRowMap(ctx, tblRowMap)
 }
 
-func (e *DeleteExec) removeRowsInTblRowMap(tblRowMap tableRowMapType) error {
+func (e *DeleteExec) removeRowsInTblRowMap(ctx context.Context, tblRowMap tableRowMapType) error {
 	for id, rowMap := range tblRowMap {
 		var err error
 		rowMap.Range(func(h kv.Handle, val interface{}) bool {
-			err = e.removeRow(e.ctx, e.tblID2Table[id], h, val.([]types.Datum))
+			err = e.removeRow(ctx, e.tblID2Table[id], h, val.([]types.Datum))
 			return err == nil
 		})
 		if err != nil {
@@ -219,18 +232,49 @@ func (e *DeleteExec) removeRowsInTblRowMap(tblRowMap tableRowMapType) error {
 	return nil
 }
 
-func (e *DeleteExec) removeRow(ctx sessionctx.Context, t table.Table, h kv.Handle, data []types.Datum) error {
+func (e *DeleteExec) removeRow(ctx context.Context, t table.Table, h kv.Handle, data []types.Datum) error {
+	triggers := e.triggers[t.Meta().ID]
+	if err := triggers.fire(ctx, model.TriggerTimingBefore, model.TriggerEventDelete, data, nil, nil); err != nil {
+		return err
+	}
 	txnState, err := e.ctx.Txn(false)
 	if err != nil {
 		return err
 	}
 	memUsageOfTxnState := txnState.Size()
-	err = t.RemoveRecord(ctx, h, data)
+	err = t.RemoveRecord(e.ctx, h, data)
 	if err != nil {
 		return err
 	}
 	e.memTracker.Consume(int64(txnState.Size() - memUsageOfTxnState))
-	ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	e.ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	if err = e.handleFKTriggers(e.ctx, t.Meta().ID, data); err != nil {
+		return err
+	}
+	return triggers.fire(ctx, model.TriggerTimingAfter, model.TriggerEventDelete, data, nil, nil)
+}
+
+func (e *DeleteExec) handleFKTriggers(ctx sessionctx.Context, tblID int64, data []types.Datum) error {
+	for _, fkc := range e.fkChecks[tblID] {
+		if err := fkc.deleteRowNeedToCheck(context.TODO(), data); err != nil {
+			return err
+		}
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	for _, fkc := range e.fkCascades[tblID] {
+		if err := fkc.onDeleteRow(sc, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *DeleteExec) executeFKCascades(ctx context.Context) error {
+	for _, info := range e.tblColPosInfos {
+		if err := executeFKCascades(ctx, e.fkCascades[info.TblID]); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -53,6 +53,9 @@ var (
 	ErrIllegalPrivilegeLevel         = dbterror.ClassExecutor.NewStd(mysql.ErrIllegalPrivilegeLevel)
 	ErrInvalidSplitRegionRanges      = dbterror.ClassExecutor.NewStd(mysql.ErrInvalidSplitRegionRanges)
 	ErrViewInvalid                   = dbterror.ClassExecutor.NewStd(mysql.ErrViewInvalid)
+	ErrRowIsReferenced2              = dbterror.ClassExecutor.NewStd(mysql.ErrRowIsReferenced2)
+	ErrNoReferencedRow2              = dbterror.ClassExecutor.NewStd(mysql.ErrNoReferencedRow2)
+	ErrFKDepthExceeded               = dbterror.ClassExecutor.NewStd(mysql.ErrFKDepthExceeded)
 
 	ErrBRIEBackupFailed              = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEBackupFailed)
 	ErrBRIERestoreFailed             = dbterror.ClassExecutor.NewStd(mysql.ErrBRIERestoreFailed)
@@ -65,6 +68,17 @@ var (
 	ErrPluginIsNotLoaded             = dbterror.ClassExecutor.NewStd(mysql.ErrPluginIsNotLoaded)
 	ErrSetPasswordAuthPlugin         = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
 	ErrFuncNotEnabled                = dbterror.ClassExecutor.NewStdErr(mysql.ErrNotSupportedYet, parser_mysql.Message("%-.32s is not supported. To enable this experimental feature, set '%-.32s' in the configuration file.", nil))
+	ErrMissingJSONTableValue         = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue           = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
+	ErrJTValueOutOfRange             = dbterror.ClassExecutor.NewStd(mysql.ErrJTValueOutOfRange)
+	ErrSpBadSelect                   = dbterror.ClassExecutor.NewStd(mysql.ErrSpBadselect)
+	ErrSpCursorAlreadyOpen           = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen               = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpWrongNoOfFetchArgs          = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData                 = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpRecursionLimit              = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+	ErrTooManyRows                   = dbterror.ClassExecutor.NewStd(mysql.ErrTooManyRows)
+	ErrCantUpdateUsedTableInSfOrTrg  = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
 
 	errUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
 	errTruncateWrongInsertValue     = dbterror.ClassTable.NewStdErr(mysql.ErrTruncatedWrongValue, parser_mysql.Message("Incorrect %-.32s value: '%-.128s' for column '%.192s' at row %d", nil))
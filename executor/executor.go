@@ -665,6 +665,8 @@ type CheckTableExec struct {
 	exitCh     chan struct{}
 	retCh      chan error
 	checkIndex bool
+	// partitions are the checked partitions of ALTER TABLE ... CHECK PARTITION, it's empty for ADMIN CHECK TABLE.
+	partitions []string
 }
 
 // Open implements the Executor Open interface.
@@ -750,7 +752,7 @@ func (e *CheckTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	for _, idx := range e.indexInfos {
 		idxNames = append(idxNames, idx.Name.O)
 	}
-	greater, idxOffset, err := admin.CheckIndicesCount(e.ctx, e.dbName, e.table.Meta().Name.O, idxNames)
+	greater, idxOffset, err := admin.CheckIndicesCount(e.ctx, e.dbName, e.table.Meta().Name.O, e.partitions, idxNames)
 	if err != nil {
 		// For admin check index statement, for speed up and compatibility, doesn't do below checks.
 		if e.checkIndex {
@@ -810,6 +812,9 @@ func (e *CheckTableExec) checkTableRecord(idxOffset int) error {
 
 	info := e.table.Meta().GetPartitionInfo()
 	for _, def := range info.Definitions {
+		if len(e.partitions) > 0 && !isPartitionChecked(e.partitions, def.Name.L) {
+			continue
+		}
 		pid := def.ID
 		partition := e.table.(table.PartitionedTable).GetPartition(pid)
 		idx := tables.NewIndex(def.ID, e.table.Meta(), idxInfo)
@@ -820,6 +825,15 @@ func (e *CheckTableExec) checkTableRecord(idxOffset int) error {
 	return nil
 }
 
+func isPartitionChecked(partitions []string, name string) bool {
+	for _, p := range partitions {
+		if strings.EqualFold(p, name) {
+			return true
+		}
+	}
+	return false
+}
+
 // ShowSlowExec represents the executor of showing the slow queries.
 // It is build from the "admin show slow" statement:
 //	admin show slow top [internal | all] N
@@ -1677,6 +1691,12 @@ func ResetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode) (err error) {
 	} else {
 		sc = vars.InitStatementContext()
 	}
+	return resetContextOfStmt(ctx, s, sc)
+}
+
+// resetContextOfStmt resets the session variables with the given StmtContext.
+func resetContextOfStmt(ctx sessionctx.Context, s ast.StmtNode, sc *stmtctx.StatementContext) (err error) {
+	vars := ctx.GetSessionVars()
 	sc.TimeZone = vars.Location()
 	sc.TaskID = stmtctx.AllocateTaskID()
 	sc.CTEStorageMap = map[int]*CTEStorages{}
@@ -0,0 +1,614 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/memory"
+)
+
+// maxForeignKeyCascadeDepth is the max depth of nested foreign key cascades, it's the same as MySQL.
+const maxForeignKeyCascadeDepth = 15
+
+type fkCascadeDepthCtxKeyType struct{}
+
+// fkCascadeDepthCtxKey is used to pass the depth of the executing foreign key cascade through the context.
+var fkCascadeDepthCtxKey = fkCascadeDepthCtxKeyType{}
+
+// FKCheckExec checks a foreign key constraint for the rows written by INSERT, UPDATE, DELETE and REPLACE.
+type FKCheckExec struct {
+	*plannercore.FKCheck
+	ctx sessionctx.Context
+}
+
+// FKCascadeExec collects the deleted or updated rows of the parent table, and executes
+// the referential action of the foreign key on the child table.
+type FKCascadeExec struct {
+	*plannercore.FKCascade
+	ctx sessionctx.Context
+	is  infoschema.InfoSchema
+
+	// fkValues are the distinct referenced values of the deleted or updated parent rows.
+	fkValues [][]types.Datum
+	// fkUpdatedValues are the new referenced values of fkValues, it's only used by ON UPDATE CASCADE.
+	fkUpdatedValues [][]types.Datum
+	fkValuesSet     map[string]struct{}
+}
+
+func (b *executorBuilder) buildFKCheckExecs(checks []*plannercore.FKCheck) []*FKCheckExec {
+	return buildFKCheckExecs(b.ctx, checks)
+}
+
+func (b *executorBuilder) buildFKCascadeExecs(cascades []*plannercore.FKCascade) []*FKCascadeExec {
+	return buildFKCascadeExecs(b.ctx, b.is, cascades)
+}
+
+func (b *executorBuilder) buildTblID2FKCheckExecs(tblID2Checks map[int64][]*plannercore.FKCheck) map[int64][]*FKCheckExec {
+	if len(tblID2Checks) == 0 {
+		return nil
+	}
+	execs := make(map[int64][]*FKCheckExec, len(tblID2Checks))
+	for tid, checks := range tblID2Checks {
+		execs[tid] = b.buildFKCheckExecs(checks)
+	}
+	return execs
+}
+
+func (b *executorBuilder) buildTblID2FKCascadeExecs(tblID2Cascades map[int64][]*plannercore.FKCascade) map[int64][]*FKCascadeExec {
+	if len(tblID2Cascades) == 0 {
+		return nil
+	}
+	execs := make(map[int64][]*FKCascadeExec, len(tblID2Cascades))
+	for tid, cascades := range tblID2Cascades {
+		execs[tid] = b.buildFKCascadeExecs(cascades)
+	}
+	return execs
+}
+
+func buildFKCheckExecs(sctx sessionctx.Context, checks []*plannercore.FKCheck) []*FKCheckExec {
+	if len(checks) == 0 {
+		return nil
+	}
+	execs := make([]*FKCheckExec, 0, len(checks))
+	for _, check := range checks {
+		execs = append(execs, &FKCheckExec{FKCheck: check, ctx: sctx})
+	}
+	return execs
+}
+
+func buildFKCascadeExecs(sctx sessionctx.Context, is infoschema.InfoSchema, cascades []*plannercore.FKCascade) []*FKCascadeExec {
+	if len(cascades) == 0 {
+		return nil
+	}
+	execs := make([]*FKCascadeExec, 0, len(cascades))
+	for _, cascade := range cascades {
+		execs = append(execs, &FKCascadeExec{FKCascade: cascade, ctx: sctx, is: is})
+	}
+	return execs
+}
+
+// insertRowNeedToCheck checks the inserted row, the referenced row must exist in the parent table.
+func (fkc *FKCheckExec) insertRowNeedToCheck(ctx context.Context, row []types.Datum) error {
+	if !fkc.CheckExist {
+		return nil
+	}
+	return fkc.check(ctx, row)
+}
+
+// deleteRowNeedToCheck checks the deleted row, no row in the child table may reference it.
+func (fkc *FKCheckExec) deleteRowNeedToCheck(ctx context.Context, row []types.Datum) error {
+	if fkc.CheckExist {
+		return nil
+	}
+	return fkc.check(ctx, row)
+}
+
+// updateRowNeedToCheck checks the updated row if the values of the foreign key columns are changed.
+func (fkc *FKCheckExec) updateRowNeedToCheck(ctx context.Context, oldRow, newRow []types.Datum) error {
+	changed, err := fkValuesChanged(fkc.ctx.GetSessionVars().StmtCtx, fkc.Offsets, oldRow, newRow)
+	if err != nil || !changed {
+		return err
+	}
+	if fkc.CheckExist {
+		return fkc.check(ctx, newRow)
+	}
+	return fkc.check(ctx, oldRow)
+}
+
+func (fkc *FKCheckExec) check(ctx context.Context, row []types.Datum) error {
+	vals := fetchFKValues(row, fkc.Offsets)
+	if vals == nil {
+		// A foreign key containing NULL values is always satisfied.
+		return nil
+	}
+	lookup := fkLookup{tbl: fkc.Tbl, cols: fkc.Cols, idx: fkc.Idx, handleLookup: fkc.HandleLookup}
+	rows, err := lookup.lookup(ctx, fkc.ctx, vals, false)
+	if err != nil {
+		return err
+	}
+	if !fkc.CheckExist {
+		if len(rows) > 0 {
+			return ErrRowIsReferenced2.GenWithStackByArgs(fkc.constraintString())
+		}
+		return nil
+	}
+	if len(rows) == 0 {
+		return ErrNoReferencedRow2.GenWithStackByArgs(fkc.constraintString())
+	}
+	// Lock the referenced row in a pessimistic transaction, so it can't be deleted
+	// or updated by other transactions before the current transaction ends.
+	txnCtx := fkc.ctx.GetSessionVars().TxnCtx
+	if txnCtx.IsPessimistic {
+		txnCtx.AddUnchangedRowKey(rows[0].recordKey())
+	}
+	return nil
+}
+
+func (fkc *FKCheckExec) constraintString() string {
+	var buf strings.Builder
+	fmt.Fprintf(&buf, "`%s`.`%s`, CONSTRAINT `%s` FOREIGN KEY (", fkc.ChildSchema.O, fkc.ChildTable.O, fkc.FK.Name.O)
+	writeFKColumnNames(&buf, fkc.FK.Cols)
+	fmt.Fprintf(&buf, ") REFERENCES `%s` (", fkc.FK.RefTable.O)
+	writeFKColumnNames(&buf, fkc.FK.RefCols)
+	buf.WriteString(")")
+	if opt := ast.ReferOptionType(fkc.FK.OnDelete); opt != ast.ReferOptionNoOption {
+		fmt.Fprintf(&buf, " ON DELETE %s", opt)
+	}
+	if opt := ast.ReferOptionType(fkc.FK.OnUpdate); opt != ast.ReferOptionNoOption {
+		fmt.Fprintf(&buf, " ON UPDATE %s", opt)
+	}
+	return buf.String()
+}
+
+func writeFKColumnNames(buf *strings.Builder, cols []model.CIStr) {
+	for i, col := range cols {
+		if i > 0 {
+			buf.WriteString(", ")
+		}
+		fmt.Fprintf(buf, "`%s`", col.O)
+	}
+}
+
+// fetchFKValues returns the values of the foreign key columns, it returns nil if any of them is NULL.
+func fetchFKValues(row []types.Datum, offsets []int) []types.Datum {
+	vals := make([]types.Datum, 0, len(offsets))
+	for _, offset := range offsets {
+		if row[offset].IsNull() {
+			return nil
+		}
+		vals = append(vals, row[offset])
+	}
+	return vals
+}
+
+func fkValuesChanged(sc *stmtctx.StatementContext, offsets []int, oldRow, newRow []types.Datum) (bool, error) {
+	for _, offset := range offsets {
+		oldVal := oldRow[offset]
+		// Use the binary collation, e.g. updating 'a' to 'A' also changes the foreign key.
+		oldVal.SetCollation(charset.CollationBin)
+		cmp, err := oldVal.CompareDatum(sc, &newRow[offset])
+		if err != nil {
+			return false, err
+		}
+		if cmp != 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// onDeleteRow collects the deleted row of the parent table.
+func (fkc *FKCascadeExec) onDeleteRow(sc *stmtctx.StatementContext, row []types.Datum) error {
+	return fkc.addValues(sc, row, nil)
+}
+
+// onUpdateRow collects the updated row of the parent table if the referenced values are changed.
+func (fkc *FKCascadeExec) onUpdateRow(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum) error {
+	changed, err := fkValuesChanged(sc, fkc.Offsets, oldRow, newRow)
+	if err != nil || !changed {
+		return err
+	}
+	if fkc.ReferOption() == ast.ReferOptionSetNull {
+		return fkc.addValues(sc, oldRow, nil)
+	}
+	return fkc.addValues(sc, oldRow, newRow)
+}
+
+func (fkc *FKCascadeExec) addValues(sc *stmtctx.StatementContext, oldRow, newRow []types.Datum) error {
+	vals := fetchFKValues(oldRow, fkc.Offsets)
+	if vals == nil {
+		// No child row references NULL values.
+		return nil
+	}
+	key, err := codec.EncodeKey(sc, nil, vals...)
+	if err != nil {
+		return err
+	}
+	var newVals []types.Datum
+	if newRow != nil {
+		newVals = make([]types.Datum, 0, len(fkc.Offsets))
+		for _, offset := range fkc.Offsets {
+			newVals = append(newVals, newRow[offset])
+		}
+		// The same old values may be updated to different new values by multiple rows
+		// of a parent table whose referenced columns are not unique.
+		key, err = codec.EncodeKey(sc, key, newVals...)
+		if err != nil {
+			return err
+		}
+	}
+	if fkc.fkValuesSet == nil {
+		fkc.fkValuesSet = make(map[string]struct{})
+	}
+	if _, ok := fkc.fkValuesSet[string(key)]; ok {
+		return nil
+	}
+	fkc.fkValuesSet[string(key)] = struct{}{}
+	fkc.fkValues = append(fkc.fkValues, vals)
+	if newVals != nil {
+		fkc.fkUpdatedValues = append(fkc.fkUpdatedValues, newVals)
+	}
+	return nil
+}
+
+// execute deletes or updates the child rows which reference the collected parent rows,
+// then checks and cascades the foreign keys referencing the child table in turn.
+// The child rows are looked up in the transaction directly, because the readers of a
+// statement can't see the rows written by the statement itself. The rows changed by
+// the cascade are not counted in the affected rows of the statement.
+func (fkc *FKCascadeExec) execute(ctx context.Context) error {
+	fkValues, fkUpdatedValues := fkc.fkValues, fkc.fkUpdatedValues
+	fkc.fkValues, fkc.fkUpdatedValues, fkc.fkValuesSet = nil, nil, nil
+	if len(fkValues) == 0 || fkc.Cols == nil {
+		return nil
+	}
+	depth, _ := ctx.Value(fkCascadeDepthCtxKey).(int)
+	if depth >= maxForeignKeyCascadeDepth {
+		return ErrFKDepthExceeded.GenWithStackByArgs(maxForeignKeyCascadeDepth)
+	}
+	ctx = context.WithValue(ctx, fkCascadeDepthCtxKey, depth+1)
+
+	sctx := fkc.ctx
+	sc := sctx.GetSessionVars().StmtCtx
+	counters := sc.GetDMLRowCounters()
+	defer sc.SetDMLRowCounters(counters)
+
+	// Collect all the child rows before changing any of them, otherwise a row updated
+	// by ON UPDATE CASCADE may be matched again by the following values.
+	type childRow struct {
+		fkMatchedRow
+		valIdx int
+	}
+	var childRows []childRow
+	visited := make(map[string]struct{})
+	lookup := fkLookup{tbl: fkc.ChildTable, cols: fkc.Cols, idx: fkc.Idx, handleLookup: fkc.HandleLookup}
+	for i, vals := range fkValues {
+		rows, err := lookup.lookup(ctx, sctx, vals, true)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			key := string(row.recordKey())
+			if _, ok := visited[key]; ok {
+				continue
+			}
+			visited[key] = struct{}{}
+			childRows = append(childRows, childRow{fkMatchedRow: row, valIdx: i})
+		}
+	}
+	if len(childRows) == 0 {
+		return nil
+	}
+
+	tbl := fkc.ChildTable
+	isDelete := fkc.Tp == plannercore.FKCascadeOnDelete && fkc.ReferOption() == ast.ReferOptionCascade
+	tp := plannercore.FKCascadeOnDelete
+	var updatedCols map[string]struct{}
+	if !isDelete {
+		tp = plannercore.FKCascadeOnUpdate
+		updatedCols = make(map[string]struct{}, len(fkc.Cols))
+		for _, col := range fkc.Cols {
+			updatedCols[col.Name.L] = struct{}{}
+		}
+	}
+	planChecks, planCascades := plannercore.BuildParentFKChecksAndCascades(sctx, fkc.is, fkc.ChildSchema, tbl.Meta(), tp, updatedCols)
+	checks := buildFKCheckExecs(sctx, planChecks)
+	cascades := buildFKCascadeExecs(sctx, fkc.is, planCascades)
+
+	genExprs, err := buildGeneratedColumnExprs(sctx, tbl)
+	if err != nil {
+		return err
+	}
+	var constraints []*table.Constraint
+	if !isDelete {
+		constraints, err = table.BuildWritableConstraints(sctx, tbl.Meta())
+		if err != nil {
+			return err
+		}
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return err
+	}
+	memTracker := memory.NewTracker(fkc.ID(), -1)
+	memTracker.AttachTo(sc.MemTracker)
+	for _, row := range childRows {
+		oldRow, err := getOldRow(ctx, sctx, txn, row.tbl, row.handle, genExprs)
+		if err != nil {
+			return err
+		}
+		if isDelete {
+			if err = tbl.RemoveRecord(sctx, row.handle, oldRow); err != nil {
+				return err
+			}
+			for _, check := range checks {
+				if err = check.deleteRowNeedToCheck(ctx, oldRow); err != nil {
+					return err
+				}
+			}
+			for _, cascade := range cascades {
+				if err = cascade.onDeleteRow(sc, oldRow); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+		var newVals []types.Datum
+		if fkUpdatedValues != nil {
+			newVals = fkUpdatedValues[row.valIdx]
+		}
+		newRow, modified, err := fkc.composeNewRow(tbl, oldRow, newVals, genExprs)
+		if err != nil {
+			return err
+		}
+		if err = table.CheckRowConstraint(sctx, constraints, newRow); err != nil {
+			return err
+		}
+		changed, err := updateRecord(ctx, sctx, row.handle, oldRow, newRow, modified, tbl, false, memTracker)
+		if err != nil {
+			return err
+		}
+		if !changed {
+			continue
+		}
+		for _, check := range checks {
+			if err = check.updateRowNeedToCheck(ctx, oldRow, newRow); err != nil {
+				return err
+			}
+		}
+		for _, cascade := range cascades {
+			if err = cascade.onUpdateRow(sc, oldRow, newRow); err != nil {
+				return err
+			}
+		}
+	}
+	return executeFKCascades(ctx, cascades)
+}
+
+// composeNewRow sets the foreign key columns of the child row to the new values, or NULL if
+// newVals is nil, and evaluates the generated columns again.
+func (fkc *FKCascadeExec) composeNewRow(tbl table.Table, oldRow, newVals []types.Datum,
+	genExprs []expression.Expression) ([]types.Datum, []bool, error) {
+	newRow := make([]types.Datum, len(oldRow))
+	copy(newRow, oldRow)
+	modified := make([]bool, len(oldRow))
+	for i, col := range fkc.Cols {
+		if newVals != nil {
+			newRow[col.Offset] = newVals[i]
+		} else {
+			newRow[col.Offset].SetNull()
+		}
+		modified[col.Offset] = true
+	}
+	gIdx := 0
+	for _, col := range tbl.WritableCols() {
+		if !col.IsGenerated() {
+			continue
+		}
+		val, err := genExprs[gIdx].Eval(chunk.MutRowFromDatums(newRow).ToRow())
+		if err != nil {
+			return nil, nil, err
+		}
+		newRow[col.Offset], err = table.CastValue(fkc.ctx, val, col.ToInfo(), false, false)
+		if err != nil {
+			return nil, nil, err
+		}
+		modified[col.Offset] = true
+		gIdx++
+	}
+	return newRow, modified, nil
+}
+
+// buildGeneratedColumnExprs builds the expressions of the writable generated columns of the table.
+func buildGeneratedColumnExprs(sctx sessionctx.Context, tbl table.Table) ([]expression.Expression, error) {
+	var exprs []expression.Expression
+	for _, col := range tbl.WritableCols() {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr, err := expression.ParseSimpleExprCastWithTableInfo(sctx, col.GeneratedExprString, tbl.Meta(), &col.FieldType)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	return exprs, nil
+}
+
+// executeFKCascades executes the collected foreign key cascades.
+func executeFKCascades(ctx context.Context, cascades []*FKCascadeExec) error {
+	for _, fkc := range cascades {
+		if err := fkc.execute(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fkLookup looks up the rows of a table whose columns match the values of a foreign key.
+// The rows are read from the transaction, so the rows written by the current statement are visible.
+type fkLookup struct {
+	tbl          table.Table
+	cols         []*model.ColumnInfo
+	idx          *model.IndexInfo
+	handleLookup bool
+}
+
+type fkMatchedRow struct {
+	tbl    table.PhysicalTable
+	handle kv.Handle
+}
+
+func (r fkMatchedRow) recordKey() kv.Key {
+	return tablecodec.EncodeRowKeyWithHandle(r.tbl.GetPhysicalID(), r.handle)
+}
+
+// lookup returns the rows matching the values, it returns at most one row if all is false.
+func (l *fkLookup) lookup(ctx context.Context, sctx sessionctx.Context, vals []types.Datum, all bool) ([]fkMatchedRow, error) {
+	if l.tbl == nil || l.cols == nil {
+		return nil, nil
+	}
+	sc := sctx.GetSessionVars().StmtCtx
+	converted := make([]types.Datum, 0, len(vals))
+	for i, col := range l.cols {
+		d, err := vals[i].ConvertTo(sc, &col.FieldType)
+		if err != nil {
+			// The value can't be stored in the looked up column, so no row matches it.
+			return nil, nil
+		}
+		converted = append(converted, d)
+	}
+	txn, err := sctx.Txn(true)
+	if err != nil {
+		return nil, err
+	}
+	var physicalTables []table.PhysicalTable
+	if pt, ok := l.tbl.(table.PartitionedTable); ok {
+		for _, def := range l.tbl.Meta().Partition.Definitions {
+			physicalTables = append(physicalTables, pt.GetPartition(def.ID))
+		}
+	} else {
+		physicalTables = append(physicalTables, l.tbl.(table.PhysicalTable))
+	}
+	var rows []fkMatchedRow
+	for _, tbl := range physicalTables {
+		rows, err = l.lookupPhysicalTable(ctx, sctx, txn, tbl, converted, all, rows)
+		if err != nil {
+			return nil, err
+		}
+		if !all && len(rows) > 0 {
+			break
+		}
+	}
+	return rows, nil
+}
+
+func (l *fkLookup) lookupPhysicalTable(ctx context.Context, sctx sessionctx.Context, txn kv.Transaction, tbl table.PhysicalTable,
+	vals []types.Datum, all bool, rows []fkMatchedRow) ([]fkMatchedRow, error) {
+	sc := sctx.GetSessionVars().StmtCtx
+	tblInfo := tbl.Meta()
+	pid := tbl.GetPhysicalID()
+	switch {
+	case l.handleLookup:
+		handle := kv.IntHandle(vals[0].GetInt64())
+		_, err := txn.Get(ctx, tablecodec.EncodeRowKeyWithHandle(pid, handle))
+		if kv.IsErrNotFound(err) {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		return append(rows, fkMatchedRow{tbl: tbl, handle: handle}), nil
+	case l.idx != nil && l.idx.Primary && tblInfo.IsCommonHandle:
+		// The clustered index is stored in the record keys.
+		prefix, err := codec.EncodeKey(sc, tablecodec.GenTableRecordPrefix(pid), vals...)
+		if err != nil {
+			return nil, err
+		}
+		return iterFKPrefix(txn, prefix, all, rows, func(key, _ []byte) (fkMatchedRow, error) {
+			handle, err := tablecodec.DecodeRowKey(key)
+			return fkMatchedRow{tbl: tbl, handle: handle}, err
+		})
+	case l.idx != nil:
+		prefix, _, err := tablecodec.GenIndexKey(sc, tblInfo, l.idx, pid, vals, nil, nil)
+		if err != nil {
+			return nil, err
+		}
+		return iterFKPrefix(txn, prefix, all, rows, func(key, value []byte) (fkMatchedRow, error) {
+			handle, err := tablecodec.DecodeIndexHandle(key, value, len(l.idx.Columns))
+			return fkMatchedRow{tbl: tbl, handle: handle}, err
+		})
+	}
+	err := tables.IterRecords(tbl, sctx, tbl.Cols(), func(h kv.Handle, rec []types.Datum, cols []*table.Column) (bool, error) {
+		for i, col := range l.cols {
+			d := rec[col.Offset]
+			if d.IsNull() {
+				return true, nil
+			}
+			cmp, err := vals[i].CompareDatum(sc, &d)
+			if err != nil {
+				return false, err
+			}
+			if cmp != 0 {
+				return true, nil
+			}
+		}
+		rows = append(rows, fkMatchedRow{tbl: tbl, handle: h})
+		return all, nil
+	})
+	return rows, err
+}
+
+// iterFKPrefix appends the rows decoded from the keys with the prefix, it appends at most one row if all is false.
+func iterFKPrefix(txn kv.Transaction, prefix kv.Key, all bool, rows []fkMatchedRow,
+	decode func(key, value []byte) (fkMatchedRow, error)) ([]fkMatchedRow, error) {
+	it, err := txn.Iter(prefix, prefix.PrefixNext())
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for it.Valid() && it.Key().HasPrefix(prefix) {
+		row, err := decode(it.Key(), it.Value())
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+		if !all {
+			break
+		}
+		if err = it.Next(); err != nil {
+			return nil, err
+		}
+	}
+	return rows, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForeignKeyCheckOnInsertAndUpdate(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table p (id int primary key, name varchar(10), unique key(name))")
+	tk.MustExec("create table c1 (id int primary key, pid int, foreign key fk_1 (pid) references p(id))")
+	tk.MustExec("create table c2 (id int, pname varchar(10), foreign key fk_2 (pname) references p(name))")
+	tk.MustExec("insert into p values (1, 'a'), (2, 'b')")
+
+	tk.MustExec("insert into c1 values (1, 1), (2, null)")
+	tk.MustGetErrMsg("insert into c1 values (3, 3)",
+		"[executor:1452]Cannot add or update a child row: a foreign key constraint fails (`test`.`c1`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `p` (`id`))")
+	tk.MustExec("insert into c2 values (1, 'a')")
+	tk.MustGetErrCode("insert into c2 values (2, 'c')", errno.ErrNoReferencedRow2)
+	tk.MustQuery("select * from c1 order by id").Check(testkit.Rows("1 1", "2 <nil>"))
+
+	tk.MustExec("update c1 set pid = 2 where id = 1")
+	tk.MustGetErrCode("update c1 set pid = 3 where id = 1", errno.ErrNoReferencedRow2)
+	tk.MustExec("update c1 set id = 10 where id = 1")
+	tk.MustGetErrCode("insert into c1 values (10, 2) on duplicate key update pid = 3", errno.ErrNoReferencedRow2)
+	tk.MustQuery("select * from c1 order by id").Check(testkit.Rows("2 <nil>", "10 2"))
+
+	// The referenced rows can't be deleted or updated.
+	tk.MustGetErrMsg("delete from p where id = 2",
+		"[executor:1451]Cannot delete or update a parent row: a foreign key constraint fails (`test`.`c1`, CONSTRAINT `fk_1` FOREIGN KEY (`pid`) REFERENCES `p` (`id`))")
+	tk.MustGetErrCode("update p set id = 3 where id = 2", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("update p set name = 'c' where id = 1", errno.ErrRowIsReferenced2)
+	tk.MustGetErrCode("replace into p values (2, 'd')", errno.ErrRowIsReferenced2)
+	tk.MustExec("update p set name = 'b' where id = 2")
+	tk.MustExec("delete from c2")
+	tk.MustExec("delete from p where id = 1")
+	tk.MustQuery("select * from p").Check(testkit.Rows("2 b"))
+
+	// The checks are skipped if foreign_key_checks is disabled.
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustExec("insert into c1 values (3, 3)")
+	tk.MustExec("delete from p")
+	tk.MustQuery("select * from c1 order by id").Check(testkit.Rows("2 <nil>", "3 3", "10 2"))
+}
+
+func TestForeignKeyCheckWithoutIndex(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table p (a varchar(10), b int) partition by hash(b) partitions 3")
+	tk.MustExec("create table c (a varchar(10), b int, foreign key (b, a) references p(b, a))")
+	tk.MustExec("insert into p values ('x', 1), ('y', 2)")
+	tk.MustExec("insert into c values ('x', 1), ('y', null)")
+	tk.MustGetErrCode("insert into c values ('y', 1)", errno.ErrNoReferencedRow2)
+	tk.MustGetErrCode("delete from p where b = 1", errno.ErrRowIsReferenced2)
+	tk.MustExec("delete from p where b = 2")
+	tk.MustQuery("select * from p").Check(testkit.Rows("x 1"))
+}
+
+func TestForeignKeyCascade(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table p (id int primary key, v int)")
+	tk.MustExec("create table c1 (id int primary key, pid int, foreign key (pid) references p(id) on delete cascade on update cascade)")
+	tk.MustExec("create table c2 (id int primary key, pid int, key(pid), foreign key (pid) references p(id) on delete set null on update set null)")
+	tk.MustExec("create table c3 (id int primary key, c1id int, foreign key (c1id) references c1(id) on delete cascade)")
+	tk.MustExec("insert into p values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into c1 values (1, 1), (2, 1), (3, 2)")
+	tk.MustExec("insert into c2 values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into c3 values (1, 1), (2, 3)")
+
+	tk.MustExec("update p set id = 10 where id = 1")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select * from c1 order by id").Check(testkit.Rows("1 10", "2 10", "3 2"))
+	tk.MustQuery("select * from c2 order by id").Check(testkit.Rows("1 <nil>", "2 2", "3 3"))
+
+	// The rows changed by the cascades are not counted in the affected rows.
+	tk.MustExec("delete from p where id in (2, 3)")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from c1 order by id").Check(testkit.Rows("1 10", "2 10"))
+	tk.MustQuery("select * from c2 order by id").Check(testkit.Rows("1 <nil>", "2 <nil>", "3 <nil>"))
+	tk.MustQuery("select * from c3 order by id").Check(testkit.Rows("1 1"))
+
+	tk.MustExec("replace into p values (10, 100)")
+	tk.MustQuery("select * from c1").Check(testkit.Rows())
+	tk.MustQuery("select * from c3").Check(testkit.Rows())
+
+	// The cascades are rolled back with the statement.
+	tk.MustExec("insert into c1 values (4, 10)")
+	tk.MustExec("insert into c3 values (3, 4)")
+	tk.MustExec("create table c4 (id int, c3id int, foreign key (c3id) references c3(id))")
+	tk.MustExec("insert into c4 values (1, 3)")
+	tk.MustGetErrCode("delete from p", errno.ErrRowIsReferenced2)
+	tk.MustQuery("select * from p").Check(testkit.Rows("10 100"))
+	tk.MustQuery("select * from c1").Check(testkit.Rows("4 10"))
+	tk.MustQuery("select * from c3").Check(testkit.Rows("3 4"))
+}
+
+func TestForeignKeyCascadeSelfReference(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table t (id int primary key, pid int, foreign key (pid) references t(id) on delete cascade)")
+	tk.MustExec("insert into t values (1, 1)")
+	for i := 2; i <= 16; i++ {
+		tk.MustExec("insert into t values (?, ?)", i, i-1)
+	}
+	tk.MustGetErrCode("delete from t where id = 1", errno.ErrFKDepthExceeded)
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select * from t").Check(testkit.Rows("1 1"))
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows())
+}
+
+func TestForeignKeyExplain(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table p (id int primary key)")
+	tk.MustExec("create table c (id int, pid int, key idx(pid), constraint fk foreign key (pid) references p(id) on delete cascade)")
+	tk.MustQuery("explain format = 'brief' insert into c values (1, 1)").Check(testkit.Rows(
+		"Insert N/A root  N/A",
+		"└─Foreign_Key_Check N/A root table:p, handle:id foreign_key:fk, check_exist"))
+	tk.MustQuery("explain format = 'brief' delete from p where id = 1").Check(testkit.Rows(
+		"Delete N/A root  N/A",
+		"├─Point_Get 1.00 root table:p handle:1",
+		"└─Foreign_Key_Cascade N/A root table:c, index:idx foreign_key:fk, on_delete:CASCADE"))
+	tk.MustExec("set @@foreign_key_checks = 0")
+	tk.MustQuery("explain format = 'brief' insert into c values (1, 1)").Check(testkit.Rows("Insert N/A root  N/A"))
+}
+
+func TestForeignKeyCheckLockParentRow(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table p (id int primary key, v int)")
+	tk.MustExec("create table c (id int, pid int, foreign key (pid) references p(id))")
+	tk.MustExec("insert into p values (1, 1)")
+
+	// The referenced parent row is locked by the pessimistic transaction.
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("insert into c values (1, 1)")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	tk2.MustExec("set @@innodb_lock_wait_timeout = 1")
+	tk2.MustExec("begin pessimistic")
+	tk2.MustGetErrCode("update p set v = 2 where id = 1", errno.ErrLockWaitTimeout)
+	tk2.MustExec("rollback")
+	tk.MustExec("commit")
+	tk.MustQuery("select * from c").Check(testkit.Rows("1 1"))
+}
@@ -0,0 +1,77 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/fulltext"
+)
+
+var _ Executor = &FullTextIndexLookUpExec{}
+
+// FullTextIndexLookUpExec reads the rows containing the searched words of `MATCH ... AGAINST` through
+// the inverted index entries of a FULLTEXT index. The relevance of the rows is not checked here,
+// the `MATCH ... AGAINST` condition is evaluated by the Selection on it.
+type FullTextIndexLookUpExec struct {
+	kvIndexLookUpExec
+
+	against  expression.Expression
+	modifier ast.FulltextSearchModifier
+}
+
+// Next implements the Executor interface.
+func (e *FullTextIndexLookUpExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.prepared {
+		if err := e.prepare(ctx); err != nil {
+			return err
+		}
+		e.prepared = true
+	}
+	return e.fillChunk(req)
+}
+
+// prepare looks up the handles of the rows containing the searched words in the FULLTEXT index,
+// and fetches the rows by the handles.
+func (e *FullTextIndexLookUpExec) prepare(ctx context.Context) error {
+	against, err := e.against.Eval(chunk.Row{})
+	if err != nil || against.IsNull() {
+		return err
+	}
+	text, err := against.ToString()
+	if err != nil {
+		return err
+	}
+	query := fulltext.ParseQuery(fulltext.NewTokenizer(e.idxInfo.ParserName), text, e.modifier.IsBooleanMode())
+	terms := query.LookupTerms()
+	if len(terms) == 0 {
+		return nil
+	}
+	ranges := make([]kv.KeyRange, 0, len(terms))
+	for _, term := range terms {
+		kr, err := tablecodec.GenFullTextIndexRange(e.tblInfo.ID, e.idxInfo.ID, term.Token, term.Prefix)
+		if err != nil {
+			return err
+		}
+		ranges = append(ranges, kr)
+	}
+	return e.lookUp(ctx, ranges)
+}
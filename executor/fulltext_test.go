@@ -0,0 +1,159 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestFulltextSearch(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, title varchar(100), body text, fulltext key ft_title (title), fulltext key ft (title, body))")
+	tk.MustExec("insert into t values (1, 'TiDB Tutorial', 'TiDB is a distributed database.')," +
+		"(2, 'MySQL Tutorial', 'MySQL is a popular database, MySQL is open source.')," +
+		"(3, 'Optimizing TiDB', 'Tuning the optimizer of the distributed SQL engine.')," +
+		"(4, 'Database Security', null)")
+
+	// Natural language mode matches the rows containing any of the words.
+	tk.MustQuery("select id from t where match (title, body) against ('distributed database') order by id").Check(testkit.Rows("1", "2", "3", "4"))
+	tk.MustQuery("select id, match (title, body) against ('mysql') from t order by id").Check(testkit.Rows("1 0", "2 3", "3 0", "4 0"))
+	tk.MustQuery("select id from t where match (title) against ('tidb') order by id").Check(testkit.Rows("1", "3"))
+	// The stopwords and the too short words are ignored.
+	tk.MustQuery("select id from t where match (title, body) against ('is a the')").Check(testkit.Rows())
+
+	// Boolean mode supports the operators.
+	tk.MustQuery("select id from t where match (title, body) against ('+database -mysql' in boolean mode) order by id").Check(testkit.Rows("1", "4"))
+	tk.MustQuery("select id from t where match (title, body) against ('optim*' in boolean mode) order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from t where match (title, body) against ('\"distributed database\"' in boolean mode) order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where match (title, body) against ('+tutorial +(tidb mysql)' in boolean mode) order by id").Check(testkit.Rows("1", "2"))
+
+	// The FULLTEXT index is used to find the matched rows.
+	tk.MustQuery("explain format = 'brief' select id from t where match (title, body) against ('database' in boolean mode) and id > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  gt(test.t.id, 1), match_against(1, \"\", \"database\", test.t.title, test.t.body)",
+		"  └─FullTextIndexLookUp 3333.33 root table:t, index:ft(title, body) against:database, boolean mode"))
+
+	// The MATCH columns must be the columns of a FULLTEXT index.
+	tk.MustGetErrCode("select id from t where match (body) against ('database')", errno.ErrFtMatchingKeyNotFound)
+	tk.MustGetErrCode("select id from t where match (title, body) against (title)", errno.ErrWrongArguments)
+	tk.MustGetErrCode("select id from t where match (title) against ('tidb' with query expansion)", errno.ErrNotSupportedYet)
+}
+
+func TestFulltextIndexMaintenance(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, content text, fulltext key ft (content))")
+	tk.MustExec("insert into t values (1, 'apple banana'), (2, 'banana cherry'), (3, null)")
+
+	tk.MustExec("update t set content = 'cherry durian' where id = 1")
+	tk.MustExec("update t set content = 'apple' where id = 3")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select id from t where match (content) against ('apple') order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from t where match (content) against ('cherry banana') order by id").Check(testkit.Rows("1"))
+
+	// The uncommitted changes of the transaction are visible to itself.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (4, 'banana split')")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select id from t where match (content) against ('banana durian') order by id").Check(testkit.Rows("4"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select id from t where match (content) against ('banana durian') order by id").Check(testkit.Rows("1"))
+
+	// The existing rows are indexed when the index is added.
+	tk.MustExec("alter table t drop index ft")
+	tk.MustExec("insert into t values (5, 'elderberry apple')")
+	tk.MustExec("alter table t add fulltext index ft (content)")
+	tk.MustQuery("select id from t where match (content) against ('apple') order by id").Check(testkit.Rows("3", "5"))
+	tk.MustExec("admin check table t")
+}
+
+func TestFulltextNgramParser(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int, content varchar(100), fulltext key ft (content) with parser ngram)")
+	tk.MustExec("insert into t values (1, '分布式数据库'), (2, '关系型数据库'), (3, '分布式系统')")
+
+	tk.MustQuery("select id from t where match (content) against ('数据库') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where match (content) against ('分布式' in boolean mode) order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t where match (content) against ('+分布 -系统' in boolean mode) order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where match (content) against ('关*' in boolean mode) order by id").Check(testkit.Rows("2"))
+}
+
+func TestFulltextIndexAccessPath(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, b int, content varchar(100), key kb (b), fulltext key ft (content))")
+	tk.MustExec("insert into t values (1, 1, 'apple banana'), (2, 1, 'cherry'), (3, 2, 'banana')")
+
+	// The FULLTEXT index is chosen by the cost like the other indexes.
+	tk.MustQuery("explain format = 'brief' select id from t where match (content) against ('banana') and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  gt(test.t.b, 1), match_against(0, \"\", \"banana\", test.t.content)",
+		"  └─FullTextIndexLookUp 3333.33 root table:t, index:ft(content) against:banana, natural language mode"))
+	tk.MustQuery("explain format = 'brief' select id from t where match (content) against ('banana') and id = 1").Check(testkit.Rows(
+		"Projection 0.80 root  test.t.id",
+		"└─Selection 0.80 root  match_against(0, \"\", \"banana\", test.t.content)",
+		"  └─Point_Get 1.00 root table:t handle:1"))
+	tk.MustQuery("explain format = 'brief' select id from t where match (content) against ('banana') and b = 1").Check(testkit.Rows(
+		"Projection 8.00 root  test.t.id",
+		"└─Selection 8.00 root  match_against(0, \"\", \"banana\", test.t.content)",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:kb(b) range:[1,1], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+
+	// The index hints are honored, MATCH ... AGAINST is evaluated on the rows without the FULLTEXT index.
+	tk.MustQuery("explain format = 'brief' select id from t use index (kb) where match (content) against ('banana') and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  match_against(0, \"\", \"banana\", test.t.content)",
+		"  └─IndexLookUp 3333.33 root  ",
+		"    ├─IndexRangeScan(Build) 3333.33 cop[tikv] table:t, index:kb(b) range:(1,+inf], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 3333.33 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t ignore index (ft) where match (content) against ('banana')").Check(testkit.Rows(
+		"Projection 8000.00 root  test.t.id",
+		"└─Selection 8000.00 root  match_against(0, \"\", \"banana\", test.t.content)",
+		"  └─TableReader 10000.00 root  data:TableFullScan",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t use index (ft) where b = 1").Check(testkit.Rows(
+		"Projection 10.00 root  test.t.id",
+		"└─TableReader 10.00 root  data:Selection",
+		"  └─Selection 10.00 cop[tikv]  eq(test.t.b, 1)",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("select id from t use index (kb) where match (content) against ('banana') and b > 0 order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t ignore index (ft) where match (content) against ('banana') order by id").Check(testkit.Rows("1", "3"))
+}
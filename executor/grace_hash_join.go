@@ -0,0 +1,551 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"go.uber.org/zap"
+)
+
+// The states of HashJoinExec.spillState.
+const (
+	// hashJoinBuildInMemory indicates the hash table is being built in memory.
+	hashJoinBuildInMemory uint32 = iota
+	// hashJoinSpillRequested indicates the rows of both sides should be partitioned into disk.
+	hashJoinSpillRequested
+	// hashJoinBuildFinished indicates the hash table is built in memory, it can't be partitioned any more.
+	hashJoinBuildFinished
+)
+
+const (
+	// hashJoinPartitionBits is the number of bits of the hash value used to choose
+	// the partition of a row in each level.
+	hashJoinPartitionBits = 4
+	// hashJoinPartitions is the number of partitions a partition is split into.
+	hashJoinPartitions = 1 << hashJoinPartitionBits
+	// maxHashJoinPartitionLevel is the max times a partition is repartitioned, the
+	// rows with the same join key can't be split by repartitioning.
+	maxHashJoinPartitionLevel = 3
+)
+
+// hashJoinPartitionIdx returns the partition of a row in the level by the hash
+// value of its join key. Different bits of the hash value are used in different
+// levels, so the rows of a skewed partition can be split by repartitioning.
+func hashJoinPartitionIdx(hashKey uint64, level int) int {
+	// Mix the hash value, the low bits of FNV hash are not spread well.
+	hashKey ^= hashKey >> 33
+	hashKey *= 0xff51afd7ed558ccd
+	hashKey ^= hashKey >> 33
+	return int(hashKey>>(uint(level)*hashJoinPartitionBits)) & (hashJoinPartitions - 1)
+}
+
+// hashJoinPartition is a partition of the rows of both sides, the rows in it can
+// only be matched by the rows in the same partition.
+type hashJoinPartition struct {
+	level int
+	// build and probe are nil if there is no row of the side in the partition.
+	build *chunk.ListInDisk
+	probe *chunk.ListInDisk
+}
+
+func (p *hashJoinPartition) close() (err error) {
+	for _, l := range []*chunk.ListInDisk{p.build, p.probe} {
+		if l == nil {
+			continue
+		}
+		if err1 := l.Close(); err == nil {
+			err = err1
+		}
+	}
+	p.build, p.probe = nil, nil
+	return err
+}
+
+// hashJoinPartitioner writes the rows of one side into the partitions in disk.
+type hashJoinPartitioner struct {
+	level       int
+	fieldTypes  []*types.FieldType
+	diskTracker *disk.Tracker
+	lists       []*chunk.ListInDisk
+	// bufs keep the rows before they are written into lists, the rows of all the
+	// partitions take about one chunk.
+	bufs    []*chunk.Chunk
+	bufSize int
+}
+
+func newHashJoinPartitioner(level int, fieldTypes []*types.FieldType, maxChunkSize int, diskTracker *disk.Tracker) *hashJoinPartitioner {
+	bufSize := maxChunkSize / hashJoinPartitions
+	if bufSize == 0 {
+		bufSize = 1
+	}
+	return &hashJoinPartitioner{
+		level:       level,
+		fieldTypes:  fieldTypes,
+		diskTracker: diskTracker,
+		lists:       make([]*chunk.ListInDisk, hashJoinPartitions),
+		bufs:        make([]*chunk.Chunk, hashJoinPartitions),
+		bufSize:     bufSize,
+	}
+}
+
+// addChunk writes the rows of chk into the partitions by the hash values in hCtx.
+// The rows which are not selected or have NULL join keys can't be matched, they are
+// written into the first partition if keepUnmatched, or dropped otherwise. It
+// returns the number of the rows which may be matched.
+func (p *hashJoinPartitioner) addChunk(chk *chunk.Chunk, hCtx *hashContext, selected []bool, keepUnmatched bool) (numMatchable int, err error) {
+	for i := 0; i < chk.NumRows(); i++ {
+		idx := 0
+		if (selected != nil && !selected[i]) || hCtx.hasNull[i] {
+			if !keepUnmatched {
+				continue
+			}
+		} else {
+			idx = hashJoinPartitionIdx(hCtx.hashVals[i].Sum64(), p.level)
+			numMatchable++
+		}
+		if p.bufs[idx] == nil {
+			p.bufs[idx] = chunk.NewChunkWithCapacity(p.fieldTypes, p.bufSize)
+		}
+		p.bufs[idx].AppendRow(chk.GetRow(i))
+		if p.bufs[idx].NumRows() >= p.bufSize {
+			if err = p.flush(idx); err != nil {
+				return numMatchable, err
+			}
+		}
+	}
+	return numMatchable, nil
+}
+
+func (p *hashJoinPartitioner) flush(idx int) error {
+	if p.lists[idx] == nil {
+		p.lists[idx] = chunk.NewListInDisk(p.fieldTypes)
+		p.lists[idx].GetDiskTracker().AttachTo(p.diskTracker)
+	}
+	// The chunk can't be reused after it is added to the list.
+	err := p.lists[idx].Add(p.bufs[idx])
+	p.bufs[idx] = nil
+	return err
+}
+
+// finish flushes the buffered rows and returns the partitions.
+func (p *hashJoinPartitioner) finish() ([]*chunk.ListInDisk, error) {
+	for idx, buf := range p.bufs {
+		if buf == nil || buf.NumRows() == 0 {
+			continue
+		}
+		if err := p.flush(idx); err != nil {
+			return nil, err
+		}
+	}
+	lists := p.lists
+	p.lists = nil
+	return lists, nil
+}
+
+func (p *hashJoinPartitioner) close() {
+	for _, l := range p.lists {
+		if l != nil {
+			terror.Call(l.Close)
+		}
+	}
+	p.lists, p.bufs = nil, nil
+}
+
+// hashKeys computes the hash values of the join keys of the selected rows of chk.
+func (e *HashJoinExec) hashKeys(hCtx *hashContext, chk *chunk.Chunk, selected []bool) error {
+	hCtx.initHash(chk.NumRows())
+	for keyIdx, colIdx := range hCtx.keyColIdx {
+		ignoreNull := len(e.isNullEQ) > keyIdx && e.isNullEQ[keyIdx]
+		err := codec.HashChunkSelected(e.ctx.GetSessionVars().StmtCtx, hCtx.hashVals, chk, hCtx.allTypes[keyIdx], colIdx, hCtx.buf, hCtx.hasNull, selected, ignoreNull)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// partitionBuildSideChunk writes the build side rows of chk into the partitions.
+func (e *HashJoinExec) partitionBuildSideChunk(p *hashJoinPartitioner, chk *chunk.Chunk, selected []bool) (_ []bool, err error) {
+	var sel []bool
+	if e.useOuterToBuild && len(e.outerFilter) > 0 {
+		selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
+		if err != nil {
+			return selected, err
+		}
+		sel = selected
+	}
+	hCtx := e.rowContainer.hCtx
+	if err = e.hashKeys(hCtx, chk, sel); err != nil {
+		return selected, err
+	}
+	// The unmatched build side rows are only needed by the outer hash join.
+	numMatchable, err := p.addChunk(chk, hCtx, sel, e.useOuterToBuild)
+	if p == e.buildSidePartitioner {
+		e.numSpilledBuildRows += numMatchable
+	}
+	return selected, err
+}
+
+// partitionProbeSideChunk writes the probe side rows of chk into the partitions.
+func (e *HashJoinExec) partitionProbeSideChunk(p *hashJoinPartitioner, hCtx *hashContext, chk *chunk.Chunk, selected []bool) (_ []bool, err error) {
+	var sel []bool
+	if !e.useOuterToBuild {
+		selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
+		if err != nil {
+			return selected, err
+		}
+		sel = selected
+	}
+	if err = e.hashKeys(hCtx, chk, sel); err != nil {
+		return selected, err
+	}
+	// The unmatched probe side rows are only needed if the probe side is the outer side.
+	_, err = p.addChunk(chk, hCtx, sel, !e.useOuterToBuild)
+	return selected, err
+}
+
+// spillBuildSide starts to partition the build side rows into disk, the rows which
+// have been put into the hash table are partitioned first.
+func (e *HashJoinExec) spillBuildSide() (err error) {
+	logutil.BgLogger().Info("memory exceeds quota, partition the rows of hash join into disk",
+		zap.Int64("consumed", e.memTracker.BytesConsumed()))
+	e.buildSidePartitioner = newHashJoinPartitioner(0, retTypes(e.buildSideExec), e.maxChunkSize, e.diskTracker)
+	var (
+		selected []bool
+		chk      *chunk.Chunk
+	)
+	for i := 0; i < e.rowContainer.NumChunks(); i++ {
+		if chk, err = e.rowContainer.GetChunk(i); err != nil {
+			return err
+		}
+		if selected, err = e.partitionBuildSideChunk(e.buildSidePartitioner, chk, selected); err != nil {
+			return err
+		}
+	}
+	for _, bitMap := range e.outerMatchedStatus {
+		e.memTracker.Consume(-bitMap.BytesConsumed())
+	}
+	e.outerMatchedStatus = e.outerMatchedStatus[:0]
+	if err = e.rowContainer.Close(); err != nil {
+		return err
+	}
+	// The hash table is built for each partition after the probe side is partitioned.
+	e.initRowContainer(0)
+	return nil
+}
+
+// finishBuildSide is called after all the build side rows are fetched.
+func (e *HashJoinExec) finishBuildSide() error {
+	if !atomic.CompareAndSwapUint32(&e.spillState, hashJoinBuildInMemory, hashJoinBuildFinished) && e.buildSidePartitioner == nil {
+		if err := e.spillBuildSide(); err != nil {
+			return err
+		}
+	}
+	if e.buildSidePartitioner == nil {
+		return nil
+	}
+	lists, err := e.buildSidePartitioner.finish()
+	if err != nil {
+		return err
+	}
+	e.spilledPartitions = make([]*hashJoinPartition, 0, len(lists))
+	for _, l := range lists {
+		e.spilledPartitions = append(e.spilledPartitions, &hashJoinPartition{build: l})
+	}
+	return nil
+}
+
+// spillProbeSide partitions all the probe side rows into disk, probeSideResult is
+// the first probe side chunk.
+func (e *HashJoinExec) spillProbeSide(ctx context.Context, probeSideResult *chunk.Chunk) (err error) {
+	p := newHashJoinPartitioner(0, retTypes(e.probeSideExec), e.maxChunkSize, e.diskTracker)
+	defer p.close()
+	hCtx := &hashContext{
+		allTypes:  e.probeTypes,
+		keyColIdx: e.probeKeyColIdx(),
+	}
+	var selected []bool
+	probeSideResult.SetRequiredRows(e.maxChunkSize, e.maxChunkSize)
+	for probeSideResult.NumRows() > 0 {
+		if e.finished.Load().(bool) {
+			return nil
+		}
+		if selected, err = e.partitionProbeSideChunk(p, hCtx, probeSideResult, selected); err != nil {
+			return err
+		}
+		if err = Next(ctx, e.probeSideExec, probeSideResult); err != nil {
+			return err
+		}
+	}
+	lists, err := p.finish()
+	if err != nil {
+		return err
+	}
+	for i, l := range lists {
+		e.spilledPartitions[i].probe = l
+	}
+	e.probeSideSpilled = true
+	return nil
+}
+
+// joinSpilledPartitions joins the partitions one by one after the rows of both
+// sides are partitioned. A partition is repartitioned if its build side rows are
+// too many to be kept in memory.
+func (e *HashJoinExec) joinSpilledPartitions() {
+	maxPartitionBytes := e.ctx.GetSessionVars().StmtCtx.MemTracker.GetBytesLimit() / 4
+	partitions := append([]*hashJoinPartition(nil), e.spilledPartitions...)
+	for len(partitions) > 0 {
+		if e.finished.Load().(bool) {
+			return
+		}
+		p := partitions[len(partitions)-1]
+		partitions = partitions[:len(partitions)-1]
+		var err error
+		switch {
+		case e.canSkipSpilledPartition(p):
+		case maxPartitionBytes > 0 && p.level < maxHashJoinPartitionLevel && p.build != nil && p.build.GetDiskTracker().BytesConsumed() > maxPartitionBytes:
+			var subPartitions []*hashJoinPartition
+			subPartitions, err = e.repartition(p)
+			e.spilledPartitions = append(e.spilledPartitions, subPartitions...)
+			partitions = append(partitions, subPartitions...)
+		default:
+			err = e.joinSpilledPartition(p)
+		}
+		if err1 := p.close(); err == nil {
+			err = err1
+		}
+		if err != nil {
+			e.joinResultCh <- &hashjoinWorkerResult{err: err}
+			return
+		}
+	}
+}
+
+// canSkipSpilledPartition checks whether the partition produces no result. The
+// build side of a partition which can't be skipped is never nil.
+func (e *HashJoinExec) canSkipSpilledPartition(p *hashJoinPartition) bool {
+	if p.build == nil {
+		// The probe side rows may be needed by the outer join or the anti semi join.
+		return e.useOuterToBuild || p.probe == nil || e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin
+	}
+	return p.probe == nil && !e.useOuterToBuild
+}
+
+// repartition splits the rows of the partition into the partitions of the next level.
+func (e *HashJoinExec) repartition(p *hashJoinPartition) (_ []*hashJoinPartition, err error) {
+	buildSide := newHashJoinPartitioner(p.level+1, retTypes(e.buildSideExec), e.maxChunkSize, e.diskTracker)
+	defer buildSide.close()
+	probeSide := newHashJoinPartitioner(p.level+1, retTypes(e.probeSideExec), e.maxChunkSize, e.diskTracker)
+	defer probeSide.close()
+	var (
+		selected []bool
+		chk      *chunk.Chunk
+	)
+	for i := 0; i < p.build.NumChunks(); i++ {
+		if chk, err = p.build.GetChunk(i); err != nil {
+			return nil, err
+		}
+		if selected, err = e.partitionBuildSideChunk(buildSide, chk, selected); err != nil {
+			return nil, err
+		}
+	}
+	hCtx := &hashContext{
+		allTypes:  e.probeTypes,
+		keyColIdx: e.probeKeyColIdx(),
+	}
+	for i := 0; p.probe != nil && i < p.probe.NumChunks(); i++ {
+		if chk, err = p.probe.GetChunk(i); err != nil {
+			return nil, err
+		}
+		if selected, err = e.partitionProbeSideChunk(probeSide, hCtx, chk, selected); err != nil {
+			return nil, err
+		}
+	}
+	buildLists, err := buildSide.finish()
+	if err != nil {
+		return nil, err
+	}
+	probeLists, err := probeSide.finish()
+	if err != nil {
+		for _, l := range buildLists {
+			if l != nil {
+				terror.Call(l.Close)
+			}
+		}
+		return nil, err
+	}
+	partitions := make([]*hashJoinPartition, 0, hashJoinPartitions)
+	for i := range buildLists {
+		partitions = append(partitions, &hashJoinPartition{level: p.level + 1, build: buildLists[i], probe: probeLists[i]})
+	}
+	return partitions, nil
+}
+
+// joinSpilledPartition builds the hash table for the build side rows of the
+// partition, and probes it with the probe side rows by the join workers.
+func (e *HashJoinExec) joinSpilledPartition(p *hashJoinPartition) (err error) {
+	numBuildRows := 0
+	if p.build != nil {
+		numBuildRows = p.build.Len()
+	}
+	e.initRowContainer(numBuildRows)
+	if e.spillAction != nil {
+		e.spillAction.setRowContainer(e.rowContainer)
+	}
+	defer func() {
+		for _, bitMap := range e.outerMatchedStatus {
+			e.memTracker.Consume(-bitMap.BytesConsumed())
+		}
+		e.outerMatchedStatus = e.outerMatchedStatus[:0]
+		if err1 := e.rowContainer.Close(); err == nil {
+			err = err1
+		}
+		e.rowContainer.GetMemTracker().Detach()
+		e.rowContainer.GetDiskTracker().Detach()
+	}()
+	var (
+		selected []bool
+		chk      *chunk.Chunk
+	)
+	for i := 0; p.build != nil && i < p.build.NumChunks(); i++ {
+		if chk, err = p.build.GetChunk(i); err != nil {
+			return err
+		}
+		if selected, err = e.putBuildSideChunk(chk, selected); err != nil {
+			return err
+		}
+	}
+
+	probeKeyColIdx := e.probeKeyColIdx()
+	if p.probe != nil {
+		for i := uint(0); i < e.concurrency; i++ {
+			workerID := i
+			e.joinWorkerWaitGroup.Add(1)
+			go util.WithRecovery(func() {
+				e.probeSpilledPartition(workerID, p.probe, probeKeyColIdx)
+			}, e.handleJoinWorkerPanic)
+		}
+		e.joinWorkerWaitGroup.Wait()
+	}
+	if e.useOuterToBuild && !e.finished.Load().(bool) {
+		for i := uint(0); i < e.concurrency; i++ {
+			var workerID = i
+			e.joinWorkerWaitGroup.Add(1)
+			go util.WithRecovery(func() { e.handleUnmatchedRowsFromHashTable(workerID) }, e.handleJoinWorkerPanic)
+		}
+		e.joinWorkerWaitGroup.Wait()
+	}
+	return nil
+}
+
+// probeSpilledPartition probes the hash table with the chunks of probeSide, each
+// join worker handles the chunks whose index modulo the concurrency is its ID.
+func (e *HashJoinExec) probeSpilledPartition(workerID uint, probeSide *chunk.ListInDisk, probeKeyColIdx []int) {
+	ok, joinResult := e.getNewJoinResult(workerID)
+	if !ok {
+		return
+	}
+	hCtx := &hashContext{
+		allTypes:  e.probeTypes,
+		keyColIdx: probeKeyColIdx,
+	}
+	selected := make([]bool, 0, chunk.InitialCapacity)
+	for i := int(workerID); i < probeSide.NumChunks(); i += int(e.concurrency) {
+		if e.finished.Load().(bool) {
+			break
+		}
+		probeSideResult, err := probeSide.GetChunk(i)
+		if err != nil {
+			joinResult.err = err
+			break
+		}
+		if e.useOuterToBuild {
+			ok, joinResult = e.join2ChunkForOuterHashJoin(workerID, probeSideResult, hCtx, e.rowContainerForProbe[workerID], joinResult)
+		} else {
+			ok, joinResult = e.join2Chunk(workerID, probeSideResult, hCtx, e.rowContainerForProbe[workerID], joinResult, selected)
+		}
+		if !ok {
+			break
+		}
+	}
+	e.sendLastJoinResult(workerID, joinResult)
+}
+
+func (e *HashJoinExec) closeSpilledPartitions() {
+	if e.buildSidePartitioner != nil {
+		e.buildSidePartitioner.close()
+		e.buildSidePartitioner = nil
+	}
+	for _, p := range e.spilledPartitions {
+		terror.Call(p.close)
+	}
+	e.spilledPartitions = nil
+}
+
+// HashJoinSpillDiskAction implements memory.ActionOnExceed for HashJoinExec. If the
+// memory quota of a query is exceeded while the hash table is being built, the
+// rows of both sides are partitioned into disk and the partitions are joined one
+// by one. Otherwise the rows of the hash table are spilled.
+type HashJoinSpillDiskAction struct {
+	memory.BaseOOMAction
+	e *HashJoinExec
+
+	m sync.Mutex
+	// rowContainerAction spills the rows of the hash table being built or probed.
+	rowContainerAction *chunk.SpillDiskAction
+}
+
+func (a *HashJoinSpillDiskAction) setRowContainer(c *hashRowContainer) {
+	a.m.Lock()
+	defer a.m.Unlock()
+	a.rowContainerAction = c.rowContainer.ActionSpill()
+}
+
+// Action partitions the rows of hash join into disk if the hash table is being built.
+func (a *HashJoinSpillDiskAction) Action(t *memory.Tracker) {
+	if atomic.CompareAndSwapUint32(&a.e.spillState, hashJoinBuildInMemory, hashJoinSpillRequested) {
+		logutil.BgLogger().Info("memory exceeds quota, set hash join to spill-mode",
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		return
+	}
+	a.m.Lock()
+	rowContainerAction := a.rowContainerAction
+	a.m.Unlock()
+	rowContainerAction.SetFallback(a.GetFallback())
+	rowContainerAction.Action(t)
+}
+
+// GetPriority get the priority of the Action
+func (a *HashJoinSpillDiskAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
+func (a *HashJoinSpillDiskAction) SetLogHook(hook func(uint64)) {}
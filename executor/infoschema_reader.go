@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"math/bits"
 	"net/http"
 	"sort"
 	"strconv"
@@ -57,6 +58,7 @@ import (
 	"github.com/pingcap/tidb/types"
 	binaryJson "github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/advisorylock"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/deadlockhistory"
@@ -119,6 +121,10 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableRoutines:
+			e.setDataFromRoutines(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -166,6 +172,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataForAttributes(sctx)
 		case infoschema.TablePlacementRules:
 			err = e.setDataFromPlacementRules(ctx, sctx, dbs)
+		case infoschema.TableCheckConstraints:
+			e.setDataFromCheckConstraints(sctx, dbs)
+		case infoschema.TableAdvisoryLocks,
+			infoschema.ClusterTableAdvisoryLocks:
+			err = e.setDataForAdvisoryLocks(sctx)
+		case infoschema.TableInstancePlanCache,
+			infoschema.ClusterTableInstancePlanCache:
+			err = e.setDataForInstancePlanCache(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -450,6 +464,11 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				expression = tblCol.GeneratedExprString
 			}
 
+			indexType := "BTREE"
+			if index.IsFulltext() || index.IsSpatial() {
+				indexType = index.Tp.String()
+			}
+
 			record := types.MakeDatums(
 				infoschema.CatalogVal, // TABLE_CATALOG
 				schema.Name.O,         // TABLE_SCHEMA
@@ -464,7 +483,7 @@ func (e *memtableRetriever) setDataForStatisticsInTable(schema *model.DBInfo, ta
 				nil,                   // SUB_PART
 				nil,                   // PACKED
 				nullable,              // NULLABLE
-				"BTREE",               // INDEX_TYPE
+				indexType,             // INDEX_TYPE
 				"",                    // COMMENT
 				"",                    // INDEX_COMMENT
 				visible,               // IS_VISIBLE
@@ -786,6 +805,17 @@ func calcCharOctLength(lenInChar int, cs string) int {
 	return lenInBytes
 }
 
+func partitionColumnsString(cols []model.CIStr) string {
+	buf := bytes.NewBuffer(nil)
+	for i, col := range cols {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+		buf.WriteString(col.String())
+	}
+	return buf.String()
+}
+
 func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sessionctx.Context, schemas []*model.DBInfo) error {
 	tableRowsMap, colLengthMap, err := tableStatsCache.get(ctx, sctx)
 	if err != nil {
@@ -881,17 +911,25 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 					partitionExpr := table.Partition.Expr
 					if table.Partition.Type == model.PartitionTypeRange && len(table.Partition.Columns) > 0 {
 						partitionMethod = "RANGE COLUMNS"
-						partitionExpr = table.Partition.Columns[0].String()
+						partitionExpr = partitionColumnsString(table.Partition.Columns)
 					} else if table.Partition.Type == model.PartitionTypeList && len(table.Partition.Columns) > 0 {
 						partitionMethod = "LIST COLUMNS"
-						buf := bytes.NewBuffer(nil)
-						for i, col := range table.Partition.Columns {
-							if i > 0 {
-								buf.WriteString(",")
-							}
-							buf.WriteString(col.String())
+						partitionExpr = partitionColumnsString(table.Partition.Columns)
+					} else if table.Partition.Type == model.PartitionTypeKey {
+						partitionExpr = partitionColumnsString(table.Partition.Columns)
+					}
+
+					partitionName, partitionPos := pi.Name.O, i+1
+					var subPartitionName, subPartitionPos, subPartitionMethod, subPartitionExpr interface{}
+					if sub := table.Partition.Sub; sub != nil {
+						// The definitions are the subpartitions, which are grouped by the logical partitions.
+						num := int(sub.Num)
+						partitionName, partitionPos = sub.Parents[i/num].Name.O, i/num+1
+						subPartitionName, subPartitionPos = pi.Name.O, i%num+1
+						subPartitionMethod, subPartitionExpr = sub.Type.String(), sub.Expr
+						if sub.Type == model.PartitionTypeKey {
+							subPartitionExpr = partitionColumnsString(sub.Columns)
 						}
-						partitionExpr = buf.String()
 					}
 
 					var policyName, directPlacement interface{}
@@ -905,14 +943,14 @@ func (e *memtableRetriever) setDataFromPartitions(ctx context.Context, sctx sess
 						infoschema.CatalogVal, // TABLE_CATALOG
 						schema.Name.O,         // TABLE_SCHEMA
 						table.Name.O,          // TABLE_NAME
-						pi.Name.O,             // PARTITION_NAME
-						nil,                   // SUBPARTITION_NAME
-						i+1,                   // PARTITION_ORDINAL_POSITION
-						nil,                   // SUBPARTITION_ORDINAL_POSITION
+						partitionName,         // PARTITION_NAME
+						subPartitionName,      // SUBPARTITION_NAME
+						partitionPos,          // PARTITION_ORDINAL_POSITION
+						subPartitionPos,       // SUBPARTITION_ORDINAL_POSITION
 						partitionMethod,       // PARTITION_METHOD
-						nil,                   // SUBPARTITION_METHOD
+						subPartitionMethod,    // SUBPARTITION_METHOD
 						partitionExpr,         // PARTITION_EXPRESSION
-						nil,                   // SUBPARTITION_EXPRESSION
+						subPartitionExpr,      // SUBPARTITION_EXPRESSION
 						partitionDesc,         // PARTITION_DESCRIPTION
 						rowCount,              // TABLE_ROWS
 						avgRowLength,          // AVG_ROW_LENGTH
@@ -1026,6 +1064,7 @@ func (e *memtableRetriever) setDataFromIndexes(ctx sessionctx.Context, schemas [
 
 func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*model.DBInfo) {
 	checker := privilege.GetPrivilegeManager(ctx)
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
 	var rows [][]types.Datum
 	for _, schema := range schemas {
 		for _, table := range schema.Tables {
@@ -1043,13 +1082,17 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.AllPrivMask) {
 				continue
 			}
+			updatable := "NO"
+			if plannercore.IsUpdatableView(ctx, is, schema.Name, table) {
+				updatable = "YES"
+			}
 			record := types.MakeDatums(
 				infoschema.CatalogVal,           // TABLE_CATALOG
 				schema.Name.O,                   // TABLE_SCHEMA
 				table.Name.O,                    // TABLE_NAME
 				table.View.SelectStmt,           // VIEW_DEFINITION
 				table.View.CheckOption.String(), // CHECK_OPTION
-				"NO",                            // IS_UPDATABLE
+				updatable,                       // IS_UPDATABLE
 				table.View.Definer.String(),     // DEFINER
 				table.View.Security.String(),    // SECURITY_TYPE
 				charset,                         // CHARACTER_SET_CLIENT
@@ -1061,6 +1104,165 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromRoutines(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.ExecutePriv) &&
+			!checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.CreateRoutinePriv) &&
+			!checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, "", "", mysql.AlterRoutinePriv) {
+			continue
+		}
+		for _, routine := range schema.Routines {
+			var dataType, charMaxLen, charOctLen, numericPrecision, numericScale, datetimePrecision, chs, coll, dtd interface{}
+			dataType = ""
+			if tp := routine.Returns; routine.Type == model.RoutineTypeFunction && tp != nil {
+				colLen, decimal := tp.Flen, tp.Decimal
+				defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.Tp)
+				if decimal == types.UnspecifiedLength {
+					decimal = defaultDecimal
+				}
+				if colLen == types.UnspecifiedLength {
+					colLen = defaultFlen
+				}
+				if types.IsString(tp.Tp) {
+					charMaxLen = colLen
+					charOctLen = calcCharOctLength(colLen, tp.Charset)
+					chs, coll = tp.Charset, tp.Collate
+				} else if types.IsTypeFractionable(tp.Tp) {
+					datetimePrecision = decimal
+				} else if types.IsTypeNumeric(tp.Tp) {
+					numericPrecision = colLen
+					numericScale = decimal
+				}
+				dataType = types.TypeToStr(tp.Tp, tp.Charset)
+				dtd = tp.InfoSchemaStr()
+			}
+			isDeterministic := "NO"
+			if routine.Deterministic {
+				isDeterministic = "YES"
+			}
+			var definer string
+			if routine.Definer != nil {
+				definer = routine.Definer.String()
+			}
+			created := types.NewTime(types.FromGoTime(routine.Created.In(ctx.GetSessionVars().Location())), mysql.TypeDatetime, types.DefaultFsp)
+			record := types.MakeDatums(
+				routine.Name.O,                 // SPECIFIC_NAME
+				infoschema.CatalogVal,          // ROUTINE_CATALOG
+				schema.Name.O,                  // ROUTINE_SCHEMA
+				routine.Name.O,                 // ROUTINE_NAME
+				routine.Type.String(),          // ROUTINE_TYPE
+				dataType,                       // DATA_TYPE
+				charMaxLen,                     // CHARACTER_MAXIMUM_LENGTH
+				charOctLen,                     // CHARACTER_OCTET_LENGTH
+				numericPrecision,               // NUMERIC_PRECISION
+				numericScale,                   // NUMERIC_SCALE
+				datetimePrecision,              // DATETIME_PRECISION
+				chs,                            // CHARACTER_SET_NAME
+				coll,                           // COLLATION_NAME
+				dtd,                            // DTD_IDENTIFIER
+				"SQL",                          // ROUTINE_BODY
+				routine.Body,                   // ROUTINE_DEFINITION
+				nil,                            // EXTERNAL_NAME
+				nil,                            // EXTERNAL_LANGUAGE
+				"SQL",                          // PARAMETER_STYLE
+				isDeterministic,                // IS_DETERMINISTIC
+				routine.DataAccess.String(),    // SQL_DATA_ACCESS
+				nil,                            // SQL_PATH
+				routine.Security.String(),      // SECURITY_TYPE
+				created,                        // CREATED
+				created,                        // LAST_ALTERED
+				sqlModeString(routine.SQLMode), // SQL_MODE
+				routine.Comment,                // ROUTINE_COMMENT
+				definer,                        // DEFINER
+				routine.Charset,                // CHARACTER_SET_CLIENT
+				routine.Collate,                // COLLATION_CONNECTION
+				schema.Collate,                 // DATABASE_COLLATION
+			)
+			rows = append(rows, record)
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			for i, trigger := range table.Triggers {
+				// The order is counted among the triggers of the same timing and event.
+				order := 1
+				for _, prev := range table.Triggers[:i] {
+					if prev.Timing == trigger.Timing && prev.Event == trigger.Event {
+						order++
+					}
+				}
+				var definer string
+				if trigger.Definer != nil {
+					definer = trigger.Definer.String()
+				}
+				created := types.NewTime(types.FromGoTime(trigger.Created.In(ctx.GetSessionVars().Location())), mysql.TypeDatetime, 2)
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // TRIGGER_CATALOG
+					schema.Name.O,                  // TRIGGER_SCHEMA
+					trigger.Name.O,                 // TRIGGER_NAME
+					trigger.Event.String(),         // EVENT_MANIPULATION
+					infoschema.CatalogVal,          // EVENT_OBJECT_CATALOG
+					schema.Name.O,                  // EVENT_OBJECT_SCHEMA
+					table.Name.O,                   // EVENT_OBJECT_TABLE
+					order,                          // ACTION_ORDER
+					nil,                            // ACTION_CONDITION
+					trigger.Body,                   // ACTION_STATEMENT
+					"ROW",                          // ACTION_ORIENTATION
+					trigger.Timing.String(),        // ACTION_TIMING
+					nil,                            // ACTION_REFERENCE_OLD_TABLE
+					nil,                            // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                          // ACTION_REFERENCE_OLD_ROW
+					"NEW",                          // ACTION_REFERENCE_NEW_ROW
+					created,                        // CREATED
+					sqlModeString(trigger.SQLMode), // SQL_MODE
+					definer,                        // DEFINER
+					trigger.Charset,                // CHARACTER_SET_CLIENT
+					trigger.Collate,                // COLLATION_CONNECTION
+					schema.Collate,                 // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+// sqlModeString returns the names of the modes set in the SQL mode, ordered by their values.
+func sqlModeString(mode mysql.SQLMode) string {
+	var modes []mysql.SQLMode
+	for _, m := range mysql.Str2SQLMode {
+		// Skip the combination modes.
+		if bits.OnesCount64(uint64(m)) == 1 && mode&m != 0 {
+			modes = append(modes, m)
+		}
+	}
+	sort.Slice(modes, func(i, j int) bool { return modes[i] < modes[j] })
+	names := make([]string, 0, len(modes))
+	for _, m := range modes {
+		for name, v := range mysql.Str2SQLMode {
+			if v == m {
+				names = append(names, name)
+				break
+			}
+		}
+	}
+	return strings.Join(names, ",")
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx sessionctx.Context) (err error) {
 	tikvStore, ok := ctx.GetStore().(helper.Storage)
 	if !ok {
@@ -1654,6 +1856,46 @@ func (e *memtableRetriever) setDataFromTableConstraints(ctx sessionctx.Context,
 				)
 				rows = append(rows, record)
 			}
+
+			for _, constraint := range tbl.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal,          // CONSTRAINT_CATALOG
+					schema.Name.O,                  // CONSTRAINT_SCHEMA
+					constraint.Name.O,              // CONSTRAINT_NAME
+					schema.Name.O,                  // TABLE_SCHEMA
+					tbl.Name.O,                     // TABLE_NAME
+					infoschema.CheckConstraintType, // CONSTRAINT_TYPE
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromCheckConstraints(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+			for _, constraint := range tbl.Constraints {
+				if constraint.State != model.StatePublic {
+					continue
+				}
+				record := types.MakeDatums(
+					infoschema.CatalogVal, // CONSTRAINT_CATALOG
+					schema.Name.O,         // CONSTRAINT_SCHEMA
+					constraint.Name.O,     // CONSTRAINT_NAME
+					fmt.Sprintf("(%s)", constraint.ExprString), // CHECK_CLAUSE
+				)
+				rows = append(rows, record)
+			}
 		}
 	}
 	e.rows = rows
@@ -2005,6 +2247,48 @@ func (e *memtableRetriever) setDataForStatementsSummaryEvicted(ctx sessionctx.Co
 	return nil
 }
 
+func (e *memtableRetriever) setDataForAdvisoryLocks(ctx sessionctx.Context) error {
+	if !hasPriv(ctx, mysql.ProcessPriv) {
+		return plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	locks := advisorylock.GlobalRegistry.GetAll()
+	rows := make([][]types.Datum, 0, len(locks))
+	for i := range locks {
+		rows = append(rows, locks[i].ToDatums())
+	}
+	if e.table.Name.O == infoschema.ClusterTableAdvisoryLocks {
+		var err error
+		rows, err = infoschema.AppendHostInfoToRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
+func (e *memtableRetriever) setDataForInstancePlanCache(ctx sessionctx.Context) error {
+	stats := plannercore.GlobalInstancePlanCache.Stats()
+	rows := [][]types.Datum{types.MakeDatums(
+		uint64(stats.EntryCount),    // ENTRY_COUNT
+		uint64(stats.MemoryUsage),   // MEMORY_USAGE
+		uint64(stats.MemoryQuota),   // MEMORY_QUOTA
+		uint64(stats.HitCount),      // HIT_COUNT
+		uint64(stats.MissCount),     // MISS_COUNT
+		uint64(stats.EvictionCount), // EVICTION_COUNT
+		uint64(stats.EvictedMemory), // EVICTED_MEMORY
+	)}
+	if e.table.Name.O == infoschema.ClusterTableInstancePlanCache {
+		var err error
+		rows, err = infoschema.AppendHostInfoToRows(ctx, rows)
+		if err != nil {
+			return err
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) setDataForClientErrorsSummary(ctx sessionctx.Context, tableName string) error {
 	// Seeing client errors should require the PROCESS privilege, with the exception of errors for your own user.
 	// This is similar to information_schema.processlist, which is the closest comparison.
@@ -68,6 +68,9 @@ func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
 	}
 	setResourceGroupTagForTxn(sessVars.StmtCtx, txn)
 	txnSize := txn.Size()
+	if err = e.fireBeforeInsert(ctx, rows); err != nil {
+		return err
+	}
 	sessVars.StmtCtx.AddRecordRows(uint64(len(rows)))
 	// If you use the IGNORE keyword, duplicate-key error that occurs while executing the INSERT statement are ignored.
 	// For example, without IGNORE, a row that duplicates an existing UNIQUE index or PRIMARY KEY value in
@@ -110,6 +113,9 @@ func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
 			e.stats.CheckInsertTime += time.Since(start)
 		}
 	}
+	if err = executeFKCascades(ctx, e.fkCascades); err != nil {
+		return err
+	}
 	e.memTracker.Consume(int64(txn.Size() - txnSize))
 	return nil
 }
@@ -200,7 +206,7 @@ func (e *InsertExec) updateDupRow(ctx context.Context, idxInBatch int, txn kv.Tr
 	}
 
 	err = e.doDupRowUpdate(ctx, handle, oldRow, row.row, e.OnDuplicate)
-	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && kv.ErrKeyExists.Equal(err) {
+	if e.ctx.GetSessionVars().StmtCtx.DupKeyAsWarning && (kv.ErrKeyExists.Equal(err) || table.ErrCheckConstraintViolated.Equal(err) || table.ErrViewCheckFailed.Equal(err)) {
 		e.ctx.GetSessionVars().StmtCtx.AppendWarning(err)
 		return nil
 	}
@@ -405,11 +411,33 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker)
+	if err := e.triggers.fire(ctx, model.TriggerTimingBefore, model.TriggerEventUpdate, oldRow, newData, assignFlag); err != nil {
+		return err
+	}
+	if err := table.CheckRowConstraint(e.ctx, e.checkConstraints, newData); err != nil {
+		return err
+	}
+	if err := table.CheckRowViewCondition(e.ctx, e.viewChecks, newData); err != nil {
+		return err
+	}
+	changed, err := updateRecord(ctx, e.ctx, handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker)
 	if err != nil {
 		return err
 	}
-	return nil
+	if changed {
+		for _, fkc := range e.fkChecks {
+			if err = fkc.updateRowNeedToCheck(ctx, oldRow, newData); err != nil {
+				return err
+			}
+		}
+		sc := e.ctx.GetSessionVars().StmtCtx
+		for _, fkc := range e.fkCascades {
+			if err = fkc.onUpdateRow(sc, oldRow, newData); err != nil {
+				return err
+			}
+		}
+	}
+	return e.triggers.fire(ctx, model.TriggerTimingAfter, model.TriggerEventUpdate, oldRow, newData, nil)
 }
 
 // setMessage sets info message(ERR_INSERT_INFO) generated by INSERT statement
@@ -92,6 +92,16 @@ type InsertValues struct {
 	// We use mutex to protect routine from using invalid txn.
 	isLoadData bool
 	txnInUse   sync.Mutex
+
+	fkChecks   []*FKCheckExec
+	fkCascades []*FKCascadeExec
+
+	// checkConstraints are the enforced check constraints of the table.
+	checkConstraints []*table.Constraint
+	// viewChecks are the conditions of the views WITH CHECK OPTION which the rows are inserted through.
+	viewChecks []*table.ViewCheck
+	// triggers activates the triggers of the table, it's nil if the table has no trigger.
+	triggers *triggerExec
 }
 
 type defaultVal struct {
@@ -1116,6 +1126,18 @@ func (e *InsertValues) addRecord(ctx context.Context, row []types.Datum) error {
 
 func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.Datum, reserveAutoIDCount int) (err error) {
 	vars := e.ctx.GetSessionVars()
+	err = table.CheckRowConstraint(e.ctx, e.checkConstraints, row)
+	if err == nil {
+		err = table.CheckRowViewCondition(e.ctx, e.viewChecks, row)
+	}
+	if err != nil {
+		// The row violating the check constraints or the view conditions is skipped by INSERT IGNORE.
+		if vars.StmtCtx.DupKeyAsWarning {
+			vars.StmtCtx.AppendWarning(err)
+			return nil
+		}
+		return err
+	}
 	if !vars.ConstraintCheckInPlace {
 		vars.PresumeKeyNotExists = true
 	}
@@ -1128,10 +1150,29 @@ func (e *InsertValues) addRecordWithAutoIDHint(ctx context.Context, row []types.
 	if err != nil {
 		return err
 	}
+	for _, fkc := range e.fkChecks {
+		if err = fkc.insertRowNeedToCheck(ctx, row); err != nil {
+			return err
+		}
+	}
 	vars.StmtCtx.AddAffectedRows(1)
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
+	return e.triggers.fire(ctx, model.TriggerTimingAfter, model.TriggerEventInsert, nil, row, nil)
+}
+
+// fireBeforeInsert activates the BEFORE INSERT triggers for a batch of rows. The triggers are
+// activated before the duplicate keys of the rows are checked, because they may change the keys.
+func (e *InsertValues) fireBeforeInsert(ctx context.Context, rows [][]types.Datum) error {
+	if e.triggers == nil {
+		return nil
+	}
+	for _, row := range rows {
+		if err := e.triggers.fire(ctx, model.TriggerTimingBefore, model.TriggerEventInsert, nil, row, nil); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
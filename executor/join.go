@@ -94,6 +94,19 @@ type HashJoinExec struct {
 	finished            atomic.Value
 
 	stats *hashJoinRuntimeStats
+
+	// spillState indicates whether the rows of both sides are partitioned into disk,
+	// it is set by HashJoinSpillDiskAction when the memory quota is exceeded while
+	// building the hash table.
+	spillState  uint32
+	spillAction *HashJoinSpillDiskAction
+	// buildSidePartitioner partitions the build side rows after spillState is set.
+	buildSidePartitioner *hashJoinPartitioner
+	// numSpilledBuildRows is the number of the spilled build side rows which may be matched.
+	numSpilledBuildRows int
+	// spilledPartitions are joined one by one after both sides are partitioned.
+	spilledPartitions []*hashJoinPartition
+	probeSideSpilled  bool
 }
 
 // probeChkResource stores the result of the join probe side fetch worker,
@@ -144,6 +157,7 @@ func (e *HashJoinExec) Close() error {
 		e.probeChkResourceCh = nil
 		e.joinChkResourceCh = nil
 		terror.Call(e.rowContainer.Close)
+		e.closeSpilledPartitions()
 	}
 	e.outerMatchedStatus = e.outerMatchedStatus[:0]
 
@@ -166,6 +180,10 @@ func (e *HashJoinExec) Open(ctx context.Context) error {
 	e.closeCh = make(chan struct{})
 	e.finished.Store(false)
 	e.joinWorkerWaitGroup = sync.WaitGroup{}
+	e.spillState = hashJoinBuildInMemory
+	e.spillAction = nil
+	e.numSpilledBuildRows = 0
+	e.probeSideSpilled = false
 
 	if e.probeTypes == nil {
 		e.probeTypes = retTypes(e.probeSideExec)
@@ -228,6 +246,16 @@ func (e *HashJoinExec) fetchProbeSideChunks(ctx context.Context) {
 				return
 			}
 			hasWaitedForBuild = true
+			if e.spilledPartitions != nil {
+				// The probe side rows are partitioned in the same way as the build side ones,
+				// they are joined after all the rows are partitioned.
+				if err = e.spillProbeSide(ctx, probeSideResult); err != nil {
+					e.joinResultCh <- &hashjoinWorkerResult{
+						err: err,
+					}
+				}
+				return
+			}
 		}
 
 		if probeSideResult.NumRows() == 0 {
@@ -247,7 +275,11 @@ func (e *HashJoinExec) wait4BuildSide() (emptyBuild bool, err error) {
 			return false, err
 		}
 	}
-	if e.rowContainer.Len() == uint64(0) && (e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin) {
+	numBuildRows := e.rowContainer.Len()
+	if e.spilledPartitions != nil {
+		numBuildRows = uint64(e.numSpilledBuildRows)
+	}
+	if numBuildRows == uint64(0) && (e.joinType == plannercore.InnerJoin || e.joinType == plannercore.SemiJoin) {
 		return true, nil
 	}
 	return false, nil
@@ -322,11 +354,7 @@ func (e *HashJoinExec) fetchAndProbeHashTable(ctx context.Context) {
 		e.fetchProbeSideChunks(ctx)
 	}, e.handleProbeSideFetcherPanic)
 
-	probeKeyColIdx := make([]int, len(e.probeKeys))
-	for i := range e.probeKeys {
-		probeKeyColIdx[i] = e.probeKeys[i].Index
-	}
-
+	probeKeyColIdx := e.probeKeyColIdx()
 	for i := uint(0); i < e.concurrency; i++ {
 		e.joinWorkerWaitGroup.Add(1)
 		workID := i
@@ -383,17 +411,18 @@ func (e *HashJoinExec) handleUnmatchedRowsFromHashTable(workerID uint) {
 			}
 		}
 	}
-
-	if joinResult == nil {
-		return
-	} else if joinResult.err != nil || (joinResult.chk != nil && joinResult.chk.NumRows() > 0) {
-		e.joinResultCh <- joinResult
-	}
+	e.sendLastJoinResult(workerID, joinResult)
 }
 
 func (e *HashJoinExec) waitJoinWorkersAndCloseResultChan() {
 	e.joinWorkerWaitGroup.Wait()
-	if e.useOuterToBuild {
+	if e.probeSideSpilled {
+		util.WithRecovery(e.joinSpilledPartitions, func(r interface{}) {
+			if r != nil {
+				e.joinResultCh <- &hashjoinWorkerResult{err: errors.Errorf("%v", r)}
+			}
+		})
+	} else if e.useOuterToBuild {
 		// Concurrently handling unmatched rows from the hash table at the tail
 		for i := uint(0); i < e.concurrency; i++ {
 			var workerID = i
@@ -460,6 +489,12 @@ func (e *HashJoinExec) runJoinWorker(workerID uint, probeKeyColIdx []int) {
 		emptyProbeSideResult.chk = probeSideResult
 		e.probeChkResourceCh <- emptyProbeSideResult
 	}
+	e.sendLastJoinResult(workerID, joinResult)
+}
+
+// sendLastJoinResult sends the last join result of a join worker, or gives the
+// chunk back if it is empty.
+func (e *HashJoinExec) sendLastJoinResult(workerID uint, joinResult *hashjoinWorkerResult) {
 	// note joinResult.chk may be nil when getNewJoinResult fails in loops
 	if joinResult == nil {
 		return
@@ -648,24 +683,7 @@ func (e *HashJoinExec) join2ChunkForOuterHashJoin(workerID uint, probeSideChk *c
 func (e *HashJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	if !e.prepared {
 		e.buildFinished = make(chan error, 1)
-		buildKeyColIdx := make([]int, len(e.buildKeys))
-		for i := range e.buildKeys {
-			buildKeyColIdx[i] = e.buildKeys[i].Index
-		}
-		hCtx := &hashContext{
-			allTypes:  e.buildTypes,
-			keyColIdx: buildKeyColIdx,
-		}
-		e.rowContainer = newHashRowContainer(e.ctx, int(e.buildSideEstCount), hCtx, retTypes(e.buildSideExec))
-		// we shallow copies rowContainer for each probe worker to avoid lock contention
-		e.rowContainerForProbe = make([]*hashRowContainer, e.concurrency)
-		for i := uint(0); i < e.concurrency; i++ {
-			if i == 0 {
-				e.rowContainerForProbe[i] = e.rowContainer
-			} else {
-				e.rowContainerForProbe[i] = e.rowContainer.ShallowCopy()
-			}
-		}
+		e.initRowContainer(int(e.buildSideEstCount))
 		go util.WithRecovery(func() {
 			defer trace.StartRegion(ctx, "HashJoinHashTableBuilder").End()
 			e.fetchAndBuildHashTable(ctx)
@@ -691,6 +709,45 @@ func (e *HashJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 	return nil
 }
 
+// initRowContainer creates e.rowContainer for the build side rows, and shallow
+// copies it for each probe worker.
+func (e *HashJoinExec) initRowContainer(estCount int) {
+	hCtx := &hashContext{
+		allTypes:  e.buildTypes,
+		keyColIdx: e.buildKeyColIdx(),
+	}
+	e.rowContainer = newHashRowContainer(e.ctx, estCount, hCtx, retTypes(e.buildSideExec))
+	e.rowContainer.GetMemTracker().AttachTo(e.memTracker)
+	e.rowContainer.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
+	e.rowContainer.GetDiskTracker().AttachTo(e.diskTracker)
+	e.rowContainer.GetDiskTracker().SetLabel(memory.LabelForBuildSideResult)
+	// we shallow copies rowContainer for each probe worker to avoid lock contention
+	e.rowContainerForProbe = make([]*hashRowContainer, e.concurrency)
+	for i := uint(0); i < e.concurrency; i++ {
+		if i == 0 {
+			e.rowContainerForProbe[i] = e.rowContainer
+		} else {
+			e.rowContainerForProbe[i] = e.rowContainer.ShallowCopy()
+		}
+	}
+}
+
+func (e *HashJoinExec) buildKeyColIdx() []int {
+	buildKeyColIdx := make([]int, len(e.buildKeys))
+	for i := range e.buildKeys {
+		buildKeyColIdx[i] = e.buildKeys[i].Index
+	}
+	return buildKeyColIdx
+}
+
+func (e *HashJoinExec) probeKeyColIdx() []int {
+	probeKeyColIdx := make([]int, len(e.probeKeys))
+	for i := range e.probeKeys {
+		probeKeyColIdx[i] = e.probeKeys[i].Index
+	}
+	return probeKeyColIdx
+}
+
 func (e *HashJoinExec) handleFetchAndBuildHashTablePanic(r interface{}) {
 	if r != nil {
 		e.buildFinished <- errors.Errorf("%v", r)
@@ -745,12 +802,10 @@ func (e *HashJoinExec) fetchAndBuildHashTable(ctx context.Context) {
 func (e *HashJoinExec) buildHashTableForList(buildSideResultCh <-chan *chunk.Chunk) error {
 	var err error
 	var selected []bool
-	e.rowContainer.GetMemTracker().AttachTo(e.memTracker)
-	e.rowContainer.GetMemTracker().SetLabel(memory.LabelForBuildSideResult)
-	e.rowContainer.GetDiskTracker().AttachTo(e.diskTracker)
-	e.rowContainer.GetDiskTracker().SetLabel(memory.LabelForBuildSideResult)
 	if config.GetGlobalConfig().OOMUseTmpStorage {
-		actionSpill := e.rowContainer.ActionSpill()
+		e.spillAction = &HashJoinSpillDiskAction{e: e}
+		e.spillAction.setRowContainer(e.rowContainer)
+		var actionSpill memory.ActionOnExceed = e.spillAction
 		failpoint.Inject("testRowContainerSpill", func(val failpoint.Value) {
 			if val.(bool) {
 				actionSpill = e.rowContainer.rowContainer.ActionSpillForTest()
@@ -763,27 +818,39 @@ func (e *HashJoinExec) buildHashTableForList(buildSideResultCh <-chan *chunk.Chu
 		if e.finished.Load().(bool) {
 			return nil
 		}
-		if !e.useOuterToBuild {
-			err = e.rowContainer.PutChunk(chk, e.isNullEQ)
-		} else {
-			var bitMap = bitmap.NewConcurrentBitmap(chk.NumRows())
-			e.outerMatchedStatus = append(e.outerMatchedStatus, bitMap)
-			e.memTracker.Consume(bitMap.BytesConsumed())
-			if len(e.outerFilter) == 0 {
-				err = e.rowContainer.PutChunk(chk, e.isNullEQ)
-			} else {
-				selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
-				if err != nil {
-					return err
-				}
-				err = e.rowContainer.PutChunkSelected(chk, selected, e.isNullEQ)
+		if e.buildSidePartitioner == nil && atomic.LoadUint32(&e.spillState) == hashJoinSpillRequested {
+			if err = e.spillBuildSide(); err != nil {
+				return err
 			}
 		}
+		if e.buildSidePartitioner != nil {
+			selected, err = e.partitionBuildSideChunk(e.buildSidePartitioner, chk, selected)
+		} else {
+			selected, err = e.putBuildSideChunk(chk, selected)
+		}
 		if err != nil {
 			return err
 		}
 	}
-	return nil
+	return e.finishBuildSide()
+}
+
+// putBuildSideChunk puts a build side chunk into e.rowContainer.
+func (e *HashJoinExec) putBuildSideChunk(chk *chunk.Chunk, selected []bool) (_ []bool, err error) {
+	if !e.useOuterToBuild {
+		return selected, e.rowContainer.PutChunk(chk, e.isNullEQ)
+	}
+	var bitMap = bitmap.NewConcurrentBitmap(chk.NumRows())
+	e.outerMatchedStatus = append(e.outerMatchedStatus, bitMap)
+	e.memTracker.Consume(bitMap.BytesConsumed())
+	if len(e.outerFilter) == 0 {
+		return selected, e.rowContainer.PutChunk(chk, e.isNullEQ)
+	}
+	selected, err = expression.VectorizedFilter(e.ctx, e.outerFilter, chunk.NewIterator4Chunk(chk), selected)
+	if err != nil {
+		return selected, err
+	}
+	return selected, e.rowContainer.PutChunkSelected(chk, selected, e.isNullEQ)
 }
 
 // NestedLoopApplyExec is the executor for apply.
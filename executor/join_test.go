@@ -95,6 +95,51 @@ func (s *testSuite) TestJoinInDisk(c *C) {
 	result.Check(testkit.Rows("2 2 2 3"))
 }
 
+func (s *testSuiteJoinSerial) TestGraceHashJoinInDisk(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b varchar(64))")
+	tk.MustExec("create table t2(a int, b varchar(64))")
+	sql := "insert into t1 values (null, repeat('a', 64))"
+	for i := 0; i < 1000; i++ {
+		sql += fmt.Sprintf(",(%v, repeat('a', 64))", i)
+	}
+	tk.MustExec(sql)
+	for i := 0; i < 6; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t1 select a + %v, b from t1 where a is not null", 1000<<i))
+	}
+	tk.MustExec("insert into t2 select * from t1 where a is null or a % 2 = 0")
+	tk.MustExec("set tidb_mem_quota_query = 1048576")
+
+	rows := tk.MustQuery("desc analyze select /*+ HASH_JOIN(t1, t2) */ count(*), max(t1.b), max(t2.b) from t1 join t2 on t1.a = t2.a").Rows()
+	for _, row := range rows {
+		length := len(row)
+		line := fmt.Sprintf("%v", row)
+		disk := fmt.Sprintf("%v", row[length-1])
+		if strings.Contains(line, "HashJoin") {
+			c.Assert(strings.Contains(disk, "0 Bytes"), IsFalse)
+			c.Assert(strings.Contains(disk, "MB") ||
+				strings.Contains(disk, "KB") ||
+				strings.Contains(disk, "Bytes"), IsTrue)
+		}
+	}
+	check := func() {
+		tk.MustQuery("select /*+ HASH_JOIN(t1, t2) */ count(*), sum(t1.a), sum(t2.a), length(max(t1.b)), length(max(t2.b)) from t1 join t2 on t1.a = t2.a").Check(
+			testkit.Rows("32000 1023968000 1023968000 64 64"))
+		tk.MustQuery("select /*+ HASH_JOIN(t1, t2) */ count(*), count(t2.a), count(t1.b), count(t2.b) from t1 left join t2 on t1.a = t2.a").Check(
+			testkit.Rows("64001 32000 64001 32000"))
+		tk.MustQuery("select /*+ HASH_JOIN(t1, t2) */ count(*), count(t1.a), count(t1.b), count(t2.b) from t1 right join t2 on t1.a = t2.a and t1.a % 4 = 0").Check(
+			testkit.Rows("32001 16000 16000 32001"))
+		tk.MustQuery("select count(*), max(b) = repeat('a', 64) from t1 where a in (select a from t2)").Check(testkit.Rows("32000 1"))
+		tk.MustQuery("select count(*) from t1 where not exists (select 1 from t2 where t2.a = t1.a)").Check(testkit.Rows("32001"))
+	}
+	check()
+	plannercore.ForceUseOuterBuild4Test = true
+	defer func() { plannercore.ForceUseOuterBuild4Test = false }()
+	check()
+}
+
 func (s *testSuiteJoin2) TestJoin(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 
@@ -0,0 +1,76 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONValue(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery(`select json_value('{"a": "x", "b": 1.5, "c": [1], "d": null}', '$.a'), json_value('{"b": 1.5}', '$.b'), json_value('{"c": [1]}', '$.c'), json_value('{"d": null}', '$.d')`).
+		Check(testkit.Rows("x 1.5 [1] <nil>"))
+	tk.MustQuery(`select json_value('{"a": 1.234}', '$.a' returning decimal(10, 2)), json_value('{"a": "12"}', '$.a' returning signed), json_value('{"a": "2021-01-02"}', '$.a' returning date)`).
+		Check(testkit.Rows("1.23 12 2021-01-02"))
+	tk.MustQuery(`select json_value('{"a": {"b": 1}}', '$.a' returning json), json_value(null, '$.a'), json_value('{}', null)`).
+		Check(testkit.Rows(`{"b": 1} <nil> <nil>`))
+
+	// The ON EMPTY and ON ERROR clauses.
+	tk.MustQuery(`select json_value('{}', '$.a' default 'none' on empty), json_value('{"a": "x"}', '$.a' returning signed default 0 on error), json_value('[1, 2]', '$[*]' null on error)`).
+		Check(testkit.Rows("none 0 <nil>"))
+	require.EqualError(t, tk.QueryToErr(`select json_value('{}', '$.a' error on empty)`), "[expression:3966]No value was found by 'json_value' on the specified path.")
+	require.EqualError(t, tk.QueryToErr(`select json_value('[1, 2]', '$[*]' error on error)`), "[expression:3967]More than one value was found by 'json_value' on the specified path.")
+	tk.MustGetErrMsg(`select json_value('{}', '$.a' returning signed default 'x' on empty)`, "[types:1292]Truncated incorrect DOUBLE value: 'x'")
+
+	tk.MustExec("create table t (id int primary key, doc json)")
+	tk.MustExec(`insert into t values (1, '{"price": 9.5, "name": "a"}'), (2, '{"price": "bad"}'), (3, '{}'), (4, null)`)
+	tk.MustQuery(`select id, json_value(doc, '$.price' returning decimal(10, 2) default 0 on empty default -1 on error) from t order by id`).
+		Check(testkit.Rows("1 9.50", "2 -1.00", "3 0.00", "4 <nil>"))
+	tk.MustQuery(`select id from t where json_value(doc, '$.name') = 'a'`).Check(testkit.Rows("1"))
+	// The functions with the different returning types are not the same expression.
+	tk.MustQuery(`select json_value(doc, '$.price' returning signed), json_value(doc, '$.price' returning char(10)) from t where id = 1`).
+		Check(testkit.Rows("10 9.5"))
+}
+
+func TestJSONSchemaValid(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec(`set @schema = '{"type": "object", "properties": {"id": {"type": "integer", "minimum": 1}, "name": {"type": "string"}}, "required": ["id"]}'`)
+	tk.MustQuery(`select json_schema_valid(@schema, '{"id": 1, "name": "a"}'), json_schema_valid(@schema, '{"name": "a"}'), json_schema_valid(@schema, null)`).
+		Check(testkit.Rows("1 0 <nil>"))
+	tk.MustQuery(`select json_schema_validation_report(@schema, '{"id": 1}'), json_schema_validation_report(@schema, '{"id": 0}')`).
+		Check(testkit.Rows(`{"valid": true} {"document-location": "#/id", "reason": "The JSON document location '#/id' failed requirement 'minimum' at JSON Schema location '#/properties/id'", "schema-failed-keyword": "minimum", "schema-location": "#/properties/id", "valid": false}`))
+	require.EqualError(t, tk.QueryToErr(`select json_schema_valid('[]', '1')`), "[json:3853]Invalid JSON type in argument 1 to function json_schema_valid; an object is required.")
+	require.EqualError(t, tk.QueryToErr(`select json_schema_valid('{"type": 1}', '1')`), "[json:8138]Invalid JSON schema: the value of 'type' at '#' must be an array of strings")
+
+	tk.MustExec("create table t (doc json, check (json_schema_valid('{\"required\": [\"a\"]}', doc)))")
+	tk.MustExec(`insert into t values ('{"a": 1}')`)
+	tk.MustGetErrMsg(`insert into t values ('{"b": 1}')`, "[table:3819]Check constraint 't_chk_1' is violated.")
+	tk.MustQuery(`select json_storage_free(doc), json_storage_free(null) from t`).Check(testkit.Rows("0 <nil>"))
+}
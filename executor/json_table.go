@@ -0,0 +1,174 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// JSONTableExec represents the JSON_TABLE table function executor.
+// The rows are produced from the JSON document when it's opened, the document may reference the
+// columns of the preceding tables, so it's reopened by the apply executor for every outer row.
+type JSONTableExec struct {
+	baseExecutor
+
+	expr   expression.Expression
+	root   *plannercore.JSONTablePath
+	asName model.CIStr
+
+	sc     *stmtctx.StatementContext
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	e.rows, e.cursor = nil, 0
+	// The values are converted in strict mode, the errors are handled by the ON ERROR clauses.
+	e.sc = &stmtctx.StatementContext{TimeZone: e.ctx.GetSessionVars().Location()}
+	doc, isNull, err := e.expr.EvalJSON(e.ctx, chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	e.rows, err = e.buildRows(e.root, doc)
+	return err
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.maxChunkSize)
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i, d := range e.rows[e.cursor] {
+			req.AppendDatum(i, &d)
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return nil
+}
+
+// buildRows produces the rows of every value matched by the path. The rows of the nested paths are
+// produced one sibling after another, the columns of the other siblings are NULL. If none of the
+// nested paths produces a row, a single row is produced with the nested columns being NULL.
+func (e *JSONTableExec) buildRows(path *plannercore.JSONTablePath, bj json.BinaryJSON) ([][]types.Datum, error) {
+	var rows [][]types.Datum
+	for i, item := range bj.ExtractAll(path.Path) {
+		var itemRows [][]types.Datum
+		for _, nested := range path.Nested {
+			nestedRows, err := e.buildRows(nested, item)
+			if err != nil {
+				return nil, err
+			}
+			itemRows = append(itemRows, nestedRows...)
+		}
+		if len(itemRows) == 0 {
+			itemRows = append(itemRows, make([]types.Datum, e.schema.Len()))
+		}
+		for _, col := range path.Columns {
+			d, err := e.evalColumn(col, item, i+1)
+			if err != nil {
+				return nil, err
+			}
+			for _, row := range itemRows {
+				row[col.Index] = d
+			}
+		}
+		rows = append(rows, itemRows...)
+	}
+	return rows, nil
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, item json.BinaryJSON, ordinality int) (types.Datum, error) {
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExists:
+		exists := int64(0)
+		if len(item.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		return e.convert(col, types.NewIntDatum(exists))
+	}
+	values := item.ExtractAll(col.Path)
+	switch {
+	case len(values) == 0:
+		return e.onResponse(col, col.OnEmpty, ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	case col.RetType.Tp == mysql.TypeJSON:
+		if len(values) > 1 {
+			return types.NewJSONDatum(json.CreateBinaryArray(values)), nil
+		}
+		return types.NewJSONDatum(values[0]), nil
+	case len(values) > 1, values[0].TypeCode == json.TypeCodeArray, values[0].TypeCode == json.TypeCodeObject:
+		return e.onResponse(col, col.OnError, ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.asName.O))
+	}
+	d, err := e.convert(col, jsonScalarToDatum(values[0]))
+	if err != nil {
+		return e.onResponse(col, col.OnError, err)
+	}
+	return d, nil
+}
+
+// onResponse returns the value of the ON EMPTY or ON ERROR clause, err is returned for ERROR.
+func (e *JSONTableExec) onResponse(col *plannercore.JSONTableColumn, resp plannercore.JSONTableOnResponse, err error) (types.Datum, error) {
+	switch resp.Tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		if col.RetType.Tp == mysql.TypeJSON {
+			return types.NewJSONDatum(resp.Default), nil
+		}
+		return e.convert(col, jsonScalarToDatum(resp.Default))
+	}
+	return types.Datum{}, nil
+}
+
+func (e *JSONTableExec) convert(col *plannercore.JSONTableColumn, d types.Datum) (types.Datum, error) {
+	res, err := d.ConvertTo(e.sc, col.RetType)
+	if err != nil {
+		if types.ErrOverflow.Equal(err) || types.ErrWarnDataOutOfRange.Equal(err) {
+			return res, ErrJTValueOutOfRange.GenWithStackByArgs(col.Name.O)
+		}
+		return res, errors.Trace(err)
+	}
+	return res, nil
+}
+
+// jsonScalarToDatum converts the JSON scalar to a datum, the JSON strings are unquoted.
+func jsonScalarToDatum(bj json.BinaryJSON) types.Datum {
+	switch bj.TypeCode {
+	case json.TypeCodeString:
+		return types.NewStringDatum(string(bj.GetString()))
+	case json.TypeCodeLiteral:
+		if bj.Value[0] == json.LiteralNil {
+			return types.Datum{}
+		}
+	}
+	return types.NewJSONDatum(bj)
+}
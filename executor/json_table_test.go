@@ -0,0 +1,76 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestJSONTable(t *testing.T) {
+	t.Parallel()
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": "x"}, {"a": 2}, {"b": "z"}]', '$[*]' columns (
+		id for ordinality, a int path '$.a', b varchar(10) path '$.b' default '"none"' on empty, c json path '$', d int exists path '$.b')) as jt`).
+		Check(testkit.Rows(`1 1 x {"a": 1, "b": "x"} 1`, `2 2 none {"a": 2} 0`, `3 <nil> z {"b": "z"} 1`))
+	tk.MustQuery(`select * from json_table(null, '$[*]' columns (a int path '$')) as jt`).Check(testkit.Rows())
+
+	// The nested paths produce the rows one sibling after another.
+	tk.MustQuery(`select * from json_table('[{"a": 1, "b": [1, 2], "c": ["x"]}, {"a": 2, "b": []}]', '$[*]' columns (
+		a int path '$.a',
+		nested path '$.b[*]' columns (b for ordinality, bv int path '$'),
+		nested path '$.c[*]' columns (cv varchar(10) path '$'))) as jt`).
+		Check(testkit.Rows("1 1 1 <nil>", "1 2 2 <nil>", "1 <nil> <nil> x", "2 <nil> <nil> <nil>"))
+
+	// The document can reference the columns of the preceding tables.
+	tk.MustExec("create table t (id int primary key, doc json)")
+	tk.MustExec(`insert into t values (1, '{"items": [{"name": "a", "qty": 2}, {"name": "b", "qty": 3}]}'), (2, '{"items": []}'), (3, null)`)
+	tk.MustQuery(`select t.id, jt.* from t, json_table(t.doc, '$.items[*]' columns (name varchar(10) path '$.name', qty int path '$.qty')) as jt order by t.id, jt.name`).
+		Check(testkit.Rows("1 a 2", "1 b 3"))
+	tk.MustQuery(`select t.id, jt.name from t left join json_table(t.doc, '$.items[*]' columns (name varchar(10) path '$.name')) as jt on true order by t.id, jt.name`).
+		Check(testkit.Rows("1 a", "1 b", "2 <nil>", "3 <nil>"))
+	tk.MustQuery(`select t.id, sum(jt.qty) from t join json_table(t.doc, '$.items[*]' columns (qty int path '$.qty')) as jt where jt.qty > 2 group by t.id`).
+		Check(testkit.Rows("1 3"))
+	tk.MustQuery(`explain format = 'brief' select t.id, jt.name from t, json_table(t.doc, '$.items[*]' columns (name varchar(10) path '$.name')) as jt`).Check(testkit.Rows(
+		"Projection 10000.00 root  test.t.id, Column#3",
+		"└─Apply 10000.00 root  CARTESIAN inner join",
+		"  ├─TableReader(Build) 10000.00 root  data:TableFullScan",
+		"  │ └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo",
+		"  └─JSONTable(Probe) 10.00 root  expr:test.t.doc, path:$.items[*]"))
+
+	// The ON EMPTY and ON ERROR clauses.
+	tk.MustQuery(`select * from json_table('[{"a": [1]}, {"a": "x"}, {}]', '$[*]' columns (
+		a int path '$.a' default '0' on empty default '-1' on error, b int path '$.a' null on error)) as jt`).
+		Check(testkit.Rows("-1 <nil>", "-1 <nil>", "0 <nil>"))
+	tk.MustGetErrMsg(`select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt`,
+		"[executor:3665]Missing value for JSON_TABLE column 'a'")
+	tk.MustGetErrMsg(`select * from json_table('[{"a": [1]}]', '$[*]' columns (a int path '$.a' error on error)) as jt`,
+		"[executor:3666]Can't store an array or an object in the scalar column 'a' of JSON_TABLE 'jt'.")
+	tk.MustGetErrMsg(`select * from json_table('[{"a": 1000}]', '$[*]' columns (a tinyint path '$.a' error on error)) as jt`,
+		"[executor:3669]Value is out of range for JSON_TABLE's column 'a'")
+
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$'))`, errno.ErrTFMustHaveAlias)
+	tk.MustGetErrCode(`select * from json_table('[]', '$[*]' columns (a int path '$', a int path '$')) as jt`, errno.ErrDupFieldName)
+	tk.MustGetErrMsg(`select * from json_table(t.doc, '$[*]' columns (a int path '$')) as jt join t on true`,
+		"[planner:1054]Unknown column 't.doc' in 'a table function argument'")
+	tk.MustGetErrCode(`select * from t right join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true`, errno.ErrTFForbiddenReference)
+}
@@ -0,0 +1,156 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	driver "github.com/pingcap/tidb/store/driver/txn"
+	"github.com/pingcap/tidb/tablecodec"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/rowcodec"
+)
+
+// kvIndexLookUpExec reads the rows by the handles of the index entries in the KV ranges of an index, the
+// index entries and the rows are read from the KV store directly since the indexed values of the FULLTEXT
+// and SPATIAL indexes are not the values of the columns, which can't be read by the coprocessor.
+type kvIndexLookUpExec struct {
+	baseExecutor
+
+	tblInfo *model.TableInfo
+	idxInfo *model.IndexInfo
+	startTS uint64
+
+	columns []*model.ColumnInfo
+	// virtualColumnIndex records all the indices of virtual columns and sort them in definition
+	// to make sure we can compute the virtual column in right order.
+	virtualColumnIndex []int
+	// virtualColumnRetFieldTypes records the RetFieldTypes of virtual columns.
+	virtualColumnRetFieldTypes []*types.FieldType
+	rowDecoder                 *rowcodec.ChunkDecoder
+
+	prepared bool
+	handles  []kv.Handle
+	values   [][]byte
+	index    int
+}
+
+// buildVirtualColumnInfo saves virtual column indices and sort them in definition order
+func (e *kvIndexLookUpExec) buildVirtualColumnInfo() {
+	e.virtualColumnIndex = buildVirtualColumnIndex(e.Schema(), e.columns)
+	if len(e.virtualColumnIndex) > 0 {
+		e.virtualColumnRetFieldTypes = make([]*types.FieldType, len(e.virtualColumnIndex))
+		for i, idx := range e.virtualColumnIndex {
+			e.virtualColumnRetFieldTypes[i] = e.schema.Columns[idx].RetType
+		}
+	}
+}
+
+// Open implements the Executor interface.
+func (e *kvIndexLookUpExec) Open(context.Context) error {
+	e.prepared = false
+	e.handles, e.values, e.index = nil, nil, 0
+	return nil
+}
+
+// Close implements the Executor interface.
+func (e *kvIndexLookUpExec) Close() error {
+	e.handles, e.values = nil, nil
+	return nil
+}
+
+// fillChunk decodes the fetched rows into the chunk.
+func (e *kvIndexLookUpExec) fillChunk(req *chunk.Chunk) error {
+	for !req.IsFull() && e.index < len(e.values) {
+		err := DecodeRowValToChunk(e.ctx, e.schema, e.tblInfo, e.handles[e.index], e.values[e.index], req, e.rowDecoder)
+		if err != nil {
+			return err
+		}
+		e.index++
+	}
+	return FillVirtualColumnValue(e.virtualColumnRetFieldTypes, e.virtualColumnIndex, e.schema, e.columns, e.ctx, req)
+}
+
+// lookUp scans the index entries in the KV ranges, and fetches the rows by the distinct handles of them.
+func (e *kvIndexLookUpExec) lookUp(ctx context.Context, ranges []kv.KeyRange) error {
+	txn, err := e.ctx.Txn(false)
+	if err != nil {
+		return err
+	}
+	var (
+		snapshot    kv.Snapshot
+		retriever   kv.Retriever
+		batchGetter kv.BatchGetter
+	)
+	if txn.Valid() && e.ctx.GetSessionVars().TxnCtx.StartTS == e.startTS {
+		// Read the index entries and the rows written by the transaction itself.
+		snapshot = txn.GetSnapshot()
+		retriever = txn
+		batchGetter = driver.NewBufferBatchGetter(txn.GetMemBuffer(), nil, snapshot)
+	} else {
+		snapshot = e.ctx.GetSnapshotWithTS(e.startTS)
+		retriever, batchGetter = snapshot, snapshot
+	}
+
+	dedup := kv.NewHandleMap()
+	for _, kr := range ranges {
+		it, err := retriever.Iter(kr.StartKey, kr.EndKey)
+		if err != nil {
+			return err
+		}
+		for it.Valid() {
+			h, err := tablecodec.DecodeIndexHandle(it.Key(), it.Value(), 1)
+			if err != nil {
+				it.Close()
+				return err
+			}
+			if _, ok := dedup.Get(h); !ok {
+				dedup.Set(h, true)
+				e.handles = append(e.handles, h)
+			}
+			if err = it.Next(); err != nil {
+				it.Close()
+				return err
+			}
+		}
+		it.Close()
+	}
+	sort.Slice(e.handles, func(i, j int) bool {
+		return e.handles[i].Compare(e.handles[j]) < 0
+	})
+
+	keys := make([]kv.Key, 0, len(e.handles))
+	for _, h := range e.handles {
+		keys = append(keys, tablecodec.EncodeRowKeyWithHandle(e.tblInfo.ID, h))
+	}
+	values, err := batchGetter.BatchGet(ctx, keys)
+	if err != nil {
+		return err
+	}
+	e.values = make([][]byte, 0, len(values))
+	for i, key := range keys {
+		val := values[string(key)]
+		if len(val) == 0 {
+			return kv.ErrNotExist.GenWithStack("inconsistent extra index %s, handle %s not found in table",
+				e.idxInfo.Name.O, e.handles[i])
+		}
+		e.values = append(e.values, val)
+	}
+	return nil
+}
@@ -329,8 +329,18 @@ func iterTxnMemBuffer(ctx sessionctx.Context, cacheTable kv.MemBuffer, kvRanges
 		return err
 	}
 
+	inTrigger := ctx.GetSessionVars().StmtCtx.InTrigger
 	for _, rg := range kvRanges {
-		iter := txn.GetMemBuffer().SnapshotIter(rg.StartKey, rg.EndKey)
+		var iter kv.Iterator
+		if inTrigger {
+			// The statement of a trigger reads the changes made by the triggering statement.
+			iter, err = txn.GetMemBuffer().Iter(rg.StartKey, rg.EndKey)
+			if err != nil {
+				return err
+			}
+		} else {
+			iter = txn.GetMemBuffer().SnapshotIter(rg.StartKey, rg.EndKey)
+		}
 		snapCacheIter, err := getSnapIter(ctx, cacheTable, rg)
 		if err != nil {
 			return err
@@ -0,0 +1,87 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/distsql"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+var _ Executor = &MVIndexLookUpExec{}
+
+// MVIndexLookUpExec reads the rows whose JSON arrays have any element of the values through the entries of a
+// multi-valued index. The condition is not checked here, it's evaluated by the Selection on it.
+type MVIndexLookUpExec struct {
+	kvIndexLookUpExec
+
+	values []expression.Expression
+}
+
+// Next implements the Executor interface.
+func (e *MVIndexLookUpExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.prepared {
+		if err := e.prepare(ctx); err != nil {
+			return err
+		}
+		e.prepared = true
+	}
+	return e.fillChunk(req)
+}
+
+// prepare looks up the handles of the rows in the index entries of the elements of the values, and fetches the
+// rows by the handles.
+func (e *MVIndexLookUpExec) prepare(ctx context.Context) error {
+	sc := e.ctx.GetSessionVars().StmtCtx
+	arrayTp := &e.tblInfo.Columns[e.idxInfo.Columns[0].Offset].FieldType
+	// The elements are casted like the elements of the arrays in the index. The elements which can't be casted
+	// are skipped since they can't be in any array of the index.
+	castSc := &stmtctx.StatementContext{TimeZone: sc.TimeZone}
+	var ranges []*ranger.Range
+	for _, value := range e.values {
+		doc, isNull, err := value.EvalJSON(e.ctx, chunk.Row{})
+		if err != nil {
+			return err
+		}
+		if isNull {
+			continue
+		}
+		for _, elem := range json.FlattenBinaryArray(doc) {
+			d := types.NewJSONDatum(json.CreateBinaryArray([]json.BinaryJSON{elem}))
+			casted, err := d.ConvertTo(castSc, arrayTp)
+			if err != nil {
+				continue
+			}
+			d = tables.MVIndexElemDatum(casted.GetMysqlJSON().ArrayGetElem(0), arrayTp)
+			ranges = append(ranges, &ranger.Range{LowVal: []types.Datum{d}, HighVal: []types.Datum{d}})
+		}
+	}
+	if len(ranges) == 0 {
+		return nil
+	}
+	kvRanges, err := distsql.IndexRangesToKVRanges(sc, e.tblInfo.ID, e.idxInfo.ID, ranges, nil)
+	if err != nil {
+		return err
+	}
+	return e.lookUp(ctx, kvRanges)
+}
@@ -0,0 +1,155 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONMemberOfAndOverlaps(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery(`select 1 member of ('[1, 2]'), 3 member of ('[1, 2]'), '"a"' member of ('["a"]'), 'a' member of ('["a"]'), 1 member of ('1')`).
+		Check(testkit.Rows("1 0 0 1 1"))
+	tk.MustQuery(`select cast('[1]' as json) member of ('[[1], 2]'), null member of ('[1]'), 1 member of (null)`).Check(testkit.Rows("1 <nil> <nil>"))
+	tk.MustQuery(`select json_overlaps('[1, 2]', '[2, 3]'), json_overlaps('[1, 2]', '[3]'), json_overlaps('[1, 2]', '1'), json_overlaps('1', '1')`).
+		Check(testkit.Rows("1 0 1 1"))
+	tk.MustQuery(`select json_overlaps('{"a": 1, "b": 2}', '{"b": 2}'), json_overlaps('{"a": 1}', '{"a": 2}'), json_overlaps('{"a": 1}', '[{"a": 1}]'), json_overlaps(null, '1')`).
+		Check(testkit.Rows("1 0 1 <nil>"))
+	require.EqualError(t, tk.QueryToErr("select json_overlaps('[1', '1')"), "[json:3140]Invalid JSON text: The document root must not be followed by other values.")
+}
+
+func TestMultiValuedIndex(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, tags json, index idx ((cast(tags->'$' as unsigned array))))")
+	tk.MustExec(`insert into t values (1, '[1, 2, 2]'), (2, '[2, 3]'), (3, '4'), (4, '[]'), (5, null)`)
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `tags` json DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  KEY `idx` ((cast(json_extract(`tags`, _utf8mb4'$') as unsigned array)))\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	tk.MustQuery("select id from t where 2 member of (tags->'$') order by id").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select id from t where 4 member of (tags->'$') order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from t where -1 member of (tags->'$') order by id").Check(testkit.Rows())
+	tk.MustQuery("select id from t where '2' member of (tags->'$') order by id").Check(testkit.Rows())
+	tk.MustQuery(`select id from t where json_contains(tags->'$', '[1, 2]') order by id`).Check(testkit.Rows("1"))
+	tk.MustQuery(`select id from t where json_contains(tags->'$', '[]') order by id`).Check(testkit.Rows("1", "2", "4"))
+	tk.MustQuery(`select id from t where json_overlaps(tags->'$', '[3, 4]') order by id`).Check(testkit.Rows("2", "3"))
+	tk.MustQuery(`select id from t where json_overlaps('[1, 5]', tags->'$') and id > 1 order by id`).Check(testkit.Rows())
+
+	// The multi-valued index is used to find the rows having any element of the values.
+	rows := tk.MustQuery("explain format = 'brief' select id from t where 2 member of (tags->'$')").Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, []interface{}{"  └─MVIndexLookUp", "10000.00", "root", "table:t, index:idx(cast(json_extract(`tags`, _utf8mb4'$') as unsigned array))", "values:cast(2, json BINARY)"}, rows[2])
+	rows = tk.MustQuery(`explain format = 'brief' select id from t where json_overlaps(tags->'$', '[3, 4]') and id > 1`).Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, "  └─MVIndexLookUp", rows[2][0])
+	rows = tk.MustQuery(`explain format = 'brief' select id from t where json_contains(tags->'$', '[]')`).Rows()
+	for _, row := range rows {
+		require.NotContains(t, row[0], "MVIndexLookUp")
+	}
+
+	// The index entries are maintained by the writes, including the uncommitted ones.
+	tk.MustExec("begin")
+	tk.MustExec(`update t set tags = '[5]' where id = 1`)
+	tk.MustExec(`insert into t values (6, '[2, 5]')`)
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select id from t where 2 member of (tags->'$') order by id").Check(testkit.Rows("6"))
+	tk.MustExec("commit")
+	tk.MustQuery("select id from t where 2 member of (tags->'$') order by id").Check(testkit.Rows("6"))
+	tk.MustQuery("select id from t where 5 member of (tags->'$') order by id").Check(testkit.Rows("1", "6"))
+
+	// The elements must be able to be casted to the array type.
+	tk.MustGetErrCode(`insert into t values (7, '["a"]')`, errno.ErrInvalidJSONValueForFuncIndex)
+	tk.MustGetErrCode(`insert into t values (7, '[[1]]')`, errno.ErrInvalidJSONValueForFuncIndex)
+	tk.MustGetErrCode(`insert into t values (7, '[-1]')`, errno.ErrJSONValueOutOfRangeForFuncIndex)
+	tk.MustExec("create table t1 (id int primary key, tags json, index idx ((cast(tags as char(3) array))))")
+	tk.MustExec(`insert into t1 values (1, '["abc", "d"]')`)
+	tk.MustGetErrCode(`insert into t1 values (2, '["abcd"]')`, errno.ErrFunctionalIndexDataIsTooLong)
+	tk.MustQuery(`select id from t1 where json_contains(tags, '"d"')`).Check(testkit.Rows("1"))
+
+	// The multi-valued index can be added to the existing JSON column.
+	tk.MustExec("create table t2 (id int primary key, tags json)")
+	tk.MustExec(`insert into t2 values (1, '[1.5, 2]'), (2, '[3]')`)
+	tk.MustExec("alter table t2 add index idx ((cast(tags as double array)))")
+	tk.MustQuery("select id from t2 where 1.5 member of (tags)").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t2 where 3 member of (tags)").Check(testkit.Rows("2"))
+
+	// CAST(... ARRAY) is only allowed as a key part of an index.
+	tk.MustGetErrCode("select cast('[1]' as unsigned array)", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t3 (j json, k json as (cast(j as unsigned array)))", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t3 (j json, index ((cast(j as date array))))", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create table t3 (j json, unique index ((cast(j as unsigned array))))", errno.ErrUnsupportedDDLOperation)
+	tk.MustGetErrCode("create table t3 (j json, index ((cast(j as unsigned array)), (cast(j->'$.a' as unsigned array))))", errno.ErrUnsupportedDDLOperation)
+}
+
+func TestMultiValuedIndexAccessPath(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, b int, j json, key kb (b), key mvi ((cast(j->'$' as unsigned array))))")
+	tk.MustExec(`insert into t values (1, 1, '[1, 2]'), (2, 1, '[3]'), (3, 2, '[1]')`)
+
+	// The multi-valued index is chosen by the cost like the other indexes.
+	tk.MustQuery("explain format = 'brief' select id from t where 1 member of (j->'$') and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  gt(test.t.b, 1), json_memberof(cast(1, json BINARY), json_extract(test.t.j, \"$\"))",
+		"  └─MVIndexLookUp 3333.33 root table:t, index:mvi(cast(json_extract(`j`, _utf8mb4'$') as unsigned array)) values:cast(1, json BINARY)"))
+	tk.MustQuery("explain format = 'brief' select id from t where 1 member of (j->'$') and id = 1").Check(testkit.Rows(
+		"Projection 0.80 root  test.t.id",
+		"└─Selection 0.80 root  json_memberof(cast(1, json BINARY), json_extract(test.t.j, \"$\"))",
+		"  └─Point_Get 1.00 root table:t handle:1"))
+	tk.MustQuery("explain format = 'brief' select id from t where 1 member of (j->'$') and b = 1").Check(testkit.Rows(
+		"Projection 8.00 root  test.t.id",
+		"└─Selection 8.00 root  json_memberof(cast(1, json BINARY), json_extract(test.t.j, \"$\"))",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:kb(b) range:[1,1], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+
+	// The index hints are honored.
+	tk.MustQuery("explain format = 'brief' select id from t ignore index (mvi) where 1 member of (j->'$')").Check(testkit.Rows(
+		"Projection 8000.00 root  test.t.id",
+		"└─Selection 8000.00 root  json_memberof(cast(1, json BINARY), json_extract(test.t.j, \"$\"))",
+		"  └─TableReader 10000.00 root  data:TableFullScan",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t use index (kb) where 1 member of (j->'$') and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  json_memberof(cast(1, json BINARY), json_extract(test.t.j, \"$\"))",
+		"  └─IndexLookUp 3333.33 root  ",
+		"    ├─IndexRangeScan(Build) 3333.33 cop[tikv] table:t, index:kb(b) range:(1,+inf], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 3333.33 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("select id from t ignore index (mvi) where 1 member of (j->'$') order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t use index (kb) where 1 member of (j->'$') and b > 0 order by id").Check(testkit.Rows("1", "3"))
+}
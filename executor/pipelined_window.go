@@ -66,6 +66,8 @@ type PipelinedWindowExec struct {
 	orderByCols    []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
+	// groups is used to find the bounds of `GROUPS` frames, it is nil for other frames.
+	groups *peerGroups
 
 	// rows keeps rows starting from curStartRow
 	rows                     []chunk.Row
@@ -74,11 +76,24 @@ type PipelinedWindowExec struct {
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	// chunks keeps the child chunks of data, they are spilled to disk when the memory quota is exceeded.
+	chunks *windowChunkBuffer
+	// appended is the number of rows appended to rows, including the ones read from disk after spilling.
+	appended uint64
 }
 
 // Close implements the Executor Close interface.
 func (e *PipelinedWindowExec) Close() error {
-	return errors.Trace(e.baseExecutor.Close())
+	var firstErr error
+	if e.chunks != nil {
+		firstErr = e.chunks.close()
+		e.chunks = nil
+	}
+	if err := e.baseExecutor.Close(); firstErr == nil {
+		firstErr = err
+	}
+	return errors.Trace(firstErr)
 }
 
 // Open implements the Executor Open interface
@@ -96,7 +111,12 @@ func (e *PipelinedWindowExec) Open(ctx context.Context) (err error) {
 		}
 	}
 	e.rows = make([]chunk.Row, 0)
-	return e.baseExecutor.Open(ctx)
+	e.appended = 0
+	if err = e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.chunks = newWindowChunkBuffer(&e.baseExecutor)
+	return nil
 }
 
 func (e *PipelinedWindowExec) firstResultChunkNotReady() bool {
@@ -151,6 +171,9 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 		// e.p is ready to produce data
 		if len(e.data) > e.dataIdx && e.data[e.dataIdx].remaining != 0 {
 			produced, err := e.produce(e.ctx, e.data[e.dataIdx].chk, e.data[e.dataIdx].remaining)
+			if err == nil {
+				err = e.chunks.err
+			}
 			if err != nil {
 				return err
 			}
@@ -161,6 +184,15 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 		}
 	}
 	if len(e.data) > 0 {
+		childChk, err := e.chunks.popFront()
+		if err != nil {
+			return err
+		}
+		if childChk != nil {
+			if err = e.copyChk(childChk, e.data[0].chk); err != nil {
+				return err
+			}
+		}
 		chk.SwapColumns(e.data[0].chk)
 		e.data = e.data[1:]
 		e.dataIdx--
@@ -170,7 +202,7 @@ func (e *PipelinedWindowExec) Next(ctx context.Context, chk *chunk.Chunk) (err e
 
 func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error) {
 	e.newPartition = true
-	if len(e.rows) == 0 {
+	if e.appended == e.dropped {
 		// if getRowsInPartition is called for the first time, we ignore it as a new partition
 		e.newPartition = false
 	}
@@ -197,6 +229,11 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 	}
 	begin, end := e.groupChecker.getNextGroup()
 	e.rowToConsume += uint64(end - begin)
+	e.appended += uint64(end - begin)
+	if e.chunks.spilled {
+		// The rows are read from disk after the chunks are spilled.
+		return
+	}
 	for i := begin; i < end; i++ {
 		e.rows = append(e.rows, e.childResult.GetRow(i))
 	}
@@ -204,6 +241,11 @@ func (e *PipelinedWindowExec) getRowsInPartition(ctx context.Context) (err error
 }
 
 func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
+	if e.chunks.needSpill() {
+		if err = e.spill(); err != nil {
+			return false, err
+		}
+	}
 	// TODO: reuse chunks
 	childResult := newFirstChunk(e.children[0])
 	err = Next(ctx, e.children[0], childResult)
@@ -218,10 +260,16 @@ func (e *PipelinedWindowExec) fetchChild(ctx context.Context) (EOF bool, err err
 
 	// TODO: reuse chunks
 	resultChk := chunk.New(e.retFieldTypes, 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
+	if err = e.chunks.add(childResult); err != nil {
 		return false, err
 	}
+	// The columns of the child chunk are referred when the result chunk is returned if it is spilled.
+	if !e.chunks.spilled {
+		err = e.copyChk(childResult, resultChk)
+		if err != nil {
+			return false, err
+		}
+	}
 	e.accumulated += uint64(numRows)
 	e.data = append(e.data, dataInfo{chk: resultChk, remaining: uint64(numRows), accumulated: e.accumulated})
 
@@ -239,14 +287,48 @@ func (e *PipelinedWindowExec) copyChk(src, dst *chunk.Chunk) error {
 	return nil
 }
 
+// spill spills the buffered child chunks to disk, and releases the references to
+// them from the result chunks and rows.
+func (e *PipelinedWindowExec) spill() (err error) {
+	if err = e.chunks.spill(); err != nil {
+		return err
+	}
+	for i := range e.data {
+		if e.data[i].chk, err = detachChildColumns(e.data[i].chk, e.retFieldTypes, e.numWindowFuncs); err != nil {
+			return err
+		}
+	}
+	e.rows = nil
+	return nil
+}
+
 func (e *PipelinedWindowExec) getRow(i uint64) chunk.Row {
+	if e.chunks.spilled {
+		// e.rows[0] is the row at offset e.dropped among all the rows fetched from the child.
+		return e.chunks.getRow(e.dropped + i - e.rowStart)
+	}
 	return e.rows[i-e.rowStart]
 }
 
 func (e *PipelinedWindowExec) getRows(start, end uint64) []chunk.Row {
+	if e.chunks.spilled {
+		rows := make([]chunk.Row, 0, end-start)
+		for i := start; i < end; i++ {
+			rows = append(rows, e.getRow(i))
+		}
+		return rows
+	}
 	return e.rows[start-e.rowStart : end-e.rowStart]
 }
 
+// dropRows drops the first numDrop rows in e.rows.
+func (e *PipelinedWindowExec) dropRows(numDrop uint64) {
+	e.dropped += numDrop
+	if !e.chunks.spilled {
+		e.rows = e.rows[numDrop:]
+	}
+}
+
 // finish is called upon a whole partition is consumed
 func (e *PipelinedWindowExec) finish() {
 	e.whole = true
@@ -279,6 +361,10 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 		e.stagedStartRow = start
 		return start, nil
 	}
+	if e.groups != nil {
+		e.groups.update(e.getRow, e.rowCnt, e.curRowIdx)
+		return e.groups.getStart(e.start, e.rowCnt), nil
+	}
 	switch e.start.Type {
 	case ast.Preceding:
 		if e.curRowIdx > e.start.Num {
@@ -319,6 +405,10 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 		e.stagedEndRow = end
 		return end, nil
 	}
+	if e.groups != nil {
+		e.groups.update(e.getRow, e.rowCnt, e.curRowIdx)
+		return e.groups.getEnd(e.end, e.rowCnt), nil
+	}
 	switch e.end.Type {
 	case ast.Preceding:
 		if e.curRowIdx >= e.end.Num {
@@ -413,11 +503,16 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		remained--
 	}
 	extend := mathutil.MinUint64Val(e.curRowIdx, e.lastEndRow, e.lastStartRow)
+	if e.groups != nil && e.groups.scanned > 0 {
+		// The last scanned row is kept to decide whether the next row is its peer.
+		extend = mathutil.MinUint64(extend, e.groups.scanned-1)
+	}
 	if extend > e.rowStart {
-		numDrop := extend - e.rowStart
-		e.dropped += numDrop
-		e.rows = e.rows[numDrop:]
+		e.dropRows(extend - e.rowStart)
 		e.rowStart = extend
+		if e.groups != nil {
+			e.groups.shrink(extend)
+		}
 	}
 	return
 }
@@ -449,12 +544,13 @@ func (e *PipelinedWindowExec) reset() {
 	e.emptyFrame = false
 	e.curRowIdx = 0
 	e.whole = false
-	numDrop := e.rowCnt - e.rowStart
-	e.dropped += numDrop
-	e.rows = e.rows[numDrop:]
+	e.dropRows(e.rowCnt - e.rowStart)
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
+	if e.groups != nil {
+		e.groups.reset()
+	}
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
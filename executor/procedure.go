@@ -0,0 +1,938 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+type procedureKeyType int
+
+func (k procedureKeyType) String() string {
+	return "procedure_call_stack"
+}
+
+// procedureCallStackKey is the key of the stored procedures being called in the session.
+const procedureCallStackKey procedureKeyType = 0
+
+func (e *SimpleExec) executeCall(ctx context.Context, s *ast.CallStmt) error {
+	proc := s.Procedure
+	routine, ok := e.is.RoutineByName(proc.Schema, proc.FnName, model.RoutineTypeProcedure)
+	if !ok {
+		return infoschema.ErrRoutineNotExists.GenWithStackByArgs(model.RoutineTypeProcedure.String(), proc.Schema.O+"."+proc.FnName.O)
+	}
+	args := make([]types.Datum, len(proc.Args))
+	for i, arg := range proc.Args {
+		if routine.Params[i].Mode == model.ParamModeOut {
+			continue
+		}
+		var err error
+		if args[i], err = expression.EvalAstExpr(e.ctx, arg); err != nil {
+			return err
+		}
+	}
+	outs, err := callProcedure(ctx, e.ctx, proc.Schema, routine, args)
+	if err != nil {
+		return err
+	}
+	for i, param := range routine.Params {
+		if param.Mode != model.ParamModeIn {
+			setUserVar(e.ctx.GetSessionVars(), proc.Args[i].(*ast.VariableExpr).Name, outs[i], param.Tp)
+		}
+	}
+	return nil
+}
+
+func (e *SimpleExec) executeSignal(s *ast.SignalStmt) error {
+	err := buildSignalError(e.ctx, s, func(expr ast.ExprNode) (types.Datum, error) {
+		return expression.EvalAstExpr(e.ctx, expr)
+	})
+	if signal, ok := err.(*mysql.SQLError); ok && strings.HasPrefix(signal.State, "01") {
+		e.ctx.GetSessionVars().StmtCtx.AppendWarning(signal)
+		return nil
+	}
+	return err
+}
+
+// buildSignalError evaluates the items of the SIGNAL statement and returns the error it raises.
+func buildSignalError(sctx sessionctx.Context, s *ast.SignalStmt, eval func(ast.ExprNode) (types.Datum, error)) error {
+	var code int64
+	var msg string
+	for _, item := range s.Items {
+		d, err := eval(item.Value)
+		if err != nil {
+			return err
+		}
+		if item.Name == ast.SignalMySQLErrno {
+			code, err = d.ToInt64(sctx.GetSessionVars().StmtCtx)
+		} else {
+			msg, err = d.ToString()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return expression.NewSignalError(s.SQLState, uint16(code), msg)
+}
+
+// setUserVar assigns the value to the user variable, a NULL value unsets the variable.
+func setUserVar(vars *variable.SessionVars, name string, value types.Datum, tp *types.FieldType) {
+	name = strings.ToLower(name)
+	vars.UsersLock.Lock()
+	defer vars.UsersLock.Unlock()
+	if value.IsNull() {
+		delete(vars.Users, name)
+		delete(vars.UserVarTypes, name)
+		return
+	}
+	vars.Users[name] = value
+	vars.UserVarTypes[name] = tp
+}
+
+// callProcedure executes the stored procedure with the arguments, and returns the values
+// of the parameters when the procedure finishes.
+func callProcedure(ctx context.Context, sctx sessionctx.Context, db model.CIStr, routine *model.RoutineInfo, args []types.Datum) ([]types.Datum, error) {
+	name := db.L + "." + routine.Name.L
+	stack, _ := sctx.Value(procedureCallStackKey).([]string)
+	for _, calling := range stack {
+		if calling == name {
+			return nil, ErrSpRecursionLimit.GenWithStackByArgs(0, routine.Name.O)
+		}
+	}
+	sctx.SetValue(procedureCallStackKey, append(stack, name))
+	defer sctx.SetValue(procedureCallStackKey, stack)
+
+	stmt, err := plannercore.ParseRoutineDefinition(sctx, routine)
+	if err != nil {
+		return nil, err
+	}
+	// The unqualified names in the procedure body refer to the database of the procedure.
+	vars := sctx.GetSessionVars()
+	currentDB := vars.CurrentDB
+	vars.CurrentDB = db.O
+	defer func() {
+		vars.CurrentDB = currentDB
+	}()
+	if routine.Security == model.SecurityDefiner {
+		defer bindDefinerPrivileges(sctx, routine.Definer)()
+	}
+
+	p := &procedureInterpreter{
+		sctx:   sctx,
+		is:     sctx.GetInfoSchema().(infoschema.InfoSchema),
+		db:     db,
+		name:   db.O + "." + routine.Name.O,
+		scopes: []*procedureScope{newProcedureScope()},
+	}
+	params := make([]*procedureVar, 0, len(routine.Params))
+	for i, param := range routine.Params {
+		v := &procedureVar{tp: param.Tp}
+		if param.Mode != model.ParamModeOut {
+			if err := p.setVar(v, args[i]); err != nil {
+				return nil, err
+			}
+		}
+		p.scopes[0].vars[param.Name.L] = v
+		params = append(params, v)
+	}
+	_, err = p.exec(ctx, stmt.Body)
+	if err != nil {
+		return nil, err
+	}
+	outs := make([]types.Datum, 0, len(params))
+	for _, v := range params {
+		outs = append(outs, v.value)
+	}
+	return outs, nil
+}
+
+// bindDefinerPrivileges makes the statements check the privileges of the definer instead of
+// the current user, and returns the function restoring the current user.
+func bindDefinerPrivileges(sctx sessionctx.Context, definer *auth.UserIdentity) func() {
+	vars := sctx.GetSessionVars()
+	pm := privilege.GetPrivilegeManager(sctx)
+	if definer == nil || vars.User == nil || pm == nil {
+		return func() {}
+	}
+	user := vars.User
+	vars.User = definer
+	privilege.BindPrivilegeManager(sctx, &definerPrivileges{Manager: pm, definer: definer})
+	return func() {
+		vars.User = user
+		privilege.BindPrivilegeManager(sctx, pm)
+	}
+}
+
+// definerPrivileges checks the privileges of the definer of a SQL SECURITY DEFINER routine or a trigger.
+type definerPrivileges struct {
+	privilege.Manager
+
+	definer *auth.UserIdentity
+}
+
+// RequestVerification implements the privilege.Manager interface.
+func (p *definerPrivileges) RequestVerification(_ []*auth.RoleIdentity, db, table, column string, priv mysql.PrivilegeType) bool {
+	return p.Manager.RequestVerificationWithUser(db, table, column, priv, p.definer)
+}
+
+// RequestDynamicVerification implements the privilege.Manager interface.
+func (p *definerPrivileges) RequestDynamicVerification(_ []*auth.RoleIdentity, privName string, grantable bool) bool {
+	return p.Manager.RequestDynamicVerificationWithUser(privName, grantable, p.definer)
+}
+
+// procedureVar is a local variable or a parameter of a stored procedure.
+type procedureVar struct {
+	tp    *types.FieldType
+	value types.Datum
+}
+
+// procedureCursor is a cursor declared in a stored procedure, the rows of the
+// query are fetched when the cursor is opened.
+type procedureCursor struct {
+	query  ast.StmtNode
+	open   bool
+	rows   []chunk.Row
+	fields []*types.FieldType
+	pos    int
+}
+
+// procedureScope holds the variables, cursors and handlers declared in a BEGIN ... END block.
+type procedureScope struct {
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureHandlerDecl
+	// suspended is positive when a handler of the scope or of its inner scopes is running,
+	// the conditions raised by the handler are not handled by the handlers of the scope.
+	suspended int
+}
+
+func newProcedureScope() *procedureScope {
+	return &procedureScope{
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+// procedureFlow is a jump out of the sequential execution of the statements.
+type procedureFlow struct {
+	// label is the target label of LEAVE and ITERATE in lower case.
+	label   string
+	iterate bool
+	// exit is the scope left by an EXIT handler.
+	exit *procedureScope
+}
+
+// procedureInterpreter executes the body of a stored procedure or a trigger.
+type procedureInterpreter struct {
+	sctx   sessionctx.Context
+	is     infoschema.InfoSchema
+	db     model.CIStr
+	name   string
+	scopes []*procedureScope
+	// trigger is the row which the trigger is activated for, it's nil if a stored procedure is executed.
+	trigger *triggerRow
+	// warnings are the warnings raised by the statement being executed.
+	warnings []stmtctx.SQLWarn
+}
+
+func (p *procedureInterpreter) lookupVar(name string) *procedureVar {
+	name = strings.ToLower(name)
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if v, ok := p.scopes[i].vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (p *procedureInterpreter) lookupCursor(name string) (*procedureCursor, error) {
+	name = strings.ToLower(name)
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if c, ok := p.scopes[i].cursors[name]; ok {
+			return c, nil
+		}
+	}
+	return nil, errors.Errorf("undefined cursor %s", name)
+}
+
+func (p *procedureInterpreter) setVar(v *procedureVar, d types.Datum) error {
+	sc := p.sctx.GetSessionVars().StmtCtx
+	value, err := d.ConvertTo(sc, v.tp)
+	if err = sc.HandleTruncate(err); err != nil {
+		return err
+	}
+	v.value = value
+	return nil
+}
+
+func (p *procedureInterpreter) exec(ctx context.Context, stmt ast.StmtNode) (*procedureFlow, error) {
+	if atomic.LoadUint32(&p.sctx.GetSessionVars().Killed) == 1 {
+		return nil, ErrQueryInterrupted
+	}
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return p.execBlock(ctx, x)
+	case *ast.ProcedureIfStmt:
+		for _, block := range x.Blocks {
+			ok, err := p.evalCond(ctx, block.Cond)
+			if err != nil {
+				return p.handle(ctx, err)
+			}
+			if ok {
+				return p.execStmts(ctx, block.Stmts)
+			}
+		}
+		return p.execStmts(ctx, x.Else)
+	case *ast.ProcedureLoopStmt:
+		return p.execLoop(ctx, x)
+	case *ast.ProcedureJumpStmt:
+		return &procedureFlow{label: strings.ToLower(x.Label), iterate: x.Tp == ast.JumpTypeIterate}, nil
+	}
+	p.warnings = p.warnings[:0]
+	if err := p.execSimple(ctx, stmt); err != nil {
+		p.flushWarnings()
+		return p.handle(ctx, err)
+	}
+	return p.handleWarnings(ctx)
+}
+
+func (p *procedureInterpreter) execStmts(ctx context.Context, stmts []ast.StmtNode) (*procedureFlow, error) {
+	for _, stmt := range stmts {
+		flow, err := p.exec(ctx, stmt)
+		if err != nil || flow != nil {
+			return flow, err
+		}
+	}
+	return nil, nil
+}
+
+func (p *procedureInterpreter) execBlock(ctx context.Context, block *ast.ProcedureBlock) (*procedureFlow, error) {
+	scope := newProcedureScope()
+	p.scopes = append(p.scopes, scope)
+	defer func() {
+		p.scopes = p.scopes[:len(p.scopes)-1]
+	}()
+	flow, err := p.execDecls(ctx, scope, block.Decls)
+	if err == nil && flow == nil {
+		flow, err = p.execStmts(ctx, block.Stmts)
+	}
+	if flow != nil && (flow.exit == scope || (flow.exit == nil && !flow.iterate && flow.label != "" && flow.label == strings.ToLower(block.Label))) {
+		flow = nil
+	}
+	return flow, err
+}
+
+func (p *procedureInterpreter) execDecls(ctx context.Context, scope *procedureScope, decls []ast.StmtNode) (*procedureFlow, error) {
+	for _, decl := range decls {
+		switch d := decl.(type) {
+		case *ast.ProcedureDecl:
+			tp, err := plannercore.RoutineFieldType(p.is, p.db, d.Tp)
+			if err != nil {
+				return nil, err
+			}
+			// The default value can't refer to the variables declared by itself.
+			var value types.Datum
+			if d.Default != nil {
+				if value, err = p.evalExpr(ctx, d.Default); err != nil {
+					if flow, err := p.handle(ctx, err); err != nil || flow != nil {
+						return flow, err
+					}
+				}
+			}
+			for _, name := range d.Names {
+				v := &procedureVar{tp: tp}
+				if err := p.setVar(v, value); err != nil {
+					return nil, err
+				}
+				scope.vars[strings.ToLower(name)] = v
+			}
+		case *ast.ProcedureCursorDecl:
+			scope.cursors[strings.ToLower(d.Name)] = &procedureCursor{query: d.Query}
+		case *ast.ProcedureHandlerDecl:
+			scope.handlers = append(scope.handlers, d)
+		}
+	}
+	return nil, nil
+}
+
+func (p *procedureInterpreter) execLoop(ctx context.Context, loop *ast.ProcedureLoopStmt) (*procedureFlow, error) {
+	label := strings.ToLower(loop.Label)
+	for {
+		if loop.Tp == ast.LoopTypeWhile {
+			ok, err := p.evalCond(ctx, loop.Cond)
+			if err != nil {
+				return p.handle(ctx, err)
+			}
+			if !ok {
+				return nil, nil
+			}
+		}
+		flow, err := p.execStmts(ctx, loop.Stmts)
+		if err != nil {
+			return nil, err
+		}
+		if flow != nil {
+			if flow.exit != nil || label == "" || flow.label != label {
+				return flow, nil
+			}
+			if !flow.iterate {
+				return nil, nil
+			}
+		}
+		if loop.Tp == ast.LoopTypeRepeat {
+			ok, err := p.evalCond(ctx, loop.Cond)
+			if err != nil {
+				return p.handle(ctx, err)
+			}
+			if ok {
+				return nil, nil
+			}
+		}
+		if atomic.LoadUint32(&p.sctx.GetSessionVars().Killed) == 1 {
+			return nil, ErrQueryInterrupted
+		}
+	}
+}
+
+// execSimple executes a statement which isn't a compound statement.
+func (p *procedureInterpreter) execSimple(ctx context.Context, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.SetStmt:
+		return p.execSet(ctx, x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return p.execSelectInto(ctx, x)
+		}
+	case *ast.ProcedureOpenCursorStmt:
+		return p.execOpenCursor(ctx, x)
+	case *ast.ProcedureFetchCursorStmt:
+		return p.execFetchCursor(x)
+	case *ast.ProcedureCloseCursorStmt:
+		c, err := p.lookupCursor(x.Name)
+		if err != nil {
+			return err
+		}
+		if !c.open {
+			return ErrSpCursorNotOpen
+		}
+		c.open, c.rows, c.fields = false, nil, nil
+		return nil
+	case *ast.SignalStmt:
+		err := buildSignalError(p.sctx, x, func(expr ast.ExprNode) (types.Datum, error) {
+			return p.evalExpr(ctx, expr)
+		})
+		if signal, ok := err.(*mysql.SQLError); ok && strings.HasPrefix(signal.State, "01") {
+			p.warnings = append(p.warnings, stmtctx.SQLWarn{Level: stmtctx.WarnLevelWarning, Err: signal})
+			return nil
+		}
+		return err
+	case *ast.CallStmt:
+		return p.execCall(ctx, x)
+	}
+	_, fields, err := p.runStmt(ctx, stmt)
+	if err == nil && len(fields) > 0 {
+		err = ErrSpBadSelect.GenWithStackByArgs(p.name)
+	}
+	return err
+}
+
+func (p *procedureInterpreter) execSet(ctx context.Context, s *ast.SetStmt) error {
+	for _, assign := range s.Variables {
+		if sep := strings.IndexByte(assign.Name, '.'); p.trigger != nil && sep > 0 && assign.IsSystem && !assign.IsGlobal &&
+			strings.EqualFold(assign.Name[:sep], "new") {
+			value, err := p.evalExpr(ctx, assign.Value)
+			if err != nil {
+				return err
+			}
+			if err := p.trigger.setColumn(p.sctx, assign.Name[sep+1:], value); err != nil {
+				return err
+			}
+			continue
+		}
+		if v := p.lookupVar(assign.Name); v != nil && assign.IsSystem && !assign.IsGlobal {
+			value, err := p.evalExpr(ctx, assign.Value)
+			if err != nil {
+				return err
+			}
+			if err := p.setVar(v, value); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, _, err := p.runStmt(ctx, &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *procedureInterpreter) execSelectInto(ctx context.Context, sel *ast.SelectStmt) error {
+	into := sel.SelectIntoOpt
+	sel.SelectIntoOpt = nil
+	defer func() {
+		sel.SelectIntoOpt = into
+	}()
+	rows, fields, err := p.runStmt(ctx, sel)
+	if err != nil {
+		return err
+	}
+	if len(fields) != len(into.Variables) {
+		return plannercore.ErrWrongNumberOfColumnsInSelect
+	}
+	if len(rows) > 1 {
+		return ErrTooManyRows
+	}
+	if len(rows) == 0 {
+		p.warnings = append(p.warnings, stmtctx.SQLWarn{Level: stmtctx.WarnLevelWarning, Err: ErrSpFetchNoData})
+		return nil
+	}
+	for i, target := range into.Variables {
+		if err := p.assign(target, rows[0].GetDatum(i, fields[i]), fields[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// assign assigns the value to a local variable or a user variable.
+func (p *procedureInterpreter) assign(target ast.ExprNode, value types.Datum, tp *types.FieldType) error {
+	switch x := target.(type) {
+	case *ast.ColumnNameExpr:
+		v := p.lookupVar(x.Name.Name.O)
+		if v == nil {
+			return plannercore.ErrSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+		}
+		return p.setVar(v, value)
+	case *ast.VariableExpr:
+		setUserVar(p.sctx.GetSessionVars(), x.Name, value, tp)
+	}
+	return nil
+}
+
+func (p *procedureInterpreter) execOpenCursor(ctx context.Context, s *ast.ProcedureOpenCursorStmt) error {
+	c, err := p.lookupCursor(s.Name)
+	if err != nil {
+		return err
+	}
+	if c.open {
+		return ErrSpCursorAlreadyOpen
+	}
+	rows, fields, err := p.runStmt(ctx, c.query)
+	if err != nil {
+		return err
+	}
+	c.open, c.rows, c.fields, c.pos = true, rows, fields, 0
+	return nil
+}
+
+func (p *procedureInterpreter) execFetchCursor(s *ast.ProcedureFetchCursorStmt) error {
+	c, err := p.lookupCursor(s.Name)
+	if err != nil {
+		return err
+	}
+	if !c.open {
+		return ErrSpCursorNotOpen
+	}
+	if len(s.Vars) != len(c.fields) {
+		return ErrSpWrongNoOfFetchArgs
+	}
+	if c.pos >= len(c.rows) {
+		return ErrSpFetchNoData
+	}
+	row := c.rows[c.pos]
+	c.pos++
+	for i, name := range s.Vars {
+		v := p.lookupVar(name)
+		if v == nil {
+			return plannercore.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+		}
+		if err := p.setVar(v, row.GetDatum(i, c.fields[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *procedureInterpreter) execCall(ctx context.Context, s *ast.CallStmt) error {
+	proc := s.Procedure
+	db := proc.Schema
+	if db.L == "" {
+		db = model.NewCIStr(p.sctx.GetSessionVars().CurrentDB)
+	}
+	fullName := db.O + "." + proc.FnName.O
+	is := p.sctx.GetInfoSchema().(infoschema.InfoSchema)
+	routine, ok := is.RoutineByName(db, proc.FnName, model.RoutineTypeProcedure)
+	if !ok {
+		return infoschema.ErrRoutineNotExists.GenWithStackByArgs(model.RoutineTypeProcedure.String(), fullName)
+	}
+	if len(routine.Params) != len(proc.Args) {
+		return plannercore.ErrSpWrongNoOfArgs.GenWithStackByArgs(model.RoutineTypeProcedure.String(), fullName, len(routine.Params), len(proc.Args))
+	}
+	vars := p.sctx.GetSessionVars()
+	if pm := privilege.GetPrivilegeManager(p.sctx); pm != nil && !pm.RequestVerification(vars.ActiveRoles, db.L, "", "", mysql.ExecutePriv) {
+		return plannercore.ErrProcaccessDenied.GenWithStackByArgs("execute", vars.User.AuthUsername, vars.User.AuthHostname, fullName)
+	}
+	args := make([]types.Datum, len(proc.Args))
+	for i, param := range routine.Params {
+		if param.Mode != model.ParamModeIn {
+			switch x := proc.Args[i].(type) {
+			case *ast.ColumnNameExpr:
+				if x.Name.Table.L == "" && p.lookupVar(x.Name.Name.L) != nil {
+					break
+				}
+				return plannercore.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			case *ast.VariableExpr:
+				if !x.IsSystem {
+					break
+				}
+				return plannercore.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			default:
+				return plannercore.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+		}
+		if param.Mode == model.ParamModeOut {
+			continue
+		}
+		var err error
+		if args[i], err = p.evalExpr(ctx, proc.Args[i]); err != nil {
+			return err
+		}
+	}
+	outs, err := callProcedure(ctx, p.sctx, db, routine, args)
+	if err != nil {
+		return err
+	}
+	for i, param := range routine.Params {
+		if param.Mode == model.ParamModeIn {
+			continue
+		}
+		if err := p.assign(proc.Args[i], outs[i], param.Tp); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evalExpr evaluates the expression by the statement `SELECT expr`.
+func (p *procedureInterpreter) evalExpr(ctx context.Context, expr ast.ExprNode) (types.Datum, error) {
+	sel := &ast.SelectStmt{
+		SelectStmtOpts: &ast.SelectStmtOpts{SQLCache: true},
+		Fields:         &ast.FieldList{Fields: []*ast.SelectField{{Expr: expr}}},
+		Kind:           ast.SelectStmtKindSelect,
+	}
+	rows, fields, err := p.runStmt(ctx, sel)
+	if err != nil || len(rows) == 0 {
+		return types.Datum{}, err
+	}
+	return rows[0].GetDatum(0, fields[0]), nil
+}
+
+func (p *procedureInterpreter) evalCond(ctx context.Context, expr ast.ExprNode) (bool, error) {
+	d, err := p.evalExpr(ctx, expr)
+	if err != nil || d.IsNull() {
+		return false, err
+	}
+	b, err := d.ToBool(p.sctx.GetSessionVars().StmtCtx)
+	return b != 0, err
+}
+
+// runStmt executes the SQL statement with the local variables replaced by their values,
+// and returns the rows of the result set if the statement has one.
+func (p *procedureInterpreter) runStmt(ctx context.Context, stmt ast.StmtNode) (rows []chunk.Row, fields []*types.FieldType, err error) {
+	triggered := inTrigger(p.sctx)
+	if triggered {
+		switch stmt.(type) {
+		case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt:
+			return nil, nil, ddl.ErrCommitNotAllowedInSfOrTrg
+		}
+	}
+	substitutor := &localVarSubstitutor{p: p, replaced: make(map[ast.Node]ast.Node)}
+	stmt.Accept(substitutor)
+	defer stmt.Accept(&localVarRestorer{replaced: substitutor.replaced})
+	if substitutor.err != nil {
+		return nil, nil, substitutor.err
+	}
+
+	vars := p.sctx.GetSessionVars()
+	outer := vars.StmtCtx
+	// The nested statement must not reuse the cached statement context which is used by the CALL statement.
+	if err = resetContextOfStmt(p.sctx, stmt, &stmtctx.StatementContext{}); err != nil {
+		return nil, nil, err
+	}
+	sc := vars.StmtCtx
+	sc.InTrigger = triggered
+	defer func() {
+		sc.MemTracker.DetachFromGlobalTracker()
+		sc.DiskTracker.DetachFromGlobalTracker()
+		p.warnings = append(p.warnings, sc.GetWarnings()...)
+		// The rows changed by the triggers aren't counted in the triggering statement.
+		if (sc.InInsertStmt || sc.InUpdateStmt || sc.InDeleteStmt) && !triggered {
+			outer.AddAffectedRows(sc.AffectedRows())
+			if sc.LastInsertID > 0 {
+				outer.LastInsertID = sc.LastInsertID
+			}
+		}
+		vars.StmtCtx = outer
+	}()
+
+	if _, ok := stmt.(ast.DMLNode); ok && !ast.IsReadOnly(stmt) && triggered {
+		// The statement of a trigger is a part of the triggering statement, its changes are
+		// kept in a nested staging buffer, which is rolled back if the statement fails.
+		txn, txnErr := p.sctx.Txn(true)
+		if txnErr != nil {
+			return nil, nil, txnErr
+		}
+		buf := txn.GetMemBuffer()
+		h := buf.Staging()
+		defer func() {
+			if err != nil {
+				buf.Cleanup(h)
+			} else {
+				buf.Release(h)
+			}
+		}()
+	} else if ok && !ast.IsReadOnly(stmt) {
+		// Every statement in the procedure is a separate statement, so the changes of a failed
+		// statement are rolled back and the changes of the others are visible to the following
+		// ones. The transaction statements and DDL statements commit the transaction by themselves.
+		defer func() {
+			if err != nil {
+				p.sctx.StmtRollback()
+			} else {
+				p.sctx.StmtCommit()
+			}
+		}()
+	}
+
+	compiler := Compiler{Ctx: p.sctx}
+	execStmt, err := compiler.Compile(ctx, stmt)
+	if err != nil {
+		return nil, nil, err
+	}
+	rs, err := execStmt.Exec(ctx)
+	if err != nil || rs == nil {
+		return nil, nil, err
+	}
+	rows, err = sqlexec.DrainRecordSet(ctx, rs, vars.MaxChunkSize)
+	terror.Call(rs.Close)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, f := range rs.Fields() {
+		fields = append(fields, &f.Column.FieldType)
+	}
+	return rows, fields, nil
+}
+
+// handle runs the handler for the error raised by a statement. The error is returned
+// if no handler is declared for it.
+func (p *procedureInterpreter) handle(ctx context.Context, err error) (*procedureFlow, error) {
+	code, state := procedureCondition(err)
+	if code == mysql.ErrQueryInterrupted {
+		return nil, err
+	}
+	for i := len(p.scopes) - 1; i >= 0; i-- {
+		if p.scopes[i].suspended > 0 {
+			continue
+		}
+		if h := p.scopes[i].findHandler(code, state); h != nil {
+			return p.runHandler(ctx, i, h)
+		}
+	}
+	return nil, err
+}
+
+// handleWarnings runs the handler for the warnings raised by a statement, the handled
+// warning is removed and the others are appended to the CALL statement.
+func (p *procedureInterpreter) handleWarnings(ctx context.Context) (*procedureFlow, error) {
+	for i := len(p.warnings) - 1; i >= 0; i-- {
+		if p.warnings[i].Level != stmtctx.WarnLevelWarning {
+			continue
+		}
+		code, state := procedureCondition(p.warnings[i].Err)
+		for j := len(p.scopes) - 1; j >= 0; j-- {
+			if p.scopes[j].suspended > 0 {
+				continue
+			}
+			if h := p.scopes[j].findHandler(code, state); h != nil {
+				p.warnings = append(p.warnings[:i], p.warnings[i+1:]...)
+				p.flushWarnings()
+				return p.runHandler(ctx, j, h)
+			}
+		}
+	}
+	p.flushWarnings()
+	return nil, nil
+}
+
+func (p *procedureInterpreter) flushWarnings() {
+	p.sctx.GetSessionVars().StmtCtx.AppendWarnings(p.warnings)
+	p.warnings = p.warnings[:0]
+}
+
+func (p *procedureInterpreter) runHandler(ctx context.Context, scopeIdx int, h *ast.ProcedureHandlerDecl) (*procedureFlow, error) {
+	for _, scope := range p.scopes[scopeIdx:] {
+		scope.suspended++
+	}
+	_, err := p.exec(ctx, h.Stmt)
+	for _, scope := range p.scopes[scopeIdx:] {
+		scope.suspended--
+	}
+	if err != nil {
+		return nil, err
+	}
+	if h.Action == ast.HandlerActionExit {
+		return &procedureFlow{exit: p.scopes[scopeIdx]}, nil
+	}
+	return nil, nil
+}
+
+// findHandler returns the most specific handler of the scope for the condition.
+func (s *procedureScope) findHandler(code uint16, state string) *ast.ProcedureHandlerDecl {
+	var found *ast.ProcedureHandlerDecl
+	var rank int
+	for _, h := range s.handlers {
+		for _, cond := range h.Conditions {
+			r := 0
+			switch cond.Tp {
+			case ast.HandlerConditionErrorCode:
+				if uint16(cond.ErrorCode) == code {
+					r = 3
+				}
+			case ast.HandlerConditionSQLState:
+				if cond.SQLState == state {
+					r = 2
+				}
+			case ast.HandlerConditionSQLWarning:
+				if strings.HasPrefix(state, "01") {
+					r = 1
+				}
+			case ast.HandlerConditionNotFound:
+				if strings.HasPrefix(state, "02") {
+					r = 1
+				}
+			case ast.HandlerConditionSQLException:
+				if !strings.HasPrefix(state, "00") && !strings.HasPrefix(state, "01") && !strings.HasPrefix(state, "02") {
+					r = 1
+				}
+			}
+			if r > rank {
+				found, rank = h, r
+			}
+		}
+	}
+	return found
+}
+
+// procedureCondition returns the error code and the SQLSTATE of the condition.
+func procedureCondition(err error) (uint16, string) {
+	switch x := errors.Cause(err).(type) {
+	case *mysql.SQLError:
+		return x.Code, x.State
+	case *terror.Error:
+		sqlErr := terror.ToSQLError(x)
+		return sqlErr.Code, sqlErr.State
+	}
+	return mysql.ErrUnknown, mysql.DefaultMySQLState
+}
+
+// localVarSubstitutor replaces the references of the local variables and the columns of
+// the NEW and OLD rows of the trigger in a statement by their values.
+type localVarSubstitutor struct {
+	p        *procedureInterpreter
+	replaced map[ast.Node]ast.Node
+	err      error
+}
+
+func (s *localVarSubstitutor) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.ValuesExpr, *ast.SelectIntoOption:
+		// The column of VALUES() refers to the inserted row.
+		return in, true
+	}
+	return in, false
+}
+
+func (s *localVarSubstitutor) Leave(in ast.Node) (ast.Node, bool) {
+	col, ok := in.(*ast.ColumnNameExpr)
+	if !ok || col.Name.Schema.L != "" {
+		return in, true
+	}
+	if col.Name.Table.L != "" {
+		return s.substituteTriggerRow(col), true
+	}
+	v := s.p.lookupVar(col.Name.Name.L)
+	if v == nil {
+		return in, true
+	}
+	value := ast.NewValueExpr(v.value.GetValue(), v.tp.Charset, v.tp.Collate)
+	value.SetType(v.tp.Clone())
+	s.replaced[value] = col
+	return value, true
+}
+
+func (s *localVarSubstitutor) substituteTriggerRow(col *ast.ColumnNameExpr) ast.ExprNode {
+	row := col.Name.Table.L
+	if s.p.trigger == nil || (row != "new" && row != "old") {
+		return col
+	}
+	c, d, ok := s.p.trigger.column(row, col.Name.Name.O)
+	if !ok {
+		if s.err == nil {
+			s.err = plannercore.ErrUnknownColumn.GenWithStackByArgs(col.Name.Name.O, strings.ToUpper(row))
+		}
+		return col
+	}
+	value := ast.NewValueExpr(d.GetValue(), c.Charset, c.Collate)
+	value.SetType(c.FieldType.Clone())
+	s.replaced[value] = col
+	return value
+}
+
+// localVarRestorer restores the references of the local variables replaced by localVarSubstitutor.
+type localVarRestorer struct {
+	replaced map[ast.Node]ast.Node
+}
+
+func (r *localVarRestorer) Enter(in ast.Node) (ast.Node, bool) {
+	return in, false
+}
+
+func (r *localVarRestorer) Leave(in ast.Node) (ast.Node, bool) {
+	if col, ok := r.replaced[in]; ok {
+		return col, true
+	}
+	return in, true
+}
@@ -0,0 +1,279 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStoredFunction(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create function add_one(a int) returns int deterministic return a + 1")
+	tk.MustExec(`create function fact(n int) returns bigint deterministic
+		begin
+			declare r bigint default 1;
+			declare i int default 2;
+			while i <= n do
+				set r = r * i;
+				set i = i + 1;
+			end while;
+			return r;
+		end`)
+	tk.MustExec(`create function grade(score int) returns varchar(10) deterministic
+		begin
+			if score is null then
+				return null;
+			elseif score >= 90 then
+				return 'A';
+			elseif score >= 60 then
+				return 'B';
+			end if;
+			return 'C';
+		end`)
+	tk.MustQuery("select add_one(1), test.add_one(add_one(1)), fact(5), fact(0), grade(95), grade(70), grade(10), grade(null)").
+		Check(testkit.Rows("2 3 120 1 A B C <nil>"))
+
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("insert into t values (1), (2), (3)")
+	tk.MustQuery("select a, fact(a) from t where add_one(a) > 2 order by a").Check(testkit.Rows("2 2", "3 6"))
+
+	// The arguments and the result are converted to the declared types.
+	tk.MustExec("create function half(a decimal(10, 2)) returns decimal(10, 1) deterministic return a / 2")
+	tk.MustQuery("select half(3), half('5.55')").Check(testkit.Rows("1.5 2.8"))
+
+	// LEAVE and ITERATE with labels.
+	tk.MustExec(`create function odd_sum(n int) returns int deterministic
+		begin
+			declare i, s int default 0;
+			l: loop
+				set i = i + 1;
+				if i > n then
+					leave l;
+				end if;
+				if i % 2 = 0 then
+					iterate l;
+				end if;
+				set s = s + i;
+			end loop l;
+			return s;
+		end`)
+	tk.MustQuery("select odd_sum(10)").Check(testkit.Rows("25"))
+
+	tk.MustGetErrCode("select add_one(1, 2)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("select no_such_func(1)", errno.ErrSpDoesNotExist)
+	tk.MustExec("create function no_ret(a int) returns int deterministic begin if a > 0 then return a; end if; end")
+	tk.MustQuery("select no_ret(1)").Check(testkit.Rows("1"))
+	require.EqualError(t, tk.QueryToErr("select no_ret(0)"), "[expression:1321]FUNCTION test.no_ret ended without RETURN")
+	tk.MustExec("create function sig(a int) returns int deterministic begin if a < 0 then signal sqlstate '45000' set message_text = 'negative'; end if; return a; end")
+	require.EqualError(t, tk.QueryToErr("select sig(-1)"), "ERROR 1644 (45000): negative")
+
+	// The restrictions of the stored functions.
+	tk.MustGetErrCode("create function add_one(a int) returns int deterministic return a", errno.ErrSpAlreadyExists)
+	tk.MustExec("create function if not exists add_one(a int) returns int deterministic return a")
+	tk.MustGetErrCode("create function f(a int) returns int return a", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create function f(a int, a int) returns int deterministic return a", errno.ErrSpDupParam)
+	tk.MustGetErrCode("create function f() returns int deterministic begin declare x int; end", errno.ErrSpNoreturn)
+	tk.MustGetErrCode("create function f() returns int deterministic begin insert into t values (1); return 1; end", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create function f() returns int deterministic begin l: loop leave m; end loop; return 1; end", errno.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create table t1 (a int, b int as (add_one(a)))", errno.ErrGeneratedColumnFunctionIsNotAllowed)
+
+	tk.MustExec("drop function add_one")
+	tk.MustGetErrCode("select add_one(1)", errno.ErrSpDoesNotExist)
+	tk.MustGetErrCode("drop function add_one", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop function if exists add_one")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 FUNCTION test.add_one does not exist"))
+}
+
+func TestStoredProcedure(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, v varchar(10))")
+	tk.MustExec(`create procedure fill(in n int, out cnt int)
+		begin
+			declare i int default 1;
+			while i <= n do
+				insert into t values (i, concat('v', i));
+				set i = i + 1;
+			end while;
+			select count(*) into cnt from t;
+		end`)
+	tk.MustExec("call fill(3, @cnt)")
+	tk.MustQuery("select @cnt").Check(testkit.Rows("3"))
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 v1", "2 v2", "3 v3"))
+
+	// INOUT parameters and the user variables.
+	tk.MustExec("create procedure twice(inout x int) set x = x * 2")
+	tk.MustExec("set @x = 21")
+	tk.MustExec("call twice(@x)")
+	tk.MustQuery("select @x").Check(testkit.Rows("42"))
+	tk.MustGetErrCode("call twice(1)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call twice()", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call no_such_proc()", errno.ErrSpDoesNotExist)
+
+	// Cursors and the NOT FOUND handler.
+	tk.MustExec(`create procedure concat_all(out res varchar(100))
+		begin
+			declare done int default 0;
+			declare s varchar(10);
+			declare c cursor for select v from t order by id desc;
+			declare continue handler for not found set done = 1;
+			set res = '';
+			open c;
+			read_loop: loop
+				fetch c into s;
+				if done then
+					leave read_loop;
+				end if;
+				set res = concat(res, s);
+			end loop;
+			close c;
+		end`)
+	tk.MustExec("call concat_all(@res)")
+	tk.MustQuery("select @res").Check(testkit.Rows("v3v2v1"))
+
+	// The EXIT handler leaves the block declaring it, and the failed statement is rolled back.
+	// The duplicate keys are checked when the statement is executed rather than committed.
+	tk.MustExec("set @@tidb_constraint_check_in_place = 1")
+	tk.MustExec(`create procedure add_row(id int, out status varchar(20))
+		begin
+			declare exit handler for 1062 set status = 'duplicate';
+			set status = 'ok';
+			insert into t values (id, 'new');
+		end`)
+	tk.MustExec("call add_row(4, @s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("ok"))
+	tk.MustExec("call add_row(4, @s)")
+	tk.MustQuery("select @s").Check(testkit.Rows("duplicate"))
+	tk.MustExec(`create procedure continue_on_error(out n int)
+		begin
+			declare continue handler for sqlexception set n = -1;
+			insert into t values (5, 'a'), (1, 'b');
+			set n = (select count(*) from t);
+		end`)
+	tk.MustExec("call continue_on_error(@n)")
+	tk.MustQuery("select @n").Check(testkit.Rows("4"))
+	tk.MustQuery("select count(*) from t where id = 5").Check(testkit.Rows("0"))
+
+	// SIGNAL raises the error with its SQLSTATE and message.
+	tk.MustExec(`create procedure check_pos(a int)
+		begin
+			if a <= 0 then
+				signal sqlstate '45000' set message_text = 'must be positive', mysql_errno = 1700;
+			end if;
+		end`)
+	tk.MustExec("call check_pos(1)")
+	tk.MustGetErrMsg("call check_pos(0)", "ERROR 1700 (45000): must be positive")
+	tk.MustExec("signal sqlstate '01000' set message_text = 'just a warning'")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1642 just a warning"))
+
+	// The nested calls and the recursion limit.
+	tk.MustExec(`create procedure outer_proc(out r int)
+		begin
+			declare x int default 5;
+			call twice(x);
+			set r = x;
+		end`)
+	tk.MustExec("call outer_proc(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("10"))
+	tk.MustExec("create procedure rec() call rec()")
+	tk.MustGetErrCode("call rec()", errno.ErrSpRecursionLimit)
+
+	// The procedures can't return result sets.
+	tk.MustExec("create procedure sel() select 1")
+	tk.MustGetErrCode("call sel()", errno.ErrSpBadselect)
+
+	tk.MustGetErrCode("create procedure bad() begin declare c cursor for select 1; open d; end", errno.ErrSpCursorMismatch)
+	tk.MustGetErrCode("create procedure bad() begin declare x int; declare x int; end", errno.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure bad() return 1", errno.ErrSpBadreturn)
+	tk.MustExec("drop procedure fill")
+	tk.MustGetErrCode("call fill(1, @cnt)", errno.ErrSpDoesNotExist)
+}
+
+func TestSelectIntoVariables(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'x'), (2, 'y')")
+	tk.MustExec("select a, b into @a, @b from t where a = 2")
+	tk.MustQuery("select @a, @b").Check(testkit.Rows("2 y"))
+	tk.MustExec("select a from t where a = 3 into @a")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1329 No data - zero rows fetched, selected, or processed"))
+	tk.MustQuery("select @a").Check(testkit.Rows("2"))
+	tk.MustGetErrCode("select a into @a from t", errno.ErrTooManyRows)
+	tk.MustGetErrCode("select a, b into @a from t", errno.ErrWrongNumberOfColumnsInSelect)
+	tk.MustGetErrCode("select a into x from t", errno.ErrSpUndeclaredVar)
+}
+
+func TestRoutinePrivileges(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create user 'u1'@'%', 'u2'@'%'")
+	tk.MustExec("grant create routine on test.* to 'u1'@'%'")
+	tk.MustExec("grant insert on test.* to 'u1'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	require.True(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil))
+	tk1.MustExec("create procedure ins(a int) insert into t values (a)")
+	tk1.MustExec("create procedure ins_invoker(a int) sql security invoker insert into t values (a)")
+	tk1.MustGetErrCode("call ins(1)", errno.ErrProcaccessDenied)
+
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	require.True(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil))
+	tk2.MustGetErrCode("create procedure p() select 1", errno.ErrDBaccessDenied)
+	tk2.MustGetErrCode("call ins(1)", errno.ErrProcaccessDenied)
+	tk2.MustGetErrCode("drop procedure ins", errno.ErrProcaccessDenied)
+	tk2.MustQuery("select count(*) from information_schema.routines where routine_schema = 'test'").Check(testkit.Rows("0"))
+
+	// The DEFINER routines run with the privileges of the definer.
+	tk.MustExec("grant execute on test.* to 'u2'@'%'")
+	tk2.MustExec("call ins(1)")
+	tk2.MustGetErrCode("call ins_invoker(2)", errno.ErrTableaccessDenied)
+	tk.MustQuery("select a from t").Check(testkit.Rows("1"))
+
+	tk.MustQuery("select routine_schema, routine_name, routine_type, security_type, definer from information_schema.routines where routine_schema = 'test' order by routine_name").
+		Check(testkit.Rows("test ins PROCEDURE DEFINER u1@%", "test ins_invoker PROCEDURE INVOKER u1@%"))
+	tk.MustExec("create function f(a varchar(5)) returns varchar(20) deterministic comment 'hi' return concat(a, a)")
+	tk.MustQuery("select data_type, character_maximum_length, dtd_identifier, routine_definition, is_deterministic, sql_data_access, routine_comment from information_schema.routines where routine_name = 'f'").
+		Check(testkit.Rows("varchar 20 varchar(20) return concat(a, a) YES CONTAINS SQL hi"))
+}
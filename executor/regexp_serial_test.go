@@ -0,0 +1,60 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegexpFunctions(t *testing.T) {
+	collate.SetNewCollationEnabledForTest(true)
+	defer collate.SetNewCollationEnabledForTest(false)
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a varchar(20) collate utf8mb4_general_ci, b varchar(20) collate utf8mb4_bin, c varbinary(20))")
+	tk.MustExec("insert into t values (1, 'Hello World', 'Hello World', 'Hello World'), (2, '你好 世界', '你好 世界', '你好 世界'), (3, null, null, null)")
+
+	// The case sensitivity is determined by the collation unless it's specified by the match type.
+	tk.MustQuery("select id, regexp_like(a, 'hello'), regexp_like(b, 'hello'), regexp_like(c, 'hello'), regexp_like(b, 'hello', 'i'), regexp_like(a, 'hello', 'c') from t order by id").
+		Check(testkit.Rows("1 1 0 0 1 0", "2 0 0 0 0 0", "3 <nil> <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select id from t where regexp_like(a, '^h') order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select id, regexp_substr(a, '[a-z]+', 1, 2), regexp_instr(b, '世界'), regexp_instr(c, '世界'), regexp_replace(a, 'o', '0') from t order by id").
+		Check(testkit.Rows("1 World 0 0 Hell0 W0rld", "2 <nil> 4 8 你好 世界", "3 <nil> <nil> <nil> <nil>"))
+	tk.MustQuery("select collation(regexp_substr(a, 'x')), collation(regexp_replace(b, 'x', 'y')) from t where id = 1").
+		Check(testkit.Rows("utf8mb4_general_ci utf8mb4_bin"))
+
+	// The regexp functions are pushed down to the coprocessor.
+	tk.MustQuery("explain format = 'brief' select id from t where regexp_instr(b, 'o', 1, 2) > 0").Check(testkit.Rows(
+		"Projection 8000.00 root  test.t.id",
+		"└─TableReader 8000.00 root  data:Selection",
+		"  └─Selection 8000.00 cop[tikv]  gt(regexp_instr(test.t.b, \"o\", 1, 2), 0)",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("select id from t where regexp_instr(b, 'o', 1, 2) > 0").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where regexp_like(a, 'WORLD$') and regexp_replace(c, '[a-z]', '') = 'H W'").Check(testkit.Rows("1"))
+
+	// The errors are reported when the functions are evaluated.
+	require.EqualError(t, tk.QueryToErr("select regexp_substr('abc', 'b', 5)"), "[expression:3686]Index out of bounds in regular expression search.")
+	require.EqualError(t, tk.QueryToErr("select regexp_like('abc', 'b', 'x')"), "[expression:1210]Incorrect arguments to regexp_like")
+	require.EqualError(t, tk.QueryToErr("select regexp_instr('abc', 'b', 1, 1, 2)"), "[expression:1210]Incorrect arguments to regexp_instr: return_option must be 1 or 0.")
+	require.EqualError(t, tk.QueryToErr("select regexp_like('abc', '(')"), "[expression:1139]Got error 'error parsing regexp: missing closing ): `(`' from regexp")
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/tablecodec"
@@ -85,11 +86,27 @@ func (e *ReplaceExec) removeRow(ctx context.Context, txn kv.Transaction, handle
 		return true, nil
 	}
 
+	if err = e.triggers.fire(ctx, model.TriggerTimingBefore, model.TriggerEventDelete, oldRow, nil, nil); err != nil {
+		return false, err
+	}
 	err = r.t.RemoveRecord(e.ctx, handle, oldRow)
 	if err != nil {
 		return false, err
 	}
 	e.ctx.GetSessionVars().StmtCtx.AddAffectedRows(1)
+	for _, fkc := range e.fkChecks {
+		if err = fkc.deleteRowNeedToCheck(ctx, oldRow); err != nil {
+			return false, err
+		}
+	}
+	for _, fkc := range e.fkCascades {
+		if err = fkc.onDeleteRow(e.ctx.GetSessionVars().StmtCtx, oldRow); err != nil {
+			return false, err
+		}
+	}
+	if err = e.triggers.fire(ctx, model.TriggerTimingAfter, model.TriggerEventDelete, oldRow, nil, nil); err != nil {
+		return false, err
+	}
 	return false, nil
 }
 
@@ -208,6 +225,9 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 	 */
 
 	defer trace.StartRegion(ctx, "ReplaceExec").End()
+	if err := e.fireBeforeInsert(ctx, newRows); err != nil {
+		return err
+	}
 	// Get keys need to be checked.
 	toBeCheckedRows, err := getKeysNeedCheck(ctx, e.ctx, e.Table, newRows)
 	if err != nil {
@@ -244,6 +264,9 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 			return err
 		}
 	}
+	if err = executeFKCascades(ctx, e.fkCascades); err != nil {
+		return err
+	}
 	e.memTracker.Consume(int64(txn.Size() - txnSize))
 	return nil
 }
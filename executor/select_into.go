@@ -47,7 +47,11 @@ type SelectIntoExec struct {
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		s.chk = newFirstChunk(s.children[0])
+		return s.baseExecutor.Open(ctx)
+	}
+	// only 'select ... into outfile' and 'select ... into var_list' are supported now
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
@@ -68,6 +72,9 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.assignVars(ctx)
+	}
 	for {
 		if err := Next(ctx, s.children[0], s.chk); err != nil {
 			return err
@@ -82,6 +89,32 @@ func (s *SelectIntoExec) Next(ctx context.Context, req *chunk.Chunk) error {
 	return nil
 }
 
+// assignVars assigns the only row of the result to the user variables.
+func (s *SelectIntoExec) assignVars(ctx context.Context) error {
+	fieldTypes := retTypes(s.children[0])
+	var row []types.Datum
+	for {
+		if err := Next(ctx, s.children[0], s.chk); err != nil {
+			return err
+		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return ErrTooManyRows
+		}
+		row = s.chk.GetRow(0).GetDatumRow(fieldTypes)
+	}
+	if row == nil {
+		s.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrSpFetchNoData)
+		return nil
+	}
+	for i, v := range s.intoOpt.Variables {
+		setUserVar(s.ctx.GetSessionVars(), v.(*ast.VariableExpr).Name, *row[i].Clone(), fieldTypes[i])
+	}
+	return nil
+}
+
 func (s *SelectIntoExec) considerEncloseOpt(et types.EvalType) bool {
 	return et == types.ETString || et == types.ETDuration ||
 		et == types.ETTimestamp || et == types.ETDatetime ||
@@ -178,7 +211,7 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			case mysql.TypeNewDecimal:
 				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
 			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob, mysql.TypeGeometry:
 				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
 			case mysql.TypeBit:
 				// bit value won't be escaped anyway (verified on MySQL, test case added)
@@ -216,6 +249,9 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.baseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
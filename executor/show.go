@@ -947,6 +947,10 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsFulltext() {
+			fmt.Fprintf(buf, "  FULLTEXT KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.IsSpatial() {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -965,6 +969,9 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 			cols = append(cols, colInfo)
 		}
 		fmt.Fprintf(buf, "(%s)", strings.Join(cols, ","))
+		if idxInfo.ParserName != "" {
+			fmt.Fprintf(buf, " /*!50100 WITH PARSER %s */", stringutil.Escape(idxInfo.ParserName, sqlMode))
+		}
 		if idxInfo.Invisible {
 			fmt.Fprintf(buf, ` /*!80000 INVISIBLE */`)
 		}
@@ -1006,6 +1013,16 @@ func ConstructResultOfShowCreateTable(ctx sessionctx.Context, tableInfo *model.T
 		}
 	}
 
+	for _, constraint := range tableInfo.Constraints {
+		if constraint.State != model.StatePublic {
+			continue
+		}
+		fmt.Fprintf(buf, ",\n  CONSTRAINT %s CHECK ((%s))", stringutil.Escape(constraint.Name.O, sqlMode), constraint.ExprString)
+		if !constraint.Enforced {
+			buf.WriteString(" /*!80016 NOT ENFORCED */")
+		}
+	}
+
 	buf.WriteString("\n")
 
 	buf.WriteString(") ENGINE=InnoDB")
@@ -1208,6 +1225,9 @@ func fetchShowCreateTable4View(ctx sessionctx.Context, tb *model.TableInfo, buf
 		}
 	}
 	fmt.Fprintf(buf, ") AS %s", tb.View.SelectStmt)
+	if tb.View.CheckOption != model.CheckOptionNone {
+		fmt.Fprintf(buf, " WITH %s CHECK OPTION", tb.View.CheckOption.String())
+	}
 }
 
 func appendDirectPlacementInfo(directPlacementOpts *model.PlacementSettings, buf *bytes.Buffer) {
@@ -1240,6 +1260,11 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 		fmt.Fprintf(buf, "\nPARTITIONS %d", partitionInfo.Num)
 		return
 	}
+	if partitionInfo.Type == model.PartitionTypeKey {
+		fmt.Fprintf(buf, "\nPARTITION BY KEY(%s)", keyPartitionColumnsString(partitionInfo.Columns))
+		fmt.Fprintf(buf, "\nPARTITIONS %d", partitionInfo.Num)
+		return
+	}
 	// this if statement takes care of range columns case
 	if partitionInfo.Columns != nil && partitionInfo.Type == model.PartitionTypeRange {
 		buf.WriteString("\nPARTITION BY RANGE COLUMNS(")
@@ -1249,10 +1274,10 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 				buf.WriteString(",")
 			}
 		}
-		buf.WriteString(") (\n")
+		buf.WriteString(")")
 	} else if partitionInfo.Type == model.PartitionTypeList {
 		if len(partitionInfo.Columns) == 0 {
-			fmt.Fprintf(buf, "\nPARTITION BY %s (%s) (\n", partitionInfo.Type.String(), partitionInfo.Expr)
+			fmt.Fprintf(buf, "\nPARTITION BY %s (%s)", partitionInfo.Type.String(), partitionInfo.Expr)
 		} else {
 			colsName := ""
 			for _, col := range partitionInfo.Columns {
@@ -1261,11 +1286,23 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 				}
 				colsName += col.L
 			}
-			fmt.Fprintf(buf, "\nPARTITION BY LIST COLUMNS(%s) (\n", colsName)
+			fmt.Fprintf(buf, "\nPARTITION BY LIST COLUMNS(%s)", colsName)
 		}
 	} else {
-		fmt.Fprintf(buf, "\nPARTITION BY %s ( %s ) (\n", partitionInfo.Type.String(), partitionInfo.Expr)
+		fmt.Fprintf(buf, "\nPARTITION BY %s ( %s )", partitionInfo.Type.String(), partitionInfo.Expr)
 	}
+	if sub := partitionInfo.Sub; sub != nil {
+		if sub.Type == model.PartitionTypeKey {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY KEY(%s)", keyPartitionColumnsString(sub.Columns))
+		} else {
+			fmt.Fprintf(buf, "\nSUBPARTITION BY HASH( %s )", sub.Expr)
+		}
+		fmt.Fprintf(buf, "\nSUBPARTITIONS %d", sub.Num)
+	}
+	buf.WriteString(" (\n")
+	// The definitions of a subpartitioned table are shown by the logical partitions.
+	sub, subDefs := partitionInfo.Sub, partitionInfo.Definitions
+	partitionInfo = partitionInfo.ParentView()
 	if partitionInfo.Type == model.PartitionTypeRange {
 		for i, def := range partitionInfo.Definitions {
 			lessThans := strings.Join(def.LessThan, ",")
@@ -1278,6 +1315,7 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 				// add placement ref info here
 				fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
 			}
+			appendSubPartitionDefinitions(sub, subDefs, i, buf)
 			if i < len(partitionInfo.Definitions)-1 {
 				buf.WriteString(",\n")
 			} else {
@@ -1309,6 +1347,7 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 				// add placement ref info here
 				fmt.Fprintf(buf, " /*T![placement] PLACEMENT POLICY=%s */", stringutil.Escape(def.PlacementPolicyRef.Name.O, sqlMode))
 			}
+			appendSubPartitionDefinitions(sub, subDefs, i, buf)
 			if i < len(partitionInfo.Definitions)-1 {
 				buf.WriteString(",\n")
 			} else {
@@ -1319,6 +1358,31 @@ func appendPartitionInfo(partitionInfo *model.PartitionInfo, buf *bytes.Buffer,
 	}
 }
 
+func keyPartitionColumnsString(cols []model.CIStr) string {
+	names := make([]string, 0, len(cols))
+	for _, col := range cols {
+		names = append(names, "`"+col.O+"`")
+	}
+	return strings.Join(names, ",")
+}
+
+// appendSubPartitionDefinitions appends the subpartitions of the i-th logical partition.
+func appendSubPartitionDefinitions(sub *model.SubPartitionInfo, subDefs []model.PartitionDefinition, i int, buf *bytes.Buffer) {
+	if sub == nil {
+		return
+	}
+	num := int(sub.Num)
+	for j, def := range subDefs[i*num : (i+1)*num] {
+		if j == 0 {
+			buf.WriteString("\n   (")
+		} else {
+			buf.WriteString(",\n    ")
+		}
+		fmt.Fprintf(buf, "SUBPARTITION `%s`", def.Name)
+	}
+	buf.WriteString(")")
+}
+
 // ConstructResultOfShowCreateDatabase constructs the result for show create database.
 func ConstructResultOfShowCreateDatabase(ctx sessionctx.Context, dbInfo *model.DBInfo, ifNotExists bool, buf *bytes.Buffer) (err error) {
 	sqlMode := ctx.GetSessionVars().SQLMode
@@ -1572,6 +1636,32 @@ func (e *ShowExec) fetchShowPrivileges() error {
 }
 
 func (e *ShowExec) fetchShowTriggers() error {
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	checker := privilege.GetPrivilegeManager(e.ctx)
+	activeRoles := e.ctx.GetSessionVars().ActiveRoles
+	tables := e.is.SchemaTables(e.DBName)
+	sort.Slice(tables, func(i, j int) bool { return tables[i].Meta().Name.L < tables[j].Meta().Name.L })
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(activeRoles, e.DBName.O, tblInfo.Name.O, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, trigger := range tblInfo.Triggers {
+			var definer string
+			if trigger.Definer != nil {
+				definer = trigger.Definer.String()
+			}
+			created := types.NewTime(types.FromGoTime(trigger.Created.In(e.ctx.GetSessionVars().Location())), mysql.TypeDatetime, types.DefaultFsp)
+			e.appendRow([]interface{}{trigger.Name.O, trigger.Event.String(), tblInfo.Name.O, trigger.Body, trigger.Timing.String(),
+				created, sqlModeString(trigger.SQLMode), definer, trigger.Charset, trigger.Collate, dbInfo.Collate})
+		}
+	}
 	return nil
 }
 
@@ -1600,6 +1690,8 @@ func (e *ShowExec) fetchShowWarnings(errOnly bool) error {
 		case *terror.Error:
 			sqlErr := terror.ToSQLError(x)
 			e.appendRow([]interface{}{w.Level, int64(sqlErr.Code), sqlErr.Message})
+		case *mysql.SQLError:
+			e.appendRow([]interface{}{w.Level, int64(x.Code), x.Message})
 		default:
 			e.appendRow([]interface{}{w.Level, int64(mysql.ErrUnknown), warn.Error()})
 		}
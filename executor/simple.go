@@ -161,6 +161,10 @@ func (e *SimpleExec) Next(ctx context.Context, req *chunk.Chunk) (err error) {
 		err = e.executeShutdown(x)
 	case *ast.AdminStmt:
 		err = e.executeAdminReloadStatistics(x)
+	case *ast.CallStmt:
+		err = e.executeCall(ctx, x)
+	case *ast.SignalStmt:
+		err = e.executeSignal(x)
 	}
 	e.done = true
 	return err
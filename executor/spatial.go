@@ -0,0 +1,72 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/distsql"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+var _ Executor = &SpatialIndexLookUpExec{}
+
+// SpatialIndexLookUpExec reads the rows whose geometries may intersect the MBR of the shape through the
+// cell ids of a SPATIAL index. The spatial relation is not checked here, the condition is evaluated by the
+// Selection on it.
+type SpatialIndexLookUpExec struct {
+	kvIndexLookUpExec
+
+	shape expression.Expression
+}
+
+// Next implements the Executor interface.
+func (e *SpatialIndexLookUpExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if !e.prepared {
+		if err := e.prepare(ctx); err != nil {
+			return err
+		}
+		e.prepared = true
+	}
+	return e.fillChunk(req)
+}
+
+// prepare looks up the handles of the rows in the cells covering the MBR of the shape, and fetches the rows by the handles.
+func (e *SpatialIndexLookUpExec) prepare(ctx context.Context) error {
+	shape, err := e.shape.Eval(chunk.Row{})
+	if err != nil || shape.IsNull() {
+		return err
+	}
+	ranges := ranger.FullRange()
+	if _, g, err := spatial.Decode(shape.GetBytes()); err == nil {
+		m, ok := g.MBR()
+		if !ok {
+			// The empty geometry has no spatial relation with any geometry.
+			return nil
+		}
+		ranges = ranger.BuildSpatialRanges(m)
+	}
+	// The invalid geometry is reported by the Selection on the rows of the whole index.
+	sc := e.ctx.GetSessionVars().StmtCtx
+	kvRanges, err := distsql.IndexRangesToKVRanges(sc, e.tblInfo.ID, e.idxInfo.ID, ranges, nil)
+	if err != nil {
+		return err
+	}
+	return e.lookUp(ctx, kvRanges)
+}
@@ -0,0 +1,181 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSpatialFunctions(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select st_astext(st_geomfromtext('point(1 2)')), st_x(st_geomfromtext('POINT(1 2)')), st_y(st_geomfromtext('POINT(1 2)'))").Check(testkit.Rows("POINT(1 2) 1 2"))
+	tk.MustQuery("select st_srid(st_geomfromtext('POINT(1 2)', 4326)), st_srid(st_geometryfromtext('POINT(1 2)'))").Check(testkit.Rows("4326 0"))
+	tk.MustQuery("select st_astext(st_geomfromwkb(st_asbinary(st_geomfromtext('LINESTRING(0 0, 1 1)'))))").Check(testkit.Rows("LINESTRING(0 0,1 1)"))
+	tk.MustQuery("select hex(st_aswkb(st_geomfromtext('POINT(1 2)')))").Check(testkit.Rows("0101000000000000000000F03F0000000000000040"))
+	tk.MustQuery("select st_distance(st_geomfromtext('POINT(0 0)'), st_geomfromtext('POINT(3 4)')), " +
+		"st_distance(st_geomfromtext('POINT(0 0)'), st_geomfromtext('GEOMETRYCOLLECTION EMPTY'))").Check(testkit.Rows("5 <nil>"))
+
+	tk.MustExec("set @poly = st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')")
+	tk.MustQuery("select st_contains(@poly, st_geomfromtext('POINT(5 5)')), st_contains(@poly, st_geomfromtext('POINT(0 5)')), " +
+		"st_within(st_geomfromtext('POINT(5 5)'), @poly), st_intersects(@poly, st_geomfromtext('LINESTRING(5 5,20 20)')), " +
+		"st_intersects(@poly, st_geomfromtext('POINT(20 20)'))").Check(testkit.Rows("1 0 1 1 0"))
+	tk.MustQuery("select st_contains(null, @poly), st_astext(null)").Check(testkit.Rows("<nil> <nil>"))
+
+	require.EqualError(t, tk.QueryToErr("select st_geomfromtext('POINT(1)')"), "[expression:3037]Invalid GIS data provided to function st_geomfromtext.")
+	require.EqualError(t, tk.QueryToErr("select st_astext('abc')"), "[expression:3037]Invalid GIS data provided to function st_astext.")
+	require.EqualError(t, tk.QueryToErr("select st_x(st_geomfromtext('LINESTRING(0 0,1 1)'))"), "[expression:3037]Invalid GIS data provided to function st_x.")
+	require.EqualError(t, tk.QueryToErr("select st_contains(st_geomfromtext('POINT(1 1)', 4326), st_geomfromtext('POINT(1 1)'))"),
+		"[expression:3033]Binary geometry function st_contains given two geometries of different srids: 4326 and 0, which should have been identical.")
+}
+
+func TestSpatialColumn(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry, p point)")
+	tk.MustExec("insert into t values (1, st_geomfromtext('LINESTRING(0 0,1 1)'), st_geomfromtext('POINT(1 2)')), (2, null, null)")
+	tk.MustQuery("select id, st_astext(g), st_astext(p) from t order by id").Check(testkit.Rows("1 LINESTRING(0 0,1 1) POINT(1 2)", "2 <nil> <nil>"))
+	tk.MustQuery("select id from t where st_x(p) = 1").Check(testkit.Rows("1"))
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry DEFAULT NULL,\n" +
+		"  `p` point DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	// The value must be a geometry of the column type.
+	tk.MustGetErrCode("insert into t values (3, 'abc', null)", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (3, null, st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (3, 1, null)", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("create table t1 (g geometry default 'abc')", errno.ErrBlobCantHaveDefault)
+}
+
+func TestSpatialIndex(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, g geometry not null, spatial key sk (g))")
+	tk.MustExec("insert into t values (1, st_geomfromtext('POINT(1 1)')), (2, st_geomfromtext('POINT(-5 3)'))," +
+		"(3, st_geomfromtext('LINESTRING(-100 -100,100 100)')), (4, st_geomfromtext('POLYGON((20 20,30 20,30 30,20 30,20 20))'))," +
+		"(5, st_geomfromtext('GEOMETRYCOLLECTION EMPTY'))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry NOT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  SPATIAL KEY `sk` (`g`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+
+	box := "st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')"
+	tk.MustQuery("select id from t where st_within(g, " + box + ") order by id").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t where st_intersects(g, " + box + ") order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t where st_contains(g, st_geomfromtext('POINT(25 25)')) order by id").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select id from t where st_intersects(" + box + ", g) and id > 1 order by id").Check(testkit.Rows("3"))
+	tk.MustQuery("select id from t where st_intersects(g, st_geomfromtext('GEOMETRYCOLLECTION EMPTY'))").Check(testkit.Rows())
+	tk.MustQuery("select id from t where st_intersects(g, null)").Check(testkit.Rows())
+	require.EqualError(t, tk.QueryToErr("select id from t where st_intersects(g, 'abc')"), "[expression:3037]Invalid GIS data provided to function st_intersects.")
+
+	// The SPATIAL index is used to find the rows whose geometries may have the relation.
+	rows := tk.MustQuery("explain format = 'brief' select id from t where st_intersects(g, " + box + ") and id > 1").Rows()
+	require.Len(t, rows, 3)
+	require.Equal(t, []interface{}{"  └─SpatialIndexLookUp", "3333.33", "root", "table:t, index:sk(g)", "shape:POLYGON((0 0,10 0,10 10,0 10,0 0))"}, rows[2])
+
+	// The index entries are maintained by the writes, including the uncommitted ones.
+	tk.MustExec("begin")
+	tk.MustExec("update t set g = st_geomfromtext('POINT(100 100)') where id = 1")
+	tk.MustExec("insert into t values (6, st_geomfromtext('MULTIPOINT(2 2,50 50)'))")
+	tk.MustExec("delete from t where id = 3")
+	tk.MustQuery("select id from t where st_intersects(g, " + box + ") order by id").Check(testkit.Rows("6"))
+	tk.MustExec("commit")
+	tk.MustQuery("select id from t where st_intersects(g, " + box + ") order by id").Check(testkit.Rows("6"))
+	tk.MustExec("admin check table t")
+
+	// The SPATIAL index can be added to the existing geometry column.
+	tk.MustExec("create table t1 (id int primary key, g point not null)")
+	tk.MustExec("insert into t1 values (1, st_geomfromtext('POINT(1 1)')), (2, st_geomfromtext('POINT(20 20)'))")
+	tk.MustExec("alter table t1 add spatial index sk (g)")
+	tk.MustQuery("select id from t1 where st_within(g, " + box + ")").Check(testkit.Rows("1"))
+	tk.MustExec("alter table t1 drop index sk")
+	tk.MustExec("create spatial index sk on t1 (g)")
+	tk.MustQuery("select id from t1 where st_within(g, " + box + ")").Check(testkit.Rows("1"))
+
+	// The indexed column must be a NOT NULL geometry column, and the table can't be partitioned.
+	tk.MustGetErrCode("create table t2 (g geometry, spatial key (g))", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t2 (a int not null, spatial key (a))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("create table t2 (g geometry not null, h geometry not null, spatial key (g, h))", errno.ErrTooManyKeyParts)
+	tk.MustGetErrCode("create table t2 (id int, g geometry not null, spatial key (g)) partition by hash(id) partitions 2", errno.ErrTableCantHandleSpkeys)
+	tk.MustGetErrCode("alter table t1 add spatial index sk2 (id)", errno.ErrSpatialMustHaveGeomCol)
+}
+
+func TestSpatialIndexAccessPath(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, b int, g geometry not null, key kb (b), spatial key sk (g))")
+	tk.MustExec("insert into t values (1, 1, st_geomfromtext('POINT(1 1)')), (2, 1, st_geomfromtext('POINT(20 20)')), (3, 2, st_geomfromtext('POINT(2 2)'))")
+
+	// The SPATIAL index is chosen by the cost like the other indexes, and the geometry is shown in the well-known text.
+	box := "st_geomfromtext('POLYGON((0 0,10 0,10 10,0 10,0 0))')"
+	tk.MustQuery("explain format = 'brief' select id from t where st_within(g, " + box + ") and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  gt(test.t.b, 1), st_within(test.t.g, \"POLYGON((0 0,10 0,10 10,0 10,0 0))\")",
+		"  └─SpatialIndexLookUp 3333.33 root table:t, index:sk(g) shape:POLYGON((0 0,10 0,10 10,0 10,0 0))"))
+	tk.MustQuery("explain format = 'brief' select id from t where st_within(g, " + box + ") and id = 1").Check(testkit.Rows(
+		"Projection 0.80 root  test.t.id",
+		"└─Selection 0.80 root  st_within(test.t.g, \"POLYGON((0 0,10 0,10 10,0 10,0 0))\")",
+		"  └─Point_Get 1.00 root table:t handle:1"))
+	tk.MustQuery("explain format = 'brief' select id from t where st_within(g, " + box + ") and b = 1").Check(testkit.Rows(
+		"Projection 8.00 root  test.t.id",
+		"└─Selection 8.00 root  st_within(test.t.g, \"POLYGON((0 0,10 0,10 10,0 10,0 0))\")",
+		"  └─IndexLookUp 10.00 root  ",
+		"    ├─IndexRangeScan(Build) 10.00 cop[tikv] table:t, index:kb(b) range:[1,1], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 10.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+
+	// The index hints are honored.
+	tk.MustQuery("explain format = 'brief' select id from t ignore index (sk) where st_within(g, " + box + ")").Check(testkit.Rows(
+		"Projection 8000.00 root  test.t.id",
+		"└─Selection 8000.00 root  st_within(test.t.g, \"POLYGON((0 0,10 0,10 10,0 10,0 0))\")",
+		"  └─TableReader 10000.00 root  data:TableFullScan",
+		"    └─TableFullScan 10000.00 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("explain format = 'brief' select id from t use index (kb) where st_within(g, " + box + ") and b > 1").Check(testkit.Rows(
+		"Projection 2666.67 root  test.t.id",
+		"└─Selection 2666.67 root  st_within(test.t.g, \"POLYGON((0 0,10 0,10 10,0 10,0 0))\")",
+		"  └─IndexLookUp 3333.33 root  ",
+		"    ├─IndexRangeScan(Build) 3333.33 cop[tikv] table:t, index:kb(b) range:(1,+inf], keep order:false, stats:pseudo",
+		"    └─TableRowIDScan(Probe) 3333.33 cop[tikv] table:t keep order:false, stats:pseudo"))
+	tk.MustQuery("select id from t ignore index (sk) where st_within(g, " + box + ") order by id").Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select id from t use index (kb) where st_within(g, " + box + ") and b > 0 order by id").Check(testkit.Rows("1", "3"))
+}
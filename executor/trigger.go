@@ -0,0 +1,277 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+type triggerKeyType int
+
+func (k triggerKeyType) String() string {
+	return "trigger_table_stack"
+}
+
+// triggerTableStackKey is the key of the IDs of the tables whose triggers are being executed in the session.
+const triggerTableStackKey triggerKeyType = 0
+
+// inTrigger returns whether the session is executing a trigger.
+func inTrigger(sctx sessionctx.Context) bool {
+	stack, _ := sctx.Value(triggerTableStackKey).([]int64)
+	return len(stack) > 0
+}
+
+// checkTriggerTable checks whether the table is changed by the triggers activated by itself.
+func checkTriggerTable(sctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	stack, _ := sctx.Value(triggerTableStackKey).([]int64)
+	for _, id := range stack {
+		if id == tblInfo.ID {
+			return ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tblInfo.Name.O)
+		}
+	}
+	return nil
+}
+
+// buildTriggerExec builds the executor activating the triggers of the table, it returns nil
+// if the table has no trigger.
+func (b *executorBuilder) buildTriggerExec(tbl table.Table) (*triggerExec, error) {
+	if err := checkTriggerTable(b.ctx, tbl.Meta()); err != nil {
+		return nil, err
+	}
+	if len(tbl.Meta().Triggers) == 0 {
+		return nil, nil
+	}
+	dbInfo, ok := b.is.SchemaByTable(tbl.Meta())
+	if !ok {
+		return nil, errors.Errorf("can not find the schema of table %s", tbl.Meta().Name.O)
+	}
+	return &triggerExec{ctx: b.ctx, tbl: tbl, db: dbInfo.Name}, nil
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2table map[int64]table.Table) (map[int64]*triggerExec, error) {
+	var execs map[int64]*triggerExec
+	for tid, tbl := range tblID2table {
+		exec, err := b.buildTriggerExec(tbl)
+		if err != nil {
+			return nil, err
+		}
+		if exec == nil {
+			continue
+		}
+		if execs == nil {
+			execs = make(map[int64]*triggerExec, len(tblID2table))
+		}
+		execs[tid] = exec
+	}
+	return execs, nil
+}
+
+// triggerExec activates the triggers of a table for the rows changed by a DML statement.
+// The triggers are executed in the transaction of the statement, and their changes are
+// rolled back with the statement.
+type triggerExec struct {
+	ctx sessionctx.Context
+	tbl table.Table
+	db  model.CIStr
+	// genExprs are the expressions of the generated columns, which are evaluated again
+	// after the columns of the NEW row are assigned by the BEFORE triggers.
+	genExprs []expression.Expression
+}
+
+// triggerRow is the row which a trigger is activated for.
+type triggerRow struct {
+	tbl    table.Table
+	oldRow []types.Datum
+	newRow []types.Datum
+	// assigned marks the columns of the NEW row assigned by the triggers, it's nil
+	// if the NEW row can't be assigned.
+	assigned []bool
+}
+
+// column returns the column of the NEW or OLD row, the name of the row is in lower case.
+func (r *triggerRow) column(row, name string) (*table.Column, types.Datum, bool) {
+	data := r.newRow
+	if row == "old" {
+		data = r.oldRow
+	}
+	col := table.FindCol(r.tbl.Cols(), name)
+	if col == nil || col.Offset >= len(data) {
+		return nil, types.Datum{}, false
+	}
+	return col, data[col.Offset], true
+}
+
+// setColumn assigns the column of the NEW row.
+func (r *triggerRow) setColumn(sctx sessionctx.Context, name string, value types.Datum) error {
+	if r.assigned == nil {
+		return ddl.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+	}
+	col := table.FindCol(r.tbl.Cols(), name)
+	if col == nil || col.Offset >= len(r.newRow) {
+		return plannercore.ErrUnknownColumn.GenWithStackByArgs(name, "NEW")
+	}
+	if col.IsGenerated() {
+		return plannercore.ErrBadGeneratedColumn.GenWithStackByArgs(col.Name.O, r.tbl.Meta().Name.O)
+	}
+	casted, err := table.CastValue(sctx, value, col.ToInfo(), false, false)
+	if err != nil {
+		return err
+	}
+	r.newRow[col.Offset] = casted
+	r.assigned[col.Offset] = true
+	return nil
+}
+
+// fire activates the triggers of the timing and the event for a row. The NEW row can be
+// assigned by the BEFORE triggers, the assigned columns and the generated columns evaluated
+// again are marked in modified if it isn't nil.
+func (e *triggerExec) fire(ctx context.Context, timing model.TriggerTiming, event model.TriggerEvent, oldRow, newRow []types.Datum, modified []bool) error {
+	if e == nil {
+		return nil
+	}
+	row := &triggerRow{tbl: e.tbl, oldRow: oldRow, newRow: newRow}
+	if timing == model.TriggerTimingBefore && newRow != nil {
+		row.assigned = make([]bool, len(newRow))
+	}
+	var fired bool
+	for _, trigger := range e.tbl.Meta().Triggers {
+		if trigger.Timing != timing || trigger.Event != event {
+			continue
+		}
+		if err := e.run(ctx, trigger, row); err != nil {
+			return err
+		}
+		fired = true
+	}
+	if !fired || row.assigned == nil {
+		return nil
+	}
+	return e.completeNewRow(row, modified)
+}
+
+// completeNewRow checks the columns of the NEW row assigned by the BEFORE triggers, and
+// evaluates the generated columns again.
+func (e *triggerExec) completeNewRow(row *triggerRow, modified []bool) error {
+	var assigned bool
+	sc := e.ctx.GetSessionVars().StmtCtx
+	for _, col := range e.tbl.Cols() {
+		if col.Offset >= len(row.assigned) || !row.assigned[col.Offset] {
+			continue
+		}
+		assigned = true
+		if err := col.HandleBadNull(&row.newRow[col.Offset], sc); err != nil {
+			return err
+		}
+		if modified != nil {
+			modified[col.Offset] = true
+		}
+	}
+	if !assigned {
+		return nil
+	}
+	if e.genExprs == nil {
+		var err error
+		if e.genExprs, err = buildGeneratedColumnExprs(e.ctx, e.tbl); err != nil {
+			return err
+		}
+	}
+	gIdx := 0
+	for _, col := range e.tbl.WritableCols() {
+		if !col.IsGenerated() {
+			continue
+		}
+		expr := e.genExprs[gIdx]
+		gIdx++
+		if col.Offset >= len(row.newRow) {
+			continue
+		}
+		val, err := expr.Eval(chunk.MutRowFromDatums(row.newRow).ToRow())
+		if err != nil {
+			return err
+		}
+		row.newRow[col.Offset], err = table.CastValue(e.ctx, val, col.ToInfo(), false, false)
+		if err != nil {
+			return err
+		}
+		if modified != nil {
+			modified[col.Offset] = true
+		}
+	}
+	return nil
+}
+
+// run executes the body of the trigger with the privileges of its definer.
+func (e *triggerExec) run(ctx context.Context, trigger *model.TriggerInfo, row *triggerRow) error {
+	sctx := e.ctx
+	stack, _ := sctx.Value(triggerTableStackKey).([]int64)
+	sctx.SetValue(triggerTableStackKey, append(stack, e.tbl.Meta().ID))
+	defer sctx.SetValue(triggerTableStackKey, stack)
+
+	stmt, err := parseTriggerDefinition(sctx, trigger)
+	if err != nil {
+		return err
+	}
+	// The unqualified names in the trigger body refer to the database of the table. The
+	// parameters of the prepared statement being executed are cleared by the statements
+	// of the trigger, so they are restored for the following rows.
+	vars := sctx.GetSessionVars()
+	currentDB, params := vars.CurrentDB, vars.PreparedParams
+	vars.CurrentDB = e.db.O
+	defer func() {
+		vars.CurrentDB, vars.PreparedParams = currentDB, params
+	}()
+	defer bindDefinerPrivileges(sctx, trigger.Definer)()
+
+	p := &procedureInterpreter{
+		sctx:    sctx,
+		is:      sctx.GetInfoSchema().(infoschema.InfoSchema),
+		db:      e.db,
+		name:    e.db.O + "." + trigger.Name.O,
+		scopes:  []*procedureScope{newProcedureScope()},
+		trigger: row,
+	}
+	_, err = p.exec(ctx, stmt.Body)
+	return err
+}
+
+// parseTriggerDefinition parses the CREATE statement of a trigger with the SQL mode in
+// effect when the trigger was created.
+func parseTriggerDefinition(sctx sessionctx.Context, trigger *model.TriggerInfo) (*ast.CreateTriggerStmt, error) {
+	p := parser.New()
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	p.SetSQLMode(trigger.SQLMode)
+	stmt, err := p.ParseOneStmt(trigger.Definition, trigger.Charset, trigger.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	createStmt, ok := stmt.(*ast.CreateTriggerStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of trigger %s", trigger.Name.O)
+	}
+	return createStmt, nil
+}
@@ -0,0 +1,201 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrigger(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int as (a * 2))")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(64))")
+
+	// The BEFORE triggers can change the NEW row, and the generated columns are evaluated again.
+	tk.MustExec("create trigger t_bi before insert on t for each row set new.a = ifnull(new.a, 0) + 100")
+	tk.MustExec("create trigger t_ai after insert on t for each row insert into log (msg) values (concat('ins ', new.id, ' ', new.a, ' ', new.b))")
+	tk.MustExec("insert into t (id, a) values (1, 1), (2, null)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 101 202", "2 100 200"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("ins 1 101 202", "ins 2 100 200"))
+
+	tk.MustExec(`create trigger t_bu before update on t for each row
+		begin
+			if new.a > 1000 then
+				set new.a = old.a;
+			end if;
+		end`)
+	tk.MustExec("create trigger t_au after update on t for each row insert into log (msg) values (concat('upd ', old.a, '->', new.a))")
+	tk.MustExec("create trigger t_ad after delete on t for each row insert into log (msg) values (concat('del ', old.id))")
+	tk.MustExec("delete from log")
+	tk.MustExec("update t set a = a + 1 where id = 1")
+	tk.MustExec("update t set a = 5000 where id = 2")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 102 204", "2 100 200"))
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("upd 101->102", "upd 100->100", "del 2"))
+
+	// INSERT ... ON DUPLICATE KEY UPDATE activates the UPDATE triggers for the duplicated rows,
+	// and REPLACE activates the DELETE triggers for the replaced rows.
+	tk.MustExec("delete from log")
+	tk.MustExec("insert into t (id, a) values (1, 1) on duplicate key update a = 7")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 7 14"))
+	tk.MustExec("replace into t (id, a) values (1, 3)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 103 206"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("upd 102->7", "del 1", "ins 1 103 206"))
+
+	// The changes of the triggers are rolled back with the statement.
+	tk.MustExec("create table limits (a int primary key)")
+	tk.MustExec("insert into limits values (1)")
+	tk.MustExec("create trigger t_bd before delete on t for each row insert into limits values (old.id)")
+	tk.MustGetErrCode("delete from t", errno.ErrDupEntry)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from limits").Check(testkit.Rows("1"))
+
+	// The triggers aren't activated by the statements which don't change the table.
+	tk.MustExec("delete from log")
+	tk.MustExec("update t set a = a where id = 3")
+	tk.MustExec("delete from t where id = 3")
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("0"))
+}
+
+func TestTriggerOrderAndMeta(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(20))")
+	tk.MustExec("create trigger tr1 after insert on t for each row insert into log (msg) values ('tr1')")
+	tk.MustExec("create trigger tr2 after insert on t for each row insert into log (msg) values ('tr2')")
+	tk.MustExec("create trigger tr3 after insert on t for each row follows tr1 insert into log (msg) values ('tr3')")
+	tk.MustExec("create trigger tr4 after insert on t for each row precedes tr1 insert into log (msg) values ('tr4')")
+	tk.MustGetErrCode("create trigger tr5 after insert on t for each row follows no_such insert into log (msg) values ('tr5')", errno.ErrReferencedTrgDoesNotExist)
+	tk.MustExec("insert into t values (1)")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("tr4", "tr1", "tr3", "tr2"))
+
+	tk.MustQuery("select trigger_name, event_manipulation, event_object_table, action_order, action_timing, action_statement from information_schema.triggers where trigger_schema = 'test' order by action_order").Check(testkit.Rows(
+		"tr4 INSERT t 1 AFTER insert into log (msg) values ('tr4')",
+		"tr1 INSERT t 2 AFTER insert into log (msg) values ('tr1')",
+		"tr3 INSERT t 3 AFTER insert into log (msg) values ('tr3')",
+		"tr2 INSERT t 4 AFTER insert into log (msg) values ('tr2')"))
+	rows := tk.MustQuery("show triggers").Rows()
+	require.Len(t, rows, 4)
+	require.Equal(t, []interface{}{"tr4", "INSERT", "t", "insert into log (msg) values ('tr4')", "AFTER"}, rows[0][:5])
+	rows = tk.MustQuery("show triggers like 'tr1'").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "tr1", rows[0][0])
+
+	tk.MustGetErrCode("create trigger tr1 before delete on t for each row set @a = 1", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 before delete on t for each row set @a = 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustExec("drop trigger tr1")
+	tk.MustExec("drop trigger test.tr2")
+	tk.MustGetErrCode("drop trigger tr2", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustExec("delete from log")
+	tk.MustExec("insert into t values (2)")
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows("tr4", "tr3"))
+
+	// The triggers are dropped with the table.
+	tk.MustExec("drop table t")
+	tk.MustQuery("select count(*) from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("0"))
+}
+
+func TestTriggerErrors(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database db2")
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table t2 (a int)")
+	tk.MustExec("create view v as select a from t")
+
+	tk.MustGetErrCode("create trigger tr after insert on t for each row set new.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr before update on t for each row set old.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set @a = old.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr before delete on t for each row set @a = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row set @a = new.b", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row select 1", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger tr before insert on t for each row create table t3 (a int)", errno.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustGetErrCode("create trigger tr before insert on v for each row set @a = 1", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger db2.tr before insert on t for each row set @a = 1", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger mysql.tr before insert on mysql.user for each row set @a = 1", errno.ErrNoTriggersOnSystemSchema)
+	tk.MustGetErrCode("create trigger tr before insert on no_such for each row set @a = 1", errno.ErrNoSuchTable)
+
+	// A trigger can't change the table which activates it.
+	tk.MustExec("create trigger tr after insert on t for each row insert into t2 values (new.a)")
+	tk.MustExec("create trigger tr2 after insert on t2 for each row insert into t values (new.a)")
+	tk.MustGetErrCode("insert into t values (1)", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+	tk.MustExec("drop trigger tr2")
+	tk.MustExec("insert into t values (1)")
+	tk.MustQuery("select a from t2").Check(testkit.Rows("1"))
+}
+
+func TestTriggerPrivileges(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create table log (a int)")
+	tk.MustExec("create user 'u1'@'%', 'u2'@'%'")
+	tk.MustExec("grant trigger on test.t to 'u1'@'%'")
+	tk.MustExec("grant insert on test.log to 'u1'@'%'")
+	tk.MustExec("grant insert on test.t to 'u2'@'%'")
+
+	tk1 := testkit.NewTestKit(t, store)
+	tk1.MustExec("use test")
+	require.True(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil))
+	tk1.MustExec("create trigger tr after insert on t for each row insert into log values (new.a)")
+	tk1.MustQuery("select trigger_name, definer from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("tr u1@%"))
+
+	// The trigger runs with the privileges of the definer.
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	require.True(t, tk2.Session().Auth(&auth.UserIdentity{Username: "u2", Hostname: "%"}, nil, nil))
+	tk2.MustExec("insert into t values (1)")
+	tk.MustQuery("select a from log").Check(testkit.Rows("1"))
+	tk2.MustGetErrCode("create trigger tr3 before insert on t for each row set @a = 1", errno.ErrTableaccessDenied)
+	tk2.MustGetErrCode("drop trigger tr", errno.ErrTableaccessDenied)
+	tk2.MustQuery("select count(*) from information_schema.triggers where trigger_schema = 'test'").Check(testkit.Rows("0"))
+	tk2.MustQuery("show triggers").Check(testkit.Rows())
+
+	tk.MustExec("revoke insert on test.log from 'u1'@'%'")
+	tk2.MustGetErrCode("insert into t values (2)", errno.ErrTableaccessDenied)
+	tk.MustQuery("select a from t").Check(testkit.Rows("1"))
+}
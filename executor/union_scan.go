@@ -91,6 +91,9 @@ func (us *UnionScanExec) open(ctx context.Context) error {
 
 	us.memBuf = mb
 	us.memBufSnap = mb.SnapshotGetter()
+	if us.ctx.GetSessionVars().StmtCtx.InTrigger {
+		us.memBufSnap = mb
+	}
 
 	// 1. select without virtual columns
 	// 2. build virtual columns and select with virtual columns
@@ -0,0 +1,133 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateDeleteThroughView(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int, c int as (a + b))")
+	tk.MustExec("insert into t (id, a, b) values (1, 1, 1), (2, 2, 2), (3, -1, 3)")
+	tk.MustExec("create view v as select * from t where a > 0")
+	tk.MustExec("create view v2 (p, q) as select a, b + 1 from v where id < 10")
+
+	// The generated columns of the base table are updated as well.
+	tk.MustExec("update v set a = 5 where id = 1")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 5 1 6", "2 2 2 4", "3 -1 3 2"))
+	// The rows filtered out by the view aren't updated.
+	tk.MustExec("update v2 set p = 7")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 7 1 8", "2 7 2 9", "3 -1 3 2"))
+	tk.MustGetErrMsg("update v2 set q = 7", "[planner:1348]Column 'q' is not updatable")
+	tk.MustExec("update v as x, t set x.b = 9 where x.id = t.id and t.id = 2")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 7 1 8", "2 7 9 16", "3 -1 3 2"))
+
+	tk.MustExec("delete from v where id = 2")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("1", "3"))
+	tk.MustExec("delete x from v as x join t on x.id = t.id")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("3"))
+	tk.MustExec("delete from v2")
+	tk.MustQuery("select id from t order by id").Check(testkit.Rows("3"))
+
+	// The views which can't be merged into the statement are not updatable.
+	tk.MustExec("create view v3 as select distinct a from t")
+	tk.MustExec("create view v4 as select count(*) as cnt from t")
+	tk.MustExec("create view v5 as select t.a from t join t as t2 on t.id = t2.id")
+	tk.MustGetErrMsg("update v3 set a = 1", "[planner:1288]The target table v3 of the UPDATE is not updatable")
+	tk.MustGetErrMsg("update v4 set cnt = 1", "[planner:1288]The target table v4 of the UPDATE is not updatable")
+	tk.MustGetErrMsg("delete from v5", "[planner:1288]The target table v5 of the DELETE is not updatable")
+	tk.MustQuery("select table_name, is_updatable from information_schema.views where table_schema = 'test' order by table_name").
+		Check(testkit.Rows("v YES", "v2 YES", "v3 NO", "v4 NO", "v5 NO"))
+}
+
+func TestInsertThroughView(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int default 10)")
+	tk.MustExec("create view v (x, y) as select id, a from t")
+	tk.MustExec("create view v2 as select id, a + 1 as a from t")
+	tk.MustExec("create view v3 (x, y) as select id, id from t")
+
+	tk.MustExec("insert into v values (1, 1)")
+	tk.MustExec("insert into v (y, x) values (2, 2)")
+	tk.MustExec("insert into v set x = 3, y = 3")
+	tk.MustExec("insert into v values (1, 5) on duplicate key update y = values(y) + y")
+	tk.MustExec("replace into v values (2, 7)")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 6 10", "2 7 10", "3 3 10"))
+	tk.MustGetErrMsg("insert into v (z) values (1)", "[planner:1054]Unknown column 'z' in 'field list'")
+
+	tk.MustGetErrMsg("insert into v2 values (4, 4)", "[planner:1471]The target table v2 of the INSERT is not insertable-into")
+	tk.MustGetErrMsg("replace into v3 values (4, 4)", "[planner:1471]The target table v3 of the REPLACE is not insertable-into")
+}
+
+func TestViewCheckOption(t *testing.T) {
+	t.Parallel()
+
+	store, clean := testkit.CreateMockStore(t)
+	defer clean()
+
+	tk := testkit.NewTestKit(t, store)
+	require.True(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int)")
+	tk.MustExec("create view v as select * from t where a > 0")
+	tk.MustExec("create view v_local as select * from v where a < 10 with local check option")
+	tk.MustExec("create view v_cascaded as select * from v where a < 10 with check option")
+	tk.MustQuery("select table_name, check_option from information_schema.views where table_schema = 'test' order by table_name").
+		Check(testkit.Rows("v NONE", "v_cascaded CASCADED", "v_local LOCAL"))
+	tk.MustQuery("show create view v_local").Check(testkit.Rows("v_local CREATE ALGORITHM=UNDEFINED DEFINER=`root`@`%` SQL SECURITY DEFINER " +
+		"VIEW `v_local` (`id`, `a`) AS SELECT `test`.`v`.`id` AS `id`,`test`.`v`.`a` AS `a` FROM `test`.`v` WHERE `a`<10 WITH LOCAL CHECK OPTION utf8mb4 utf8mb4_bin"))
+
+	// The view without CHECK OPTION doesn't check the rows.
+	tk.MustExec("insert into v values (1, -1)")
+	// LOCAL only checks the conditions of the view itself.
+	tk.MustExec("insert into v_local values (2, -2)")
+	tk.MustGetErrMsg("insert into v_local values (3, 20)", "[table:1369]CHECK OPTION failed 'test.v_local'")
+	// CASCADED checks the conditions of the underlying views as well.
+	tk.MustGetErrMsg("insert into v_cascaded values (3, -3)", "[table:1369]CHECK OPTION failed 'test.v'")
+	tk.MustGetErrMsg("insert into v_cascaded values (3, null)", "[table:1369]CHECK OPTION failed 'test.v'")
+	tk.MustExec("insert into v_cascaded values (3, 3)")
+	tk.MustGetErrCode("update v_cascaded set a = 10", errno.ErrViewCheckFailed)
+	tk.MustGetErrCode("insert into v_cascaded values (3, 3) on duplicate key update a = 0", errno.ErrViewCheckFailed)
+
+	// IGNORE skips the rows failing the check.
+	tk.MustExec("insert ignore into v_cascaded values (4, 4), (5, 50)")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1369 CHECK OPTION failed 'test.v_cascaded'"))
+	tk.MustExec("update ignore v_cascaded set a = a + 5")
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 -1", "2 -2", "3 8", "4 9"))
+
+	tk.MustGetErrMsg("create view v_bad as select distinct a from t with check option", "[planner:1368]CHECK OPTION on non-updatable view 'test.v_bad'")
+	tk.MustExec("create view v_agg as select count(*) as cnt from t")
+	tk.MustGetErrCode("create view v_bad as select * from v_agg with local check option", errno.ErrViewNonupdCheck)
+}
@@ -45,6 +45,11 @@ type UpdateExec struct {
 	// The value is true if the row is changed, or false otherwise
 	updatedRowKeys map[int]*kv.HandleMap
 	tblID2table    map[int64]table.Table
+	// tblID2Constraints stores the enforced check constraints of the updated tables.
+	tblID2Constraints map[int64][]*table.Constraint
+	// viewChecks stores the conditions of the views WITH CHECK OPTION, the key is the start offset
+	// of the updated table in the row.
+	viewChecks map[int][]*table.ViewCheck
 	// mergedRowData is a map for unique (Table, handle) pair.
 	// The value is cached table row
 	mergedRowData          map[int64]*kv.HandleMap
@@ -67,6 +72,10 @@ type UpdateExec struct {
 	tableUpdatable []bool
 	changed        []bool
 	matches        []bool
+
+	fkChecks   map[int64][]*FKCheckExec
+	fkCascades map[int64][]*FKCascadeExec
+	triggers   map[int64]*triggerExec
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -188,15 +197,36 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 		newTableData := newData[content.Start:content.End]
 		flags := bAssignFlag[content.Start:content.End]
 
+		// The columns assigned by the BEFORE UPDATE triggers are marked in the flags.
+		triggers := e.triggers[content.TblID]
+		if err := triggers.fire(ctx, model.TriggerTimingBefore, model.TriggerEventUpdate, oldData, newTableData, flags); err != nil {
+			return err
+		}
+
 		// Update row
-		changed, err1 := updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker)
+		err1 := table.CheckRowConstraint(e.ctx, e.tblID2Constraints[content.TblID], newTableData)
+		if err1 == nil {
+			err1 = table.CheckRowViewCondition(e.ctx, e.viewChecks[content.Start], newTableData)
+		}
+		var changed bool
+		if err1 == nil {
+			changed, err1 = updateRecord(ctx, e.ctx, handle, oldData, newTableData, flags, tbl, false, e.memTracker)
+		}
 		if err1 == nil {
 			e.updatedRowKeys[content.Start].Set(handle, changed)
-			continue
+			if changed {
+				err1 = e.handleFKTriggers(ctx, content.TblID, oldData, newTableData)
+			}
+			if err1 == nil {
+				if err := triggers.fire(ctx, model.TriggerTimingAfter, model.TriggerEventUpdate, oldData, newTableData, nil); err != nil {
+					return err
+				}
+				continue
+			}
 		}
 
 		sc := e.ctx.GetSessionVars().StmtCtx
-		if kv.ErrKeyExists.Equal(err1) && sc.DupKeyAsWarning {
+		if (kv.ErrKeyExists.Equal(err1) || table.ErrCheckConstraintViolated.Equal(err1) || table.ErrViewCheckFailed.Equal(err1)) && sc.DupKeyAsWarning {
 			sc.AppendWarning(err1)
 			continue
 		}
@@ -205,6 +235,30 @@ func (e *UpdateExec) exec(ctx context.Context, schema *expression.Schema, row, n
 	return nil
 }
 
+func (e *UpdateExec) handleFKTriggers(ctx context.Context, tblID int64, oldData, newData []types.Datum) error {
+	for _, fkc := range e.fkChecks[tblID] {
+		if err := fkc.updateRowNeedToCheck(ctx, oldData, newData); err != nil {
+			return err
+		}
+	}
+	sc := e.ctx.GetSessionVars().StmtCtx
+	for _, fkc := range e.fkCascades[tblID] {
+		if err := fkc.onUpdateRow(sc, oldData, newData); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *UpdateExec) executeFKCascades(ctx context.Context) error {
+	for _, info := range e.tblColPosInfos {
+		if err := executeFKCascades(ctx, e.fkCascades[info.TblID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // unmatchedOuterRow checks the tableCols of a record to decide whether that record
 // can not be updated. The handle is NULL only when it is the inner side of an
 // outer join: the outer row can not match any inner rows, and in this scenario
@@ -227,6 +281,9 @@ func (e *UpdateExec) Next(ctx context.Context, req *chunk.Chunk) error {
 		if err != nil {
 			return err
 		}
+		if err = e.executeFKCascades(ctx); err != nil {
+			return err
+		}
 		e.drained = true
 		e.ctx.GetSessionVars().StmtCtx.AddRecordRows(uint64(numRows))
 	}
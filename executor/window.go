@@ -16,15 +16,23 @@ package executor
 
 import (
 	"context"
+	"sort"
+	"sync/atomic"
 
 	"github.com/cznic/mathutil"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/executor/aggfuncs"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/disk"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	"go.uber.org/zap"
 )
 
 // WindowExec is the executor for window functions.
@@ -43,11 +51,31 @@ type WindowExec struct {
 
 	numWindowFuncs int
 	processor      windowProcessor
+
+	// chunks keeps the child chunks of resultChunks, they are spilled to disk when the memory quota is exceeded.
+	chunks *windowChunkBuffer
+}
+
+// Open implements the Executor Open interface.
+func (e *WindowExec) Open(ctx context.Context) error {
+	if err := e.baseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.chunks = newWindowChunkBuffer(&e.baseExecutor)
+	return nil
 }
 
 // Close implements the Executor Close interface.
 func (e *WindowExec) Close() error {
-	return errors.Trace(e.baseExecutor.Close())
+	var firstErr error
+	if e.chunks != nil {
+		firstErr = e.chunks.close()
+		e.chunks = nil
+	}
+	if err := e.baseExecutor.Close(); firstErr == nil {
+		firstErr = err
+	}
+	return errors.Trace(firstErr)
 }
 
 // Next implements the Executor Next interface.
@@ -61,6 +89,15 @@ func (e *WindowExec) Next(ctx context.Context, chk *chunk.Chunk) error {
 		}
 	}
 	if len(e.resultChunks) > 0 {
+		childChk, err := e.chunks.popFront()
+		if err != nil {
+			return err
+		}
+		if childChk != nil {
+			if err = e.copyChk(childChk, e.resultChunks[0]); err != nil {
+				return err
+			}
+		}
 		chk.SwapColumns(e.resultChunks[0])
 		e.resultChunks[0] = nil // GC it. TODO: Reuse it.
 		e.resultChunks = e.resultChunks[1:]
@@ -74,7 +111,32 @@ func (e *WindowExec) preparedChunkAvailable() bool {
 }
 
 func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
-	var groupRows []chunk.Row
+	var (
+		groupRows []chunk.Row
+		// groupOffset is the offset of the first row of the group among all the rows fetched from the child.
+		groupOffset  uint64
+		numGroupRows uint64
+	)
+	appendGroupRows := func(begin, end int) {
+		if numGroupRows == 0 {
+			groupOffset = e.chunks.numRows - uint64(e.childResult.NumRows()-begin)
+		}
+		numGroupRows += uint64(end - begin)
+		if e.chunks.spilled {
+			// The rows are read from disk after the chunks are spilled.
+			groupRows = nil
+			return
+		}
+		for i := begin; i < end; i++ {
+			groupRows = append(groupRows, e.childResult.GetRow(i))
+		}
+	}
+	getGroupRows := func() windowRows {
+		if e.chunks.spilled {
+			return &spilledRows{chunks: e.chunks, offset: groupOffset, n: numGroupRows}
+		}
+		return memRows(groupRows)
+	}
 	if e.groupChecker.isExhausted() {
 		eof, err := e.fetchChild(ctx)
 		if err != nil {
@@ -82,7 +144,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows(getGroupRows())
 		}
 		_, err = e.groupChecker.splitIntoGroups(e.childResult)
 		if err != nil {
@@ -90,9 +152,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 	}
 	begin, end := e.groupChecker.getNextGroup()
-	for i := begin; i < end; i++ {
-		groupRows = append(groupRows, e.childResult.GetRow(i))
-	}
+	appendGroupRows(begin, end)
 
 	for meetLastGroup := end == e.childResult.NumRows(); meetLastGroup; {
 		meetLastGroup = false
@@ -102,7 +162,7 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 		}
 		if eof {
 			e.executed = true
-			return e.consumeGroupRows(groupRows)
+			return e.consumeGroupRows(getGroupRows())
 		}
 
 		isFirstGroupSameAsPrev, err := e.groupChecker.splitIntoGroups(e.childResult)
@@ -112,20 +172,23 @@ func (e *WindowExec) consumeOneGroup(ctx context.Context) error {
 
 		if isFirstGroupSameAsPrev {
 			begin, end = e.groupChecker.getNextGroup()
-			for i := begin; i < end; i++ {
-				groupRows = append(groupRows, e.childResult.GetRow(i))
-			}
+			appendGroupRows(begin, end)
 			meetLastGroup = end == e.childResult.NumRows()
 		}
 	}
-	return e.consumeGroupRows(groupRows)
+	return e.consumeGroupRows(getGroupRows())
 }
 
-func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
-	remainingRowsInGroup := len(groupRows)
+func (e *WindowExec) consumeGroupRows(groupRows windowRows) (err error) {
+	remainingRowsInGroup := int(groupRows.numRows())
 	if remainingRowsInGroup == 0 {
 		return nil
 	}
+	defer func() {
+		if err == nil {
+			err = e.chunks.err
+		}
+	}()
 	for i := 0; i < len(e.resultChunks); i++ {
 		remained := mathutil.Min(e.remainingRowsInChunk[i], remainingRowsInGroup)
 		e.remainingRowsInChunk[i] -= remained
@@ -151,6 +214,11 @@ func (e *WindowExec) consumeGroupRows(groupRows []chunk.Row) (err error) {
 }
 
 func (e *WindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
+	if e.chunks.needSpill() {
+		if err = e.spill(); err != nil {
+			return false, err
+		}
+	}
 	childResult := newFirstChunk(e.children[0])
 	err = Next(ctx, e.children[0], childResult)
 	if err != nil {
@@ -163,10 +231,16 @@ func (e *WindowExec) fetchChild(ctx context.Context) (EOF bool, err error) {
 	}
 
 	resultChk := chunk.New(e.retFieldTypes, 0, numRows)
-	err = e.copyChk(childResult, resultChk)
-	if err != nil {
+	if err = e.chunks.add(childResult); err != nil {
 		return false, err
 	}
+	// The columns of the child chunk are referred when the result chunk is returned if it is spilled.
+	if !e.chunks.spilled {
+		err = e.copyChk(childResult, resultChk)
+		if err != nil {
+			return false, err
+		}
+	}
 	e.resultChunks = append(e.resultChunks, resultChk)
 	e.remainingRowsInChunk = append(e.remainingRowsInChunk, numRows)
 
@@ -184,14 +258,78 @@ func (e *WindowExec) copyChk(src, dst *chunk.Chunk) error {
 	return nil
 }
 
+// spill spills the buffered child chunks to disk, and releases the references to
+// them from the result chunks.
+func (e *WindowExec) spill() (err error) {
+	if err = e.chunks.spill(); err != nil {
+		return err
+	}
+	for i, chk := range e.resultChunks {
+		if e.resultChunks[i], err = detachChildColumns(chk, e.retFieldTypes, e.numWindowFuncs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// windowRowsBatchSize is the number of rows read at a time by aggWindowProcessor.
+const windowRowsBatchSize = 1024
+
+// windowRows gives the window processors access to the rows of a partition.
+type windowRows interface {
+	numRows() uint64
+	getRow(i uint64) chunk.Row
+	getRows(start, end uint64) []chunk.Row
+}
+
+// memRows are the rows of a partition which are kept in memory.
+type memRows []chunk.Row
+
+func (r memRows) numRows() uint64 {
+	return uint64(len(r))
+}
+
+func (r memRows) getRow(i uint64) chunk.Row {
+	return r[i]
+}
+
+func (r memRows) getRows(start, end uint64) []chunk.Row {
+	return r[start:end]
+}
+
+// spilledRows are the rows of a partition which are spilled to disk, they are
+// read from windowChunkBuffer when accessed.
+type spilledRows struct {
+	chunks *windowChunkBuffer
+	// offset is the offset of the first row of the partition in chunks.
+	offset uint64
+	n      uint64
+}
+
+func (r *spilledRows) numRows() uint64 {
+	return r.n
+}
+
+func (r *spilledRows) getRow(i uint64) chunk.Row {
+	return r.chunks.getRow(r.offset + i)
+}
+
+func (r *spilledRows) getRows(start, end uint64) []chunk.Row {
+	rows := make([]chunk.Row, 0, end-start)
+	for i := start; i < end; i++ {
+		rows = append(rows, r.getRow(i))
+	}
+	return rows
+}
+
 // windowProcessor is the interface for processing different kinds of windows.
 type windowProcessor interface {
 	// consumeGroupRows updates the result for an window function using the input rows
 	// which belong to the same partition.
-	consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error)
+	consumeGroupRows(ctx sessionctx.Context, rows windowRows) (windowRows, error)
 	// appendResult2Chunk appends the final results to chunk.
 	// It is called when there are no more rows in current partition.
-	appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error)
+	appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) (windowRows, error)
 	// resetPartialResult resets the partial result to the original state for a specific window function.
 	resetPartialResult()
 }
@@ -201,19 +339,23 @@ type aggWindowProcessor struct {
 	partialResults []aggfuncs.PartialResult
 }
 
-func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
-	for i, windowFunc := range p.windowFuncs {
-		// @todo Add memory trace
-		_, err := windowFunc.UpdatePartialResult(ctx, rows, p.partialResults[i])
-		if err != nil {
-			return nil, err
+func (p *aggWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows windowRows) (windowRows, error) {
+	numRows := rows.numRows()
+	// The rows are consumed in batches, so that the spilled rows are not read into memory at once.
+	for start := uint64(0); start < numRows; start += windowRowsBatchSize {
+		rowsInBatch := rows.getRows(start, mathutil.MinUint64(start+windowRowsBatchSize, numRows))
+		for i, windowFunc := range p.windowFuncs {
+			// @todo Add memory trace
+			_, err := windowFunc.UpdatePartialResult(ctx, rowsInBatch, p.partialResults[i])
+			if err != nil {
+				return nil, err
+			}
 		}
 	}
-	rows = rows[:0]
-	return rows, nil
+	return memRows(nil), nil
 }
 
-func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *aggWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) (windowRows, error) {
 	for remained > 0 {
 		for i, windowFunc := range p.windowFuncs {
 			// TODO: We can extend the agg func interface to avoid the `for` loop  here.
@@ -287,12 +429,12 @@ func (p *rowFrameWindowProcessor) getEndOffset(numRows uint64) uint64 {
 	return 0
 }
 
-func (p *rowFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+func (p *rowFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows windowRows) (windowRows, error) {
 	return rows, nil
 }
 
-func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
-	numRows := uint64(len(rows))
+func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) (windowRows, error) {
+	numRows := rows.numRows()
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -320,9 +462,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx, rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
 						return nil, err
 					}
@@ -338,9 +478,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slidingWindowAggFunc.Slide(ctx, rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				// For MinMaxSlidingWindowAggFuncs, it needs the absolute value of each start of window, to compare
 				// whether elements inside deque are out of current window.
@@ -348,7 +486,7 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 					// Store start inside MaxMinSlidingWindowAggFunc.windowInfo
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+				_, err = windowFunc.UpdatePartialResult(ctx, rows.getRows(start, end), p.partialResults[i])
 			}
 			if err != nil {
 				return nil, err
@@ -388,16 +526,16 @@ type rangeFrameWindowProcessor struct {
 	expectedCmpResult int64
 }
 
-func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
+func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows windowRows) (uint64, error) {
 	if p.start.UnBounded {
 		return 0, nil
 	}
-	numRows := uint64(len(rows))
+	numRows := rows.numRows()
 	for ; p.lastStartOffset < numRows; p.lastStartOffset++ {
 		var res int64
 		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.start.CmpFuncs[i](ctx, p.orderByCols[i], p.start.CalcFuncs[i], rows[p.lastStartOffset], rows[p.curRowIdx])
+			res, _, err = p.start.CmpFuncs[i](ctx, p.orderByCols[i], p.start.CalcFuncs[i], rows.getRow(p.lastStartOffset), rows.getRow(p.curRowIdx))
 			if err != nil {
 				return 0, err
 			}
@@ -414,8 +552,8 @@ func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows
 	return p.lastStartOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
-	numRows := uint64(len(rows))
+func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows windowRows) (uint64, error) {
+	numRows := rows.numRows()
 	if p.end.UnBounded {
 		return numRows, nil
 	}
@@ -423,7 +561,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 		var res int64
 		var err error
 		for i := range p.orderByCols {
-			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.orderByCols[i], rows[p.curRowIdx], rows[p.lastEndOffset])
+			res, _, err = p.end.CmpFuncs[i](ctx, p.end.CalcFuncs[i], p.orderByCols[i], rows.getRow(p.curRowIdx), rows.getRow(p.lastEndOffset))
 			if err != nil {
 				return 0, err
 			}
@@ -440,7 +578,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 	return p.lastEndOffset, nil
 }
 
-func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) (windowRows, error) {
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -474,9 +612,7 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 			for i, windowFunc := range p.windowFuncs {
 				slidingWindowAggFunc := slidingWindowAggFuncs[i]
 				if slidingWindowAggFunc != nil && initializedSlidingWindow {
-					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-						return rows[u]
-					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					err = slidingWindowAggFunc.Slide(ctx, rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 					if err != nil {
 						return nil, err
 					}
@@ -492,14 +628,12 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		for i, windowFunc := range p.windowFuncs {
 			slidingWindowAggFunc := slidingWindowAggFuncs[i]
 			if slidingWindowAggFunc != nil && initializedSlidingWindow {
-				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
-					return rows[u]
-				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+				err = slidingWindowAggFunc.Slide(ctx, rows.getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
 			} else {
 				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
 					minMaxSlidingWindowAggFunc.SetWindowStart(start)
 				}
-				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+				_, err = windowFunc.UpdatePartialResult(ctx, rows.getRows(start, end), p.partialResults[i])
 			}
 			if err != nil {
 				return nil, err
@@ -522,7 +656,7 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 	return rows, nil
 }
 
-func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+func (p *rangeFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows windowRows) (windowRows, error) {
 	return rows, nil
 }
 
@@ -531,3 +665,431 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
 }
+
+// peerGroups records where the peer groups of a partition start. Rows which
+// are equal on the order by items are peers and belong to the same group,
+// the bounds of `GROUPS` frames are counted in peer groups.
+type peerGroups struct {
+	colIdx   []int
+	cmpFuncs []chunk.CompareFunc
+	// starts[i] is the offset of the first row of the (base+i)-th peer group.
+	starts []uint64
+	// base is the number of peer groups which have been dropped from starts.
+	base uint64
+	// scanned is the number of rows which have been assigned to peer groups.
+	scanned uint64
+	// curGroup is the peer group of the current row.
+	curGroup uint64
+}
+
+func newPeerGroups(orderByCols []*expression.Column) *peerGroups {
+	g := &peerGroups{
+		colIdx:   make([]int, 0, len(orderByCols)),
+		cmpFuncs: make([]chunk.CompareFunc, 0, len(orderByCols)),
+	}
+	for _, col := range orderByCols {
+		cmpFunc := chunk.GetCompareFunc(col.RetType)
+		if cmpFunc == nil {
+			continue
+		}
+		g.cmpFuncs = append(g.cmpFuncs, cmpFunc)
+		g.colIdx = append(g.colIdx, col.Index)
+	}
+	return g
+}
+
+func (g *peerGroups) isPeer(prev, curr chunk.Row) bool {
+	for i, idx := range g.colIdx {
+		if g.cmpFuncs[i](prev, idx, curr, idx) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// update assigns the rows in [g.scanned, numRows) to peer groups, and moves
+// g.curGroup to the group which the current row belongs to.
+func (g *peerGroups) update(getRow func(uint64) chunk.Row, numRows, curRowIdx uint64) {
+	for ; g.scanned < numRows; g.scanned++ {
+		if g.scanned == 0 || !g.isPeer(getRow(g.scanned-1), getRow(g.scanned)) {
+			g.starts = append(g.starts, g.scanned)
+		}
+	}
+	for g.curGroup+1 < g.base+uint64(len(g.starts)) && g.starts[g.curGroup+1-g.base] <= curRowIdx {
+		g.curGroup++
+	}
+}
+
+// groupStart returns the offset of the first row of the k-th peer group,
+// numRows is returned if the group has not been seen yet.
+func (g *peerGroups) groupStart(k, numRows uint64) uint64 {
+	if k-g.base >= uint64(len(g.starts)) {
+		return numRows
+	}
+	return g.starts[k-g.base]
+}
+
+func (g *peerGroups) getStart(bound *core.FrameBound, numRows uint64) uint64 {
+	switch bound.Type {
+	case ast.Preceding:
+		if g.curGroup >= bound.Num {
+			return g.groupStart(g.curGroup-bound.Num, numRows)
+		}
+		return 0
+	case ast.Following:
+		return g.groupStart(g.curGroup+bound.Num, numRows)
+	default: // ast.CurrentRow
+		return g.groupStart(g.curGroup, numRows)
+	}
+}
+
+func (g *peerGroups) getEnd(bound *core.FrameBound, numRows uint64) uint64 {
+	switch bound.Type {
+	case ast.Preceding:
+		if g.curGroup >= bound.Num {
+			return g.groupStart(g.curGroup-bound.Num+1, numRows)
+		}
+		return 0
+	case ast.Following:
+		return g.groupStart(g.curGroup+bound.Num+1, numRows)
+	default: // ast.CurrentRow
+		return g.groupStart(g.curGroup+1, numRows)
+	}
+}
+
+// shrink drops the peer groups which end before the row at offset `rowStart`.
+func (g *peerGroups) shrink(rowStart uint64) {
+	i := 0
+	for i+1 < len(g.starts) && g.starts[i+1] <= rowStart && g.base+uint64(i) < g.curGroup {
+		i++
+	}
+	g.starts = g.starts[i:]
+	g.base += uint64(i)
+}
+
+func (g *peerGroups) reset() {
+	g.starts = g.starts[:0]
+	g.base = 0
+	g.scanned = 0
+	g.curGroup = 0
+}
+
+type groupFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *core.FrameBound
+	end            *core.FrameBound
+	curRowIdx      uint64
+	groups         *peerGroups
+}
+
+func (p *groupFrameWindowProcessor) getStartOffset(rows windowRows) uint64 {
+	if p.start.UnBounded {
+		return 0
+	}
+	return p.groups.getStart(p.start, rows.numRows())
+}
+
+func (p *groupFrameWindowProcessor) getEndOffset(rows windowRows) uint64 {
+	if p.end.UnBounded {
+		return rows.numRows()
+	}
+	return p.groups.getEnd(p.end, rows.numRows())
+}
+
+func (p *groupFrameWindowProcessor) consumeGroupRows(ctx sessionctx.Context, rows windowRows) (windowRows, error) {
+	return rows, nil
+}
+
+func (p *groupFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows windowRows, chk *chunk.Chunk, remained int) (windowRows, error) {
+	var (
+		err                      error
+		initializedSlidingWindow bool
+		start                    uint64
+		end                      uint64
+		lastStart                uint64
+		lastEnd                  uint64
+		shiftStart               uint64
+		shiftEnd                 uint64
+	)
+	getRow := rows.getRow
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
+	for i, windowFunc := range p.windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	for ; remained > 0; lastStart, lastEnd = start, end {
+		p.groups.update(getRow, rows.numRows(), p.curRowIdx)
+		start = p.getStartOffset(rows)
+		end = p.getEndOffset(rows)
+		p.curRowIdx++
+		remained--
+		shiftStart = start - lastStart
+		shiftEnd = end - lastEnd
+		if start >= end {
+			for i, windowFunc := range p.windowFuncs {
+				slidingWindowAggFunc := slidingWindowAggFuncs[i]
+				if slidingWindowAggFunc != nil && initializedSlidingWindow {
+					err = slidingWindowAggFunc.Slide(ctx, getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		for i, windowFunc := range p.windowFuncs {
+			slidingWindowAggFunc := slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx, getRow, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+			} else {
+				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				}
+				_, err = windowFunc.UpdatePartialResult(ctx, rows.getRows(start, end), p.partialResults[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+			if err != nil {
+				return nil, err
+			}
+			if slidingWindowAggFunc == nil {
+				windowFunc.ResetPartialResult(p.partialResults[i])
+			}
+		}
+		if !initializedSlidingWindow {
+			initializedSlidingWindow = true
+		}
+	}
+	for i, windowFunc := range p.windowFuncs {
+		windowFunc.ResetPartialResult(p.partialResults[i])
+	}
+	return rows, nil
+}
+
+func (p *groupFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.groups.reset()
+}
+
+// maxCachedSpilledChunks is the number of chunks cached by windowChunkBuffer after
+// they are read from disk.
+const maxCachedSpilledChunks = 4
+
+// windowChunkBuffer keeps the child chunks of a window executor until the rows in
+// them are returned. The rows are addressed by their offsets among all the rows
+// fetched from the child. After the executor is set to `spill mode` by
+// WindowSpillDiskAction, the buffered chunks and the ones added later are kept in
+// disk instead.
+type windowChunkBuffer struct {
+	fieldTypes []*types.FieldType
+	chunks     []bufferedChunk
+	// numRows is the number of rows added to the buffer.
+	numRows uint64
+
+	memTracker  *memory.Tracker
+	diskTracker *disk.Tracker
+	listInDisk  *chunk.ListInDisk
+	// inSpillMode is set by WindowSpillDiskAction, the buffer is spilled before the
+	// executor fetches the next chunk.
+	inSpillMode uint32
+	// spilled indicates the chunks are kept in disk.
+	spilled bool
+	// cache keeps the chunks read from disk recently.
+	cache []bufferedChunk
+	// err is the error met when reading rows from disk by getRow.
+	err error
+}
+
+type bufferedChunk struct {
+	// chk is nil if the chunk is spilled.
+	chk      *chunk.Chunk
+	diskIdx  int
+	start    uint64
+	end      uint64
+	memUsage int64
+}
+
+// newWindowChunkBuffer creates the windowChunkBuffer for a window executor, and
+// registers WindowSpillDiskAction if the rows can be spilled.
+func newWindowChunkBuffer(e *baseExecutor) *windowChunkBuffer {
+	stmtCtx := e.ctx.GetSessionVars().StmtCtx
+	b := &windowChunkBuffer{
+		fieldTypes: retTypes(e.children[0]),
+		memTracker: memory.NewTracker(e.id, -1),
+	}
+	b.memTracker.AttachTo(stmtCtx.MemTracker)
+	if config.GetGlobalConfig().OOMUseTmpStorage {
+		b.diskTracker = disk.NewTracker(e.id, -1)
+		b.diskTracker.AttachTo(stmtCtx.DiskTracker)
+		stmtCtx.MemTracker.FallbackOldAndSetNewActionForSoftLimit(&WindowSpillDiskAction{chunks: b})
+	}
+	return b
+}
+
+func (b *windowChunkBuffer) needSpill() bool {
+	return !b.spilled && atomic.LoadUint32(&b.inSpillMode) == 1
+}
+
+// add appends a child chunk to the buffer.
+func (b *windowChunkBuffer) add(chk *chunk.Chunk) error {
+	c := bufferedChunk{start: b.numRows, end: b.numRows + uint64(chk.NumRows())}
+	if b.spilled {
+		diskIdx, err := b.addToDisk(chk)
+		if err != nil {
+			return err
+		}
+		c.diskIdx = diskIdx
+	} else {
+		c.chk, c.memUsage = chk, chk.MemoryUsage()
+		b.memTracker.Consume(c.memUsage)
+	}
+	b.chunks = append(b.chunks, c)
+	b.numRows = c.end
+	return nil
+}
+
+func (b *windowChunkBuffer) addToDisk(chk *chunk.Chunk) (int, error) {
+	if b.listInDisk == nil {
+		b.listInDisk = chunk.NewListInDisk(b.fieldTypes)
+		b.listInDisk.GetDiskTracker().AttachTo(b.diskTracker)
+	}
+	if err := b.listInDisk.Add(chk); err != nil {
+		return 0, err
+	}
+	return b.listInDisk.NumChunks() - 1, nil
+}
+
+// spill writes the buffered chunks to disk.
+func (b *windowChunkBuffer) spill() error {
+	logutil.BgLogger().Info("window executor spills the buffered chunks to disk",
+		zap.Int("chunks", len(b.chunks)), zap.Int64("consumed", b.memTracker.BytesConsumed()))
+	for i := range b.chunks {
+		c := &b.chunks[i]
+		diskIdx, err := b.addToDisk(c.chk)
+		if err != nil {
+			return err
+		}
+		b.memTracker.Consume(-c.memUsage)
+		c.chk, c.diskIdx, c.memUsage = nil, diskIdx, 0
+	}
+	b.spilled = true
+	return nil
+}
+
+// popFront removes the first chunk from the buffer. If the chunk is spilled, it is
+// read from disk and returned, otherwise nil is returned.
+func (b *windowChunkBuffer) popFront() (chk *chunk.Chunk, err error) {
+	c := b.chunks[0]
+	b.chunks = b.chunks[1:]
+	if c.chk != nil {
+		b.memTracker.Consume(-c.memUsage)
+		return nil, nil
+	}
+	chk, err = b.readChunk(c.diskIdx)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.chunks) == 0 {
+		// All the spilled chunks are returned, the disk file can be removed.
+		b.cache = b.cache[:0]
+		err = b.listInDisk.Close()
+		b.listInDisk = nil
+	}
+	return chk, err
+}
+
+// getRow returns the row at offset. If an error is met when reading the row from
+// disk, it is recorded in b.err and a row of zero values is returned.
+func (b *windowChunkBuffer) getRow(offset uint64) chunk.Row {
+	i := sort.Search(len(b.chunks), func(i int) bool {
+		return b.chunks[i].end > offset
+	})
+	c := &b.chunks[i]
+	if c.chk != nil {
+		return c.chk.GetRow(int(offset - c.start))
+	}
+	chk, err := b.readChunk(c.diskIdx)
+	if err != nil {
+		b.err = err
+		return chunk.MutRowFromTypes(b.fieldTypes).ToRow()
+	}
+	return chk.GetRow(int(offset - c.start))
+}
+
+func (b *windowChunkBuffer) readChunk(diskIdx int) (*chunk.Chunk, error) {
+	for _, c := range b.cache {
+		if c.diskIdx == diskIdx {
+			return c.chk, nil
+		}
+	}
+	chk, err := b.listInDisk.GetChunk(diskIdx)
+	if err != nil {
+		return nil, err
+	}
+	if len(b.cache) == maxCachedSpilledChunks {
+		b.cache = append(b.cache[:0], b.cache[1:]...)
+	}
+	b.cache = append(b.cache, bufferedChunk{chk: chk, diskIdx: diskIdx})
+	return chk, nil
+}
+
+func (b *windowChunkBuffer) close() error {
+	b.memTracker.Consume(-b.memTracker.BytesConsumed())
+	b.chunks, b.cache = nil, nil
+	if b.listInDisk != nil {
+		return b.listInDisk.Close()
+	}
+	return nil
+}
+
+// detachChildColumns returns a chunk which shares the columns of window functions
+// with chk, the other columns referring to the child chunk are left empty.
+func detachChildColumns(chk *chunk.Chunk, fieldTypes []*types.FieldType, numWindowFuncs int) (*chunk.Chunk, error) {
+	newChk := chunk.New(fieldTypes, 0, chk.RequiredRows())
+	for i := len(fieldTypes) - numWindowFuncs; i < len(fieldTypes); i++ {
+		if err := newChk.MakeRefTo(i, chk, i); err != nil {
+			return nil, err
+		}
+	}
+	return newChk, nil
+}
+
+// WindowSpillDiskAction implements memory.ActionOnExceed for the window executors.
+// If the memory quota of a query is exceeded, WindowSpillDiskAction.Action is
+// triggered, and the executor spills its buffered chunks before fetching the next
+// chunk from its child.
+type WindowSpillDiskAction struct {
+	memory.BaseOOMAction
+	chunks *windowChunkBuffer
+}
+
+// Action sets the window executor to spill mode.
+func (a *WindowSpillDiskAction) Action(t *memory.Tracker) {
+	// Guarantee that the buffered data is at least 20% of the threshold, otherwise spilling makes little sense.
+	if a.chunks.memTracker.BytesConsumed() >= t.GetBytesLimit()/5 && atomic.CompareAndSwapUint32(&a.chunks.inSpillMode, 0, 1) {
+		logutil.BgLogger().Info("memory exceeds quota, set window to spill-mode",
+			zap.Int64("consumed", t.BytesConsumed()),
+			zap.Int64("quota", t.GetBytesLimit()))
+		return
+	}
+	if fallback := a.GetFallback(); fallback != nil {
+		fallback.Action(t)
+	}
+}
+
+// GetPriority get the priority of the Action
+func (a *WindowSpillDiskAction) GetPriority() int64 {
+	return memory.DefSpillPriority
+}
+
+// SetLogHook sets the hook, it does nothing just to form the memory.ActionOnExceed interface.
+func (a *WindowSpillDiskAction) SetLogHook(hook func(uint64)) {}
@@ -16,6 +16,7 @@ package executor_test
 
 import (
 	"fmt"
+	"strings"
 
 	. "github.com/pingcap/check"
 	"github.com/pingcap/tidb/util/testkit"
@@ -219,6 +220,63 @@ func doTestWindowFunctions(tk *testkit.TestKit) {
 	result.Check(testkit.Rows("1 1", "1 2", "2 1", "2 2"))
 }
 
+func (s *testSuite7) TestWindowFunctionsFrameGroupsAndModifiers(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t (id int, g int, v int)")
+	tk.MustExec("insert into t values (1,1,null),(2,1,10),(3,2,20),(4,2,null),(5,3,30),(6,4,10),(7,4,40)")
+	defer func() {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function=1;")
+	}()
+	maxChunkSize := tk.Se.GetSessionVars().MaxChunkSize
+	defer func() {
+		tk.Se.GetSessionVars().MaxChunkSize = maxChunkSize
+	}()
+	for _, pipelined := range []int{0, 1} {
+		for _, chunkSize := range []int{2, maxChunkSize} {
+			tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function=%d", pipelined))
+			tk.Se.GetSessionVars().MaxChunkSize = chunkSize
+
+			// GROUPS frames count the peer groups decided by the order by items.
+			tk.MustQuery("select id, sum(v) over (order by g groups between 1 preceding and current row) from t order by id").Check(
+				testkit.Rows("1 10", "2 10", "3 30", "4 30", "5 50", "6 80", "7 80"))
+			tk.MustQuery("select id, count(*) over (order by g groups between current row and 1 following) from t order by id").Check(
+				testkit.Rows("1 4", "2 4", "3 3", "4 3", "5 3", "6 2", "7 2"))
+			tk.MustQuery("select id, count(*) over (order by g groups between 2 following and unbounded following) from t order by id").Check(
+				testkit.Rows("1 3", "2 3", "3 2", "4 2", "5 0", "6 0", "7 0"))
+			tk.MustQuery("select id, count(*) over (groups between current row and current row) from t order by id").Check(
+				testkit.Rows("1 7", "2 7", "3 7", "4 7", "5 7", "6 7", "7 7"))
+
+			// IGNORE NULLS and FROM LAST.
+			tk.MustQuery("select id, first_value(v) ignore nulls over (order by id rows between unbounded preceding and current row) from t order by id").Check(
+				testkit.Rows("1 <nil>", "2 10", "3 10", "4 10", "5 10", "6 10", "7 10"))
+			tk.MustQuery("select id, last_value(v) ignore nulls over (order by id rows between current row and 1 following) from t order by id").Check(
+				testkit.Rows("1 10", "2 20", "3 20", "4 30", "5 10", "6 40", "7 40"))
+			tk.MustQuery("select id, nth_value(v, 2) from last over (order by id rows between unbounded preceding and unbounded following) from t order by id").Check(
+				testkit.Rows("1 10", "2 10", "3 10", "4 10", "5 10", "6 10", "7 10"))
+			tk.MustQuery("select id, nth_value(v, 2) from last ignore nulls over (order by id rows between 2 preceding and current row) from t order by id").Check(
+				testkit.Rows("1 <nil>", "2 <nil>", "3 10", "4 10", "5 20", "6 30", "7 10"))
+			tk.MustQuery("select id, nth_value(v, 2) ignore nulls over (order by id) from t order by id").Check(
+				testkit.Rows("1 <nil>", "2 <nil>", "3 20", "4 20", "5 20", "6 20", "7 20"))
+			tk.MustQuery("select id, lead(v) ignore nulls over (order by id), lag(v, 2, -1) ignore nulls over (order by id) from t order by id").Check(
+				testkit.Rows("1 10 -1", "2 20 -1", "3 30 -1", "4 30 10", "5 10 10", "6 40 20", "7 <nil> 30"))
+
+			// Aggregate functions with DISTINCT.
+			tk.MustQuery("select id, count(distinct v) over w, sum(distinct v) over w from t window w as (order by id rows between 4 preceding and current row) order by id").Check(
+				testkit.Rows("1 0 <nil>", "2 1 10", "3 2 30", "4 2 30", "5 3 60", "6 3 60", "7 4 100"))
+			tk.MustQuery("select id, count(distinct v) over (partition by g), count(distinct g, v) over () from t order by id").Check(
+				testkit.Rows("1 1 5", "2 1 5", "3 1 5", "4 1 5", "5 1 5", "6 2 5", "7 2 5"))
+
+			// GROUP_CONCAT as window function.
+			tk.MustQuery("select group_concat(distinct g) over (), group_concat(distinct g separator ';') over () from t limit 1").Check(
+				testkit.Rows("1,2,3,4 1;2;3;4"))
+			tk.MustQuery("select id, group_concat(v) over (order by id rows between 1 preceding and current row) from t order by id").Check(
+				testkit.Rows("1 <nil>", "2 10", "3 10,20", "4 20", "5 30", "6 30,10", "7 10,40"))
+		}
+	}
+}
+
 func (s *testSuite7) TestWindowFunctionsDataReference(c *C) {
 	// see https://github.com/pingcap/tidb/issues/11614
 	tk := testkit.NewTestKit(c, s.store)
@@ -471,3 +529,40 @@ func (s *testSuite7) TestIssue24264(c *C) {
 		"8297270320597030697",
 		"<nil>"))
 }
+
+func (s *testSerialSuite) TestWindowInDisk(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int primary key, b varchar(64))")
+	sql := "insert into t values (0, repeat('a', 64))"
+	for i := 1; i < 1000; i++ {
+		sql += fmt.Sprintf(",(%v, repeat('a', 64))", i)
+	}
+	tk.MustExec(sql)
+	for i := 0; i < 4; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t select a + %v, b from t", 1000<<i))
+	}
+	tk.MustExec("set @@tidb_window_concurrency = 1")
+	tk.MustExec("set tidb_mem_quota_query = 1048576")
+	defer tk.MustExec("set @@tidb_enable_pipelined_window_function = 1")
+	for _, pipelined := range []int{0, 1} {
+		tk.MustExec(fmt.Sprintf("set @@tidb_enable_pipelined_window_function = %v", pipelined))
+		rows := tk.MustQuery("desc analyze select a, b, count(*) over (order by a rows between unbounded preceding and unbounded following) from t").Rows()
+		for _, row := range rows {
+			length := len(row)
+			line := fmt.Sprintf("%v", row)
+			disk := fmt.Sprintf("%v", row[length-1])
+			if strings.Contains(line, "Window") {
+				c.Assert(strings.Contains(disk, "0 Bytes"), IsFalse)
+				c.Assert(strings.Contains(disk, "MB") ||
+					strings.Contains(disk, "KB") ||
+					strings.Contains(disk, "Bytes"), IsTrue)
+			}
+		}
+		tk.MustQuery("select count(*), sum(c), sum(a), count(distinct b) from (select a, b, count(*) over (order by a rows between unbounded preceding and unbounded following) as c from t) tt").Check(
+			testkit.Rows("16000 256000000 127992000 1"))
+		tk.MustQuery("select count(*), sum(s) from (select sum(a) over (order by a rows between 1 preceding and 1 following) as s from t) tt").Check(
+			testkit.Rows("16000 383960001"))
+	}
+}
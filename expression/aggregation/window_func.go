@@ -15,6 +15,7 @@
 package aggregation
 
 import (
+	"bytes"
 	"strings"
 
 	"github.com/pingcap/tidb/expression"
@@ -25,6 +26,12 @@ import (
 // WindowFuncDesc describes a window function signature, only used in planner.
 type WindowFuncDesc struct {
 	baseFuncDesc
+	// HasDistinct indicates whether the aggregate function only aggregates distinct values, e.g. `count(distinct a) over w`.
+	HasDistinct bool
+	// IgnoreNull indicates whether the NULL values are skipped, it's specified by `IGNORE NULLS`.
+	IgnoreNull bool
+	// FromLast indicates whether `nth_value` counts the rows from the last row of the frame.
+	FromLast bool
 }
 
 // NewWindowFuncDesc creates a window function signature descriptor.
@@ -55,7 +62,30 @@ func NewWindowFuncDesc(ctx sessionctx.Context, name string, args []expression.Ex
 	if err != nil {
 		return nil, err
 	}
-	return &WindowFuncDesc{base}, nil
+	return &WindowFuncDesc{baseFuncDesc: base}, nil
+}
+
+// String implements the fmt.Stringer interface.
+func (a *WindowFuncDesc) String() string {
+	buffer := bytes.NewBufferString(a.Name)
+	buffer.WriteString("(")
+	if a.HasDistinct {
+		buffer.WriteString("distinct ")
+	}
+	for i, arg := range a.Args {
+		buffer.WriteString(arg.String())
+		if i+1 != len(a.Args) {
+			buffer.WriteString(", ")
+		}
+	}
+	buffer.WriteString(")")
+	if a.FromLast {
+		buffer.WriteString(" from last")
+	}
+	if a.IgnoreNull {
+		buffer.WriteString(" ignore nulls")
+	}
+	return buffer.String()
 }
 
 // noFrameWindowFuncs is the functions that operate on the entire partition,
@@ -357,6 +357,10 @@ func (b *baseBuiltinFunc) isChildrenVectorized() bool {
 func (b *baseBuiltinFunc) getRetTp() *types.FieldType {
 	switch b.tp.EvalType() {
 	case types.ETString:
+		if b.tp.Tp == mysql.TypeGeometry {
+			// The geometries are as long as the LONGBLOB, but they keep their own type.
+			break
+		}
 		if b.tp.Flen >= mysql.MaxBlobWidth {
 			b.tp.Tp = mysql.TypeLongBlob
 		} else if b.tp.Flen >= 65536 {
@@ -386,6 +390,10 @@ func (b *baseBuiltinFunc) getCtx() sessionctx.Context {
 	return b.ctx
 }
 
+func (b *baseBuiltinFunc) setCtx(ctx sessionctx.Context) {
+	b.ctx = ctx
+}
+
 func (b *baseBuiltinFunc) cloneFrom(from *baseBuiltinFunc) {
 	b.args = make([]Expression, 0, len(b.args))
 	for _, arg := range from.args {
@@ -499,6 +507,8 @@ type builtinFunc interface {
 	equal(builtinFunc) bool
 	// getCtx returns this function's context.
 	getCtx() sessionctx.Context
+	// setCtx sets this function's context.
+	setCtx(ctx sessionctx.Context)
 	// getRetTp returns the return type of the built-in function.
 	getRetTp() *types.FieldType
 	// setPbCode sets pbCode for signature.
@@ -730,6 +740,12 @@ var funcs = map[string]functionClass{
 	ast.Unhex:           &unhexFunctionClass{baseFunctionClass{ast.Unhex, 1, 1}},
 	ast.WeightString:    &weightStringFunctionClass{baseFunctionClass{ast.WeightString, 1, 3}},
 
+	// regexp functions
+	ast.RegexpLike:    &regexpLikeFunctionClass{baseFunctionClass{ast.RegexpLike, 2, 3}},
+	ast.RegexpSubstr:  &regexpSubstrFunctionClass{baseFunctionClass{ast.RegexpSubstr, 2, 5}},
+	ast.RegexpInStr:   &regexpInStrFunctionClass{baseFunctionClass{ast.RegexpInStr, 2, 6}},
+	ast.RegexpReplace: &regexpReplaceFunctionClass{baseFunctionClass{ast.RegexpReplace, 3, 6}},
+
 	// information functions
 	ast.ConnectionID: &connectionIDFunctionClass{baseFunctionClass{ast.ConnectionID, 0, 0}},
 	ast.CurrentUser:  &currentUserFunctionClass{baseFunctionClass{ast.CurrentUser, 0, 0}},
@@ -821,6 +837,7 @@ var funcs = map[string]functionClass{
 	ast.SetVar:             &setVarFunctionClass{baseFunctionClass{ast.SetVar, 2, 2}},
 	ast.BitCount:           &bitCountFunctionClass{baseFunctionClass{ast.BitCount, 1, 1}},
 	ast.GetParam:           &getParamFunctionClass{baseFunctionClass{ast.GetParam, 1, 1}},
+	ast.MatchAgainstFunc:   &matchAgainstFunctionClass{baseFunctionClass{ast.MatchAgainstFunc, 4, -1}},
 
 	// encryption and compression functions
 	ast.AesDecrypt:               &aesDecryptFunctionClass{baseFunctionClass{ast.AesDecrypt, 2, 3}},
@@ -842,6 +859,23 @@ var funcs = map[string]functionClass{
 	ast.UncompressedLength:       &uncompressedLengthFunctionClass{baseFunctionClass{ast.UncompressedLength, 1, 1}},
 	ast.ValidatePasswordStrength: &validatePasswordStrengthFunctionClass{baseFunctionClass{ast.ValidatePasswordStrength, 1, 1}},
 
+	// spatial functions
+	ast.StGeomFromText:     &stGeomFromTextFunctionClass{baseFunctionClass{ast.StGeomFromText, 1, 2}},
+	ast.StGeometryFromText: &stGeomFromTextFunctionClass{baseFunctionClass{ast.StGeometryFromText, 1, 2}},
+	ast.StGeomFromWKB:      &stGeomFromWKBFunctionClass{baseFunctionClass{ast.StGeomFromWKB, 1, 2}},
+	ast.StGeometryFromWKB:  &stGeomFromWKBFunctionClass{baseFunctionClass{ast.StGeometryFromWKB, 1, 2}},
+	ast.StAsText:           &stAsTextFunctionClass{baseFunctionClass{ast.StAsText, 1, 1}},
+	ast.StAsWKT:            &stAsTextFunctionClass{baseFunctionClass{ast.StAsWKT, 1, 1}},
+	ast.StAsBinary:         &stAsBinaryFunctionClass{baseFunctionClass{ast.StAsBinary, 1, 1}},
+	ast.StAsWKB:            &stAsBinaryFunctionClass{baseFunctionClass{ast.StAsWKB, 1, 1}},
+	ast.StX:                &stCoordinateFunctionClass{baseFunctionClass{ast.StX, 1, 1}},
+	ast.StY:                &stCoordinateFunctionClass{baseFunctionClass{ast.StY, 1, 1}},
+	ast.StSRID:             &stSRIDFunctionClass{baseFunctionClass{ast.StSRID, 1, 1}},
+	ast.StDistance:         &stDistanceFunctionClass{baseFunctionClass{ast.StDistance, 2, 2}},
+	ast.StContains:         &stRelationFunctionClass{baseFunctionClass{ast.StContains, 2, 2}},
+	ast.StIntersects:       &stRelationFunctionClass{baseFunctionClass{ast.StIntersects, 2, 2}},
+	ast.StWithin:           &stRelationFunctionClass{baseFunctionClass{ast.StWithin, 2, 2}},
+
 	// json functions
 	ast.JSONType:          &jsonTypeFunctionClass{baseFunctionClass{ast.JSONType, 1, 1}},
 	ast.JSONExtract:       &jsonExtractFunctionClass{baseFunctionClass{ast.JSONExtract, 2, -1}},
@@ -867,6 +901,13 @@ var funcs = map[string]functionClass{
 	ast.JSONDepth:         &jsonDepthFunctionClass{baseFunctionClass{ast.JSONDepth, 1, 1}},
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
+	ast.JSONMemberOf:      &jsonMemberOfFunctionClass{baseFunctionClass{ast.JSONMemberOf, 2, 2}},
+	ast.JSONOverlaps:      &jsonOverlapsFunctionClass{baseFunctionClass{ast.JSONOverlaps, 2, 2}},
+	ast.JSONStorageFree:   &jsonStorageFreeFunctionClass{baseFunctionClass{ast.JSONStorageFree, 1, 1}},
+	ast.JSONValue:         &jsonValueFunctionClass{baseFunctionClass: baseFunctionClass{ast.JSONValue, 6, 6}},
+
+	ast.JSONSchemaValid:            &jsonSchemaValidFunctionClass{baseFunctionClass{ast.JSONSchemaValid, 2, 2}},
+	ast.JSONSchemaValidationReport: &jsonSchemaValidationReportFunctionClass{baseFunctionClass{ast.JSONSchemaValidationReport, 2, 2}},
 
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
@@ -906,7 +947,7 @@ func GetDisplayName(name string) string {
 func GetBuiltinList() []string {
 	res := make([]string, 0, len(funcs))
 	notImplementedFunctions := []string{ast.RowFunc, ast.IsTruthWithNull}
-	implicitFunctions := []string{InternalFuncToBinary}
+	implicitFunctions := []string{InternalFuncToBinary, ast.MatchAgainstFunc}
 	for funcName := range funcs {
 		skipFunc := false
 		// Skip not implemented functions
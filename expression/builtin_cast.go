@@ -48,6 +48,7 @@ var (
 	_ functionClass = &castAsTimeFunctionClass{}
 	_ functionClass = &castAsDurationFunctionClass{}
 	_ functionClass = &castAsJSONFunctionClass{}
+	_ functionClass = &castAsArrayFunctionClass{}
 )
 
 var (
@@ -106,6 +107,7 @@ var (
 	_ builtinFunc = &builtinCastJSONAsTimeSig{}
 	_ builtinFunc = &builtinCastJSONAsDurationSig{}
 	_ builtinFunc = &builtinCastJSONAsJSONSig{}
+	_ builtinFunc = &builtinCastJSONAsArraySig{}
 )
 
 type castAsIntFunctionClass struct {
@@ -1611,6 +1613,51 @@ func (b *builtinCastDurationAsTimeSig) evalTime(row chunk.Row) (res types.Time,
 	return res, false, err
 }
 
+// castAsArrayFunctionClass is the function class of `CAST(... AS ... ARRAY)`, which is only allowed as the
+// expression of a multi-valued index.
+type castAsArrayFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+func (c *castAsArrayFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (sig builtinFunc, err error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = c.tp
+	sig = &builtinCastJSONAsArraySig{bf}
+	return sig, nil
+}
+
+type builtinCastJSONAsArraySig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinCastJSONAsArraySig) Clone() builtinFunc {
+	newSig := &builtinCastJSONAsArraySig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalJSON returns the JSON array of the document, the document which is not an array is wrapped as the array of
+// one element. The elements are converted to the element type when the array is stored to the hidden column of
+// the multi-valued index, see table.CastValue, so that the errors are reported with the index.
+func (b *builtinCastJSONAsArraySig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	val, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if val.TypeCode != json.TypeCodeArray {
+		val = json.CreateBinaryArray([]json.BinaryJSON{val})
+	}
+	return val, false, nil
+}
+
 type builtinCastJSONAsJSONSig struct {
 	baseBuiltinFunc
 }
@@ -1834,7 +1881,11 @@ func BuildCastFunction(ctx sessionctx.Context, expr Expression, tp *types.FieldT
 	case types.ETDuration:
 		fc = &castAsDurationFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	case types.ETJson:
-		fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		if tp.Array {
+			fc = &castAsArrayFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		} else {
+			fc = &castAsJSONFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
+		}
 	case types.ETString:
 		fc = &castAsStringFunctionClass{baseFunctionClass{ast.Cast, 1, 1}, tp}
 	}
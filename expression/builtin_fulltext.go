@@ -0,0 +1,158 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/fulltext"
+)
+
+var (
+	_ functionClass = &matchAgainstFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinMatchAgainstSig{}
+)
+
+// The leading arguments of the `match_against` function, the columns to match follow them.
+const (
+	matchAgainstModifierArg = iota
+	matchAgainstParserArg
+	matchAgainstQueryArg
+	matchAgainstColumnsArg
+)
+
+// NewMatchAgainst creates the function of the `MATCH (cols) AGAINST (against modifier)` expression.
+// The parser is the full-text parser of the FULLTEXT index on the columns.
+func NewMatchAgainst(ctx sessionctx.Context, modifier ast.FulltextSearchModifier, parser string, against Expression, cols []*Column) (Expression, error) {
+	args := make([]Expression, 0, len(cols)+matchAgainstColumnsArg)
+	args = append(args, &Constant{Value: types.NewIntDatum(int64(modifier)), RetType: types.NewFieldType(mysql.TypeLonglong)})
+	args = append(args, &Constant{Value: types.NewStringDatum(parser), RetType: types.NewFieldType(mysql.TypeVarString)})
+	args = append(args, against)
+	for _, col := range cols {
+		args = append(args, col)
+	}
+	return NewFunction(ctx, ast.MatchAgainstFunc, types.NewFieldType(mysql.TypeDouble), args...)
+}
+
+// MatchAgainstInfo returns the search modifier and the matched columns of the `match_against` function.
+func MatchAgainstInfo(sf *ScalarFunction) (modifier ast.FulltextSearchModifier, against Expression, cols []*Column, ok bool) {
+	if sf.FuncName.L != ast.MatchAgainstFunc {
+		return 0, nil, nil, false
+	}
+	args := sf.GetArgs()
+	modifierArg, ok := args[matchAgainstModifierArg].(*Constant)
+	if !ok {
+		return 0, nil, nil, false
+	}
+	for _, arg := range args[matchAgainstColumnsArg:] {
+		col, ok := arg.(*Column)
+		if !ok {
+			return 0, nil, nil, false
+		}
+		cols = append(cols, col)
+	}
+	return ast.FulltextSearchModifier(modifierArg.Value.GetInt64()), args[matchAgainstQueryArg], cols, true
+}
+
+type matchAgainstFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *matchAgainstFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := make([]types.EvalType, 0, len(args))
+	argTps = append(argTps, types.ETInt)
+	for range args[1:] {
+		argTps = append(argTps, types.ETString)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinMatchAgainstSig{baseBuiltinFunc: bf}
+	return sig, nil
+}
+
+type builtinMatchAgainstSig struct {
+	baseBuiltinFunc
+	// query and isMemorizedQuery are not serialized with builtinMatchAgainstSig, treat them as a cache to
+	// avoid parsing the search string of every row.
+	query            *fulltext.Query
+	isMemorizedQuery bool
+	once             sync.Once
+}
+
+func (b *builtinMatchAgainstSig) Clone() builtinFunc {
+	newSig := &builtinMatchAgainstSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	newSig.query = b.query
+	newSig.isMemorizedQuery = b.isMemorizedQuery
+	return newSig
+}
+
+// evalReal evals the relevance of `MATCH (cols) AGAINST (against modifier)`, which is positive for the matched rows.
+// See https://dev.mysql.com/doc/refman/5.7/en/fulltext-search.html#function_match
+func (b *builtinMatchAgainstSig) evalReal(row chunk.Row) (float64, bool, error) {
+	modifier, _, err := b.args[matchAgainstModifierArg].EvalInt(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	parser, _, err := b.args[matchAgainstParserArg].EvalString(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	against, isNull, err := b.args[matchAgainstQueryArg].EvalString(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull {
+		return 0, false, nil
+	}
+	texts := make([]string, 0, len(b.args)-matchAgainstColumnsArg)
+	for _, arg := range b.args[matchAgainstColumnsArg:] {
+		text, isNull, err := arg.EvalString(b.ctx, row)
+		if err != nil {
+			return 0, true, err
+		}
+		if !isNull {
+			texts = append(texts, text)
+		}
+	}
+	booleanMode := ast.FulltextSearchModifier(modifier).IsBooleanMode()
+	memorization := func() {
+		if b.args[matchAgainstQueryArg].ConstItem(b.ctx.GetSessionVars().StmtCtx) {
+			b.query = fulltext.ParseQuery(fulltext.NewTokenizer(parser), against, booleanMode)
+			b.isMemorizedQuery = true
+		}
+	}
+	// Only be executed once to achieve thread-safe
+	b.once.Do(memorization)
+	query := b.query
+	if !b.isMemorizedQuery {
+		query = fulltext.ParseQuery(fulltext.NewTokenizer(parser), against, booleanMode)
+	}
+	return query.Relevance(strings.Join(texts, " ")), false, nil
+}
@@ -22,8 +22,10 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
@@ -57,6 +59,12 @@ var (
 	_ functionClass = &jsonDepthFunctionClass{}
 	_ functionClass = &jsonKeysFunctionClass{}
 	_ functionClass = &jsonLengthFunctionClass{}
+	_ functionClass = &jsonMemberOfFunctionClass{}
+	_ functionClass = &jsonOverlapsFunctionClass{}
+	_ functionClass = &jsonStorageFreeFunctionClass{}
+	_ functionClass = &jsonSchemaValidFunctionClass{}
+	_ functionClass = &jsonSchemaValidationReportFunctionClass{}
+	_ functionClass = &jsonValueFunctionClass{}
 
 	_ builtinFunc = &builtinJSONTypeSig{}
 	_ builtinFunc = &builtinJSONQuoteSig{}
@@ -78,6 +86,12 @@ var (
 	_ builtinFunc = &builtinJSONKeysSig{}
 	_ builtinFunc = &builtinJSONKeys2ArgsSig{}
 	_ builtinFunc = &builtinJSONLengthSig{}
+	_ builtinFunc = &builtinJSONMemberOfSig{}
+	_ builtinFunc = &builtinJSONOverlapsSig{}
+	_ builtinFunc = &builtinJSONStorageFreeSig{}
+	_ builtinFunc = &builtinJSONSchemaValidSig{}
+	_ builtinFunc = &builtinJSONSchemaValidationReportSig{}
+	_ builtinFunc = &builtinJSONValueSig{}
 	_ builtinFunc = &builtinJSONValidJSONSig{}
 	_ builtinFunc = &builtinJSONValidStringSig{}
 	_ builtinFunc = &builtinJSONValidOthersSig{}
@@ -1543,3 +1557,481 @@ func (b *builtinJSONLengthSig) evalInt(row chunk.Row) (res int64, isNull bool, e
 	}
 	return int64(obj.GetElemCount()), false, nil
 }
+
+type jsonMemberOfFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONMemberOfSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONMemberOfSig) Clone() builtinFunc {
+	newSig := &builtinJSONMemberOfSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// isJSONOrNullArg returns whether the argument is a JSON document, a string or the NULL constant.
+func isJSONOrNullArg(arg Expression) bool {
+	if c, ok := arg.(*Constant); ok && c.Value.IsNull() {
+		return true
+	}
+	evalType := arg.GetType().EvalType()
+	return evalType == types.ETJson || evalType == types.ETString
+}
+
+func (c *jsonMemberOfFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	if !isJSONOrNullArg(args[1]) {
+		return json.ErrInvalidJSONData.GenWithStackByArgs(2, "member of")
+	}
+	return nil
+}
+
+func (c *jsonMemberOfFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	// The value is a scalar, the string is not parsed as a JSON document.
+	DisableParseJSONFlag4Expr(args[0])
+	sig := &builtinJSONMemberOfSig{bf}
+	return sig, nil
+}
+
+func (b *builtinJSONMemberOfSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	target, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	arr, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if json.MemberOfBinary(target, arr) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type jsonOverlapsFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONOverlapsSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONOverlapsSig) Clone() builtinFunc {
+	newSig := &builtinJSONOverlapsSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonOverlapsFunctionClass) verifyArgs(args []Expression) error {
+	if err := c.baseFunctionClass.verifyArgs(args); err != nil {
+		return err
+	}
+	if !isJSONOrNullArg(args[0]) {
+		return json.ErrInvalidJSONData.GenWithStackByArgs(1, "json_overlaps")
+	}
+	if !isJSONOrNullArg(args[1]) {
+		return json.ErrInvalidJSONData.GenWithStackByArgs(2, "json_overlaps")
+	}
+	return nil
+}
+
+func (c *jsonOverlapsFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinJSONOverlapsSig{bf}
+	return sig, nil
+}
+
+func (b *builtinJSONOverlapsSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	obj, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	target, isNull, err := b.args[1].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if json.OverlapsBinary(obj, target) {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type jsonStorageFreeFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONStorageFreeSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONStorageFreeSig) Clone() builtinFunc {
+	newSig := &builtinJSONStorageFreeSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonStorageFreeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinJSONStorageFreeSig{bf}
+	return sig, nil
+}
+
+// evalInt evals a builtinJSONStorageFreeSig.
+// The JSON documents are never updated in place, so there is no space freed by the partial updates.
+func (b *builtinJSONStorageFreeSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	_, isNull, err = b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return 0, false, nil
+}
+
+type jsonSchemaValidFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONSchemaValidSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONSchemaValidSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonSchemaValidFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinJSONSchemaValidSig{bf}
+	return sig, nil
+}
+
+func (b *builtinJSONSchemaValidSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	report, isNull, err := evalJSONSchemaValidation(b.ctx, ast.JSONSchemaValid, b.args, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	if report.Valid {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+type jsonSchemaValidationReportFunctionClass struct {
+	baseFunctionClass
+}
+
+type builtinJSONSchemaValidationReportSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinJSONSchemaValidationReportSig) Clone() builtinFunc {
+	newSig := &builtinJSONSchemaValidationReportSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (c *jsonSchemaValidationReportFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETJson, types.ETJson, types.ETJson)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinJSONSchemaValidationReportSig{bf}
+	return sig, nil
+}
+
+func (b *builtinJSONSchemaValidationReportSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	report, isNull, err := evalJSONSchemaValidation(b.ctx, ast.JSONSchemaValidationReport, b.args, row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return report.ToBinaryJSON(), false, nil
+}
+
+// evalJSONSchemaValidation validates the document of the second argument against the schema of the first argument.
+func evalJSONSchemaValidation(ctx sessionctx.Context, funcName string, args []Expression, row chunk.Row) (report *json.SchemaValidationReport, isNull bool, err error) {
+	schema, isNull, err := args[0].EvalJSON(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	doc, isNull, err := args[1].EvalJSON(ctx, row)
+	if isNull || err != nil {
+		return nil, isNull, err
+	}
+	report, err = validateJSONSchema(funcName, schema, doc)
+	return report, false, err
+}
+
+func validateJSONSchema(funcName string, schema, doc json.BinaryJSON) (*json.SchemaValidationReport, error) {
+	if schema.TypeCode != json.TypeCodeObject {
+		return nil, json.ErrInvalidJSONType.GenWithStackByArgs(1, funcName, "object")
+	}
+	return json.ValidateSchema(schema, doc)
+}
+
+// BuildJSONValueFunction builds the JSON_VALUE ScalarFunction. The ON EMPTY and ON ERROR clauses are passed as the
+// constant arguments following the document and the path, so the function can be rebuilt from its arguments.
+func BuildJSONValueFunction(ctx sessionctx.Context, doc, path Expression, tp *types.FieldType, onEmpty, onError *ast.JSONTableOnResponse) (Expression, error) {
+	if tp == nil {
+		tp = jsonValueDefaultReturningType()
+	}
+	args := []Expression{doc, path}
+	for _, resp := range []*ast.JSONTableOnResponse{onEmpty, onError} {
+		respTp, respDefault := ast.JSONTableOnResponseNull, types.Datum{}
+		if resp != nil {
+			respTp = resp.Tp
+			if resp.Tp == ast.JSONTableOnResponseDefault {
+				respDefault = types.NewStringDatum(resp.Default)
+			}
+		}
+		args = append(args, DatumToConstant(types.NewIntDatum(int64(respTp)), mysql.TypeLonglong, 0))
+		args = append(args, DatumToConstant(respDefault, mysql.TypeVarString, 0))
+	}
+	return NewFunction(ctx, ast.JSONValue, tp, args...)
+}
+
+func buildJSONValueFunction(ctx sessionctx.Context, args []Expression, tp *types.FieldType) (Expression, error) {
+	fc := &jsonValueFunctionClass{baseFunctionClass{ast.JSONValue, 6, 6}, tp}
+	f, err := fc.getFunction(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+	return FoldConstant(&ScalarFunction{
+		FuncName: model.NewCIStr(ast.JSONValue),
+		RetType:  tp,
+		Function: f,
+	}), nil
+}
+
+// jsonValueDefaultReturningType returns the returning type of JSON_VALUE when the RETURNING clause is omitted.
+func jsonValueDefaultReturningType() *types.FieldType {
+	tp := types.NewFieldType(mysql.TypeVarString)
+	tp.Flen = 512
+	tp.Charset, tp.Collate = mysql.DefaultCharset, mysql.DefaultCollationName
+	return tp
+}
+
+type jsonValueFunctionClass struct {
+	baseFunctionClass
+
+	tp *types.FieldType
+}
+
+// jsonValueResponse is the ON EMPTY or ON ERROR clause of JSON_VALUE, the default value has been converted to the
+// returning type.
+type jsonValueResponse struct {
+	tp           ast.JSONTableOnResponseType
+	defaultValue types.Datum
+}
+
+type builtinJSONValueSig struct {
+	baseBuiltinFunc
+
+	onEmpty jsonValueResponse
+	onError jsonValueResponse
+}
+
+func (b *builtinJSONValueSig) Clone() builtinFunc {
+	newSig := &builtinJSONValueSig{onEmpty: b.onEmpty, onError: b.onError}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinJSONValueSig) equal(fun builtinFunc) bool {
+	sig, ok := fun.(*builtinJSONValueSig)
+	return ok && b.tp.Equal(sig.tp) && b.baseBuiltinFunc.equal(fun)
+}
+
+func (c *jsonValueFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	tp := c.tp
+	if tp == nil {
+		tp = jsonValueDefaultReturningType()
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, tp.EvalType(),
+		types.ETJson, types.ETString, types.ETInt, types.ETString, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = tp
+	sig := &builtinJSONValueSig{baseBuiltinFunc: bf}
+	if sig.onEmpty, err = sig.buildResponse(args[2], args[3]); err != nil {
+		return nil, err
+	}
+	if sig.onError, err = sig.buildResponse(args[4], args[5]); err != nil {
+		return nil, err
+	}
+	return sig, nil
+}
+
+func (b *builtinJSONValueSig) buildResponse(tpArg, defaultArg Expression) (resp jsonValueResponse, err error) {
+	tp, _, err := tpArg.EvalInt(b.ctx, chunk.Row{})
+	if err != nil {
+		return resp, err
+	}
+	resp.tp = ast.JSONTableOnResponseType(tp)
+	if resp.tp != ast.JSONTableOnResponseDefault {
+		return resp, nil
+	}
+	d, err := defaultArg.Eval(chunk.Row{})
+	if err != nil {
+		return resp, err
+	}
+	resp.defaultValue, err = d.ConvertTo(b.newStmtCtx(), b.tp)
+	return resp, err
+}
+
+// newStmtCtx returns the StatementContext converting the values in strict mode, the errors are handled by the
+// ON ERROR clause.
+func (b *builtinJSONValueSig) newStmtCtx() *stmtctx.StatementContext {
+	return &stmtctx.StatementContext{TimeZone: b.ctx.GetSessionVars().Location()}
+}
+
+// extract extracts the scalar by the path and converts it to the returning type.
+func (b *builtinJSONValueSig) extract(sc *stmtctx.StatementContext, doc json.BinaryJSON, path string) (types.Datum, error) {
+	pathExpr, err := json.ParseJSONPathExpr(path)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	values := doc.ExtractAll(pathExpr)
+	switch {
+	case len(values) == 0:
+		return b.respond(b.onEmpty, errMissingJSONValue.GenWithStackByArgs(ast.JSONValue))
+	case len(values) > 1:
+		return b.respond(b.onError, errMultipleJSONValues.GenWithStackByArgs(ast.JSONValue))
+	case b.tp.EvalType() == types.ETJson:
+		return types.NewJSONDatum(values[0]), nil
+	}
+	var d types.Datum
+	switch values[0].TypeCode {
+	case json.TypeCodeString:
+		d = types.NewStringDatum(string(values[0].GetString()))
+	case json.TypeCodeLiteral:
+		if values[0].Value[0] == json.LiteralNil {
+			return d, nil
+		}
+		d = types.NewJSONDatum(values[0])
+	default:
+		d = types.NewJSONDatum(values[0])
+	}
+	res, err := d.ConvertTo(sc, b.tp)
+	if err != nil {
+		return b.respond(b.onError, err)
+	}
+	return res, nil
+}
+
+// respond returns the value of the ON EMPTY or ON ERROR clause, err is returned for ERROR.
+func (b *builtinJSONValueSig) respond(resp jsonValueResponse, err error) (types.Datum, error) {
+	switch resp.tp {
+	case ast.JSONTableOnResponseError:
+		return types.Datum{}, err
+	case ast.JSONTableOnResponseDefault:
+		return resp.defaultValue, nil
+	}
+	return types.Datum{}, nil
+}
+
+func (b *builtinJSONValueSig) evalDatum(row chunk.Row) (types.Datum, bool, error) {
+	doc, isNull, err := b.args[0].EvalJSON(b.ctx, row)
+	if isNull || err != nil {
+		return types.Datum{}, isNull, err
+	}
+	path, isNull, err := b.args[1].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return types.Datum{}, isNull, err
+	}
+	d, err := b.extract(b.newStmtCtx(), doc, path)
+	return d, d.IsNull(), err
+}
+
+func (b *builtinJSONValueSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetString(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *builtinJSONValueSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
@@ -477,6 +477,50 @@ func TestJSONContains(t *testing.T) {
 	}
 }
 
+func TestJSONOverlaps(t *testing.T) {
+	t.Parallel()
+	ctx := createContext(t)
+	fc := funcs[ast.JSONOverlaps]
+	tbl := []struct {
+		input    []interface{}
+		expected interface{}
+		err      error
+	}{
+		{[]interface{}{nil, `1`}, nil, nil},
+		{[]interface{}{`[1]`, nil}, nil, nil},
+		{[]interface{}{`[1, 2]`, `[2, 3]`}, 1, nil},
+		{[]interface{}{`[1, 2]`, `[3, 4]`}, 0, nil},
+		{[]interface{}{`[1, 2]`, `2`}, 1, nil},
+		{[]interface{}{`1`, `[1]`}, 1, nil},
+		{[]interface{}{`1`, `"1"`}, 0, nil},
+		{[]interface{}{`[1, [2, 3]]`, `[2, 3]`}, 0, nil},
+		{[]interface{}{`[1, [2, 3]]`, `[[2, 3]]`}, 1, nil},
+		{[]interface{}{`[]`, `[]`}, 0, nil},
+		{[]interface{}{`{"a": 1, "b": 2}`, `{"b": 2, "c": 3}`}, 1, nil},
+		{[]interface{}{`{"a": 1}`, `{"a": 2}`}, 0, nil},
+		{[]interface{}{`{"a": 1}`, `[{"a": 1}]`}, 1, nil},
+		{[]interface{}{`[1`, `1`}, nil, json.ErrInvalidJSONText},
+	}
+	for _, tt := range tbl {
+		args := types.MakeDatums(tt.input...)
+		f, err := fc.getFunction(ctx, datumsToConstants(args))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if tt.err == nil {
+			require.NoError(t, err)
+			if tt.expected == nil {
+				require.True(t, d.IsNull())
+			} else {
+				require.Equal(t, int64(tt.expected.(int)), d.GetInt64())
+			}
+		} else {
+			require.True(t, tt.err.(*terror.Error).Equal(err))
+		}
+	}
+	_, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(1, `[1]`)))
+	require.True(t, json.ErrInvalidJSONData.Equal(err))
+}
+
 func TestJSONContainsPath(t *testing.T) {
 	t.Parallel()
 	ctx := createContext(t)
@@ -1215,3 +1259,134 @@ func TestJSONMergePatch(t *testing.T) {
 		}
 	}
 }
+
+func TestJSONStorageFree(t *testing.T) {
+	t.Parallel()
+	ctx := createContext(t)
+	fc := funcs[ast.JSONStorageFree]
+	tbl := []struct {
+		input    interface{}
+		expected interface{}
+		success  bool
+	}{
+		{`{"a": 1}`, int64(0), true},
+		{`null`, int64(0), true},
+		{nil, nil, true},
+		{`{"a": 1`, nil, false},
+	}
+	for _, tt := range tbl {
+		f, err := fc.getFunction(ctx, datumsToConstants(types.MakeDatums(tt.input)))
+		require.NoError(t, err)
+		d, err := evalBuiltinFunc(f, chunk.Row{})
+		if !tt.success {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, d.GetValue())
+	}
+}
+
+func TestJSONSchemaValid(t *testing.T) {
+	t.Parallel()
+	ctx := createContext(t)
+	schema := `{"type": "array", "items": {"type": "number"}}`
+	tbl := []struct {
+		input    []interface{}
+		valid    interface{}
+		report   interface{}
+		errMatch string
+	}{
+		{[]interface{}{schema, `[1, 2.5]`}, int64(1), `{"valid": true}`, ""},
+		{[]interface{}{schema, `[1, "a"]`}, int64(0), `{"document-location": "#/1", "reason": "The JSON document location '#/1' failed requirement 'type' at JSON Schema location '#/items'", "schema-failed-keyword": "type", "schema-location": "#/items", "valid": false}`, ""},
+		{[]interface{}{nil, `[1]`}, nil, nil, ""},
+		{[]interface{}{schema, nil}, nil, nil, ""},
+		{[]interface{}{`"a"`, `[1]`}, nil, nil, "Invalid JSON type in argument 1"},
+		{[]interface{}{`{"type": ["array", 1]}`, `[1]`}, nil, nil, "Invalid JSON schema"},
+	}
+	for _, tt := range tbl {
+		args := datumsToConstants(types.MakeDatums(tt.input...))
+		f, err := funcs[ast.JSONSchemaValid].getFunction(ctx, args)
+		require.NoError(t, err)
+		valid, err := evalBuiltinFunc(f, chunk.Row{})
+		f, err2 := funcs[ast.JSONSchemaValidationReport].getFunction(ctx, args)
+		require.NoError(t, err2)
+		report, err2 := evalBuiltinFunc(f, chunk.Row{})
+		if tt.errMatch != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMatch)
+			require.Error(t, err2)
+			require.Contains(t, err2.Error(), tt.errMatch)
+			continue
+		}
+		require.NoError(t, err)
+		require.NoError(t, err2)
+		require.Equal(t, tt.valid, valid.GetValue())
+		if tt.report == nil {
+			require.True(t, report.IsNull())
+		} else {
+			require.Equal(t, tt.report, report.GetMysqlJSON().String())
+		}
+	}
+}
+
+func TestJSONValue(t *testing.T) {
+	t.Parallel()
+	ctx := createContext(t)
+	decimalTp := types.NewFieldType(mysql.TypeNewDecimal)
+	decimalTp.Flen, decimalTp.Decimal = 10, 2
+	tbl := []struct {
+		doc      interface{}
+		path     string
+		tp       *types.FieldType
+		onEmpty  *ast.JSONTableOnResponse
+		onError  *ast.JSONTableOnResponse
+		expected interface{}
+		errMatch string
+	}{
+		{`{"a": "x"}`, "$.a", nil, nil, nil, "x", ""},
+		{`{"a": [1, 2]}`, "$.a", nil, nil, nil, "[1, 2]", ""},
+		{`{"a": null}`, "$.a", nil, nil, nil, nil, ""},
+		{nil, "$.a", nil, nil, nil, nil, ""},
+		{`{"a": 1.234}`, "$.a", decimalTp, nil, nil, "1.23", ""},
+		{`{"a": "12"}`, "$.a", types.NewFieldType(mysql.TypeLonglong), nil, nil, int64(12), ""},
+		{`{"a": 1.5}`, "$.a", types.NewFieldType(mysql.TypeDouble), nil, nil, 1.5, ""},
+		{`{"a": true}`, "$.a", types.NewFieldType(mysql.TypeJSON), nil, nil, "true", ""},
+		{`{}`, "$.a", nil, nil, nil, nil, ""},
+		{`{}`, "$.a", nil, &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: "none"}, nil, "none", ""},
+		{`{}`, "$.a", nil, &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}, nil, nil, "No value was found"},
+		{`[1, 2]`, "$[*]", nil, nil, nil, nil, ""},
+		{`[1, 2]`, "$[*]", nil, nil, &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}, nil, "More than one value was found"},
+		{`{"a": "x"}`, "$.a", types.NewFieldType(mysql.TypeLonglong), nil, &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: "-1"}, int64(-1), ""},
+		{`{"a": "x"}`, "$.a", types.NewFieldType(mysql.TypeLonglong), nil, &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}, nil, "Truncated incorrect"},
+	}
+	for _, tt := range tbl {
+		doc := datumsToConstants(types.MakeDatums(tt.doc))[0]
+		path := datumsToConstants(types.MakeDatums(tt.path))[0]
+		f, err := BuildJSONValueFunction(ctx, doc, path, tt.tp, tt.onEmpty, tt.onError)
+		require.NoError(t, err)
+		d, err := f.Eval(chunk.Row{})
+		if tt.errMatch != "" {
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.errMatch)
+			continue
+		}
+		require.NoError(t, err)
+		switch x := tt.expected.(type) {
+		case nil:
+			require.True(t, d.IsNull())
+		case string:
+			s, err := d.ToString()
+			require.NoError(t, err)
+			require.Equal(t, x, s)
+		default:
+			require.Equal(t, x, d.GetValue())
+		}
+	}
+
+	// The default value is converted to the returning type when the function is built.
+	doc := datumsToConstants(types.MakeDatums(`{}`))[0]
+	path := datumsToConstants(types.MakeDatums("$.a"))[0]
+	_, err := BuildJSONValueFunction(ctx, doc, path, types.NewFieldType(mysql.TypeLonglong), &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: "x"}, nil)
+	require.Error(t, err)
+}
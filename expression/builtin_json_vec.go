@@ -23,6 +23,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tipb/go-tipb"
@@ -1266,3 +1267,255 @@ func (b *builtinJSONMergePatchSig) vecEvalJSON(input *chunk.Chunk, result *chunk
 
 	return nil
 }
+
+func (b *builtinJSONStorageFreeSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONStorageFreeSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	n := input.NumRows()
+	buf, err := b.bufAllocator.get()
+	if err != nil {
+		return err
+	}
+	defer b.bufAllocator.put(buf)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, buf); err != nil {
+		return err
+	}
+	result.ResizeInt64(n, false)
+	result.MergeNulls(buf)
+	int64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		int64s[i] = 0
+	}
+	return nil
+}
+
+// vecEvalJSONSchemaValidation validates the documents of the second argument against the schemas of the first
+// argument, the report is nil if any of the arguments is NULL.
+func vecEvalJSONSchemaValidation(ctx sessionctx.Context, funcName string, args []Expression, bufAllocator columnBufferAllocator, input *chunk.Chunk) ([]*json.SchemaValidationReport, error) {
+	n := input.NumRows()
+	schemaBuf, err := bufAllocator.get()
+	if err != nil {
+		return nil, err
+	}
+	defer bufAllocator.put(schemaBuf)
+	if err := args[0].VecEvalJSON(ctx, input, schemaBuf); err != nil {
+		return nil, err
+	}
+	docBuf, err := bufAllocator.get()
+	if err != nil {
+		return nil, err
+	}
+	defer bufAllocator.put(docBuf)
+	if err := args[1].VecEvalJSON(ctx, input, docBuf); err != nil {
+		return nil, err
+	}
+	reports := make([]*json.SchemaValidationReport, n)
+	for i := 0; i < n; i++ {
+		if schemaBuf.IsNull(i) || docBuf.IsNull(i) {
+			continue
+		}
+		if reports[i], err = validateJSONSchema(funcName, schemaBuf.GetJSON(i), docBuf.GetJSON(i)); err != nil {
+			return nil, err
+		}
+	}
+	return reports, nil
+}
+
+func (b *builtinJSONSchemaValidSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONSchemaValidSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	reports, err := vecEvalJSONSchemaValidation(b.ctx, ast.JSONSchemaValid, b.args, b.bufAllocator, input)
+	if err != nil {
+		return err
+	}
+	result.ResizeInt64(len(reports), false)
+	int64s := result.Int64s()
+	for i, report := range reports {
+		switch {
+		case report == nil:
+			result.SetNull(i, true)
+		case report.Valid:
+			int64s[i] = 1
+		default:
+			int64s[i] = 0
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONSchemaValidationReportSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinJSONSchemaValidationReportSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	reports, err := vecEvalJSONSchemaValidation(b.ctx, ast.JSONSchemaValidationReport, b.args, b.bufAllocator, input)
+	if err != nil {
+		return err
+	}
+	result.ReserveJSON(len(reports))
+	for _, report := range reports {
+		if report == nil {
+			result.AppendNull()
+		} else {
+			result.AppendJSON(report.ToBinaryJSON())
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vectorized() bool {
+	return true
+}
+
+// vecEvalDatums evaluates the values of JSON_VALUE, the datums of NULL are returned for the NULL arguments.
+func (b *builtinJSONValueSig) vecEvalDatums(input *chunk.Chunk) ([]types.Datum, error) {
+	n := input.NumRows()
+	docBuf, err := b.bufAllocator.get()
+	if err != nil {
+		return nil, err
+	}
+	defer b.bufAllocator.put(docBuf)
+	if err := b.args[0].VecEvalJSON(b.ctx, input, docBuf); err != nil {
+		return nil, err
+	}
+	pathBuf, err := b.bufAllocator.get()
+	if err != nil {
+		return nil, err
+	}
+	defer b.bufAllocator.put(pathBuf)
+	if err := b.args[1].VecEvalString(b.ctx, input, pathBuf); err != nil {
+		return nil, err
+	}
+	sc := b.newStmtCtx()
+	datums := make([]types.Datum, n)
+	for i := 0; i < n; i++ {
+		if docBuf.IsNull(i) || pathBuf.IsNull(i) {
+			continue
+		}
+		if datums[i], err = b.extract(sc, docBuf.GetJSON(i), pathBuf.GetString(i)); err != nil {
+			return nil, err
+		}
+	}
+	return datums, nil
+}
+
+func (b *builtinJSONValueSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ResizeInt64(len(datums), false)
+	int64s := result.Int64s()
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.SetNull(i, true)
+		} else {
+			int64s[i] = datums[i].GetInt64()
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalReal(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ResizeFloat64(len(datums), false)
+	f64s := result.Float64s()
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.SetNull(i, true)
+		} else {
+			f64s[i] = datums[i].GetFloat64()
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalDecimal(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ResizeDecimal(len(datums), false)
+	decs := result.Decimals()
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.SetNull(i, true)
+		} else {
+			decs[i] = *datums[i].GetMysqlDecimal()
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ReserveString(len(datums))
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.AppendNull()
+		} else {
+			result.AppendString(datums[i].GetString())
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalTime(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ResizeTime(len(datums), false)
+	times := result.Times()
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.SetNull(i, true)
+		} else {
+			times[i] = datums[i].GetMysqlTime()
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalDuration(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ResizeGoDuration(len(datums), false)
+	durations := result.GoDurations()
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.SetNull(i, true)
+		} else {
+			durations[i] = datums[i].GetMysqlDuration().Duration
+		}
+	}
+	return nil
+}
+
+func (b *builtinJSONValueSig) vecEvalJSON(input *chunk.Chunk, result *chunk.Column) error {
+	datums, err := b.vecEvalDatums(input)
+	if err != nil {
+		return err
+	}
+	result.ReserveJSON(len(datums))
+	for i := range datums {
+		if datums[i].IsNull() {
+			result.AppendNull()
+		} else {
+			result.AppendJSON(datums[i].GetMysqlJSON())
+		}
+	}
+	return nil
+}
@@ -18,9 +18,20 @@ import (
 	"testing"
 
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
 )
 
+// jsonValueResponseConstants returns the constant arguments of the ON EMPTY and ON ERROR clauses of JSON_VALUE.
+func jsonValueResponseConstants(onEmpty, onError ast.JSONTableOnResponseType, defaultValue string) []*Constant {
+	return []*Constant{nil, nil,
+		{Value: types.NewIntDatum(int64(onEmpty)), RetType: types.NewFieldType(mysql.TypeLonglong)},
+		{Value: types.NewStringDatum(defaultValue), RetType: types.NewFieldType(mysql.TypeVarString)},
+		{Value: types.NewIntDatum(int64(onError)), RetType: types.NewFieldType(mysql.TypeLonglong)},
+		{Value: types.NewStringDatum(defaultValue), RetType: types.NewFieldType(mysql.TypeVarString)},
+	}
+}
+
 var vecBuiltinJSONCases = map[string][]vecExprBenchCase{
 	ast.JSONKeys: {
 		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson}},
@@ -124,7 +135,20 @@ var vecBuiltinJSONCases = map[string][]vecExprBenchCase{
 		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETJson}, geners: []dataGenerator{nil, &constStrGener{"$.key"}, nil}},
 	},
 	ast.JSONStorageSize: {{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson}}},
+	ast.JSONStorageFree: {{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson}}},
 	ast.JSONDepth:       {{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson}}},
+	ast.JSONSchemaValid: {
+		{retEvalType: types.ETInt, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}, geners: []dataGenerator{&constJSONGener{`{"properties": {"key": {"maximum": 1000000}}}`}, nil}},
+	},
+	ast.JSONSchemaValidationReport: {
+		{retEvalType: types.ETJson, childrenTypes: []types.EvalType{types.ETJson, types.ETJson}, geners: []dataGenerator{&constJSONGener{`{"properties": {"key": {"maximum": 1000000}}}`}, nil}},
+	},
+	ast.JSONValue: {
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETInt, types.ETString, types.ETInt, types.ETString},
+			geners: []dataGenerator{nil, &constStrGener{"$.key"}}, constants: jsonValueResponseConstants(ast.JSONTableOnResponseNull, ast.JSONTableOnResponseNull, "")},
+		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETJson, types.ETString, types.ETInt, types.ETString, types.ETInt, types.ETString},
+			geners: []dataGenerator{nil, &constStrGener{"$.none"}}, constants: jsonValueResponseConstants(ast.JSONTableOnResponseDefault, ast.JSONTableOnResponseNull, "none")},
+	},
 	ast.JSONUnquote: {
 		{retEvalType: types.ETString, childrenTypes: []types.EvalType{types.ETString}, geners: []dataGenerator{newJSONStringGener()}},
 	},
@@ -22,10 +22,12 @@ import (
 	"net"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/google/uuid"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
+	storeerr "github.com/pingcap/tidb/store/driver/error"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/chunk"
@@ -63,6 +65,9 @@ var (
 	_ builtinFunc = &builtinSleepSig{}
 	_ builtinFunc = &builtinLockSig{}
 	_ builtinFunc = &builtinReleaseLockSig{}
+	_ builtinFunc = &builtinIsFreeLockSig{}
+	_ builtinFunc = &builtinIsUsedLockSig{}
+	_ builtinFunc = &builtinReleaseAllLocksSig{}
 	_ builtinFunc = &builtinDecimalAnyValueSig{}
 	_ builtinFunc = &builtinDurationAnyValueSig{}
 	_ builtinFunc = &builtinIntAnyValueSig{}
@@ -148,6 +153,9 @@ func (b *builtinSleepSig) evalInt(row chunk.Row) (int64, bool, error) {
 	return 0, false, nil
 }
 
+// maxUserLockNameLength is the max length of the advisory lock names in characters.
+const maxUserLockNameLength = 64
+
 type lockFunctionClass struct {
 	baseFunctionClass
 }
@@ -177,10 +185,45 @@ func (b *builtinLockSig) Clone() builtinFunc {
 
 // evalInt evals a builtinLockSig.
 // See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_get-lock
-// The lock function will do nothing.
-// Warning: get_lock() function is parsed but ignored.
-func (b *builtinLockSig) evalInt(_ chunk.Row) (int64, bool, error) {
-	return 1, false, nil
+func (b *builtinLockSig) evalInt(row chunk.Row) (int64, bool, error) {
+	lockName, err := evalAdvisoryLockName(b.ctx, b.args[0], row)
+	if err != nil {
+		return 0, true, err
+	}
+	// A NULL timeout is treated as 0, and a negative timeout means waiting infinitely.
+	timeout, isNull, err := b.args[1].EvalInt(b.ctx, row)
+	if err != nil {
+		return 0, true, err
+	}
+	if isNull {
+		timeout = 0
+	}
+	err = b.ctx.GetAdvisoryLock(lockName, timeout)
+	switch {
+	case err == nil:
+		return 1, false, nil
+	case storeerr.ErrLockWaitTimeout.Equal(err), storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err):
+		return 0, false, nil
+	case storeerr.ErrQueryInterrupted.Equal(err):
+		return 0, true, nil
+	}
+	return 0, true, err
+}
+
+// evalAdvisoryLockName evaluates and validates the name of the advisory lock.
+// The name is case-insensitive, so it's converted to lower case.
+func evalAdvisoryLockName(ctx sessionctx.Context, arg Expression, row chunk.Row) (string, error) {
+	lockName, isNull, err := arg.EvalString(ctx, row)
+	if err != nil {
+		return "", err
+	}
+	if isNull {
+		return "", ErrUserLockWrongName.GenWithStackByArgs("NULL")
+	}
+	if lockName == "" || utf8.RuneCountInString(lockName) > maxUserLockNameLength {
+		return "", ErrUserLockWrongName.GenWithStackByArgs(lockName)
+	}
+	return strings.ToLower(lockName), nil
 }
 
 type releaseLockFunctionClass struct {
@@ -212,10 +255,21 @@ func (b *builtinReleaseLockSig) Clone() builtinFunc {
 
 // evalInt evals a builtinReleaseLockSig.
 // See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_release-lock
-// The release lock function will do nothing.
-// Warning: release_lock() function is parsed but ignored.
-func (b *builtinReleaseLockSig) evalInt(_ chunk.Row) (int64, bool, error) {
-	return 1, false, nil
+func (b *builtinReleaseLockSig) evalInt(row chunk.Row) (int64, bool, error) {
+	lockName, err := evalAdvisoryLockName(b.ctx, b.args[0], row)
+	if err != nil {
+		return 0, true, err
+	}
+	if b.ctx.ReleaseAdvisoryLock(lockName) {
+		return 1, false, nil
+	}
+	// The lock is not held by the current session.
+	// It returns 0 if the lock is held by another session, and NULL if the lock doesn't exist.
+	used, err := b.ctx.IsUsedAdvisoryLock(lockName)
+	if err != nil || !used {
+		return 0, true, err
+	}
+	return 0, false, nil
 }
 
 type anyValueFunctionClass struct {
@@ -643,7 +697,43 @@ type isFreeLockFunctionClass struct {
 }
 
 func (c *isFreeLockFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
-	return nil, errFunctionNotExists.GenWithStackByArgs("FUNCTION", "IS_FREE_LOCK")
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinIsFreeLockSig{bf}
+	bf.tp.Flen = 1
+	return sig, nil
+}
+
+type builtinIsFreeLockSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIsFreeLockSig) Clone() builtinFunc {
+	newSig := &builtinIsFreeLockSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinIsFreeLockSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_is-free-lock
+func (b *builtinIsFreeLockSig) evalInt(row chunk.Row) (int64, bool, error) {
+	lockName, err := evalAdvisoryLockName(b.ctx, b.args[0], row)
+	if err != nil {
+		return 0, true, err
+	}
+	used, err := b.ctx.IsUsedAdvisoryLock(lockName)
+	if err != nil {
+		return 0, true, err
+	}
+	if used {
+		return 0, false, nil
+	}
+	return 1, false, nil
 }
 
 type isIPv4FunctionClass struct {
@@ -859,7 +949,42 @@ type isUsedLockFunctionClass struct {
 }
 
 func (c *isUsedLockFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
-	return nil, errFunctionNotExists.GenWithStackByArgs("FUNCTION", "IS_USED_LOCK")
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinIsUsedLockSig{bf}
+	bf.tp.Flen = mysql.MaxIntWidth
+	bf.tp.Flag |= mysql.UnsignedFlag
+	return sig, nil
+}
+
+type builtinIsUsedLockSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinIsUsedLockSig) Clone() builtinFunc {
+	newSig := &builtinIsUsedLockSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinIsUsedLockSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_is-used-lock
+// It returns the connection ID of the session holding the lock, or NULL if the lock is free.
+func (b *builtinIsUsedLockSig) evalInt(row chunk.Row) (int64, bool, error) {
+	lockName, err := evalAdvisoryLockName(b.ctx, b.args[0], row)
+	if err != nil {
+		return 0, true, err
+	}
+	connID, err := b.ctx.AdvisoryLockHolder(lockName)
+	if err != nil || connID == 0 {
+		return 0, true, err
+	}
+	return int64(connID), false, nil
 }
 
 type masterPosWaitFunctionClass struct {
@@ -1012,7 +1137,32 @@ type releaseAllLocksFunctionClass struct {
 }
 
 func (c *releaseAllLocksFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
-	return nil, errFunctionNotExists.GenWithStackByArgs("FUNCTION", "RELEASE_ALL_LOCKS")
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinReleaseAllLocksSig{bf}
+	bf.tp.Flen = 1
+	return sig, nil
+}
+
+type builtinReleaseAllLocksSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinReleaseAllLocksSig) Clone() builtinFunc {
+	newSig := &builtinReleaseAllLocksSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals a builtinReleaseAllLocksSig.
+// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_release-all-locks
+func (b *builtinReleaseAllLocksSig) evalInt(_ chunk.Row) (int64, bool, error) {
+	return int64(b.ctx.ReleaseAllAdvisoryLocks()), false, nil
 }
 
 type uuidFunctionClass struct {
@@ -0,0 +1,576 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tipb/go-tipb"
+)
+
+var (
+	_ functionClass = &regexpLikeFunctionClass{}
+	_ functionClass = &regexpSubstrFunctionClass{}
+	_ functionClass = &regexpInStrFunctionClass{}
+	_ functionClass = &regexpReplaceFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinRegexpLikeFuncSig{}
+	_ builtinFunc = &builtinRegexpSubstrFuncSig{}
+	_ builtinFunc = &builtinRegexpInStrFuncSig{}
+	_ builtinFunc = &builtinRegexpReplaceFuncSig{}
+)
+
+// regexpArg is the role of an argument of the REGEXP_* functions.
+type regexpArg int
+
+const (
+	regexpArgExpr regexpArg = iota
+	regexpArgPat
+	regexpArgRepl
+	regexpArgPos
+	regexpArgOccurrence
+	regexpArgReturnOption
+	regexpArgMatchType
+)
+
+func (a regexpArg) evalType() types.EvalType {
+	switch a {
+	case regexpArgPos, regexpArgOccurrence, regexpArgReturnOption:
+		return types.ETInt
+	}
+	return types.ETString
+}
+
+// The roles of the arguments of the REGEXP_* functions, the trailing arguments are optional.
+var (
+	regexpLikeArgs    = []regexpArg{regexpArgExpr, regexpArgPat, regexpArgMatchType}
+	regexpSubstrArgs  = []regexpArg{regexpArgExpr, regexpArgPat, regexpArgPos, regexpArgOccurrence, regexpArgMatchType}
+	regexpInStrArgs   = []regexpArg{regexpArgExpr, regexpArgPat, regexpArgPos, regexpArgOccurrence, regexpArgReturnOption, regexpArgMatchType}
+	regexpReplaceArgs = []regexpArg{regexpArgExpr, regexpArgPat, regexpArgRepl, regexpArgPos, regexpArgOccurrence, regexpArgMatchType}
+)
+
+// regexpParams are the evaluated arguments of a REGEXP_* function.
+type regexpParams struct {
+	expr, pat, repl, matchType    string
+	pos, occurrence, returnOption int64
+}
+
+func (p *regexpParams) setString(role regexpArg, s string) {
+	switch role {
+	case regexpArgExpr:
+		p.expr = s
+	case regexpArgPat:
+		p.pat = s
+	case regexpArgRepl:
+		p.repl = s
+	case regexpArgMatchType:
+		p.matchType = s
+	}
+}
+
+func (p *regexpParams) setInt(role regexpArg, v int64) {
+	switch role {
+	case regexpArgPos:
+		p.pos = v
+	case regexpArgOccurrence:
+		p.occurrence = v
+	case regexpArgReturnOption:
+		p.returnOption = v
+	}
+}
+
+// newRegexpBaseBuiltinFunc creates the baseBuiltinFunc of a REGEXP_* function, the comparison collation is derived
+// from the expression and the pattern.
+func newRegexpBaseBuiltinFunc(ctx sessionctx.Context, funcName string, args []Expression, retType types.EvalType, roles []regexpArg) (baseBuiltinFunc, error) {
+	argTps := make([]types.EvalType, 0, len(args))
+	for i := range args {
+		argTps = append(argTps, roles[i].evalType())
+	}
+	return newBaseBuiltinFuncWithTp(ctx, funcName, args, retType, argTps...)
+}
+
+type regexpBaseFuncSig struct {
+	baseBuiltinFunc
+	funcName string
+	roles    []regexpArg
+	// defaultOccurrence is the occurrence used when the argument is omitted.
+	defaultOccurrence int64
+
+	// The compiled regexp is memorized if both the pattern and the match type are constant.
+	isMemorizable   bool
+	memorizedOnce   *sync.Once
+	memorizedRegexp *regexp.Regexp
+	memorizedErr    error
+}
+
+func newRegexpBaseFuncSig(bf baseBuiltinFunc, funcName string, roles []regexpArg) regexpBaseFuncSig {
+	sc := bf.ctx.GetSessionVars().StmtCtx
+	re := regexpBaseFuncSig{
+		baseBuiltinFunc:   bf,
+		funcName:          funcName,
+		roles:             roles[:len(bf.args)],
+		defaultOccurrence: 1,
+		isMemorizable:     true,
+		memorizedOnce:     new(sync.Once),
+	}
+	for i, role := range re.roles {
+		if (role == regexpArgPat || role == regexpArgMatchType) && !bf.args[i].ConstItem(sc) {
+			re.isMemorizable = false
+		}
+	}
+	return re
+}
+
+func (re *regexpBaseFuncSig) clone(from *regexpBaseFuncSig) {
+	re.cloneFrom(&from.baseBuiltinFunc)
+	re.funcName = from.funcName
+	re.roles = from.roles
+	re.defaultOccurrence = from.defaultOccurrence
+	re.isMemorizable = from.isMemorizable
+	re.memorizedOnce = new(sync.Once)
+}
+
+func (re *regexpBaseFuncSig) isBinary() bool {
+	return re.collation == charset.CollationBin
+}
+
+// evalParams evaluates the arguments of the row, isNull is true if any argument is NULL.
+func (re *regexpBaseFuncSig) evalParams(row chunk.Row) (p regexpParams, isNull bool, err error) {
+	p = regexpParams{pos: 1, occurrence: re.defaultOccurrence}
+	for i, role := range re.roles {
+		if role.evalType() == types.ETInt {
+			v, isNull, err := re.args[i].EvalInt(re.ctx, row)
+			if isNull || err != nil {
+				return p, true, err
+			}
+			p.setInt(role, v)
+			continue
+		}
+		s, isNull, err := re.args[i].EvalString(re.ctx, row)
+		if isNull || err != nil {
+			return p, true, err
+		}
+		p.setString(role, s)
+	}
+	return p, false, nil
+}
+
+// getRegexp returns the compiled regexp of the pattern with the match type.
+func (re *regexpBaseFuncSig) getRegexp(pat, matchType string) (*regexp.Regexp, error) {
+	if !re.isMemorizable {
+		return re.compile(pat, matchType)
+	}
+	re.memorizedOnce.Do(func() {
+		re.memorizedRegexp, re.memorizedErr = re.compile(pat, matchType)
+	})
+	return re.memorizedRegexp, re.memorizedErr
+}
+
+// compile compiles the pattern with the flags of the match type, the case sensitivity is determined by the
+// collation unless it's specified by the match type. The binary strings are always matched case-sensitively.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (re *regexpBaseFuncSig) compile(pat, matchType string) (*regexp.Regexp, error) {
+	ci := !re.isBinary() && collate.IsCICollation(re.collation)
+	var multiLine, dotAll bool
+	for _, c := range matchType {
+		switch c {
+		case 'c':
+			ci = false
+		case 'i':
+			ci = !re.isBinary()
+		case 'm':
+			multiLine = true
+		case 'n':
+			dotAll = true
+		case 'u':
+			// Only the '\n' is recognized as the line terminator in Go.
+		default:
+			return nil, errIncorrectArgs.GenWithStackByArgs(re.funcName)
+		}
+	}
+	var flags strings.Builder
+	if ci {
+		flags.WriteByte('i')
+	}
+	if multiLine {
+		flags.WriteByte('m')
+	}
+	if dotAll {
+		flags.WriteByte('s')
+	}
+	if flags.Len() > 0 {
+		pat = "(?" + flags.String() + ")" + pat
+	}
+	compiled, err := regexp.Compile(pat)
+	if err != nil {
+		return nil, ErrRegexp.GenWithStackByArgs(err.Error())
+	}
+	return compiled, nil
+}
+
+// byteOffset returns the byte offset of the 1-based position of the string, the position is counted in characters
+// unless the string is binary.
+func (re *regexpBaseFuncSig) byteOffset(s string, pos int64) (int, error) {
+	if pos < 1 {
+		return 0, errRegexpIndexOutOfBounds.GenWithStackByArgs()
+	}
+	if re.isBinary() {
+		if pos > int64(len(s))+1 {
+			return 0, errRegexpIndexOutOfBounds.GenWithStackByArgs()
+		}
+		return int(pos - 1), nil
+	}
+	offset := 0
+	for i := int64(1); i < pos; i++ {
+		if offset >= len(s) {
+			return 0, errRegexpIndexOutOfBounds.GenWithStackByArgs()
+		}
+		_, size := utf8.DecodeRuneInString(s[offset:])
+		offset += size
+	}
+	return offset, nil
+}
+
+// position returns the 1-based position of the byte offset of the string.
+func (re *regexpBaseFuncSig) position(s string, offset int) int64 {
+	if re.isBinary() {
+		return int64(offset) + 1
+	}
+	return int64(utf8.RuneCountInString(s[:offset])) + 1
+}
+
+// findMatch returns the byte indexes of the occurrence-th match in the string starting from the position,
+// it returns nil if there are not so many matches.
+func (re *regexpBaseFuncSig) findMatch(p *regexpParams) ([]int, error) {
+	compiled, err := re.getRegexp(p.pat, p.matchType)
+	if err != nil {
+		return nil, err
+	}
+	offset, err := re.byteOffset(p.expr, p.pos)
+	if err != nil {
+		return nil, err
+	}
+	occurrence := p.occurrence
+	if occurrence < 1 {
+		occurrence = 1
+	}
+	matches := compiled.FindAllStringIndex(p.expr[offset:], int(occurrence))
+	if int64(len(matches)) < occurrence {
+		return nil, nil
+	}
+	match := matches[occurrence-1]
+	return []int{match[0] + offset, match[1] + offset}, nil
+}
+
+type regexpLikeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpLikeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newRegexpBaseBuiltinFunc(ctx, c.funcName, args, types.ETInt, regexpLikeArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := newBuiltinRegexpLikeFuncSig(bf)
+	if sig.isBinary() {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpLikeUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpLikeFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpLikeFuncSig(bf baseBuiltinFunc) *builtinRegexpLikeFuncSig {
+	return &builtinRegexpLikeFuncSig{newRegexpBaseFuncSig(bf, "regexp_like", regexpLikeArgs)}
+}
+
+func (b *builtinRegexpLikeFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpLikeFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_LIKE(expr, pat[, match_type])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-like
+func (b *builtinRegexpLikeFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
+	p, isNull, err := b.evalParams(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	res, err := b.like(&p)
+	return res, err != nil, err
+}
+
+func (b *builtinRegexpLikeFuncSig) like(p *regexpParams) (int64, error) {
+	compiled, err := b.getRegexp(p.pat, p.matchType)
+	if err != nil {
+		return 0, err
+	}
+	return boolToInt64(compiled.MatchString(p.expr)), nil
+}
+
+type regexpSubstrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpSubstrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newRegexpBaseBuiltinFunc(ctx, c.funcName, args, types.ETString, regexpSubstrArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = args[0].GetType().Flen
+	sig := newBuiltinRegexpSubstrFuncSig(bf)
+	if sig.isBinary() {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpSubstrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpSubstrFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpSubstrFuncSig(bf baseBuiltinFunc) *builtinRegexpSubstrFuncSig {
+	return &builtinRegexpSubstrFuncSig{newRegexpBaseFuncSig(bf, "regexp_substr", regexpSubstrArgs)}
+}
+
+func (b *builtinRegexpSubstrFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpSubstrFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_SUBSTR(expr, pat[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-substr
+func (b *builtinRegexpSubstrFuncSig) evalString(row chunk.Row) (string, bool, error) {
+	p, isNull, err := b.evalParams(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	return b.substr(&p)
+}
+
+// substr returns the matched substring, isNull is true if there is no match.
+func (b *builtinRegexpSubstrFuncSig) substr(p *regexpParams) (string, bool, error) {
+	match, err := b.findMatch(p)
+	if match == nil || err != nil {
+		return "", true, err
+	}
+	return p.expr[match[0]:match[1]], false, nil
+}
+
+type regexpInStrFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpInStrFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newRegexpBaseBuiltinFunc(ctx, c.funcName, args, types.ETInt, regexpInStrArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxIntWidth
+	sig := newBuiltinRegexpInStrFuncSig(bf)
+	if sig.isBinary() {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpInStrUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpInStrFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpInStrFuncSig(bf baseBuiltinFunc) *builtinRegexpInStrFuncSig {
+	return &builtinRegexpInStrFuncSig{newRegexpBaseFuncSig(bf, "regexp_instr", regexpInStrArgs)}
+}
+
+func (b *builtinRegexpInStrFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpInStrFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalInt evals `REGEXP_INSTR(expr, pat[, pos[, occurrence[, return_option[, match_type]]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-instr
+func (b *builtinRegexpInStrFuncSig) evalInt(row chunk.Row) (int64, bool, error) {
+	p, isNull, err := b.evalParams(row)
+	if isNull || err != nil {
+		return 0, true, err
+	}
+	res, err := b.instr(&p)
+	return res, err != nil, err
+}
+
+// instr returns the position of the beginning of the match, or the position following the end of the match if
+// the return option is 1. It returns 0 if there is no match.
+func (b *builtinRegexpInStrFuncSig) instr(p *regexpParams) (int64, error) {
+	if p.returnOption != 0 && p.returnOption != 1 {
+		return 0, errIncorrectArgs.GenWithStackByArgs("regexp_instr: return_option must be 1 or 0.")
+	}
+	match, err := b.findMatch(p)
+	if match == nil || err != nil {
+		return 0, err
+	}
+	return b.position(p.expr, match[p.returnOption]), nil
+}
+
+type regexpReplaceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *regexpReplaceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newRegexpBaseBuiltinFunc(ctx, c.funcName, args, types.ETString, regexpReplaceArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	sig := newBuiltinRegexpReplaceFuncSig(bf)
+	if sig.isBinary() {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceSig)
+	} else {
+		sig.setPbCode(tipb.ScalarFuncSig_RegexpReplaceUTF8Sig)
+	}
+	return sig, nil
+}
+
+type builtinRegexpReplaceFuncSig struct {
+	regexpBaseFuncSig
+}
+
+func newBuiltinRegexpReplaceFuncSig(bf baseBuiltinFunc) *builtinRegexpReplaceFuncSig {
+	sig := &builtinRegexpReplaceFuncSig{newRegexpBaseFuncSig(bf, "regexp_replace", regexpReplaceArgs)}
+	// All the matches are replaced by default.
+	sig.defaultOccurrence = 0
+	return sig
+}
+
+func (b *builtinRegexpReplaceFuncSig) Clone() builtinFunc {
+	newSig := &builtinRegexpReplaceFuncSig{}
+	newSig.clone(&b.regexpBaseFuncSig)
+	return newSig
+}
+
+// evalString evals `REGEXP_REPLACE(expr, pat, repl[, pos[, occurrence[, match_type]]])`.
+// See https://dev.mysql.com/doc/refman/8.0/en/regexp.html#function_regexp-replace
+func (b *builtinRegexpReplaceFuncSig) evalString(row chunk.Row) (string, bool, error) {
+	p, isNull, err := b.evalParams(row)
+	if isNull || err != nil {
+		return "", true, err
+	}
+	res, err := b.replace(&p)
+	return res, err != nil, err
+}
+
+// replace replaces the occurrence-th match starting from the position, all the matches are replaced if the
+// occurrence is 0.
+func (b *builtinRegexpReplaceFuncSig) replace(p *regexpParams) (string, error) {
+	compiled, err := b.getRegexp(p.pat, p.matchType)
+	if err != nil {
+		return "", err
+	}
+	offset, err := b.byteOffset(p.expr, p.pos)
+	if err != nil {
+		return "", err
+	}
+	n := -1
+	if p.occurrence > 0 {
+		n = int(p.occurrence)
+	}
+	src := p.expr[offset:]
+	matches := compiled.FindAllStringSubmatchIndex(src, n)
+	if n > 0 {
+		if len(matches) < n {
+			return p.expr, nil
+		}
+		matches = matches[n-1:]
+	}
+	template := convertRegexpReplacement(p.repl)
+	var sb strings.Builder
+	sb.WriteString(p.expr[:offset])
+	last := 0
+	for _, match := range matches {
+		sb.WriteString(src[last:match[0]])
+		sb.Write(compiled.ExpandString(nil, template, src, match))
+		last = match[1]
+	}
+	sb.WriteString(src[last:])
+	return sb.String(), nil
+}
+
+// convertRegexpReplacement converts the replacement of MySQL to the template of Go, `$n` is the n-th captured
+// group and the character following a backslash is a literal.
+func convertRegexpReplacement(repl string) string {
+	if !strings.ContainsAny(repl, `$\`) {
+		return repl
+	}
+	var sb strings.Builder
+	for i := 0; i < len(repl); i++ {
+		switch c := repl[i]; {
+		case c == '\\' && i+1 < len(repl):
+			i++
+			if repl[i] == '$' {
+				sb.WriteString("$$")
+			} else {
+				sb.WriteByte(repl[i])
+			}
+		case c == '$':
+			j := i + 1
+			for j < len(repl) && repl[j] >= '0' && repl[j] <= '9' {
+				j++
+			}
+			if j == i+1 {
+				sb.WriteString("$$")
+				continue
+			}
+			sb.WriteString("${" + repl[i+1:j] + "}")
+			i = j - 1
+		default:
+			sb.WriteByte(c)
+		}
+	}
+	return sb.String()
+}
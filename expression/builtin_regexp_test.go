@@ -0,0 +1,190 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/testkit/trequire"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/stretchr/testify/require"
+)
+
+func evalRegexpFunc(t *testing.T, funcName string, args []interface{}) (types.Datum, error) {
+	ctx := createContext(t)
+	f, err := funcs[funcName].getFunction(ctx, datumsToConstants(types.MakeDatums(args...)))
+	require.NoError(t, err)
+	return evalBuiltinFunc(f, chunk.Row{})
+}
+
+func TestRegexpLike(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args  []interface{}
+		match interface{}
+		err   error
+	}{
+		{[]interface{}{"abc", "b"}, int64(1), nil},
+		{[]interface{}{"abc", "^b"}, int64(0), nil},
+		{[]interface{}{"abc", "B"}, int64(0), nil},
+		{[]interface{}{"abc", "B", "i"}, int64(1), nil},
+		{[]interface{}{"abc", "B", "ic"}, int64(0), nil},
+		{[]interface{}{"abc", "B", "ci"}, int64(1), nil},
+		{[]interface{}{"a\nb", "^b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "^b", "m"}, int64(1), nil},
+		{[]interface{}{"a\nb", "a.b"}, int64(0), nil},
+		{[]interface{}{"a\nb", "a.b", "n"}, int64(1), nil},
+		{[]interface{}{"a\nb", "a.b", "u"}, int64(0), nil},
+		{[]interface{}{"你好", "^.好$"}, int64(1), nil},
+		{[]interface{}{nil, "a"}, nil, nil},
+		{[]interface{}{"a", nil}, nil, nil},
+		{[]interface{}{"a", "a", nil}, nil, nil},
+		{[]interface{}{"a", "(", ""}, nil, ErrRegexp},
+		{[]interface{}{"a", "a", "x"}, nil, errIncorrectArgs},
+	}
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%v", tt.args)
+		match, err := evalRegexpFunc(t, ast.RegexpLike, tt.args)
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		trequire.DatumEqual(t, types.NewDatum(tt.match), match, comment)
+	}
+}
+
+func TestRegexpSubstr(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args []interface{}
+		res  interface{}
+		err  error
+	}{
+		{[]interface{}{"abc def ghi", "[a-z]+"}, "abc", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 3}, "ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 4}, nil, nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 2}, "bc", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 6, 1}, "ef", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 12}, nil, nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", 13}, nil, errRegexpIndexOutOfBounds},
+		{[]interface{}{"abc def ghi", "[a-z]+", 0}, nil, errRegexpIndexOutOfBounds},
+		{[]interface{}{"abc def ghi", "[a-z]+", 1, 0}, "abc", nil},
+		{[]interface{}{"ABC def", "[a-z]+", 1, 1, "c"}, "def", nil},
+		{[]interface{}{"ABC def", "[a-z]+", 1, 1, "i"}, "ABC", nil},
+		{[]interface{}{"你好世界", "世.", 2}, "世界", nil},
+		{[]interface{}{"你好世界", "x"}, nil, nil},
+		{[]interface{}{nil, "x"}, nil, nil},
+		{[]interface{}{"a", "a", nil}, nil, nil},
+	}
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%v", tt.args)
+		res, err := evalRegexpFunc(t, ast.RegexpSubstr, tt.args)
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		trequire.DatumEqual(t, types.NewDatum(tt.res), res, comment)
+	}
+}
+
+func TestRegexpInStr(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args []interface{}
+		res  interface{}
+		err  error
+	}{
+		{[]interface{}{"dog cat dog", "dog"}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "dog", 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 2}, int64(9), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 3}, int64(0), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 1, 1}, int64(4), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 2, 1}, int64(12), nil},
+		{[]interface{}{"dog cat dog", "dog", 1, 1, 2}, nil, errIncorrectArgs},
+		{[]interface{}{"dog cat dog", "DOG", 1, 1, 0, "i"}, int64(1), nil},
+		{[]interface{}{"dog cat dog", "DOG", 1, 1, 0, "c"}, int64(0), nil},
+		{[]interface{}{"你好世界", "世界"}, int64(3), nil},
+		{[]interface{}{"你好世界", "世界", 1, 1, 1}, int64(5), nil},
+		{[]interface{}{"dog", "dog", 5}, nil, errRegexpIndexOutOfBounds},
+		{[]interface{}{"dog", "o", nil}, nil, nil},
+	}
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%v", tt.args)
+		res, err := evalRegexpFunc(t, ast.RegexpInStr, tt.args)
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		trequire.DatumEqual(t, types.NewDatum(tt.res), res, comment)
+	}
+}
+
+func TestRegexpReplace(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args []interface{}
+		res  interface{}
+		err  error
+	}{
+		{[]interface{}{"a b c", "b", "X"}, "a X c", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X"}, "X X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 0}, "X X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 3}, "abc def X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 1, 4}, "abc def ghi", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 2}, "aX X X", nil},
+		{[]interface{}{"abc def ghi", "[a-z]+", "X", 5, 1}, "abc X ghi", nil},
+		{[]interface{}{"abc def", "([a-z]+) ([a-z]+)", "$2 $1"}, "def abc", nil},
+		{[]interface{}{"abc", "b", "$"}, "a$c", nil},
+		{[]interface{}{"abc", "b", `\$1`}, "a$1c", nil},
+		{[]interface{}{"ABC", "b", "x", 1, 0, "i"}, "AxC", nil},
+		{[]interface{}{"ABC", "b", "x", 1, 0, "c"}, "ABC", nil},
+		{[]interface{}{"你好世界", ".", "x", 3}, "你好xx", nil},
+		{[]interface{}{"abc", "b", "x", 5}, nil, errRegexpIndexOutOfBounds},
+		{[]interface{}{"abc", "b", nil}, nil, nil},
+	}
+	for _, tt := range tests {
+		comment := fmt.Sprintf("%v", tt.args)
+		res, err := evalRegexpFunc(t, ast.RegexpReplace, tt.args)
+		if tt.err != nil {
+			require.True(t, terror.ErrorEqual(err, tt.err), comment)
+			continue
+		}
+		require.NoError(t, err, comment)
+		trequire.DatumEqual(t, types.NewDatum(tt.res), res, comment)
+	}
+}
+
+func TestRegexpBinaryString(t *testing.T) {
+	t.Parallel()
+	ctx := createContext(t)
+	args := datumsToConstants(types.MakeDatums("你好世界", "世界", 1, 1, 0, "i"))
+	for _, arg := range args[:2] {
+		arg.GetType().Charset, arg.GetType().Collate = charset.CharsetBin, charset.CollationBin
+	}
+	f, err := funcs[ast.RegexpInStr].getFunction(ctx, args)
+	require.NoError(t, err)
+	res, err := evalBuiltinFunc(f, chunk.Row{})
+	require.NoError(t, err)
+	// The positions of the binary strings are counted in bytes.
+	trequire.DatumEqual(t, types.NewDatum(int64(7)), res)
+}
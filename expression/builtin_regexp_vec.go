@@ -0,0 +1,173 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// vecEvalArgs evaluates all the arguments of the input, the buffers must be put back by putBufs.
+func (re *regexpBaseFuncSig) vecEvalArgs(input *chunk.Chunk) ([]*chunk.Column, error) {
+	bufs := make([]*chunk.Column, 0, len(re.args))
+	for i, role := range re.roles {
+		buf, err := re.bufAllocator.get()
+		if err != nil {
+			return bufs, err
+		}
+		bufs = append(bufs, buf)
+		if role.evalType() == types.ETInt {
+			err = re.args[i].VecEvalInt(re.ctx, input, buf)
+		} else {
+			err = re.args[i].VecEvalString(re.ctx, input, buf)
+		}
+		if err != nil {
+			return bufs, err
+		}
+	}
+	return bufs, nil
+}
+
+func (re *regexpBaseFuncSig) putBufs(bufs []*chunk.Column) {
+	for _, buf := range bufs {
+		re.bufAllocator.put(buf)
+	}
+}
+
+// rowParams returns the parameters of the i-th row of the evaluated arguments, isNull is true if any argument is NULL.
+func (re *regexpBaseFuncSig) rowParams(bufs []*chunk.Column, i int) (p regexpParams, isNull bool) {
+	p = regexpParams{pos: 1, occurrence: re.defaultOccurrence}
+	for j, role := range re.roles {
+		if bufs[j].IsNull(i) {
+			return p, true
+		}
+		if role.evalType() == types.ETInt {
+			p.setInt(role, bufs[j].GetInt64(i))
+		} else {
+			p.setString(role, bufs[j].GetString(i))
+		}
+	}
+	return p, false
+}
+
+func (b *builtinRegexpLikeFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpLikeFuncSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putBufs(bufs)
+	if err != nil {
+		return err
+	}
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		p, _ := b.rowParams(bufs, i)
+		if i64s[i], err = b.like(&p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpSubstrFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpSubstrFuncSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putBufs(bufs)
+	if err != nil {
+		return err
+	}
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		p, isNull := b.rowParams(bufs, i)
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		res, isNull, err := b.substr(&p)
+		if err != nil {
+			return err
+		}
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
+
+func (b *builtinRegexpInStrFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpInStrFuncSig) vecEvalInt(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putBufs(bufs)
+	if err != nil {
+		return err
+	}
+	n := input.NumRows()
+	result.ResizeInt64(n, false)
+	result.MergeNulls(bufs...)
+	i64s := result.Int64s()
+	for i := 0; i < n; i++ {
+		if result.IsNull(i) {
+			continue
+		}
+		p, _ := b.rowParams(bufs, i)
+		if i64s[i], err = b.instr(&p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (b *builtinRegexpReplaceFuncSig) vectorized() bool {
+	return true
+}
+
+func (b *builtinRegexpReplaceFuncSig) vecEvalString(input *chunk.Chunk, result *chunk.Column) error {
+	bufs, err := b.vecEvalArgs(input)
+	defer b.putBufs(bufs)
+	if err != nil {
+		return err
+	}
+	n := input.NumRows()
+	result.ReserveString(n)
+	for i := 0; i < n; i++ {
+		p, isNull := b.rowParams(bufs, i)
+		if isNull {
+			result.AppendNull()
+			continue
+		}
+		res, err := b.replace(&p)
+		if err != nil {
+			return err
+		}
+		result.AppendString(res)
+	}
+	return nil
+}
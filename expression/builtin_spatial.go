@@ -0,0 +1,449 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// The geometries are computed on the cartesian plane whatever their SRIDs are, the geographic spatial
+// reference systems are not supported.
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stGeomFromWKBFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stRelationFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinStGeomFromTextSig{}
+	_ builtinFunc = &builtinStGeomFromWKBSig{}
+	_ builtinFunc = &builtinStAsTextSig{}
+	_ builtinFunc = &builtinStAsBinarySig{}
+	_ builtinFunc = &builtinStCoordinateSig{}
+	_ builtinFunc = &builtinStSRIDSig{}
+	_ builtinFunc = &builtinStDistanceSig{}
+	_ builtinFunc = &builtinStRelationSig{}
+)
+
+// setGeometryRetType sets the return type of the function returning the geometries.
+func setGeometryRetType(tp *types.FieldType) {
+	tp.Tp = mysql.TypeGeometry
+	tp.Flen = mysql.MaxBlobWidth
+	types.SetBinChsClnFlag(tp)
+}
+
+// evalGeometry evaluates the argument as a geometry value in the storage format of the spatial types.
+func evalGeometry(ctx sessionctx.Context, arg Expression, row chunk.Row, funcName string) (srid uint32, g *spatial.Geometry, isNull bool, err error) {
+	s, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, nil, isNull, err
+	}
+	srid, g, err = spatial.Decode([]byte(s))
+	if err != nil {
+		return 0, nil, false, errGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return srid, g, false, nil
+}
+
+// evalSRID evaluates the optional SRID argument of the functions constructing the geometries.
+func evalSRID(ctx sessionctx.Context, args []Expression, row chunk.Row, funcName string) (srid uint32, isNull bool, err error) {
+	if len(args) < 2 {
+		return 0, false, nil
+	}
+	v, isNull, err := args[1].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if v < 0 || v > math.MaxUint32 {
+		return 0, false, errIncorrectArgs.GenWithStackByArgs(funcName)
+	}
+	return uint32(v), false, nil
+}
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp)
+	sig := &builtinStGeomFromTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStGeomFromTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinStGeomFromTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromText(wkt [, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinStGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := spatial.ParseWKT(wkt)
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return string(spatial.Encode(srid, g)), false, nil
+}
+
+type stGeomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString}
+	if len(args) == 2 {
+		argTps = append(argTps, types.ETInt)
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp)
+	sig := &builtinStGeomFromWKBSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStGeomFromWKBSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinStGeomFromWKBSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromWKB(wkb [, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinStGeomFromWKBSig) evalString(row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := spatial.DecodeWKB([]byte(wkb))
+	if err != nil {
+		return "", false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return string(spatial.Encode(srid, g)), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Charset, bf.tp.Collate = ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.Flen = mysql.MaxBlobWidth
+	sig := &builtinStAsTextSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStAsTextSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStAsTextSig) Clone() builtinFunc {
+	newSig := &builtinStAsTextSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinStAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = mysql.MaxBlobWidth
+	types.SetBinChsClnFlag(bf.tp)
+	sig := &builtinStAsBinarySig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStAsBinarySig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinStAsBinarySig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalString evals ST_AsBinary(g), which returns the WKB of the geometry.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinStAsBinarySig) evalString(row chunk.Row) (string, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(spatial.EncodeWKB(g)), false, nil
+}
+
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinStCoordinateSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStCoordinateSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinStCoordinateSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_X(p) and ST_Y(p), the argument must be a point.
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html
+func (b *builtinStCoordinateSig) evalReal(row chunk.Row) (float64, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.args[0], row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g.Tp != mysql.GeometryTypePoint {
+		return 0, false, errGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if b.funcName == ast.StX {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flag |= mysql.UnsignedFlag
+	bf.tp.Flen = 10
+	sig := &builtinStSRIDSig{bf}
+	return sig, nil
+}
+
+type builtinStSRIDSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinStSRIDSig) Clone() builtinFunc {
+	newSig := &builtinStSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinStSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	srid, _, isNull, err := evalGeometry(b.ctx, b.args[0], row, ast.StSRID)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(srid), false, nil
+}
+
+// evalGeometryPair evaluates the two geometry arguments of the binary spatial functions, which must
+// have the same SRID.
+func evalGeometryPair(ctx sessionctx.Context, args []Expression, row chunk.Row, funcName string) (g1, g2 *spatial.Geometry, isNull bool, err error) {
+	srid1, g1, isNull, err := evalGeometry(ctx, args[0], row, funcName)
+	if isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	srid2, g2, isNull, err := evalGeometry(ctx, args[1], row, funcName)
+	if isNull || err != nil {
+		return nil, nil, isNull, err
+	}
+	if srid1 != srid2 {
+		return nil, nil, false, errGISDifferentSRIDs.GenWithStackByArgs(funcName, srid1, srid2)
+	}
+	return g1, g2, false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	sig := &builtinStDistanceSig{bf}
+	return sig, nil
+}
+
+type builtinStDistanceSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinStDistanceSig) Clone() builtinFunc {
+	newSig := &builtinStDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance(g1, g2), it returns NULL if any geometry is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinStDistanceSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, ast.StDistance)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	d, ok := spatial.Distance(g1, g2)
+	return d, !ok, nil
+}
+
+type stRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.Flen = 1
+	sig := &builtinStRelationSig{bf, c.funcName}
+	return sig, nil
+}
+
+type builtinStRelationSig struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *builtinStRelationSig) Clone() builtinFunc {
+	newSig := &builtinStRelationSig{funcName: b.funcName}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals ST_Contains(g1, g2), ST_Intersects(g1, g2) and ST_Within(g1, g2).
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinStRelationSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.args, row, b.funcName)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	var res bool
+	switch b.funcName {
+	case ast.StContains:
+		res = spatial.Contains(g1, g2)
+	case ast.StIntersects:
+		res = spatial.Intersects(g1, g2)
+	case ast.StWithin:
+		res = spatial.Within(g1, g2)
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
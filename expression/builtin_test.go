@@ -16,6 +16,7 @@ package expression
 
 import (
 	"reflect"
+	"strings"
 	"sync"
 	"testing"
 
@@ -141,19 +142,35 @@ func TestIsNullFunc(t *testing.T) {
 func TestLock(t *testing.T) {
 	t.Parallel()
 	ctx := createContext(t)
-	lock := funcs[ast.GetLock]
-	f, err := lock.getFunction(ctx, datumsToConstants(types.MakeDatums(nil, 1)))
-	require.NoError(t, err)
-	v, err := evalBuiltinFunc(f, chunk.Row{})
-	require.NoError(t, err)
-	require.Equal(t, int64(1), v.GetInt64())
+	ctx.GetSessionVars().ConnectionID = 5
+	evalLockFunc := func(name string, args ...interface{}) interface{} {
+		f, err := funcs[name].getFunction(ctx, datumsToConstants(types.MakeDatums(args...)))
+		require.NoError(t, err)
+		v, err := evalBuiltinFunc(f, chunk.Row{})
+		require.NoError(t, err)
+		return v.GetValue()
+	}
 
-	releaseLock := funcs[ast.ReleaseLock]
-	f, err = releaseLock.getFunction(ctx, datumsToConstants(types.MakeDatums(1)))
-	require.NoError(t, err)
-	v, err = evalBuiltinFunc(f, chunk.Row{})
-	require.NoError(t, err)
-	require.Equal(t, int64(1), v.GetInt64())
+	require.Equal(t, int64(1), evalLockFunc(ast.IsFreeLock, "a"))
+	require.Nil(t, evalLockFunc(ast.IsUsedLock, "a"))
+	require.Nil(t, evalLockFunc(ast.ReleaseLock, "a"))
+	require.Equal(t, int64(1), evalLockFunc(ast.GetLock, "a", 1))
+	// The lock names are case-insensitive, and a lock can be acquired multiple times.
+	require.Equal(t, int64(1), evalLockFunc(ast.GetLock, "A", nil))
+	require.Equal(t, int64(1), evalLockFunc(ast.GetLock, "b", -1))
+	require.Equal(t, int64(0), evalLockFunc(ast.IsFreeLock, "a"))
+	require.Equal(t, uint64(5), evalLockFunc(ast.IsUsedLock, "a"))
+	require.Equal(t, int64(1), evalLockFunc(ast.ReleaseLock, "a"))
+	require.Equal(t, int64(2), evalLockFunc(ast.ReleaseAllLocks))
+	require.Equal(t, int64(1), evalLockFunc(ast.IsFreeLock, "a"))
+	require.Equal(t, int64(0), evalLockFunc(ast.ReleaseAllLocks))
+
+	for _, lockName := range []interface{}{nil, "", strings.Repeat("a", 65)} {
+		f, err := funcs[ast.GetLock].getFunction(ctx, datumsToConstants(types.MakeDatums(lockName, 1)))
+		require.NoError(t, err)
+		_, err = evalBuiltinFunc(f, chunk.Row{})
+		require.True(t, ErrUserLockWrongName.Equal(err))
+	}
 }
 
 func TestDisplayName(t *testing.T) {
@@ -653,6 +653,14 @@ func getSignatureByPB(ctx sessionctx.Context, sigCode tipb.ScalarFuncSig, tp *ti
 	// 	f = &builtinRegexpSig{base}
 	// case tipb.ScalarFuncSig_RegexpUTF8Sig:
 	// 	f = &builtinRegexpUTF8Sig{base}
+	case tipb.ScalarFuncSig_RegexpLikeSig, tipb.ScalarFuncSig_RegexpLikeUTF8Sig:
+		f = newBuiltinRegexpLikeFuncSig(base)
+	case tipb.ScalarFuncSig_RegexpSubstrSig, tipb.ScalarFuncSig_RegexpSubstrUTF8Sig:
+		f = newBuiltinRegexpSubstrFuncSig(base)
+	case tipb.ScalarFuncSig_RegexpInStrSig, tipb.ScalarFuncSig_RegexpInStrUTF8Sig:
+		f = newBuiltinRegexpInStrFuncSig(base)
+	case tipb.ScalarFuncSig_RegexpReplaceSig, tipb.ScalarFuncSig_RegexpReplaceUTF8Sig:
+		f = newBuiltinRegexpReplaceFuncSig(base)
 	case tipb.ScalarFuncSig_JsonExtractSig:
 		f = &builtinJSONExtractSig{base}
 	case tipb.ScalarFuncSig_JsonUnquoteSig:
@@ -34,6 +34,8 @@ var (
 	ErrInvalidArgumentForLogarithm = dbterror.ClassExpression.NewStd(mysql.ErrInvalidArgumentForLogarithm)
 	ErrIncorrectType               = dbterror.ClassExpression.NewStd(mysql.ErrIncorrectType)
 	ErrInvalidTableSample          = dbterror.ClassExpression.NewStd(mysql.ErrInvalidTableSample)
+	ErrUserLockWrongName           = dbterror.ClassExpression.NewStd(mysql.ErrUserLockWrongName)
+	ErrUserLockDeadlock            = dbterror.ClassExpression.NewStd(mysql.ErrUserLockDeadlock)
 	ErrInternal                    = dbterror.ClassOptimizer.NewStd(mysql.ErrInternal)
 	ErrNoDB                        = dbterror.ClassOptimizer.NewStd(mysql.ErrNoDB)
 
@@ -53,6 +55,13 @@ var (
 	errWrongValueForType             = dbterror.ClassExpression.NewStd(mysql.ErrWrongValueForType)
 	errUnknown                       = dbterror.ClassExpression.NewStd(mysql.ErrUnknown)
 	errSpecificAccessDenied          = dbterror.ClassExpression.NewStd(mysql.ErrSpecificAccessDenied)
+	errGISInvalidData                = dbterror.ClassExpression.NewStd(mysql.ErrGISInvalidData)
+	errGISDifferentSRIDs             = dbterror.ClassExpression.NewStd(mysql.ErrGISDifferentSRIDs)
+	errRegexpIndexOutOfBounds        = dbterror.ClassExpression.NewStd(mysql.ErrRegexpIndexOutOfBounds)
+	errMissingJSONValue              = dbterror.ClassExpression.NewStd(mysql.ErrMissingJSONValue)
+	errMultipleJSONValues            = dbterror.ClassExpression.NewStd(mysql.ErrMultipleJSONValues)
+	errSpNoreturnend                 = dbterror.ClassExpression.NewStd(mysql.ErrSpNoreturnend)
+	errQueryInterrupted              = dbterror.ClassExpression.NewStd(mysql.ErrQueryInterrupted)
 
 	// Sequence usage privilege check.
 	errSequenceAccessDenied      = dbterror.ClassExpression.NewStd(mysql.ErrTableaccessDenied)
@@ -21,7 +21,9 @@ import (
 	"strings"
 
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/chunk"
 )
 
@@ -93,6 +95,12 @@ func (expr *Constant) ExplainNormalizedInfo() string {
 }
 
 func (expr *Constant) format(dt types.Datum) string {
+	// The geometry is shown in the well-known text like `ST_AsText` instead of the binary storage format.
+	if expr.RetType.Tp == mysql.TypeGeometry && !dt.IsNull() {
+		if _, g, err := spatial.Decode(dt.GetBytes()); err == nil {
+			return fmt.Sprintf("\"%v\"", g.WKT())
+		}
+	}
 	switch dt.Kind() {
 	case types.KindNull:
 		return "NULL"
@@ -49,6 +49,12 @@ var unFoldableFunctions = map[string]struct{}{
 	ast.NextVal:   {},
 	ast.LastVal:   {},
 	ast.SetVal:    {},
+
+	ast.GetLock:         {},
+	ast.ReleaseLock:     {},
+	ast.ReleaseAllLocks: {},
+	ast.IsFreeLock:      {},
+	ast.IsUsedLock:      {},
 }
 
 // DisableFoldFunctions stores functions which prevent child scope functions from being constant folded.
@@ -223,15 +229,18 @@ var mutableEffectsFunctions = map[string]struct{}{
 	ast.SetVar:      {},
 	ast.GetVar:      {},
 	ast.AnyValue:    {},
+
+	ast.GetLock:         {},
+	ast.ReleaseLock:     {},
+	ast.ReleaseAllLocks: {},
+	ast.IsFreeLock:      {},
+	ast.IsUsedLock:      {},
 }
 
-// some functions like "get_lock" and "release_lock" currently do NOT have
-// right implementations, but may have noop ones(like with any inputs, always return 1)
+// noopFuncs stores the functions which currently do NOT have right implementations,
+// but may have noop ones(like with any inputs, always return 1)
 // if apps really need these "funcs" to run, we offer sys var(tidb_enable_noop_functions) to enable noop usage
-var noopFuncs = map[string]struct{}{
-	ast.GetLock:     {},
-	ast.ReleaseLock: {},
-}
+var noopFuncs = map[string]struct{}{}
 
 // booleanFunctions stores boolean functions
 var booleanFunctions = map[string]struct{}{
@@ -253,6 +262,7 @@ var booleanFunctions = map[string]struct{}{
 	ast.IsNull:             {},
 	ast.Like:               {},
 	ast.Regexp:             {},
+	ast.RegexpLike:         {},
 	ast.IsIPv4:             {},
 	ast.IsIPv4Compat:       {},
 	ast.IsIPv4Mapped:       {},
@@ -5222,7 +5222,9 @@ func (s *testIntegrationSuite) TestForeignKeyVar(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 
 	tk.MustExec("SET FOREIGN_KEY_CHECKS=1")
-	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows("Warning 8047 variable 'foreign_key_checks' does not yet support value: 1"))
+	tk.MustQuery("SHOW WARNINGS").Check(testkit.Rows())
+	tk.MustQuery("SHOW VARIABLES LIKE 'foreign_key_checks'").Check(testkit.Rows("foreign_key_checks ON"))
+	tk.MustExec("SET FOREIGN_KEY_CHECKS=0")
 }
 
 func (s *testIntegrationSuite) TestUserVarMockWindFunc(c *C) {
@@ -0,0 +1,452 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// BuildRoutineFunction builds the stored function with the qualified name `db.func`.
+// It is implemented in planner/core package to compile the function body, and is used
+// to rebuild a stored function by its name, e.g. when substituting the columns.
+var BuildRoutineFunction func(ctx sessionctx.Context, name string, args []Expression) (Expression, error)
+
+// StoredFunction is a stored function compiled to be evaluated as a scalar function.
+type StoredFunction struct {
+	// Name is the qualified name of the function in lower case.
+	Name   string
+	Params []*types.FieldType
+	// Locals are the types of all the local variables, the parameters come first.
+	Locals []*types.FieldType
+	RetTp  *types.FieldType
+	Body   RoutineStmt
+}
+
+// NewStoredFunction creates a scalar function calling the stored function.
+func NewStoredFunction(ctx sessionctx.Context, fn *StoredFunction, args []Expression) (Expression, error) {
+	funcArgs := make([]Expression, len(args))
+	copy(funcArgs, args)
+	bf, err := newBaseBuiltinFuncWithFieldType(ctx, fn.RetTp, funcArgs)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp = fn.RetTp
+	// The result of a stored function has the implicit coercibility like a column.
+	if fn.RetTp.EvalType() == types.ETString {
+		bf.SetCoercibility(CoercibilityImplicit)
+	} else {
+		bf.SetCoercibility(CoercibilityNumeric)
+	}
+	sig := &builtinStoredFunctionSig{baseBuiltinFunc: bf, fn: fn}
+	return FoldConstant(&ScalarFunction{
+		FuncName: model.NewCIStr(fn.Name),
+		RetType:  fn.RetTp,
+		Function: sig,
+	}), nil
+}
+
+// NewSignalError creates the error raised by a SIGNAL statement. The error code and the
+// message are derived from the class of the SQLSTATE value if they are not specified.
+func NewSignalError(sqlState string, code uint16, msg string) *mysql.SQLError {
+	if code == 0 {
+		switch {
+		case strings.HasPrefix(sqlState, "01"):
+			code = mysql.ErrSignalWarn
+		case strings.HasPrefix(sqlState, "02"):
+			code = mysql.ErrSignalNotFound
+		default:
+			code = mysql.ErrSignalException
+		}
+	}
+	if msg == "" {
+		switch {
+		case strings.HasPrefix(sqlState, "01"):
+			msg = mysql.MySQLErrName[mysql.ErrSignalWarn].Raw
+		case strings.HasPrefix(sqlState, "02"):
+			msg = mysql.MySQLErrName[mysql.ErrSignalNotFound].Raw
+		default:
+			msg = mysql.MySQLErrName[mysql.ErrSignalException].Raw
+		}
+	}
+	return &mysql.SQLError{Code: code, Message: msg, State: sqlState}
+}
+
+type builtinStoredFunctionSig struct {
+	baseBuiltinFunc
+
+	fn *StoredFunction
+}
+
+func (b *builtinStoredFunctionSig) Clone() builtinFunc {
+	fn := *b.fn
+	fn.Body = b.fn.Body.clone()
+	newSig := &builtinStoredFunctionSig{fn: &fn}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+func (b *builtinStoredFunctionSig) equal(fun builtinFunc) bool {
+	sig, ok := fun.(*builtinStoredFunctionSig)
+	return ok && b.fn.Name == sig.fn.Name && b.baseBuiltinFunc.equal(fun)
+}
+
+func (b *builtinStoredFunctionSig) evalDatum(row chunk.Row) (types.Datum, bool, error) {
+	s := &routineState{ctx: b.ctx, frame: chunk.MutRowFromTypes(b.fn.Locals)}
+	for i, arg := range b.args {
+		d, err := arg.Eval(row)
+		if err != nil {
+			return types.Datum{}, false, err
+		}
+		if err = s.setVar(i, b.fn.Params[i], d); err != nil {
+			return types.Datum{}, false, err
+		}
+	}
+	if err := b.fn.Body.exec(s); err != nil {
+		return types.Datum{}, false, err
+	}
+	if !s.returned {
+		return types.Datum{}, false, errSpNoreturnend.GenWithStackByArgs(b.fn.Name)
+	}
+	d, err := s.ret.ConvertTo(b.ctx.GetSessionVars().StmtCtx, b.tp)
+	if err != nil {
+		return types.Datum{}, false, err
+	}
+	return d, d.IsNull(), nil
+}
+
+func (b *builtinStoredFunctionSig) evalInt(row chunk.Row) (res int64, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetInt64(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalReal(row chunk.Row) (res float64, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetFloat64(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalDecimal(row chunk.Row) (res *types.MyDecimal, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlDecimal(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalString(row chunk.Row) (res string, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetString(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalTime(row chunk.Row) (res types.Time, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlTime(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalDuration(row chunk.Row) (res types.Duration, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlDuration(), false, nil
+}
+
+func (b *builtinStoredFunctionSig) evalJSON(row chunk.Row) (res json.BinaryJSON, isNull bool, err error) {
+	d, isNull, err := b.evalDatum(row)
+	if isNull || err != nil {
+		return res, isNull, err
+	}
+	return d.GetMysqlJSON(), false, nil
+}
+
+// routineState is the state of an invocation of a stored function.
+type routineState struct {
+	ctx sessionctx.Context
+	// frame holds the values of the local variables.
+	frame chunk.MutRow
+	// jump is the pending LEAVE or ITERATE statement.
+	jump     *RoutineJump
+	ret      types.Datum
+	returned bool
+}
+
+func (s *routineState) row() chunk.Row {
+	return s.frame.ToRow()
+}
+
+func (s *routineState) done() bool {
+	return s.returned || s.jump != nil
+}
+
+func (s *routineState) setVar(slot int, tp *types.FieldType, d types.Datum) error {
+	sc := s.ctx.GetSessionVars().StmtCtx
+	v, err := d.ConvertTo(sc, tp)
+	if err = sc.HandleTruncate(err); err != nil {
+		return err
+	}
+	s.frame.SetDatum(slot, v)
+	return nil
+}
+
+func (s *routineState) evalCond(cond Expression) (bool, error) {
+	if atomic.LoadUint32(&s.ctx.GetSessionVars().Killed) == 1 {
+		return false, errQueryInterrupted
+	}
+	ok, _, err := EvalBool(s.ctx, CNFExprs{cond}, s.row())
+	return ok, err
+}
+
+// RoutineStmt is a compiled statement of a stored function.
+type RoutineStmt interface {
+	exec(s *routineState) error
+	clone() RoutineStmt
+}
+
+func execRoutineStmts(s *routineState, stmts []RoutineStmt) error {
+	for _, stmt := range stmts {
+		if err := stmt.exec(s); err != nil {
+			return err
+		}
+		if s.done() {
+			return nil
+		}
+	}
+	return nil
+}
+
+func cloneRoutineStmts(stmts []RoutineStmt) []RoutineStmt {
+	cloned := make([]RoutineStmt, len(stmts))
+	for i, stmt := range stmts {
+		cloned[i] = stmt.clone()
+	}
+	return cloned
+}
+
+func cloneExpr(expr Expression) Expression {
+	if expr == nil {
+		return nil
+	}
+	return expr.Clone()
+}
+
+// RoutineBlock is a BEGIN ... END block.
+type RoutineBlock struct {
+	// Label is the label of the block in lower case.
+	Label string
+	Stmts []RoutineStmt
+}
+
+func (b *RoutineBlock) exec(s *routineState) error {
+	if err := execRoutineStmts(s, b.Stmts); err != nil {
+		return err
+	}
+	if s.jump != nil && s.jump.Tp == ast.JumpTypeLeave && s.jump.Label == b.Label {
+		s.jump = nil
+	}
+	return nil
+}
+
+func (b *RoutineBlock) clone() RoutineStmt {
+	return &RoutineBlock{Label: b.Label, Stmts: cloneRoutineStmts(b.Stmts)}
+}
+
+// RoutineSet assigns a value to a local variable, a nil Expr assigns NULL.
+type RoutineSet struct {
+	Slot int
+	Tp   *types.FieldType
+	Expr Expression
+}
+
+func (r *RoutineSet) exec(s *routineState) error {
+	var d types.Datum
+	if r.Expr != nil {
+		var err error
+		if d, err = r.Expr.Eval(s.row()); err != nil {
+			return err
+		}
+	}
+	return s.setVar(r.Slot, r.Tp, d)
+}
+
+func (r *RoutineSet) clone() RoutineStmt {
+	return &RoutineSet{Slot: r.Slot, Tp: r.Tp, Expr: cloneExpr(r.Expr)}
+}
+
+// RoutineIf is an IF statement, Blocks[i] is executed when Conds[i] is true.
+type RoutineIf struct {
+	Conds  []Expression
+	Blocks [][]RoutineStmt
+	Else   []RoutineStmt
+}
+
+func (r *RoutineIf) exec(s *routineState) error {
+	for i, cond := range r.Conds {
+		ok, err := s.evalCond(cond)
+		if err != nil {
+			return err
+		}
+		if ok {
+			return execRoutineStmts(s, r.Blocks[i])
+		}
+	}
+	return execRoutineStmts(s, r.Else)
+}
+
+func (r *RoutineIf) clone() RoutineStmt {
+	cloned := &RoutineIf{
+		Conds:  make([]Expression, len(r.Conds)),
+		Blocks: make([][]RoutineStmt, len(r.Blocks)),
+		Else:   cloneRoutineStmts(r.Else),
+	}
+	for i := range r.Conds {
+		cloned.Conds[i] = r.Conds[i].Clone()
+		cloned.Blocks[i] = cloneRoutineStmts(r.Blocks[i])
+	}
+	return cloned
+}
+
+// RoutineLoop is a LOOP, WHILE or REPEAT statement.
+type RoutineLoop struct {
+	// Label is the label of the loop in lower case.
+	Label string
+	Tp    ast.LoopType
+	Cond  Expression
+	Stmts []RoutineStmt
+}
+
+func (r *RoutineLoop) exec(s *routineState) error {
+	for {
+		if r.Tp == ast.LoopTypeWhile {
+			ok, err := s.evalCond(r.Cond)
+			if err != nil || !ok {
+				return err
+			}
+		} else if atomic.LoadUint32(&s.ctx.GetSessionVars().Killed) == 1 {
+			return errQueryInterrupted
+		}
+		if err := execRoutineStmts(s, r.Stmts); err != nil {
+			return err
+		}
+		if s.returned {
+			return nil
+		}
+		if s.jump != nil {
+			if s.jump.Label != r.Label {
+				return nil
+			}
+			tp := s.jump.Tp
+			s.jump = nil
+			if tp == ast.JumpTypeLeave {
+				return nil
+			}
+			continue
+		}
+		if r.Tp == ast.LoopTypeRepeat {
+			ok, err := s.evalCond(r.Cond)
+			if err != nil || ok {
+				return err
+			}
+		}
+	}
+}
+
+func (r *RoutineLoop) clone() RoutineStmt {
+	return &RoutineLoop{Label: r.Label, Tp: r.Tp, Cond: cloneExpr(r.Cond), Stmts: cloneRoutineStmts(r.Stmts)}
+}
+
+// RoutineJump is a LEAVE or ITERATE statement.
+type RoutineJump struct {
+	Tp ast.JumpType
+	// Label is the target label in lower case.
+	Label string
+}
+
+func (r *RoutineJump) exec(s *routineState) error {
+	s.jump = r
+	return nil
+}
+
+func (r *RoutineJump) clone() RoutineStmt {
+	return r
+}
+
+// RoutineReturn is a RETURN statement.
+type RoutineReturn struct {
+	Expr Expression
+}
+
+func (r *RoutineReturn) exec(s *routineState) (err error) {
+	s.ret, err = r.Expr.Eval(s.row())
+	s.returned = err == nil
+	return err
+}
+
+func (r *RoutineReturn) clone() RoutineStmt {
+	return &RoutineReturn{Expr: r.Expr.Clone()}
+}
+
+// RoutineSignal is a SIGNAL statement, Code and Message are nil if they are not specified.
+type RoutineSignal struct {
+	SQLState string
+	Code     Expression
+	Message  Expression
+}
+
+func (r *RoutineSignal) exec(s *routineState) error {
+	var code int64
+	var msg string
+	if r.Code != nil {
+		var err error
+		if code, _, err = r.Code.EvalInt(s.ctx, s.row()); err != nil {
+			return err
+		}
+	}
+	if r.Message != nil {
+		var err error
+		if msg, _, err = r.Message.EvalString(s.ctx, s.row()); err != nil {
+			return err
+		}
+	}
+	err := NewSignalError(r.SQLState, uint16(code), msg)
+	if strings.HasPrefix(r.SQLState, "01") {
+		s.ctx.GetSessionVars().StmtCtx.AppendWarning(err)
+		return nil
+	}
+	return err
+}
+
+func (r *RoutineSignal) clone() RoutineStmt {
+	return &RoutineSignal{SQLState: r.SQLState, Code: cloneExpr(r.Code), Message: cloneExpr(r.Message)}
+}
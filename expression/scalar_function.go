@@ -17,6 +17,7 @@ package expression
 import (
 	"bytes"
 	"fmt"
+	"strings"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/parser/ast"
@@ -183,9 +184,15 @@ func newFunctionImpl(ctx sessionctx.Context, fold int, funcName string, retType
 		return BuildCastFunction(ctx, args[0], retType), nil
 	case ast.GetVar:
 		return BuildGetVarFunction(ctx, args[0], retType)
+	case ast.JSONValue:
+		return buildJSONValueFunction(ctx, args, retType)
 	}
 	fc, ok := funcs[funcName]
 	if !ok {
+		if strings.Contains(funcName, ".") && BuildRoutineFunction != nil {
+			// Only the stored functions have qualified names.
+			return BuildRoutineFunction(ctx, funcName, args)
+		}
 		db := ctx.GetSessionVars().CurrentDB
 		if db == "" {
 			return nil, errors.Trace(ErrNoDB)
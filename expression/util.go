@@ -948,6 +948,44 @@ func containMutableConst(ctx sessionctx.Context, exprs []Expression) bool {
 	return false
 }
 
+// SetCtxForPlanCache binds the expressions cloned from a plan cached by another session to the
+// session which reuses the plan, so they are evaluated with its variables and parameters.
+// The parameter markers and deferred expressions are replaced in place, so the expressions must
+// be cloned before. It returns false if some expressions can not be shared between the sessions.
+func SetCtxForPlanCache(ctx sessionctx.Context, exprs []Expression) bool {
+	for _, expr := range exprs {
+		switch v := expr.(type) {
+		case *Column:
+			if v.VirtualExpr != nil {
+				return false
+			}
+		case *CorrelatedColumn:
+			// The data of the correlated columns is shared by the cloned expressions.
+			return false
+		case *Constant:
+			if v.ParamMarker != nil {
+				v.ParamMarker = &ParamMarker{ctx: ctx, order: v.ParamMarker.order}
+			}
+			if v.DeferredExpr != nil {
+				v.DeferredExpr = v.DeferredExpr.Clone()
+				if !SetCtxForPlanCache(ctx, []Expression{v.DeferredExpr}) {
+					return false
+				}
+			}
+		case *ScalarFunction:
+			// The body of the stored function is bound to the session which builds it.
+			if _, ok := v.Function.(*builtinStoredFunctionSig); ok {
+				return false
+			}
+			v.Function.setCtx(ctx)
+			if !SetCtxForPlanCache(ctx, v.GetArgs()) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // RemoveMutableConst used to remove the `ParamMarker` and `DeferredExpr` in the `Constant` expr.
 func RemoveMutableConst(ctx sessionctx.Context, exprs []Expression) {
 	for _, expr := range exprs {
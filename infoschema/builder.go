@@ -62,6 +62,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		return b.applyDropPolicy(diff.SchemaID), nil
 	case model.ActionAlterPlacementPolicy:
 		return b.applyAlterPolicy(m, diff)
+	case model.ActionCreateRoutine, model.ActionDropRoutine:
+		return nil, b.applyRoutines(m, diff)
 	}
 	roDBInfo, ok := b.is.SchemaByID(diff.SchemaID)
 	if !ok {
@@ -75,7 +77,7 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		newTableID = diff.TableID
 	case model.ActionDropTable, model.ActionDropView, model.ActionDropSequence:
 		oldTableID = diff.TableID
-	case model.ActionTruncateTable, model.ActionCreateView, model.ActionExchangeTablePartition:
+	case model.ActionTruncateTable, model.ActionCreateView, model.ActionExchangeTablePartition, model.ActionRemovePartitioning:
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
 	default:
@@ -90,7 +92,7 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 		}
 	case model.ActionDropTable:
 		b.applyPlacementDelete(placement.GroupID(oldTableID))
-	case model.ActionTruncateTable:
+	case model.ActionTruncateTable, model.ActionRemovePartitioning:
 		b.applyPlacementDelete(placement.GroupID(oldTableID))
 		if err := b.applyPlacementUpdate(placement.GroupID(newTableID)); err != nil {
 			return nil, errors.Trace(err)
@@ -165,7 +167,8 @@ func (b *Builder) ApplyDiff(m *meta.Meta, diff *model.SchemaDiff) ([]int64, erro
 					return nil, errors.Trace(err)
 				}
 				continue
-			case model.ActionDropTable, model.ActionDropTablePartition:
+			case model.ActionDropTable, model.ActionDropTablePartition, model.ActionReorganizePartition,
+				model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 				b.applyPlacementDelete(placement.GroupID(opt.OldTableID))
 				continue
 			case model.ActionTruncateTable:
@@ -307,6 +310,23 @@ func (b *Builder) applyModifySchemaCharsetAndCollate(m *meta.Meta, diff *model.S
 	return nil
 }
 
+// applyRoutines reloads the stored procedures and functions of the schema.
+func (b *Builder) applyRoutines(m *meta.Meta, diff *model.SchemaDiff) error {
+	roDBInfo, ok := b.is.SchemaByID(diff.SchemaID)
+	if !ok {
+		return ErrDatabaseNotExists.GenWithStackByArgs(
+			fmt.Sprintf("(Schema ID %d)", diff.SchemaID),
+		)
+	}
+	routines, err := m.ListRoutines(diff.SchemaID)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	newDbInfo := b.copySchemaTables(roDBInfo.Name.L)
+	newDbInfo.Routines = routines
+	return nil
+}
+
 func (b *Builder) applyModifySchemaDefaultPlacement(m *meta.Meta, diff *model.SchemaDiff) error {
 	di, err := m.GetDatabase(diff.SchemaID)
 	if err != nil {
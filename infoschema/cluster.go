@@ -44,6 +44,10 @@ const (
 	ClusterTableTiDBTrx = "CLUSTER_TIDB_TRX"
 	// ClusterTableDeadlocks is the string constant of cluster dead lock table.
 	ClusterTableDeadlocks = "CLUSTER_DEADLOCKS"
+	// ClusterTableAdvisoryLocks is the string constant of cluster advisory locks table.
+	ClusterTableAdvisoryLocks = "CLUSTER_ADVISORY_LOCKS"
+	// ClusterTableInstancePlanCache is the string constant of cluster instance plan cache table.
+	ClusterTableInstancePlanCache = "CLUSTER_INSTANCE_PLAN_CACHE"
 )
 
 // memTableToClusterTables means add memory table to cluster table.
@@ -55,6 +59,8 @@ var memTableToClusterTables = map[string]string{
 	TableStatementsSummaryEvicted: ClusterTableStatementsSummaryEvicted,
 	TableTiDBTrx:                  ClusterTableTiDBTrx,
 	TableDeadlocks:                ClusterTableDeadlocks,
+	TableAdvisoryLocks:            ClusterTableAdvisoryLocks,
+	TableInstancePlanCache:        ClusterTableInstancePlanCache,
 }
 
 func init() {
@@ -32,6 +32,14 @@ var (
 	ErrPlacementPolicyExists = dbterror.ClassSchema.NewStd(mysql.ErrPlacementPolicyExists)
 	// ErrPlacementPolicyNotExists return for placement_policy policy not exists.
 	ErrPlacementPolicyNotExists = dbterror.ClassSchema.NewStd(mysql.ErrPlacementPolicyNotExists)
+	// ErrRoutineExists returns for stored procedure or function already exists.
+	ErrRoutineExists = dbterror.ClassSchema.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists returns for stored procedure or function not exists.
+	ErrRoutineNotExists = dbterror.ClassSchema.NewStd(mysql.ErrSpDoesNotExist)
+	// ErrTriggerExists returns for trigger already exists.
+	ErrTriggerExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTriggerNotExists returns for trigger not exists.
+	ErrTriggerNotExists = dbterror.ClassSchema.NewStd(mysql.ErrTrgDoesNotExist)
 	// ErrReservedSyntax  for internal syntax.
 	ErrReservedSyntax = dbterror.ClassSchema.NewStd(mysql.ErrReservedSyntax)
 	// ErrTableExists returns for table already exists.
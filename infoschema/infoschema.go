@@ -59,6 +59,8 @@ type InfoSchema interface {
 	RuleBundles() []*placement.Bundle
 	// AllPlacementPolicies returns all placement policies
 	AllPlacementPolicies() []*model.PolicyInfo
+	// RoutineByName returns the stored procedure or function of the given type.
+	RoutineByName(schema, name model.CIStr, tp model.RoutineType) (*model.RoutineInfo, bool)
 }
 
 type sortedTables []table.Table
@@ -329,6 +331,19 @@ func GetSequenceByName(is InfoSchema, schema, sequence model.CIStr) (util.Sequen
 	return tbl.(util.SequenceTable), nil
 }
 
+// TriggerByName gets the trigger by name and the table which the trigger is created on.
+// The names of triggers are unique in a schema.
+func TriggerByName(is InfoSchema, schema, trigger model.CIStr) (table.Table, *model.TriggerInfo, bool) {
+	for _, tbl := range is.SchemaTables(schema) {
+		for _, info := range tbl.Meta().Triggers {
+			if info.Name.L == trigger.L {
+				return tbl, info, true
+			}
+		}
+	}
+	return nil, nil, false
+}
+
 func init() {
 	// Initialize the information shema database and register the driver to `drivers`
 	dbID := autoid.InformationSchemaDBID
@@ -376,6 +391,20 @@ func (is *infoSchema) PolicyByName(name model.CIStr) (*model.PolicyInfo, bool) {
 	return t, r
 }
 
+// RoutineByName is used to find the stored procedure or function.
+func (is *infoSchema) RoutineByName(schema, name model.CIStr, tp model.RoutineType) (*model.RoutineInfo, bool) {
+	tableNames, ok := is.schemaMap[schema.L]
+	if !ok {
+		return nil, false
+	}
+	for _, routine := range tableNames.dbInfo.Routines {
+		if routine.Type == tp && routine.Name.L == name.L {
+			return routine, true
+		}
+	}
+	return nil, false
+}
+
 // AllPlacementPolicies returns all placement policies
 func (is *infoSchema) AllPlacementPolicies() []*model.PolicyInfo {
 	is.policyMutex.RLock()
@@ -46,6 +46,7 @@ var perfSchemaTables = []string{
 	tablePDProfileAllocs,
 	tablePDProfileBlock,
 	tablePDProfileGoroutines,
+	tableMetadataLocks,
 }
 
 // tableGlobalStatus contains the column name definitions for table global_status, same as MySQL.
@@ -544,3 +545,17 @@ const tablePDProfileGoroutines = "CREATE TABLE IF NOT EXISTS " + tableNamePDProf
 	"ID INT(8) NOT NULL," +
 	"STATE VARCHAR(16) NOT NULL," +
 	"LOCATION VARCHAR(512) NOT NULL);"
+
+// tableMetadataLocks contains the column name definitions for table metadata_locks, same as MySQL.
+// Only the advisory locks, that is the USER LEVEL LOCK objects, are recorded in TiDB.
+const tableMetadataLocks = "CREATE TABLE if not exists performance_schema." + tableNameMetadataLocks + " (" +
+	"OBJECT_TYPE		VARCHAR(64) NOT NULL," +
+	"OBJECT_SCHEMA		VARCHAR(64)," +
+	"OBJECT_NAME		VARCHAR(64)," +
+	"OBJECT_INSTANCE_BEGIN	BIGINT(20) UNSIGNED NOT NULL," +
+	"LOCK_TYPE		VARCHAR(32) NOT NULL," +
+	"LOCK_DURATION		VARCHAR(32) NOT NULL," +
+	"LOCK_STATUS		VARCHAR(32) NOT NULL," +
+	"SOURCE			VARCHAR(64)," +
+	"OWNER_THREAD_ID		BIGINT(20) UNSIGNED," +
+	"OWNER_EVENT_ID		BIGINT(20) UNSIGNED);"
@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/advisorylock"
 	"github.com/pingcap/tidb/util/profile"
 )
 
@@ -68,6 +69,7 @@ const (
 	tableNamePDProfileAllocs                 = "pd_profile_allocs"
 	tableNamePDProfileBlock                  = "pd_profile_block"
 	tableNamePDProfileGoroutines             = "pd_profile_goroutines"
+	tableNameMetadataLocks                   = "metadata_locks"
 )
 
 var tableIDMap = map[string]int64{
@@ -101,6 +103,7 @@ var tableIDMap = map[string]int64{
 	tableNamePDProfileAllocs:                 autoid.PerformanceSchemaDBID + 28,
 	tableNamePDProfileBlock:                  autoid.PerformanceSchemaDBID + 29,
 	tableNamePDProfileGoroutines:             autoid.PerformanceSchemaDBID + 30,
+	tableNameMetadataLocks:                   autoid.PerformanceSchemaDBID + 31,
 }
 
 // perfSchemaTable stands for the fake table all its data is in the memory.
@@ -247,6 +250,8 @@ func (vt *perfSchemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 		fullRows, err = dataForRemoteProfile(ctx, "pd", "/pd/api/v1/debug/pprof/block", false)
 	case tableNamePDProfileGoroutines:
 		fullRows, err = dataForRemoteProfile(ctx, "pd", "/pd/api/v1/debug/pprof/goroutine?debug=2", true)
+	case tableNameMetadataLocks:
+		fullRows = dataForMetadataLocks()
 	}
 	if err != nil {
 		return
@@ -284,6 +289,27 @@ func (vt *perfSchemaTable) IterRecords(ctx sessionctx.Context, cols []*table.Col
 	return nil
 }
 
+// dataForMetadataLocks returns the advisory locks held or waited for by the sessions of this instance.
+func dataForMetadataLocks() [][]types.Datum {
+	locks := advisorylock.GlobalRegistry.GetAll()
+	rows := make([][]types.Datum, 0, len(locks))
+	for _, lock := range locks {
+		rows = append(rows, types.MakeDatums(
+			"USER LEVEL LOCK", // OBJECT_TYPE
+			nil,               // OBJECT_SCHEMA
+			lock.Name,         // OBJECT_NAME
+			uint64(0),         // OBJECT_INSTANCE_BEGIN
+			"EXCLUSIVE",       // LOCK_TYPE
+			"EXPLICIT",        // LOCK_DURATION
+			lock.State,        // LOCK_STATUS
+			nil,               // SOURCE
+			lock.SessionID,    // OWNER_THREAD_ID
+			nil,               // OWNER_EVENT_ID
+		))
+	}
+	return rows
+}
+
 func dataForRemoteProfile(ctx sessionctx.Context, nodeType, uri string, isGoroutine bool) ([][]types.Datum, error) {
 	var (
 		servers []infoschema.ServerInfo
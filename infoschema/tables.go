@@ -44,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/advisorylock"
 	"github.com/pingcap/tidb/util/deadlockhistory"
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/pdapi"
@@ -80,7 +81,8 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
-	tableTriggers    = "TRIGGERS"
+	// TableTriggers is the string constant of infoschema table.
+	TableTriggers = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
 	tableSchemaPrivileges = "SCHEMA_PRIVILEGES"
@@ -89,8 +91,9 @@ const (
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews           = "VIEWS"
-	tableRoutines        = "ROUTINES"
+	TableViews = "VIEWS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines        = "ROUTINES"
 	tableParameters      = "PARAMETERS"
 	tableEvents          = "EVENTS"
 	tableGlobalStatus    = "GLOBAL_STATUS"
@@ -178,6 +181,12 @@ const (
 	TableAttributes = "ATTRIBUTES"
 	// TablePlacementRules is the string constant of placement rules table.
 	TablePlacementRules = "PLACEMENT_RULES"
+	// TableCheckConstraints is the string constant of CHECK_CONSTRAINTS.
+	TableCheckConstraints = "CHECK_CONSTRAINTS"
+	// TableAdvisoryLocks is the string constant of advisory locks table.
+	TableAdvisoryLocks = "ADVISORY_LOCKS"
+	// TableInstancePlanCache is the string constant of instance plan cache table.
+	TableInstancePlanCache = "INSTANCE_PLAN_CACHE"
 )
 
 const (
@@ -212,14 +221,14 @@ var tableIDMap = map[string]int64{
 	TableSessionVar:                         autoid.InformationSchemaDBID + 14,
 	tablePlugins:                            autoid.InformationSchemaDBID + 15,
 	TableConstraints:                        autoid.InformationSchemaDBID + 16,
-	tableTriggers:                           autoid.InformationSchemaDBID + 17,
+	TableTriggers:                           autoid.InformationSchemaDBID + 17,
 	TableUserPrivileges:                     autoid.InformationSchemaDBID + 18,
 	tableSchemaPrivileges:                   autoid.InformationSchemaDBID + 19,
 	tableTablePrivileges:                    autoid.InformationSchemaDBID + 20,
 	tableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
 	TableEngines:                            autoid.InformationSchemaDBID + 22,
 	TableViews:                              autoid.InformationSchemaDBID + 23,
-	tableRoutines:                           autoid.InformationSchemaDBID + 24,
+	TableRoutines:                           autoid.InformationSchemaDBID + 24,
 	tableParameters:                         autoid.InformationSchemaDBID + 25,
 	tableEvents:                             autoid.InformationSchemaDBID + 26,
 	tableGlobalStatus:                       autoid.InformationSchemaDBID + 27,
@@ -276,6 +285,11 @@ var tableIDMap = map[string]int64{
 	TableAttributes:                      autoid.InformationSchemaDBID + 77,
 	TableTiDBHotRegionsHistory:           autoid.InformationSchemaDBID + 78,
 	TablePlacementRules:                  autoid.InformationSchemaDBID + 79,
+	TableCheckConstraints:                autoid.InformationSchemaDBID + 80,
+	TableAdvisoryLocks:                   autoid.InformationSchemaDBID + 81,
+	ClusterTableAdvisoryLocks:            autoid.InformationSchemaDBID + 82,
+	TableInstancePlanCache:               autoid.InformationSchemaDBID + 83,
+	ClusterTableInstancePlanCache:        autoid.InformationSchemaDBID + 84,
 }
 
 type columnInfo struct {
@@ -574,6 +588,13 @@ var tableConstraintsCols = []columnInfo{
 	{name: "CONSTRAINT_TYPE", tp: mysql.TypeVarchar, size: 64},
 }
 
+var tableCheckConstraintsCols = []columnInfo{
+	{name: "CONSTRAINT_CATALOG", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_SCHEMA", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CONSTRAINT_NAME", tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag},
+	{name: "CHECK_CLAUSE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength, flag: mysql.NotNullFlag},
+}
+
 var tableTriggersCols = []columnInfo{
 	{name: "TRIGGER_CATALOG", tp: mysql.TypeVarchar, size: 512},
 	{name: "TRIGGER_SCHEMA", tp: mysql.TypeVarchar, size: 64},
@@ -1269,6 +1290,7 @@ var tableStatementsSummaryCols = []columnInfo{
 	{name: stmtsummary.LastSeenStr, tp: mysql.TypeTimestamp, size: 26, flag: mysql.NotNullFlag, comment: "The time these statements are seen for the last time"},
 	{name: stmtsummary.PlanInCacheStr, tp: mysql.TypeTiny, size: 1, flag: mysql.NotNullFlag, comment: "Whether the last statement hit plan cache"},
 	{name: stmtsummary.PlanCacheHitsStr, tp: mysql.TypeLonglong, size: 20, flag: mysql.NotNullFlag, comment: "The number of times these statements hit plan cache"},
+	{name: stmtsummary.PlanCacheMissesStr, tp: mysql.TypeLonglong, size: 20, flag: mysql.NotNullFlag, comment: "The number of times these statements could use plan cache but missed it"},
 	{name: stmtsummary.PlanInBindingStr, tp: mysql.TypeTiny, size: 1, flag: mysql.NotNullFlag, comment: "Whether the last statement is matched with the hints in the binding"},
 	{name: stmtsummary.QuerySampleTextStr, tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "Sampled original statement"},
 	{name: stmtsummary.PrevSampleTextStr, tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The previous statement before commit"},
@@ -1428,6 +1450,23 @@ var tableDeadlocksCols = []columnInfo{
 	{name: deadlockhistory.ColTrxHoldingLockStr, tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The transaction ID (start ts) of the transaction that's currently holding the lock"},
 }
 
+var tableAdvisoryLocksCols = []columnInfo{
+	{name: advisorylock.ColLockNameStr, tp: mysql.TypeVarchar, size: 64, flag: mysql.NotNullFlag, comment: "The name of the advisory lock"},
+	{name: advisorylock.ColSessionIDStr, tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "Which session holds or waits for the lock"},
+	{name: advisorylock.ColStateStr, tp: mysql.TypeVarchar, size: 16, flag: mysql.NotNullFlag, comment: "GRANTED if the lock is held by the session, PENDING if the session is waiting for it"},
+	{name: advisorylock.ColStartTimeStr, tp: mysql.TypeTimestamp, decimal: 6, size: 26, comment: "The time when the lock is granted or the waiting begins"},
+}
+
+var tableInstancePlanCacheCols = []columnInfo{
+	{name: "ENTRY_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the plans in the cache"},
+	{name: "MEMORY_USAGE", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The estimated memory usage of the plans in bytes"},
+	{name: "MEMORY_QUOTA", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The memory quota of the cache in bytes"},
+	{name: "HIT_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the executions which reuse a cached plan"},
+	{name: "MISS_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the executions which find no usable plan in the cache"},
+	{name: "EVICTION_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The number of the plans evicted because of the memory quota"},
+	{name: "EVICTED_MEMORY", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag | mysql.UnsignedFlag, comment: "The estimated memory usage of the evicted plans in bytes"},
+}
+
 var tableDataLockWaitsCols = []columnInfo{
 	{name: DataLockWaitsColumnKey, tp: mysql.TypeBlob, size: types.UnspecifiedLength, flag: mysql.NotNullFlag, comment: "The key that's being waiting on"},
 	{name: DataLockWaitsColumnKeyInfo, tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "Information of the key"},
@@ -1500,6 +1539,8 @@ const (
 	PrimaryConstraint = "PRIMARY"
 	// UniqueKeyType is the string constant of UNIQUE.
 	UniqueKeyType = "UNIQUE"
+	// CheckConstraintType is the string constant of CHECK.
+	CheckConstraintType = "CHECK"
 )
 
 // ServerInfo represents the basic server information of single cluster component
@@ -1783,14 +1824,14 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableSessionVar:                         sessionVarCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
-	tableTriggers:                           tableTriggersCols,
+	TableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
 	tableTablePrivileges:                    tableTablePrivilegesCols,
 	tableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
 	tableEvents:                             tableEventsCols,
 	tableGlobalStatus:                       tableGlobalStatusCols,
@@ -1838,6 +1879,9 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableDataLockWaits:                      tableDataLockWaitsCols,
 	TableAttributes:                         tableAttributesCols,
 	TablePlacementRules:                     tablePlacementRulesCols,
+	TableCheckConstraints:                   tableCheckConstraintsCols,
+	TableAdvisoryLocks:                      tableAdvisoryLocksCols,
+	TableInstancePlanCache:                  tableInstancePlanCacheCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
@@ -1879,8 +1923,7 @@ func (it *infoschemaTable) getRows(ctx sessionctx.Context, cols []*table.Column)
 	sort.Sort(SchemasSorter(dbs))
 	switch it.meta.Name.O {
 	case tableFiles:
-	case tablePlugins, tableTriggers:
-	case tableRoutines:
+	case tablePlugins:
 	// TODO: Fill the following tables.
 	case tableSchemaPrivileges:
 	case tableTablePrivileges:
@@ -65,6 +65,7 @@ var (
 	mDBs              = []byte("DBs")
 	mDBPrefix         = "DB"
 	mTablePrefix      = "Table"
+	mRoutinePrefix    = "Routine"
 	mSequencePrefix   = "SID"
 	mSeqCyclePrefix   = "SequenceCycle"
 	mTableIDPrefix    = "TID"
@@ -106,6 +107,10 @@ var (
 	ErrTableExists = dbterror.ClassMeta.NewStd(mysql.ErrTableExists)
 	// ErrTableNotExists is the error for table not exists.
 	ErrTableNotExists = dbterror.ClassMeta.NewStd(mysql.ErrNoSuchTable)
+	// ErrRoutineExists is the error for stored routine exists.
+	ErrRoutineExists = dbterror.ClassMeta.NewStd(mysql.ErrSpAlreadyExists)
+	// ErrRoutineNotExists is the error for stored routine not exists.
+	ErrRoutineNotExists = dbterror.ClassMeta.NewStd(mysql.ErrSpDoesNotExist)
 	// ErrDDLReorgElementNotExist is the error for reorg element not exists.
 	ErrDDLReorgElementNotExist = dbterror.ClassMeta.NewStd(errno.ErrDDLReorgElementNotExist)
 )
@@ -199,6 +204,10 @@ func (m *Meta) tableKey(tableID int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mTablePrefix, tableID))
 }
 
+func (m *Meta) routineKey(routineID int64) []byte {
+	return []byte(fmt.Sprintf("%s:%d", mRoutinePrefix, routineID))
+}
+
 func (m *Meta) sequenceKey(sequenceID int64) []byte {
 	return []byte(fmt.Sprintf("%s:%d", mSequencePrefix, sequenceID))
 }
@@ -487,6 +496,77 @@ func (m *Meta) DropTableOrView(dbID int64, tblID int64) error {
 	return nil
 }
 
+// CreateRoutine creates a stored procedure or function in database.
+func (m *Meta) CreateRoutine(dbID int64, routine *model.RoutineInfo) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routine.ID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v != nil {
+		return ErrRoutineExists.GenWithStack("routine already exists")
+	}
+
+	data, err := json.Marshal(routine)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	return m.txn.HSet(dbKey, routineKey, data)
+}
+
+// DropRoutine drops a stored procedure or function in database.
+func (m *Meta) DropRoutine(dbID int64, routineID int64) error {
+	// Check if db exists.
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return errors.Trace(err)
+	}
+
+	routineKey := m.routineKey(routineID)
+	v, err := m.txn.HGet(dbKey, routineKey)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if v == nil {
+		return ErrRoutineNotExists.GenWithStack("routine doesn't exist")
+	}
+	return errors.Trace(m.txn.HDel(dbKey, routineKey))
+}
+
+// ListRoutines shows all stored procedures and functions in database.
+func (m *Meta) ListRoutines(dbID int64) ([]*model.RoutineInfo, error) {
+	dbKey := m.dbKey(dbID)
+	if err := m.checkDBExists(dbKey); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	res, err := m.txn.HGetAll(dbKey)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	var routines []*model.RoutineInfo
+	for _, r := range res {
+		if !strings.HasPrefix(string(r.Field), mRoutinePrefix) {
+			continue
+		}
+
+		routine := &model.RoutineInfo{}
+		err = json.Unmarshal(r.Value, routine)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		routines = append(routines, routine)
+	}
+	return routines, nil
+}
+
 // UpdateTable updates the table with table info.
 func (m *Meta) UpdateTable(dbID int64, tableInfo *model.TableInfo) error {
 	// Check if db exists.
@@ -1016,6 +1096,8 @@ var (
 	ColumnElementKey ElementKeyType = []byte("_col_")
 	// IndexElementKey is the key for index element.
 	IndexElementKey ElementKeyType = []byte("_idx_")
+	// TableElementKey is the key for table element, it is used when the records of the table are reorganized.
+	TableElementKey ElementKeyType = []byte("_tab_")
 )
 
 const elementKeyLen = 5
@@ -1056,6 +1138,8 @@ func DecodeElement(b []byte) (*Element, error) {
 		tp = IndexElementKey
 	case string(ColumnElementKey):
 		tp = ColumnElementKey
+	case string(TableElementKey):
+		tp = TableElementKey
 	default:
 		return nil, errors.Errorf("invalid encoded element key prefix %q", prefix)
 	}
@@ -740,6 +740,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -794,6 +795,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1403,7 +1406,7 @@ func (n *CreateViewStmt) Restore(ctx *format.RestoreCtx) error {
 		return errors.Annotate(err, "An error occurred while create CreateViewStmt.Select")
 	}
 
-	if n.CheckOption != model.CheckOptionCascaded {
+	if n.CheckOption != model.CheckOptionNone {
 		ctx.WriteKeyWord(" WITH ")
 		ctx.WriteKeyWord(n.CheckOption.String())
 		ctx.WriteKeyWord(" CHECK OPTION")
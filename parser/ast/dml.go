@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -597,6 +598,164 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTableColumnType is the type of the column definition of JSON_TABLE.
+type JSONTableColumnType int
+
+const (
+	// JSONTableColumnPath is the column whose value is extracted by the path.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnExists is the column whose value is 1 if the path matches any value, otherwise 0.
+	JSONTableColumnExists
+	// JSONTableColumnOrdinality is the column which enumerates the rows of the path.
+	JSONTableColumnOrdinality
+	// JSONTableColumnNested is the nested path whose columns are flattened into the columns of JSON_TABLE.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the behavior of the column when the value is missing or can't be converted.
+type JSONTableOnResponseType int
+
+const (
+	// JSONTableOnResponseNull returns NULL.
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	// JSONTableOnResponseError returns an error.
+	JSONTableOnResponseError
+	// JSONTableOnResponseDefault returns the default value.
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of the column of JSON_TABLE and JSON_VALUE.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the default value, it's the JSON text for JSON_TABLE.
+	Default string
+}
+
+// Restore implements Node interface.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) error {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+	return nil
+}
+
+// JSONTableColumn is the column definition of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp   JSONTableColumnType
+	Name model.CIStr
+	// FieldType is the type of the JSONTableColumnPath and JSONTableColumnExists columns.
+	FieldType *types.FieldType
+	Path      string
+	OnEmpty   *JSONTableOnResponse
+	OnError   *JSONTableOnResponse
+	// Columns are the columns of the nested path.
+	Columns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WriteKeyWord(" COLUMNS ")
+		return restoreJSONTableColumns(ctx, n.Columns)
+	}
+	ctx.WriteName(n.Name.O)
+	if n.Tp == JSONTableColumnOrdinality {
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	if n.Tp == JSONTableColumnExists {
+		ctx.WriteKeyWord(" EXISTS")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnEmpty.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnEmpty")
+		}
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnError.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore JSONTableColumn.OnError")
+		}
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i > 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// JSONTable is the JSON_TABLE table function, which extracts the rows from the JSON document by the path
+// and the columns from every row by the paths of the columns.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	Expr    ExprNode
+	Path    string
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WriteKeyWord(" COLUMNS ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
@@ -3095,12 +3254,27 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Variables are the targets of SELECT ... INTO var_list, each of them is either
+	// a *VariableExpr for user variables or a *ColumnNameExpr for local variables.
+	Variables []ExprNode
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Variables {
+			if i != 0 {
+				ctx.WritePlain(", ")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Variables[%d]", i)
+			}
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE and INTO var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
@@ -3192,6 +3366,8 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ROWS")
 	case Ranges:
 		ctx.WriteKeyWord("RANGE")
+	case Groups:
+		ctx.WriteKeyWord("GROUPS")
 	default:
 		return errors.New("Unsupported window function frame type")
 	}
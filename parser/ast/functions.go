@@ -237,6 +237,12 @@ const (
 	WeightString    = "weight_string"
 	Soundex         = "soundex"
 
+	// regexp functions
+	RegexpLike    = "regexp_like"
+	RegexpSubstr  = "regexp_substr"
+	RegexpInStr   = "regexp_instr"
+	RegexpReplace = "regexp_replace"
+
 	// information functions
 	Benchmark            = "benchmark"
 	Charset              = "charset"
@@ -335,9 +341,16 @@ const (
 	JSONQuote         = "json_quote"
 	JSONSearch        = "json_search"
 	JSONStorageSize   = "json_storage_size"
+	JSONStorageFree   = "json_storage_free"
 	JSONDepth         = "json_depth"
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
+	JSONMemberOf      = "json_memberof"
+	JSONOverlaps      = "json_overlaps"
+	JSONValue         = "json_value"
+
+	JSONSchemaValid            = "json_schema_valid"
+	JSONSchemaValidationReport = "json_schema_validation_report"
 
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
@@ -350,6 +363,26 @@ const (
 	NextVal = "nextval"
 	LastVal = "lastval"
 	SetVal  = "setval"
+
+	// Full-text search function, which is the `MATCH ... AGAINST` expression.
+	MatchAgainstFunc = "match_against"
+
+	// spatial functions
+	StGeomFromText     = "st_geomfromtext"
+	StGeometryFromText = "st_geometryfromtext"
+	StGeomFromWKB      = "st_geomfromwkb"
+	StGeometryFromWKB  = "st_geometryfromwkb"
+	StAsText           = "st_astext"
+	StAsWKT            = "st_aswkt"
+	StAsBinary         = "st_asbinary"
+	StAsWKB            = "st_aswkb"
+	StX                = "st_x"
+	StY                = "st_y"
+	StSRID             = "st_srid"
+	StDistance         = "st_distance"
+	StContains         = "st_contains"
+	StIntersects       = "st_intersects"
+	StWithin           = "st_within"
 )
 
 type FuncCallExprType int8
@@ -388,6 +421,18 @@ func (n *FuncCallExpr) Restore(ctx *format.RestoreCtx) error {
 		}
 		return nil
 	}
+	if n.FnName.L == JSONMemberOf {
+		if err := n.Args[0].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[0]")
+		}
+		ctx.WriteKeyWord(" MEMBER OF ")
+		ctx.WritePlain("(")
+		if err := n.Args[1].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncCallExpr.Args[1]")
+		}
+		ctx.WritePlain(")")
+		return nil
+	}
 
 	if len(n.Schema.String()) != 0 {
 		ctx.WriteName(n.Schema.O)
@@ -489,6 +534,13 @@ func (n *FuncCallExpr) Restore(ctx *format.RestoreCtx) error {
 
 // Format the ExprNode into a Writer.
 func (n *FuncCallExpr) Format(w io.Writer) {
+	if n.FnName.L == JSONMemberOf {
+		n.Args[0].Format(w)
+		fmt.Fprint(w, " MEMBER OF (")
+		n.Args[1].Format(w)
+		fmt.Fprint(w, ")")
+		return
+	}
 	fmt.Fprintf(w, "%s(", n.FnName.L)
 	if !n.specialFormatArgs(w) {
 		for i, arg := range n.Args {
@@ -622,6 +674,87 @@ func (n *FuncCastExpr) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// FuncJSONValueExpr is the JSON_VALUE function extracting a scalar from the JSON document, e.g,
+// json_value(doc, '$.a' RETURNING DECIMAL(10,2) NULL ON EMPTY ERROR ON ERROR).
+// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+type FuncJSONValueExpr struct {
+	funcNode
+	// Expr is the JSON document.
+	Expr ExprNode
+	// Path is the path of the scalar.
+	Path ExprNode
+	// Tp is the type of the RETURNING clause, it's nil if the clause is omitted.
+	Tp      *types.FieldType
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+}
+
+// Restore implements Node interface.
+func (n *FuncJSONValueExpr) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_VALUE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore FuncJSONValueExpr.Expr")
+	}
+	ctx.WritePlain(", ")
+	if err := n.Path.Restore(ctx); err != nil {
+		return errors.Annotatef(err, "An error occurred while restore FuncJSONValueExpr.Path")
+	}
+	if n.Tp != nil {
+		ctx.WriteKeyWord(" RETURNING ")
+		n.Tp.RestoreAsCastType(ctx, false)
+	}
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnEmpty.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncJSONValueExpr.OnEmpty")
+		}
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		if err := n.OnError.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore FuncJSONValueExpr.OnError")
+		}
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Format the ExprNode into a Writer.
+func (n *FuncJSONValueExpr) Format(w io.Writer) {
+	fmt.Fprint(w, "JSON_VALUE(")
+	n.Expr.Format(w)
+	fmt.Fprint(w, ", ")
+	n.Path.Format(w)
+	if n.Tp != nil {
+		fmt.Fprint(w, " RETURNING ")
+		n.Tp.FormatAsCastType(w, false)
+	}
+	fmt.Fprint(w, ")")
+}
+
+// Accept implements Node Accept interface.
+func (n *FuncJSONValueExpr) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*FuncJSONValueExpr)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	node, ok = n.Path.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Path = node.(ExprNode)
+	return v.Leave(n)
+}
+
 // TrimDirectionType is the type for trim direction.
 type TrimDirectionType int
 
@@ -865,7 +998,12 @@ type WindowFuncExpr struct {
 func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord(n.F)
 	ctx.WritePlain("(")
-	for i, v := range n.Args {
+	args := n.Args
+	if strings.ToLower(n.F) == AggFuncGroupConcat && len(args) > 0 {
+		// The last argument of group_concat is the separator.
+		args = args[:len(args)-1]
+	}
+	for i, v := range args {
 		if i != 0 {
 			ctx.WritePlain(", ")
 		} else if n.Distinct {
@@ -875,6 +1013,12 @@ func (n *WindowFuncExpr) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotatef(err, "An error occurred while restore WindowFuncExpr.Args[%d]", i)
 		}
 	}
+	if len(args) < len(n.Args) {
+		ctx.WriteKeyWord(" SEPARATOR ")
+		if err := n.Args[len(n.Args)-1].Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore WindowFuncExpr.Args SEPARATOR")
+		}
+	}
 	ctx.WritePlain(")")
 	if n.FromLast {
 		ctx.WriteKeyWord(" FROM LAST")
@@ -3262,6 +3262,7 @@ type TableOptimizerHint struct {
 	// - READ_FROM_STORAGE   => model.CIStr
 	// - USE_TOJA            => bool
 	// - NTH_PLAN            => int64
+	// - LEADING             => *ast.LeadingList
 	HintData interface{}
 	// QBName is the default effective query block of this hint.
 	QBName  model.CIStr
@@ -3281,6 +3282,42 @@ type HintSetVar struct {
 	Value   string
 }
 
+// LeadingList is the payload of `LEADING` hint, each item is a *HintTable or a nested *LeadingList.
+type LeadingList struct {
+	Items []interface{}
+}
+
+// Tables returns all the tables in the list in order.
+func (l *LeadingList) Tables() []HintTable {
+	var tables []HintTable
+	for _, item := range l.Items {
+		switch x := item.(type) {
+		case *HintTable:
+			tables = append(tables, *x)
+		case *LeadingList:
+			tables = append(tables, x.Tables()...)
+		}
+	}
+	return tables
+}
+
+// Restore restores the list into the format of the LEADING hint arguments.
+func (l *LeadingList) Restore(ctx *format.RestoreCtx) {
+	for i, item := range l.Items {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		switch x := item.(type) {
+		case *HintTable:
+			x.Restore(ctx)
+		case *LeadingList:
+			ctx.WritePlain("(")
+			x.Restore(ctx)
+			ctx.WritePlain(")")
+		}
+	}
+}
+
 // HintTable is table in the hint. It may have query block info.
 type HintTable struct {
 	DBName        model.CIStr
@@ -3324,7 +3361,7 @@ func (n *TableOptimizerHint) Restore(ctx *format.RestoreCtx) error {
 	}
 	// Hints without args except query block.
 	switch n.HintName.L {
-	case "hash_agg", "stream_agg", "agg_to_cop", "read_consistent_replica", "no_index_merge", "qb_name", "ignore_plan_cache", "limit_to_cop":
+	case "hash_agg", "stream_agg", "agg_to_cop", "read_consistent_replica", "no_index_merge", "qb_name", "ignore_plan_cache", "limit_to_cop", "no_decorrelate":
 		ctx.WritePlain(")")
 		return nil
 	}
@@ -3337,6 +3374,8 @@ func (n *TableOptimizerHint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WritePlainf("%d", n.HintData.(uint64))
 	case "nth_plan":
 		ctx.WritePlainf("%d", n.HintData.(int64))
+	case "leading":
+		n.HintData.(*LeadingList).Restore(ctx)
 	case "tidb_hj", "tidb_smj", "tidb_inlj", "hash_join", "merge_join", "inl_join", "broadcast_join", "broadcast_join_local", "inl_hash_join", "inl_merge_join":
 		for i, table := range n.Tables {
 			if i != 0 {
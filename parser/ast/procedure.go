@@ -0,0 +1,924 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var (
+	_ DDLNode  = &CreateRoutineStmt{}
+	_ DDLNode  = &DropRoutineStmt{}
+	_ DDLNode  = &CreateTriggerStmt{}
+	_ DDLNode  = &DropTriggerStmt{}
+	_ StmtNode = &ProcedureBlock{}
+	_ StmtNode = &ProcedureDecl{}
+	_ StmtNode = &ProcedureCursorDecl{}
+	_ StmtNode = &ProcedureHandlerDecl{}
+	_ StmtNode = &ProcedureIfStmt{}
+	_ StmtNode = &ProcedureLoopStmt{}
+	_ StmtNode = &ProcedureJumpStmt{}
+	_ StmtNode = &ProcedureOpenCursorStmt{}
+	_ StmtNode = &ProcedureFetchCursorStmt{}
+	_ StmtNode = &ProcedureCloseCursorStmt{}
+	_ StmtNode = &ProcedureReturnStmt{}
+	_ StmtNode = &SignalStmt{}
+
+	_ Node = &RoutineParam{}
+	_ Node = &ProcedureIfBlock{}
+)
+
+// RoutineParam is a parameter of a stored procedure or function.
+type RoutineParam struct {
+	node
+
+	Mode model.RoutineParamMode
+	Name string
+	Tp   *types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *RoutineParam) Restore(ctx *format.RestoreCtx) error {
+	if n.Mode != model.ParamModeIn {
+		ctx.WriteKeyWord(n.Mode.String())
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteName(n.Name)
+	ctx.WritePlain(" ")
+	return errors.Annotate(n.Tp.Restore(ctx), "An error occurred while restore RoutineParam.Tp")
+}
+
+// Accept implements Node Accept interface.
+func (n *RoutineParam) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// CreateRoutineStmt is a statement to create a stored procedure or function.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-procedure.html
+type CreateRoutineStmt struct {
+	ddlNode
+
+	Tp          model.RoutineType
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	Name        *TableName
+	Params      []*RoutineParam
+	// Returns is the return type of a function.
+	Returns *types.FieldType
+
+	Comment       string
+	Deterministic bool
+	DataAccess    model.RoutineDataAccess
+	Security      model.ViewSecurity
+
+	Body StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateRoutineStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if err := n.Definer.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.Definer")
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord(n.Tp.String())
+	ctx.WritePlain(" ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.Name")
+	}
+	ctx.WritePlain("(")
+	for i, param := range n.Params {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := param.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore CreateRoutineStmt.Params[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	if n.Returns != nil {
+		ctx.WriteKeyWord(" RETURNS ")
+		if err := n.Returns.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateRoutineStmt.Returns")
+		}
+	}
+	if n.Comment != "" {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(n.Comment)
+	}
+	if n.Deterministic {
+		ctx.WriteKeyWord(" DETERMINISTIC")
+	}
+	if n.DataAccess != model.DataAccessContainsSQL {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.DataAccess.String())
+	}
+	if n.Security != model.SecurityDefiner {
+		ctx.WriteKeyWord(" SQL SECURITY ")
+		ctx.WriteKeyWord(n.Security.String())
+	}
+	ctx.WritePlain(" ")
+	return errors.Annotate(n.Body.Restore(ctx), "An error occurred while restore CreateRoutineStmt.Body")
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateRoutineStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateRoutineStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	for i, param := range n.Params {
+		node, ok = param.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Params[i] = node.(*RoutineParam)
+	}
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropRoutineStmt is a statement to drop a stored procedure or function.
+// See https://dev.mysql.com/doc/refman/5.7/en/drop-procedure.html
+type DropRoutineStmt struct {
+	ddlNode
+
+	Tp       model.RoutineType
+	IfExists bool
+	Name     *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropRoutineStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP ")
+	ctx.WriteKeyWord(n.Tp.String())
+	ctx.WritePlain(" ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	return errors.Annotate(n.Name.Restore(ctx), "An error occurred while restore DropRoutineStmt.Name")
+}
+
+// Accept implements Node Accept interface.
+func (n *DropRoutineStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropRoutineStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	return v.Leave(n)
+}
+
+// TriggerOrderType is the type of the order clause of CREATE TRIGGER.
+type TriggerOrderType int
+
+const (
+	TriggerOrderNone TriggerOrderType = iota
+	TriggerOrderFollows
+	TriggerOrderPrecedes
+)
+
+// CreateTriggerStmt is a statement to create a trigger.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-trigger.html
+type CreateTriggerStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	Name        *TableName
+	Timing      model.TriggerTiming
+	Event       model.TriggerEvent
+	Table       *TableName
+	// Order places the trigger after or before OrderTrigger, which has the same
+	// action time and event.
+	Order        TriggerOrderType
+	OrderTrigger string
+
+	Body StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if err := n.Definer.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Definer")
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord("TRIGGER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.Name.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Name")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Timing.String())
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Event.String())
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Table")
+	}
+	ctx.WriteKeyWord(" FOR EACH ROW ")
+	switch n.Order {
+	case TriggerOrderFollows:
+		ctx.WriteKeyWord("FOLLOWS ")
+		ctx.WriteName(n.OrderTrigger)
+		ctx.WritePlain(" ")
+	case TriggerOrderPrecedes:
+		ctx.WriteKeyWord("PRECEDES ")
+		ctx.WriteName(n.OrderTrigger)
+		ctx.WritePlain(" ")
+	}
+	return errors.Annotate(n.Body.Restore(ctx), "An error occurred while restore CreateTriggerStmt.Body")
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateTriggerStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	node, ok = n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	node, ok = n.Body.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Body = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropTriggerStmt is a statement to drop a trigger.
+// See https://dev.mysql.com/doc/refman/5.7/en/drop-trigger.html
+type DropTriggerStmt struct {
+	ddlNode
+
+	IfExists bool
+	Name     *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP TRIGGER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	return errors.Annotate(n.Name.Restore(ctx), "An error occurred while restore DropTriggerStmt.Name")
+}
+
+// Accept implements Node Accept interface.
+func (n *DropTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropTriggerStmt)
+	node, ok := n.Name.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Name = node.(*TableName)
+	return v.Leave(n)
+}
+
+func restoreStmtList(ctx *format.RestoreCtx, stmts []StmtNode, field string) error {
+	for i, stmt := range stmts {
+		if err := stmt.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore %s[%d]", field, i)
+		}
+		ctx.WritePlain("; ")
+	}
+	return nil
+}
+
+func acceptStmtList(v Visitor, stmts []StmtNode) bool {
+	for i, stmt := range stmts {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return false
+		}
+		stmts[i] = node.(StmtNode)
+	}
+	return true
+}
+
+func restoreLabel(ctx *format.RestoreCtx, label string) {
+	if label != "" {
+		ctx.WriteName(label)
+		ctx.WritePlain(": ")
+	}
+}
+
+func restoreEndLabel(ctx *format.RestoreCtx, label string) {
+	if label != "" {
+		ctx.WritePlain(" ")
+		ctx.WriteName(label)
+	}
+}
+
+// ProcedureBlock is a BEGIN ... END compound statement in a stored program.
+// The declarations always come before the other statements.
+type ProcedureBlock struct {
+	stmtNode
+
+	Label string
+	Decls []StmtNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureBlock) Restore(ctx *format.RestoreCtx) error {
+	restoreLabel(ctx, n.Label)
+	ctx.WriteKeyWord("BEGIN ")
+	if err := restoreStmtList(ctx, n.Decls, "ProcedureBlock.Decls"); err != nil {
+		return err
+	}
+	if err := restoreStmtList(ctx, n.Stmts, "ProcedureBlock.Stmts"); err != nil {
+		return err
+	}
+	ctx.WriteKeyWord("END")
+	restoreEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureBlock) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureBlock)
+	if !acceptStmtList(v, n.Decls) || !acceptStmtList(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureDecl is a DECLARE statement of local variables.
+type ProcedureDecl struct {
+	stmtNode
+
+	Names   []string
+	Tp      *types.FieldType
+	Default ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	for i, name := range n.Names {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteName(name)
+	}
+	ctx.WritePlain(" ")
+	if err := n.Tp.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureDecl.Tp")
+	}
+	if n.Default != nil {
+		ctx.WriteKeyWord(" DEFAULT ")
+		if err := n.Default.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureDecl.Default")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureDecl)
+	if n.Default != nil {
+		node, ok := n.Default.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Default = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+// ProcedureCursorDecl is a DECLARE ... CURSOR FOR statement.
+type ProcedureCursorDecl struct {
+	stmtNode
+
+	Name  string
+	Query StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCursorDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	ctx.WriteName(n.Name)
+	ctx.WriteKeyWord(" CURSOR FOR ")
+	return errors.Annotate(n.Query.Restore(ctx), "An error occurred while restore ProcedureCursorDecl.Query")
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCursorDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureCursorDecl)
+	node, ok := n.Query.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Query = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// HandlerAction is the action of a condition handler.
+type HandlerAction int
+
+const (
+	HandlerActionContinue HandlerAction = iota
+	HandlerActionExit
+)
+
+// HandlerConditionType is the type of a handler condition value.
+type HandlerConditionType int
+
+const (
+	HandlerConditionErrorCode HandlerConditionType = iota
+	HandlerConditionSQLState
+	HandlerConditionSQLWarning
+	HandlerConditionNotFound
+	HandlerConditionSQLException
+)
+
+// HandlerCondition is a condition value a handler is declared for.
+type HandlerCondition struct {
+	Tp        HandlerConditionType
+	ErrorCode uint64
+	SQLState  string
+}
+
+// Restore restores the condition value.
+func (n *HandlerCondition) Restore(ctx *format.RestoreCtx) {
+	switch n.Tp {
+	case HandlerConditionErrorCode:
+		ctx.WritePlainf("%d", n.ErrorCode)
+	case HandlerConditionSQLState:
+		ctx.WriteKeyWord("SQLSTATE ")
+		ctx.WriteString(n.SQLState)
+	case HandlerConditionSQLWarning:
+		ctx.WriteKeyWord("SQLWARNING")
+	case HandlerConditionNotFound:
+		ctx.WriteKeyWord("NOT FOUND")
+	case HandlerConditionSQLException:
+		ctx.WriteKeyWord("SQLEXCEPTION")
+	}
+}
+
+// ProcedureHandlerDecl is a DECLARE ... HANDLER statement.
+type ProcedureHandlerDecl struct {
+	stmtNode
+
+	Action     HandlerAction
+	Conditions []*HandlerCondition
+	Stmt       StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureHandlerDecl) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DECLARE ")
+	if n.Action == HandlerActionExit {
+		ctx.WriteKeyWord("EXIT")
+	} else {
+		ctx.WriteKeyWord("CONTINUE")
+	}
+	ctx.WriteKeyWord(" HANDLER FOR ")
+	for i, cond := range n.Conditions {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		cond.Restore(ctx)
+	}
+	ctx.WritePlain(" ")
+	return errors.Annotate(n.Stmt.Restore(ctx), "An error occurred while restore ProcedureHandlerDecl.Stmt")
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureHandlerDecl) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureHandlerDecl)
+	node, ok := n.Stmt.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Stmt = node.(StmtNode)
+	return v.Leave(n)
+}
+
+// ProcedureIfBlock is an IF or ELSEIF branch of an IF statement.
+type ProcedureIfBlock struct {
+	node
+
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureIfBlock) Restore(ctx *format.RestoreCtx) error {
+	if err := n.Cond.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore ProcedureIfBlock.Cond")
+	}
+	ctx.WriteKeyWord(" THEN ")
+	return restoreStmtList(ctx, n.Stmts, "ProcedureIfBlock.Stmts")
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureIfBlock) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureIfBlock)
+	node, ok := n.Cond.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Cond = node.(ExprNode)
+	if !acceptStmtList(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// ProcedureIfStmt is an IF statement in a stored program.
+type ProcedureIfStmt struct {
+	stmtNode
+
+	Blocks []*ProcedureIfBlock
+	Else   []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureIfStmt) Restore(ctx *format.RestoreCtx) error {
+	for i, block := range n.Blocks {
+		if i == 0 {
+			ctx.WriteKeyWord("IF ")
+		} else {
+			ctx.WriteKeyWord("ELSEIF ")
+		}
+		if err := block.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore ProcedureIfStmt.Blocks[%d]", i)
+		}
+	}
+	if n.Else != nil {
+		ctx.WriteKeyWord("ELSE ")
+		if err := restoreStmtList(ctx, n.Else, "ProcedureIfStmt.Else"); err != nil {
+			return err
+		}
+	}
+	ctx.WriteKeyWord("END IF")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureIfStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureIfStmt)
+	for i, block := range n.Blocks {
+		node, ok := block.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Blocks[i] = node.(*ProcedureIfBlock)
+	}
+	if !acceptStmtList(v, n.Else) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// LoopType is the type of a loop statement.
+type LoopType int
+
+const (
+	LoopTypeLoop LoopType = iota
+	LoopTypeWhile
+	LoopTypeRepeat
+)
+
+// ProcedureLoopStmt is a LOOP, WHILE or REPEAT statement in a stored program.
+type ProcedureLoopStmt struct {
+	stmtNode
+
+	Label string
+	Tp    LoopType
+	// Cond is the search condition of WHILE and REPEAT.
+	Cond  ExprNode
+	Stmts []StmtNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureLoopStmt) Restore(ctx *format.RestoreCtx) error {
+	restoreLabel(ctx, n.Label)
+	switch n.Tp {
+	case LoopTypeLoop:
+		ctx.WriteKeyWord("LOOP ")
+	case LoopTypeWhile:
+		ctx.WriteKeyWord("WHILE ")
+		if err := n.Cond.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureLoopStmt.Cond")
+		}
+		ctx.WriteKeyWord(" DO ")
+	case LoopTypeRepeat:
+		ctx.WriteKeyWord("REPEAT ")
+	}
+	if err := restoreStmtList(ctx, n.Stmts, "ProcedureLoopStmt.Stmts"); err != nil {
+		return err
+	}
+	switch n.Tp {
+	case LoopTypeLoop:
+		ctx.WriteKeyWord("END LOOP")
+	case LoopTypeWhile:
+		ctx.WriteKeyWord("END WHILE")
+	case LoopTypeRepeat:
+		ctx.WriteKeyWord("UNTIL ")
+		if err := n.Cond.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ProcedureLoopStmt.Cond")
+		}
+		ctx.WriteKeyWord(" END REPEAT")
+	}
+	restoreEndLabel(ctx, n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureLoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureLoopStmt)
+	if n.Cond != nil {
+		node, ok := n.Cond.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Cond = node.(ExprNode)
+	}
+	if !acceptStmtList(v, n.Stmts) {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// JumpType is the type of a jump statement.
+type JumpType int
+
+const (
+	JumpTypeLeave JumpType = iota
+	JumpTypeIterate
+)
+
+// ProcedureJumpStmt is a LEAVE or ITERATE statement.
+type ProcedureJumpStmt struct {
+	stmtNode
+
+	Tp    JumpType
+	Label string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureJumpStmt) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JumpTypeIterate {
+		ctx.WriteKeyWord("ITERATE ")
+	} else {
+		ctx.WriteKeyWord("LEAVE ")
+	}
+	ctx.WriteName(n.Label)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureJumpStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureOpenCursorStmt is an OPEN cursor statement.
+type ProcedureOpenCursorStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureOpenCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("OPEN ")
+	ctx.WriteName(n.Name)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureOpenCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureFetchCursorStmt is a FETCH cursor INTO statement.
+type ProcedureFetchCursorStmt struct {
+	stmtNode
+
+	Name string
+	Vars []string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureFetchCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("FETCH ")
+	ctx.WriteName(n.Name)
+	ctx.WriteKeyWord(" INTO ")
+	for i, name := range n.Vars {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		ctx.WriteName(name)
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureFetchCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureCloseCursorStmt is a CLOSE cursor statement.
+type ProcedureCloseCursorStmt struct {
+	stmtNode
+
+	Name string
+}
+
+// Restore implements Node interface.
+func (n *ProcedureCloseCursorStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CLOSE ")
+	ctx.WriteName(n.Name)
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureCloseCursorStmt) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
+// ProcedureReturnStmt is a RETURN statement in a stored function.
+type ProcedureReturnStmt struct {
+	stmtNode
+
+	Expr ExprNode
+}
+
+// Restore implements Node interface.
+func (n *ProcedureReturnStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("RETURN ")
+	return errors.Annotate(n.Expr.Restore(ctx), "An error occurred while restore ProcedureReturnStmt.Expr")
+}
+
+// Accept implements Node Accept interface.
+func (n *ProcedureReturnStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureReturnStmt)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// SignalInfoItem is the name of a condition information item of SIGNAL.
+type SignalInfoItem int
+
+const (
+	SignalMessageText SignalInfoItem = iota
+	SignalMySQLErrno
+)
+
+// SignalItem is an `item = value` pair in the SET clause of SIGNAL.
+type SignalItem struct {
+	Name  SignalInfoItem
+	Value ExprNode
+}
+
+// SignalStmt is a SIGNAL statement.
+// See https://dev.mysql.com/doc/refman/5.7/en/signal.html
+type SignalStmt struct {
+	stmtNode
+
+	SQLState string
+	Items    []*SignalItem
+}
+
+// Restore implements Node interface.
+func (n *SignalStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("SIGNAL SQLSTATE ")
+	ctx.WriteString(n.SQLState)
+	for i, item := range n.Items {
+		if i == 0 {
+			ctx.WriteKeyWord(" SET ")
+		} else {
+			ctx.WritePlain(", ")
+		}
+		if item.Name == SignalMySQLErrno {
+			ctx.WriteKeyWord("MYSQL_ERRNO")
+		} else {
+			ctx.WriteKeyWord("MESSAGE_TEXT")
+		}
+		ctx.WritePlain(" = ")
+		if err := item.Value.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore SignalStmt.Items[%d]", i)
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *SignalStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*SignalStmt)
+	for _, item := range n.Items {
+		node, ok := item.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		item.Value = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
@@ -26,14 +26,16 @@ import (
 )
 
 type yyhintSymType struct {
-	yys         int
-	offset      int
-	ident       string
-	number      uint64
-	hint        *ast.TableOptimizerHint
-	hints       []*ast.TableOptimizerHint
-	table       ast.HintTable
-	modelIdents []model.CIStr
+	yys            int
+	offset         int
+	ident          string
+	number         uint64
+	hint           *ast.TableOptimizerHint
+	hints          []*ast.TableOptimizerHint
+	table          ast.HintTable
+	modelIdents    []model.CIStr
+	leadingList    *ast.LeadingList
+	leadingElement interface{}
 }
 
 type yyhintXError struct {
@@ -41,7 +43,7 @@ type yyhintXError struct {
 }
 
 const (
-	yyhintDefault             = 57415
+	yyhintDefault             = 57417
 	yyhintEOFCode             = 57344
 	yyhintErrCode             = 57345
 	hintAggToCop              = 57376
@@ -49,11 +51,11 @@ const (
 	hintBCJoinPreferLocal     = 57390
 	hintBKA                   = 57354
 	hintBNL                   = 57356
-	hintDupsWeedOut           = 57411
-	hintFalse                 = 57407
-	hintFirstMatch            = 57412
+	hintDupsWeedOut           = 57413
+	hintFalse                 = 57409
+	hintFirstMatch            = 57414
 	hintForceIndex            = 57401
-	hintGB                    = 57410
+	hintGB                    = 57412
 	hintHashAgg               = 57378
 	hintHashJoin              = 57358
 	hintIdentifier            = 57347
@@ -68,16 +70,18 @@ const (
 	hintJoinOrder             = 57351
 	hintJoinPrefix            = 57352
 	hintJoinSuffix            = 57353
+	hintLeading               = 57402
 	hintLimitToCop            = 57400
-	hintLooseScan             = 57413
-	hintMB                    = 57409
+	hintLooseScan             = 57415
+	hintMB                    = 57411
 	hintMRR                   = 57364
-	hintMaterialization       = 57414
+	hintMaterialization       = 57416
 	hintMaxExecutionTime      = 57372
 	hintMemoryQuota           = 57383
 	hintMerge                 = 57360
 	hintNoBKA                 = 57355
 	hintNoBNL                 = 57357
+	hintNoDecorrelate         = 57403
 	hintNoHashJoin            = 57359
 	hintNoICP                 = 57366
 	hintNoIndexMerge          = 57363
@@ -88,9 +92,9 @@ const (
 	hintNoSkipScan            = 57369
 	hintNoSwapJoinInputs      = 57384
 	hintNthPlan               = 57399
-	hintOLAP                  = 57402
-	hintOLTP                  = 57403
-	hintPartition             = 57404
+	hintOLAP                  = 57404
+	hintOLTP                  = 57405
+	hintPartition             = 57406
 	hintQBName                = 57375
 	hintQueryType             = 57385
 	hintReadConsistentReplica = 57386
@@ -104,10 +108,10 @@ const (
 	hintStreamAgg             = 57391
 	hintStringLit             = 57349
 	hintSwapJoinInputs        = 57392
-	hintTiFlash               = 57406
-	hintTiKV                  = 57405
+	hintTiFlash               = 57408
+	hintTiKV                  = 57407
 	hintTimeRange             = 57397
-	hintTrue                  = 57408
+	hintTrue                  = 57410
 	hintUseCascades           = 57398
 	hintUseIndex              = 57394
 	hintUseIndexMerge         = 57393
@@ -115,127 +119,132 @@ const (
 	hintUseToja               = 57396
 
 	yyhintMaxDepth = 200
-	yyhintTabOfs   = -172
+	yyhintTabOfs   = -180
 )
 
 var (
 	yyhintXLAT = map[int]int{
-		41:    0,   // ')' (130x)
-		57376: 1,   // hintAggToCop (122x)
-		57389: 2,   // hintBCJoin (122x)
-		57390: 3,   // hintBCJoinPreferLocal (122x)
-		57354: 4,   // hintBKA (122x)
-		57356: 5,   // hintBNL (122x)
-		57401: 6,   // hintForceIndex (122x)
-		57378: 7,   // hintHashAgg (122x)
-		57358: 8,   // hintHashJoin (122x)
-		57379: 9,   // hintIgnoreIndex (122x)
-		57377: 10,  // hintIgnorePlanCache (122x)
-		57362: 11,  // hintIndexMerge (122x)
-		57380: 12,  // hintInlHashJoin (122x)
-		57381: 13,  // hintInlJoin (122x)
-		57382: 14,  // hintInlMergeJoin (122x)
-		57350: 15,  // hintJoinFixedOrder (122x)
-		57351: 16,  // hintJoinOrder (122x)
-		57352: 17,  // hintJoinPrefix (122x)
-		57353: 18,  // hintJoinSuffix (122x)
-		57400: 19,  // hintLimitToCop (122x)
-		57372: 20,  // hintMaxExecutionTime (122x)
-		57383: 21,  // hintMemoryQuota (122x)
-		57360: 22,  // hintMerge (122x)
-		57364: 23,  // hintMRR (122x)
-		57355: 24,  // hintNoBKA (122x)
-		57357: 25,  // hintNoBNL (122x)
-		57359: 26,  // hintNoHashJoin (122x)
-		57366: 27,  // hintNoICP (122x)
-		57363: 28,  // hintNoIndexMerge (122x)
-		57361: 29,  // hintNoMerge (122x)
-		57365: 30,  // hintNoMRR (122x)
-		57367: 31,  // hintNoRangeOptimization (122x)
-		57371: 32,  // hintNoSemijoin (122x)
-		57369: 33,  // hintNoSkipScan (122x)
-		57384: 34,  // hintNoSwapJoinInputs (122x)
-		57399: 35,  // hintNthPlan (122x)
-		57375: 36,  // hintQBName (122x)
-		57385: 37,  // hintQueryType (122x)
-		57386: 38,  // hintReadConsistentReplica (122x)
-		57387: 39,  // hintReadFromStorage (122x)
-		57374: 40,  // hintResourceGroup (122x)
-		57370: 41,  // hintSemijoin (122x)
-		57373: 42,  // hintSetVar (122x)
-		57368: 43,  // hintSkipScan (122x)
-		57388: 44,  // hintSMJoin (122x)
-		57391: 45,  // hintStreamAgg (122x)
-		57392: 46,  // hintSwapJoinInputs (122x)
-		57397: 47,  // hintTimeRange (122x)
-		57398: 48,  // hintUseCascades (122x)
-		57394: 49,  // hintUseIndex (122x)
-		57393: 50,  // hintUseIndexMerge (122x)
-		57395: 51,  // hintUsePlanCache (122x)
-		57396: 52,  // hintUseToja (122x)
-		44:    53,  // ',' (120x)
-		57411: 54,  // hintDupsWeedOut (100x)
-		57412: 55,  // hintFirstMatch (100x)
-		57413: 56,  // hintLooseScan (100x)
-		57414: 57,  // hintMaterialization (100x)
-		57406: 58,  // hintTiFlash (100x)
-		57405: 59,  // hintTiKV (100x)
-		57407: 60,  // hintFalse (99x)
-		57402: 61,  // hintOLAP (99x)
-		57403: 62,  // hintOLTP (99x)
-		57408: 63,  // hintTrue (99x)
-		57410: 64,  // hintGB (98x)
-		57409: 65,  // hintMB (98x)
-		57347: 66,  // hintIdentifier (97x)
-		57348: 67,  // hintSingleAtIdentifier (82x)
-		93:    68,  // ']' (76x)
-		57404: 69,  // hintPartition (70x)
-		46:    70,  // '.' (66x)
-		61:    71,  // '=' (66x)
-		40:    72,  // '(' (61x)
-		57344: 73,  // $end (24x)
-		57435: 74,  // QueryBlockOpt (17x)
-		57427: 75,  // Identifier (13x)
-		57346: 76,  // hintIntLit (8x)
-		57349: 77,  // hintStringLit (5x)
-		57417: 78,  // CommaOpt (4x)
-		57423: 79,  // HintTable (4x)
-		57424: 80,  // HintTableList (4x)
-		91:    81,  // '[' (3x)
-		57416: 82,  // BooleanHintName (2x)
-		57418: 83,  // HintIndexList (2x)
-		57420: 84,  // HintStorageType (2x)
-		57421: 85,  // HintStorageTypeAndTable (2x)
-		57425: 86,  // HintTableListOpt (2x)
-		57430: 87,  // JoinOrderOptimizerHintName (2x)
-		57431: 88,  // NullaryHintName (2x)
-		57434: 89,  // PartitionListOpt (2x)
-		57437: 90,  // StorageOptimizerHintOpt (2x)
-		57438: 91,  // SubqueryOptimizerHintName (2x)
-		57441: 92,  // SubqueryStrategy (2x)
-		57442: 93,  // SupportedIndexLevelOptimizerHintName (2x)
-		57443: 94,  // SupportedTableLevelOptimizerHintName (2x)
-		57444: 95,  // TableOptimizerHintOpt (2x)
-		57446: 96,  // UnsupportedIndexLevelOptimizerHintName (2x)
-		57447: 97,  // UnsupportedTableLevelOptimizerHintName (2x)
-		57419: 98,  // HintQueryType (1x)
-		57422: 99,  // HintStorageTypeAndTableList (1x)
-		57426: 100, // HintTrueOrFalse (1x)
-		57428: 101, // IndexNameList (1x)
-		57429: 102, // IndexNameListOpt (1x)
-		57432: 103, // OptimizerHintList (1x)
-		57433: 104, // PartitionList (1x)
-		57436: 105, // Start (1x)
-		57439: 106, // SubqueryStrategies (1x)
-		57440: 107, // SubqueryStrategiesOpt (1x)
-		57445: 108, // UnitOfBytes (1x)
-		57448: 109, // Value (1x)
-		57415: 110, // $default (0x)
-		57345: 111, // error (0x)
+		41:    0,   // ')' (138x)
+		44:    1,   // ',' (129x)
+		57376: 2,   // hintAggToCop (129x)
+		57389: 3,   // hintBCJoin (129x)
+		57390: 4,   // hintBCJoinPreferLocal (129x)
+		57354: 5,   // hintBKA (129x)
+		57356: 6,   // hintBNL (129x)
+		57401: 7,   // hintForceIndex (129x)
+		57378: 8,   // hintHashAgg (129x)
+		57358: 9,   // hintHashJoin (129x)
+		57379: 10,  // hintIgnoreIndex (129x)
+		57377: 11,  // hintIgnorePlanCache (129x)
+		57362: 12,  // hintIndexMerge (129x)
+		57380: 13,  // hintInlHashJoin (129x)
+		57381: 14,  // hintInlJoin (129x)
+		57382: 15,  // hintInlMergeJoin (129x)
+		57350: 16,  // hintJoinFixedOrder (129x)
+		57351: 17,  // hintJoinOrder (129x)
+		57352: 18,  // hintJoinPrefix (129x)
+		57353: 19,  // hintJoinSuffix (129x)
+		57402: 20,  // hintLeading (129x)
+		57400: 21,  // hintLimitToCop (129x)
+		57372: 22,  // hintMaxExecutionTime (129x)
+		57383: 23,  // hintMemoryQuota (129x)
+		57360: 24,  // hintMerge (129x)
+		57364: 25,  // hintMRR (129x)
+		57355: 26,  // hintNoBKA (129x)
+		57357: 27,  // hintNoBNL (129x)
+		57403: 28,  // hintNoDecorrelate (129x)
+		57359: 29,  // hintNoHashJoin (129x)
+		57366: 30,  // hintNoICP (129x)
+		57363: 31,  // hintNoIndexMerge (129x)
+		57361: 32,  // hintNoMerge (129x)
+		57365: 33,  // hintNoMRR (129x)
+		57367: 34,  // hintNoRangeOptimization (129x)
+		57371: 35,  // hintNoSemijoin (129x)
+		57369: 36,  // hintNoSkipScan (129x)
+		57384: 37,  // hintNoSwapJoinInputs (129x)
+		57399: 38,  // hintNthPlan (129x)
+		57375: 39,  // hintQBName (129x)
+		57385: 40,  // hintQueryType (129x)
+		57386: 41,  // hintReadConsistentReplica (129x)
+		57387: 42,  // hintReadFromStorage (129x)
+		57374: 43,  // hintResourceGroup (129x)
+		57370: 44,  // hintSemijoin (129x)
+		57373: 45,  // hintSetVar (129x)
+		57368: 46,  // hintSkipScan (129x)
+		57388: 47,  // hintSMJoin (129x)
+		57391: 48,  // hintStreamAgg (129x)
+		57392: 49,  // hintSwapJoinInputs (129x)
+		57397: 50,  // hintTimeRange (129x)
+		57398: 51,  // hintUseCascades (129x)
+		57394: 52,  // hintUseIndex (129x)
+		57393: 53,  // hintUseIndexMerge (129x)
+		57395: 54,  // hintUsePlanCache (129x)
+		57396: 55,  // hintUseToja (129x)
+		57413: 56,  // hintDupsWeedOut (106x)
+		57414: 57,  // hintFirstMatch (106x)
+		57415: 58,  // hintLooseScan (106x)
+		57416: 59,  // hintMaterialization (106x)
+		57408: 60,  // hintTiFlash (106x)
+		57407: 61,  // hintTiKV (106x)
+		57409: 62,  // hintFalse (105x)
+		57404: 63,  // hintOLAP (105x)
+		57405: 64,  // hintOLTP (105x)
+		57410: 65,  // hintTrue (105x)
+		57412: 66,  // hintGB (104x)
+		57411: 67,  // hintMB (104x)
+		57347: 68,  // hintIdentifier (103x)
+		57348: 69,  // hintSingleAtIdentifier (85x)
+		93:    70,  // ']' (78x)
+		57406: 71,  // hintPartition (72x)
+		40:    72,  // '(' (68x)
+		46:    73,  // '.' (68x)
+		61:    74,  // '=' (68x)
+		57344: 75,  // $end (25x)
+		57439: 76,  // QueryBlockOpt (18x)
+		57429: 77,  // Identifier (16x)
+		57346: 78,  // hintIntLit (8x)
+		57425: 79,  // HintTable (7x)
+		57349: 80,  // hintStringLit (5x)
+		57419: 81,  // CommaOpt (4x)
+		57426: 82,  // HintTableList (4x)
+		91:    83,  // '[' (3x)
+		57433: 84,  // LeadingTableElement (3x)
+		57418: 85,  // BooleanHintName (2x)
+		57420: 86,  // HintIndexList (2x)
+		57422: 87,  // HintStorageType (2x)
+		57423: 88,  // HintStorageTypeAndTable (2x)
+		57427: 89,  // HintTableListOpt (2x)
+		57432: 90,  // JoinOrderOptimizerHintName (2x)
+		57434: 91,  // LeadingTableList (2x)
+		57435: 92,  // NullaryHintName (2x)
+		57438: 93,  // PartitionListOpt (2x)
+		57441: 94,  // StorageOptimizerHintOpt (2x)
+		57442: 95,  // SubqueryOptimizerHintName (2x)
+		57445: 96,  // SubqueryStrategy (2x)
+		57446: 97,  // SupportedIndexLevelOptimizerHintName (2x)
+		57447: 98,  // SupportedTableLevelOptimizerHintName (2x)
+		57448: 99,  // TableOptimizerHintOpt (2x)
+		57450: 100, // UnsupportedIndexLevelOptimizerHintName (2x)
+		57451: 101, // UnsupportedTableLevelOptimizerHintName (2x)
+		57421: 102, // HintQueryType (1x)
+		57424: 103, // HintStorageTypeAndTableList (1x)
+		57428: 104, // HintTrueOrFalse (1x)
+		57430: 105, // IndexNameList (1x)
+		57431: 106, // IndexNameListOpt (1x)
+		57436: 107, // OptimizerHintList (1x)
+		57437: 108, // PartitionList (1x)
+		57440: 109, // Start (1x)
+		57443: 110, // SubqueryStrategies (1x)
+		57444: 111, // SubqueryStrategiesOpt (1x)
+		57449: 112, // UnitOfBytes (1x)
+		57452: 113, // Value (1x)
+		57417: 114, // $default (0x)
+		57345: 115, // error (0x)
 	}
 
 	yyhintSymNames = []string{
 		"')'",
+		"','",
 		"hintAggToCop",
 		"hintBCJoin",
 		"hintBCJoinPreferLocal",
@@ -254,6 +263,7 @@ var (
 		"hintJoinOrder",
 		"hintJoinPrefix",
 		"hintJoinSuffix",
+		"hintLeading",
 		"hintLimitToCop",
 		"hintMaxExecutionTime",
 		"hintMemoryQuota",
@@ -261,6 +271,7 @@ var (
 		"hintMRR",
 		"hintNoBKA",
 		"hintNoBNL",
+		"hintNoDecorrelate",
 		"hintNoHashJoin",
 		"hintNoICP",
 		"hintNoIndexMerge",
@@ -288,7 +299,6 @@ var (
 		"hintUseIndexMerge",
 		"hintUsePlanCache",
 		"hintUseToja",
-		"','",
 		"hintDupsWeedOut",
 		"hintFirstMatch",
 		"hintLooseScan",
@@ -305,24 +315,26 @@ var (
 		"hintSingleAtIdentifier",
 		"']'",
 		"hintPartition",
+		"'('",
 		"'.'",
 		"'='",
-		"'('",
 		"$end",
 		"QueryBlockOpt",
 		"Identifier",
 		"hintIntLit",
+		"HintTable",
 		"hintStringLit",
 		"CommaOpt",
-		"HintTable",
 		"HintTableList",
 		"'['",
+		"LeadingTableElement",
 		"BooleanHintName",
 		"HintIndexList",
 		"HintStorageType",
 		"HintStorageTypeAndTable",
 		"HintTableListOpt",
 		"JoinOrderOptimizerHintName",
+		"LeadingTableList",
 		"NullaryHintName",
 		"PartitionListOpt",
 		"StorageOptimizerHintOpt",
@@ -351,488 +363,514 @@ var (
 
 	yyhintReductions = []struct{ xsym, components int }{
 		{0, 1},
-		{105, 1},
-		{103, 1},
-		{103, 3},
+		{109, 1},
+		{107, 1},
+		{107, 3},
+		{107, 1},
+		{107, 3},
+		{99, 4},
+		{99, 4},
+		{99, 4},
+		{99, 4},
+		{99, 4},
+		{99, 4},
+		{99, 5},
+		{99, 5},
+		{99, 5},
+		{99, 5},
+		{99, 6},
+		{99, 4},
+		{99, 4},
+		{99, 6},
+		{99, 6},
+		{99, 5},
+		{99, 4},
+		{99, 5},
+		{94, 5},
 		{103, 1},
 		{103, 3},
-		{95, 4},
-		{95, 4},
-		{95, 4},
-		{95, 4},
-		{95, 4},
-		{95, 4},
-		{95, 5},
-		{95, 5},
-		{95, 5},
-		{95, 6},
-		{95, 4},
-		{95, 4},
-		{95, 6},
-		{95, 6},
-		{95, 5},
-		{95, 4},
-		{95, 5},
-		{90, 5},
-		{99, 1},
-		{99, 3},
-		{85, 4},
-		{74, 0},
-		{74, 1},
-		{78, 0},
-		{78, 1},
-		{89, 0},
-		{89, 4},
-		{104, 1},
-		{104, 3},
-		{86, 1},
-		{86, 1},
-		{80, 2},
-		{80, 3},
+		{88, 4},
+		{76, 0},
+		{76, 1},
+		{81, 0},
+		{81, 1},
+		{93, 0},
+		{93, 4},
+		{108, 1},
+		{108, 3},
+		{89, 1},
+		{89, 1},
+		{82, 2},
+		{82, 3},
 		{79, 3},
 		{79, 5},
-		{83, 4},
-		{102, 0},
-		{102, 1},
-		{101, 1},
-		{101, 3},
-		{107, 0},
-		{107, 1},
+		{91, 1},
+		{91, 3},
+		{84, 1},
+		{84, 3},
+		{86, 4},
+		{106, 0},
 		{106, 1},
-		{106, 3},
-		{109, 1},
-		{109, 1},
-		{109, 1},
-		{108, 1},
-		{108, 1},
+		{105, 1},
+		{105, 3},
+		{111, 0},
+		{111, 1},
+		{110, 1},
+		{110, 3},
+		{113, 1},
+		{113, 1},
+		{113, 1},
+		{112, 1},
+		{112, 1},
+		{104, 1},
+		{104, 1},
+		{90, 1},
+		{90, 1},
+		{90, 1},
+		{101, 1},
+		{101, 1},
+		{101, 1},
+		{101, 1},
+		{101, 1},
+		{101, 1},
+		{101, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{98, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
+		{100, 1},
 		{100, 1},
 		{100, 1},
-		{87, 1},
-		{87, 1},
-		{87, 1},
-		{97, 1},
-		{97, 1},
-		{97, 1},
 		{97, 1},
 		{97, 1},
 		{97, 1},
 		{97, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{94, 1},
-		{96, 1},
-		{96, 1},
+		{95, 1},
+		{95, 1},
 		{96, 1},
 		{96, 1},
 		{96, 1},
 		{96, 1},
-		{96, 1},
-		{93, 1},
-		{93, 1},
-		{93, 1},
-		{93, 1},
-		{91, 1},
-		{91, 1},
+		{85, 1},
+		{85, 1},
 		{92, 1},
 		{92, 1},
 		{92, 1},
 		{92, 1},
-		{82, 1},
-		{82, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{88, 1},
-		{98, 1},
-		{98, 1},
-		{84, 1},
-		{84, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
-		{75, 1},
+		{92, 1},
+		{92, 1},
+		{92, 1},
+		{92, 1},
+		{92, 1},
+		{102, 1},
+		{102, 1},
+		{87, 1},
+		{87, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
+		{77, 1},
 	}
 
 	yyhintXErrors = map[yyhintXError]string{}
 
-	yyhintParseTab = [255][]uint16{
+	yyhintParseTab = [270][]uint16{
 		// 0
-		{1: 232, 206, 207, 198, 200, 224, 230, 213, 222, 236, 214, 209, 208, 212, 177, 195, 196, 197, 233, 184, 189, 203, 215, 199, 201, 202, 217, 234, 204, 216, 218, 226, 220, 211, 185, 188, 193, 235, 194, 187, 225, 186, 219, 205, 231, 210, 190, 228, 221, 223, 229, 227, 82: 191, 87: 178, 192, 90: 176, 183, 93: 182, 180, 175, 181, 179, 103: 174, 105: 173},
-		{73: 172},
-		{1: 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 324, 73: 171, 78: 424},
-		{1: 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 73: 170},
-		{1: 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 73: 168},
+		{2: 241, 215, 216, 207, 209, 233, 239, 222, 231, 246, 223, 218, 217, 221, 185, 204, 205, 206, 193, 242, 192, 198, 212, 224, 208, 210, 243, 211, 226, 244, 213, 225, 227, 235, 229, 220, 194, 197, 202, 245, 203, 196, 234, 195, 228, 214, 240, 219, 199, 237, 230, 232, 238, 236, 85: 200, 90: 186, 92: 201, 94: 184, 191, 97: 190, 188, 183, 189, 187, 107: 182, 109: 181},
+		{75: 180},
+		{1: 336, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 75: 179, 81: 447},
+		{1: 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 178, 75: 178},
+		{1: 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 176, 75: 176},
 		// 5
-		{72: 421},
-		{72: 418},
-		{72: 415},
-		{72: 410},
-		{72: 407},
+		{72: 444},
+		{72: 441},
+		{72: 438},
+		{72: 433},
+		{72: 430},
 		// 10
-		{72: 396},
-		{72: 384},
-		{72: 380},
-		{72: 376},
-		{72: 368},
+		{72: 419},
+		{72: 407},
+		{72: 403},
+		{72: 392},
+		{72: 388},
 		// 15
-		{72: 365},
+		{72: 380},
+		{72: 377},
+		{72: 374},
+		{72: 367},
 		{72: 362},
-		{72: 355},
-		{72: 350},
-		{72: 344},
 		// 20
-		{72: 341},
-		{72: 335},
-		{72: 237},
+		{72: 356},
+		{72: 353},
+		{72: 347},
+		{72: 247},
+		{72: 118},
+		// 25
+		{72: 117},
+		{72: 116},
 		{72: 115},
 		{72: 114},
-		// 25
 		{72: 113},
+		// 30
 		{72: 112},
 		{72: 111},
 		{72: 110},
 		{72: 109},
-		// 30
 		{72: 108},
+		// 35
 		{72: 107},
 		{72: 106},
 		{72: 105},
 		{72: 104},
-		// 35
 		{72: 103},
+		// 40
 		{72: 102},
 		{72: 101},
 		{72: 100},
 		{72: 99},
-		// 40
 		{72: 98},
+		// 45
 		{72: 97},
 		{72: 96},
 		{72: 95},
 		{72: 94},
-		// 45
 		{72: 93},
+		// 50
 		{72: 92},
 		{72: 91},
 		{72: 90},
 		{72: 89},
-		// 50
 		{72: 88},
-		{72: 87},
-		{72: 86},
-		{72: 85},
-		{72: 84},
 		// 55
+		{72: 87},
+		{72: 82},
+		{72: 81},
+		{72: 80},
 		{72: 79},
+		// 60
 		{72: 78},
 		{72: 77},
 		{72: 76},
 		{72: 75},
-		// 60
 		{72: 74},
+		// 65
 		{72: 73},
 		{72: 72},
-		{72: 71},
-		{72: 70},
-		// 65
-		{58: 145, 145, 67: 239, 74: 238},
-		{58: 244, 243, 84: 242, 241, 99: 240},
-		{144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 144, 68: 144, 144, 76: 144},
-		{332, 53: 333},
-		{148, 53: 148},
+		{60: 152, 152, 69: 249, 76: 248},
+		{60: 254, 253, 87: 252, 251, 103: 250},
+		{151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 70: 151, 151, 151, 78: 151},
 		// 70
-		{81: 245},
-		{81: 67},
-		{81: 66},
-		{1: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 247, 80: 246},
-		{53: 330, 68: 329},
+		{344, 345},
+		{155, 155},
+		{83: 255},
+		{83: 69},
+		{83: 68},
 		// 75
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 249, 79: 248},
-		{135, 53: 135, 68: 135},
-		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 145, 145, 316, 74: 315},
-		{65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65},
-		{64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64},
+		{2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 257, 82: 256},
+		{1: 342, 70: 341},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 259, 79: 258},
+		{142, 142, 70: 142},
+		{152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 152, 152, 73: 328, 76: 327},
 		// 80
-		{63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63},
-		{62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62},
-		{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61},
-		{60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60},
-		{59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59},
+		{67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 67, 73: 67, 67},
+		{66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 66, 73: 66, 66},
+		{65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 65, 73: 65, 65},
+		{64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 64, 73: 64, 64},
+		{63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 63, 73: 63, 63},
 		// 85
-		{58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58},
-		{57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57},
-		{56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56},
-		{55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55},
-		{54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54},
+		{62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 62, 73: 62, 62},
+		{61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 61, 73: 61, 61},
+		{60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 60, 73: 60, 60},
+		{59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 59, 73: 59, 59},
+		{58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 58, 73: 58, 58},
 		// 90
-		{53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53},
-		{52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52},
-		{51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51},
-		{50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50},
-		{49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49},
+		{57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 57, 73: 57, 57},
+		{56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 56, 73: 56, 56},
+		{55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 55, 73: 55, 55},
+		{54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 54, 73: 54, 54},
+		{53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 53, 73: 53, 53},
 		// 95
-		{48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48},
-		{47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47},
-		{46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46},
-		{45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45},
-		{44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44},
+		{52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 52, 73: 52, 52},
+		{51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 51, 73: 51, 51},
+		{50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 50, 73: 50, 50},
+		{49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 49, 73: 49, 49},
+		{48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 48, 73: 48, 48},
 		// 100
-		{43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43},
-		{42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42},
-		{41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41},
-		{40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40},
-		{39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39},
+		{47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 47, 73: 47, 47},
+		{46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 46, 73: 46, 46},
+		{45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 45, 73: 45, 45},
+		{44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 44, 73: 44, 44},
+		{43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 43, 73: 43, 43},
 		// 105
-		{38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38},
-		{37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37},
-		{36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36},
-		{35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35},
-		{34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34},
+		{42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 42, 73: 42, 42},
+		{41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 41, 73: 41, 41},
+		{40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 40, 73: 40, 40},
+		{39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 39, 73: 39, 39},
+		{38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 38, 73: 38, 38},
 		// 110
-		{33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33},
-		{32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32},
-		{31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31},
-		{30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
-		{29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29},
+		{37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 37, 73: 37, 37},
+		{36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 36, 73: 36, 36},
+		{35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 35, 73: 35, 35},
+		{34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 34, 73: 34, 34},
+		{33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 33, 73: 33, 33},
 		// 115
-		{28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
-		{27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27},
-		{26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26},
-		{25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25},
-		{24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24},
+		{32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 32, 73: 32, 32},
+		{31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 31, 73: 31, 31},
+		{30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 73: 30, 30},
+		{29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 29, 73: 29, 29},
+		{28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 73: 28, 28},
 		// 120
-		{23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23},
-		{22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22},
-		{21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21},
-		{20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20},
-		{19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19},
+		{27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 27, 73: 27, 27},
+		{26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 26, 73: 26, 26},
+		{25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 25, 73: 25, 25},
+		{24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 24, 73: 24, 24},
+		{23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 23, 73: 23, 23},
 		// 125
-		{18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18},
-		{17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17},
-		{16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16},
-		{15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15},
-		{14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14},
+		{22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 22, 73: 22, 22},
+		{21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 21, 73: 21, 21},
+		{20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 20, 73: 20, 20},
+		{19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 19, 73: 19, 19},
+		{18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 18, 73: 18, 18},
 		// 130
-		{13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13},
-		{12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12},
-		{11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11},
-		{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10},
-		{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9},
+		{17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 17, 73: 17, 17},
+		{16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 16, 73: 16, 16},
+		{15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 15, 73: 15, 15},
+		{14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 14, 73: 14, 14},
+		{13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 13, 73: 13, 13},
 		// 135
-		{8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8},
-		{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7},
-		{6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6},
-		{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5},
-		{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4},
+		{12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 12, 73: 12, 12},
+		{11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 11, 73: 11, 11},
+		{10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 10, 73: 10, 10},
+		{9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 9, 73: 9, 9},
+		{8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 8, 73: 8, 8},
 		// 140
-		{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3},
-		{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
-		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1},
-		{141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 68: 141, 319, 89: 328},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 317},
+		{7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 7, 73: 7, 7},
+		{6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 6, 73: 6, 6},
+		{5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 5, 73: 5, 5},
+		{4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 4, 73: 4, 4},
+		{3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 3, 73: 3, 3},
 		// 145
-		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 145, 145, 74: 318},
-		{141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 141, 68: 141, 319, 89: 320},
-		{72: 321},
-		{132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 132, 68: 132},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 323, 104: 322},
+		{2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 2, 73: 2, 2},
+		{1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 1, 73: 1, 1},
+		{148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 70: 148, 331, 93: 340},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 329},
+		{152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 152, 152, 76: 330},
 		// 150
-		{325, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 324, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 78: 326},
-		{139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139},
-		{142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 54: 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 142, 77: 142},
-		{140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 68: 140},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 327},
+		{148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 148, 70: 148, 331, 93: 332},
+		{72: 333},
+		{139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 139, 70: 139},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 335, 108: 334},
+		{337, 336, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 81: 338},
 		// 155
-		{138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138, 138},
-		{133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 133, 68: 133},
-		{146, 53: 146},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 249, 79: 331},
-		{134, 53: 134, 68: 134},
+		{146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146, 146},
+		{149, 2: 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 80: 149},
+		{147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 147, 70: 147},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 339},
+		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145},
 		// 160
-		{1: 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 149, 73: 149},
-		{58: 244, 243, 84: 242, 334},
-		{147, 53: 147},
-		{61: 145, 145, 67: 239, 74: 336},
-		{61: 338, 339, 98: 337},
+		{140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 140, 70: 140},
+		{153, 153},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 259, 79: 343},
+		{141, 141, 70: 141},
+		{1: 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 75: 156},
 		// 165
-		{340},
-		{69},
-		{68},
-		{1: 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 73: 150},
-		{145, 67: 239, 74: 342},
+		{60: 254, 253, 87: 252, 346},
+		{154, 154},
+		{63: 152, 152, 69: 249, 76: 348},
+		{63: 350, 351, 102: 349},
+		{352},
 		// 170
-		{343},
-		{1: 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 151, 73: 151},
-		{60: 145, 63: 145, 67: 239, 74: 345},
-		{60: 348, 63: 347, 100: 346},
-		{349},
+		{71},
+		{70},
+		{1: 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 75: 157},
+		{152, 69: 249, 76: 354},
+		{355},
 		// 175
-		{117},
-		{116},
-		{1: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 73: 152},
-		{77: 351},
-		{53: 324, 77: 143, 352},
-		// 180
-		{77: 353},
-		{354},
-		{1: 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 153, 73: 153},
-		{67: 239, 74: 356, 76: 145},
-		{76: 357},
-		// 185
-		{64: 360, 359, 108: 358},
+		{1: 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 75: 158},
+		{62: 152, 65: 152, 69: 249, 76: 357},
+		{62: 360, 65: 359, 104: 358},
 		{361},
+		{120},
+		// 180
 		{119},
-		{118},
-		{1: 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 154, 73: 154},
+		{1: 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 75: 159},
+		{80: 363},
+		{1: 336, 80: 150, 364},
+		{80: 365},
+		// 185
+		{366},
+		{1: 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 75: 160},
+		{69: 249, 76: 368, 78: 152},
+		{78: 369},
+		{66: 372, 371, 112: 370},
 		// 190
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 363},
-		{364},
-		{1: 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 155, 73: 155},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 366},
-		{367},
-		// 195
-		{1: 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 156, 73: 156},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 369},
-		{71: 370},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 373, 374, 372, 109: 371},
-		{375},
-		// 200
+		{373},
 		{122},
 		{121},
-		{120},
-		{1: 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 157, 73: 157},
-		{67: 239, 74: 377, 76: 145},
-		// 205
-		{76: 378},
+		{1: 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 75: 161},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 375},
+		// 195
+		{376},
+		{1: 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 75: 162},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 378},
 		{379},
-		{1: 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 158, 73: 158},
-		{67: 239, 74: 381, 76: 145},
-		{76: 382},
+		{1: 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 75: 163},
+		// 200
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 381},
+		{74: 382},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 385, 386, 80: 384, 113: 383},
+		{387},
+		{125},
+		// 205
+		{124},
+		{123},
+		{1: 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 75: 164},
+		{69: 249, 76: 389, 78: 152},
+		{78: 390},
 		// 210
-		{383},
-		{1: 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 159, 73: 159},
-		{145, 54: 145, 145, 145, 145, 67: 239, 74: 385},
-		{126, 54: 389, 390, 391, 392, 92: 388, 106: 387, 386},
-		{395},
+		{391},
+		{1: 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 75: 165},
+		{2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 72: 152, 76: 393},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 72: 397, 77: 259, 79: 396, 84: 395, 91: 394},
+		{402, 399},
 		// 215
-		{125, 53: 393},
-		{124, 53: 124},
-		{83, 53: 83},
-		{82, 53: 82},
-		{81, 53: 81},
+		{138, 138},
+		{136, 136},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 72: 397, 77: 259, 79: 396, 84: 395, 91: 398},
+		{400, 399},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 72: 397, 77: 259, 79: 396, 84: 401},
 		// 220
-		{80, 53: 80},
-		{54: 389, 390, 391, 392, 92: 394},
-		{123, 53: 123},
-		{1: 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 160, 73: 160},
-		{1: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 398, 83: 397},
+		{135, 135},
+		{137, 137},
+		{1: 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 75: 166},
+		{69: 249, 76: 404, 78: 152},
+		{78: 405},
 		// 225
 		{406},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 249, 79: 399},
-		{143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 324, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 143, 78: 400},
-		{130, 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 403, 101: 402, 401},
-		{131},
+		{1: 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 75: 167},
+		{152, 56: 152, 152, 152, 152, 69: 249, 76: 408},
+		{129, 56: 412, 413, 414, 415, 96: 411, 110: 410, 409},
+		{418},
 		// 230
-		{129, 53: 404},
-		{128, 53: 128},
-		{1: 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 405},
-		{127, 53: 127},
-		{1: 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 161, 73: 161},
+		{128, 416},
+		{127, 127},
+		{86, 86},
+		{85, 85},
+		{84, 84},
 		// 235
-		{1: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 398, 83: 408},
-		{409},
-		{1: 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 162, 73: 162},
-		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 413, 80: 412, 86: 411},
-		{414},
+		{83, 83},
+		{56: 412, 413, 414, 415, 96: 417},
+		{126, 126},
+		{1: 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 168, 75: 168},
+		{2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 421, 86: 420},
 		// 240
-		{137, 53: 330},
-		{136, 277, 291, 292, 255, 257, 302, 280, 259, 281, 279, 263, 282, 283, 284, 251, 252, 253, 254, 278, 273, 285, 261, 265, 256, 258, 260, 267, 264, 262, 266, 268, 272, 270, 286, 301, 276, 287, 288, 289, 275, 271, 274, 269, 290, 293, 294, 299, 300, 296, 295, 297, 298, 54: 311, 312, 313, 314, 306, 305, 307, 303, 304, 308, 310, 309, 250, 75: 249, 79: 248},
-		{1: 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 163, 73: 163},
-		{145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 413, 80: 412, 86: 416},
-		{417},
+		{429},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 259, 79: 422},
+		{150, 336, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 150, 81: 423},
+		{133, 2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 426, 105: 425, 424},
+		{134},
 		// 245
-		{1: 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 164, 73: 164},
-		{1: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 54: 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 145, 239, 74: 247, 80: 419},
-		{420, 53: 330},
-		{1: 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 165, 73: 165},
-		{145, 67: 239, 74: 422},
+		{132, 427},
+		{131, 131},
+		{2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 428},
+		{130, 130},
+		{1: 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 75: 169},
 		// 250
-		{423},
-		{1: 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 166, 73: 166},
-		{1: 232, 206, 207, 198, 200, 224, 230, 213, 222, 236, 214, 209, 208, 212, 177, 195, 196, 197, 233, 184, 189, 203, 215, 199, 201, 202, 217, 234, 204, 216, 218, 226, 220, 211, 185, 188, 193, 235, 194, 187, 225, 186, 219, 205, 231, 210, 190, 228, 221, 223, 229, 227, 82: 191, 87: 178, 192, 90: 426, 183, 93: 182, 180, 425, 181, 179},
-		{1: 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 169, 73: 169},
-		{1: 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 167, 73: 167},
+		{2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 421, 86: 431},
+		{432},
+		{1: 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 170, 75: 170},
+		{152, 2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 436, 82: 435, 89: 434},
+		{437},
+		// 255
+		{144, 342},
+		{143, 2: 287, 302, 303, 265, 267, 313, 291, 269, 292, 290, 273, 293, 294, 295, 261, 262, 263, 264, 314, 288, 283, 296, 271, 275, 266, 268, 289, 270, 277, 274, 272, 276, 278, 282, 280, 297, 312, 286, 298, 299, 300, 285, 281, 284, 279, 301, 304, 305, 310, 311, 307, 306, 308, 309, 323, 324, 325, 326, 318, 317, 319, 315, 316, 320, 322, 321, 260, 77: 259, 79: 258},
+		{1: 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 171, 75: 171},
+		{152, 2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 436, 82: 435, 89: 439},
+		{440},
+		// 260
+		{1: 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 172, 75: 172},
+		{2: 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 152, 249, 76: 257, 82: 442},
+		{443, 342},
+		{1: 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 173, 75: 173},
+		{152, 69: 249, 76: 445},
+		// 265
+		{446},
+		{1: 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 174, 75: 174},
+		{2: 241, 215, 216, 207, 209, 233, 239, 222, 231, 246, 223, 218, 217, 221, 185, 204, 205, 206, 193, 242, 192, 198, 212, 224, 208, 210, 243, 211, 226, 244, 213, 225, 227, 235, 229, 220, 194, 197, 202, 245, 203, 196, 234, 195, 228, 214, 240, 219, 199, 237, 230, 232, 238, 236, 85: 200, 90: 186, 92: 201, 94: 449, 191, 97: 190, 188, 448, 189, 187},
+		{1: 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 177, 75: 177},
+		{1: 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 175, 75: 175},
 	}
 )
 
@@ -872,7 +910,7 @@ func yyhintlex1(yylex yyhintLexer, lval *yyhintSymType) (n int) {
 }
 
 func yyhintParse(yylex yyhintLexer, parser *hintParser) int {
-	const yyError = 111
+	const yyError = 115
 
 	yyEx, _ := yylex.(yyhintLexerEx)
 	var yyn int
@@ -1123,10 +1161,19 @@ yynewstate:
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-4].ident),
 				QBName:   model.NewCIStr(yyS[yypt-2].ident),
-				HintData: int64(yyS[yypt-1].number),
+				Tables:   yyS[yypt-1].leadingList.Tables(),
+				HintData: yyS[yypt-1].leadingList,
 			}
 		}
 	case 15:
+		{
+			parser.yyVAL.hint = &ast.TableOptimizerHint{
+				HintName: model.NewCIStr(yyS[yypt-4].ident),
+				QBName:   model.NewCIStr(yyS[yypt-2].ident),
+				HintData: int64(yyS[yypt-1].number),
+			}
+		}
+	case 16:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-5].ident),
@@ -1136,19 +1183,19 @@ yynewstate:
 				},
 			}
 		}
-	case 16:
+	case 17:
 		{
 			parser.warnUnsupportedHint(yyS[yypt-3].ident)
 			parser.yyVAL.hint = nil
 		}
-	case 17:
+	case 18:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-3].ident),
 				QBName:   model.NewCIStr(yyS[yypt-1].ident),
 			}
 		}
-	case 18:
+	case 19:
 		{
 			maxValue := uint64(math.MaxInt64) / yyS[yypt-1].number
 			if yyS[yypt-2].number <= maxValue {
@@ -1163,7 +1210,7 @@ yynewstate:
 				parser.yyVAL.hint = nil
 			}
 		}
-	case 19:
+	case 20:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-5].ident),
@@ -1173,21 +1220,21 @@ yynewstate:
 				},
 			}
 		}
-	case 20:
+	case 21:
 		{
 			h := yyS[yypt-1].hint
 			h.HintName = model.NewCIStr(yyS[yypt-4].ident)
 			h.QBName = model.NewCIStr(yyS[yypt-2].ident)
 			parser.yyVAL.hint = h
 		}
-	case 21:
+	case 22:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-3].ident),
 				QBName:   model.NewCIStr(yyS[yypt-1].ident),
 			}
 		}
-	case 22:
+	case 23:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				HintName: model.NewCIStr(yyS[yypt-4].ident),
@@ -1195,7 +1242,7 @@ yynewstate:
 				HintData: model.NewCIStr(yyS[yypt-1].ident),
 			}
 		}
-	case 23:
+	case 24:
 		{
 			hs := yyS[yypt-1].hints
 			name := model.NewCIStr(yyS[yypt-4].ident)
@@ -1206,60 +1253,60 @@ yynewstate:
 			}
 			parser.yyVAL.hints = hs
 		}
-	case 24:
+	case 25:
 		{
 			parser.yyVAL.hints = []*ast.TableOptimizerHint{yyS[yypt-0].hint}
 		}
-	case 25:
+	case 26:
 		{
 			parser.yyVAL.hints = append(yyS[yypt-2].hints, yyS[yypt-0].hint)
 		}
-	case 26:
+	case 27:
 		{
 			h := yyS[yypt-1].hint
 			h.HintData = model.NewCIStr(yyS[yypt-3].ident)
 			parser.yyVAL.hint = h
 		}
-	case 27:
+	case 28:
 		{
 			parser.yyVAL.ident = ""
 		}
-	case 31:
+	case 32:
 		{
 			parser.yyVAL.modelIdents = nil
 		}
-	case 32:
+	case 33:
 		{
 			parser.yyVAL.modelIdents = yyS[yypt-1].modelIdents
 		}
-	case 33:
+	case 34:
 		{
 			parser.yyVAL.modelIdents = []model.CIStr{model.NewCIStr(yyS[yypt-0].ident)}
 		}
-	case 34:
+	case 35:
 		{
 			parser.yyVAL.modelIdents = append(yyS[yypt-2].modelIdents, model.NewCIStr(yyS[yypt-0].ident))
 		}
-	case 36:
+	case 37:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				QBName: model.NewCIStr(yyS[yypt-0].ident),
 			}
 		}
-	case 37:
+	case 38:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				Tables: []ast.HintTable{yyS[yypt-0].table},
 				QBName: model.NewCIStr(yyS[yypt-1].ident),
 			}
 		}
-	case 38:
+	case 39:
 		{
 			h := yyS[yypt-2].hint
 			h.Tables = append(h.Tables, yyS[yypt-0].table)
 			parser.yyVAL.hint = h
 		}
-	case 39:
+	case 40:
 		{
 			parser.yyVAL.table = ast.HintTable{
 				TableName:     model.NewCIStr(yyS[yypt-2].ident),
@@ -1267,7 +1314,7 @@ yynewstate:
 				PartitionList: yyS[yypt-0].modelIdents,
 			}
 		}
-	case 40:
+	case 41:
 		{
 			parser.yyVAL.table = ast.HintTable{
 				DBName:        model.NewCIStr(yyS[yypt-4].ident),
@@ -1276,46 +1323,65 @@ yynewstate:
 				PartitionList: yyS[yypt-0].modelIdents,
 			}
 		}
-	case 41:
+	case 42:
+		{
+			parser.yyVAL.leadingList = &ast.LeadingList{Items: []interface{}{yyS[yypt-0].leadingElement}}
+		}
+	case 43:
+		{
+			h := yyS[yypt-2].leadingList
+			h.Items = append(h.Items, yyS[yypt-0].leadingElement)
+			parser.yyVAL.leadingList = h
+		}
+	case 44:
+		{
+			table := yyS[yypt-0].table
+			parser.yyVAL.leadingElement = &table
+		}
+	case 45:
+		{
+			parser.yyVAL.leadingElement = yyS[yypt-1].leadingList
+		}
+	case 46:
 		{
 			h := yyS[yypt-0].hint
 			h.Tables = []ast.HintTable{yyS[yypt-2].table}
 			h.QBName = model.NewCIStr(yyS[yypt-3].ident)
 			parser.yyVAL.hint = h
 		}
-	case 42:
+	case 47:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{}
 		}
-	case 44:
+	case 49:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{
 				Indexes: []model.CIStr{model.NewCIStr(yyS[yypt-0].ident)},
 			}
 		}
-	case 45:
+	case 50:
 		{
 			h := yyS[yypt-2].hint
 			h.Indexes = append(h.Indexes, model.NewCIStr(yyS[yypt-0].ident))
 			parser.yyVAL.hint = h
 		}
-	case 52:
+	case 57:
 		{
 			parser.yyVAL.ident = strconv.FormatUint(yyS[yypt-0].number, 10)
 		}
-	case 53:
+	case 58:
 		{
 			parser.yyVAL.number = 1024 * 1024
 		}
-	case 54:
+	case 59:
 		{
 			parser.yyVAL.number = 1024 * 1024 * 1024
 		}
-	case 55:
+	case 60:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{HintData: true}
 		}
-	case 56:
+	case 61:
 		{
 			parser.yyVAL.hint = &ast.TableOptimizerHint{HintData: false}
 		}
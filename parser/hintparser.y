@@ -32,6 +32,8 @@ import (
 	hints []*ast.TableOptimizerHint
 	table 	ast.HintTable
 	modelIdents []model.CIStr
+	leadingList *ast.LeadingList
+	leadingElement interface{}
 }
 
 %token	<number>
@@ -105,6 +107,8 @@ import (
 	hintNthPlan               "NTH_PLAN"
 	hintLimitToCop            "LIMIT_TO_COP"
 	hintForceIndex            "FORCE_INDEX"
+	hintLeading               "LEADING"
+	hintNoDecorrelate         "NO_DECORRELATE"
 
 	/* Other keywords */
 	hintOLAP            "OLAP"
@@ -165,6 +169,12 @@ import (
 	PartitionList    "partition name list in optimizer hint"
 	PartitionListOpt "optional partition name list in optimizer hint"
 
+%type	<leadingList>
+	LeadingTableList "table list in the LEADING hint"
+
+%type	<leadingElement>
+	LeadingTableElement "table or nested table list in the LEADING hint"
+
 
 %start	Start
 
@@ -246,6 +256,15 @@ TableOptimizerHintOpt:
 			HintData: $4,
 		}
 	}
+|	"LEADING" '(' QueryBlockOpt LeadingTableList ')'
+	{
+		$$ = &ast.TableOptimizerHint{
+			HintName: model.NewCIStr($1),
+			QBName:   model.NewCIStr($3),
+			Tables:   $4.Tables(),
+			HintData: $4,
+		}
+	}
 |	"NTH_PLAN" '(' QueryBlockOpt hintIntLit ')'
 	{
 		$$ = &ast.TableOptimizerHint{
@@ -438,6 +457,35 @@ HintTable:
 		}
 	}
 
+/**
+ * LeadingTableList:
+ *
+ *	tbl_name [, tbl_name | (LeadingTableList)] ...
+ *	(LeadingTableList) [, tbl_name | (LeadingTableList)] ...
+ */
+LeadingTableList:
+	LeadingTableElement
+	{
+		$$ = &ast.LeadingList{Items: []interface{}{$1}}
+	}
+|	LeadingTableList ',' LeadingTableElement
+	{
+		h := $1
+		h.Items = append(h.Items, $3)
+		$$ = h
+	}
+
+LeadingTableElement:
+	HintTable
+	{
+		table := $1
+		$$ = &table
+	}
+|	'(' LeadingTableList ')'
+	{
+		$$ = $2
+	}
+
 /**
  * HintIndexList:
  *
@@ -577,6 +625,7 @@ NullaryHintName:
 |	"STREAM_AGG"
 |	"AGG_TO_COP"
 |	"LIMIT_TO_COP"
+|	"NO_DECORRELATE"
 |	"NO_INDEX_MERGE"
 |	"READ_CONSISTENT_REPLICA"
 |	"IGNORE_PLAN_CACHE"
@@ -621,6 +670,7 @@ Identifier:
 /* TiDB hint names */
 |	"AGG_TO_COP"
 |	"LIMIT_TO_COP"
+|	"NO_DECORRELATE"
 |	"IGNORE_PLAN_CACHE"
 |	"HASH_AGG"
 |	"IGNORE_INDEX"
@@ -645,6 +695,7 @@ Identifier:
 |	"USE_CASCADES"
 |	"NTH_PLAN"
 |	"FORCE_INDEX"
+|	"LEADING"
 /* other keywords */
 |	"OLAP"
 |	"OLTP"
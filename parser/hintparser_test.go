@@ -146,6 +146,34 @@ func TestParseHint(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "LEADING(t1, (t2, db.t3@qb2)) LEADING(@qb1 t4)",
+			output: []*ast.TableOptimizerHint{
+				{
+					HintName: model.NewCIStr("LEADING"),
+					Tables: []ast.HintTable{
+						{TableName: model.NewCIStr("t1")},
+						{TableName: model.NewCIStr("t2")},
+						{DBName: model.NewCIStr("db"), TableName: model.NewCIStr("t3"), QBName: model.NewCIStr("qb2")},
+					},
+					HintData: &ast.LeadingList{Items: []interface{}{
+						&ast.HintTable{TableName: model.NewCIStr("t1")},
+						&ast.LeadingList{Items: []interface{}{
+							&ast.HintTable{TableName: model.NewCIStr("t2")},
+							&ast.HintTable{DBName: model.NewCIStr("db"), TableName: model.NewCIStr("t3"), QBName: model.NewCIStr("qb2")},
+						}},
+					}},
+				},
+				{
+					HintName: model.NewCIStr("LEADING"),
+					QBName:   model.NewCIStr("qb1"),
+					Tables:   []ast.HintTable{{TableName: model.NewCIStr("t4")}},
+					HintData: &ast.LeadingList{Items: []interface{}{
+						&ast.HintTable{TableName: model.NewCIStr("t4")},
+					}},
+				},
+			},
+		},
 		{
 			input: "USE_INDEX_MERGE(@qb1 tbl1 x, y, z) IGNORE_INDEX(tbl2@qb2) USE_INDEX(tbl3 PRIMARY) FORCE_INDEX(tbl4@qb3 c1)",
 			output: []*ast.TableOptimizerHint{
@@ -151,12 +151,42 @@ var tokenMap = map[string]int{
 	"ANALYZE":                  analyze,
 	"AND":                      and,
 	"ANY":                      any,
+	"ARRAY":                    array,
 	"APPROX_COUNT_DISTINCT":    approxCountDistinct,
 	"APPROX_PERCENTILE":        approxPercentile,
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
 	"ATTRIBUTES":               attributes,
+	"BEFORE":                   before,
+	"CLOSE":                    closeKwd,
+	"CONTAINS":                 contains,
+	"CONTINUE":                 continueKwd,
+	"CURSOR":                   cursor,
+	"DECLARE":                  declare,
+	"DETERMINISTIC":            deterministic,
+	"ELSEIF":                   elseIfKwd,
+	"EACH":                     each,
+	"EXIT":                     exit,
+	"FOLLOWS":                  follows,
+	"FOUND":                    found,
+	"HANDLER":                  handler,
+	"INOUT":                    inout,
+	"ITERATE":                  iterate,
+	"LEAVE":                    leave,
+	"LOOP":                     loop,
+	"MESSAGE_TEXT":             messageText,
+	"MODIFIES":                 modifies,
+	"MYSQL_ERRNO":              mysqlErrno,
+	"OUT":                      out,
+	"PRECEDES":                 precedes,
+	"READS":                    reads,
+	"RETURN":                   returnKwd,
+	"RETURNS":                  returns,
+	"SIGNAL":                   signal,
+	"SQLEXCEPTION":             sqlexception,
+	"SQLSTATE":                 sqlstate,
+	"SQLWARNING":               sqlwarning,
 	"STATS_OPTIONS":            statsOptions,
 	"STATS_SAMPLE_RATE":        statsSampleRate,
 	"STATS_COL_CHOICE":         statsColChoice,
@@ -302,6 +332,7 @@ var tokenMap = map[string]int{
 	"DUPLICATE":                duplicate,
 	"DYNAMIC":                  dynamic,
 	"ELSE":                     elseKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
@@ -354,6 +385,8 @@ var tokenMap = map[string]int{
 	"GENERAL":                  general,
 	"GENERATED":                generated,
 	"GET_FORMAT":               getFormat,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geometryCollection,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
 	"GRANTS":                   grants,
@@ -408,6 +441,7 @@ var tokenMap = map[string]int{
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
@@ -433,6 +467,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineString,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -461,6 +496,7 @@ var tokenMap = map[string]int{
 	"MEDIUMBLOB":               mediumblobType,
 	"MEDIUMINT":                mediumIntType,
 	"MEDIUMTEXT":               mediumtextType,
+	"MEMBER":                   member,
 	"MEMORY":                   memory,
 	"MERGE":                    merge,
 	"MICROSECOND":              microsecond,
@@ -474,6 +510,9 @@ var tokenMap = map[string]int{
 	"MODE":                     mode,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineString,
+	"MULTIPOINT":               multiPoint,
+	"MULTIPOLYGON":             multiPolygon,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
@@ -481,6 +520,7 @@ var tokenMap = map[string]int{
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
+	"NESTED":                   nested,
 	"NEXTVAL":                  nextval,
 	"NO_WRITE_TO_BINLOG":       noWriteToBinLog,
 	"NO":                       no,
@@ -513,6 +553,7 @@ var tokenMap = map[string]int{
 	"OPTIMIZE":                 optimize,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"ORDINALITY":               ordinality,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -526,6 +567,7 @@ var tokenMap = map[string]int{
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
 	"PASSWORD":                 password,
+	"PATH":                     pathKwd,
 	"PERCENT":                  percent,
 	"PER_DB":                   per_db,
 	"PER_TABLE":                per_table,
@@ -533,7 +575,9 @@ var tokenMap = map[string]int{
 	"PLACEMENT":                placement,
 	"PLAN":                     plan,
 	"PLUGINS":                  plugins,
+	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygon,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -590,6 +634,7 @@ var tokenMap = map[string]int{
 	"RESTORE":                  restore,
 	"RESTORES":                 restores,
 	"RESTRICT":                 restrict,
+	"RETURNING":                returning,
 	"REVERSE":                  reverse,
 	"REVOKE":                   revoke,
 	"RIGHT":                    right,
@@ -751,6 +796,7 @@ var tokenMap = map[string]int{
 	"UNKNOWN":                  unknown,
 	"UNLOCK":                   unlock,
 	"UNSIGNED":                 unsigned,
+	"UNTIL":                    until,
 	"UPDATE":                   update,
 	"USAGE":                    usage,
 	"USE":                      use,
@@ -782,6 +828,7 @@ var tokenMap = map[string]int{
 	"WEIGHT_STRING":            weightString,
 	"WHEN":                     when,
 	"WHERE":                    where,
+	"WHILE":                    while,
 	"WIDTH":                    width,
 	"WITH":                     with,
 	"WITHOUT":                  without,
@@ -810,6 +857,7 @@ var btFuncTokenMap = map[string]int{
 	"DATE_SUB":              builtinDateSub,
 	"EXTRACT":               builtinExtract,
 	"GROUP_CONCAT":          builtinGroupConcat,
+	"JSON_VALUE":            builtinJSONValue,
 	"MAX":                   builtinMax,
 	"MID":                   builtinSubstring,
 	"MIN":                   builtinMin,
@@ -904,6 +952,7 @@ var hintTokenMap = map[string]int{
 	// TiDB hint names
 	"AGG_TO_COP":              hintAggToCop,
 	"LIMIT_TO_COP":            hintLimitToCop,
+	"NO_DECORRELATE":          hintNoDecorrelate,
 	"IGNORE_PLAN_CACHE":       hintIgnorePlanCache,
 	"HASH_AGG":                hintHashAgg,
 	"IGNORE_INDEX":            hintIgnoreIndex,
@@ -928,6 +977,7 @@ var hintTokenMap = map[string]int{
 	"USE_CASCADES":            hintUseCascades,
 	"NTH_PLAN":                hintNthPlan,
 	"FORCE_INDEX":             hintForceIndex,
+	"LEADING":                 hintLeading,
 
 	// TiDB hint aliases
 	"TIDB_HJ":   hintHashJoin,
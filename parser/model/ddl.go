@@ -94,6 +94,14 @@ const (
 	ActionAlterCacheTable               ActionType = 57
 	ActionAlterTableStatsOptions        ActionType = 58
 	ActionAlterNoCacheTable             ActionType = 59
+	ActionReorganizePartition           ActionType = 60
+	ActionMultiSchemaChange             ActionType = 61
+	ActionAlterTablePartitioning        ActionType = 62
+	ActionRemovePartitioning            ActionType = 63
+	ActionCreateRoutine                 ActionType = 64
+	ActionDropRoutine                   ActionType = 65
+	ActionCreateTrigger                 ActionType = 66
+	ActionDropTrigger                   ActionType = 67
 )
 
 var actionMap = map[ActionType]string{
@@ -151,6 +159,14 @@ var actionMap = map[ActionType]string{
 	ActionModifySchemaDefaultPlacement:  "modify schema default placement",
 	ActionAlterCacheTable:               "alter cache table",
 	ActionAlterTableStatsOptions:        "alter table statistics options",
+	ActionReorganizePartition:           "alter table reorganize partition",
+	ActionMultiSchemaChange:             "alter table multi-schema change",
+	ActionAlterTablePartitioning:        "alter table partition by",
+	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionCreateRoutine:                 "create routine",
+	ActionDropRoutine:                   "drop routine",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
@@ -215,6 +231,93 @@ func NewDDLReorgMeta() *DDLReorgMeta {
 // MultiSchemaInfo keeps some information for multi schema change.
 type MultiSchemaInfo struct {
 	Warnings []*errors.Error
+
+	// SubJobs are the sub-jobs of an ActionMultiSchemaChange job.
+	SubJobs []*SubJob `json:"sub_jobs"`
+	// Revertible indicates whether the job can be rolled back as a whole.
+	// It becomes false once all the sub-jobs reach their non-revertible states.
+	Revertible bool `json:"revertible"`
+
+	// The following names are only used for checking the conflicts
+	// between the sub-jobs before the job is submitted.
+	AddColumns      []CIStr `json:"-"`
+	DropColumns     []CIStr `json:"-"`
+	ModifyColumns   []CIStr `json:"-"`
+	RelativeColumns []CIStr `json:"-"`
+	PositionColumns []CIStr `json:"-"`
+	AddIndexes      []CIStr `json:"-"`
+	DropIndexes     []CIStr `json:"-"`
+	AlterIndexes    []CIStr `json:"-"`
+}
+
+// NewMultiSchemaInfo new a MultiSchemaInfo.
+func NewMultiSchemaInfo() *MultiSchemaInfo {
+	return &MultiSchemaInfo{
+		SubJobs:    nil,
+		Revertible: true,
+	}
+}
+
+// SubJob is a representation of one DDL schema change. A Job may contain zero
+// (when multi-schema change is not applicable) or more SubJobs.
+type SubJob struct {
+	Type        ActionType      `json:"type"`
+	Args        []interface{}   `json:"-"`
+	RawArgs     json.RawMessage `json:"raw_args"`
+	SchemaState SchemaState     `json:"schema_state"`
+	SnapshotVer uint64          `json:"snapshot_ver"`
+	Revertible  bool            `json:"revertible"`
+	State       JobState        `json:"state"`
+	RowCount    int64           `json:"row_count"`
+	CtxVars     []interface{}   `json:"-"`
+}
+
+// IsFinished returns whether the sub-job is finished or not.
+func (sub *SubJob) IsFinished() bool {
+	return sub.State == JobStateDone ||
+		sub.State == JobStateRollbackDone ||
+		sub.State == JobStateCancelled
+}
+
+// ToProxyJob converts a sub-job to a proxy job, so that the sub-job can be
+// handled like a normal job.
+func (sub *SubJob) ToProxyJob(parentJob *Job) *Job {
+	return &Job{
+		ID:              parentJob.ID,
+		Type:            sub.Type,
+		SchemaID:        parentJob.SchemaID,
+		TableID:         parentJob.TableID,
+		SchemaName:      parentJob.SchemaName,
+		State:           sub.State,
+		Error:           nil,
+		ErrorCount:      0,
+		RowCount:        sub.RowCount,
+		CtxVars:         sub.CtxVars,
+		Args:            sub.Args,
+		RawArgs:         sub.RawArgs,
+		SchemaState:     sub.SchemaState,
+		SnapshotVer:     sub.SnapshotVer,
+		RealStartTS:     parentJob.RealStartTS,
+		StartTS:         parentJob.StartTS,
+		DependencyID:    parentJob.DependencyID,
+		Query:           parentJob.Query,
+		BinlogInfo:      parentJob.BinlogInfo,
+		Version:         parentJob.Version,
+		ReorgMeta:       parentJob.ReorgMeta,
+		MultiSchemaInfo: &MultiSchemaInfo{Revertible: sub.Revertible},
+		Priority:        parentJob.Priority,
+	}
+}
+
+// FromProxyJob saves the state of the proxy job back to the sub-job.
+func (sub *SubJob) FromProxyJob(proxyJob *Job) {
+	sub.Revertible = proxyJob.MultiSchemaInfo.Revertible
+	sub.SchemaState = proxyJob.SchemaState
+	sub.SnapshotVer = proxyJob.SnapshotVer
+	sub.Args = proxyJob.Args
+	sub.State = proxyJob.State
+	sub.RowCount = proxyJob.RowCount
+	sub.CtxVars = proxyJob.CtxVars
 }
 
 // Job is for a DDL operation.
@@ -266,6 +369,14 @@ type Job struct {
 	Priority int `json:"priority"`
 }
 
+// MarkNonRevertible marks the current job as non-revertible.
+// It means the job cannot be cancelled or rollbacked.
+func (job *Job) MarkNonRevertible() {
+	if job.MultiSchemaInfo != nil {
+		job.MultiSchemaInfo.Revertible = false
+	}
+}
+
 // FinishTableJob is called when a job is finished.
 // It updates the job's state information and adds tblInfo to the binlog.
 func (job *Job) FinishTableJob(jobState JobState, schemaState SchemaState, ver int64, tblInfo *TableInfo) {
@@ -324,6 +435,18 @@ func (job *Job) Encode(updateRawArgs bool) ([]byte, error) {
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
+		if job.MultiSchemaInfo != nil {
+			for _, sub := range job.MultiSchemaInfo.SubJobs {
+				// Only update the args of executing sub-jobs.
+				if sub.Args == nil {
+					continue
+				}
+				sub.RawArgs, err = json.Marshal(sub.Args)
+				if err != nil {
+					return nil, errors.Trace(err)
+				}
+			}
+		}
 	}
 
 	var b []byte
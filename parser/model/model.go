@@ -276,7 +276,9 @@ type TableInfo struct {
 	Indices     []*IndexInfo      `json:"index_info"`
 	Constraints []*ConstraintInfo `json:"constraint_info"`
 	ForeignKeys []*FKInfo         `json:"fk_info"`
-	State       SchemaState       `json:"state"`
+	// Triggers are listed in the order in which they are activated.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+	State    SchemaState    `json:"state"`
 	// PKIsHandle is true when primary key is a single integer column.
 	PKIsHandle bool `json:"pk_is_handle"`
 	// IsCommonHandle is true when clustered index feature is
@@ -534,6 +536,20 @@ func (t *TableInfo) Clone() *TableInfo {
 		nt.ForeignKeys[i] = t.ForeignKeys[i].Clone()
 	}
 
+	if t.Constraints != nil {
+		nt.Constraints = make([]*ConstraintInfo, len(t.Constraints))
+		for i := range t.Constraints {
+			nt.Constraints[i] = t.Constraints[i].Clone()
+		}
+	}
+
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+
 	return &nt
 }
 
@@ -719,6 +735,9 @@ type ViewCheckOption int
 const (
 	CheckOptionLocal ViewCheckOption = iota
 	CheckOptionCascaded
+	// CheckOptionNone means the view is created without WITH CHECK OPTION. The views created
+	// before it is introduced always record CheckOptionCascaded.
+	CheckOptionNone
 )
 
 func (v *ViewCheckOption) String() string {
@@ -727,6 +746,8 @@ func (v *ViewCheckOption) String() string {
 		return "LOCAL"
 	case CheckOptionCascaded:
 		return "CASCADED"
+	case CheckOptionNone:
+		return "NONE"
 	default:
 		return "CASCADED"
 	}
@@ -768,6 +789,158 @@ type SequenceInfo struct {
 	Comment    string `json:"sequence_comment"`
 }
 
+// RoutineType is the type of a stored routine.
+type RoutineType byte
+
+const (
+	RoutineTypeProcedure RoutineType = iota + 1
+	RoutineTypeFunction
+)
+
+func (t RoutineType) String() string {
+	switch t {
+	case RoutineTypeFunction:
+		return "FUNCTION"
+	default:
+		return "PROCEDURE"
+	}
+}
+
+// RoutineDataAccess is the SQL data access characteristic of a stored routine.
+// See https://dev.mysql.com/doc/refman/5.7/en/create-procedure.html
+type RoutineDataAccess byte
+
+const (
+	DataAccessContainsSQL RoutineDataAccess = iota
+	DataAccessNoSQL
+	DataAccessReadsSQLData
+	DataAccessModifiesSQLData
+)
+
+func (a RoutineDataAccess) String() string {
+	switch a {
+	case DataAccessNoSQL:
+		return "NO SQL"
+	case DataAccessReadsSQLData:
+		return "READS SQL DATA"
+	case DataAccessModifiesSQLData:
+		return "MODIFIES SQL DATA"
+	default:
+		return "CONTAINS SQL"
+	}
+}
+
+// RoutineParamMode is the mode of a stored procedure parameter.
+type RoutineParamMode byte
+
+const (
+	ParamModeIn RoutineParamMode = iota
+	ParamModeOut
+	ParamModeInOut
+)
+
+func (m RoutineParamMode) String() string {
+	switch m {
+	case ParamModeOut:
+		return "OUT"
+	case ParamModeInOut:
+		return "INOUT"
+	default:
+		return "IN"
+	}
+}
+
+// RoutineParam provides meta data describing a parameter of a stored routine.
+type RoutineParam struct {
+	Name CIStr            `json:"name"`
+	Mode RoutineParamMode `json:"mode"`
+	Tp   *types.FieldType `json:"type"`
+}
+
+// RoutineInfo provides meta data describing a stored procedure or function.
+type RoutineInfo struct {
+	ID      int64            `json:"id"`
+	Name    CIStr            `json:"name"`
+	Type    RoutineType      `json:"type"`
+	Params  []*RoutineParam  `json:"params"`
+	Returns *types.FieldType `json:"returns"`
+	// Body is the text of the routine body.
+	Body string `json:"body"`
+	// Definition is the text of the whole CREATE statement, it is parsed
+	// again every time the routine is invoked.
+	Definition    string             `json:"definition"`
+	Definer       *auth.UserIdentity `json:"definer"`
+	Security      ViewSecurity       `json:"security"`
+	DataAccess    RoutineDataAccess  `json:"data_access"`
+	Deterministic bool               `json:"deterministic"`
+	Comment       string             `json:"comment"`
+	SQLMode       mysql.SQLMode      `json:"sql_mode"`
+	Charset       string             `json:"charset"`
+	Collate       string             `json:"collate"`
+	Created       time.Time          `json:"created"`
+	State         SchemaState        `json:"state"`
+}
+
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming byte
+
+const (
+	TriggerTimingBefore TriggerTiming = iota + 1
+	TriggerTimingAfter
+)
+
+func (t TriggerTiming) String() string {
+	switch t {
+	case TriggerTimingAfter:
+		return "AFTER"
+	default:
+		return "BEFORE"
+	}
+}
+
+// TriggerEvent is the kind of the statement which activates a trigger.
+type TriggerEvent byte
+
+const (
+	TriggerEventInsert TriggerEvent = iota + 1
+	TriggerEventUpdate
+	TriggerEventDelete
+)
+
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerEventUpdate:
+		return "UPDATE"
+	case TriggerEventDelete:
+		return "DELETE"
+	default:
+		return "INSERT"
+	}
+}
+
+// TriggerInfo provides meta data describing a trigger of a table.
+type TriggerInfo struct {
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Body is the text of the trigger body.
+	Body string `json:"body"`
+	// Definition is the text of the whole CREATE statement, it is parsed
+	// again every time the trigger is activated.
+	Definition string             `json:"definition"`
+	Definer    *auth.UserIdentity `json:"definer"`
+	SQLMode    mysql.SQLMode      `json:"sql_mode"`
+	Charset    string             `json:"charset"`
+	Collate    string             `json:"collate"`
+	Created    time.Time          `json:"created"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	return &nt
+}
+
 // PartitionType is the type for PartitionInfo
 type PartitionType int
 
@@ -816,6 +989,68 @@ type PartitionInfo struct {
 	DroppingDefinitions []PartitionDefinition `json:"dropping_definitions"`
 	States              []PartitionState      `json:"states"`
 	Num                 uint64                `json:"num"`
+	// DDLState is the schema state of the running partition reorganization, it is only used
+	// together with DDLAction. The DML path uses it to decide whether the rows should also be
+	// written to, or deleted from, AddingDefinitions/DroppingDefinitions.
+	DDLState SchemaState `json:"ddl_state"`
+	// DDLAction is the action of the running partition reorganization job.
+	DDLAction ActionType `json:"ddl_action"`
+	// DDLType, DDLExpr and DDLColumns are the partitioning scheme of the other side of a running
+	// ActionAlterTablePartitioning or ActionRemovePartitioning job: the new scheme before the
+	// partition definitions are switched, and the old scheme after that.
+	DDLType    PartitionType `json:"ddl_type,omitempty"`
+	DDLExpr    string        `json:"ddl_expr,omitempty"`
+	DDLColumns []CIStr       `json:"ddl_columns,omitempty"`
+	// Sub is the subpartition info, it is nil if the table is not subpartitioned.
+	// When it is not nil, Definitions holds the physical subpartitions ordered by
+	// their parent partition, and the parent of Definitions[i] is Sub.Parents[i/Sub.Num].
+	Sub *SubPartitionInfo `json:"sub,omitempty"`
+}
+
+// SubPartitionInfo provides the subpartition info of a table.
+type SubPartitionInfo struct {
+	Type    PartitionType `json:"type"`
+	Expr    string        `json:"expr"`
+	Columns []CIStr       `json:"columns"`
+	// Num is the number of subpartitions in each partition.
+	Num uint64 `json:"num"`
+	// Parents are the logical partitions, they don't have their own physical IDs.
+	Parents []PartitionDefinition `json:"parents"`
+}
+
+// ParentView returns a PartitionInfo which describes the logical partitions of a
+// subpartitioned table, so that the partition expression of the first level can be
+// built and evaluated on its own. It returns pi itself when the table is not subpartitioned.
+func (pi *PartitionInfo) ParentView() *PartitionInfo {
+	if pi.Sub == nil {
+		return pi
+	}
+	return &PartitionInfo{
+		Type:        pi.Type,
+		Expr:        pi.Expr,
+		Columns:     pi.Columns,
+		Enable:      pi.Enable,
+		Definitions: pi.Sub.Parents,
+		Num:         uint64(len(pi.Sub.Parents)),
+	}
+}
+
+// SubView returns a PartitionInfo which describes the subpartitions of a single
+// logical partition. Its definitions are the subpartitions of the first logical partition,
+// the subpartitions of the other logical partitions are laid out in the same way.
+// It returns nil when the table is not subpartitioned.
+func (pi *PartitionInfo) SubView() *PartitionInfo {
+	if pi.Sub == nil {
+		return nil
+	}
+	return &PartitionInfo{
+		Type:        pi.Sub.Type,
+		Expr:        pi.Sub.Expr,
+		Columns:     pi.Sub.Columns,
+		Enable:      pi.Enable,
+		Definitions: pi.Definitions[:pi.Sub.Num],
+		Num:         pi.Sub.Num,
+	}
 }
 
 // GetNameByID gets the partition name by ID.
@@ -970,6 +1205,10 @@ func (t IndexType) String() string {
 		return "HASH"
 	case IndexTypeRtree:
 		return "RTREE"
+	case IndexTypeFulltext:
+		return "FULLTEXT"
+	case IndexTypeSpatial:
+		return "SPATIAL"
 	default:
 		return ""
 	}
@@ -981,6 +1220,8 @@ const (
 	IndexTypeBtree
 	IndexTypeHash
 	IndexTypeRtree
+	IndexTypeFulltext
+	IndexTypeSpatial
 )
 
 // IndexInfo provides meta data describing a DB index.
@@ -993,11 +1234,16 @@ type IndexInfo struct {
 	Columns   []*IndexColumn `json:"idx_cols"` // Index columns.
 	State     SchemaState    `json:"state"`
 	Comment   string         `json:"comment"`      // Comment
-	Tp        IndexType      `json:"index_type"`   // Index type: Btree, Hash or Rtree
+	Tp        IndexType      `json:"index_type"`   // Index type: Btree, Hash, Rtree, Fulltext or Spatial
 	Unique    bool           `json:"is_unique"`    // Whether the index is unique.
 	Primary   bool           `json:"is_primary"`   // Whether the index is primary key.
 	Invisible bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global    bool           `json:"is_global"`    // Whether the index is global.
+	// ParserName is the full-text parser of the FULLTEXT index, empty for the built-in parser.
+	ParserName string `json:"parser_name,omitempty"`
+	// MVIndex indicates the index is a multi-valued index, which has an index entry for every element of
+	// the JSON array of the `CAST(... AS ... ARRAY)` column.
+	MVIndex bool `json:"mv_index,omitempty"`
 }
 
 // Clone clones IndexInfo.
@@ -1010,6 +1256,16 @@ func (index *IndexInfo) Clone() *IndexInfo {
 	return &ni
 }
 
+// IsFulltext returns whether the index is a FULLTEXT index.
+func (index *IndexInfo) IsFulltext() bool {
+	return index.Tp == IndexTypeFulltext
+}
+
+// IsSpatial returns whether the index is a SPATIAL index.
+func (index *IndexInfo) IsSpatial() bool {
+	return index.Tp == IndexTypeSpatial
+}
+
 // HasPrefixIndex returns whether any columns of this index uses prefix length.
 func (index *IndexInfo) HasPrefixIndex() bool {
 	for _, ic := range index.Columns {
@@ -1083,6 +1339,7 @@ type DBInfo struct {
 	Charset             string             `json:"charset"`
 	Collate             string             `json:"collate"`
 	Tables              []*TableInfo       `json:"-"` // Tables in the DB.
+	Routines            []*RoutineInfo     `json:"-"` // Stored routines in the DB.
 	State               SchemaState        `json:"state"`
 	PlacementPolicyRef  *PolicyRefInfo     `json:"policy_ref_info"`
 	DirectPlacementOpts *PlacementSettings `json:"placement_settings"`
@@ -1103,6 +1360,8 @@ func (db *DBInfo) Copy() *DBInfo {
 	newInfo := *db
 	newInfo.Tables = make([]*TableInfo, len(db.Tables))
 	copy(newInfo.Tables, db.Tables)
+	newInfo.Routines = make([]*RoutineInfo, len(db.Routines))
+	copy(newInfo.Routines, db.Routines)
 	return &newInfo
 }
 
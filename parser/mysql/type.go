@@ -47,6 +47,18 @@ const (
 	TypeGeometry   byte = 0xff
 )
 
+// GeometryType is the subtype of the spatial data type, which is the same as the WKB geometry type.
+const (
+	GeometryTypeGeometry           byte = 0
+	GeometryTypePoint              byte = 1
+	GeometryTypeLineString         byte = 2
+	GeometryTypePolygon            byte = 3
+	GeometryTypeMultiPoint         byte = 4
+	GeometryTypeMultiLineString    byte = 5
+	GeometryTypeMultiPolygon       byte = 6
+	GeometryTypeGeometryCollection byte = 7
+)
+
 // Flag information.
 const (
 	NotNullFlag        uint = 1 << 0  /* Field can't be NULL */
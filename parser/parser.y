@@ -26,6 +26,7 @@
 package parser
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/pingcap/tidb/parser/mysql"
@@ -52,6 +53,7 @@ import (
 	/*yy:token "%c"     */
 	identifier "identifier"
 	asof       "AS OF"
+	memberof   "MEMBER OF"
 
 	/*yy:token "_%c"    */
 	underscoreCS "UNDERSCORE_CHARSET"
@@ -78,6 +80,7 @@ import (
 	and               "AND"
 	as                "AS"
 	asc               "ASC"
+	before            "BEFORE"
 	between           "BETWEEN"
 	bigIntType        "BIGINT"
 	binaryType        "BINARY"
@@ -94,6 +97,7 @@ import (
 	collate           "COLLATE"
 	column            "COLUMN"
 	constraint        "CONSTRAINT"
+	continueKwd       "CONTINUE"
 	convert           "CONVERT"
 	create            "CREATE"
 	cross             "CROSS"
@@ -103,6 +107,7 @@ import (
 	currentTs         "CURRENT_TIMESTAMP"
 	currentUser       "CURRENT_USER"
 	currentRole       "CURRENT_ROLE"
+	cursor            "CURSOR"
 	database          "DATABASE"
 	databases         "DATABASES"
 	dayHour           "DAY_HOUR"
@@ -110,22 +115,27 @@ import (
 	dayMinute         "DAY_MINUTE"
 	daySecond         "DAY_SECOND"
 	decimalType       "DECIMAL"
+	declare           "DECLARE"
 	defaultKwd        "DEFAULT"
 	delayed           "DELAYED"
 	deleteKwd         "DELETE"
 	denseRank         "DENSE_RANK"
 	desc              "DESC"
 	describe          "DESCRIBE"
+	deterministic     "DETERMINISTIC"
 	distinct          "DISTINCT"
 	distinctRow       "DISTINCTROW"
 	div               "DIV"
 	doubleType        "DOUBLE"
 	drop              "DROP"
 	dual              "DUAL"
+	each              "EACH"
 	elseKwd           "ELSE"
+	elseIfKwd         "ELSEIF"
 	enclosed          "ENCLOSED"
 	escaped           "ESCAPED"
 	exists            "EXISTS"
+	exit              "EXIT"
 	explain           "EXPLAIN"
 	except            "EXCEPT"
 	falseKwd          "FALSE"
@@ -152,10 +162,16 @@ import (
 	index             "INDEX"
 	infile            "INFILE"
 	inner             "INNER"
+	inout             "INOUT"
 	integerType       "INTEGER"
 	intersect         "INTERSECT"
 	interval          "INTERVAL"
 	into              "INTO"
+	iterate           "ITERATE"
+	leave             "LEAVE"
+	loop              "LOOP"
+	modifies          "MODIFIES"
+	out               "OUT"
 	outfile           "OUTFILE"
 	is                "IS"
 	insert            "INSERT"
@@ -166,6 +182,7 @@ import (
 	int4Type          "INT4"
 	int8Type          "INT8"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
@@ -216,6 +233,7 @@ import (
 	rangeKwd          "RANGE"
 	rank              "RANK"
 	read              "READ"
+	reads             "READS"
 	realType          "REAL"
 	recursive         "RECURSIVE"
 	references        "REFERENCES"
@@ -226,6 +244,7 @@ import (
 	replace           "REPLACE"
 	require           "REQUIRE"
 	restrict          "RESTRICT"
+	returnKwd         "RETURN"
 	revoke            "REVOKE"
 	right             "RIGHT"
 	rlike             "RLIKE"
@@ -236,9 +255,13 @@ import (
 	selectKwd         "SELECT"
 	set               "SET"
 	show              "SHOW"
+	signal            "SIGNAL"
 	smallIntType      "SMALLINT"
 	spatial           "SPATIAL"
 	sql               "SQL"
+	sqlexception      "SQLEXCEPTION"
+	sqlstate          "SQLSTATE"
+	sqlwarning        "SQLWARNING"
 	sqlBigResult      "SQL_BIG_RESULT"
 	sqlCalcFoundRows  "SQL_CALC_FOUND_ROWS"
 	sqlSmallResult    "SQL_SMALL_RESULT"
@@ -262,6 +285,7 @@ import (
 	union             "UNION"
 	unlock            "UNLOCK"
 	unsigned          "UNSIGNED"
+	until             "UNTIL"
 	update            "UPDATE"
 	usage             "USAGE"
 	use               "USE"
@@ -278,6 +302,7 @@ import (
 	virtual           "VIRTUAL"
 	when              "WHEN"
 	where             "WHERE"
+	while             "WHILE"
 	write             "WRITE"
 	window            "WINDOW"
 	with              "WITH"
@@ -296,8 +321,18 @@ import (
 	algorithm             "ALGORITHM"
 	always                "ALWAYS"
 	any                   "ANY"
+	array                 "ARRAY"
 	ascii                 "ASCII"
 	attributes            "ATTRIBUTES"
+	closeKwd              "CLOSE"
+	contains              "CONTAINS"
+	follows               "FOLLOWS"
+	found                 "FOUND"
+	handler               "HANDLER"
+	messageText           "MESSAGE_TEXT"
+	mysqlErrno            "MYSQL_ERRNO"
+	precedes              "PRECEDES"
+	returns               "RETURNS"
 	statsOptions          "STATS_OPTIONS"
 	statsSampleRate       "STATS_SAMPLE_RATE"
 	statsColChoice        "STATS_COL_CHOICE"
@@ -375,6 +410,7 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	encryption            "ENCRYPTION"
 	end                   "END"
@@ -404,6 +440,8 @@ import (
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geometryCollection    "GEOMETRYCOLLECTION"
+	geometryType          "GEOMETRY"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	hash                  "HASH"
@@ -436,6 +474,7 @@ import (
 	lastval               "LASTVAL"
 	less                  "LESS"
 	level                 "LEVEL"
+	lineString            "LINESTRING"
 	list                  "LIST"
 	local                 "LOCAL"
 	locked                "LOCKED"
@@ -450,6 +489,7 @@ import (
 	maxUpdatesPerHour     "MAX_UPDATES_PER_HOUR"
 	maxUserConnections    "MAX_USER_CONNECTIONS"
 	mb                    "MB"
+	member                "MEMBER"
 	memory                "MEMORY"
 	merge                 "MERGE"
 	microsecond           "MICROSECOND"
@@ -459,11 +499,15 @@ import (
 	mode                  "MODE"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineString       "MULTILINESTRING"
+	multiPoint            "MULTIPOINT"
+	multiPolygon          "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
 	never                 "NEVER"
 	next                  "NEXT"
+	nested                "NESTED"
 	nextval               "NEXTVAL"
 	no                    "NO"
 	nocache               "NOCACHE"
@@ -483,6 +527,7 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
@@ -490,12 +535,15 @@ import (
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
+	pathKwd               "PATH"
 	percent               "PERCENT"
 	per_db                "PER_DB"
 	per_table             "PER_TABLE"
 	pipesAsOr
 	plugins               "PLUGINS"
+	point                 "POINT"
 	policy                "POLICY"
+	polygon               "POLYGON"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
@@ -529,6 +577,7 @@ import (
 	restore               "RESTORE"
 	restores              "RESTORES"
 	resume                "RESUME"
+	returning             "RETURNING"
 	reverse               "REVERSE"
 	role                  "ROLE"
 	rollback              "ROLLBACK"
@@ -759,6 +808,7 @@ import (
 	builtinDateSub
 	builtinExtract
 	builtinGroupConcat
+	builtinJSONValue
 	builtinMax
 	builtinMin
 	builtinNow
@@ -807,6 +857,7 @@ import (
 
 %token not2
 %type	<expr>
+	SelectIntoVar          "SELECT ... INTO variable"
 	Expression             "expression"
 	MaxValueOrExpression   "maxvalue or expression"
 	BoolPri                "boolean primary expression"
@@ -838,6 +889,21 @@ import (
 	ProcedureCall          "Procedure call with Identifier or identifier"
 
 %type	<statement>
+	CreateRoutineStmt          "CREATE PROCEDURE/FUNCTION statement"
+	DropRoutineStmt            "DROP PROCEDURE/FUNCTION statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	ProcedureProcStmt          "Statement in a stored program"
+	ProcedureUnlabeledBlock    "BEGIN ... END block"
+	ProcedureLabeledStmt       "Labeled block or loop"
+	ProcedureIfStmt            "IF statement in a stored program"
+	ProcedureUnlabeledLoop     "LOOP, WHILE or REPEAT statement"
+	ProcedureJumpStmt          "LEAVE or ITERATE statement"
+	ProcedureCursorStmt        "OPEN, FETCH or CLOSE cursor statement"
+	ProcedureReturnStmt        "RETURN statement"
+	ProcedureDecl              "DECLARE statement"
+	ProcedureCursorQuery       "Query of a cursor"
+	SignalStmt                 "SIGNAL statement"
 	AdminStmt                  "Check table statement or show ddl statement"
 	AlterDatabaseStmt          "Alter database statement"
 	AlterTableStmt             "Alter table statement"
@@ -934,6 +1000,33 @@ import (
 	HelpStmt                   "HELP statement"
 
 %type	<item>
+	RoutineType                            "{PROCEDURE|FUNCTION}"
+	TriggerTiming                          "{BEFORE|AFTER}"
+	TriggerEvent                           "{INSERT|UPDATE|DELETE}"
+	TriggerOrderOpt                        "Optional {FOLLOWS|PRECEDES} clause"
+	RoutineParamListOpt                    "Optional stored routine parameter list"
+	RoutineParamList                       "Stored routine parameter list"
+	RoutineParam                           "Stored routine parameter"
+	RoutineParamMode                       "{IN|OUT|INOUT}"
+	RoutineReturnsOpt                      "Optional RETURNS clause"
+	RoutineCharacteristicListOpt           "Optional stored routine characteristics"
+	ProcedureStmtList                      "Statements in a stored program"
+	ProcedureStmtListOpt                   "Optional statements in a stored program"
+	ProcedureDeclListOpt                   "Optional declarations in a block"
+	ProcedureDeclList                      "Declarations in a block"
+	ProcedureVarList                       "Local variable list"
+	ProcedureDeclDefaultOpt                "Optional DEFAULT of a local variable"
+	ProcedureHandlerAction                 "{CONTINUE|EXIT}"
+	ProcedureHandlerConditionList          "Handler condition list"
+	ProcedureHandlerCondition              "Handler condition"
+	ValueSymOpt                            "Optional VALUE keyword"
+	ProcedureIfBlockList                   "IF and ELSEIF branches"
+	ProcedureElseOpt                       "Optional ELSE branch"
+	ProcedureFetchFrom                     "[NEXT] FROM of FETCH"
+	SignalSetOpt                           "Optional SET clause of SIGNAL"
+	SignalItemList                         "Signal information item list"
+	SignalItem                             "Signal information item"
+	SelectIntoVarList                      "SELECT ... INTO variable list"
 	AdminShowSlow                          "Admin Show Slow statement"
 	AllOrPartitionNameList                 "All or partition name list"
 	AlgorithmClause                        "Alter table algorithm"
@@ -947,6 +1040,7 @@ import (
 	AnalyzeOptionList                      "Analyze option list"
 	AnalyzeOptionListOpt                   "Optional analyze option list"
 	AnyOrAll                               "Any or All for subquery"
+	ArrayKwdOpt                            "Array options"
 	Assignment                             "assignment"
 	AssignmentList                         "assignment list"
 	AssignmentListOpt                      "assignment list opt"
@@ -1051,6 +1145,14 @@ import (
 	IndexPartSpecificationListOpt          "Optional list of index column name or expression"
 	InsertValues                           "Rest part of INSERT/REPLACE INTO statement"
 	JoinTable                              "join table"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnOnClauseOpt             "Optional ON EMPTY and ON ERROR clauses of JSON_TABLE column"
+	JSONTableOnResponse                    "Behavior of JSON_TABLE column on empty or error"
+	JSONValueReturningOpt                  "Optional RETURNING clause of JSON_VALUE"
+	JSONValueOnClauseOpt                   "Optional ON EMPTY and ON ERROR clauses of JSON_VALUE"
+	JSONValueOnResponse                    "Behavior of JSON_VALUE on empty or error"
+	JSONTablePathKwdOpt                    "Optional PATH keyword of JSON_TABLE nested path"
 	JoinType                               "join type"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
@@ -1141,6 +1243,7 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoClause                       "SELECT statement non-empty into clause"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1271,6 +1374,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	GeometryType                           "Geometry types"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1314,6 +1419,7 @@ import (
 	StatsOptionsOpt                        "Stats options"
 
 %type	<ident>
+	EndLabelOpt       "Optional end label"
 	AsOpt             "AS or EmptyString"
 	KeyOrIndex        "{KEY|INDEX}"
 	ColumnKeywordOpt  "Column keyword or empty"
@@ -1394,6 +1500,8 @@ import (
 %precedence empty
 %precedence as
 %precedence placement
+%precedence lowerThanInto
+%precedence into
 %precedence lowerThanSelectOpt
 %precedence sqlBufferResult
 %precedence sqlBigResult
@@ -3158,6 +3266,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -4185,7 +4306,7 @@ CreateViewStmt:
 			endOffset := parser.startOffset(&yyS[yypt])
 			selStmt.SetText(strings.TrimSpace(parser.src[startOffset:endOffset]))
 		} else {
-			x.CheckOption = model.CheckOptionCascaded
+			x.CheckOption = model.CheckOptionNone
 		}
 		$$ = x
 	}
@@ -4270,6 +4391,10 @@ ViewCheckOption:
 	{
 		$$ = nil
 	}
+|	"WITH" "CHECK" "OPTION"
+	{
+		$$ = model.CheckOptionCascaded
+	}
 |	"WITH" "CASCADED" "CHECK" "OPTION"
 	{
 		$$ = model.CheckOptionCascaded
@@ -5397,6 +5522,10 @@ PredicateExpr:
 	{
 		$$ = &ast.PatternRegexpExpr{Expr: $1, Pattern: $3, Not: !$2.(bool)}
 	}
+|	BitExpr memberof '(' SimpleExpr ')'
+	{
+		$$ = &ast.FuncCallExpr{FnName: model.NewCIStr(ast.JSONMemberOf), Args: []ast.ExprNode{$1, $4}}
+	}
 |	BitExpr
 
 RegexpSym:
@@ -5810,6 +5939,13 @@ UnReservedKeyword:
 |	"WEEK"
 |	"WEIGHT_STRING"
 |	"ANY"
+|	"ARRAY"
+|	"MEMBER"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
+|	"RETURNING"
 |	"SOME"
 |	"USER"
 |	"IDENTIFIED"
@@ -5832,6 +5968,14 @@ UnReservedKeyword:
 |	"DELAY_KEY_WRITE"
 |	"ISOLATION"
 |	"JSON"
+|	"GEOMETRY"
+|	"POINT"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
 |	"REPEATABLE"
 |	"RESPECT"
 |	"COMMITTED"
@@ -6030,6 +6174,15 @@ UnReservedKeyword:
 |	"CLUSTERED"
 |	"NONCLUSTERED"
 |	"PRESERVE"
+|	"CLOSE"
+|	"CONTAINS"
+|	"FOLLOWS"
+|	"FOUND"
+|	"HANDLER"
+|	"MESSAGE_TEXT"
+|	"MYSQL_ERRNO"
+|	"PRECEDES"
+|	"RETURNS"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6154,6 +6307,635 @@ NotKeywordToken:
 |	"LEARNER_CONSTRAINTS"
 |	"VOTER_CONSTRAINTS"
 
+/*******************************************************************
+ *
+ *  Create Procedure / Function Statement
+ *
+ *  Example:
+ *      CREATE DEFINER = root@localhost PROCEDURE p(IN a INT, OUT b INT) COMMENT 'c'
+ *          BEGIN DECLARE x INT DEFAULT 0; SELECT COUNT(*) FROM t WHERE c = a INTO b; END
+ *      CREATE FUNCTION f(a INT) RETURNS INT DETERMINISTIC RETURN a + 1
+ *******************************************************************/
+CreateRoutineStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity RoutineType IfNotExists TableName '(' RoutineParamListOpt ')' RoutineReturnsOpt RoutineCharacteristicListOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(yylex.Errorf("OR REPLACE, ALGORITHM and SQL SECURITY must not precede %s", $6.(model.RoutineType)))
+			return 1
+		}
+		x := $13.(*ast.CreateRoutineStmt)
+		x.Tp = $6.(model.RoutineType)
+		x.IfNotExists = $7.(bool)
+		x.Definer = $4.(*auth.UserIdentity)
+		x.Name = $8.(*ast.TableName)
+		x.Params = $10.([]*ast.RoutineParam)
+		x.Body = $14.(ast.StmtNode)
+		x.Body.SetText(strings.TrimSuffix(strings.TrimSpace(parser.src[parser.startOffset(&yyS[yypt]):]), ";"))
+		if $12 != nil {
+			x.Returns = $12.(*types.FieldType)
+		}
+		if (x.Tp == model.RoutineTypeFunction) != (x.Returns != nil) {
+			yylex.AppendError(yylex.Errorf("RETURNS is required for FUNCTION and not allowed for PROCEDURE"))
+			return 1
+		}
+		if x.Tp == model.RoutineTypeFunction {
+			for _, param := range x.Params {
+				if param.Mode != model.ParamModeIn {
+					yylex.AppendError(yylex.Errorf("OUT and INOUT parameters are not allowed for FUNCTION"))
+					return 1
+				}
+			}
+		}
+		$$ = x
+	}
+
+RoutineType:
+	"PROCEDURE"
+	{
+		$$ = model.RoutineTypeProcedure
+	}
+|	"FUNCTION"
+	{
+		$$ = model.RoutineTypeFunction
+	}
+
+RoutineParamListOpt:
+	/* EMPTY */
+	{
+		$$ = []*ast.RoutineParam{}
+	}
+|	RoutineParamList
+
+RoutineParamList:
+	RoutineParam
+	{
+		$$ = []*ast.RoutineParam{$1.(*ast.RoutineParam)}
+	}
+|	RoutineParamList ',' RoutineParam
+	{
+		$$ = append($1.([]*ast.RoutineParam), $3.(*ast.RoutineParam))
+	}
+
+RoutineParam:
+	RoutineParamMode Identifier Type
+	{
+		$$ = &ast.RoutineParam{
+			Mode: $1.(model.RoutineParamMode),
+			Name: $2,
+			Tp:   $3.(*types.FieldType),
+		}
+	}
+
+RoutineParamMode:
+	/* EMPTY */
+	{
+		$$ = model.ParamModeIn
+	}
+|	"IN"
+	{
+		$$ = model.ParamModeIn
+	}
+|	"OUT"
+	{
+		$$ = model.ParamModeOut
+	}
+|	"INOUT"
+	{
+		$$ = model.ParamModeInOut
+	}
+
+RoutineReturnsOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"RETURNS" Type
+	{
+		$$ = $2
+	}
+
+RoutineCharacteristicListOpt:
+	/* EMPTY */
+	{
+		$$ = &ast.CreateRoutineStmt{}
+	}
+|	RoutineCharacteristicListOpt "COMMENT" stringLit
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.Comment = $3
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "LANGUAGE" "SQL"
+	{
+		$$ = $1
+	}
+|	RoutineCharacteristicListOpt "DETERMINISTIC"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.Deterministic = true
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "NOT" "DETERMINISTIC"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.Deterministic = false
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "CONTAINS" "SQL"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.DataAccess = model.DataAccessContainsSQL
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "NO" "SQL"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.DataAccess = model.DataAccessNoSQL
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "READS" "SQL" "DATA"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.DataAccess = model.DataAccessReadsSQLData
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "MODIFIES" "SQL" "DATA"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.DataAccess = model.DataAccessModifiesSQLData
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "SQL" "SECURITY" "DEFINER"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.Security = model.SecurityDefiner
+		$$ = x
+	}
+|	RoutineCharacteristicListOpt "SQL" "SECURITY" "INVOKER"
+	{
+		x := $1.(*ast.CreateRoutineStmt)
+		x.Security = model.SecurityInvoker
+		$$ = x
+	}
+
+/*******************************************************************
+ *
+ *  Drop Procedure / Function Statement
+ *
+ *******************************************************************/
+DropRoutineStmt:
+	"DROP" RoutineType IfExists TableName
+	{
+		$$ = &ast.DropRoutineStmt{
+			Tp:       $2.(model.RoutineType),
+			IfExists: $3.(bool),
+			Name:     $4.(*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *      CREATE DEFINER = root@localhost TRIGGER tr BEFORE INSERT ON t FOR EACH ROW
+ *          SET NEW.c = NEW.c + 1
+ *******************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" TriggerOrderOpt ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(yylex.Errorf("OR REPLACE, ALGORITHM and SQL SECURITY must not precede TRIGGER"))
+			return 1
+		}
+		x := $16.(*ast.CreateTriggerStmt)
+		x.IfNotExists = $7.(bool)
+		x.Definer = $4.(*auth.UserIdentity)
+		x.Name = $8.(*ast.TableName)
+		x.Timing = $9.(model.TriggerTiming)
+		x.Event = $10.(model.TriggerEvent)
+		x.Table = $12.(*ast.TableName)
+		x.Body = $17.(ast.StmtNode)
+		x.Body.SetText(strings.TrimSuffix(strings.TrimSpace(parser.src[parser.startOffset(&yyS[yypt]):]), ";"))
+		$$ = x
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerTimingBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerTimingAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerEventInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerEventUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerEventDelete
+	}
+
+TriggerOrderOpt:
+	/* EMPTY */
+	{
+		$$ = &ast.CreateTriggerStmt{}
+	}
+|	"FOLLOWS" Identifier
+	{
+		$$ = &ast.CreateTriggerStmt{Order: ast.TriggerOrderFollows, OrderTrigger: $2}
+	}
+|	"PRECEDES" Identifier
+	{
+		$$ = &ast.CreateTriggerStmt{Order: ast.TriggerOrderPrecedes, OrderTrigger: $2}
+	}
+
+/*******************************************************************
+ *
+ *  Drop Trigger Statement
+ *
+ *******************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists: $3.(bool),
+			Name:     $4.(*ast.TableName),
+		}
+	}
+
+/*******************************************************************
+ *
+ *  Stored program statements
+ *
+ *******************************************************************/
+ProcedureProcStmt:
+	ProcedureUnlabeledBlock
+|	ProcedureLabeledStmt
+|	ProcedureIfStmt
+|	ProcedureUnlabeledLoop
+|	ProcedureJumpStmt
+|	ProcedureCursorStmt
+|	ProcedureReturnStmt
+|	SignalStmt
+|	SelectStmt
+|	SelectStmtWithClause
+|	InsertIntoStmt
+|	ReplaceIntoStmt
+|	UpdateStmt
+|	DeleteFromStmt
+|	SetStmt
+|	CallStmt
+|	DoStmt
+|	CreateTableStmt
+|	DropTableStmt
+|	TruncateTableStmt
+
+ProcedureStmtList:
+	ProcedureProcStmt ';'
+	{
+		$$ = []ast.StmtNode{$1}
+	}
+|	ProcedureStmtList ProcedureProcStmt ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+ProcedureStmtListOpt:
+	/* EMPTY */
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	ProcedureStmtList
+
+ProcedureUnlabeledBlock:
+	"BEGIN" ProcedureDeclListOpt ProcedureStmtListOpt "END"
+	{
+		$$ = &ast.ProcedureBlock{
+			Decls: $2.([]ast.StmtNode),
+			Stmts: $3.([]ast.StmtNode),
+		}
+	}
+
+ProcedureLabeledStmt:
+	identifier ':' ProcedureUnlabeledBlock EndLabelOpt
+	{
+		if $4 != "" && !strings.EqualFold($1, $4) {
+			yylex.AppendError(yylex.Errorf("End-label %s without match", $4))
+			return 1
+		}
+		x := $3.(*ast.ProcedureBlock)
+		x.Label = $1
+		$$ = x
+	}
+|	identifier ':' ProcedureUnlabeledLoop EndLabelOpt
+	{
+		if $4 != "" && !strings.EqualFold($1, $4) {
+			yylex.AppendError(yylex.Errorf("End-label %s without match", $4))
+			return 1
+		}
+		x := $3.(*ast.ProcedureLoopStmt)
+		x.Label = $1
+		$$ = x
+	}
+
+EndLabelOpt:
+	/* EMPTY */
+	{
+		$$ = ""
+	}
+|	identifier
+
+ProcedureDeclListOpt:
+	/* EMPTY */
+	{
+		$$ = []ast.StmtNode{}
+	}
+|	ProcedureDeclList
+
+ProcedureDeclList:
+	ProcedureDecl ';'
+	{
+		$$ = []ast.StmtNode{$1}
+	}
+|	ProcedureDeclList ProcedureDecl ';'
+	{
+		$$ = append($1.([]ast.StmtNode), $2)
+	}
+
+ProcedureDecl:
+	"DECLARE" ProcedureVarList Type ProcedureDeclDefaultOpt
+	{
+		x := &ast.ProcedureDecl{
+			Names: $2.([]string),
+			Tp:    $3.(*types.FieldType),
+		}
+		if $4 != nil {
+			x.Default = $4.(ast.ExprNode)
+		}
+		$$ = x
+	}
+|	"DECLARE" Identifier "CURSOR" "FOR" ProcedureCursorQuery
+	{
+		$$ = &ast.ProcedureCursorDecl{
+			Name:  $2,
+			Query: $5,
+		}
+	}
+|	"DECLARE" ProcedureHandlerAction "HANDLER" "FOR" ProcedureHandlerConditionList ProcedureProcStmt
+	{
+		$$ = &ast.ProcedureHandlerDecl{
+			Action:     $2.(ast.HandlerAction),
+			Conditions: $5.([]*ast.HandlerCondition),
+			Stmt:       $6,
+		}
+	}
+
+ProcedureCursorQuery:
+	SelectStmt
+|	SelectStmtWithClause
+|	SetOprStmt
+
+ProcedureVarList:
+	Identifier
+	{
+		$$ = []string{$1}
+	}
+|	ProcedureVarList ',' Identifier
+	{
+		$$ = append($1.([]string), $3)
+	}
+
+ProcedureDeclDefaultOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"DEFAULT" Expression
+	{
+		$$ = $2
+	}
+
+ProcedureHandlerAction:
+	"CONTINUE"
+	{
+		$$ = ast.HandlerActionContinue
+	}
+|	"EXIT"
+	{
+		$$ = ast.HandlerActionExit
+	}
+
+ProcedureHandlerConditionList:
+	ProcedureHandlerCondition
+	{
+		$$ = []*ast.HandlerCondition{$1.(*ast.HandlerCondition)}
+	}
+|	ProcedureHandlerConditionList ',' ProcedureHandlerCondition
+	{
+		$$ = append($1.([]*ast.HandlerCondition), $3.(*ast.HandlerCondition))
+	}
+
+ProcedureHandlerCondition:
+	NUM
+	{
+		$$ = &ast.HandlerCondition{
+			Tp:        ast.HandlerConditionErrorCode,
+			ErrorCode: getUint64FromNUM($1),
+		}
+	}
+|	"SQLSTATE" ValueSymOpt stringLit
+	{
+		$$ = &ast.HandlerCondition{
+			Tp:       ast.HandlerConditionSQLState,
+			SQLState: $3,
+		}
+	}
+|	"SQLWARNING"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionSQLWarning}
+	}
+|	"NOT" "FOUND"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionNotFound}
+	}
+|	"SQLEXCEPTION"
+	{
+		$$ = &ast.HandlerCondition{Tp: ast.HandlerConditionSQLException}
+	}
+
+ValueSymOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"VALUE"
+	{
+		$$ = nil
+	}
+
+ProcedureIfStmt:
+	"IF" ProcedureIfBlockList ProcedureElseOpt "END" "IF"
+	{
+		x := &ast.ProcedureIfStmt{
+			Blocks: $2.([]*ast.ProcedureIfBlock),
+		}
+		if $3 != nil {
+			x.Else = $3.([]ast.StmtNode)
+		}
+		$$ = x
+	}
+
+ProcedureIfBlockList:
+	Expression "THEN" ProcedureStmtList
+	{
+		$$ = []*ast.ProcedureIfBlock{{Cond: $1, Stmts: $3.([]ast.StmtNode)}}
+	}
+|	ProcedureIfBlockList "ELSEIF" Expression "THEN" ProcedureStmtList
+	{
+		$$ = append($1.([]*ast.ProcedureIfBlock), &ast.ProcedureIfBlock{Cond: $3, Stmts: $5.([]ast.StmtNode)})
+	}
+
+ProcedureElseOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"ELSE" ProcedureStmtList
+	{
+		$$ = $2
+	}
+
+ProcedureUnlabeledLoop:
+	"LOOP" ProcedureStmtList "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Tp:    ast.LoopTypeLoop,
+			Stmts: $2.([]ast.StmtNode),
+		}
+	}
+|	"WHILE" Expression "DO" ProcedureStmtList "END" "WHILE"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Tp:    ast.LoopTypeWhile,
+			Cond:  $2,
+			Stmts: $4.([]ast.StmtNode),
+		}
+	}
+|	"REPEAT" ProcedureStmtList "UNTIL" Expression "END" "REPEAT"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Tp:    ast.LoopTypeRepeat,
+			Cond:  $4,
+			Stmts: $2.([]ast.StmtNode),
+		}
+	}
+
+ProcedureJumpStmt:
+	"LEAVE" Identifier
+	{
+		$$ = &ast.ProcedureJumpStmt{Tp: ast.JumpTypeLeave, Label: $2}
+	}
+|	"ITERATE" Identifier
+	{
+		$$ = &ast.ProcedureJumpStmt{Tp: ast.JumpTypeIterate, Label: $2}
+	}
+
+ProcedureCursorStmt:
+	"OPEN" Identifier
+	{
+		$$ = &ast.ProcedureOpenCursorStmt{Name: $2}
+	}
+|	"FETCH" ProcedureFetchFrom Identifier "INTO" ProcedureVarList
+	{
+		$$ = &ast.ProcedureFetchCursorStmt{
+			Name: $3,
+			Vars: $5.([]string),
+		}
+	}
+|	"FETCH" Identifier "INTO" ProcedureVarList
+	{
+		$$ = &ast.ProcedureFetchCursorStmt{
+			Name: $2,
+			Vars: $4.([]string),
+		}
+	}
+|	"CLOSE" Identifier
+	{
+		$$ = &ast.ProcedureCloseCursorStmt{Name: $2}
+	}
+
+ProcedureFetchFrom:
+	"FROM"
+	{
+		$$ = nil
+	}
+|	"NEXT" "FROM"
+	{
+		$$ = nil
+	}
+
+ProcedureReturnStmt:
+	"RETURN" Expression
+	{
+		$$ = &ast.ProcedureReturnStmt{Expr: $2}
+	}
+
+/*******************************************************************
+ *
+ *  Signal Statement
+ *
+ *  Example:
+ *      SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = 'error', MYSQL_ERRNO = 1644
+ *******************************************************************/
+SignalStmt:
+	"SIGNAL" "SQLSTATE" ValueSymOpt stringLit SignalSetOpt
+	{
+		x := &ast.SignalStmt{SQLState: $4}
+		if $5 != nil {
+			x.Items = $5.([]*ast.SignalItem)
+		}
+		$$ = x
+	}
+
+SignalSetOpt:
+	/* EMPTY */
+	{
+		$$ = nil
+	}
+|	"SET" SignalItemList
+	{
+		$$ = $2
+	}
+
+SignalItemList:
+	SignalItem
+	{
+		$$ = []*ast.SignalItem{$1.(*ast.SignalItem)}
+	}
+|	SignalItemList ',' SignalItem
+	{
+		$$ = append($1.([]*ast.SignalItem), $3.(*ast.SignalItem))
+	}
+
+SignalItem:
+	"MESSAGE_TEXT" eq Expression
+	{
+		$$ = &ast.SignalItem{Name: ast.SignalMessageText, Value: $3}
+	}
+|	"MYSQL_ERRNO" eq Expression
+	{
+		$$ = &ast.SignalItem{Name: ast.SignalMySQLErrno, Value: $3}
+	}
+
 /************************************************************************************
  *
  *  Call Statements
@@ -6786,10 +7568,11 @@ SimpleExpr:
 			FunctionType: ast.CastBinaryOperator,
 		}
 	}
-|	builtinCast '(' Expression "AS" CastType ')'
+|	builtinCast '(' Expression "AS" CastType ArrayKwdOpt ')'
 	{
 		/* See https://dev.mysql.com/doc/refman/5.7/en/cast-functions.html#function_cast */
 		tp := $5.(*types.FieldType)
+		tp.Array = $6.(bool)
 		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
 		if tp.Flen == types.UnspecifiedLength {
 			tp.Flen = defaultFlen
@@ -7186,6 +7969,38 @@ FunctionCallNonKeyword:
 			Args:   []ast.ExprNode{$3, $5, $7},
 		}
 	}
+|	builtinJSONValue '(' Expression ',' Expression JSONValueReturningOpt JSONValueOnClauseOpt ')'
+	{
+		// See https://dev.mysql.com/doc/refman/8.0/en/json-search-functions.html#function_json-value
+		x := &ast.FuncJSONValueExpr{
+			Expr: $3,
+			Path: $5,
+		}
+		if $6 != nil {
+			x.Tp = $6.(*types.FieldType)
+		}
+		onClause := $7.(*ast.JSONTableColumn)
+		x.OnEmpty, x.OnError = onClause.OnEmpty, onClause.OnError
+		$$ = x
+	}
+
+JSONValueReturningOpt:
+	{
+		$$ = nil
+	}
+|	"RETURNING" CastType
+	{
+		tp := $2.(*types.FieldType)
+		defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimalForCast(tp.Tp)
+		if tp.Flen == types.UnspecifiedLength {
+			tp.Flen = defaultFlen
+		}
+		if tp.Decimal == types.UnspecifiedLength {
+			tp.Decimal = defaultDecimal
+		}
+		parser.explicitCharset = false
+		$$ = tp
+	}
 
 GetFormatSelector:
 	"DATE"
@@ -7316,9 +8131,13 @@ SumExpr:
 			$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$4}}
 		}
 	}
-|	builtinCount '(' DistinctKwd ExpressionList ')'
+|	builtinCount '(' DistinctKwd ExpressionList ')' OptWindowingClause
 	{
-		$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		if $6 != nil {
+			$$ = &ast.WindowFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true, Spec: *($6.(*ast.WindowSpec))}
+		} else {
+			$$ = &ast.AggregateFuncExpr{F: $1, Args: $4.([]ast.ExprNode), Distinct: true}
+		}
 	}
 |	builtinCount '(' "ALL" Expression ')' OptWindowingClause
 	{
@@ -7350,6 +8169,10 @@ SumExpr:
 		args := $4.([]ast.ExprNode)
 		args = append(args, $6.(ast.ExprNode))
 		if $8 != nil {
+			if $5 != nil {
+				yylex.AppendError(yylex.Errorf("The ORDER BY clause of GROUP_CONCAT is not supported when it is used as a window function."))
+				return 1
+			}
 			$$ = &ast.WindowFuncExpr{F: $1, Args: args, Distinct: $3.(bool), Spec: *($8.(*ast.WindowSpec))}
 		} else {
 			agg := &ast.AggregateFuncExpr{F: $1, Args: args, Distinct: $3.(bool)}
@@ -7659,6 +8482,15 @@ ElseOpt:
 		$$ = $2
 	}
 
+ArrayKwdOpt:
+	{
+		$$ = false
+	}
+|	"ARRAY"
+	{
+		$$ = true
+	}
+
 CastType:
 	"BINARY" OptFieldLen
 	{
@@ -8024,7 +8856,7 @@ HelpStmt:
 	}
 
 SelectStmtBasic:
-	"SELECT" SelectStmtOpts SelectStmtFieldList
+	"SELECT" SelectStmtOpts SelectStmtFieldList %prec lowerThanInto
 	{
 		st := &ast.SelectStmt{
 			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
@@ -8037,6 +8869,20 @@ SelectStmtBasic:
 		}
 		$$ = st
 	}
+|	"SELECT" SelectStmtOpts SelectStmtFieldList SelectIntoClause
+	{
+		st := &ast.SelectStmt{
+			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
+			Distinct:       $2.(*ast.SelectStmtOpts).Distinct,
+			Fields:         $3.(*ast.FieldList),
+			Kind:           ast.SelectStmtKindSelect,
+			SelectIntoOpt:  $4.(*ast.SelectIntoOption),
+		}
+		if st.SelectStmtOpts.TableHints != nil {
+			st.TableHints = st.SelectStmtOpts.TableHints
+		}
+		$$ = st
+	}
 
 SelectStmtFromDualTable:
 	SelectStmtBasic FromDual WhereClauseOptional
@@ -8699,6 +9545,110 @@ TableFactor:
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit "COLUMNS" '(' JSONTableColumnList ')' ')' TableAsNameOpt
+	{
+		/* See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html */
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $8.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $11.(model.CIStr)}
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableColumnOnClauseOpt
+	{
+		col := $5.(*ast.JSONTableColumn)
+		col.Tp = ast.JSONTableColumnPath
+		col.Name = model.NewCIStr($1)
+		col.FieldType = $2.(*types.FieldType)
+		col.Path = $4
+		$$ = col
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnExists, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" JSONTablePathKwdOpt stringLit "COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, Columns: $6.([]*ast.JSONTableColumn)}
+	}
+
+JSONTablePathKwdOpt:
+	{}
+|	"PATH"
+	{}
+
+JSONTableColumnOnClauseOpt:
+	{
+		$$ = &ast.JSONTableColumn{}
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = &ast.JSONTableColumn{OnEmpty: $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = &ast.JSONTableColumn{OnError: $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = &ast.JSONTableColumn{OnEmpty: $1.(*ast.JSONTableOnResponse), OnError: $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
+
+JSONValueOnClauseOpt:
+	{
+		$$ = &ast.JSONTableColumn{}
+	}
+|	JSONValueOnResponse "ON" "EMPTY"
+	{
+		$$ = &ast.JSONTableColumn{OnEmpty: $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONValueOnResponse "ON" "ERROR"
+	{
+		$$ = &ast.JSONTableColumn{OnError: $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONValueOnResponse "ON" "EMPTY" JSONValueOnResponse "ON" "ERROR"
+	{
+		$$ = &ast.JSONTableColumn{OnEmpty: $1.(*ast.JSONTableOnResponse), OnError: $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONValueOnResponse:
+	JSONTableOnResponse
+|	"DEFAULT" NumLiteral
+	{
+		// Unlike JSON_TABLE, the default value of JSON_VALUE can be a number.
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: fmt.Sprintf("%v", $2)}
+	}
+|	"DEFAULT" '-' NumLiteral
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: fmt.Sprintf("-%v", $3)}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -9095,7 +10045,10 @@ SelectStmtIntoOption:
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	SelectIntoClause
+
+SelectIntoClause:
+	"INTO" "OUTFILE" stringLit Fields Lines
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
@@ -9110,6 +10063,30 @@ SelectStmtIntoOption:
 
 		$$ = x
 	}
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]ast.ExprNode),
+		}
+	}
+
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3)
+	}
+
+SelectIntoVar:
+	UserVariable
+|	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
 
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
@@ -10859,6 +11836,8 @@ Statement:
 |	CreateIndexStmt
 |	CreateTableStmt
 |	CreateViewStmt
+|	CreateRoutineStmt
+|	CreateTriggerStmt
 |	CreateUserStmt
 |	CreateRoleStmt
 |	CreateBindingStmt
@@ -10873,6 +11852,8 @@ Statement:
 |	DropPolicyStmt
 |	DropSequenceStmt
 |	DropViewStmt
+|	DropRoutineStmt
+|	DropTriggerStmt
 |	DropUserStmt
 |	DropRoleStmt
 |	DropStatisticsStmt
@@ -10917,6 +11898,7 @@ Statement:
 		$$ = sel
 	}
 |	SetStmt
+|	SignalStmt
 |	SetRoleStmt
 |	SetDefaultRoleStmt
 |	SplitRegionStmt
@@ -11357,6 +12339,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -11788,6 +12771,51 @@ TextType:
 		$$ = x
 	}
 
+SpatialType:
+	GeometryType
+	{
+		x := types.NewFieldType(mysql.TypeGeometry)
+		x.GeometryType = $1.(byte)
+		x.Charset = charset.CharsetBin
+		x.Collate = charset.CollationBin
+		x.Flag |= mysql.BinaryFlag
+		$$ = x
+	}
+
+GeometryType:
+	"GEOMETRY"
+	{
+		$$ = mysql.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = mysql.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = mysql.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = mysql.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = mysql.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = mysql.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = mysql.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+
 OptCharsetWithOptBinary:
 	OptBinary
 |	"ASCII"
@@ -61,7 +61,7 @@ func TestSimple(t *testing.T) {
 		"delayed", "high_priority", "low_priority",
 		"cumeDist", "denseRank", "firstValue", "lag", "lastValue", "lead", "nthValue", "ntile",
 		"over", "percentRank", "rank", "row", "rows", "rowNumber", "window", "linear",
-		"match", "until", "placement", "tablesample", "attributes",
+		"match", "until", "placement", "tablesample", "attributes", "before", "each",
 		// TODO: support the following keywords
 		// "with",
 	}
@@ -1561,6 +1561,12 @@ func TestBuiltin(t *testing.T) {
 		// for cast as signed int, fix issue #3691.
 		{"select cast(1 as signed int);", true, "SELECT CAST(1 AS SIGNED)"},
 
+		// for cast as array
+		{"select cast(a as unsigned array) from t", true, "SELECT CAST(`a` AS UNSIGNED ARRAY) FROM `t`"},
+		{"select cast(a->'$.tags' as char(10) array) from t", true, "SELECT CAST(JSON_EXTRACT(`a`, _UTF8MB4'$.tags') AS CHAR(10) ARRAY) FROM `t`"},
+		{"select cast(a as array) from t", false, ""},
+		{"select array from t", true, "SELECT `array` FROM `t`"},
+
 		// for cast as double
 		{"select cast(1 as double);", true, "SELECT CAST(1 AS DOUBLE)"},
 
@@ -2112,7 +2118,24 @@ func TestBuiltin(t *testing.T) {
 		{`SELECT a->'$.a' FROM t`, true, "SELECT JSON_EXTRACT(`a`, _UTF8MB4'$.a') FROM `t`"},
 		{`SELECT a->>'$.a' FROM t`, true, "SELECT JSON_UNQUOTE(JSON_EXTRACT(`a`, _UTF8MB4'$.a')) FROM `t`"},
 		{`SELECT '{}'->'$.a' FROM t`, false, ""},
+
+		// For MEMBER OF and JSON_OVERLAPS.
+		{`SELECT 1 MEMBER OF (a) FROM t`, true, "SELECT 1 MEMBER OF (`a`) FROM `t`"},
+		{`SELECT * FROM t WHERE 'x' member of (a->'$.tags')`, true, "SELECT * FROM `t` WHERE _UTF8MB4'x' MEMBER OF (JSON_EXTRACT(`a`, _UTF8MB4'$.tags'))"},
+		{`SELECT 1 MEMBER OF a FROM t`, false, ""},
+		{`SELECT member FROM member`, true, "SELECT `member` FROM `member`"},
+		{`SELECT JSON_OVERLAPS(a, '[1, 2]') FROM t`, true, "SELECT JSON_OVERLAPS(`a`, _UTF8MB4'[1, 2]') FROM `t`"},
 		{`SELECT '{}'->>'$.a' FROM t`, false, ""},
+
+		// For JSON_VALUE.
+		{`SELECT JSON_VALUE(a, '$.b') FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b') FROM `t`"},
+		{`SELECT json_value(a, '$.b' returning decimal(10,2)) FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b' RETURNING DECIMAL(10, 2)) FROM `t`"},
+		{`SELECT JSON_VALUE(a, '$.b' RETURNING SIGNED DEFAULT 0 ON EMPTY ERROR ON ERROR) FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b' RETURNING SIGNED DEFAULT '0' ON EMPTY ERROR ON ERROR) FROM `t`"},
+		{`SELECT JSON_VALUE(a, '$.b' RETURNING CHAR(10) DEFAULT 'x' ON ERROR) FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b' RETURNING CHAR(10) DEFAULT 'x' ON ERROR) FROM `t`"},
+		{`SELECT JSON_VALUE(a, '$.b' NULL ON EMPTY) FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b' NULL ON EMPTY) FROM `t`"},
+		{`SELECT JSON_VALUE(a, '$.b' RETURNING DOUBLE DEFAULT -1.5 ON ERROR) FROM t`, true, "SELECT JSON_VALUE(`a`, _UTF8MB4'$.b' RETURNING DOUBLE DEFAULT '-1.5' ON ERROR) FROM `t`"},
+		{`SELECT JSON_VALUE(a) FROM t`, false, ""},
+		{`SELECT json_value, returning FROM t`, true, "SELECT `json_value`,`returning` FROM `t`"},
 		{`SELECT a->3 FROM t`, false, ""},
 		{`SELECT a->>3 FROM t`, false, ""},
 
@@ -2797,6 +2820,7 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD SPATIAL KEY `sp` (`g`)", true, "ALTER TABLE `t` ADD SPATIAL `sp`(`g`)"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3265,6 +3289,7 @@ func TestDDL(t *testing.T) {
 		{"create table a(a int, b int, key(a, (b+1)));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX(`a`, (`b`+1)))"},
 		{"create table a(a int, b int, key((a+1), b));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX((`a`+1), `b`))"},
 		{"create table a(a int, b int, key((a + 1) desc));", true, "CREATE TABLE `a` (`a` INT,`b` INT,INDEX((`a`+1)))"},
+		{"create table a(a json, key((cast(a->'$.ids' as signed array))));", true, "CREATE TABLE `a` (`a` JSON,INDEX((CAST(JSON_EXTRACT(`a`, _UTF8MB4'$.ids') AS SIGNED ARRAY))))"},
 
 		// for create sequence
 		{"create sequence sequence", true, "CREATE SEQUENCE `sequence`"},
@@ -3873,6 +3898,22 @@ func TestOptimizerHints(t *testing.T) {
 		require.Equal(t, int64(10), hints[0].HintData.(int64))
 	}
 
+	// Test LEADING
+	stmt, _, err = p.Parse("select /*+ LEADING(t3, (t1, t2)) */ * from t1, t2, t3 where t1.c1 = t2.c1 and t2.c1 = t3.c1", "", "")
+	require.NoError(t, err)
+	selectStmt = stmt[0].(*ast.SelectStmt)
+
+	hints = selectStmt.TableHints
+	require.Len(t, hints, 1)
+	require.Equal(t, "leading", hints[0].HintName.L)
+	require.Len(t, hints[0].Tables, 3)
+	require.Equal(t, "t3", hints[0].Tables[0].TableName.L)
+	require.Equal(t, "t1", hints[0].Tables[1].TableName.L)
+	require.Equal(t, "t2", hints[0].Tables[2].TableName.L)
+	leading := hints[0].HintData.(*ast.LeadingList)
+	require.Len(t, leading.Items, 2)
+	require.Len(t, leading.Items[1].(*ast.LeadingList).Items, 2)
+
 	// Test USE_INDEX_MERGE
 	stmt, _, err = p.Parse("select /*+ USE_INDEX_MERGE(t1, c1), use_index_merge(t2, c1), use_index_merge(t3, c1, primary, c2) */ c1, c2 from t1, t2, t3 where t1.c1 = t2.c1 and t3.c2 = t1.c2", "", "")
 	require.NoError(t, err)
@@ -4064,6 +4105,18 @@ func TestOptimizerHints(t *testing.T) {
 	require.Len(t, hints, 2)
 	require.Equal(t, "limit_to_cop", hints[0].HintName.L)
 	require.Equal(t, "limit_to_cop", hints[1].HintName.L)
+
+	// Test NO_DECORRELATE
+	stmt, _, err = p.Parse("select c1 from t1 where exists (select /*+ NO_DECORRELATE(), no_decorrelate(@sel_2) */ 1 from t2 where t1.c1 = t2.c1)", "", "")
+	require.NoError(t, err)
+	selectStmt = stmt[0].(*ast.SelectStmt)
+	subq := selectStmt.Where.(*ast.ExistsSubqueryExpr).Sel.(*ast.SubqueryExpr).Query.(*ast.SelectStmt)
+
+	hints = subq.TableHints
+	require.Len(t, hints, 2)
+	require.Equal(t, "no_decorrelate", hints[0].HintName.L)
+	require.Equal(t, "no_decorrelate", hints[1].HintName.L)
+	require.Equal(t, "sel_2", hints[1].QBName.L)
 }
 
 func TestType(t *testing.T) {
@@ -4107,6 +4160,13 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{"create table t (g geometry, p point not null, l linestring, pg polygon, mp multipoint, ml multilinestring, mpg multipolygon, gc geometrycollection)", true,
+			"CREATE TABLE `t` (`g` GEOMETRY,`p` POINT NOT NULL,`l` LINESTRING,`pg` POLYGON,`mp` MULTIPOINT,`ml` MULTILINESTRING,`mpg` MULTIPOLYGON,`gc` GEOMETRYCOLLECTION)"},
+		{"create table t (p point not null, spatial key sp (p))", true, "CREATE TABLE `t` (`p` POINT NOT NULL,SPATIAL `sp`(`p`))"},
+		{"create table t (p point not null, spatial index (p))", true, "CREATE TABLE `t` (`p` POINT NOT NULL,SPATIAL(`p`))"},
+		{"create table point (point int)", true, "CREATE TABLE `point` (`point` INT)"},
 	}
 	RunTest(t, table, false)
 }
@@ -4855,7 +4915,8 @@ func TestView(t *testing.T) {
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v as select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` AS SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t with local check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` WITH LOCAL CHECK OPTION"},
-		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t`"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` WITH CASCADED CHECK OPTION"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t with check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` WITH CASCADED CHECK OPTION"},
 		{"create or replace algorithm = merge definer = current_user view v as select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM `t`"},
 
 		// create view with `(` select statement `)`
@@ -4869,7 +4930,7 @@ func TestView(t *testing.T) {
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v as (select * from t)", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` AS (SELECT * FROM `t`)"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t)", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t`)"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t) with local check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t`) WITH LOCAL CHECK OPTION"},
-		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t) with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t`)"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t) with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t`) WITH CASCADED CHECK OPTION"},
 		{"create or replace algorithm = merge definer = current_user view v as (select * from t)", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS (SELECT * FROM `t`)"},
 
 		// create view with union statement
@@ -4883,7 +4944,7 @@ func TestView(t *testing.T) {
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v as select * from t union select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` AS SELECT * FROM `t` UNION SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union select * from t with local check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION SELECT * FROM `t` WITH LOCAL CHECK OPTION"},
-		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION SELECT * FROM `t`"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION SELECT * FROM `t` WITH CASCADED CHECK OPTION"},
 		{"create or replace algorithm = merge definer = current_user view v as select * from t union select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM `t` UNION SELECT * FROM `t`"},
 
 		// create view with union all statement
@@ -4897,7 +4958,7 @@ func TestView(t *testing.T) {
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v as select * from t union all select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` AS SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union all select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union all select * from t with local check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION ALL SELECT * FROM `t` WITH LOCAL CHECK OPTION"},
-		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union all select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as select * from t union all select * from t with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS SELECT * FROM `t` UNION ALL SELECT * FROM `t` WITH CASCADED CHECK OPTION"},
 		{"create or replace algorithm = merge definer = current_user view v as select * from t union all select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
 
 		// create view with `(` union statement `)`
@@ -4911,7 +4972,7 @@ func TestView(t *testing.T) {
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v as (select * from t union all select * from t)", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`)"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t union all select * from t)", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`)"},
 		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t union all select * from t) with local check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`) WITH LOCAL CHECK OPTION"},
-		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t union all select * from t) with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`)"},
+		{"create or replace algorithm = merge definer = 'root' sql security invoker view v(a,b) as (select * from t union all select * from t) with cascaded check option", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = `root`@`%` SQL SECURITY INVOKER VIEW `v` (`a`,`b`) AS (SELECT * FROM `t` UNION ALL SELECT * FROM `t`) WITH CASCADED CHECK OPTION"},
 		{"create or replace algorithm = merge definer = current_user view v as select * from t union all select * from t", true, "CREATE OR REPLACE ALGORITHM = MERGE DEFINER = CURRENT_USER SQL SECURITY DEFINER VIEW `v` AS SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
 	}
 	RunTest(t, table, false)
@@ -4925,7 +4986,7 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.AlgorithmUndefined, v.Algorithm)
 	require.Equal(t, "select * from t", v.Select.Text())
 	require.Equal(t, model.SecurityDefiner, v.Security)
-	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
+	require.Equal(t, model.CheckOptionNone, v.CheckOption)
 
 	src := `CREATE OR REPLACE ALGORITHM = UNDEFINED DEFINER = root@localhost
                   SQL SECURITY DEFINER
@@ -4949,6 +5010,52 @@ func TestView(t *testing.T) {
 	require.Equal(t, model.CheckOptionCascaded, v.CheckOption)
 }
 
+func TestStoredRoutine(t *testing.T) {
+	t.Parallel()
+	table := []testCase{
+		{"create procedure p() select 1", true, "CREATE DEFINER = CURRENT_USER PROCEDURE `p`() SELECT 1"},
+		{"create definer = 'root'@'%' procedure if not exists test.p(in a int, out b varchar(10), inout c bigint) comment 'x' sql security invoker modifies sql data begin declare x int default 1; set @b = 'a'; end", true, "CREATE DEFINER = `root`@`%` PROCEDURE IF NOT EXISTS `test`.`p`(`a` INT, OUT `b` VARCHAR(10), INOUT `c` BIGINT) COMMENT 'x' MODIFIES SQL DATA SQL SECURITY INVOKER BEGIN DECLARE `x` INT DEFAULT 1; SET @`b`=_UTF8MB4'a'; END"},
+		{"create function f(a int) returns int deterministic return a + 1", true, "CREATE DEFINER = CURRENT_USER FUNCTION `f`(`a` INT) RETURNS INT DETERMINISTIC RETURN `a`+1"},
+		{"create function f(a int) returns int no sql not deterministic begin declare r, s int; return a * 2; end", true, "CREATE DEFINER = CURRENT_USER FUNCTION `f`(`a` INT) RETURNS INT NO SQL BEGIN DECLARE `r`, `s` INT; RETURN `a`*2; END"},
+		{"create procedure p() lbl: begin declare done int default 0; declare c cursor for select a from t; declare continue handler for not found set @done = 1; declare exit handler for sqlexception, sqlwarning, 1062, sqlstate value '23000' begin end; open c; read_loop: loop fetch c into x; if x = 1 then leave read_loop; elseif x > 1 then iterate read_loop; else set @s = x; end if; end loop read_loop; close c; end lbl", true, "CREATE DEFINER = CURRENT_USER PROCEDURE `p`() `lbl`: BEGIN DECLARE `done` INT DEFAULT 0; DECLARE `c` CURSOR FOR SELECT `a` FROM `t`; DECLARE CONTINUE HANDLER FOR NOT FOUND SET @`done`=1; DECLARE EXIT HANDLER FOR SQLEXCEPTION, SQLWARNING, 1062, SQLSTATE '23000' BEGIN END; OPEN `c`; `read_loop`: LOOP FETCH `c` INTO `x`; IF `x`=1 THEN LEAVE `read_loop`; ELSEIF `x`>1 THEN ITERATE `read_loop`; ELSE SET @`s`=`x`; END IF; END LOOP `read_loop`; CLOSE `c`; END `lbl`"},
+		{"create procedure p() begin while @i < 10 do set @i = @i + 1; end while; repeat set @i = @i - 1; until @i = 0 end repeat; end", true, "CREATE DEFINER = CURRENT_USER PROCEDURE `p`() BEGIN WHILE @`i`<10 DO SET @`i`=@`i`+1; END WHILE; REPEAT SET @`i`=@`i`-1; UNTIL @`i`=0 END REPEAT; END"},
+		{"create procedure p() begin select a, b into x, @y from t limit 1; fetch next from c into x, y; end", true, "CREATE DEFINER = CURRENT_USER PROCEDURE `p`() BEGIN SELECT `a`,`b` FROM `t` LIMIT 1 INTO `x`, @`y`; FETCH `c` INTO `x`, `y`; END"},
+		{"create procedure p() begin signal sqlstate '45000' set message_text = 'boom', mysql_errno = 1644; end", true, "CREATE DEFINER = CURRENT_USER PROCEDURE `p`() BEGIN SIGNAL SQLSTATE '45000' SET MESSAGE_TEXT = _UTF8MB4'boom', MYSQL_ERRNO = 1644; END"},
+		{"select a into @x from t", true, "SELECT `a` FROM `t` INTO @`x`"},
+		{"select a from t into @x, @y", true, "SELECT `a` FROM `t` INTO @`x`, @`y`"},
+		{"signal sqlstate '45000'", true, "SIGNAL SQLSTATE '45000'"},
+		{"drop procedure p", true, "DROP PROCEDURE `p`"},
+		{"drop function if exists test.f", true, "DROP FUNCTION IF EXISTS `test`.`f`"},
+
+		{"create function f(out a int) returns int return 1", false, ""},
+		{"create procedure p() returns int select 1", false, ""},
+		{"create function f() return 1", false, ""},
+		{"create or replace procedure p() select 1", false, ""},
+		{"create procedure p() begin leave; end", false, ""},
+		{"create procedure p() begin end if", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestTrigger(t *testing.T) {
+	t.Parallel()
+	table := []testCase{
+		{"create trigger tr before insert on t for each row set new.a = new.a + 1", true, "CREATE DEFINER = CURRENT_USER TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=`new`.`a`+1"},
+		{"create definer = 'root'@'%' trigger if not exists test.tr after update on test.t for each row follows tr1 insert into log values (old.a, new.a)", true, "CREATE DEFINER = `root`@`%` TRIGGER IF NOT EXISTS `test`.`tr` AFTER UPDATE ON `test`.`t` FOR EACH ROW FOLLOWS `tr1` INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`)"},
+		{"create trigger tr after delete on t for each row precedes tr1 begin declare x int default 0; if old.a > 0 then delete from t2 where a = old.a; end if; end", true, "CREATE DEFINER = CURRENT_USER TRIGGER `tr` AFTER DELETE ON `t` FOR EACH ROW PRECEDES `tr1` BEGIN DECLARE `x` INT DEFAULT 0; IF `old`.`a`>0 THEN DELETE FROM `t2` WHERE `a`=`old`.`a`; END IF; END"},
+		{"drop trigger tr", true, "DROP TRIGGER `tr`"},
+		{"drop trigger if exists test.tr", true, "DROP TRIGGER IF EXISTS `test`.`tr`"},
+		{"select before from t", false, ""},
+		{"select follows, precedes from t", true, "SELECT `follows`,`precedes` FROM `t`"},
+
+		{"create trigger tr insert on t for each row set @a = 1", false, ""},
+		{"create trigger tr before insert on t set @a = 1", false, ""},
+		{"create trigger tr before select on t for each row set @a = 1", false, ""},
+		{"create or replace trigger tr before insert on t for each row set @a = 1", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestTimestampDiffUnit(t *testing.T) {
 	t.Parallel()
 	// Test case for timestampdiff unit.
@@ -5245,6 +5352,32 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	t.Parallel()
+	table := []testCase{
+		// positive test cases
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from t, json_table(t.doc, '$.items[*]' columns (id for ordinality, name varchar(10) path '$.name', has_tag int exists path '$.tag')) as jt", true, "SELECT * FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$.items[*]' COLUMNS (`id` FOR ORDINALITY, `name` VARCHAR(10) PATH '$.name', `has_tag` INT EXISTS PATH '$.tag')) AS `jt`"},
+		{"select * from t left join json_table(t.doc, '$' columns (a int path '$.a' default '0' on empty null on error)) as jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$' COLUMNS (`a` INT PATH '$.a' DEFAULT '0' ON EMPTY NULL ON ERROR)) AS `jt` ON TRUE"},
+		{"select * from json_table('{}', '$' columns (a json path '$.a' error on empty, b int path '$.b' error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'{}', '$' COLUMNS (`a` JSON PATH '$.a' ERROR ON EMPTY, `b` INT PATH '$.b' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$', nested '$.c' columns (c for ordinality)))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$', NESTED PATH '$.c' COLUMNS (`c` FOR ORDINALITY)))) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (path int path '$', nested int path '$', ordinality int exists path '$', empty char(1) path '$' default '\"x\"' on empty)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`path` INT PATH '$', `nested` INT PATH '$', `ordinality` INT EXISTS PATH '$', `empty` CHAR(1) PATH '$' DEFAULT '\"x\"' ON EMPTY)) AS `jt`"},
+		{"select nested, ordinality, path, empty from t", true, "SELECT `nested`,`ordinality`,`path`,`empty` FROM `t`"},
+
+		// negative test cases
+		{"select * from json_table('[]', '$[*]') as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns ()) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' null on error null on empty)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' default 1 on empty)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a for ordinality path '$')) as jt", false, ""},
+		{"select * from json_table('[]', a columns (a int path '$')) as jt", false, ""},
+		{"select * from json_table", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	t.Parallel()
 	tests := []struct {
@@ -5613,6 +5746,10 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT COUNT(profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(ALL profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT COUNT(*) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(1) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT COUNT(DISTINCT profit) OVER() AS country_profit FROM sales;`, true, "SELECT COUNT(DISTINCT `profit`) OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT COUNT(DISTINCT profit, year) OVER w AS country_profit FROM sales;`, true, "SELECT COUNT(DISTINCT `profit`, `year`) OVER `w` AS `country_profit` FROM `sales`"},
+		{`SELECT GROUP_CONCAT(DISTINCT profit) OVER() AS country_profit FROM sales;`, true, "SELECT GROUP_CONCAT(DISTINCT `profit` SEPARATOR ',') OVER () AS `country_profit` FROM `sales`"},
+		{`SELECT GROUP_CONCAT(profit ORDER BY year) OVER() AS country_profit FROM sales;`, false, ""},
 		{`SELECT MAX(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MAX(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT MIN(profit) OVER() AS country_profit FROM sales;`, true, "SELECT MIN(`profit`) OVER () AS `country_profit` FROM `sales`"},
 		{`SELECT SUM(profit) OVER() AS country_profit FROM sales;`, true, "SELECT SUM(`profit`) OVER () AS `country_profit` FROM `sales`"},
@@ -5987,6 +6124,12 @@ func (checker *nodeTextCleaner) Enter(in ast.Node) (out ast.Node, skipChildren b
 		node.F = strings.ToLower(node.F)
 	case *ast.SelectField:
 		node.Offset = 0
+	case *ast.SelectStmt:
+		if node.SelectIntoOpt != nil {
+			for _, v := range node.SelectIntoOpt.Variables {
+				v.SetOriginTextPosition(0)
+			}
+		}
 	case *test_driver.ValueExpr:
 		if node.Kind() == test_driver.KindMysqlDecimal {
 			_ = node.GetMysqlDecimal().FromString(node.GetMysqlDecimal().ToString())
@@ -124,6 +124,22 @@ func TypeToStr(tp byte, cs string) (r string) {
 	return ts
 }
 
+var geometryType2Str = map[byte]string{
+	mysql.GeometryTypeGeometry:           "geometry",
+	mysql.GeometryTypePoint:              "point",
+	mysql.GeometryTypeLineString:         "linestring",
+	mysql.GeometryTypePolygon:            "polygon",
+	mysql.GeometryTypeMultiPoint:         "multipoint",
+	mysql.GeometryTypeMultiLineString:    "multilinestring",
+	mysql.GeometryTypeMultiPolygon:       "multipolygon",
+	mysql.GeometryTypeGeometryCollection: "geometrycollection",
+}
+
+// GeometryTypeToStr converts the subtype of the spatial type to a string.
+func GeometryTypeToStr(tp byte) string {
+	return geometryType2Str[tp]
+}
+
 // StrToType convert a string to type enum.
 // Args:
 // 	ts: type string
@@ -46,6 +46,11 @@ type FieldType struct {
 	Collate string
 	// Elems is the element list for enum and set type.
 	Elems []string
+	// GeometryType is the subtype of the spatial type, like mysql.GeometryTypePoint.
+	GeometryType byte `json:",omitempty"`
+	// Array indicates the type is an array of the values of the type, like `CAST(... AS UNSIGNED ARRAY)`.
+	// The values of the array types are evaluated as JSON arrays.
+	Array bool `json:",omitempty"`
 }
 
 // NewFieldType returns a FieldType,
@@ -79,7 +84,9 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.Charset == other.Charset &&
 		ft.Collate == other.Collate &&
 		flenEqual &&
-		mysql.HasUnsignedFlag(ft.Flag) == mysql.HasUnsignedFlag(other.Flag)
+		mysql.HasUnsignedFlag(ft.Flag) == mysql.HasUnsignedFlag(other.Flag) &&
+		ft.GeometryType == other.GeometryType &&
+		ft.Array == other.Array
 	if !partialEqual || len(ft.Elems) != len(other.Elems) {
 		return false
 	}
@@ -93,6 +100,9 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 
 // EvalType gets the type in evaluation.
 func (ft *FieldType) EvalType() EvalType {
+	if ft.Array {
+		return ETJson
+	}
 	switch ft.Tp {
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong,
 		mysql.TypeBit, mysql.TypeYear:
@@ -133,6 +143,9 @@ func (ft *FieldType) Init(tp byte) {
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
 	ts := TypeToStr(ft.Tp, ft.Charset)
+	if ft.Tp == mysql.TypeGeometry {
+		ts = GeometryTypeToStr(ft.GeometryType)
+	}
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.Tp)
@@ -207,6 +220,9 @@ func (ft *FieldType) String() string {
 	if mysql.HasBinaryFlag(ft.Flag) && ft.Tp != mysql.TypeString {
 		strs = append(strs, "BINARY")
 	}
+	if ft.Array {
+		strs = append(strs, "ARRAY")
+	}
 
 	if IsTypeChar(ft.Tp) || IsTypeBlob(ft.Tp) {
 		if ft.Charset != "" && ft.Charset != charset.CharsetBin {
@@ -222,6 +238,10 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
+	if ft.Tp == mysql.TypeGeometry {
+		ctx.WriteKeyWord(GeometryTypeToStr(ft.GeometryType))
+		return nil
+	}
 	ctx.WriteKeyWord(TypeToStr(ft.Tp, ft.Charset))
 
 	precision := UnspecifiedLength
@@ -291,15 +311,14 @@ func (ft *FieldType) RestoreAsCastType(ctx *format.RestoreCtx, explicitCharset b
 		if ft.Flen != UnspecifiedLength {
 			ctx.WritePlainf("(%d)", ft.Flen)
 		}
-		if !explicitCharset {
-			return
-		}
-		if !skipWriteBinary && ft.Flag&mysql.BinaryFlag != 0 {
-			ctx.WriteKeyWord(" BINARY")
-		}
-		if ft.Charset != charset.CharsetBin && ft.Charset != mysql.DefaultCharset {
-			ctx.WriteKeyWord(" CHARSET ")
-			ctx.WriteKeyWord(ft.Charset)
+		if explicitCharset {
+			if !skipWriteBinary && ft.Flag&mysql.BinaryFlag != 0 {
+				ctx.WriteKeyWord(" BINARY")
+			}
+			if ft.Charset != charset.CharsetBin && ft.Charset != mysql.DefaultCharset {
+				ctx.WriteKeyWord(" CHARSET ")
+				ctx.WriteKeyWord(ft.Charset)
+			}
 		}
 	case mysql.TypeDate:
 		ctx.WriteKeyWord("DATE")
@@ -335,6 +354,10 @@ func (ft *FieldType) RestoreAsCastType(ctx *format.RestoreCtx, explicitCharset b
 	case mysql.TypeYear:
 		ctx.WriteKeyWord("YEAR")
 	}
+	if ft.Array {
+		ctx.WriteKeyWord(" ARRAY")
+	}
+
 }
 
 // FormatAsCastType is used for write AST back to string.
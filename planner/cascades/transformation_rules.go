@@ -2498,8 +2498,9 @@ func (r *PullSelectionUpApply) OnTransform(old *memo.ExprIter) (newExprs []*memo
 		newConds = append(newConds, cond.Clone().Decorrelate(outerChildGroup.Prop.Schema))
 	}
 	newApply := plannercore.LogicalApply{
-		LogicalJoin: *(apply.LogicalJoin.Shallow()),
-		CorCols:     apply.CorCols,
+		LogicalJoin:   *(apply.LogicalJoin.Shallow()),
+		CorCols:       apply.CorCols,
+		NoDecorrelate: apply.NoDecorrelate,
 	}.Init(apply.SCtx(), apply.SelectBlockOffset())
 	// Update Join conditions.
 	eq, left, right, other := newApply.LogicalJoin.ExtractOnCondition(newConds, outerChildGroup.Prop.Schema, innerChildGroup.Prop.Schema, false, false)
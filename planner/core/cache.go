@@ -74,6 +74,7 @@ type pstmtPlanCacheKey struct {
 	timezoneOffset       int
 	isolationReadEngines map[kv.StoreType]struct{}
 	selectLimit          uint64
+	foreignKeyChecks     bool
 
 	hash []byte
 }
@@ -83,7 +84,7 @@ func (key *pstmtPlanCacheKey) Hash() []byte {
 	if len(key.hash) == 0 {
 		var (
 			dbBytes    = hack.Slice(key.database)
-			bufferSize = len(dbBytes) + 8*6 + 3*8
+			bufferSize = len(dbBytes) + 8*6 + 3*8 + 1
 		)
 		if key.hash == nil {
 			key.hash = make([]byte, 0, bufferSize)
@@ -104,6 +105,11 @@ func (key *pstmtPlanCacheKey) Hash() []byte {
 			key.hash = append(key.hash, kv.TiFlash.Name()...)
 		}
 		key.hash = codec.EncodeInt(key.hash, int64(key.selectLimit))
+		if key.foreignKeyChecks {
+			key.hash = append(key.hash, 1)
+		} else {
+			key.hash = append(key.hash, 0)
+		}
 	}
 	return key.hash
 }
@@ -139,6 +145,7 @@ func NewPSTMTPlanCacheKey(sessionVars *variable.SessionVars, pstmtID uint32, sch
 		timezoneOffset:       timezoneOffset,
 		isolationReadEngines: make(map[kv.StoreType]struct{}),
 		selectLimit:          sessionVars.SelectLimit,
+		foreignKeyChecks:     sessionVars.ForeignKeyChecks,
 	}
 	for k, v := range sessionVars.IsolationReadEngines {
 		key.isolationReadEngines[k] = v
@@ -146,6 +153,26 @@ func NewPSTMTPlanCacheKey(sessionVars *variable.SessionVars, pstmtID uint32, sch
 	return key
 }
 
+// getOptimizerVars returns the values of the system variables affecting the plans besides the ones in
+// pstmtPlanCacheKey, in the order of variable.GetPlanAffectingSysVarNames.
+func getOptimizerVars(sessionVars *variable.SessionVars) []string {
+	names := variable.GetPlanAffectingSysVarNames()
+	vals := make([]string, 0, len(names))
+	for _, name := range names {
+		val, _ := sessionVars.GetSystemVar(name)
+		vals = append(vals, val)
+	}
+	return vals
+}
+
+// encodeOptimizerVars appends the values returned by getOptimizerVars to the hash of a plan cache key.
+func encodeOptimizerVars(b []byte, vals []string) []byte {
+	for _, val := range vals {
+		b = codec.EncodeCompactBytes(b, hack.Slice(val))
+	}
+	return b
+}
+
 // FieldSlice is the slice of the types.FieldType
 type FieldSlice []types.FieldType
 
@@ -86,6 +86,8 @@ type CheckTable struct {
 	IndexInfos         []*model.IndexInfo
 	IndexLookUpReaders []*PhysicalIndexLookUpReader
 	CheckIndex         bool
+	// Partitions are the names of the checked partitions, all the partitions are checked if it's empty.
+	Partitions []model.CIStr
 }
 
 // RecoverIndex is used for backfilling corrupted index data.
@@ -381,7 +383,7 @@ func (e *Execute) setFoundInPlanCache(sctx sessionctx.Context, opt bool) error {
 }
 
 func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, preparedStmt *CachedPrepareStmt) error {
-	var cacheKey kvcache.Key
+	var cacheKey, instanceCacheKey kvcache.Key
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 	prepared := preparedStmt.PreparedAst
@@ -412,6 +414,10 @@ func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context,
 			tps[i] = types.NewFieldType(mysql.TypeNull)
 		}
 	}
+	useInstanceCache := prepared.UseCache && variable.EnableInstancePlanCache.Load()
+	if useInstanceCache {
+		instanceCacheKey = NewInstancePlanCacheKey(sessVars, preparedStmt, tps, GetBindSQL4PlanCache(sctx, prepared.Stmt))
+	}
 	if prepared.CachedPlan != nil {
 		// Rewriting the expression in the select.where condition  will convert its
 		// type from "paramMarker" to "Constant".When Point Select queries are executed,
@@ -438,6 +444,12 @@ func (e *Execute) getPhysicalPlan(ctx context.Context, sctx sessionctx.Context,
 		stmtCtx.PointExec = true
 		return nil
 	}
+	if useInstanceCache {
+		hit, err := e.getPlanFromInstancePlanCache(ctx, sctx, is, preparedStmt, instanceCacheKey)
+		if err != nil || hit {
+			return err
+		}
+	}
 	if prepared.UseCache {
 		if cacheValue, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
 			if err := e.checkPreparedPriv(ctx, sctx, preparedStmt, is); err != nil {
@@ -497,6 +509,12 @@ REBUILD:
 	e.Plan = p
 	_, isTableDual := p.(*PhysicalTableDual)
 	if !isTableDual && prepared.UseCache && !stmtCtx.MaybeOverOptimized4PlanCache {
+		preparedStmt.NormalizedPlan, preparedStmt.PlanDigest = NormalizePlan(p)
+		stmtCtx.SetPlanDigest(preparedStmt.NormalizedPlan, preparedStmt.PlanDigest)
+		// The plans which can not be shared between the sessions still go to the session plan cache.
+		if useInstanceCache && putPlanIntoInstancePlanCache(sctx, instanceCacheKey, p, names, preparedStmt) {
+			return e.setFoundInPlanCache(sctx, false)
+		}
 		// rebuild key to exclude kv.TiFlash when stmt is not read only
 		if _, isolationReadContainTiFlash := sessVars.IsolationReadEngines[kv.TiFlash]; isolationReadContainTiFlash && !IsReadOnly(stmt, sessVars) {
 			delete(sessVars.IsolationReadEngines, kv.TiFlash)
@@ -504,8 +522,6 @@ REBUILD:
 			sessVars.IsolationReadEngines[kv.TiFlash] = struct{}{}
 		}
 		cached := NewPSTMTPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, tps)
-		preparedStmt.NormalizedPlan, preparedStmt.PlanDigest = NormalizePlan(p)
-		stmtCtx.SetPlanDigest(preparedStmt.NormalizedPlan, preparedStmt.PlanDigest)
 		if cacheVals, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
 			hitVal := false
 			for i, cacheVal := range cacheVals.([]*PSTMTPlanCacheValue) {
@@ -914,6 +930,12 @@ type Insert struct {
 	AllAssignmentsAreConstant bool
 
 	RowLen int
+
+	FKChecks   []*FKCheck
+	FKCascades []*FKCascade
+
+	// ViewChecks are the conditions of the views WITH CHECK OPTION when inserting through a view.
+	ViewChecks []*table.ViewCheck
 }
 
 // Update represents Update plan.
@@ -935,6 +957,13 @@ type Update struct {
 	PartitionedTable []table.PartitionedTable
 
 	tblID2Table map[int64]table.Table
+
+	FKChecks   map[int64][]*FKCheck
+	FKCascades map[int64][]*FKCascade
+
+	// ViewChecks are the conditions of the views WITH CHECK OPTION that the updated rows should
+	// satisfy, keyed by the Start of the TblColPosInfo of the view.
+	ViewChecks map[int][]*table.ViewCheck
 }
 
 // Delete represents a delete plan.
@@ -946,6 +975,9 @@ type Delete struct {
 	SelectPlan PhysicalPlan
 
 	TblColPosInfos TblColPosInfoSlice
+
+	FKChecks   map[int64][]*FKCheck
+	FKCascades map[int64][]*FKCascade
 }
 
 // AnalyzeInfo is used to store the database name, table name and partition name of analyze task.
@@ -1281,17 +1313,13 @@ func (e *Explain) explainPlanInRowFormat(p Plan, taskType, driverSide, indent st
 		}
 		err = e.explainPlanInRowFormat(x.tablePlan, "cop[tikv]", "(Probe)", childIndent, true)
 	case *Insert:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
-		}
+		err = e.explainDMLChildren(x.SelectPlan, x.FKChecks, x.FKCascades, childIndent)
 	case *Update:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
-		}
+		checks, cascades := flattenFKTriggers(x.TblColPosInfos, x.FKChecks, x.FKCascades)
+		err = e.explainDMLChildren(x.SelectPlan, checks, cascades, childIndent)
 	case *Delete:
-		if x.SelectPlan != nil {
-			err = e.explainPlanInRowFormat(x.SelectPlan, "root", "", childIndent, true)
-		}
+		checks, cascades := flattenFKTriggers(x.TblColPosInfos, x.FKChecks, x.FKCascades)
+		err = e.explainDMLChildren(x.SelectPlan, checks, cascades, childIndent)
 	case *Execute:
 		if x.Plan != nil {
 			err = e.explainPlanInRowFormat(x.Plan, "root", "", indent, true)
@@ -1302,6 +1330,46 @@ func (e *Explain) explainPlanInRowFormat(p Plan, taskType, driverSide, indent st
 	return
 }
 
+// explainDMLChildren explains the select plan of a DML statement, followed by
+// the foreign key checks and cascades of the statement.
+func (e *Explain) explainDMLChildren(selectPlan PhysicalPlan, checks []*FKCheck, cascades []*FKCascade, childIndent string) (err error) {
+	children := make([]Plan, 0, 1+len(checks)+len(cascades))
+	if selectPlan != nil {
+		children = append(children, selectPlan)
+	}
+	for _, check := range checks {
+		children = append(children, check)
+	}
+	for _, cascade := range cascades {
+		children = append(children, cascade)
+	}
+	for i, child := range children {
+		err = e.explainPlanInRowFormat(child, "root", "", childIndent, i == len(children)-1)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// flattenFKTriggers returns the foreign key checks and cascades of the tables in the order of the tables.
+func flattenFKTriggers(infos TblColPosInfoSlice, fkChecks map[int64][]*FKCheck, fkCascades map[int64][]*FKCascade) ([]*FKCheck, []*FKCascade) {
+	var (
+		checks   []*FKCheck
+		cascades []*FKCascade
+	)
+	visited := make(map[int64]struct{}, len(infos))
+	for _, info := range infos {
+		if _, ok := visited[info.TblID]; ok {
+			continue
+		}
+		visited[info.TblID] = struct{}{}
+		checks = append(checks, fkChecks[info.TblID]...)
+		cascades = append(cascades, fkCascades[info.TblID]...)
+	}
+	return checks, cascades
+}
+
 func getRuntimeInfo(ctx sessionctx.Context, p Plan, runtimeStatsColl *execdetails.RuntimeStatsColl) (actRows, analyzeInfo, memoryInfo, diskInfo string) {
 	if runtimeStatsColl == nil {
 		runtimeStatsColl = ctx.GetSessionVars().StmtCtx.RuntimeStatsColl
@@ -50,6 +50,9 @@ var (
 	ErrWrongGroupField                       = dbterror.ClassOptimizer.NewStd(mysql.ErrWrongGroupField)
 	ErrDupFieldName                          = dbterror.ClassOptimizer.NewStd(mysql.ErrDupFieldName)
 	ErrNonUpdatableTable                     = dbterror.ClassOptimizer.NewStd(mysql.ErrNonUpdatableTable)
+	ErrNonInsertableTable                    = dbterror.ClassOptimizer.NewStd(mysql.ErrNonInsertableTable)
+	ErrNonupdateableColumn                   = dbterror.ClassOptimizer.NewStd(mysql.ErrNonupdateableColumn)
+	ErrViewNonupdCheck                       = dbterror.ClassOptimizer.NewStd(mysql.ErrViewNonupdCheck)
 	ErrMultiUpdateKeyConflict                = dbterror.ClassOptimizer.NewStd(mysql.ErrMultiUpdateKeyConflict)
 	ErrInternal                              = dbterror.ClassOptimizer.NewStd(mysql.ErrInternal)
 	ErrNonUniqTable                          = dbterror.ClassOptimizer.NewStd(mysql.ErrNonuniqTable)
@@ -62,6 +65,7 @@ var (
 	ErrWindowNoRedefineOrderBy               = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowNoRedefineOrderBy)
 	ErrWindowDuplicateName                   = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowDuplicateName)
 	ErrPartitionClauseOnNonpartitioned       = dbterror.ClassOptimizer.NewStd(mysql.ErrPartitionClauseOnNonpartitioned)
+	ErrPartitionMgmtOnNonpartitioned         = dbterror.ClassOptimizer.NewStd(mysql.ErrPartitionMgmtOnNonpartitioned)
 	ErrWindowFrameStartIllegal               = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowFrameStartIllegal)
 	ErrWindowFrameEndIllegal                 = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowFrameEndIllegal)
 	ErrWindowFrameIllegal                    = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowFrameIllegal)
@@ -94,6 +98,8 @@ var (
 	ErrCTERecursiveRequiresNonRecursiveFirst = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveRequiresNonRecursiveFirst)
 	ErrCTERecursiveForbidsAggregation        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbidsAggregation)
 	ErrCTERecursiveForbiddenJoinOrder        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbiddenJoinOrder)
+	ErrTFMustHaveAlias                       = dbterror.ClassOptimizer.NewStd(mysql.ErrTFMustHaveAlias)
+	ErrTFForbiddenReference                  = dbterror.ClassOptimizer.NewStd(mysql.ErrTFForbiddenReference)
 	ErrInvalidRequiresSingleReference        = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidRequiresSingleReference)
 	ErrSQLInReadOnlyMode                     = dbterror.ClassOptimizer.NewStd(mysql.ErrReadOnlyMode)
 	// Since we cannot know if user logged in with a password, use message of ErrAccessDeniedNoPassword instead
@@ -108,4 +114,10 @@ var (
 	ErrPartitionNoTemporary     = dbterror.ClassOptimizer.NewStd(mysql.ErrPartitionNoTemporary)
 	ErrViewSelectTemporaryTable = dbterror.ClassOptimizer.NewStd(mysql.ErrViewSelectTmptable)
 	ErrSubqueryMoreThan1Row     = dbterror.ClassOptimizer.NewStd(mysql.ErrSubqueryNo1Row)
+	ErrFtMatchingKeyNotFound    = dbterror.ClassOptimizer.NewStd(mysql.ErrFtMatchingKeyNotFound)
+	ErrProcaccessDenied         = dbterror.ClassOptimizer.NewStd(mysql.ErrProcaccessDenied)
+	ErrSpWrongNoOfArgs          = dbterror.ClassOptimizer.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpNoRecursion            = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNoRecursion)
+	ErrSpUndeclaredVar          = dbterror.ClassOptimizer.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpNotVarArg              = dbterror.ClassOptimizer.NewStd(mysql.ErrSpNotVarArg)
 )
@@ -32,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/plancodec"
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/pingcap/tipb/go-tipb"
@@ -813,9 +814,12 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		if !isFirst {
 			buffer.WriteString(" ")
 		}
-		if p.Frame.Type == ast.Rows {
+		switch p.Frame.Type {
+		case ast.Rows:
 			buffer.WriteString("rows")
-		} else {
+		case ast.Groups:
+			buffer.WriteString("groups")
+		default:
 			buffer.WriteString("range")
 		}
 		buffer.WriteString(" between ")
@@ -900,11 +904,30 @@ func (p *LogicalSelection) ExplainInfo() string {
 	return string(expression.SortedExplainExpressionList(p.Conditions))
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root)
+}
+
 // ExplainInfo implements Plan interface.
 func (p *LogicalApply) ExplainInfo() string {
 	return p.LogicalJoin.ExplainInfo()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *LogicalJSONTable) ExplainInfo() string {
+	return explainJSONTable(p.Expr, p.Root)
+}
+
+func explainJSONTable(expr expression.Expression, root *JSONTablePath) string {
+	var str strings.Builder
+	str.WriteString("expr:")
+	str.WriteString(expr.ExplainInfo())
+	str.WriteString(", path:")
+	str.WriteString(root.Path.String())
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *LogicalTableDual) ExplainInfo() string {
 	var str strings.Builder
@@ -1062,6 +1085,112 @@ func (p *TiKVSingleGather) ExplainInfo() string {
 	return buffer.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalFullTextIndexLookUp) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *PhysicalFullTextIndexLookUp) AccessObject(_ bool) string {
+	var buffer strings.Builder
+	tblName := p.Table.Name.O
+	if p.TableAsName != nil && p.TableAsName.O != "" {
+		tblName = p.TableAsName.O
+	}
+	buffer.WriteString("table:" + tblName)
+	buffer.WriteString(", index:" + p.Index.Name.O + "(")
+	for i, idxCol := range p.Index.Columns {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		buffer.WriteString(idxCol.Name.O)
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *PhysicalFullTextIndexLookUp) OperatorInfo(normalized bool) string {
+	var buffer strings.Builder
+	buffer.WriteString("against:")
+	if normalized {
+		buffer.WriteString("?")
+	} else {
+		buffer.WriteString(p.Against.String())
+	}
+	if p.Modifier.IsBooleanMode() {
+		buffer.WriteString(", boolean mode")
+	} else {
+		buffer.WriteString(", natural language mode")
+	}
+	return buffer.String()
+}
+
+// ExplainInfo implements Plan interface.
+func (p *PhysicalSpatialIndexLookUp) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *PhysicalSpatialIndexLookUp) AccessObject(_ bool) string {
+	tblName := p.Table.Name.O
+	if p.TableAsName != nil && p.TableAsName.O != "" {
+		tblName = p.TableAsName.O
+	}
+	return "table:" + tblName + ", index:" + p.Index.Name.O + "(" + p.Index.Columns[0].Name.O + ")"
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *PhysicalSpatialIndexLookUp) OperatorInfo(normalized bool) string {
+	if normalized {
+		return "shape:?"
+	}
+	// The constant geometry is shown in the well-known text instead of the binary storage format.
+	if c, ok := p.Shape.(*expression.Constant); ok && c.DeferredExpr == nil && c.ParamMarker == nil && !c.Value.IsNull() {
+		if _, g, err := spatial.Decode(c.Value.GetBytes()); err == nil {
+			return "shape:" + g.WKT()
+		}
+	}
+	return "shape:" + p.Shape.String()
+}
+
+// ExplainInfo implements Plan interface.
+func (p *PhysicalMVIndexLookUp) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *PhysicalMVIndexLookUp) AccessObject(_ bool) string {
+	var buffer strings.Builder
+	buffer.WriteString("table:")
+	if p.TableAsName != nil && p.TableAsName.O != "" {
+		buffer.WriteString(p.TableAsName.O)
+	} else {
+		buffer.WriteString(p.Table.Name.O)
+	}
+	buffer.WriteString(", index:" + p.Index.Name.O + "(")
+	for i, idxCol := range p.Index.Columns {
+		if i > 0 {
+			buffer.WriteString(", ")
+		}
+		if tblCol := p.Table.Columns[idxCol.Offset]; tblCol.Hidden {
+			buffer.WriteString(tblCol.GeneratedExprString)
+		} else {
+			buffer.WriteString(idxCol.Name.O)
+		}
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *PhysicalMVIndexLookUp) OperatorInfo(normalized bool) string {
+	if normalized {
+		return "values:?"
+	}
+	return "values:" + string(expression.SortedExplainExpressionList(p.Values))
+}
+
 // MetricTableTimeFormat is the time format for metric table explain and format.
 const MetricTableTimeFormat = "2006-01-02 15:04:05.999"
 
@@ -1086,3 +1215,53 @@ func (p *PhysicalMemTable) OperatorInfo(_ bool) string {
 	}
 	return ""
 }
+
+// ExplainInfo implements Plan interface.
+func (p *FKCheck) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCheck) AccessObject(_ bool) string {
+	var buffer strings.Builder
+	buffer.WriteString("table:" + p.TblName.O)
+	if p.Idx != nil {
+		buffer.WriteString(", index:" + p.Idx.Name.O)
+	} else if p.HandleLookup {
+		buffer.WriteString(", handle:" + p.Cols[0].Name.O)
+	}
+	return buffer.String()
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCheck) OperatorInfo(_ bool) string {
+	if p.CheckExist {
+		return "foreign_key:" + p.FK.Name.O + ", check_exist"
+	}
+	return "foreign_key:" + p.FK.Name.O + ", check_not_exist"
+}
+
+// ExplainInfo implements Plan interface.
+func (p *FKCascade) ExplainInfo() string {
+	return p.AccessObject(false) + ", " + p.OperatorInfo(false)
+}
+
+// AccessObject implements dataAccesser interface.
+func (p *FKCascade) AccessObject(_ bool) string {
+	var buffer strings.Builder
+	buffer.WriteString("table:" + p.ChildTable.Meta().Name.O)
+	if p.Idx != nil {
+		buffer.WriteString(", index:" + p.Idx.Name.O)
+	} else if p.HandleLookup {
+		buffer.WriteString(", handle:" + p.Cols[0].Name.O)
+	}
+	return buffer.String()
+}
+
+// OperatorInfo implements dataAccesser interface.
+func (p *FKCascade) OperatorInfo(_ bool) string {
+	if p.Tp == FKCascadeOnDelete {
+		return "foreign_key:" + p.FK.Name.O + ", on_delete:" + p.ReferOption().String()
+	}
+	return "foreign_key:" + p.FK.Name.O + ", on_update:" + p.ReferOption().String()
+}
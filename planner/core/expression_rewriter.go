@@ -315,7 +315,9 @@ func (er *expressionRewriter) constructBinaryOpFunction(l expression.Expression,
 	}
 }
 
-func (er *expressionRewriter) buildSubquery(ctx context.Context, subq *ast.SubqueryExpr) (LogicalPlan, error) {
+// buildSubquery builds the plan of the subquery, it also returns whether the applies built from the subquery
+// are marked as not decorrelated by the NO_DECORRELATE hint.
+func (er *expressionRewriter) buildSubquery(ctx context.Context, subq *ast.SubqueryExpr) (np LogicalPlan, noDecorrelate bool, err error) {
 	if er.schema != nil {
 		outerSchema := er.schema.Clone()
 		er.b.outerSchemas = append(er.b.outerSchemas, outerSchema)
@@ -325,14 +327,28 @@ func (er *expressionRewriter) buildSubquery(ctx context.Context, subq *ast.Subqu
 			er.b.outerNames = er.b.outerNames[0 : len(er.b.outerNames)-1]
 		}()
 	}
+	oldBlockOffset, oldNoDecorrelate := er.b.subQueryBlockOffset, er.b.subQueryNoDecorrelate
+	er.b.subQueryBlockOffset, er.b.subQueryNoDecorrelate = 0, false
+	if sel, ok := subq.Query.(*ast.SelectStmt); ok {
+		er.b.subQueryBlockOffset = sel.QueryBlockOffset
+	}
+	defer func() {
+		er.b.subQueryBlockOffset, er.b.subQueryNoDecorrelate = oldBlockOffset, oldNoDecorrelate
+	}()
 
-	np, err := er.b.buildResultSetNode(ctx, subq.Query)
+	np, err = er.b.buildResultSetNode(ctx, subq.Query)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 	// Pop the handle map generated by the subquery.
 	er.b.handleHelper.popMap()
-	return np, nil
+	noDecorrelate = er.b.subQueryNoDecorrelate
+	if noDecorrelate && (er.schema == nil || !hasCorrelatedCols(np, er.schema)) {
+		er.sctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+			"NO_DECORRELATE() is inapplicable because there are no correlated columns"))
+		noDecorrelate = false
+	}
+	return np, noDecorrelate, nil
 }
 
 // Enter implements Visitor interface.
@@ -456,7 +472,7 @@ func (er *expressionRewriter) Enter(inNode ast.Node) (ast.Node, bool) {
 	return inNode, false
 }
 
-func (er *expressionRewriter) buildSemiApplyFromEqualSubq(np LogicalPlan, l, r expression.Expression, not bool) {
+func (er *expressionRewriter) buildSemiApplyFromEqualSubq(np LogicalPlan, l, r expression.Expression, not, markNoDecorrelate bool) {
 	if er.asScalar || not {
 		if expression.GetRowLen(r) == 1 {
 			rCol := r.(*expression.Column)
@@ -496,7 +512,7 @@ func (er *expressionRewriter) buildSemiApplyFromEqualSubq(np LogicalPlan, l, r e
 	if er.err != nil {
 		return
 	}
-	er.p, er.err = er.b.buildSemiApply(er.p, np, []expression.Expression{condition}, er.asScalar, not)
+	er.p, er.err = er.b.buildSemiApply(er.p, np, []expression.Expression{condition}, er.asScalar, not, markNoDecorrelate)
 }
 
 func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.CompareSubqueryExpr) (ast.Node, bool) {
@@ -512,7 +528,7 @@ func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.
 		er.err = errors.Errorf("Unknown compare type %T.", v.R)
 		return v, true
 	}
-	np, err := er.buildSubquery(ctx, subq)
+	np, noDecorrelate, err := er.buildSubquery(ctx, subq)
 	if err != nil {
 		er.err = err
 		return v, true
@@ -555,11 +571,11 @@ func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.
 	case opcode.EQ, opcode.NE, opcode.NullEQ:
 		if v.Op == opcode.EQ {
 			if v.All {
-				er.handleEQAll(lexpr, rexpr, np)
+				er.handleEQAll(lexpr, rexpr, np, noDecorrelate)
 			} else {
 				// `a = any(subq)` will be rewriten as `a in (subq)`.
 				er.asScalar = true
-				er.buildSemiApplyFromEqualSubq(np, lexpr, rexpr, false)
+				er.buildSemiApplyFromEqualSubq(np, lexpr, rexpr, false, noDecorrelate)
 				if er.err != nil {
 					return v, true
 				}
@@ -568,12 +584,12 @@ func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.
 			if v.All {
 				// `a != all(subq)` will be rewriten as `a not in (subq)`.
 				er.asScalar = true
-				er.buildSemiApplyFromEqualSubq(np, lexpr, rexpr, true)
+				er.buildSemiApplyFromEqualSubq(np, lexpr, rexpr, true, noDecorrelate)
 				if er.err != nil {
 					return v, true
 				}
 			} else {
-				er.handleNEAny(lexpr, rexpr, np)
+				er.handleNEAny(lexpr, rexpr, np, noDecorrelate)
 			}
 		} else {
 			// TODO: Support this in future.
@@ -583,7 +599,7 @@ func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.
 	default:
 		// When < all or > any , the agg function should use min.
 		useMin := ((v.Op == opcode.LT || v.Op == opcode.LE) && v.All) || ((v.Op == opcode.GT || v.Op == opcode.GE) && !v.All)
-		er.handleOtherComparableSubq(lexpr, rexpr, np, useMin, v.Op.String(), v.All)
+		er.handleOtherComparableSubq(lexpr, rexpr, np, useMin, v.Op.String(), v.All, noDecorrelate)
 	}
 	if er.asScalar {
 		// The parent expression only use the last column in schema, which represents whether the condition is matched.
@@ -595,7 +611,7 @@ func (er *expressionRewriter) handleCompareSubquery(ctx context.Context, v *ast.
 
 // handleOtherComparableSubq handles the queries like < any, < max, etc. For example, if the query is t.id < any (select s.id from s),
 // it will be rewrote to t.id < (select max(s.id) from s).
-func (er *expressionRewriter) handleOtherComparableSubq(lexpr, rexpr expression.Expression, np LogicalPlan, useMin bool, cmpFunc string, all bool) {
+func (er *expressionRewriter) handleOtherComparableSubq(lexpr, rexpr expression.Expression, np LogicalPlan, useMin bool, cmpFunc string, all, markNoDecorrelate bool) {
 	plan4Agg := LogicalAggregation{}.Init(er.sctx, er.b.getSelectOffset())
 	if hint := er.b.TableHints(); hint != nil {
 		plan4Agg.aggHints = hint.aggHints
@@ -625,11 +641,11 @@ func (er *expressionRewriter) handleOtherComparableSubq(lexpr, rexpr expression.
 	plan4Agg.AggFuncs = []*aggregation.AggFuncDesc{funcMaxOrMin}
 
 	cond := expression.NewFunctionInternal(er.sctx, cmpFunc, types.NewFieldType(mysql.TypeTiny), lexpr, colMaxOrMin)
-	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, all)
+	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, all, markNoDecorrelate)
 }
 
 // buildQuantifierPlan adds extra condition for any / all subquery.
-func (er *expressionRewriter) buildQuantifierPlan(plan4Agg *LogicalAggregation, cond, lexpr, rexpr expression.Expression, all bool) {
+func (er *expressionRewriter) buildQuantifierPlan(plan4Agg *LogicalAggregation, cond, lexpr, rexpr expression.Expression, all, markNoDecorrelate bool) {
 	innerIsNull := expression.NewFunctionInternal(er.sctx, ast.IsNull, types.NewFieldType(mysql.TypeTiny), rexpr)
 	outerIsNull := expression.NewFunctionInternal(er.sctx, ast.IsNull, types.NewFieldType(mysql.TypeTiny), lexpr)
 
@@ -685,12 +701,12 @@ func (er *expressionRewriter) buildQuantifierPlan(plan4Agg *LogicalAggregation,
 	// plan4Agg.buildProjectionIfNecessary()
 	if !er.asScalar {
 		// For Semi LogicalApply without aux column, the result is no matter false or null. So we can add it to join predicate.
-		er.p, er.err = er.b.buildSemiApply(er.p, plan4Agg, []expression.Expression{cond}, false, false)
+		er.p, er.err = er.b.buildSemiApply(er.p, plan4Agg, []expression.Expression{cond}, false, false, markNoDecorrelate)
 		return
 	}
 	// If we treat the result as a scalar value, we will add a projection with a extra column to output true, false or null.
 	outerSchemaLen := er.p.Schema().Len()
-	er.p = er.b.buildApplyWithJoinType(er.p, plan4Agg, InnerJoin)
+	er.p = er.b.buildApplyWithJoinType(er.p, plan4Agg, InnerJoin, markNoDecorrelate)
 	joinSchema := er.p.Schema()
 	proj := LogicalProjection{
 		Exprs: expression.Column2Exprs(joinSchema.Clone().Columns[:outerSchemaLen]),
@@ -711,7 +727,7 @@ func (er *expressionRewriter) buildQuantifierPlan(plan4Agg *LogicalAggregation,
 // handleNEAny handles the case of != any. For example, if the query is t.id != any (select s.id from s), it will be rewrote to
 // t.id != s.id or count(distinct s.id) > 1 or [any checker]. If there are two different values in s.id ,
 // there must exist a s.id that doesn't equal to t.id.
-func (er *expressionRewriter) handleNEAny(lexpr, rexpr expression.Expression, np LogicalPlan) {
+func (er *expressionRewriter) handleNEAny(lexpr, rexpr expression.Expression, np LogicalPlan, markNoDecorrelate bool) {
 	// If there is NULL in s.id column, s.id should be the value that isn't null in condition t.id != s.id.
 	// So use function max to filter NULL.
 	maxFunc, err := aggregation.NewAggFuncDesc(er.sctx, ast.AggFuncMax, []expression.Expression{rexpr}, false)
@@ -744,12 +760,12 @@ func (er *expressionRewriter) handleNEAny(lexpr, rexpr expression.Expression, np
 	gtFunc := expression.NewFunctionInternal(er.sctx, ast.GT, types.NewFieldType(mysql.TypeTiny), count, expression.NewOne())
 	neCond := expression.NewFunctionInternal(er.sctx, ast.NE, types.NewFieldType(mysql.TypeTiny), lexpr, maxResultCol)
 	cond := expression.ComposeDNFCondition(er.sctx, gtFunc, neCond)
-	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, false)
+	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, false, markNoDecorrelate)
 }
 
 // handleEQAll handles the case of = all. For example, if the query is t.id = all (select s.id from s), it will be rewrote to
 // t.id = (select s.id from s having count(distinct s.id) <= 1 and [all checker]).
-func (er *expressionRewriter) handleEQAll(lexpr, rexpr expression.Expression, np LogicalPlan) {
+func (er *expressionRewriter) handleEQAll(lexpr, rexpr expression.Expression, np LogicalPlan, markNoDecorrelate bool) {
 	firstRowFunc, err := aggregation.NewAggFuncDesc(er.sctx, ast.AggFuncFirstRow, []expression.Expression{rexpr}, false)
 	if err != nil {
 		er.err = err
@@ -781,7 +797,7 @@ func (er *expressionRewriter) handleEQAll(lexpr, rexpr expression.Expression, np
 	leFunc := expression.NewFunctionInternal(er.sctx, ast.LE, types.NewFieldType(mysql.TypeTiny), count, expression.NewOne())
 	eqCond := expression.NewFunctionInternal(er.sctx, ast.EQ, types.NewFieldType(mysql.TypeTiny), lexpr, firstRowResultCol)
 	cond := expression.ComposeCNFCondition(er.sctx, leFunc, eqCond)
-	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, true)
+	er.buildQuantifierPlan(plan4Agg, cond, lexpr, rexpr, true, markNoDecorrelate)
 }
 
 func (er *expressionRewriter) handleExistSubquery(ctx context.Context, v *ast.ExistsSubqueryExpr) (ast.Node, bool) {
@@ -792,14 +808,14 @@ func (er *expressionRewriter) handleExistSubquery(ctx context.Context, v *ast.Ex
 		er.err = errors.Errorf("Unknown exists type %T.", v.Sel)
 		return v, true
 	}
-	np, err := er.buildSubquery(ctx, subq)
+	np, noDecorrelate, err := er.buildSubquery(ctx, subq)
 	if err != nil {
 		er.err = err
 		return v, true
 	}
 	np = er.popExistsSubPlan(np)
 	if len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 {
-		er.p, er.err = er.b.buildSemiApply(er.p, np, nil, er.asScalar, v.Not)
+		er.p, er.err = er.b.buildSemiApply(er.p, np, nil, er.asScalar, v.Not, noDecorrelate)
 		if er.err != nil || !er.asScalar {
 			return v, true
 		}
@@ -866,7 +882,7 @@ func (er *expressionRewriter) handleInSubquery(ctx context.Context, v *ast.Patte
 		er.err = errors.Errorf("Unknown compare type %T.", v.Sel)
 		return v, true
 	}
-	np, err := er.buildSubquery(ctx, subq)
+	np, noDecorrelate, err := er.buildSubquery(ctx, subq)
 	if err != nil {
 		er.err = err
 		return v, true
@@ -950,7 +966,7 @@ func (er *expressionRewriter) handleInSubquery(ctx context.Context, v *ast.Patte
 		}
 		er.p = join
 	} else {
-		er.p, er.err = er.b.buildSemiApply(er.p, np, expression.SplitCNFItems(checkCondition), asScalar, v.Not)
+		er.p, er.err = er.b.buildSemiApply(er.p, np, expression.SplitCNFItems(checkCondition), asScalar, v.Not, noDecorrelate)
 		if er.err != nil {
 			return v, true
 		}
@@ -967,14 +983,14 @@ func (er *expressionRewriter) handleInSubquery(ctx context.Context, v *ast.Patte
 func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, v *ast.SubqueryExpr) (ast.Node, bool) {
 	ci := er.b.prepareCTECheckForSubQuery()
 	defer resetCTECheckForSubQuery(ci)
-	np, err := er.buildSubquery(ctx, v)
+	np, noDecorrelate, err := er.buildSubquery(ctx, v)
 	if err != nil {
 		er.err = err
 		return v, true
 	}
 	np = er.b.buildMaxOneRow(np)
 	if len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 {
-		er.p = er.b.buildApplyWithJoinType(er.p, np, LeftOuterJoin)
+		er.p = er.b.buildApplyWithJoinType(er.p, np, LeftOuterJoin, noDecorrelate)
 		if np.Schema().Len() > 1 {
 			newCols := make([]expression.Expression, 0, np.Schema().Len())
 			for _, col := range np.Schema().Columns {
@@ -1127,12 +1143,27 @@ func (er *expressionRewriter) Leave(originInNode ast.Node) (retNode ast.Node, ok
 
 		er.ctxStack[len(er.ctxStack)-1] = castFunction
 		er.ctxNameStk[len(er.ctxNameStk)-1] = types.EmptyName
+	case *ast.FuncJSONValueExpr:
+		args := er.ctxStack[len(er.ctxStack)-2:]
+		er.err = expression.CheckArgsNotMultiColumnRow(args...)
+		if er.err != nil {
+			return retNode, false
+		}
+		var function expression.Expression
+		function, er.err = expression.BuildJSONValueFunction(er.sctx, args[0], args[1], v.Tp, v.OnEmpty, v.OnError)
+		if er.err != nil {
+			return retNode, false
+		}
+		er.ctxStackPop(2)
+		er.ctxStackAppend(function, types.EmptyName)
 	case *ast.PatternLikeExpr:
 		er.patternLikeToExpression(v)
 	case *ast.PatternRegexpExpr:
 		er.regexpToScalarFunc(v)
 	case *ast.RowExpr:
 		er.rowToScalarFunc(v)
+	case *ast.MatchAgainst:
+		er.matchAgainstToExpression(v)
 	case *ast.PatternInExpr:
 		if v.Sel == nil {
 			er.inToExpression(len(v.List), v.Not, &v.Type)
@@ -1606,6 +1637,73 @@ func (er *expressionRewriter) regexpToScalarFunc(v *ast.PatternRegexpExpr) {
 	er.ctxStackAppend(function, types.EmptyName)
 }
 
+func (er *expressionRewriter) matchAgainstToExpression(v *ast.MatchAgainst) {
+	stkLen := len(er.ctxStack)
+	colLen := len(v.ColumnNames)
+	if v.Modifier.WithQueryExpansion() {
+		er.err = ErrNotSupportedYet.GenWithStackByArgs("WITH QUERY EXPANSION")
+		return
+	}
+	against := er.ctxStack[stkLen-1]
+	if _, ok := against.(*expression.Constant); !ok {
+		er.err = ErrWrongArguments.GenWithStackByArgs("AGAINST")
+		return
+	}
+	cols := make([]*expression.Column, 0, colLen)
+	for _, arg := range er.ctxStack[stkLen-colLen-1 : stkLen-1] {
+		col, ok := arg.(*expression.Column)
+		if !ok {
+			er.err = ErrWrongArguments.GenWithStackByArgs("MATCH")
+			return
+		}
+		cols = append(cols, col)
+	}
+	idx := er.findFulltextIndex(er.ctxNameStk[stkLen-colLen-1 : stkLen-1])
+	if idx == nil {
+		er.err = ErrFtMatchingKeyNotFound.GenWithStackByArgs()
+		return
+	}
+	function, err := expression.NewMatchAgainst(er.sctx, v.Modifier, idx.ParserName, against, cols)
+	if err != nil {
+		er.err = err
+		return
+	}
+	er.ctxStackPop(colLen + 1)
+	er.ctxStackAppend(function, types.EmptyName)
+}
+
+// findFulltextIndex finds the FULLTEXT index whose columns are exactly the matched columns.
+func (er *expressionRewriter) findFulltextIndex(names []*types.FieldName) *model.IndexInfo {
+	dbName, tblName := names[0].DBName, names[0].OrigTblName
+	for _, name := range names {
+		if name.DBName.L != dbName.L || name.OrigTblName.L != tblName.L {
+			return nil
+		}
+	}
+	tbl, err := er.b.is.TableByName(dbName, tblName)
+	if err != nil {
+		return nil
+	}
+	for _, idx := range tbl.Meta().Indices {
+		if !idx.IsFulltext() || idx.State != model.StatePublic || len(idx.Columns) != len(names) {
+			continue
+		}
+		matchedCnt := 0
+		for _, name := range names {
+			for _, idxCol := range idx.Columns {
+				if idxCol.Name.L == name.OrigColName.L {
+					matchedCnt++
+					break
+				}
+			}
+		}
+		if matchedCnt == len(names) {
+			return idx
+		}
+	}
+	return nil
+}
+
 func (er *expressionRewriter) rowToScalarFunc(v *ast.RowExpr) {
 	stkLen := len(er.ctxStack)
 	length := len(v.Values)
@@ -1780,6 +1878,11 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	if er.rewriteFuncCall(v) {
 		return
 	}
+	if er.b.is != nil && (v.Tp == ast.FuncCallExprTypeGeneric || !expression.IsFunctionSupported(v.FnName.L)) {
+		if er.storedFuncToExpression(v, args) {
+			return
+		}
+	}
 
 	var function expression.Expression
 	er.ctxStackPop(len(v.Args))
@@ -1800,6 +1903,37 @@ func (er *expressionRewriter) funcCallToExpression(v *ast.FuncCallExpr) {
 	}
 }
 
+// storedFuncToExpression builds the call of a stored function. It returns false if the
+// unqualified function isn't a stored function, so it's built as a builtin function.
+func (er *expressionRewriter) storedFuncToExpression(v *ast.FuncCallExpr, args []expression.Expression) bool {
+	db := v.Schema
+	if db.L == "" {
+		db = model.NewCIStr(er.sctx.GetSessionVars().CurrentDB)
+	}
+	fullName := db.O + "." + v.FnName.O
+	routine, ok := er.b.is.RoutineByName(db, v.FnName, model.RoutineTypeFunction)
+	if !ok {
+		if v.Schema.L == "" {
+			return false
+		}
+		er.err = infoschema.ErrRoutineNotExists.GenWithStackByArgs(model.RoutineTypeFunction.String(), fullName)
+		return true
+	}
+	var authErr error
+	if user := er.sctx.GetSessionVars().User; user != nil {
+		authErr = ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, fullName)
+	}
+	er.b.visitInfo = appendVisitInfo(er.b.visitInfo, mysql.ExecutePriv, db.L, "", "", authErr)
+	function, err := buildRoutineFunction(er.sctx, er.b.is, db, routine, args)
+	if err != nil {
+		er.err = err
+		return true
+	}
+	er.ctxStackPop(len(v.Args))
+	er.ctxStackAppend(function, types.EmptyName)
+	return true
+}
+
 // Now TableName in expression only used by sequence function like nextval(seq).
 // The function arg should be evaluated as a table name rather than normal column name like mysql does.
 func (er *expressionRewriter) toTable(v *ast.TableName) {
@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	tidbutil "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
@@ -151,6 +152,21 @@ func (p *LogicalTableDual) findBestTask(prop *property.PhysicalProperty, planCou
 	return &rootTask{p: dual, isEmpty: p.RowCount == 0}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp) (task, int64, error) {
+	// The rows are produced in the order of the document, so no property can be ensured.
+	if !prop.IsEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		Expr:   p.Expr,
+		Root:   p.Root,
+		AsName: p.AsName,
+	}.Init(p.ctx, p.stats, p.blockOffset)
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	return &rootTask{p: jt}, 1, nil
+}
+
 func (p *LogicalShow) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp) (task, int64, error) {
 	if !prop.IsEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
@@ -573,6 +589,11 @@ func (ds *DataSource) skylinePruning(prop *property.PhysicalProperty) []*candida
 			candidates = append(candidates, ds.getIndexMergeCandidate(path))
 			continue
 		}
+		// The search index paths can't be compared with the other paths by the columns of the access conditions.
+		if path.IsSearchIndexPath() {
+			candidates = append(candidates, &candidatePath{path: path})
+			continue
+		}
 		// if we already know the range of the scan is empty, just return a TableDual
 		if len(path.Ranges) == 0 {
 			return []*candidatePath{{path: path}}
@@ -631,7 +652,7 @@ func (ds *DataSource) skylinePruning(prop *property.PhysicalProperty) []*candida
 		preferredPaths := make([]*candidatePath, 0, len(candidates))
 		var hasRangeScanPath bool
 		for _, c := range candidates {
-			if c.path.Forced || c.path.StoreType == kv.TiFlash {
+			if c.path.Forced || c.path.StoreType == kv.TiFlash || c.path.IsSearchIndexPath() {
 				preferredPaths = append(preferredPaths, c)
 				continue
 			}
@@ -802,6 +823,20 @@ func (ds *DataSource) findBestTask(prop *property.PhysicalProperty, planCounter
 			}
 			continue
 		}
+		if path.IsSearchIndexPath() {
+			searchTask := ds.convertToSearchIndexLookUp(prop, candidate)
+			if !searchTask.invalid() {
+				cntPlan += 1
+				planCounter.Dec(1)
+			}
+			if searchTask.cost() < t.cost() || planCounter.Empty() {
+				t = searchTask
+			}
+			if planCounter.Empty() {
+				return t, cntPlan, nil
+			}
+			continue
+		}
 		// if we already know the range of the scan is empty, just return a TableDual
 		if len(path.Ranges) == 0 && !ds.ctx.GetSessionVars().StmtCtx.UseCache {
 			dual := PhysicalTableDual{}.Init(ds.ctx, ds.stats, ds.blockOffset)
@@ -1820,6 +1855,210 @@ func (ds *DataSource) convertToSampleTable(prop *property.PhysicalProperty, cand
 	}, nil
 }
 
+// convertToSearchIndexLookUp converts the search index path to the plan reading the FULLTEXT, SPATIAL or multi-valued
+// index entries of the search condition and then the rows from the KV store directly. The search condition is kept in
+// the Selection above the DataSource, and the pushed down conditions are evaluated by a Selection on the plan.
+func (ds *DataSource) convertToSearchIndexLookUp(prop *property.PhysicalProperty, candidate *candidatePath) task {
+	if prop.TaskTp != property.RootTaskType || !prop.IsEmpty() {
+		return invalidTask
+	}
+	path := candidate.path
+	sf := path.SearchCond.(*expression.ScalarFunction)
+	args := ds.searchIndexLookUpArgs(path.Index, sf)
+	var p PhysicalPlan
+	switch {
+	case path.Index.IsFulltext():
+		modifier, _, _, _ := expression.MatchAgainstInfo(sf)
+		lookUp := PhysicalFullTextIndexLookUp{
+			Table:       ds.tableInfo,
+			TableAsName: ds.TableAsName,
+			Index:       path.Index,
+			Columns:     ds.Columns,
+			Against:     args[0],
+			Modifier:    modifier,
+		}.Init(ds.ctx, ds.stats, ds.blockOffset)
+		lookUp.SetSchema(ds.schema)
+		p = lookUp
+	case path.Index.IsSpatial():
+		lookUp := PhysicalSpatialIndexLookUp{
+			Table:       ds.tableInfo,
+			TableAsName: ds.TableAsName,
+			Index:       path.Index,
+			Columns:     ds.Columns,
+			Shape:       args[0],
+		}.Init(ds.ctx, ds.stats, ds.blockOffset)
+		lookUp.SetSchema(ds.schema)
+		p = lookUp
+	default:
+		lookUp := PhysicalMVIndexLookUp{
+			Table:       ds.tableInfo,
+			TableAsName: ds.TableAsName,
+			Index:       path.Index,
+			Columns:     ds.Columns,
+			Values:      args,
+		}.Init(ds.ctx, ds.stats, ds.blockOffset)
+		lookUp.SetSchema(ds.schema)
+		p = lookUp
+	}
+	return ds.kvIndexLookUpTask(p, path)
+}
+
+// kvIndexLookUpTask returns the root task of the plan reading the index and the rows from the KV store directly,
+// the pushed down conditions are evaluated by a Selection on it.
+// Like the IndexLookUp, the cost consists of scanning the index entries and looking up the rows, but the rows are
+// read by TiDB itself, so the cost isn't amortized to the coprocessor workers.
+func (ds *DataSource) kvIndexLookUpTask(p PhysicalPlan, path *util.AccessPath) task {
+	sessVars := ds.ctx.GetSessionVars()
+	indexRows := path.CountAfterAccess
+	idxRowSize := ds.TblColHists.GetIndexAvgRowSize(ds.ctx, path.FullIdxCols, path.Index.Unique)
+	tblRowSize := ds.TblColHists.GetTableAvgRowSize(ds.ctx, ds.TblCols, kv.TiKV, true)
+	cost := indexRows * idxRowSize * sessVars.GetScanFactor(ds.tableInfo)
+	cost += indexRows * (sessVars.CPUFactor + sessVars.GetSeekFactor(ds.tableInfo))
+	cost += indexRows * tblRowSize * (sessVars.GetScanFactor(ds.tableInfo) + sessVars.GetNetworkFactor(ds.tableInfo))
+	if len(ds.pushedDownConds) == 0 {
+		return &rootTask{p: p, cst: cost}
+	}
+	cost += indexRows * sessVars.CPUFactor
+	sel := PhysicalSelection{
+		Conditions: ds.pushedDownConds,
+	}.Init(ds.ctx, ds.stats, ds.blockOffset)
+	sel.SetChildren(p)
+	return &rootTask{p: sel, cst: cost}
+}
+
+// searchIndexLookUpArgs returns the arguments looked up through the search index for the condition, like the search
+// string of `MATCH ... AGAINST` on the FULLTEXT index, the constant geometry of the spatial relation functions on the
+// SPATIAL index and the JSON documents of the JSON array predicates on the multi-valued index. It returns nil if the
+// condition can't use the index.
+func (ds *DataSource) searchIndexLookUpArgs(idx *model.IndexInfo, sf *expression.ScalarFunction) []expression.Expression {
+	switch {
+	case idx.IsFulltext():
+		_, against, cols, ok := expression.MatchAgainstInfo(sf)
+		if !ok || !ds.isFulltextIndexOf(idx, cols) {
+			return nil
+		}
+		return []expression.Expression{against}
+	case idx.IsSpatial():
+		switch sf.FuncName.L {
+		case ast.StContains, ast.StWithin, ast.StIntersects:
+		default:
+			return nil
+		}
+		args := sf.GetArgs()
+		for i := range args {
+			col, ok := args[i].(*expression.Column)
+			if !ok || ds.tableInfo.Columns[idx.Columns[0].Offset].ID != col.ID {
+				continue
+			}
+			if shape := args[1-i]; len(expression.ExtractColumns(shape)) == 0 {
+				return []expression.Expression{shape}
+			}
+		}
+	case idx.MVIndex:
+		arrayExpr := ds.mvIndexArrayExpr(idx)
+		if arrayExpr == nil {
+			return nil
+		}
+		if value := ds.mvIndexLookUpValue(sf, arrayExpr); value != nil {
+			return []expression.Expression{value}
+		}
+	}
+	return nil
+}
+
+// searchIndexKeyCount returns the number of the keys looked up through the search index for the arguments, it's the
+// number of the elements of the constant JSON documents for the multi-valued index, and 1 otherwise.
+func (ds *DataSource) searchIndexKeyCount(idx *model.IndexInfo, args []expression.Expression) int {
+	if !idx.MVIndex {
+		return 1
+	}
+	keyCnt := 0
+	for _, arg := range args {
+		con, ok := arg.(*expression.Constant)
+		if !ok || con.ParamMarker != nil || con.DeferredExpr != nil {
+			keyCnt++
+			continue
+		}
+		doc, isNull, err := con.EvalJSON(ds.ctx, chunk.Row{})
+		if err != nil || isNull {
+			continue
+		}
+		keyCnt += len(json.FlattenBinaryArray(doc))
+	}
+	return keyCnt
+}
+
+// isFulltextIndexOf checks whether the columns are exactly the columns of the FULLTEXT index.
+func (ds *DataSource) isFulltextIndexOf(idx *model.IndexInfo, cols []*expression.Column) bool {
+	if len(idx.Columns) != len(cols) {
+		return false
+	}
+	for _, col := range cols {
+		matched := false
+		for _, idxCol := range idx.Columns {
+			if ds.tableInfo.Columns[idxCol.Offset].ID == col.ID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
+// mvIndexArrayExpr returns the JSON array expression casted to the array of the multi-valued index.
+func (ds *DataSource) mvIndexArrayExpr(idx *model.IndexInfo) expression.Expression {
+	colInfo := ds.tableInfo.Columns[idx.Columns[0].Offset]
+	if !colInfo.FieldType.Array {
+		return nil
+	}
+	for _, col := range ds.TblCols {
+		if col.ID != colInfo.ID {
+			continue
+		}
+		if cast, ok := col.VirtualExpr.(*expression.ScalarFunction); ok && cast.FuncName.L == ast.Cast {
+			return cast.GetArgs()[0]
+		}
+	}
+	return nil
+}
+
+// mvIndexLookUpValue returns the JSON document whose elements are looked up in the multi-valued index for
+// the condition on the JSON array, it returns nil if the condition can't use the index.
+func (ds *DataSource) mvIndexLookUpValue(sf *expression.ScalarFunction, arrayExpr expression.Expression) expression.Expression {
+	args := sf.GetArgs()
+	switch sf.FuncName.L {
+	case ast.JSONMemberOf:
+		if args[1].Equal(ds.ctx, arrayExpr) && len(expression.ExtractColumns(args[0])) == 0 {
+			return args[0]
+		}
+	case ast.JSONOverlaps:
+		for i := range args {
+			if args[i].Equal(ds.ctx, arrayExpr) && len(expression.ExtractColumns(args[1-i])) == 0 {
+				return args[1-i]
+			}
+		}
+	case ast.JSONContains:
+		if len(args) != 2 || !args[0].Equal(ds.ctx, arrayExpr) {
+			return nil
+		}
+		// The empty candidate is contained by all the JSON arrays, so only the constant candidate having at least
+		// one element can use the index.
+		con, ok := args[1].(*expression.Constant)
+		if !ok || expression.MaybeOverOptimized4PlanCache(ds.ctx, []expression.Expression{con}) {
+			return nil
+		}
+		candidate, isNull, err := con.EvalJSON(ds.ctx, chunk.Row{})
+		if err != nil || isNull || len(json.FlattenBinaryArray(candidate)) == 0 {
+			return nil
+		}
+		return con
+	}
+	return nil
+}
+
 func (ds *DataSource) convertToPointGet(prop *property.PhysicalProperty, candidate *candidatePath) task {
 	if !prop.IsEmpty() && !candidate.isMatchProp {
 		return invalidTask
@@ -0,0 +1,337 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/plancodec"
+)
+
+// FKCheck indicates the foreign key constraint check of a written row.
+// On the child side it checks that the referenced row exists in the parent table,
+// on the parent side it checks that no row in the child table references the row any more.
+type FKCheck struct {
+	basePhysicalPlan
+
+	// FK is the foreign key constraint to check, it is defined on the child table.
+	FK *model.FKInfo
+	// ChildSchema and ChildTable are the names of the schema and table which define the foreign key.
+	ChildSchema model.CIStr
+	ChildTable  model.CIStr
+	// Tbl is the table whose rows are looked up, it is nil if that table doesn't exist.
+	Tbl table.Table
+	// TblName is the name of the looked up table.
+	TblName model.CIStr
+	// Cols are the columns of Tbl compared with the values of the written row.
+	// It is nil if some of the columns don't exist.
+	Cols []*model.ColumnInfo
+	// Idx is the index used to look up the rows. It is nil if the rows are
+	// looked up by the integer handle, or the whole table has to be scanned.
+	Idx *model.IndexInfo
+	// HandleLookup indicates the rows are looked up by the integer handle.
+	HandleLookup bool
+	// Offsets are the offsets of the written table columns that provide the values.
+	Offsets []int
+	// CheckExist is true if a matching row must exist, and false if no row may match.
+	CheckExist bool
+}
+
+// FKCascadeType is the type of the statement which triggers a foreign key cascade.
+type FKCascadeType int8
+
+const (
+	// FKCascadeOnDelete indicates the cascade is triggered by deleting rows of the parent table.
+	FKCascadeOnDelete FKCascadeType = iota
+	// FKCascadeOnUpdate indicates the cascade is triggered by updating rows of the parent table.
+	FKCascadeOnUpdate
+)
+
+// FKCascade indicates the referential action of a foreign key, i.e. CASCADE or SET NULL,
+// that is executed on the child table when the referenced rows of the parent table are
+// deleted or updated.
+type FKCascade struct {
+	basePhysicalPlan
+
+	Tp FKCascadeType
+	// FK is the foreign key constraint, it is defined on ChildTable.
+	FK *model.FKInfo
+	// ChildSchema and ChildTable are the schema and table which define the foreign key.
+	ChildSchema model.CIStr
+	ChildTable  table.Table
+	// Cols are the foreign key columns of the child table, it is nil if some of the columns don't exist.
+	Cols []*model.ColumnInfo
+	// Idx is the index used to look up the child rows, it is nil if the rows are looked up
+	// by the integer handle, or the whole table has to be scanned.
+	Idx *model.IndexInfo
+	// HandleLookup indicates the child rows are looked up by the integer handle.
+	HandleLookup bool
+	// Offsets are the offsets of the referenced columns in the rows of the parent table.
+	Offsets []int
+}
+
+// Init initializes FKCheck.
+func (p FKCheck) Init(ctx sessionctx.Context) *FKCheck {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeForeignKeyCheck, &p, 0)
+	return &p
+}
+
+// Init initializes FKCascade.
+func (p FKCascade) Init(ctx sessionctx.Context) *FKCascade {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeForeignKeyCascade, &p, 0)
+	return &p
+}
+
+// ReferOption returns the referential action of the foreign key for the cascade.
+func (p *FKCascade) ReferOption() ast.ReferOptionType {
+	if p.Tp == FKCascadeOnDelete {
+		return ast.ReferOptionType(p.FK.OnDelete)
+	}
+	return ast.ReferOptionType(p.FK.OnUpdate)
+}
+
+func (p *Insert) buildOnInsertFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr) {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return
+	}
+	tblInfo := p.Table.Meta()
+	p.FKChecks = buildChildFKChecks(ctx, is, dbName, tblInfo, nil)
+	if p.IsReplace || len(p.OnDuplicate) > 0 {
+		// REPLACE deletes the conflicting rows, and ON DUPLICATE KEY UPDATE updates them.
+		var updatedCols map[string]struct{}
+		tp := FKCascadeOnDelete
+		if !p.IsReplace {
+			tp = FKCascadeOnUpdate
+			updatedCols = make(map[string]struct{}, len(p.OnDuplicate)+len(p.GenCols.OnDuplicates))
+			for _, assign := range p.OnDuplicate {
+				updatedCols[assign.ColName.L] = struct{}{}
+			}
+			for _, assign := range p.GenCols.OnDuplicates {
+				updatedCols[assign.ColName.L] = struct{}{}
+			}
+		}
+		checks, cascades := BuildParentFKChecksAndCascades(ctx, is, dbName, tblInfo, tp, updatedCols)
+		p.FKChecks = append(p.FKChecks, checks...)
+		p.FKCascades = cascades
+	}
+}
+
+func (updt *Update) buildOnUpdateFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return
+	}
+	updatedCols := make(map[int64]map[string]struct{}, len(tblID2table))
+	for _, assign := range updt.OrderedList {
+		for _, content := range updt.TblColPosInfos {
+			if assign.Col.Index < content.Start || assign.Col.Index >= content.End {
+				continue
+			}
+			tbl := tblID2table[content.TblID]
+			if updatedCols[content.TblID] == nil {
+				updatedCols[content.TblID] = make(map[string]struct{})
+			}
+			updatedCols[content.TblID][tbl.WritableCols()[assign.Col.Index-content.Start].Name.L] = struct{}{}
+		}
+	}
+	updt.FKChecks = make(map[int64][]*FKCheck)
+	updt.FKCascades = make(map[int64][]*FKCascade)
+	for tid, cols := range updatedCols {
+		tblInfo := tblID2table[tid].Meta()
+		dbInfo, ok := is.SchemaByTable(tblInfo)
+		if !ok {
+			continue
+		}
+		checks := buildChildFKChecks(ctx, is, dbInfo.Name, tblInfo, cols)
+		parentChecks, cascades := BuildParentFKChecksAndCascades(ctx, is, dbInfo.Name, tblInfo, FKCascadeOnUpdate, cols)
+		checks = append(checks, parentChecks...)
+		if len(checks) > 0 {
+			updt.FKChecks[tid] = checks
+		}
+		if len(cascades) > 0 {
+			updt.FKCascades[tid] = cascades
+		}
+	}
+}
+
+func (del *Delete) buildOnDeleteFKTriggers(ctx sessionctx.Context, is infoschema.InfoSchema, tblID2table map[int64]table.Table) {
+	if !ctx.GetSessionVars().ForeignKeyChecks {
+		return
+	}
+	del.FKChecks = make(map[int64][]*FKCheck)
+	del.FKCascades = make(map[int64][]*FKCascade)
+	for tid, tbl := range tblID2table {
+		tblInfo := tbl.Meta()
+		dbInfo, ok := is.SchemaByTable(tblInfo)
+		if !ok {
+			continue
+		}
+		checks, cascades := BuildParentFKChecksAndCascades(ctx, is, dbInfo.Name, tblInfo, FKCascadeOnDelete, nil)
+		if len(checks) > 0 {
+			del.FKChecks[tid] = checks
+		}
+		if len(cascades) > 0 {
+			del.FKCascades[tid] = cascades
+		}
+	}
+}
+
+// buildChildFKChecks builds the checks of the foreign keys defined on the written table.
+// If updatedCols is not nil, only the foreign keys containing the updated columns are checked.
+func buildChildFKChecks(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tblInfo *model.TableInfo, updatedCols map[string]struct{}) []*FKCheck {
+	var checks []*FKCheck
+	for _, fk := range tblInfo.ForeignKeys {
+		if fk.State != model.StatePublic || !fkColumnsUpdated(fk.Cols, updatedCols) {
+			continue
+		}
+		offsets, ok := fkColumnOffsets(tblInfo, fk.Cols)
+		if !ok {
+			continue
+		}
+		check := FKCheck{
+			FK:          fk,
+			ChildSchema: dbName,
+			ChildTable:  tblInfo.Name,
+			TblName:     fk.RefTable,
+			Offsets:     offsets,
+			CheckExist:  true,
+		}.Init(ctx)
+		if parent, err := is.TableByName(dbName, fk.RefTable); err == nil {
+			check.Tbl = parent
+			check.Cols, check.Idx, check.HandleLookup = findFKLookupColumns(parent.Meta(), fk.RefCols)
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+// BuildParentFKChecksAndCascades builds the checks and the cascades of the foreign keys
+// which reference the written table. If updatedCols is not nil, only the foreign keys
+// referencing the updated columns are considered.
+func BuildParentFKChecksAndCascades(ctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tblInfo *model.TableInfo,
+	tp FKCascadeType, updatedCols map[string]struct{}) ([]*FKCheck, []*FKCascade) {
+	var (
+		checks   []*FKCheck
+		cascades []*FKCascade
+	)
+	for _, child := range is.SchemaTables(dbName) {
+		for _, fk := range child.Meta().ForeignKeys {
+			if fk.State != model.StatePublic || fk.RefTable.L != tblInfo.Name.L || !fkColumnsUpdated(fk.RefCols, updatedCols) {
+				continue
+			}
+			offsets, ok := fkColumnOffsets(tblInfo, fk.RefCols)
+			if !ok {
+				continue
+			}
+			cols, idx, handleLookup := findFKLookupColumns(child.Meta(), fk.Cols)
+			opt := ast.ReferOptionType(fk.OnUpdate)
+			if tp == FKCascadeOnDelete {
+				opt = ast.ReferOptionType(fk.OnDelete)
+			}
+			switch opt {
+			case ast.ReferOptionCascade, ast.ReferOptionSetNull:
+				cascades = append(cascades, FKCascade{
+					Tp:           tp,
+					FK:           fk,
+					ChildSchema:  dbName,
+					ChildTable:   child,
+					Cols:         cols,
+					Idx:          idx,
+					HandleLookup: handleLookup,
+					Offsets:      offsets,
+				}.Init(ctx))
+			default:
+				// RESTRICT, NO ACTION and SET DEFAULT are all checked immediately, as InnoDB does.
+				checks = append(checks, FKCheck{
+					FK:           fk,
+					ChildSchema:  dbName,
+					ChildTable:   child.Meta().Name,
+					Tbl:          child,
+					TblName:      child.Meta().Name,
+					Cols:         cols,
+					Idx:          idx,
+					HandleLookup: handleLookup,
+					Offsets:      offsets,
+				}.Init(ctx))
+			}
+		}
+	}
+	return checks, cascades
+}
+
+func fkColumnsUpdated(cols []model.CIStr, updatedCols map[string]struct{}) bool {
+	if updatedCols == nil {
+		return true
+	}
+	for _, col := range cols {
+		if _, ok := updatedCols[col.L]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func fkColumnOffsets(tblInfo *model.TableInfo, cols []model.CIStr) ([]int, bool) {
+	offsets := make([]int, 0, len(cols))
+	for _, name := range cols {
+		col := model.FindColumnInfo(tblInfo.Columns, name.L)
+		if col == nil || col.State != model.StatePublic {
+			return nil, false
+		}
+		offsets = append(offsets, col.Offset)
+	}
+	return offsets, true
+}
+
+// findFKLookupColumns finds the columns of tblInfo and the way to look up the rows matching them.
+// The integer handle is used if the columns are the integer primary key, otherwise the index
+// whose leading columns are the given columns is used, preferring the primary key and the unique indices.
+func findFKLookupColumns(tblInfo *model.TableInfo, names []model.CIStr) (cols []*model.ColumnInfo, idx *model.IndexInfo, handleLookup bool) {
+	cols = make([]*model.ColumnInfo, 0, len(names))
+	for _, name := range names {
+		col := model.FindColumnInfo(tblInfo.Columns, name.L)
+		if col == nil || col.State != model.StatePublic {
+			return nil, nil, false
+		}
+		cols = append(cols, col)
+	}
+	if tblInfo.PKIsHandle && len(cols) == 1 {
+		if pk := tblInfo.GetPkColInfo(); pk != nil && pk.ID == cols[0].ID {
+			return cols, nil, true
+		}
+	}
+	for _, index := range tblInfo.Indices {
+		if index.State != model.StatePublic || index.Global || len(index.Columns) < len(cols) {
+			continue
+		}
+		matched := true
+		for i, col := range cols {
+			if index.Columns[i].Offset != col.Offset || index.Columns[i].Length != types.UnspecifiedLength {
+				matched = false
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		if idx == nil || (index.Primary && !idx.Primary) || (index.Unique && !idx.Unique && !idx.Primary) {
+			idx = index
+		}
+	}
+	return cols, idx, false
+}
@@ -0,0 +1,375 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+	"github.com/pingcap/tidb/util/hint"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/logutil"
+	"go.uber.org/zap"
+)
+
+var generalPlanCacheCounter = metrics.PlanCacheCounter.WithLabelValues("general")
+
+// generalPlanCacheKey is the key of a parameterized text protocol statement in the plan cache.
+// The optimizer variables are contained, so the plan isn't reused after they are changed.
+type generalPlanCacheKey struct {
+	pstmtPlanCacheKey
+	paramSQL      string
+	optimizerVars []string
+}
+
+// Hash implements Key interface.
+func (key *generalPlanCacheKey) Hash() []byte {
+	if len(key.hash) == 0 {
+		key.pstmtPlanCacheKey.Hash()
+		key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(key.paramSQL))
+		key.hash = encodeOptimizerVars(key.hash, key.optimizerVars)
+	}
+	return key.hash
+}
+
+// NewGeneralPlanCacheKey creates a new generalPlanCacheKey object.
+func NewGeneralPlanCacheKey(sessionVars *variable.SessionVars, paramSQL string, schemaVersion int64) kvcache.Key {
+	key := NewPSTMTPlanCacheKey(sessionVars, 0, schemaVersion).(*pstmtPlanCacheKey)
+	return &generalPlanCacheKey{
+		pstmtPlanCacheKey: *key,
+		paramSQL:          paramSQL,
+		optimizerVars:     getOptimizerVars(sessionVars),
+	}
+}
+
+// generalPlanCacheEntry stores the cached plans of a parameterized text protocol statement,
+// one for each group of parameter types.
+type generalPlanCacheEntry struct {
+	plans      []*PSTMTPlanCacheValue
+	visitInfos []visitInfo
+	tables     []stmtctx.TableEntry
+	hits       int64
+	misses     int64
+}
+
+// ParameterizedStmt is a text protocol statement whose literals are replaced by parameter markers
+// to look up the general plan cache.
+type ParameterizedStmt struct {
+	stmt     ast.StmtNode
+	paramSQL string
+	params   []types.Datum
+	paramTps []*types.FieldType
+	slots    []*ast.ExprNode
+	literals []ast.ExprNode
+	entry    *generalPlanCacheEntry
+}
+
+// GeneralPlanCacheable checks whether the plan of the text protocol statement can be cached by
+// the general plan cache. Only SELECT statements that pass the checks of the prepared plan cache
+// are supported currently.
+func GeneralPlanCacheable(sctx sessionctx.Context, node ast.Node, is infoschema.InfoSchema) bool {
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.SelectIntoOpt != nil {
+		return false
+	}
+	sessVars := sctx.GetSessionVars()
+	if sessVars.InRestrictedSQL || sessVars.StmtCtx.IsStaleness || sctx.PreparedPlanCache() == nil {
+		return false
+	}
+	// The noop functions are checked when building the plan, so the plans built with
+	// them enabled or warned can not be reused after they are disabled.
+	if sessVars.NoopFuncsMode != variable.OffInt {
+		return false
+	}
+	checker := &generalCacheableChecker{cacheable: true}
+	node.Accept(checker)
+	return checker.cacheable && CacheableWithCtx(sctx, node, is)
+}
+
+// generalCacheableChecker checks the parts of a text protocol statement which
+// can not be reused by the general plan cache, such as parameter markers and cached tables.
+// NULL literals are rejected too, since the conditions on them are folded into empty ranges
+// which can not be rebuilt from the access conditions.
+type generalCacheableChecker struct {
+	cacheable bool
+}
+
+// Enter implements Visitor interface.
+func (checker *generalCacheableChecker) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch node := in.(type) {
+	case *driver.ParamMarkerExpr, *ast.AsOfClause:
+		checker.cacheable = false
+		return in, true
+	case *driver.ValueExpr:
+		if node.Kind() == types.KindNull {
+			checker.cacheable = false
+			return in, true
+		}
+	case *ast.TableName:
+		// The partitions read by the plan may be pruned by the literals, so the partitioned tables are not supported.
+		if tblInfo := node.TableInfo; tblInfo != nil && (tblInfo.TableCacheStatusType == model.TableCacheStatusEnable ||
+			tblInfo.GetPartitionInfo() != nil || hasYearColumn(tblInfo)) {
+			checker.cacheable = false
+			return in, true
+		}
+	}
+	return in, false
+}
+
+// hasYearColumn checks whether the table has a YEAR column. The integer constants compared with
+// YEAR columns are converted to years only when they are not parameters, e.g. 69 is 2069.
+func hasYearColumn(tblInfo *model.TableInfo) bool {
+	for _, col := range tblInfo.Columns {
+		if col.Tp == mysql.TypeYear {
+			return true
+		}
+	}
+	return false
+}
+
+// Leave implements Visitor interface.
+func (checker *generalCacheableChecker) Leave(in ast.Node) (out ast.Node, ok bool) {
+	return in, checker.cacheable
+}
+
+// paramReplacer replaces the literals compared with columns or expressions by parameter markers.
+// The select fields, the ordering and the aggregate and window functions are kept unchanged since
+// their literals may be part of the output column names or need to be constant when building the plan.
+type paramReplacer struct {
+	ps     *ParameterizedStmt
+	fields []string
+}
+
+// Enter implements Visitor interface.
+func (pr *paramReplacer) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
+	switch node := in.(type) {
+	case *ast.SelectField:
+		pr.fields = append(pr.fields, node.Text())
+		return in, true
+	case *ast.ByItem, *ast.Limit, *ast.WindowSpec, *ast.AggregateFuncExpr, *ast.WindowFuncExpr,
+		*ast.MatchAgainst, *ast.JSONTable:
+		return in, true
+	case *ast.BinaryOperationExpr:
+		switch node.Op {
+		case opcode.EQ, opcode.NE, opcode.LT, opcode.LE, opcode.GT, opcode.GE, opcode.NullEQ:
+			// Keep the comparisons between literals such as `1 = 1` unchanged.
+			_, lIsLiteral := node.L.(*driver.ValueExpr)
+			_, rIsLiteral := node.R.(*driver.ValueExpr)
+			if lIsLiteral != rIsLiteral {
+				pr.replace(&node.L)
+				pr.replace(&node.R)
+			}
+		}
+	case *ast.PatternInExpr:
+		for i := range node.List {
+			pr.replace(&node.List[i])
+		}
+	case *ast.BetweenExpr:
+		pr.replace(&node.Left)
+		pr.replace(&node.Right)
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (pr *paramReplacer) Leave(in ast.Node) (out ast.Node, ok bool) {
+	return in, true
+}
+
+// replace replaces the literal in the slot by a parameter marker if the parameter has the
+// same type as the literal, so the plan built for it has the same semantic.
+func (pr *paramReplacer) replace(slot *ast.ExprNode) {
+	literal, ok := (*slot).(*driver.ValueExpr)
+	if !ok {
+		return
+	}
+	switch literal.Kind() {
+	case types.KindInt64, types.KindFloat64, types.KindMysqlDecimal, types.KindString:
+	default:
+		return
+	}
+	tp := types.NewFieldType(mysql.TypeUnspecified)
+	types.DefaultParamTypeForValue(literal.GetValue(), tp)
+	if tp.Tp != literal.Type.Tp || tp.Charset != literal.Type.Charset || tp.Collate != literal.Type.Collate ||
+		mysql.HasIsBooleanFlag(literal.Type.Flag) || mysql.HasUnsignedFlag(tp.Flag) != mysql.HasUnsignedFlag(literal.Type.Flag) {
+		return
+	}
+	ps := pr.ps
+	marker := &driver.ParamMarkerExpr{
+		ValueExpr: driver.ValueExpr{Datum: *literal.Datum.Clone()},
+		Order:     len(ps.params),
+		InExecute: true,
+	}
+	marker.Type = *tp
+	ps.params = append(ps.params, literal.Datum)
+	ps.paramTps = append(ps.paramTps, tp)
+	ps.slots = append(ps.slots, slot)
+	ps.literals = append(ps.literals, literal)
+	*slot = marker
+}
+
+// ParameterizeAST replaces the literals of the text protocol statement by parameter markers in place.
+// The statement must be restored by RestoreAST after the plan is got.
+func ParameterizeAST(stmt ast.StmtNode) (*ParameterizedStmt, error) {
+	ps := &ParameterizedStmt{stmt: stmt}
+	replacer := &paramReplacer{ps: ps}
+	stmt.Accept(replacer)
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		ps.RestoreAST()
+		return nil, errors.Trace(err)
+	}
+	// The output column names come from the original text of the select fields,
+	// so they are part of the statement in the cache too.
+	for _, field := range replacer.fields {
+		sb.WriteByte(0)
+		sb.WriteString(field)
+	}
+	ps.paramSQL = sb.String()
+	return ps, nil
+}
+
+// RestoreAST puts the literals replaced by ParameterizeAST back into the statement.
+func (ps *ParameterizedStmt) RestoreAST() {
+	for i, slot := range ps.slots {
+		*slot = ps.literals[i]
+	}
+}
+
+// GetPlanFromGeneralPlanCache tries to get the plan of the parameterized statement from the general plan cache.
+// The parameters are set as the prepared parameters of the session, so the cached plan and the plan built for
+// the statement later refer to them.
+func GetPlanFromGeneralPlanCache(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, ps *ParameterizedStmt) (Plan, types.NameSlice, bool, error) {
+	sessVars := sctx.GetSessionVars()
+	stmtCtx := sessVars.StmtCtx
+	sessVars.PreparedParams = append(sessVars.PreparedParams[:0], ps.params...)
+	stmtCtx.UseCache = true
+	stmtCtx.UseGeneralPlanCache = true
+
+	cacheKey := NewGeneralPlanCacheKey(sessVars, ps.paramSQL, is.SchemaMetaVersion())
+	if cacheValue, exists := sctx.PreparedPlanCache().Get(cacheKey); exists {
+		ps.entry = cacheValue.(*generalPlanCacheEntry)
+	} else {
+		entry, err := newGeneralPlanCacheEntry(ctx, sctx, is, ps.stmt)
+		if err != nil {
+			return nil, nil, false, err
+		}
+		sctx.PreparedPlanCache().Put(cacheKey, entry)
+		ps.entry = entry
+	}
+
+	entry := ps.entry
+	for _, cachedVal := range entry.plans {
+		if !cachedVal.UserVarTypes.Equal(ps.paramTps) {
+			continue
+		}
+		for tblInfo, unionScan := range cachedVal.TblInfo2UnionScan {
+			if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
+				return nil, nil, false, nil
+			}
+		}
+		if err := checkGeneralPlanCachePriv(sctx, is, ps.stmt, entry.visitInfos); err != nil {
+			return nil, nil, false, err
+		}
+		if err := (&Execute{}).rebuildRange(cachedVal.Plan); err != nil {
+			logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
+			return nil, nil, false, nil
+		}
+		if err := sessVars.SetSystemVar(variable.TiDBFoundInPlanCache, variable.On); err != nil {
+			return nil, nil, false, err
+		}
+		generalPlanCacheCounter.Inc()
+		entry.hits++
+		stmtCtx.Tables = entry.tables
+		appendGeneralPlanCacheNote(stmtCtx, entry, true)
+		return cachedVal.Plan, cachedVal.OutPutNames, true, nil
+	}
+	return nil, nil, false, nil
+}
+
+// PutPlanIntoGeneralPlanCache caches the plan built for the parameterized statement
+// after it is missed in the general plan cache.
+func PutPlanIntoGeneralPlanCache(sctx sessionctx.Context, ps *ParameterizedStmt, p Plan, names types.NameSlice) {
+	stmtCtx := sctx.GetSessionVars().StmtCtx
+	entry := ps.entry
+	entry.misses++
+	appendGeneralPlanCacheNote(stmtCtx, entry, false)
+	if _, isTableDual := p.(*PhysicalTableDual); isTableDual || stmtCtx.MaybeOverOptimized4PlanCache {
+		return
+	}
+	cached := NewPSTMTPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, ps.paramTps)
+	for i, cachedVal := range entry.plans {
+		if cachedVal.UserVarTypes.Equal(ps.paramTps) {
+			entry.plans[i] = cached
+			return
+		}
+	}
+	entry.plans = append(entry.plans, cached)
+}
+
+// newGeneralPlanCacheEntry builds the logical plan of the parameterized statement to
+// collect the information used to check the privileges when the cached plan is reused.
+func newGeneralPlanCacheEntry(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, stmt ast.StmtNode) (*generalPlanCacheEntry, error) {
+	stmtCtx := sctx.GetSessionVars().StmtCtx
+	// The warnings are generated again when the statement is optimized.
+	warnings := stmtCtx.GetWarnings()
+	defer stmtCtx.SetWarnings(warnings)
+	builder, _ := NewPlanBuilder().Init(sctx, is, &hint.BlockHintProcessor{})
+	if _, err := builder.Build(ctx, stmt); err != nil {
+		return nil, err
+	}
+	return &generalPlanCacheEntry{
+		visitInfos: builder.GetVisitInfo(),
+		tables:     builder.GetDBTableInfo(),
+	}, nil
+}
+
+func checkGeneralPlanCachePriv(sctx sessionctx.Context, is infoschema.InfoSchema, stmt ast.StmtNode, visitInfos []visitInfo) error {
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil {
+		if err := CheckPrivilege(sctx.GetSessionVars().ActiveRoles, pm, VisitInfo4PrivCheck(is, stmt, visitInfos)); err != nil {
+			return err
+		}
+	}
+	return CheckTableLock(sctx, is, visitInfos)
+}
+
+// appendGeneralPlanCacheNote shows whether the plan of the explained statement is from
+// the general plan cache, and how many times the cached statement is hit and missed.
+func appendGeneralPlanCacheNote(stmtCtx *stmtctx.StatementContext, entry *generalPlanCacheEntry, hit bool) {
+	if !stmtCtx.InExplainStmt {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	stmtCtx.AppendNote(errors.Errorf("general plan cache %s, hits: %d, misses: %d", result, entry.hits, entry.misses))
+}
@@ -24,21 +24,27 @@ import (
 
 // GenHintsFromPhysicalPlan generates hints from physical plan.
 func GenHintsFromPhysicalPlan(p Plan) []*ast.TableOptimizerHint {
-	var hints []*ast.TableOptimizerHint
+	var (
+		plan     PhysicalPlan
+		nodeType utilhint.NodeType
+	)
 	switch pp := p.(type) {
 	case *Explain:
 		return GenHintsFromPhysicalPlan(pp.TargetPlan)
 	case *Update:
-		hints = genHintsFromPhysicalPlan(pp.SelectPlan, utilhint.TypeUpdate)
+		plan, nodeType = pp.SelectPlan, utilhint.TypeUpdate
 	case *Delete:
-		hints = genHintsFromPhysicalPlan(pp.SelectPlan, utilhint.TypeDelete)
+		plan, nodeType = pp.SelectPlan, utilhint.TypeDelete
 	// For Insert, we only generate hints that would be used in select query block.
 	case *Insert:
-		hints = genHintsFromPhysicalPlan(pp.SelectPlan, utilhint.TypeSelect)
+		plan, nodeType = pp.SelectPlan, utilhint.TypeSelect
 	case PhysicalPlan:
-		hints = genHintsFromPhysicalPlan(pp, utilhint.TypeSelect)
+		plan, nodeType = pp, utilhint.TypeSelect
+	default:
+		return nil
 	}
-	return hints
+	hints := genHintsFromPhysicalPlan(plan, nodeType)
+	return append(hints, genLeadingHintsFromPhysicalPlan(plan, nodeType)...)
 }
 
 func getTableName(tblName model.CIStr, asName *model.CIStr) model.CIStr {
@@ -80,28 +86,38 @@ func extractTableAsName(p PhysicalPlan) (*model.CIStr, *model.CIStr) {
 	return nil, nil
 }
 
+// extractJoinHintTable returns the table name of the join child used in the join hints, and the
+// select block offset of the table. It returns nil if the child can't be specified in the hints.
+func extractJoinHintTable(sctx sessionctx.Context, parentOffset int, child PhysicalPlan) (*ast.HintTable, int) {
+	blockOffset := child.SelectBlockOffset()
+	if blockOffset == -1 {
+		return nil, -1
+	}
+	var dbName, tableName *model.CIStr
+	if blockOffset != parentOffset {
+		blockAsNames := sctx.GetSessionVars().PlannerSelectBlockAsName
+		if blockOffset >= len(blockAsNames) {
+			return nil, -1
+		}
+		hintTable := blockAsNames[blockOffset]
+		// For sub-queries like `(select * from t) t1`, t1 should belong to its surrounding select block.
+		dbName, tableName, blockOffset = &hintTable.DBName, &hintTable.TableName, parentOffset
+	} else {
+		dbName, tableName = extractTableAsName(child)
+	}
+	if tableName == nil || tableName.L == "" {
+		return nil, -1
+	}
+	return &ast.HintTable{DBName: *dbName, TableName: *tableName}, blockOffset
+}
+
 func getJoinHints(sctx sessionctx.Context, joinType string, parentOffset int, nodeType utilhint.NodeType, children ...PhysicalPlan) (res []*ast.TableOptimizerHint) {
 	if parentOffset == -1 {
 		return res
 	}
 	for _, child := range children {
-		blockOffset := child.SelectBlockOffset()
-		if blockOffset == -1 {
-			continue
-		}
-		var dbName, tableName *model.CIStr
-		if blockOffset != parentOffset {
-			blockAsNames := sctx.GetSessionVars().PlannerSelectBlockAsName
-			if blockOffset >= len(blockAsNames) {
-				continue
-			}
-			hintTable := blockAsNames[blockOffset]
-			// For sub-queries like `(select * from t) t1`, t1 should belong to its surrounding select block.
-			dbName, tableName, blockOffset = &hintTable.DBName, &hintTable.TableName, parentOffset
-		} else {
-			dbName, tableName = extractTableAsName(child)
-		}
-		if tableName == nil || tableName.L == "" {
+		hintTable, blockOffset := extractJoinHintTable(sctx, parentOffset, child)
+		if hintTable == nil {
 			continue
 		}
 		qbName, err := utilhint.GenerateQBName(nodeType, blockOffset)
@@ -111,13 +127,92 @@ func getJoinHints(sctx sessionctx.Context, joinType string, parentOffset int, no
 		res = append(res, &ast.TableOptimizerHint{
 			QBName:   qbName,
 			HintName: model.NewCIStr(joinType),
-			Tables:   []ast.HintTable{{DBName: *dbName, TableName: *tableName}},
+			Tables:   []ast.HintTable{*hintTable},
 		})
 		break
 	}
 	return res
 }
 
+// isLeadingJoin checks whether the plan is an inner join in the select block, which can be specified
+// by the LEADING hint.
+func isLeadingJoin(p PhysicalPlan, blockOffset int) bool {
+	if p.SelectBlockOffset() != blockOffset {
+		return false
+	}
+	switch x := p.(type) {
+	case *PhysicalHashJoin:
+		return x.JoinType == InnerJoin
+	case *PhysicalMergeJoin:
+		return x.JoinType == InnerJoin
+	case *PhysicalIndexJoin:
+		return x.JoinType == InnerJoin
+	case *PhysicalIndexMergeJoin:
+		return x.JoinType == InnerJoin
+	case *PhysicalIndexHashJoin:
+		return x.JoinType == InnerJoin
+	}
+	return false
+}
+
+// genLeadingHintsFromPhysicalPlan generates the LEADING hints for the inner join trees in the plan, so that
+// the join orders can be kept by the bindings. The left-deep part of a join tree is flattened and the joins
+// on the right side are nested, e.g. `Join(Join(t1, t2), Join(t3, t4))` generates `LEADING(t1, t2, (t3, t4))`.
+// Only the join trees with more than two tables generate the hints.
+func genLeadingHintsFromPhysicalPlan(p PhysicalPlan, nodeType utilhint.NodeType) (res []*ast.TableOptimizerHint) {
+	if p == nil {
+		return res
+	}
+	blockOffset := p.SelectBlockOffset()
+	if blockOffset == -1 || !isLeadingJoin(p, blockOffset) {
+		for _, child := range p.Children() {
+			res = append(res, genLeadingHintsFromPhysicalPlan(child, nodeType)...)
+		}
+		if phCte, ok := p.(*PhysicalCTE); ok {
+			res = append(res, genLeadingHintsFromPhysicalPlan(phCte.CTE.seedPartPhysicalPlan, nodeType)...)
+			res = append(res, genLeadingHintsFromPhysicalPlan(phCte.CTE.recursivePartPhysicalPlan, nodeType)...)
+		}
+		return res
+	}
+	var leaves []PhysicalPlan
+	valid := true
+	var collect func(join PhysicalPlan) *ast.LeadingList
+	collect = func(join PhysicalPlan) *ast.LeadingList {
+		list := &ast.LeadingList{}
+		for i, child := range join.Children() {
+			if isLeadingJoin(child, blockOffset) {
+				if i == 0 {
+					list.Items = append(list.Items, collect(child).Items...)
+				} else {
+					list.Items = append(list.Items, collect(child))
+				}
+				continue
+			}
+			leaves = append(leaves, child)
+			hintTable, _ := extractJoinHintTable(p.SCtx(), blockOffset, child)
+			if hintTable == nil {
+				valid = false
+				continue
+			}
+			list.Items = append(list.Items, hintTable)
+		}
+		return list
+	}
+	list := collect(p)
+	if qbName, err := utilhint.GenerateQBName(nodeType, blockOffset); err == nil && valid && len(leaves) > 2 {
+		res = append(res, &ast.TableOptimizerHint{
+			QBName:   qbName,
+			HintName: model.NewCIStr(HintLeading),
+			Tables:   list.Tables(),
+			HintData: list,
+		})
+	}
+	for _, leaf := range leaves {
+		res = append(res, genLeadingHintsFromPhysicalPlan(leaf, nodeType)...)
+	}
+	return res
+}
+
 func genHintsFromPhysicalPlan(p PhysicalPlan, nodeType utilhint.NodeType) (res []*ast.TableOptimizerHint) {
 	if p == nil {
 		return res
@@ -182,6 +182,19 @@ func (p PhysicalTableDual) Init(ctx sessionctx.Context, stats *property.StatsInf
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx sessionctx.Context, offset int) *LogicalJSONTable {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.stats = stats
+	return &p
+}
+
 // Init initializes LogicalMaxOneRow.
 func (p LogicalMaxOneRow) Init(ctx sessionctx.Context, offset int) *LogicalMaxOneRow {
 	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeMaxOneRow, &p, offset)
@@ -446,6 +459,27 @@ func (p PhysicalTableSample) Init(ctx sessionctx.Context, offset int) *PhysicalT
 	return &p
 }
 
+// Init initializes PhysicalFullTextIndexLookUp.
+func (p PhysicalFullTextIndexLookUp) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalFullTextIndexLookUp {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeFullTextIndexLookUp, &p, offset)
+	p.stats = stats
+	return &p
+}
+
+// Init initializes PhysicalSpatialIndexLookUp.
+func (p PhysicalSpatialIndexLookUp) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalSpatialIndexLookUp {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeSpatialIndexLookUp, &p, offset)
+	p.stats = stats
+	return &p
+}
+
+// Init initializes PhysicalMVIndexLookUp.
+func (p PhysicalMVIndexLookUp) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalMVIndexLookUp {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeMVIndexLookUp, &p, offset)
+	p.stats = stats
+	return &p
+}
+
 // Init initializes PhysicalIndexReader.
 func (p PhysicalIndexReader) Init(ctx sessionctx.Context, offset int) *PhysicalIndexReader {
 	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeIndexReader, &p, offset)
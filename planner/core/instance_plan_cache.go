@@ -0,0 +1,445 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"math"
+	"sync"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+	"github.com/pingcap/tidb/util/kvcache"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
+	atomic2 "go.uber.org/atomic"
+	"go.uber.org/zap"
+)
+
+var instancePlanCacheCounter = metrics.PlanCacheCounter.WithLabelValues("instance")
+
+// GlobalInstancePlanCache is the prepared plan cache shared by all the sessions of this instance.
+var GlobalInstancePlanCache = NewInstancePlanCache()
+
+const (
+	// instancePlanCacheOperatorSize is the estimated memory usage of a physical operator, excluding
+	// the expressions and ranges which are counted by the length of its explain info.
+	instancePlanCacheOperatorSize = 1024
+	// instancePlanCacheEntrySize is the estimated memory usage of the key and the value of an entry.
+	instancePlanCacheEntrySize = 256
+)
+
+// instancePlanCacheKey is the key of a prepared statement in the instance plan cache. Unlike
+// pstmtPlanCacheKey, it doesn't contain the connection and statement IDs, so the sessions which
+// prepare the same statement share the plan. The bindings and the optimizer variables used to
+// build the plan are contained, so the plan isn't reused by the sessions which would build a
+// different one.
+type instancePlanCacheKey struct {
+	pstmtPlanCacheKey
+	sqlDigest     string
+	stmtText      string
+	paramTypes    []*types.FieldType
+	bindSQL       string
+	optimizerVars []string
+}
+
+// Hash implements Key interface.
+func (key *instancePlanCacheKey) Hash() []byte {
+	if len(key.hash) == 0 {
+		key.pstmtPlanCacheKey.Hash()
+		key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(key.sqlDigest))
+		key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(key.stmtText))
+		for _, tp := range key.paramTypes {
+			// Keep the same with FieldSlice.Equal, VARCHAR and VAR_STRING are regarded as the same type,
+			// and the unsigned flag only matters for the integers.
+			tpCode := tp.Tp
+			if tpCode == mysql.TypeVarString {
+				tpCode = mysql.TypeVarchar
+			}
+			unsigned := byte(0)
+			if tp.EvalType() == types.ETInt && mysql.HasUnsignedFlag(tp.Flag) {
+				unsigned = 1
+			}
+			key.hash = append(key.hash, tpCode, unsigned)
+			key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(tp.Charset))
+			key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(tp.Collate))
+		}
+		key.hash = codec.EncodeCompactBytes(key.hash, hack.Slice(key.bindSQL))
+		key.hash = encodeOptimizerVars(key.hash, key.optimizerVars)
+	}
+	return key.hash
+}
+
+// NewInstancePlanCacheKey creates a new instancePlanCacheKey object, bindSQL is the SQL of the
+// bindings which the plan is built from.
+func NewInstancePlanCacheKey(sessionVars *variable.SessionVars, preparedStmt *CachedPrepareStmt, paramTypes []*types.FieldType, bindSQL string) kvcache.Key {
+	prepared := preparedStmt.PreparedAst
+	key := NewPSTMTPlanCacheKey(sessionVars, 0, prepared.SchemaVersion).(*pstmtPlanCacheKey)
+	key.connID = 0
+	var sqlDigest string
+	if preparedStmt.SQLDigest != nil {
+		sqlDigest = preparedStmt.SQLDigest.String()
+	}
+	return &instancePlanCacheKey{
+		pstmtPlanCacheKey: *key,
+		sqlDigest:         sqlDigest,
+		stmtText:          prepared.Stmt.Text(),
+		paramTypes:        paramTypes,
+		bindSQL:           bindSQL,
+		optimizerVars:     getOptimizerVars(sessionVars),
+	}
+}
+
+// instancePlanCacheValue stores a template plan in the instance plan cache. The template is never
+// executed, each session which reuses it executes a clone bound to the session.
+type instancePlanCacheValue struct {
+	plan              PhysicalPlan
+	outputNames       types.NameSlice
+	tblInfo2UnionScan map[*model.TableInfo]bool
+	normalizedPlan    string
+	planDigest        *parser.Digest
+	memUsage          int64
+}
+
+// instantiate clones the template plan and binds the clone to sctx. It returns false if the plan
+// can not be shared between the sessions.
+func (v *instancePlanCacheValue) instantiate(sctx sessionctx.Context) (PhysicalPlan, bool) {
+	plan, err := v.plan.Clone()
+	if err != nil {
+		return nil, false
+	}
+	if !bindPlanToSession(sctx, plan) {
+		return nil, false
+	}
+	return plan, true
+}
+
+// InstancePlanCacheStats is the statistics of the instance plan cache.
+type InstancePlanCacheStats struct {
+	EntryCount    int64
+	MemoryUsage   int64
+	MemoryQuota   int64
+	HitCount      int64
+	MissCount     int64
+	EvictionCount int64
+	EvictedMemory int64
+}
+
+// InstancePlanCache is a concurrency-safe LRU cache of the prepared plans shared by all the sessions
+// of the instance. It's bounded by the memory quota `tidb_instance_plan_cache_max_mem_size`.
+type InstancePlanCache struct {
+	mu struct {
+		sync.Mutex
+		lru *kvcache.SimpleLRUCache
+	}
+	memTracker *memory.Tracker
+
+	hits          atomic2.Int64
+	misses        atomic2.Int64
+	evictions     atomic2.Int64
+	evictedMemory atomic2.Int64
+}
+
+// NewInstancePlanCache creates an InstancePlanCache.
+func NewInstancePlanCache() *InstancePlanCache {
+	c := &InstancePlanCache{
+		memTracker: memory.NewTracker(memory.LabelForInstancePlanCache, -1),
+	}
+	// The number of the entries is not limited, they are evicted by the memory usage.
+	c.mu.lru = kvcache.NewSimpleLRUCache(math.MaxUint32, 0, 0)
+	return c
+}
+
+// MemTracker returns the memory tracker of the cache.
+func (c *InstancePlanCache) MemTracker() *memory.Tracker {
+	return c.memTracker
+}
+
+func (c *InstancePlanCache) get(key kvcache.Key) (*instancePlanCacheValue, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, exists := c.mu.lru.Get(key)
+	if !exists {
+		return nil, false
+	}
+	return value.(*instancePlanCacheValue), true
+}
+
+// put stores the value into the cache and evicts the least recently used entries until the memory
+// usage fits in the quota. A value exceeding the quota by itself is not stored.
+func (c *InstancePlanCache) put(key kvcache.Key, value *instancePlanCacheValue) {
+	quota := variable.InstancePlanCacheMaxMemSize.Load()
+	if value.memUsage > quota {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if old, exists := c.mu.lru.Get(key); exists {
+		c.mu.lru.Delete(key)
+		c.memTracker.Consume(-old.(*instancePlanCacheValue).memUsage)
+	}
+	c.mu.lru.Put(key, value)
+	c.memTracker.Consume(value.memUsage)
+	for c.memTracker.BytesConsumed() > quota {
+		_, evicted, ok := c.mu.lru.RemoveOldest()
+		if !ok {
+			break
+		}
+		memUsage := evicted.(*instancePlanCacheValue).memUsage
+		c.memTracker.Consume(-memUsage)
+		c.evictions.Inc()
+		c.evictedMemory.Add(memUsage)
+	}
+}
+
+// Stats returns the statistics of the cache.
+func (c *InstancePlanCache) Stats() InstancePlanCacheStats {
+	c.mu.Lock()
+	entryCount := int64(c.mu.lru.Size())
+	c.mu.Unlock()
+	return InstancePlanCacheStats{
+		EntryCount:    entryCount,
+		MemoryUsage:   c.memTracker.BytesConsumed(),
+		MemoryQuota:   variable.InstancePlanCacheMaxMemSize.Load(),
+		HitCount:      c.hits.Load(),
+		MissCount:     c.misses.Load(),
+		EvictionCount: c.evictions.Load(),
+		EvictedMemory: c.evictedMemory.Load(),
+	}
+}
+
+// Reset removes all the entries and clears the statistics.
+func (c *InstancePlanCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.mu.lru.DeleteAll()
+	c.memTracker.Consume(-c.memTracker.BytesConsumed())
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.evictions.Store(0)
+	c.evictedMemory.Store(0)
+}
+
+// getPlanFromInstancePlanCache looks up the instance plan cache and sets e.Plan to the plan
+// bound to sctx if the lookup hits.
+func (e *Execute) getPlanFromInstancePlanCache(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema,
+	preparedStmt *CachedPrepareStmt, cacheKey kvcache.Key) (bool, error) {
+	cache := GlobalInstancePlanCache
+	cachedVal, exists := cache.get(cacheKey)
+	if !exists {
+		cache.misses.Inc()
+		return false, nil
+	}
+	if err := e.checkPreparedPriv(ctx, sctx, preparedStmt, is); err != nil {
+		return false, err
+	}
+	for tblInfo, unionScan := range cachedVal.tblInfo2UnionScan {
+		// The entry is still valid for the other sessions, so it's kept in the cache.
+		if !unionScan && tableHasDirtyContent(sctx, tblInfo) {
+			cache.misses.Inc()
+			return false, nil
+		}
+	}
+	plan, ok := cachedVal.instantiate(sctx)
+	if !ok {
+		cache.misses.Inc()
+		return false, nil
+	}
+	if err := e.rebuildRange(plan); err != nil {
+		logutil.BgLogger().Debug("rebuild range failed", zap.Error(err))
+		cache.misses.Inc()
+		return false, nil
+	}
+	cache.hits.Inc()
+	if err := e.setFoundInPlanCache(sctx, true); err != nil {
+		return false, err
+	}
+	if metrics.ResettablePlanCacheCounterFortTest {
+		metrics.PlanCacheCounter.WithLabelValues("instance").Inc()
+	} else {
+		instancePlanCacheCounter.Inc()
+	}
+	e.names = cachedVal.outputNames
+	e.Plan = plan
+	preparedStmt.NormalizedPlan, preparedStmt.PlanDigest = cachedVal.normalizedPlan, cachedVal.planDigest
+	sctx.GetSessionVars().StmtCtx.SetPlanDigest(cachedVal.normalizedPlan, cachedVal.planDigest)
+	return true, nil
+}
+
+// putPlanIntoInstancePlanCache stores a template cloned from p into the instance plan cache.
+// It returns false if p can not be shared between the sessions.
+func putPlanIntoInstancePlanCache(sctx sessionctx.Context, cacheKey kvcache.Key, p Plan, names types.NameSlice,
+	preparedStmt *CachedPrepareStmt) bool {
+	physicalPlan, ok := p.(PhysicalPlan)
+	if !ok {
+		return false
+	}
+	template, err := physicalPlan.Clone()
+	if err != nil {
+		return false
+	}
+	// Binding the template to the session which builds it checks whether the plan can be shared.
+	if !bindPlanToSession(sctx, template) {
+		return false
+	}
+	tblInfo2UnionScan := make(map[*model.TableInfo]bool)
+	for k, v := range sctx.GetSessionVars().StmtCtx.TblInfo2UnionScan {
+		tblInfo2UnionScan[k] = v
+	}
+	value := &instancePlanCacheValue{
+		plan:              template,
+		outputNames:       names,
+		tblInfo2UnionScan: tblInfo2UnionScan,
+		normalizedPlan:    preparedStmt.NormalizedPlan,
+		planDigest:        preparedStmt.PlanDigest,
+	}
+	value.memUsage = instancePlanCacheEntrySize + int64(len(cacheKey.Hash())+len(value.normalizedPlan)) +
+		planMemUsage(template)
+	GlobalInstancePlanCache.put(cacheKey, value)
+	return true
+}
+
+// planMemUsage estimates the memory usage of a physical plan.
+func planMemUsage(p PhysicalPlan) int64 {
+	size := int64(instancePlanCacheOperatorSize + len(p.ExplainInfo()))
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		size += planMemUsage(x.tablePlan)
+	case *PhysicalIndexReader:
+		size += planMemUsage(x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		size += planMemUsage(x.indexPlan) + planMemUsage(x.tablePlan)
+	}
+	for _, child := range p.Children() {
+		size += planMemUsage(child)
+	}
+	return size
+}
+
+// bindPlanToSession binds the operators and the expressions of a cloned plan to sctx. It returns
+// false if the plan contains some operators or expressions which can not be shared between the
+// sessions.
+func bindPlanToSession(sctx sessionctx.Context, p PhysicalPlan) bool {
+	ok := true
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		x.ctx = sctx
+		ok = bindPlanToSession(sctx, x.tablePlan)
+	case *PhysicalIndexReader:
+		x.ctx = sctx
+		ok = bindPlanToSession(sctx, x.indexPlan)
+	case *PhysicalIndexLookUpReader:
+		x.ctx = sctx
+		ok = bindPlanToSession(sctx, x.indexPlan) && bindPlanToSession(sctx, x.tablePlan)
+	case *PhysicalTableScan:
+		if !tableShareable(x.Table) {
+			return false
+		}
+		x.ctx = sctx
+		ok = expression.SetCtxForPlanCache(sctx, x.AccessCondition) &&
+			expression.SetCtxForPlanCache(sctx, x.filterCondition) &&
+			expression.SetCtxForPlanCache(sctx, expression.Column2Exprs(x.schema.Columns))
+	case *PhysicalIndexScan:
+		if !tableShareable(x.Table) {
+			return false
+		}
+		x.ctx = sctx
+		ok = expression.SetCtxForPlanCache(sctx, x.AccessCondition)
+	case *PhysicalSelection:
+		x.ctx = sctx
+		ok = expression.SetCtxForPlanCache(sctx, x.Conditions)
+	case *PhysicalProjection:
+		x.ctx = sctx
+		ok = expression.SetCtxForPlanCache(sctx, x.Exprs)
+	case *PhysicalTopN:
+		x.ctx = sctx
+		ok = bindByItemsToSession(sctx, x.ByItems)
+	case *PhysicalSort:
+		x.ctx = sctx
+		ok = bindByItemsToSession(sctx, x.ByItems)
+	case *PhysicalLimit:
+		x.ctx = sctx
+	case *PhysicalUnionAll:
+		x.ctx = sctx
+	case *PhysicalHashAgg:
+		x.ctx = sctx
+		ok = bindAggToSession(sctx, &x.basePhysicalAgg)
+	case *PhysicalStreamAgg:
+		x.ctx = sctx
+		ok = bindAggToSession(sctx, &x.basePhysicalAgg)
+	case *PhysicalHashJoin:
+		x.ctx = sctx
+		ok = bindJoinToSession(sctx, &x.basePhysicalJoin)
+		for _, cond := range x.EqualConditions {
+			ok = ok && expression.SetCtxForPlanCache(sctx, []expression.Expression{cond})
+		}
+	case *PhysicalMergeJoin:
+		x.ctx = sctx
+		ok = bindJoinToSession(sctx, &x.basePhysicalJoin)
+	default:
+		// PhysicalApply shares the correlated columns, and the other operators are not cloneable yet.
+		return false
+	}
+	if !ok {
+		return false
+	}
+	for _, child := range p.Children() {
+		if !bindPlanToSession(sctx, child) {
+			return false
+		}
+	}
+	return true
+}
+
+// tableShareable checks whether the plans reading tblInfo can be shared between the sessions. The local
+// temporary tables are visible only in the session which creates them, and the partition pruning
+// result is not kept by the cloned readers.
+func tableShareable(tblInfo *model.TableInfo) bool {
+	return tblInfo.TempTableType != model.TempTableLocal && tblInfo.GetPartitionInfo() == nil
+}
+
+func bindByItemsToSession(sctx sessionctx.Context, byItems []*util.ByItems) bool {
+	for _, item := range byItems {
+		if !expression.SetCtxForPlanCache(sctx, []expression.Expression{item.Expr}) {
+			return false
+		}
+	}
+	return true
+}
+
+func bindAggToSession(sctx sessionctx.Context, p *basePhysicalAgg) bool {
+	for _, aggFunc := range p.AggFuncs {
+		if !expression.SetCtxForPlanCache(sctx, aggFunc.Args) || !bindByItemsToSession(sctx, aggFunc.OrderByItems) {
+			return false
+		}
+	}
+	return expression.SetCtxForPlanCache(sctx, p.GroupByItems)
+}
+
+func bindJoinToSession(sctx sessionctx.Context, p *basePhysicalJoin) bool {
+	return expression.SetCtxForPlanCache(sctx, p.LeftConditions) &&
+		expression.SetCtxForPlanCache(sctx, p.RightConditions) &&
+		expression.SetCtxForPlanCache(sctx, p.OtherConditions)
+}
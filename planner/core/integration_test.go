@@ -3102,6 +3102,66 @@ func (s *testIntegrationSuite) TestCorrelatedAggregate(c *C) {
 		Check(testkit.Rows("6 6 6 6"))
 }
 
+func (s *testIntegrationSuite) TestDecorrelateLimitAndWindow(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3")
+	tk.MustExec("create table t1 (a int primary key, b int, c int)")
+	tk.MustExec("create table t2 (a int, b int, c int)")
+	tk.MustExec("create table t3 (a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1, 20), (2, 2, 2), (3, 3, null), (4, null, 4)")
+	tk.MustExec("insert into t2 values (1, 1, 10), (2, 1, 20), (3, 2, 30), (4, 2, 5), (5, 3, null), (6, null, 7)")
+	tk.MustExec("insert into t3 values (1, 1), (1, 1), (2, 2), (3, null)")
+
+	tk.MustQuery("select t1.a, (select t2.c from t2 where t2.b = t1.b order by t2.c desc limit 1) from t1 order by t1.a").
+		Check(testkit.Rows("1 20", "2 30", "3 <nil>", "4 <nil>"))
+	tk.MustQuery("select t1.a from t1 where t1.c in (select t2.c from t2 where t2.b = t1.b order by t2.c limit 1, 1) order by t1.a").
+		Check(testkit.Rows("1"))
+	tk.MustQuery("select t1.a from t1 where t1.c in (select max(t2.c) from t2 where t2.b = t1.b group by t2.a) order by t1.a").
+		Check(testkit.Rows("1"))
+	tk.MustQuery("select t1.a from t1 where t1.a in (select row_number() over (order by t2.c) from t2 where t2.b = t1.b) order by t1.a").
+		Check(testkit.Rows("1", "2"))
+	// The outer plan without unique key.
+	tk.MustQuery("select t3.a, (select t2.c from t2 where t2.b = t3.b order by t2.c limit 1) from t3 order by t3.a").
+		Check(testkit.Rows("1 10", "1 10", "2 5", "3 <nil>"))
+	tk.MustQuery("select t1.a from t1 where exists (select 1 from t2 join t3 on t2.a = t3.a and t3.b = t1.b) order by t1.a").
+		Check(testkit.Rows("1", "2"))
+}
+
+func (s *testIntegrationSuite) TestNoDecorrelateHint(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (a int primary key, b int, c int)")
+	tk.MustExec("create table t2 (a int, b int, c int)")
+	tk.MustExec("insert into t1 values (1, 1, 20), (2, 2, 2), (3, 3, null), (4, null, 4)")
+	tk.MustExec("insert into t2 values (1, 1, 10), (2, 1, 20), (3, 2, 30), (4, 2, 5), (5, 3, null), (6, null, 7)")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+		Warn []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		tk.Se.GetSessionVars().StmtCtx.SetWarnings(nil)
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+			output[i].Warn = s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+		c.Assert(s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings()), DeepEquals, output[i].Warn)
+	}
+
+	// The results are the same as the decorrelated plans.
+	tk.MustQuery("select t1.a, (select /*+ NO_DECORRELATE() */ t2.c from t2 where t2.b = t1.b order by t2.c desc limit 1) from t1 order by t1.a").
+		Check(testkit.Rows("1 20", "2 30", "3 <nil>", "4 <nil>"))
+	tk.MustQuery("select t1.a from t1 where t1.c in (select /*+ NO_DECORRELATE() */ max(t2.c) from t2 where t2.b = t1.b group by t2.a) order by t1.a").
+		Check(testkit.Rows("1"))
+}
+
 func (s *testIntegrationSuite) TestCorrelatedColumnAggFuncPushDown(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 	tk.MustExec("use test;")
@@ -3134,6 +3194,112 @@ func (s *testIntegrationSuite) TestConditionColPruneInPhysicalUnionScan(c *C) {
 		Check(testkit.Rows("0"))
 }
 
+func (s *testIntegrationSuite) TestLeadingHint(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3, t4")
+	for i := 1; i <= 4; i++ {
+		tk.MustExec(fmt.Sprintf("create table t%d(a int, b int, key(a))", i))
+	}
+	// Use the greedy join reorder algorithm.
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 0")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+		Warn []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+			output[i].Warn = s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+		c.Assert(s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings()), DeepEquals, output[i].Warn)
+	}
+}
+
+func (s *testIntegrationSuite) TestLeadingHintWithDP(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3, t4")
+	for i := 1; i <= 4; i++ {
+		tk.MustExec(fmt.Sprintf("create table t%d(a int, b int, key(a))", i))
+	}
+	// Use the DP join reorder algorithm.
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 10")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+		Warn []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+			output[i].Warn = s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+		c.Assert(s.testData.ConvertSQLWarnToStrings(tk.Se.GetSessionVars().StmtCtx.GetWarnings()), DeepEquals, output[i].Warn)
+	}
+}
+
+func (s *testIntegrationSuite) TestOuterJoinReorder(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3, t4")
+	for i := 1; i <= 4; i++ {
+		tk.MustExec(fmt.Sprintf("create table t%d(a int, b int, key(a))", i))
+	}
+	// Use the greedy join reorder algorithm.
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 0")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+}
+
+func (s *testIntegrationSuite) TestOuterJoinReorderWithDP(c *C) {
+	tk := testkit.NewTestKit(c, s.store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2, t3, t4")
+	for i := 1; i <= 4; i++ {
+		tk.MustExec(fmt.Sprintf("create table t%d(a int, b int, key(a))", i))
+	}
+	// Use the DP join reorder algorithm.
+	tk.MustExec("set @@tidb_opt_join_reorder_threshold = 10")
+
+	var input []string
+	var output []struct {
+		SQL  string
+		Plan []string
+	}
+	s.testData.GetTestCases(c, &input, &output)
+	for i, tt := range input {
+		s.testData.OnRecord(func() {
+			output[i].SQL = tt
+			output[i].Plan = s.testData.ConvertRowsToStrings(tk.MustQuery(tt).Rows())
+		})
+		tk.MustQuery(tt).Check(testkit.Rows(output[i].Plan...))
+	}
+}
+
 func (s *testIntegrationSuite) TestInvalidHint(c *C) {
 	tk := testkit.NewTestKit(c, s.store)
 
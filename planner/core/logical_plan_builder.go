@@ -37,6 +37,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -52,6 +53,7 @@ import (
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/table/temptable"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	driver "github.com/pingcap/tidb/types/parser_driver"
 	util2 "github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
@@ -112,6 +114,10 @@ const (
 	HintIgnorePlanCache = "ignore_plan_cache"
 	// HintLimitToCop is a hint enforce pushing limit or topn to coprocessor.
 	HintLimitToCop = "limit_to_cop"
+	// HintLeading specifies the set of tables to be used as the prefix in the execution plan.
+	HintLeading = "leading"
+	// HintNoDecorrelate indicates that the subquery is not decorrelated, it's executed for each outer row.
+	HintNoDecorrelate = "no_decorrelate"
 )
 
 const (
@@ -340,6 +346,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, &x.AsName)
+			isTableName = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -363,6 +372,10 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		// "select * from (select 1, 1) as a;" is duplicate
 		dupNames := make(map[string]struct{}, len(p.Schema().Columns))
 		for _, name := range p.OutputNames() {
+			// The hidden base table columns of the updatable views may have the same names as the view columns.
+			if name.NotExplicitUsable {
+				continue
+			}
 			colName := name.ColName.O
 			if _, ok := dupNames[colName]; ok {
 				return nil, ErrDupFieldName.GenWithStackByArgs(colName)
@@ -588,6 +601,7 @@ func (p *LogicalJoin) setPreferredJoinType(hintInfo *tableHintInfo) {
 	if hintInfo.ifPreferINLMJ(rhsAlias) {
 		p.preferJoinType |= preferRightAsINLMJInner
 	}
+	hintInfo.ifPreferLeading(lhsAlias, rhsAlias)
 	if containDifferentJoinTypes(p.preferJoinType) {
 		errMsg := "Join hints are conflict, you can only specify one type of join"
 		warning := ErrInternal.GenWithStack(errMsg)
@@ -595,7 +609,8 @@ func (p *LogicalJoin) setPreferredJoinType(hintInfo *tableHintInfo) {
 		p.preferJoinType = 0
 	}
 	// set hintInfo for further usage if this hint info can be used.
-	if p.preferJoinType != 0 {
+	// The LEADING hint is used by the join reorder rule, so the hintInfo is also kept for it.
+	if p.preferJoinType != 0 || hintInfo.leadingJoinOrder != nil {
 		p.hintInfo = hintInfo
 	}
 }
@@ -684,10 +699,25 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// JSON_TABLE may reference the columns of the tables preceding it, so the left side is
+	// visible to it as an outer schema and the join is built as an apply if it's referenced.
+	jsonTable, lateral := isJSONTableSource(joinNode.Right)
+	if lateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right)
+	if lateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	lateral = lateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
+	if lateral && joinNode.Tp == ast.RightJoin {
+		return nil, ErrTFForbiddenReference.GenWithStackByArgs(jsonTable.AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -775,7 +805,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			if lateral {
+				sel.SetChildren(b.buildLateralApply(joinPlan))
+			} else {
+				sel.SetChildren(joinPlan)
+			}
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -785,9 +819,34 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
+	if lateral {
+		return b.buildLateralApply(joinPlan), nil
+	}
 	return joinPlan, nil
 }
 
+// isJSONTableSource checks whether the node is a JSON_TABLE table function.
+func isJSONTableSource(node ast.ResultSetNode) (*ast.TableSource, bool) {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return nil, false
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ts, ok
+}
+
+// buildLateralApply converts the join to an apply, so the right side is evaluated for every row of
+// the left side which it references.
+func (b *PlanBuilder) buildLateralApply(join *LogicalJoin) LogicalPlan {
+	b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+	join.cartesianJoin = false
+	ap := &LogicalApply{LogicalJoin: *join}
+	ap.tp = plancodec.TypeApply
+	ap.self = ap
+	ap.CorCols = extractCorColumnsBySchema4LogicalPlan(ap.children[1], ap.children[0].Schema())
+	return ap
+}
+
 // buildUsingClause eliminate the redundant columns and ordering columns based
 // on the "USING" clause.
 //
@@ -3246,6 +3305,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		aggHints                                                                                              aggHintInfo
 		timeRangeHint                                                                                         ast.HintTimeRange
 		limitHints                                                                                            limitHintInfo
+		leadingHints                                                                                          []*ast.TableOptimizerHint
 	)
 	for _, hint := range hints {
 		// Set warning for the hint that requires the table name.
@@ -3350,10 +3410,30 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 			timeRangeHint = hint.HintData.(ast.HintTimeRange)
 		case HintLimitToCop:
 			limitHints.preferLimitToCop = true
+		case HintLeading:
+			leadingHints = append(leadingHints, hint)
+		case HintNoDecorrelate:
+			if b.subQueryBlockOffset == 0 || currentLevel != b.subQueryBlockOffset {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(
+					"NO_DECORRELATE() is inapplicable because it's not in an IN, EXISTS, ANY, ALL, SOME or scalar subquery"))
+				continue
+			}
+			b.subQueryNoDecorrelate = true
 		default:
 			// ignore hints that not implemented
 		}
 	}
+	var leadingJoinOrder *leadingHintInfo
+	if len(leadingHints) > 1 {
+		errMsg := "We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid"
+		b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+	} else if len(leadingHints) == 1 {
+		hint := leadingHints[0]
+		if tables := tableNames2HintTableInfo(b.ctx, hint.HintName.L, hint.Tables, b.hintProcessor, currentLevel); len(tables) > 0 {
+			list, _ := hint.HintData.(*ast.LeadingList)
+			leadingJoinOrder = newLeadingHintInfo(tables, list)
+		}
+	}
 	b.tableHintInfo = append(b.tableHintInfo, tableHintInfo{
 		sortMergeJoinTables:         sortMergeTables,
 		broadcastJoinTables:         BCTables,
@@ -3367,6 +3447,7 @@ func (b *PlanBuilder) pushTableHints(hints []*ast.TableOptimizerHint, currentLev
 		indexMergeHintList:          indexMergeHintList,
 		timeRangeHint:               timeRangeHint,
 		limitHints:                  limitHints,
+		leadingJoinOrder:            leadingJoinOrder,
 	})
 }
 
@@ -3389,6 +3470,7 @@ func (b *PlanBuilder) popTableHints() {
 	b.appendUnmatchedJoinHintWarning(HintBCJPreferLocal, "", hintInfo.broadcastJoinPreferredLocal)
 	b.appendUnmatchedJoinHintWarning(HintHJ, TiDBHashJoin, hintInfo.hashJoinTables)
 	b.appendUnmatchedStorageHintWarning(hintInfo.tiflashTables, hintInfo.tikvTables)
+	b.appendUnmatchedLeadingHintWarning(hintInfo.leadingJoinOrder)
 	b.tableHintInfo = b.tableHintInfo[:len(b.tableHintInfo)-1]
 }
 
@@ -3426,6 +3508,20 @@ func (b *PlanBuilder) appendUnmatchedJoinHintWarning(joinType string, joinTypeAl
 	b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
 }
 
+func (b *PlanBuilder) appendUnmatchedLeadingHintWarning(leadingJoinOrder *leadingHintInfo) {
+	if leadingJoinOrder == nil {
+		return
+	}
+	unMatchedTables := extractUnmatchedTables(leadingJoinOrder.tables)
+	if len(unMatchedTables) == 0 {
+		return
+	}
+	leadingJoinOrder.warned = true
+	errMsg := fmt.Sprintf("There are no matching table names for (%s) in optimizer hint %s. Maybe you can use the table alias name",
+		strings.Join(unMatchedTables, ", "), leadingJoinOrder)
+	b.ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+}
+
 func (b *PlanBuilder) appendUnmatchedStorageHintWarning(tiflashTables, tikvTables []hintTableInfo) {
 	unMatchedTiFlashTables := extractUnmatchedTables(tiflashTables)
 	unMatchedTiKVTables := extractUnmatchedTables(tikvTables)
@@ -3720,6 +3816,138 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 	return p, nil
 }
 
+// buildJSONTable builds the JSON_TABLE table function. The columns of the tables preceding it are
+// pushed to the outer schemas by buildJoin, so the document can reference them as correlated columns.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName *model.CIStr) (LogicalPlan, error) {
+	b.curClause = tableFunctionClause
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	expr, np, err := b.rewrite(ctx, jt.Expr, mockTablePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != mockTablePlan {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries yet")
+	}
+	p := LogicalJSONTable{Expr: expression.WrapWithCastAsJSON(b.ctx, expr), AsName: *asName}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Root, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.SetSchema(schema)
+	p.names = names
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTablePath builds the row path and its columns, the columns of the nested paths are appended
+// to the schema after the columns of their parent path.
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, asName *model.CIStr, schema *expression.Schema, names *types.NameSlice) (*JSONTablePath, error) {
+	pathExpr, err := json.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	jtPath := &JSONTablePath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.Columns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			jtPath.Nested = append(jtPath.Nested, nested)
+			continue
+		}
+		jtCol, err := buildJSONTableColumn(col)
+		if err != nil {
+			return nil, err
+		}
+		jtCol.Index = schema.Len()
+		jtPath.Columns = append(jtPath.Columns, jtCol)
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  jtCol.RetType,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     *asName,
+			OrigTblName: *asName,
+			ColName:     col.Name,
+			OrigColName: col.Name,
+		})
+	}
+	return jtPath, nil
+}
+
+func buildJSONTableColumn(col *ast.JSONTableColumn) (*JSONTableColumn, error) {
+	jtCol := &JSONTableColumn{Tp: col.Tp, Name: col.Name}
+	if col.Tp == ast.JSONTableColumnOrdinality {
+		jtCol.RetType = types.NewFieldType(mysql.TypeLong)
+		jtCol.RetType.Flag |= mysql.UnsignedFlag
+		jtCol.RetType.Flen = 10
+		jtCol.RetType.Decimal = 0
+		types.SetBinChsClnFlag(jtCol.RetType)
+		return jtCol, nil
+	}
+	var err error
+	if jtCol.Path, err = json.ParseJSONPathExpr(col.Path); err != nil {
+		return nil, err
+	}
+	jtCol.RetType = col.FieldType.Clone()
+	if err = setJSONTableColumnFieldType(jtCol.RetType); err != nil {
+		return nil, err
+	}
+	if jtCol.OnEmpty, err = buildJSONTableOnResponse(col.OnEmpty); err != nil {
+		return nil, err
+	}
+	if jtCol.OnError, err = buildJSONTableOnResponse(col.OnError); err != nil {
+		return nil, err
+	}
+	return jtCol, nil
+}
+
+// setJSONTableColumnFieldType fills the charset, collation, flen and decimal of the column type
+// when they are unspecified.
+func setJSONTableColumnFieldType(tp *types.FieldType) error {
+	if types.IsString(tp.Tp) && tp.Charset != charset.CharsetBin {
+		if tp.Charset == "" {
+			tp.Charset, tp.Collate = charset.GetDefaultCharsetAndCollate()
+		} else if tp.Collate == "" {
+			collate, err := charset.GetDefaultCollation(tp.Charset)
+			if err != nil {
+				return err
+			}
+			tp.Collate = collate
+		}
+	} else if tp.Tp == mysql.TypeJSON {
+		tp.Charset, tp.Collate = mysql.DefaultCharset, mysql.DefaultCollationName
+		tp.Flag |= mysql.BinaryFlag
+	} else {
+		types.SetBinChsClnFlag(tp)
+	}
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.Tp)
+	if tp.Flen == types.UnspecifiedLength {
+		tp.Flen = defaultFlen
+	}
+	if tp.Decimal == types.UnspecifiedLength {
+		tp.Decimal = defaultDecimal
+	}
+	return nil
+}
+
+func buildJSONTableOnResponse(resp *ast.JSONTableOnResponse) (JSONTableOnResponse, error) {
+	if resp == nil {
+		return JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}, nil
+	}
+	res := JSONTableOnResponse{Tp: resp.Tp}
+	if resp.Tp == ast.JSONTableOnResponseDefault {
+		var err error
+		if res.Default, err = json.ParseBinaryFromString(resp.Default); err != nil {
+			return res, err
+		}
+	}
+	return res, nil
+}
+
 func (b *PlanBuilder) buildTableDual() *LogicalTableDual {
 	b.handleHelper.pushMap(nil)
 	return LogicalTableDual{RowCount: 1}.Init(b.ctx, b.getSelectOffset())
@@ -3926,6 +4154,24 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if tn.TableSample != nil {
 			return nil, expression.ErrInvalidTableSample.GenWithStackByArgs("Unsupported TABLESAMPLE in views")
 		}
+		if _, ok := b.updatableViews[tn]; ok {
+			viewAsName := *asName
+			if viewAsName.L == "" {
+				viewAsName = tn.Name
+			}
+			writePriv := mysql.UpdatePriv
+			if b.inDeleteStmt {
+				writePriv = mysql.DeletePriv
+			}
+			view, err := b.buildUpdatableView(ctx, dbName, viewAsName, tableInfo, []mysql.PrivilegeType{writePriv}, false)
+			if err != nil {
+				return nil, err
+			}
+			if view != nil {
+				b.updatableViews[tn] = view
+				return view.plan, nil
+			}
+		}
 		return b.BuildDataSourceFromView(ctx, dbName, tableInfo)
 	}
 
@@ -3948,11 +4194,13 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 		if len(tn.PartitionNames) > 0 {
 			pids := make(map[int64]struct{}, len(tn.PartitionNames))
 			for _, name := range tn.PartitionNames {
-				pid, err := tables.FindPartitionByName(tableInfo, name.L)
+				partIDs, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 				if err != nil {
 					return nil, err
 				}
-				pids[pid] = struct{}{}
+				for _, pid := range partIDs {
+					pids[pid] = struct{}{}
+				}
 			}
 			pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 		}
@@ -4131,7 +4379,7 @@ func (b *PlanBuilder) buildDataSource(ctx context.Context, tn *ast.TableName, as
 	}
 	// Init FullIdxCols, FullIdxColLens for accessPaths.
 	for _, path := range ds.possibleAccessPaths {
-		if !path.IsIntHandlePath {
+		if !path.IsIntHandlePath && !path.IsSearchIndexPath() {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
 		}
 	}
@@ -4430,9 +4678,9 @@ func (b *PlanBuilder) buildProjUponView(ctx context.Context, dbName model.CIStr,
 
 // buildApplyWithJoinType builds apply plan with outerPlan and innerPlan, which apply join with particular join type for
 // every row from outerPlan and the whole innerPlan.
-func (b *PlanBuilder) buildApplyWithJoinType(outerPlan, innerPlan LogicalPlan, tp JoinType) LogicalPlan {
+func (b *PlanBuilder) buildApplyWithJoinType(outerPlan, innerPlan LogicalPlan, tp JoinType, markNoDecorrelate bool) LogicalPlan {
 	b.optFlag = b.optFlag | flagPredicatePushDown | flagBuildKeyInfo | flagDecorrelate
-	ap := LogicalApply{LogicalJoin: LogicalJoin{JoinType: tp}}.Init(b.ctx, b.getSelectOffset())
+	ap := LogicalApply{LogicalJoin: LogicalJoin{JoinType: tp}, NoDecorrelate: markNoDecorrelate}.Init(b.ctx, b.getSelectOffset())
 	ap.SetChildren(outerPlan, innerPlan)
 	ap.names = make([]*types.FieldName, outerPlan.Schema().Len()+innerPlan.Schema().Len())
 	copy(ap.names, outerPlan.OutputNames())
@@ -4449,7 +4697,7 @@ func (b *PlanBuilder) buildApplyWithJoinType(outerPlan, innerPlan LogicalPlan, t
 }
 
 // buildSemiApply builds apply plan with outerPlan and innerPlan, which apply semi-join for every row from outerPlan and the whole innerPlan.
-func (b *PlanBuilder) buildSemiApply(outerPlan, innerPlan LogicalPlan, condition []expression.Expression, asScalar, not bool) (LogicalPlan, error) {
+func (b *PlanBuilder) buildSemiApply(outerPlan, innerPlan LogicalPlan, condition []expression.Expression, asScalar, not, markNoDecorrelate bool) (LogicalPlan, error) {
 	b.optFlag = b.optFlag | flagPredicatePushDown | flagBuildKeyInfo | flagDecorrelate
 
 	join, err := b.buildSemiJoin(outerPlan, innerPlan, condition, asScalar, not)
@@ -4457,7 +4705,7 @@ func (b *PlanBuilder) buildSemiApply(outerPlan, innerPlan LogicalPlan, condition
 		return nil, err
 	}
 
-	ap := &LogicalApply{LogicalJoin: *join}
+	ap := &LogicalApply{LogicalJoin: *join, NoDecorrelate: markNoDecorrelate}
 	ap.tp = plancodec.TypeApply
 	ap.self = ap
 	return ap, nil
@@ -4628,6 +4876,7 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		}
 	}
 
+	b.collectUpdatableViews(update.TableRefs.TableRefs)
 	p, err := b.buildResultSetNode(ctx, update.TableRefs.TableRefs)
 	if err != nil {
 		return nil, err
@@ -4723,9 +4972,22 @@ func (b *PlanBuilder) buildUpdate(ctx context.Context, update *ast.UpdateStmt) (
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	updt.TblColPosInfos, err = buildColumns2Handle(updt.OutputNames(), tblID2Handle, tblID2table, true)
+	if err != nil {
+		return nil, err
+	}
+	for _, content := range updt.TblColPosInfos {
+		name := updt.names[content.Start]
+		if view := b.updatableViewByName(name.DBName, name.TblName); view != nil && len(view.checks) > 0 {
+			if updt.ViewChecks == nil {
+				updt.ViewChecks = make(map[int][]*table.ViewCheck)
+			}
+			updt.ViewChecks[content.Start] = view.checks
+		}
+	}
 	updt.PartitionedTable = b.partitionedTable
 	updt.tblID2Table = tblID2table
-	return updt, err
+	updt.buildOnUpdateFKTriggers(b.ctx, b.is, tblID2table)
+	return updt, nil
 }
 
 type tblUpdateInfo struct {
@@ -4783,7 +5045,9 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 	modifyColumns := make(map[string]bool, p.Schema().Len())
 	var columnsIdx map[*ast.ColumnName]int
 	cacheColumnsIdx := false
-	if len(p.OutputNames()) > 16 {
+	// The columns of the updatable views are mapped to the columns of their base tables, the
+	// mapped index has to be cached.
+	if len(p.OutputNames()) > 16 || len(b.updatableViews) > 0 {
 		cacheColumnsIdx = true
 		columnsIdx = make(map[*ast.ColumnName]int, len(list))
 	}
@@ -4795,13 +5059,21 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		if idx < 0 {
 			return nil, nil, false, ErrUnknownColumn.GenWithStackByArgs(assign.Column.Name, "field list")
 		}
+		name := p.OutputNames()[idx]
+		if view := b.updatableViewByName(name.DBName, name.TblName); view != nil {
+			baseCol := view.baseColumnOf(p.Schema().Columns[idx])
+			if baseCol == nil {
+				return nil, nil, false, ErrNonupdateableColumn.GenWithStackByArgs(name.ColName.O)
+			}
+			idx = p.Schema().ColumnIndex(baseCol)
+			name = p.OutputNames()[idx]
+		}
 		if cacheColumnsIdx {
 			columnsIdx[assign.Column] = idx
 		}
-		name := p.OutputNames()[idx]
 		for _, tl := range tableList {
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
+				if isCTE(tl) || (tl.TableInfo.IsView() && b.updatableViewByName(name.DBName, name.TblName) == nil) || tl.TableInfo.IsSequence() {
 					return nil, nil, false, ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				// may be a subquery
@@ -4827,6 +5099,8 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 	// If columns in set list contains generated columns, raise error.
 	// And, fill virtualAssignments here; that's for generated columns.
 	virtualAssignments := make([]*ast.Assignment, 0)
+	// viewGenPlans are the plans to resolve the generated columns of the base tables of the updatable views.
+	viewGenPlans := make(map[*ast.Assignment]LogicalPlan)
 	for _, tn := range tableList {
 		// Only generate virtual to updatable table, skip not updatable table(i.e. table in update's subQuery)
 		updatable := true
@@ -4836,20 +5110,31 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 				break
 			}
 		}
-		if !updatable || isCTE(tn) || tn.TableInfo.IsView() || tn.TableInfo.IsSequence() {
+		if !updatable || isCTE(tn) || tn.TableInfo.IsSequence() {
 			continue
 		}
 
-		tableInfo := tn.TableInfo
+		tableInfo, dbName, colName := tn.TableInfo, tn.DBInfo.Name, &ast.ColumnName{Schema: tn.Schema, Table: tn.Name}
+		var genPlan LogicalPlan
+		if tableInfo.IsView() {
+			view := b.updatableViewByName(tn.DBInfo.Name, tn.Name)
+			if view == nil {
+				continue
+			}
+			// The generated columns of the base table are resolved by the hidden columns of the view.
+			tableInfo, dbName, colName = view.baseTblInfo, view.dbName, &ast.ColumnName{Schema: view.dbName, Table: view.asName}
+			genPlan = b.buildViewGenerationPlan(p, view)
+		}
 		tableVal, found := b.is.TableByID(tableInfo.ID)
 		if !found {
-			return nil, nil, false, infoschema.ErrTableNotExists.GenWithStackByArgs(tn.DBInfo.Name.O, tableInfo.Name.O)
+			return nil, nil, false, infoschema.ErrTableNotExists.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
 		}
 		for i, colInfo := range tableInfo.Columns {
-			if !colInfo.IsGenerated() {
+			// The non-public stored generated column being added is filled when the row is written.
+			if !colInfo.IsGenerated() || colInfo.State != model.StatePublic {
 				continue
 			}
-			columnFullName := fmt.Sprintf("%s.%s.%s", tn.DBInfo.Name.L, tn.Name.L, colInfo.Name.L)
+			columnFullName := fmt.Sprintf("%s.%s.%s", dbName.L, colName.Table.L, colInfo.Name.L)
 			isDefault, ok := modifyColumns[columnFullName]
 			if ok && colInfo.Hidden {
 				return nil, nil, false, ErrUnknownColumn.GenWithStackByArgs(colInfo.Name, clauseMsg[fieldList])
@@ -4859,10 +5144,14 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 			if ok && !isDefault {
 				return nil, nil, false, ErrBadGeneratedColumn.GenWithStackByArgs(colInfo.Name.O, tableInfo.Name.O)
 			}
-			virtualAssignments = append(virtualAssignments, &ast.Assignment{
-				Column: &ast.ColumnName{Schema: tn.Schema, Table: tn.Name, Name: colInfo.Name},
+			assign := &ast.Assignment{
+				Column: &ast.ColumnName{Schema: colName.Schema, Table: colName.Table, Name: colInfo.Name},
 				Expr:   tableVal.Cols()[i].GeneratedExpr,
-			})
+			}
+			if genPlan != nil {
+				viewGenPlans[assign] = genPlan
+			}
+			virtualAssignments = append(virtualAssignments, assign)
 		}
 	}
 
@@ -4881,9 +5170,12 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 	for i, assign := range allAssignments {
 		var idx int
 		var err error
+		genPlan, isViewGen := viewGenPlans[assign]
 		if cacheColumnsIdx {
 			if i, ok := columnsIdx[assign.Column]; ok {
 				idx = i
+			} else if isViewGen {
+				idx, err = expression.FindFieldName(genPlan.OutputNames(), assign.Column)
 			} else {
 				idx, err = expression.FindFieldName(p.OutputNames(), assign.Column)
 			}
@@ -4921,10 +5213,16 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 					return expr
 				}
 			}
-			newExpr, np, err = b.rewriteWithPreprocess(ctx, assign.Expr, p, nil, nil, false, rewritePreprocess)
+			if !isViewGen {
+				genPlan = p
+			}
+			newExpr, np, err = b.rewriteWithPreprocess(ctx, assign.Expr, genPlan, nil, nil, false, rewritePreprocess)
 			if err != nil {
 				return nil, nil, false, err
 			}
+			if isViewGen {
+				np = p
+			}
 			// check if the column is modified
 			dependentColumns := expression.ExtractDependentColumns(newExpr)
 			var isModified bool
@@ -4990,6 +5288,7 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 		}
 	}
 
+	b.collectUpdatableViews(delete.TableRefs.TableRefs)
 	p, err := b.buildResultSetNode(ctx, delete.TableRefs.TableRefs)
 	if err != nil {
 		return nil, err
@@ -5105,7 +5404,12 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 			tn.DBInfo = tb.DBInfo
 			tn.TableInfo = tb.TableInfo
 			if tn.TableInfo.IsView() {
-				return nil, errors.Errorf("delete view %s is not supported now.", tn.Name.O)
+				view := b.updatableViewByName(tb.DBInfo.Name, tn.Name)
+				if view == nil {
+					return nil, ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+				}
+				// The rows are deleted from the base table of the view.
+				tn.TableInfo = view.baseTblInfo
 			}
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now.", tn.Name.O)
@@ -5123,8 +5427,8 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 			if isCTE(v) {
 				return nil, ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
 			}
-			if v.TableInfo.IsView() {
-				return nil, errors.Errorf("delete view %s is not supported now.", v.Name.O)
+			if v.TableInfo.IsView() && b.updatableViews[v] == nil {
+				return nil, ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
 			}
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now.", v.Name.O)
@@ -5155,7 +5459,11 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, delete *ast.DeleteStmt) (
 		tblID2table[id], _ = b.is.TableByID(id)
 	}
 	del.TblColPosInfos, err = buildColumns2Handle(del.names, tblID2Handle, tblID2table, false)
-	return del, err
+	if err != nil {
+		return nil, err
+	}
+	del.buildOnDeleteFKTriggers(b.ctx, b.is, tblID2table)
+	return del, nil
 }
 
 func resolveIndicesForTblID2Handle(tblID2Handle map[int64][]HandleCols, schema *expression.Schema) (map[int64][]HandleCols, error) {
@@ -5348,7 +5656,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 }
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
-// For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Rows` and `Groups`, the bound expr must be an unsigned integer.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(ctx context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -5357,7 +5665,8 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(ctx context.Context, spec *a
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	// For `Groups`, the bound counts the peer groups decided by the order by items.
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -5477,9 +5786,6 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 
 func (b *PlanBuilder) checkWindowFuncArgs(ctx context.Context, p LogicalPlan, windowFuncExprs []*ast.WindowFuncExpr, windowAggMap map[*ast.AggregateFuncExpr]int) error {
 	for _, windowFuncExpr := range windowFuncExprs {
-		if strings.ToLower(windowFuncExpr.F) == ast.AggFuncGroupConcat {
-			return ErrNotSupportedYet.GenWithStackByArgs("group_concat as window function")
-		}
 		args, err := b.buildArgs4WindowFunc(ctx, p, windowFuncExpr.Args, windowAggMap)
 		if err != nil {
 			return err
@@ -5603,6 +5909,9 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 				return nil, nil, ErrWrongArguments.GenWithStackByArgs(strings.ToLower(windowFunc.F))
 			}
 			preArgs += len(windowFunc.Args)
+			desc.HasDistinct = windowFunc.Distinct
+			desc.IgnoreNull = windowFunc.IgnoreNull
+			desc.FromLast = windowFunc.FromLast
 			desc.WrapCastForAggArgs(b.ctx)
 			descs = append(descs, desc)
 			windowMap[windowFunc] = schema.Len()
@@ -5624,15 +5933,6 @@ func (b *PlanBuilder) buildWindowFunctions(ctx context.Context, p LogicalPlan, g
 // Because the grouped specification is different from them, we should especially check them before build window frame.
 func (b *PlanBuilder) checkOriginWindowFuncs(funcs []*ast.WindowFuncExpr, orderByItems []property.SortItem) error {
 	for _, f := range funcs {
-		if f.IgnoreNull {
-			return ErrNotSupportedYet.GenWithStackByArgs("IGNORE NULLS")
-		}
-		if f.Distinct {
-			return ErrNotSupportedYet.GenWithStackByArgs("<window function>(DISTINCT ..)")
-		}
-		if f.FromLast {
-			return ErrNotSupportedYet.GenWithStackByArgs("FROM LAST")
-		}
 		spec := &f.Spec
 		if f.Spec.Name.L != "" {
 			spec = b.windowSpecs[f.Spec.Name.L]
@@ -5649,9 +5949,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -5683,7 +5980,7 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/ranger"
 	"go.uber.org/zap"
@@ -44,6 +45,7 @@ var (
 	_ LogicalPlan = &LogicalApply{}
 	_ LogicalPlan = &LogicalMaxOneRow{}
 	_ LogicalPlan = &LogicalTableDual{}
+	_ LogicalPlan = &LogicalJSONTable{}
 	_ LogicalPlan = &DataSource{}
 	_ LogicalPlan = &TiKVSingleGather{}
 	_ LogicalPlan = &LogicalTableScan{}
@@ -459,6 +461,8 @@ type LogicalApply struct {
 	LogicalJoin
 
 	CorCols []*expression.CorrelatedColumn
+	// NoDecorrelate is set by the NO_DECORRELATE hint of the subquery, the apply is not decorrelated.
+	NoDecorrelate bool
 }
 
 // ExtractCorrelatedCols implements LogicalPlan interface.
@@ -484,6 +488,49 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// LogicalJSONTable represents the JSON_TABLE table function, which produces rows from a JSON document.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// Expr is the JSON document, it may reference the columns of the tables preceding the JSON_TABLE.
+	Expr   expression.Expression
+	Root   *JSONTablePath
+	AsName model.CIStr
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// JSONTablePath is a row path of JSON_TABLE. Every value matched by the path produces the rows
+// of its columns, which are joined with the rows produced by the nested paths.
+type JSONTablePath struct {
+	Path    json.PathExpression
+	Columns []*JSONTableColumn
+	// Nested are the NESTED PATH clauses, which are evaluated on the values matched by Path.
+	Nested []*JSONTablePath
+}
+
+// JSONTableColumn is a column of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp      ast.JSONTableColumnType
+	Name    model.CIStr
+	RetType *types.FieldType
+	// Path is the path of the JSONTableColumnPath and JSONTableColumnExists columns.
+	Path    json.PathExpression
+	OnEmpty JSONTableOnResponse
+	OnError JSONTableOnResponse
+	// Index is the offset of the column in the schema of JSON_TABLE.
+	Index int
+}
+
+// JSONTableOnResponse is the behavior of the column when the value is missing or can't be converted.
+type JSONTableOnResponse struct {
+	Tp      ast.JSONTableOnResponseType
+	Default json.BinaryJSON
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -693,7 +740,7 @@ func (ds *DataSource) Convert2Gathers() (gathers []LogicalPlan) {
 	tg := ds.buildTableGather()
 	gathers = append(gathers, tg)
 	for _, path := range ds.possibleAccessPaths {
-		if !path.IsIntHandlePath {
+		if !path.IsIntHandlePath && !path.IsSearchIndexPath() {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
 			path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.schema.Columns, path.Index)
 			// If index columns can cover all of the needed columns, we can use a IndexGather + IndexScan.
@@ -898,6 +945,53 @@ func (ds *DataSource) fillIndexPath(path *util.AccessPath, conds []expression.Ex
 	return nil
 }
 
+// fillSearchIndexPath finds the condition looked up through the index of the search index path and estimates the
+// row count read by it. The search conditions can't be pushed down, so they are found in all the conditions of the
+// DataSource and evaluated by the Selection above it again.
+func (ds *DataSource) fillSearchIndexPath(path *util.AccessPath) {
+	path.Ranges = ranger.FullRange()
+	path.CountAfterAccess = float64(ds.statisticTable.Count)
+	// The search index lookups read the KV store of the table directly, they don't support the partitions and
+	// the sampling.
+	if ds.SampleInfo != nil || ds.isPartition {
+		return
+	}
+	for _, cond := range ds.allConds {
+		sf, ok := cond.(*expression.ScalarFunction)
+		if !ok {
+			continue
+		}
+		args := ds.searchIndexLookUpArgs(path.Index, sf)
+		if args == nil {
+			continue
+		}
+		path.SearchCond = sf
+		// There are no statistics of the index entries, each looked up key is estimated to read the average
+		// count of rows per value.
+		keyCnt := ds.searchIndexKeyCount(path.Index, args)
+		path.CountAfterAccess = math.Min(float64(keyCnt)*ds.statisticTable.PseudoAvgCountPerValue(), path.CountAfterAccess)
+		return
+	}
+}
+
+// removeUnusableSearchIndexPaths removes the search index paths which have no search condition. The table path is
+// added if no path is left, e.g. only the unusable search index is hinted by `USE INDEX`.
+func (ds *DataSource) removeUnusableSearchIndexPaths() {
+	paths := make([]*util.AccessPath, 0, len(ds.possibleAccessPaths))
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsSearchIndexPath() && path.SearchCond == nil {
+			continue
+		}
+		paths = append(paths, path)
+	}
+	if len(paths) == 0 {
+		tablePath := &util.AccessPath{StoreType: kv.TiKV}
+		fillContentForTablePath(tablePath, ds.tableInfo)
+		paths = append(paths, tablePath)
+	}
+	ds.possibleAccessPaths = paths
+}
+
 // deriveIndexPathStats will fulfill the information that the AccessPath need.
 // conds is the conditions used to generate the DetachRangeResult for path.
 // isIm indicates whether this function is called to generate the partial path for IndexMerge.
@@ -48,6 +48,9 @@ var AllowCartesianProduct = atomic.NewBool(true)
 // IsReadOnly check whether the ast.Node is a read only statement.
 var IsReadOnly func(node ast.Node, vars *variable.SessionVars) bool
 
+// GetBindSQL4PlanCache returns the SQL of the bindings which the plan of the statement is built from.
+var GetBindSQL4PlanCache func(sctx sessionctx.Context, stmt ast.StmtNode) string
+
 const (
 	flagGcSubstitute uint64 = 1 << iota
 	flagPrunColumns
@@ -522,6 +525,7 @@ var DefaultDisabledLogicalRulesList *atomic.Value
 func init() {
 	expression.EvalAstExpr = evalAstExpr
 	expression.RewriteAstExpr = rewriteAstExpr
+	expression.BuildRoutineFunction = buildRoutineFunctionByName
 	DefaultDisabledLogicalRulesList = new(atomic.Value)
 	DefaultDisabledLogicalRulesList.Store(set.NewStringSet())
 }
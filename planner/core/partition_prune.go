@@ -35,9 +35,14 @@ func PartitionPruning(ctx sessionctx.Context, tbl table.PartitionedTable, conds
 	for i, cond := range conds {
 		conds[i] = expression.PushDownNot(ctx, cond)
 	}
+	if pi.Sub != nil {
+		return s.pruneSubPartition(ctx, tbl, pi, partitionNames, conds, columns, names)
+	}
 	switch pi.Type {
 	case model.PartitionTypeHash:
-		return s.pruneHashPartition(ctx, tbl, partitionNames, conds, columns, names)
+		return s.pruneHashPartition(ctx, pi, partitionNames, conds, columns, names)
+	case model.PartitionTypeKey:
+		return s.findUsedKeyPartitions(ctx, pi, partitionNames, conds, columns, names)
 	case model.PartitionTypeRange:
 		rangeOr, _, err := s.pruneRangePartition(ctx, pi, tbl, conds, columns, names, nil)
 		if err != nil {
@@ -46,7 +51,7 @@ func PartitionPruning(ctx sessionctx.Context, tbl table.PartitionedTable, conds
 		ret := s.convertToIntSlice(rangeOr, pi, partitionNames)
 		return ret, nil
 	case model.PartitionTypeList:
-		return s.pruneListPartition(ctx, tbl, partitionNames, conds)
+		return s.pruneListPartition(ctx, tbl, pi, partitionNames, conds)
 	}
 	return []int{FullRange}, nil
 }
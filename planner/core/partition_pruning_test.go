@@ -288,7 +288,7 @@ func (s *testPartitionPruningSuite) TestPartitionRangePrunner2VarChar(c *C) {
 		lessThan[i] = tmp[0]
 	}
 
-	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], true}
+	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], true, false}
 	cases := []struct {
 		input  string
 		result partitionRangeOR
@@ -332,7 +332,7 @@ func (s *testPartitionPruningSuite) TestPartitionRangePrunner2CharWithCollation(
 		lessThan[i] = tmp[0]
 	}
 
-	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], true}
+	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], true, false}
 	cases := []struct {
 		input  string
 		result partitionRangeOR
@@ -382,7 +382,7 @@ func (s *testPartitionPruningSuite) TestPartitionRangePrunner2Date(c *C) {
 		lessThan[i] = tmp[0]
 	}
 
-	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], false}
+	prunner := &rangeColumnsPruner{lessThan, tc.columns[0], false, false}
 	cases := []struct {
 		input  string
 		result partitionRangeOR
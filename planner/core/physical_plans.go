@@ -44,6 +44,7 @@ var (
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalMaxOneRow{}
 	_ PhysicalPlan = &PhysicalTableDual{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 	_ PhysicalPlan = &PhysicalUnionAll{}
 	_ PhysicalPlan = &PhysicalSort{}
 	_ PhysicalPlan = &NominalSort{}
@@ -67,6 +68,9 @@ var (
 	_ PhysicalPlan = &PhysicalShuffleReceiverStub{}
 	_ PhysicalPlan = &BatchPointGetPlan{}
 	_ PhysicalPlan = &PhysicalTableSample{}
+	_ PhysicalPlan = &PhysicalFullTextIndexLookUp{}
+	_ PhysicalPlan = &PhysicalSpatialIndexLookUp{}
+	_ PhysicalPlan = &PhysicalMVIndexLookUp{}
 )
 
 // PhysicalTableReader is the table reader in tidb.
@@ -159,9 +163,7 @@ func (p *PhysicalTableReader) Clone() (PhysicalPlan, error) {
 	if cloned.tablePlan, err = p.tablePlan.Clone(); err != nil {
 		return nil, err
 	}
-	if cloned.TablePlans, err = clonePhysicalPlan(p.TablePlans); err != nil {
-		return nil, err
-	}
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
 	return cloned, nil
 }
 
@@ -205,9 +207,7 @@ func (p *PhysicalIndexReader) Clone() (PhysicalPlan, error) {
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
 	cloned.OutputColumns = cloneCols(p.OutputColumns)
 	return cloned, err
 }
@@ -283,24 +283,23 @@ func (p *PhysicalIndexLookUpReader) Clone() (PhysicalPlan, error) {
 		return nil, err
 	}
 	cloned.physicalSchemaProducer = *base
-	if cloned.IndexPlans, err = clonePhysicalPlan(p.IndexPlans); err != nil {
-		return nil, err
-	}
-	if cloned.TablePlans, err = clonePhysicalPlan(p.TablePlans); err != nil {
-		return nil, err
-	}
 	if cloned.indexPlan, err = p.indexPlan.Clone(); err != nil {
 		return nil, err
 	}
 	if cloned.tablePlan, err = p.tablePlan.Clone(); err != nil {
 		return nil, err
 	}
+	cloned.IndexPlans = flattenPushDownPlan(cloned.indexPlan)
+	cloned.TablePlans = flattenPushDownPlan(cloned.tablePlan)
 	if p.ExtraHandleCol != nil {
 		cloned.ExtraHandleCol = p.ExtraHandleCol.Clone().(*expression.Column)
 	}
 	if p.PushedLimit != nil {
 		cloned.PushedLimit = p.PushedLimit.Clone()
 	}
+	if p.CommonHandleCols != nil {
+		cloned.CommonHandleCols = cloneCols(p.CommonHandleCols)
+	}
 	return cloned, nil
 }
 
@@ -778,6 +777,9 @@ func (p *PhysicalHashJoin) Clone() (PhysicalPlan, error) {
 	cloned.basePhysicalJoin = *base
 	cloned.Concurrency = p.Concurrency
 	cloned.UseOuterToBuild = p.UseOuterToBuild
+	cloned.storeTp = p.storeTp
+	cloned.globalChildIndex = p.globalChildIndex
+	cloned.mppShuffleJoin = p.mppShuffleJoin
 	for _, c := range p.EqualConditions {
 		cloned.EqualConditions = append(cloned.EqualConditions, c.Clone().(*expression.ScalarFunction))
 	}
@@ -1259,6 +1261,20 @@ func (p *PhysicalTableDual) SetOutputNames(names types.NameSlice) {
 	p.names = names
 }
 
+// PhysicalJSONTable is the physical operator of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr   expression.Expression
+	Root   *JSONTablePath
+	AsName model.CIStr
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // PhysicalWindow is the physical operator of window function.
 type PhysicalWindow struct {
 	physicalSchemaProducer
@@ -1416,6 +1432,49 @@ func NewTableSampleInfo(node *ast.TableSample, fullSchema *expression.Schema, pt
 	}
 }
 
+// PhysicalFullTextIndexLookUp represents a plan which reads the rows containing the searched words of
+// `MATCH ... AGAINST` through the inverted index entries of a FULLTEXT index.
+type PhysicalFullTextIndexLookUp struct {
+	physicalSchemaProducer
+
+	Table       *model.TableInfo
+	TableAsName *model.CIStr
+	Index       *model.IndexInfo
+	// Columns are the columns of the table to read.
+	Columns []*model.ColumnInfo
+	// Against is the search string and Modifier is the search mode of `MATCH ... AGAINST`.
+	Against  expression.Expression
+	Modifier ast.FulltextSearchModifier
+}
+
+// PhysicalSpatialIndexLookUp represents a plan which reads the rows whose geometries may have a spatial
+// relation with the shape through the cell ids of a SPATIAL index.
+type PhysicalSpatialIndexLookUp struct {
+	physicalSchemaProducer
+
+	Table       *model.TableInfo
+	TableAsName *model.CIStr
+	Index       *model.IndexInfo
+	// Columns are the columns of the table to read.
+	Columns []*model.ColumnInfo
+	// Shape is the constant geometry argument of the spatial relation function.
+	Shape expression.Expression
+}
+
+// PhysicalMVIndexLookUp represents a plan which reads the rows whose JSON arrays may have any element of the
+// values through the entries of a multi-valued index.
+type PhysicalMVIndexLookUp struct {
+	physicalSchemaProducer
+
+	Table       *model.TableInfo
+	TableAsName *model.CIStr
+	Index       *model.IndexInfo
+	// Columns are the columns of the table to read.
+	Columns []*model.ColumnInfo
+	// Values are the JSON documents whose elements are looked up, the nested arrays are flattened.
+	Values []expression.Expression
+}
+
 // PhysicalCTE is for CTE.
 type PhysicalCTE struct {
 	physicalSchemaProducer
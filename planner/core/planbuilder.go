@@ -96,12 +96,86 @@ type tableHintInfo struct {
 	indexMergeHintList          []indexHintInfo
 	timeRangeHint               ast.HintTimeRange
 	limitHints                  limitHintInfo
+	leadingJoinOrder            *leadingHintInfo
 }
 
 type limitHintInfo struct {
 	preferLimitToCop bool
 }
 
+// leadingHintInfo is the join order specified by the LEADING hint.
+type leadingHintInfo struct {
+	// tables are all the tables in the hint in order.
+	tables []hintTableInfo
+	// items is the nested join order of the tables.
+	items []leadingHintItem
+	// applied indicates whether the join order has been built by the join reorder rule.
+	applied bool
+	// warned indicates whether a warning has been generated for this hint,
+	// so that only one warning is generated for each hint.
+	warned bool
+}
+
+// leadingHintItem is either a table in leadingHintInfo.tables or a nested join order.
+type leadingHintItem struct {
+	// tableIdx is the offset of the table in leadingHintInfo.tables, -1 for the nested join order.
+	tableIdx int
+	items    []leadingHintItem
+}
+
+// newLeadingHintInfo builds the leadingHintInfo from the hint tables and the nested table list of the LEADING hint.
+func newLeadingHintInfo(tables []hintTableInfo, list *ast.LeadingList) *leadingHintInfo {
+	info := &leadingHintInfo{tables: tables}
+	if list == nil {
+		for i := range tables {
+			info.items = append(info.items, leadingHintItem{tableIdx: i})
+		}
+		return info
+	}
+	offset := 0
+	var convert func(list *ast.LeadingList) []leadingHintItem
+	convert = func(list *ast.LeadingList) []leadingHintItem {
+		items := make([]leadingHintItem, 0, len(list.Items))
+		for _, item := range list.Items {
+			switch x := item.(type) {
+			case *ast.HintTable:
+				items = append(items, leadingHintItem{tableIdx: offset})
+				offset++
+			case *ast.LeadingList:
+				items = append(items, leadingHintItem{tableIdx: -1, items: convert(x)})
+			}
+		}
+		return items
+	}
+	info.items = convert(list)
+	return info
+}
+
+// String restores the hint in the format of `/*+ LEADING(t1, (t2, t3)) */`.
+func (info *leadingHintInfo) String() string {
+	buffer := bytes.NewBufferString("/*+ ")
+	buffer.WriteString(strings.ToUpper(HintLeading))
+	buffer.WriteString("(")
+	var restore func(items []leadingHintItem)
+	restore = func(items []leadingHintItem) {
+		for i, item := range items {
+			if i > 0 {
+				buffer.WriteString(", ")
+			}
+			if item.tableIdx >= 0 {
+				buffer.WriteString(restore2TableHint(info.tables[item.tableIdx]))
+				continue
+			}
+			buffer.WriteString("(")
+			restore(item.items)
+			buffer.WriteString(")")
+		}
+	}
+	restore(info.items)
+	buffer.WriteString(") */")
+	return buffer.String()
+}
+
 type hintTableInfo struct {
 	dbName       model.CIStr
 	tblName      model.CIStr
@@ -181,7 +255,7 @@ func tableNames2HintTableInfo(ctx sessionctx.Context, hintName string, hintTable
 			tableInfo.dbName = defaultDBName
 		}
 		switch hintName {
-		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ, TiDBHashJoin, HintHJ:
+		case TiDBMergeJoin, HintSMJ, TiDBIndexNestedLoopJoin, HintINLJ, HintINLHJ, HintINLMJ, TiDBHashJoin, HintHJ, HintLeading:
 			if len(tableInfo.partitions) > 0 {
 				isInapplicable = true
 			}
@@ -237,6 +311,15 @@ func (info *tableHintInfo) ifPreferINLMJ(tableNames ...*hintTableInfo) bool {
 	return info.matchTableName(tableNames, info.indexNestedLoopJoinTables.inlmjTables)
 }
 
+// ifPreferLeading checks whether the tables are in the LEADING hint, it's only used to check whether the tables
+// in the hint exist, the join order is built by the join reorder rule.
+func (info *tableHintInfo) ifPreferLeading(tableNames ...*hintTableInfo) bool {
+	if info.leadingJoinOrder == nil {
+		return false
+	}
+	return info.matchTableName(tableNames, info.leadingJoinOrder.tables)
+}
+
 func (info *tableHintInfo) ifPreferTiFlash(tableName *hintTableInfo) *hintTableInfo {
 	if tableName == nil {
 		return nil
@@ -386,6 +469,7 @@ const (
 	expressionClause
 	windowOrderByClause
 	partitionByClause
+	tableFunctionClause
 )
 
 var clauseMsg = map[clauseCode]string{
@@ -401,6 +485,7 @@ var clauseMsg = map[clauseCode]string{
 	expressionClause:    "expression",
 	windowOrderByClause: "window order by",
 	partitionByClause:   "window partition by",
+	tableFunctionClause: "a table function argument",
 }
 
 type capFlagType = uint64
@@ -497,6 +582,9 @@ type PlanBuilder struct {
 	renamingViewName string
 	// isCreateView indicates whether the query is create view.
 	isCreateView bool
+	// updatableViews records the views referenced by the FROM clause of UPDATE or DELETE. They are
+	// built as updatable views if possible, the value is nil if the view is not updatable.
+	updatableViews map[*ast.TableName]*updatableView
 
 	// evalDefaultExpr needs this information to find the corresponding column.
 	// It stores the OutputNames before buildProjection.
@@ -508,6 +596,12 @@ type PlanBuilder struct {
 	// correlatedAggMapper stores columns for correlated aggregates which should be evaluated in outer query.
 	correlatedAggMapper map[*ast.AggregateFuncExpr]*expression.CorrelatedColumn
 
+	// subQueryBlockOffset is the query block offset of the subquery being built by the expression rewriter,
+	// it's 0 if there is no such subquery. subQueryNoDecorrelate is set if the NO_DECORRELATE hint is
+	// specified for the subquery, the applies built from the subquery are not decorrelated.
+	subQueryBlockOffset   int
+	subQueryNoDecorrelate bool
+
 	// isForUpdateRead should be true in either of the following situations
 	// 1. use `inside insert`, `update`, `delete` or `select for update` statement
 	// 2. isolation level is RC
@@ -730,7 +824,7 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.CreateUserStmt, *ast.SetPwdStmt, *ast.AlterInstanceStmt,
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
-		*ast.RenameUserStmt:
+		*ast.RenameUserStmt, *ast.CallStmt, *ast.SignalStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -746,6 +840,40 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 	return nil, ErrUnsupportedType.GenWithStack("Unsupported type %T", node)
 }
 
+// checkCallStmt resolves the procedure of the CALL statement and checks the arguments.
+func (b *PlanBuilder) checkCallStmt(stmt *ast.CallStmt) error {
+	proc := stmt.Procedure
+	if proc.Schema.L == "" {
+		currentDB := b.ctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			return ErrNoDB
+		}
+		proc.Schema = model.NewCIStr(currentDB)
+	}
+	fullName := proc.Schema.O + "." + proc.FnName.O
+	routine, ok := b.is.RoutineByName(proc.Schema, proc.FnName, model.RoutineTypeProcedure)
+	if !ok {
+		return infoschema.ErrRoutineNotExists.GenWithStackByArgs(model.RoutineTypeProcedure.String(), fullName)
+	}
+	if len(routine.Params) != len(proc.Args) {
+		return ErrSpWrongNoOfArgs.GenWithStackByArgs(model.RoutineTypeProcedure.String(), fullName, len(routine.Params), len(proc.Args))
+	}
+	for i, param := range routine.Params {
+		if param.Mode == model.ParamModeIn {
+			continue
+		}
+		if v, ok := proc.Args[i].(*ast.VariableExpr); !ok || v.IsSystem {
+			return ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+		}
+	}
+	var authErr error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		authErr = ErrProcaccessDenied.GenWithStackByArgs("execute", user.AuthUsername, user.AuthHostname, fullName)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ExecutePriv, proc.Schema.L, "", "", authErr)
+	return nil
+}
+
 func (b *PlanBuilder) buildSetConfig(ctx context.Context, v *ast.SetConfigStmt) (Plan, error) {
 	privErr := ErrSpecificAccessDenied.GenWithStackByArgs("CONFIG")
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ConfigPriv, "", "", "", privErr)
@@ -1544,7 +1672,7 @@ func tryGetPkHandleCol(tblInfo *model.TableInfo, allColSchema *expression.Schema
 	return nil, nil, false
 }
 
-func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index) ([]Plan, []*model.IndexInfo, error) {
+func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbName model.CIStr, tbl table.Table, indices []table.Index, partitions []model.CIStr) ([]Plan, []*model.IndexInfo, error) {
 	tblInfo := tbl.Meta()
 	// get index information
 	indexInfos := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
@@ -1560,6 +1688,11 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.IsFulltext() || idxInfo.IsSpatial() || idxInfo.MVIndex {
+			// Skip checking FULLTEXT, SPATIAL and multi-valued indexes, their entries are the tokens, the cells or the
+			// array elements instead of the indexed values.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -1591,6 +1724,9 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 		// For partition tables.
 		if pi := tbl.Meta().GetPartitionInfo(); pi != nil {
 			for _, def := range pi.Definitions {
+				if len(partitions) > 0 && !containsPartitionName(partitions, def.Name) {
+					continue
+				}
 				t := tbl.(table.PartitionedTable).GetPartition(def.ID)
 				reader, err := b.buildPhysicalIndexLookUpReader(ctx, dbName, t, idxInfo)
 				if err != nil {
@@ -1614,6 +1750,39 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 }
 
 func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStmt) (*CheckTable, error) {
+	return b.buildCheckTable(ctx, as, nil)
+}
+
+// buildAlterTableCheckPartitions builds ALTER TABLE ... CHECK PARTITION, it runs the checks of ADMIN CHECK TABLE
+// on the given partitions.
+func (b *PlanBuilder) buildAlterTableCheckPartitions(ctx context.Context, v *ast.AlterTableStmt) (*CheckTable, error) {
+	spec, tblInfo := v.Specs[0], v.Table.TableInfo
+	if tblInfo.GetPartitionInfo() == nil {
+		return nil, ErrPartitionMgmtOnNonpartitioned
+	}
+	var partitions []model.CIStr
+	if !spec.OnAllPartitions {
+		for _, name := range spec.PartitionNames {
+			if tblInfo.FindPartitionDefinitionByName(name.L) == nil {
+				return nil, table.ErrUnknownPartition.GenWithStackByArgs(name.O, v.Table.Name.O)
+			}
+		}
+		partitions = spec.PartitionNames
+	}
+	as := &ast.AdminStmt{Tp: ast.AdminCheckTable, Tables: []*ast.TableName{v.Table}}
+	return b.buildCheckTable(ctx, as, partitions)
+}
+
+func containsPartitionName(names []model.CIStr, name model.CIStr) bool {
+	for _, n := range names {
+		if n.L == name.L {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *PlanBuilder) buildCheckTable(ctx context.Context, as *ast.AdminStmt, partitions []model.CIStr) (*CheckTable, error) {
 	tblName := as.Tables[0]
 	tableInfo := as.Tables[0].TableInfo
 	tbl, ok := b.is.TableByID(tableInfo.ID)
@@ -1621,8 +1790,9 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 		return nil, infoschema.ErrTableNotExists.GenWithStackByArgs(tblName.DBInfo.Name.O, tableInfo.Name.O)
 	}
 	p := &CheckTable{
-		DBName: tblName.Schema.O,
-		Table:  tbl,
+		DBName:     tblName.Schema.O,
+		Table:      tbl,
+		Partitions: partitions,
 	}
 	var readerPlans []Plan
 	var indexInfos []*model.IndexInfo
@@ -1643,10 +1813,16 @@ func (b *PlanBuilder) buildAdminCheckTable(ctx context.Context, as *ast.AdminStm
 		if idx.Meta().State != model.StatePublic {
 			return nil, errors.Errorf("index %s state %s isn't public", as.Index, idx.Meta().State)
 		}
+		if idx.Meta().IsFulltext() || idx.Meta().IsSpatial() {
+			return nil, errors.Errorf("index %s is a %s index, which can't be checked", as.Index, idx.Meta().Tp)
+		}
+		if idx.Meta().MVIndex {
+			return nil, errors.Errorf("index %s is a multi-valued index, which can't be checked", as.Index)
+		}
 		p.CheckIndex = true
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx})
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, []table.Index{idx}, nil)
 	} else {
-		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices())
+		readerPlans, indexInfos, err = b.buildPhysicalIndexLookUpReaders(ctx, tblName.Schema, tbl, tbl.Indices(), partitions)
 	}
 	if err != nil {
 		return nil, errors.Trace(err)
@@ -1713,7 +1889,8 @@ func getColsInfo(tn *ast.TableName) (indicesInfo []*model.IndexInfo, colsInfo []
 		colsInfo = append(colsInfo, col)
 	}
 	for _, idx := range tn.TableInfo.Indices {
-		if idx.State == model.StatePublic {
+		// The statistics of the FULLTEXT, SPATIAL and multi-valued indexes are useless because they're never used as ordinary access paths.
+		if idx.State == model.StatePublic && !idx.IsFulltext() && !idx.IsSpatial() && !idx.MVIndex {
 			indicesInfo = append(indicesInfo, idx)
 		}
 	}
@@ -1928,7 +2105,7 @@ func getColOffsetForAnalyze(colsInfo []*model.ColumnInfo, colID int64) int {
 func getModifiedIndexesInfoForAnalyze(tblInfo *model.TableInfo, allColumns bool, colsInfo []*model.ColumnInfo) []*model.IndexInfo {
 	idxsInfo := make([]*model.IndexInfo, 0, len(tblInfo.Indices))
 	for _, originIdx := range tblInfo.Indices {
-		if originIdx.State != model.StatePublic {
+		if originIdx.State != model.StatePublic || originIdx.IsFulltext() || originIdx.IsSpatial() || originIdx.MVIndex {
 			continue
 		}
 		if allColumns {
@@ -2128,7 +2305,7 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			}
 		}
 		idx := tblInfo.FindIndexByName(idxName.L)
-		if idx == nil || idx.State != model.StatePublic {
+		if idx == nil || idx.State != model.StatePublic || idx.IsFulltext() || idx.IsSpatial() || idx.MVIndex {
 			return nil, ErrAnalyzeMissIndex.GenWithStackByArgs(idxName.O, tblInfo.Name.O)
 		}
 		for i, id := range physicalIDs {
@@ -2172,7 +2349,7 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 		return b.buildAnalyzeTable(as, opts, version)
 	}
 	for _, idx := range tblInfo.Indices {
-		if idx.State == model.StatePublic {
+		if idx.State == model.StatePublic && !idx.IsFulltext() && !idx.IsSpatial() && !idx.MVIndex {
 			for i, id := range physicalIDs {
 				if id == tblInfo.ID {
 					id = -1
@@ -2542,7 +2719,7 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	isView := false
 	isSequence := false
 	switch show.Tp {
-	case ast.ShowTables, ast.ShowTableStatus:
+	case ast.ShowTables, ast.ShowTableStatus, ast.ShowTriggers:
 		if p.DBName == "" {
 			return nil, ErrNoDB
 		}
@@ -2720,6 +2897,10 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 		if raw.DBName == "" {
 			return nil, ErrNoDB
 		}
+	case *ast.CallStmt:
+		if err := b.checkCallStmt(raw); err != nil {
+			return nil, err
+		}
 	case *ast.DropUserStmt:
 		// The main privilege checks for DROP USER are currently performed in executor/simple.go
 		// because they use complex OR conditions (not supported by visitInfo).
@@ -2975,13 +3156,20 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if !ok {
 		return nil, infoschema.ErrTableNotExists.GenWithStackByArgs()
 	}
-	tableInfo := tn.TableInfo
+	tableInfo, dbName := tn.TableInfo, tn.DBInfo.Name
+	var view *updatableView
 	if tableInfo.IsView() {
-		err := errors.Errorf("insert into view %s is not supported now.", tableInfo.Name.O)
-		if insert.IsReplace {
-			err = errors.Errorf("replace into view %s is not supported now.", tableInfo.Name.O)
+		var (
+			restore func()
+			err     error
+		)
+		view, insert, restore, err = b.buildInsertView(ctx, insert, tn)
+		if err != nil {
+			return nil, err
 		}
-		return nil, err
+		defer restore()
+		// The rows are inserted into the base table of the view.
+		tableInfo, dbName = view.baseTblInfo, view.baseDBName
 	}
 	if tableInfo.IsSequence() {
 		err := errors.Errorf("insert into sequence %s is not supported now.", tableInfo.Name.O)
@@ -2991,7 +3179,11 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		return nil, err
 	}
 	// Build Schema with DBName otherwise ColumnRef with DBName cannot match any Column in Schema.
-	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx, tn.Schema, tableInfo)
+	schemaName := tn.Schema
+	if view != nil {
+		schemaName = dbName
+	}
+	schema, names, err := expression.TableInfo2SchemaAndNames(b.ctx, schemaName, tableInfo)
 	if err != nil {
 		return nil, err
 	}
@@ -3007,16 +3199,21 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 		tableColNames: names,
 		IsReplace:     insert.IsReplace,
 	}.Init(b.ctx)
+	if view != nil {
+		insertPlan.ViewChecks = view.checks
+	}
 
 	if tableInfo.GetPartitionInfo() != nil && len(insert.PartitionNames) != 0 {
 		givenPartitionSets := make(map[int64]struct{}, len(insert.PartitionNames))
 		// check partition by name.
 		for _, name := range insert.PartitionNames {
-			id, err := tables.FindPartitionByName(tableInfo, name.L)
+			ids, err := tables.FindPartitionIDsByName(tableInfo, name.L)
 			if err != nil {
 				return nil, err
 			}
-			givenPartitionSets[id] = struct{}{}
+			for _, id := range ids {
+				givenPartitionSets[id] = struct{}{}
+			}
 		}
 		pt := tableInPlan.(table.PartitionedTable)
 		insertPlan.Table = tables.NewPartitionTableWithGivenSets(pt, givenPartitionSets)
@@ -3027,11 +3224,11 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	user := b.ctx.GetSessionVars().User
 	var authErr error
 	if user != nil {
-		authErr = ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername, user.AuthHostname, tableInfo.Name.L)
+		authErr = ErrTableaccessDenied.GenWithStackByArgs("INSERT", user.AuthUsername, user.AuthHostname, tn.TableInfo.Name.L)
 	}
 
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.InsertPriv, tn.DBInfo.Name.L,
-		tableInfo.Name.L, "", authErr)
+		tn.TableInfo.Name.L, "", authErr)
 
 	// `REPLACE INTO` requires both INSERT + DELETE privilege
 	// `ON DUPLICATE KEY UPDATE` requires both INSERT + UPDATE privilege
@@ -3044,9 +3241,9 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	if extraPriv != 0 {
 		if user != nil {
 			cmd := strings.ToUpper(mysql.Priv2Str[extraPriv])
-			authErr = ErrTableaccessDenied.GenWithStackByArgs(cmd, user.AuthUsername, user.AuthHostname, tableInfo.Name.L)
+			authErr = ErrTableaccessDenied.GenWithStackByArgs(cmd, user.AuthUsername, user.AuthHostname, tn.TableInfo.Name.L)
 		}
-		b.visitInfo = appendVisitInfo(b.visitInfo, extraPriv, tn.DBInfo.Name.L, tableInfo.Name.L, "", authErr)
+		b.visitInfo = appendVisitInfo(b.visitInfo, extraPriv, tn.DBInfo.Name.L, tn.TableInfo.Name.L, "", authErr)
 	}
 
 	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
@@ -3103,7 +3300,11 @@ func (b *PlanBuilder) buildInsert(ctx context.Context, insert *ast.InsertStmt) (
 	}
 
 	err = insertPlan.ResolveIndices()
-	return insertPlan, err
+	if err != nil {
+		return nil, err
+	}
+	insertPlan.buildOnInsertFKTriggers(b.ctx, b.is, dbName)
+	return insertPlan, nil
 }
 
 func (p *Insert) resolveOnDuplicate(onDup []*ast.Assignment, tblInfo *model.TableInfo, yield func(ast.ExprNode) (expression.Expression, error)) (map[string]struct{}, error) {
@@ -3848,6 +4049,9 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 					"stats_extended", "", authErr)
 			}
 		}
+		if len(v.Specs) == 1 && v.Specs[0].Tp == ast.AlterTableCheckPartitions {
+			return b.buildAlterTableCheckPartitions(ctx, v)
+		}
 	case *ast.AlterSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("ALTER", b.ctx.GetSessionVars().User.AuthUsername,
@@ -3923,6 +4127,9 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		if len(v.Cols) != schema.Len() {
 			return nil, ddl.ErrViewWrongList
 		}
+		if err := b.checkCreateViewCheckOption(v); err != nil {
+			return nil, err
+		}
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE VIEW", b.ctx.GetSessionVars().User.AuthUsername,
 				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
@@ -4022,6 +4229,49 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 	case *ast.RepairTableStmt:
 		// Repair table command can only be executed by administrator.
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
+	case *ast.CreateRoutineStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Name.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreateRoutinePriv, v.Name.Schema.L, "", "", authErr)
+		if v.Definer.CurrentUser && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		}
+	case *ast.DropRoutineStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrProcaccessDenied.GenWithStackByArgs("alter routine", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Name.Schema.L+"."+v.Name.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterRoutinePriv, v.Name.Schema.L, "", "", authErr)
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L, v.Table.Name.L, "", authErr)
+		if v.Definer.CurrentUser && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+		}
+	case *ast.DropTriggerStmt:
+		// The privilege is checked on the table which the trigger is created on.
+		var tableName string
+		if tbl, _, ok := infoschema.TriggerByName(b.is, v.Name.Schema, v.Name.Name); ok {
+			tableName = tbl.Meta().Name.L
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, tableName)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Name.Schema.L, tableName, "", authErr)
 	case *ast.DropPlacementPolicyStmt, *ast.CreatePlacementPolicyStmt, *ast.AlterPlacementPolicyStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or PLACEMENT_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "PLACEMENT_ADMIN", false, err)
@@ -4123,16 +4373,29 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 }
 
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (Plan, error) {
-	if sem.IsEnabled() {
+	selectIntoInfo := sel.SelectIntoOpt
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		// Only the user variables can be assigned outside of stored procedures.
+		for _, v := range selectIntoInfo.Variables {
+			if col, ok := v.(*ast.ColumnNameExpr); ok {
+				return nil, ErrSpUndeclaredVar.GenWithStackByArgs(col.Name.Name.O)
+			}
+		}
+	} else if sem.IsEnabled() {
 		return nil, ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
-	selectIntoInfo := sel.SelectIntoOpt
 	sel.SelectIntoOpt = nil
 	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
 	if err != nil {
 		return nil, err
 	}
-	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		if targetPlan.Schema().Len() != len(selectIntoInfo.Variables) {
+			return nil, ErrWrongNumberOfColumnsInSelect
+		}
+	} else {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
+	}
 	return &SelectInto{
 		TargetPlan: targetPlan,
 		IntoOpt:    selectIntoInfo,
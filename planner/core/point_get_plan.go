@@ -558,7 +558,11 @@ func newBatchPointGetPlan(
 ) *BatchPointGetPlan {
 	statsInfo := &property.StatsInfo{RowCount: float64(len(patternInExpr.List))}
 	var partitionExpr *tables.PartitionExpr
-	if tbl.GetPartitionInfo() != nil {
+	if pi := tbl.GetPartitionInfo(); pi != nil {
+		// The subpartition can't be located by a single partition column.
+		if pi.Sub != nil {
+			return nil
+		}
 		partitionExpr = getPartitionExpr(ctx, tbl)
 		if partitionExpr == nil {
 			return nil
@@ -1377,17 +1381,20 @@ func buildPointUpdatePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			if len(updateTable.PartitionNames) > 0 {
 				pids := make(map[int64]struct{}, len(updateTable.PartitionNames))
 				for _, name := range updateTable.PartitionNames {
-					pid, err := tables.FindPartitionByName(tbl, name.L)
+					partIDs, err := tables.FindPartitionIDsByName(tbl, name.L)
 					if err != nil {
 						return updatePlan
 					}
-					pids[pid] = struct{}{}
+					for _, pid := range partIDs {
+						pids[pid] = struct{}{}
+					}
 				}
 				pt = tables.NewPartitionTableWithGivenSets(pt, pids)
 			}
 			updatePlan.PartitionedTable = append(updatePlan.PartitionedTable, pt)
 		}
 	}
+	updatePlan.buildOnUpdateFKTriggers(ctx, is, updatePlan.tblID2Table)
 	return updatePlan
 }
 
@@ -1471,6 +1478,9 @@ func buildPointDeletePlan(ctx sessionctx.Context, pointPlan PhysicalPlan, dbName
 			},
 		},
 	}.Init(ctx)
+	is := ctx.GetInfoSchema().(infoschema.InfoSchema)
+	t, _ := is.TableByID(tbl.ID)
+	delPlan.buildOnDeleteFKTriggers(ctx, is, map[int64]table.Table{tbl.ID: t})
 	return delPlan
 }
 
@@ -1520,7 +1530,7 @@ func getPartitionInfo(ctx sessionctx.Context, tbl *model.TableInfo, pairs []name
 	}
 
 	pi := tbl.GetPartitionInfo()
-	if pi == nil {
+	if pi == nil || pi.Sub != nil {
 		return nil, 0, false
 	}
 
@@ -1633,6 +1643,8 @@ func getPartitionColumnPos(idx *model.IndexInfo, partitionExpr *tables.Partition
 		} else {
 			return 0, errors.Errorf("unsupported partition type in BatchGet")
 		}
+	default:
+		return 0, errors.Errorf("unsupported partition type in BatchGet")
 	}
 
 	for i, idxCol := range idx.Columns {
@@ -2203,3 +2203,300 @@ func (s *testPlanSerialSuite) TestPartitionWithVariedDatasources(c *C) {
 		}
 	}
 }
+
+func (s *testPrepareSerialSuite) TestGeneralPlanCache(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(tk.Se.Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil), IsTrue)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_general")
+	tk.MustExec("create table t_general(a int primary key, b int, c varchar(10), index idx_b(b))")
+	tk.MustExec("insert into t_general values(1, 1, 'a'), (2, 2, 'b'), (3, 3, 'c'), (4, 4, 'd')")
+	tk.MustQuery("select * from t_general where b > 2").Sort().Check(testkit.Rows("3 3 c", "4 4 d"))
+	tk.MustQuery("select * from t_general where b > 3").Check(testkit.Rows("4 4 d"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	tk.MustExec("set @@tidb_enable_general_plan_cache = 1")
+	tk.MustQuery("select * from t_general where b > 2").Sort().Check(testkit.Rows("3 3 c", "4 4 d"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select * from t_general where b > 3").Check(testkit.Rows("4 4 d"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from t_general where b > 0").Sort().Check(testkit.Rows("1 1 a", "2 2 b", "3 3 c", "4 4 d"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	tk.MustQuery("select a from t_general where b between 1 and 2").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("select a from t_general where b between 3 and 4").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t_general where b in (1, 3)").Sort().Check(testkit.Rows("1", "3"))
+	tk.MustQuery("select a from t_general where b in (2, 4)").Sort().Check(testkit.Rows("2", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select a from t_general where c = 'b'").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t_general where c = 'd'").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The plans are not shared by the parameters of different types.
+	tk.MustQuery("select a from t_general where c = 1").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select a from t_general where c = 2").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The literals in the select fields and limit are not parameterized.
+	tk.MustQuery("select b + 1 from t_general where b = 1").Check(testkit.Rows("2"))
+	tk.MustQuery("select b + 2 from t_general where b = 1").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select b + 2 from t_general where b = 2").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select b from t_general where b > 1 order by b limit 1").Check(testkit.Rows("2"))
+	tk.MustQuery("select b from t_general where b > 1 order by b limit 2").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The cached plans are invalidated by the schema change.
+	tk.MustExec("alter table t_general add index idx_c(c)")
+	tk.MustQuery("select * from t_general where b > 3").Check(testkit.Rows("4 4 d"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The changes of the transaction are read by the cached plan.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_general values(5, 5, 'e')")
+	tk.MustQuery("select * from t_general where b > 3").Sort().Check(testkit.Rows("4 4 d", "5 5 e"))
+	tk.MustQuery("select * from t_general where b > 4").Check(testkit.Rows("5 5 e"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("rollback")
+
+	// The statements with NULL literals or on the tables with YEAR columns are not cached.
+	tk.MustQuery("select a from t_general where b > null").Check(testkit.Rows())
+	tk.MustQuery("select a from t_general where b > null").Check(testkit.Rows())
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("drop table if exists t_general_year")
+	tk.MustExec("create table t_general_year(a year(4))")
+	tk.MustExec("insert into t_general_year values(69)")
+	tk.MustQuery("select * from t_general_year where a >= 69").Check(testkit.Rows("2069"))
+	tk.MustQuery("select * from t_general_year where a >= 68").Check(testkit.Rows("2069"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// EXPLAIN shows the hits and misses of the cached statement.
+	tk.MustQuery("explain select a from t_general where b < 2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1105 general plan cache miss, hits: 0, misses: 1"))
+	tk.MustQuery("select a from t_general where b < 3").Sort().Check(testkit.Rows("1", "2"))
+	tk.MustQuery("explain select a from t_general where b < 4")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1105 general plan cache hit, hits: 2, misses: 1"))
+
+	tk.MustQuery("select exec_count, plan_cache_hits, plan_cache_misses from information_schema.statements_summary " +
+		"where digest_text = 'select `a` from `t_general` where `b` between ? and ?'").Check(testkit.Rows("2 1 1"))
+	tk.MustExec("set @@tidb_enable_general_plan_cache = 0")
+	tk.MustQuery("select a from t_general where b between 1 and 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The privileges are checked when the cached plan is reused.
+	rootSe := tk.Se
+	tk.MustExec("create user 'u_general'@'localhost'")
+	tk.MustExec("grant select on test.t_general to 'u_general'@'localhost'")
+	userSess := newSession(c, store, "test")
+	c.Assert(userSess.Auth(&auth.UserIdentity{Username: "u_general", Hostname: "localhost"}, nil, nil), IsTrue)
+	mustExec(c, userSess, "set @@tidb_enable_general_plan_cache = 1")
+	tk.Se = userSess
+	tk.MustQuery("select a from t_general where b > 3").Check(testkit.Rows("4"))
+	tk.MustQuery("select a from t_general where b > 2").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.Se = rootSe
+	tk.MustExec("revoke all on test.t_general from 'u_general'@'localhost'")
+	tk.Se = userSess
+	_, err = tk.Exec("select a from t_general where b > 1")
+	c.Assert(core.ErrTableaccessDenied.Equal(err), IsTrue)
+}
+
+func (s *testPrepareSerialSuite) TestGeneralPlanCacheOptimizerVars(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(tk.Se.Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil), IsTrue)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_general_vars")
+	tk.MustExec("create table t_general_vars(a int primary key, b int)")
+	tk.MustExec("insert into t_general_vars values(1, 4), (2, 3), (3, 2), (4, 1)")
+	tk.MustExec("set @@tidb_enable_general_plan_cache = 1")
+	tk.MustQuery("select b from t_general_vars where a > 1").Check(testkit.Rows("3", "2", "1"))
+	tk.MustQuery("select b from t_general_vars where a > 0").Check(testkit.Rows("4", "3", "2", "1"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The plan is rebuilt after the optimizer variable is changed, the new plan has an extra Sort.
+	tk.MustExec("set @@tidb_enable_ordered_result_mode = 1")
+	tk.MustQuery("select b from t_general_vars where a > 0").Check(testkit.Rows("4", "3", "2", "1"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select b from t_general_vars where a > 1").Check(testkit.Rows("3", "2", "1"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(distinct plan_digest) from information_schema.statements_summary " +
+		"where digest_text = 'select `b` from `t_general_vars` where `a` > ?'").Check(testkit.Rows("2"))
+
+	// The plan built before is reused after the variable is changed back.
+	tk.MustExec("set @@tidb_enable_ordered_result_mode = 0")
+	tk.MustQuery("select b from t_general_vars where a > 2").Check(testkit.Rows("2", "1"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+}
+
+func (s *testPrepareSerialSuite) TestInstancePlanCache(c *C) {
+	defer testleak.AfterTest(c)()
+	store, dom, err := newStoreWithBootstrap()
+	c.Assert(err, IsNil)
+	tk := testkit.NewTestKit(c, store)
+	orgEnable := core.PreparedPlanCacheEnabled()
+	defer func() {
+		variable.EnableInstancePlanCache.Store(variable.DefTiDBEnableInstancePlanCache)
+		variable.InstancePlanCacheMaxMemSize.Store(variable.DefTiDBInstancePlanCacheMaxMemSize)
+		core.GlobalInstancePlanCache.Reset()
+		dom.Close()
+		err = store.Close()
+		c.Assert(err, IsNil)
+		core.SetPreparedPlanCache(orgEnable)
+	}()
+	core.SetPreparedPlanCache(true)
+	core.GlobalInstancePlanCache.Reset()
+	tk.Se, err = session.CreateSession4TestWithOpt(store, &session.Opt{
+		PreparedPlanCache: kvcache.NewSimpleLRUCache(100, 0.1, math.MaxUint64),
+	})
+	c.Assert(err, IsNil)
+	c.Assert(tk.Se.Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil), IsTrue)
+
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t_inst")
+	tk.MustExec("create table t_inst(a int primary key, b int, c varchar(10), index idx_b(b))")
+	tk.MustExec("insert into t_inst values(1, 1, 'a'), (2, 2, 'b'), (3, 3, 'c'), (4, 4, 'd')")
+	tk.MustExec("set global tidb_enable_instance_plan_cache = 1")
+
+	tk.MustExec("prepare stmt from 'select a from t_inst where b > ?'")
+	tk.MustExec("set @x = 2")
+	tk.MustQuery("execute stmt using @x").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+
+	// The plan built by one session is reused by the other sessions.
+	rootSe := tk.Se
+	tk.Se = newSession(c, store, "test")
+	tk.MustExec("prepare stmt from 'select a from t_inst where b > ?'")
+	tk.MustExec("set @x = 3")
+	tk.MustQuery("execute stmt using @x").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The plans are not shared by the parameters of different types.
+	tk.MustExec("set @x = 1.5")
+	tk.MustQuery("execute stmt using @x").Sort().Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("set @x = 2.5")
+	tk.MustQuery("execute stmt using @x").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select entry_count, hit_count, miss_count, eviction_count from information_schema.instance_plan_cache").
+		Check(testkit.Rows("2 2 2 0"))
+
+	// The changes of the transaction are read by the plan built without them.
+	tk.MustExec("begin")
+	tk.MustExec("insert into t_inst values(5, 5, 'e')")
+	tk.MustExec("set @x = 3")
+	tk.MustQuery("execute stmt using @x").Sort().Check(testkit.Rows("4", "5"))
+	tk.MustExec("rollback")
+	tk.MustQuery("execute stmt using @x").Check(testkit.Rows("4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The privileges are checked when the shared plan is reused.
+	tk.Se = rootSe
+	tk.MustExec("create user 'u_inst'@'localhost'")
+	tk.MustExec("grant select on test.t_inst to 'u_inst'@'localhost'")
+	userSess := newSession(c, store, "test")
+	c.Assert(userSess.Auth(&auth.UserIdentity{Username: "u_inst", Hostname: "localhost"}, nil, nil), IsTrue)
+	tk.Se = userSess
+	tk.MustExec("prepare stmt from 'select a from t_inst where b > ?'")
+	tk.MustExec("set @x = 1")
+	tk.MustQuery("execute stmt using @x").Sort().Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.Se = rootSe
+	tk.MustExec("revoke all on test.t_inst from 'u_inst'@'localhost'")
+	tk.Se = userSess
+	_, err = tk.Exec("execute stmt using @x")
+	c.Assert(core.ErrTableaccessDenied.Equal(err), IsTrue)
+
+	// The least recently used plans are evicted when the memory usage exceeds the quota.
+	tk.Se = rootSe
+	memUsage := tk.MustQuery("select memory_usage from information_schema.instance_plan_cache").Rows()[0][0].(string)
+	tk.MustExec("set global tidb_instance_plan_cache_max_mem_size = " + memUsage)
+	tk.MustExec("prepare stmt2 from 'select a from t_inst where c = ?'")
+	tk.MustExec("set @y = 'b'")
+	tk.MustQuery("execute stmt2 using @y").Check(testkit.Rows("2"))
+	tk.MustQuery("select eviction_count > 0, evicted_memory > 0, memory_usage <= memory_quota, memory_quota = " + memUsage +
+		" from information_schema.instance_plan_cache").Check(testkit.Rows("1 1 1 1"))
+	tk.MustExec("set @y = 'c'")
+	tk.MustQuery("execute stmt2 using @y").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The session plan cache is used when the instance plan cache is disabled.
+	tk.MustExec("set global tidb_enable_instance_plan_cache = 0")
+	tk.MustQuery("execute stmt2 using @y").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt2 using @y").Check(testkit.Rows("3"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The plans built from the session bindings are not reused by the sessions without the bindings.
+	tk.MustExec("set global tidb_enable_instance_plan_cache = 1")
+	tk.MustExec(fmt.Sprintf("set global tidb_instance_plan_cache_max_mem_size = %d", variable.DefTiDBInstancePlanCacheMaxMemSize))
+	core.GlobalInstancePlanCache.Reset()
+	tk.MustExec("create session binding for select a from t_inst where b > 1 using select a from t_inst ignore index(idx_b) where b > 1")
+	tk.MustExec("prepare stmt3 from 'select a from t_inst where b > ?'")
+	tk.MustExec("set @z = 1")
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache, @@last_plan_from_binding").Check(testkit.Rows("0 1"))
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("2", "3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.Se = newSession(c, store, "test")
+	tk.MustExec("prepare stmt3 from 'select a from t_inst where b > ?'")
+	tk.MustExec("set @z = 2")
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache, @@last_plan_from_binding").Check(testkit.Rows("0 0"))
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache, @@last_plan_from_binding").Check(testkit.Rows("1 0"))
+	tk.MustQuery("select entry_count from information_schema.instance_plan_cache").Check(testkit.Rows("2"))
+
+	// The plans built with the different optimizer variables are not shared.
+	tk.MustExec("set @@tidb_enable_index_merge = 1")
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("set @@tidb_opt_seek_factor = 100")
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt3 using @z").Sort().Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("set @@tidb_enable_ordered_result_mode = 1")
+	tk.MustQuery("execute stmt3 using @z").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("set @@tidb_enable_extended_stats = 1")
+	tk.MustQuery("execute stmt3 using @z").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt3 using @z").Check(testkit.Rows("3", "4"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select entry_count from information_schema.instance_plan_cache").Check(testkit.Rows("6"))
+}
@@ -176,6 +176,10 @@ type preprocessor struct {
 	*PreprocessorReturn
 	*PreprocessExecuteISUpdate
 	err error
+
+	// indexArrayCasts are the `CAST(... AS ... ARRAY)` expressions of the index parts, which are
+	// the only places the array casts are allowed.
+	indexArrayCasts map[*ast.FuncCastExpr]struct{}
 }
 
 func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
@@ -270,6 +274,34 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeDrop
 		p.flag |= inCreateOrDropTable
 		p.checkDropSequenceGrammar(node)
+	case *ast.CreateRoutineStmt:
+		p.stmtTp = TypeCreate
+		// The statements in the routine body are resolved when the routine is invoked.
+		p.resolveRoutineName(node.Name)
+		return in, true
+	case *ast.DropRoutineStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
+		return in, true
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		// The statements in the trigger body are resolved when the trigger is activated.
+		p.resolveRoutineName(node.Table)
+		if node.Name.Schema.L == "" {
+			node.Name.Schema = node.Table.Schema
+		}
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(node.Name)
+		return in, true
+	case *ast.IndexPartSpecification:
+		if cast, ok := node.Expr.(*ast.FuncCastExpr); ok && cast.Tp.Array {
+			if p.indexArrayCasts == nil {
+				p.indexArrayCasts = make(map[*ast.FuncCastExpr]struct{})
+			}
+			p.indexArrayCasts[cast] = struct{}{}
+		}
 	case *ast.FuncCastExpr:
 		p.checkFuncCastExpr(node)
 	case *ast.FuncCallExpr:
@@ -286,6 +318,9 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		if _, ok := node.Source.(*ast.SelectStmt); ok && !isModeOracle && len(node.AsName.L) == 0 {
 			p.err = ddl.ErrDerivedMustHaveAlias.GenWithStackByArgs()
 		}
+		if _, ok := node.Source.(*ast.JSONTable); ok && len(node.AsName.L) == 0 {
+			p.err = ErrTFMustHaveAlias.GenWithStackByArgs()
+		}
 		if v, ok := node.Source.(*ast.TableName); ok && v.TableSample != nil {
 			switch v.TableSample.SampleMethod {
 			case ast.SampleMethodTypeTiDBRegion:
@@ -973,15 +1008,12 @@ func isTableAliasDuplicate(node ast.ResultSetNode, tableAliases map[string]inter
 }
 
 func checkColumnOptions(isTempTable bool, ops []*ast.ColumnOption) (int, error) {
-	isPrimary, isGenerated, isStored := 0, 0, false
+	isPrimary := 0
 
 	for _, op := range ops {
 		switch op.Tp {
 		case ast.ColumnOptionPrimaryKey:
 			isPrimary = 1
-		case ast.ColumnOptionGenerated:
-			isGenerated = 1
-			isStored = op.Stored
 		case ast.ColumnOptionAutoRandom:
 			if isTempTable {
 				return isPrimary, ErrOptOnTemporaryTable.GenWithStackByArgs("auto_random")
@@ -989,10 +1021,6 @@ func checkColumnOptions(isTempTable bool, ops []*ast.ColumnOption) (int, error)
 		}
 	}
 
-	if isPrimary > 0 && isGenerated > 0 && !isStored {
-		return isPrimary, ErrUnsupportedOnGeneratedColumn.GenWithStackByArgs("Defining a virtual generated column as primary key")
-	}
-
 	return isPrimary, nil
 }
 
@@ -1380,6 +1408,17 @@ func (p *preprocessor) stmtType() string {
 	}
 }
 
+func (p *preprocessor) resolveRoutineName(tn *ast.TableName) {
+	if tn.Schema.L == "" {
+		currentDB := p.ctx.GetSessionVars().CurrentDB
+		if currentDB == "" {
+			p.err = errors.Trace(ErrNoDB)
+			return
+		}
+		tn.Schema = model.NewCIStr(currentDB)
+	}
+}
+
 func (p *preprocessor) handleTableName(tn *ast.TableName) {
 	if tn.Schema.L == "" {
 		if _, ok := p.withName[tn.Name.L]; ok {
@@ -1521,6 +1560,22 @@ func (p *preprocessor) resolveCreateSequenceStmt(stmt *ast.CreateSequenceStmt) {
 }
 
 func (p *preprocessor) checkFuncCastExpr(node *ast.FuncCastExpr) {
+	if node.Tp.Array {
+		if _, ok := p.indexArrayCasts[node]; !ok {
+			p.err = ErrNotSupportedYet.GenWithStackByArgs("Use of CAST( .. AS .. ARRAY) outside of functional index in CREATE(non-SELECT)/ALTER TABLE or in general expressions")
+			return
+		}
+		switch node.Tp.Tp {
+		case mysql.TypeLonglong, mysql.TypeDouble:
+		case mysql.TypeString, mysql.TypeVarString:
+			if node.Tp.Flen == types.UnspecifiedLength {
+				p.err = ErrNotSupportedYet.GenWithStackByArgs("CAST-ing data to array of char/binary BLOBs")
+			}
+		default:
+			p.err = ErrNotSupportedYet.GenWithStackByArgs(fmt.Sprintf("CAST-ing data to array of %s", node.Tp.CompactStr()))
+		}
+		return
+	}
 	if node.Tp.EvalType() == types.ETDecimal {
 		if node.Tp.Flen >= node.Tp.Decimal && node.Tp.Flen <= mysql.MaxDecimalWidth && node.Tp.Decimal <= mysql.MaxDecimalScale {
 			// valid
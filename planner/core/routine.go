@@ -0,0 +1,289 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+type routineKeyType int
+
+func (k routineKeyType) String() string {
+	return "routine_compile_stack"
+}
+
+// routineCompileStackKey is the key of the stored functions being compiled in the session.
+const routineCompileStackKey routineKeyType = 0
+
+// ParseRoutineDefinition parses the CREATE statement of a stored routine with the SQL mode
+// in effect when the routine was created.
+func ParseRoutineDefinition(sctx sessionctx.Context, routine *model.RoutineInfo) (*ast.CreateRoutineStmt, error) {
+	p := parser.New()
+	p.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	p.SetSQLMode(routine.SQLMode)
+	stmt, err := p.ParseOneStmt(routine.Definition, routine.Charset, routine.Collate)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	createStmt, ok := stmt.(*ast.CreateRoutineStmt)
+	if !ok {
+		return nil, errors.Errorf("invalid definition of routine %s", routine.Name.O)
+	}
+	return createStmt, nil
+}
+
+// RoutineFieldType returns the type of a variable declared in the stored routine of the database.
+func RoutineFieldType(is infoschema.InfoSchema, db model.CIStr, tp *types.FieldType) (*types.FieldType, error) {
+	tp = tp.Clone()
+	var chs, coll string
+	if dbInfo, ok := is.SchemaByName(db); ok {
+		chs, coll = dbInfo.Charset, dbInfo.Collate
+	}
+	return tp, ddl.SetRoutineFieldType(tp, chs, coll)
+}
+
+func buildRoutineFunctionByName(sctx sessionctx.Context, name string, args []expression.Expression) (expression.Expression, error) {
+	is, ok := sctx.GetInfoSchema().(infoschema.InfoSchema)
+	if !ok {
+		return nil, errors.Errorf("no information schema to build function %s", name)
+	}
+	sep := strings.IndexByte(name, '.')
+	db, fn := model.NewCIStr(name[:sep]), model.NewCIStr(name[sep+1:])
+	routine, ok := is.RoutineByName(db, fn, model.RoutineTypeFunction)
+	if !ok {
+		return nil, infoschema.ErrRoutineNotExists.GenWithStackByArgs(model.RoutineTypeFunction.String(), name)
+	}
+	return buildRoutineFunction(sctx, is, db, routine, args)
+}
+
+// buildRoutineFunction compiles the stored function and builds a scalar function calling it.
+func buildRoutineFunction(sctx sessionctx.Context, is infoschema.InfoSchema, db model.CIStr, routine *model.RoutineInfo, args []expression.Expression) (expression.Expression, error) {
+	name := db.L + "." + routine.Name.L
+	if len(args) != len(routine.Params) {
+		return nil, ErrSpWrongNoOfArgs.GenWithStackByArgs(routine.Type.String(), db.O+"."+routine.Name.O, len(routine.Params), len(args))
+	}
+	stack, _ := sctx.Value(routineCompileStackKey).([]string)
+	for _, compiling := range stack {
+		if compiling == name {
+			return nil, ErrSpNoRecursion
+		}
+	}
+	sctx.SetValue(routineCompileStackKey, append(stack, name))
+	// The unqualified names in the routine body refer to the database of the routine.
+	vars := sctx.GetSessionVars()
+	currentDB := vars.CurrentDB
+	vars.CurrentDB = db.O
+	defer func() {
+		vars.CurrentDB = currentDB
+		sctx.SetValue(routineCompileStackKey, stack)
+	}()
+
+	stmt, err := ParseRoutineDefinition(sctx, routine)
+	if err != nil {
+		return nil, err
+	}
+	c := &routineCompiler{sctx: sctx, is: is, db: db, scopes: []map[string]int{{}}}
+	fn := &expression.StoredFunction{Name: name, RetTp: routine.Returns}
+	for _, param := range routine.Params {
+		fn.Params = append(fn.Params, param.Tp)
+		c.declare(param.Name.O, param.Tp)
+	}
+	body, err := c.compileStmt(stmt.Body)
+	if err != nil {
+		return nil, err
+	}
+	fn.Body = &expression.RoutineBlock{Stmts: body}
+	fn.Locals = c.locals
+	return expression.NewStoredFunction(sctx, fn, args)
+}
+
+// routineCompiler compiles the body of a stored function.
+type routineCompiler struct {
+	sctx   sessionctx.Context
+	is     infoschema.InfoSchema
+	db     model.CIStr
+	locals []*types.FieldType
+	// scopes map the names of the visible local variables to their slots in the frame.
+	scopes []map[string]int
+}
+
+func (c *routineCompiler) declare(name string, tp *types.FieldType) int {
+	slot := len(c.locals)
+	c.locals = append(c.locals, tp)
+	c.scopes[len(c.scopes)-1][strings.ToLower(name)] = slot
+	return slot
+}
+
+func (c *routineCompiler) lookup(name string) (int, bool) {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if slot, ok := c.scopes[i][strings.ToLower(name)]; ok {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+
+func (c *routineCompiler) compileExpr(expr ast.ExprNode) (expression.Expression, error) {
+	if expr == nil {
+		return nil, nil
+	}
+	var columns []*expression.Column
+	var names types.NameSlice
+	visible := make(map[string]struct{})
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		for name, slot := range c.scopes[i] {
+			if _, ok := visible[name]; ok {
+				continue
+			}
+			visible[name] = struct{}{}
+			columns = append(columns, &expression.Column{
+				UniqueID: c.sctx.GetSessionVars().AllocPlanColumnID(),
+				Index:    slot,
+				RetType:  c.locals[slot],
+			})
+			names = append(names, &types.FieldName{ColName: model.NewCIStr(name)})
+		}
+	}
+	return rewriteAstExpr(c.sctx, expr, expression.NewSchema(columns...), names)
+}
+
+func (c *routineCompiler) compileStmts(stmts []ast.StmtNode) ([]expression.RoutineStmt, error) {
+	compiled := make([]expression.RoutineStmt, 0, len(stmts))
+	for _, stmt := range stmts {
+		s, err := c.compileStmt(stmt)
+		if err != nil {
+			return nil, err
+		}
+		compiled = append(compiled, s...)
+	}
+	return compiled, nil
+}
+
+func (c *routineCompiler) compileStmt(stmt ast.StmtNode) (_ []expression.RoutineStmt, err error) {
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.compileBlock(x)
+	case *ast.ProcedureIfStmt:
+		r := &expression.RoutineIf{}
+		for _, block := range x.Blocks {
+			cond, err := c.compileExpr(block.Cond)
+			if err != nil {
+				return nil, err
+			}
+			stmts, err := c.compileStmts(block.Stmts)
+			if err != nil {
+				return nil, err
+			}
+			r.Conds = append(r.Conds, cond)
+			r.Blocks = append(r.Blocks, stmts)
+		}
+		if r.Else, err = c.compileStmts(x.Else); err != nil {
+			return nil, err
+		}
+		return []expression.RoutineStmt{r}, nil
+	case *ast.ProcedureLoopStmt:
+		r := &expression.RoutineLoop{Label: strings.ToLower(x.Label), Tp: x.Tp}
+		if r.Cond, err = c.compileExpr(x.Cond); err != nil {
+			return nil, err
+		}
+		if r.Stmts, err = c.compileStmts(x.Stmts); err != nil {
+			return nil, err
+		}
+		return []expression.RoutineStmt{r}, nil
+	case *ast.ProcedureJumpStmt:
+		return []expression.RoutineStmt{&expression.RoutineJump{Tp: x.Tp, Label: strings.ToLower(x.Label)}}, nil
+	case *ast.ProcedureReturnStmt:
+		r := &expression.RoutineReturn{}
+		if r.Expr, err = c.compileExpr(x.Expr); err != nil {
+			return nil, err
+		}
+		return []expression.RoutineStmt{r}, nil
+	case *ast.SignalStmt:
+		r := &expression.RoutineSignal{SQLState: x.SQLState}
+		for _, item := range x.Items {
+			value, err := c.compileExpr(item.Value)
+			if err != nil {
+				return nil, err
+			}
+			if item.Name == ast.SignalMySQLErrno {
+				r.Code = value
+			} else {
+				r.Message = value
+			}
+		}
+		return []expression.RoutineStmt{r}, nil
+	case *ast.SetStmt:
+		stmts := make([]expression.RoutineStmt, 0, len(x.Variables))
+		for _, v := range x.Variables {
+			slot, ok := c.lookup(v.Name)
+			if !ok || !v.IsSystem || v.IsGlobal {
+				return nil, ErrSpUndeclaredVar.GenWithStackByArgs(v.Name)
+			}
+			value, err := c.compileExpr(v.Value)
+			if err != nil {
+				return nil, err
+			}
+			stmts = append(stmts, &expression.RoutineSet{Slot: slot, Tp: c.locals[slot], Expr: value})
+		}
+		return stmts, nil
+	}
+	return nil, ErrNotSupportedYet.GenWithStackByArgs("SQL statements in stored functions")
+}
+
+func (c *routineCompiler) compileBlock(block *ast.ProcedureBlock) ([]expression.RoutineStmt, error) {
+	c.scopes = append(c.scopes, make(map[string]int))
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	r := &expression.RoutineBlock{Label: strings.ToLower(block.Label)}
+	for _, decl := range block.Decls {
+		d, ok := decl.(*ast.ProcedureDecl)
+		if !ok {
+			return nil, ErrNotSupportedYet.GenWithStackByArgs("cursors and handlers in stored functions")
+		}
+		tp, err := RoutineFieldType(c.is, c.db, d.Tp)
+		if err != nil {
+			return nil, err
+		}
+		// The default value can't refer to the variables declared by itself.
+		value, err := c.compileExpr(d.Default)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range d.Names {
+			set := &expression.RoutineSet{Slot: c.declare(name, tp), Tp: tp}
+			if value != nil {
+				set.Expr = value.Clone()
+			}
+			r.Stmts = append(r.Stmts, set)
+		}
+	}
+	stmts, err := c.compileStmts(block.Stmts)
+	if err != nil {
+		return nil, err
+	}
+	r.Stmts = append(r.Stmts, stmts...)
+	return []expression.RoutineStmt{r}, nil
+}
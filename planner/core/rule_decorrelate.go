@@ -22,6 +22,8 @@ import (
 	"github.com/pingcap/tidb/expression/aggregation"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/planner/property"
+	"github.com/pingcap/tidb/statistics"
 	"github.com/pingcap/tidb/types"
 )
 
@@ -41,6 +43,22 @@ func (la *LogicalAggregation) canPullUp() bool {
 	if len(la.GroupByItems) > 0 {
 		return false
 	}
+	return la.outputNullOnEmpty()
+}
+
+// canPullUpAcrossApply checks if the aggregation can be pulled up across an apply with the join type, the
+// aggregation is grouped by the unique key of the outer plan after being pulled up.
+func (la *LogicalAggregation) canPullUpAcrossApply(joinType JoinType) bool {
+	if len(la.GroupByItems) > 0 && joinType == InnerJoin {
+		return true
+	}
+	// The outer rows without matched inner rows are kept by the left outer join, the aggregate functions
+	// must output NULL for them.
+	return la.outputNullOnEmpty()
+}
+
+// outputNullOnEmpty checks if all the aggregate functions output NULL when the input rows are NULL.
+func (la *LogicalAggregation) outputNullOnEmpty() bool {
 	for _, f := range la.AggFuncs {
 		for _, arg := range f.Args {
 			expr := expression.EvaluateExprWithNull(la.ctx, la.children[0].Schema(), arg)
@@ -52,6 +70,34 @@ func (la *LogicalAggregation) canPullUp() bool {
 	return true
 }
 
+// canPullUpAcrossApply checks if the projection can be pulled up across an apply with the join type. The outer
+// rows without matched inner rows are kept by the left outer join, the expressions must output NULL for them.
+func (p *LogicalProjection) canPullUpAcrossApply(joinType JoinType) bool {
+	if joinType != LeftOuterJoin {
+		return true
+	}
+	if dual, ok := p.children[0].(*LogicalTableDual); ok && dual.RowCount > 0 {
+		return true
+	}
+	for _, expr := range p.Exprs {
+		expr = expression.EvaluateExprWithNull(p.ctx, p.children[0].Schema(), expr)
+		if con, ok := expr.(*expression.Constant); !ok || !con.Value.IsNull() {
+			return false
+		}
+	}
+	return true
+}
+
+// isExistsApply checks if the apply only checks whether the inner plan is empty, e.g. EXISTS (SELECT ...).
+func (la *LogicalApply) isExistsApply() bool {
+	switch la.JoinType {
+	case SemiJoin, AntiSemiJoin, LeftOuterSemiJoin, AntiLeftOuterSemiJoin:
+	default:
+		return false
+	}
+	return len(la.EqualConditions)+len(la.LeftConditions)+len(la.RightConditions)+len(la.OtherConditions) == 0
+}
+
 // deCorColFromEqExpr checks whether it's an equal condition of form `col = correlated col`. If so we will change the decorrelated
 // column to normal column to make a new equal condition.
 func (la *LogicalApply) deCorColFromEqExpr(expr expression.Expression) expression.Expression {
@@ -129,6 +175,9 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 			join := &apply.LogicalJoin
 			join.self = join
 			p = join
+		} else if apply.NoDecorrelate {
+			// The apply is kept as required by the NO_DECORRELATE hint of the subquery.
+			goto NoOptimize
 		} else if sel, ok := innerPlan.(*LogicalSelection); ok {
 			// If the inner plan is a selection, we add this condition to join predicates.
 			// Notice that no matter what kind of join is, it's always right.
@@ -146,7 +195,7 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 				apply.SetChildren(outerPlan, innerPlan)
 				return s.optimize(ctx, p, opt)
 			}
-		} else if proj, ok := innerPlan.(*LogicalProjection); ok {
+		} else if proj, ok := innerPlan.(*LogicalProjection); ok && proj.canPullUpAcrossApply(apply.JoinType) {
 			for i, expr := range proj.Exprs {
 				proj.Exprs[i] = expr.Decorrelate(outerPlan.Schema())
 			}
@@ -167,61 +216,7 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 			return s.optimize(ctx, p, opt)
 		} else if agg, ok := innerPlan.(*LogicalAggregation); ok {
 			if apply.canPullUpAgg() && agg.canPullUp() {
-				innerPlan = agg.children[0]
-				apply.JoinType = LeftOuterJoin
-				apply.SetChildren(outerPlan, innerPlan)
-				agg.SetSchema(apply.Schema())
-				agg.GroupByItems = expression.Column2Exprs(outerPlan.Schema().Keys[0])
-				newAggFuncs := make([]*aggregation.AggFuncDesc, 0, apply.Schema().Len())
-
-				outerColsInSchema := make([]*expression.Column, 0, outerPlan.Schema().Len())
-				for i, col := range outerPlan.Schema().Columns {
-					first, err := aggregation.NewAggFuncDesc(agg.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
-					if err != nil {
-						return nil, err
-					}
-					newAggFuncs = append(newAggFuncs, first)
-
-					outerCol, _ := outerPlan.Schema().Columns[i].Clone().(*expression.Column)
-					outerCol.RetType = first.RetTp
-					outerColsInSchema = append(outerColsInSchema, outerCol)
-				}
-				apply.SetSchema(expression.MergeSchema(expression.NewSchema(outerColsInSchema...), innerPlan.Schema()))
-				resetNotNullFlag(apply.schema, outerPlan.Schema().Len(), apply.schema.Len())
-
-				for i, aggFunc := range agg.AggFuncs {
-					aggArgs := make([]expression.Expression, 0, len(aggFunc.Args))
-					for _, arg := range aggFunc.Args {
-						switch expr := arg.(type) {
-						case *expression.Column:
-							if idx := apply.schema.ColumnIndex(expr); idx != -1 {
-								aggArgs = append(aggArgs, apply.schema.Columns[idx])
-							} else {
-								aggArgs = append(aggArgs, expr)
-							}
-						case *expression.ScalarFunction:
-							expr.RetType = expr.RetType.Clone()
-							expr.RetType.Flag &= ^mysql.NotNullFlag
-							aggArgs = append(aggArgs, expr)
-						default:
-							aggArgs = append(aggArgs, expr)
-						}
-					}
-					desc, err := aggregation.NewAggFuncDesc(agg.ctx, agg.AggFuncs[i].Name, aggArgs, agg.AggFuncs[i].HasDistinct)
-					if err != nil {
-						return nil, err
-					}
-					newAggFuncs = append(newAggFuncs, desc)
-				}
-				agg.AggFuncs = newAggFuncs
-				np, err := s.optimize(ctx, p, opt)
-				if err != nil {
-					return nil, err
-				}
-				agg.SetChildren(np)
-				// TODO: Add a Projection if any argument of aggregate funcs or group by items are scalar functions.
-				// agg.buildProjectionIfNecessary()
-				return agg, nil
+				return s.pullUpAgg(ctx, apply, agg, outerPlan.Schema().Keys[0], opt)
 			}
 			// We can pull up the equal conditions below the aggregation as the join key of the apply, if only
 			// the equal conditions contain the correlated column of this apply.
@@ -295,8 +290,36 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 			innerPlan = sort.children[0]
 			apply.SetChildren(outerPlan, innerPlan)
 			return s.optimize(ctx, p, opt)
+		} else if join, ok := innerPlan.(*LogicalJoin); ok {
+			// If the inner plan is a join, the correlated conditions of the join can be evaluated by the apply.
+			if conds := pullUpCorrelatedConds(join, outerPlan.Schema()); len(conds) > 0 {
+				newConds := make([]expression.Expression, 0, len(conds))
+				for _, cond := range conds {
+					newConds = append(newConds, cond.Decorrelate(outerPlan.Schema()))
+				}
+				apply.AttachOnConds(newConds)
+				return s.optimize(ctx, p, opt)
+			}
+		} else if limit, ok := innerPlan.(*LogicalLimit); ok {
+			// The semi apply without conditions only checks whether the inner plan is empty, e.g.
+			// EXISTS (SELECT ... LIMIT 1), the limit can be removed if it doesn't filter out all the rows.
+			if apply.isExistsApply() && limit.Offset == 0 && limit.Count > 0 {
+				innerPlan = limit.children[0]
+				apply.SetChildren(outerPlan, innerPlan)
+				return s.optimize(ctx, p, opt)
+			}
+		}
+		if len(apply.CorCols) > 0 {
+			np, ok, err := s.unnestApply(ctx, apply, opt)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return np, nil
+			}
 		}
 	}
+NoOptimize:
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
 		np, err := s.optimize(ctx, child, opt)
@@ -309,6 +332,614 @@ func (s *decorrelateSolver) optimize(ctx context.Context, p LogicalPlan, opt *lo
 	return p, nil
 }
 
+// pullUpAgg pulls the aggregation in the inner plan up above the apply, the aggregation is grouped by the key
+// columns identifying the outer rows in addition to its own group by items, e.g.
+// Apply(outer, Agg(X)) => Agg{group by key}(Apply(outer, X)).
+func (s *decorrelateSolver) pullUpAgg(ctx context.Context, apply *LogicalApply, agg *LogicalAggregation, key []*expression.Column, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	outerPlan, innerPlan := apply.children[0], agg.children[0]
+	// The scalar aggregation always outputs one row, the rows of the outer plan must be kept even if the
+	// inner plan is empty.
+	if len(agg.GroupByItems) == 0 {
+		apply.JoinType = LeftOuterJoin
+	}
+	apply.SetChildren(outerPlan, innerPlan)
+	agg.SetSchema(apply.Schema())
+	groupByItems := expression.Column2Exprs(key)
+	for _, item := range agg.GroupByItems {
+		groupByItems = append(groupByItems, item.Decorrelate(outerPlan.Schema()))
+	}
+	agg.GroupByItems = groupByItems
+	newAggFuncs := make([]*aggregation.AggFuncDesc, 0, apply.Schema().Len())
+
+	outerColsInSchema := make([]*expression.Column, 0, outerPlan.Schema().Len())
+	for i, col := range outerPlan.Schema().Columns {
+		first, err := aggregation.NewAggFuncDesc(agg.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
+		if err != nil {
+			return nil, err
+		}
+		newAggFuncs = append(newAggFuncs, first)
+
+		outerCol, _ := outerPlan.Schema().Columns[i].Clone().(*expression.Column)
+		outerCol.RetType = first.RetTp
+		outerColsInSchema = append(outerColsInSchema, outerCol)
+	}
+	apply.SetSchema(expression.MergeSchema(expression.NewSchema(outerColsInSchema...), innerPlan.Schema()))
+	if apply.JoinType == LeftOuterJoin {
+		resetNotNullFlag(apply.schema, outerPlan.Schema().Len(), apply.schema.Len())
+	}
+
+	for i, aggFunc := range agg.AggFuncs {
+		aggArgs := make([]expression.Expression, 0, len(aggFunc.Args))
+		for _, arg := range aggFunc.Args {
+			switch expr := arg.Decorrelate(outerPlan.Schema()).(type) {
+			case *expression.Column:
+				if idx := apply.schema.ColumnIndex(expr); idx != -1 {
+					aggArgs = append(aggArgs, apply.schema.Columns[idx])
+				} else {
+					aggArgs = append(aggArgs, expr)
+				}
+			case *expression.ScalarFunction:
+				expr.RetType = expr.RetType.Clone()
+				expr.RetType.Flag &= ^mysql.NotNullFlag
+				aggArgs = append(aggArgs, expr)
+			default:
+				aggArgs = append(aggArgs, expr)
+			}
+		}
+		desc, err := aggregation.NewAggFuncDesc(agg.ctx, agg.AggFuncs[i].Name, aggArgs, agg.AggFuncs[i].HasDistinct)
+		if err != nil {
+			return nil, err
+		}
+		newAggFuncs = append(newAggFuncs, desc)
+	}
+	agg.AggFuncs = newAggFuncs
+	np, err := s.optimize(ctx, apply, opt)
+	if err != nil {
+		return nil, err
+	}
+	agg.SetChildren(np)
+	// TODO: Add a Projection if any argument of aggregate funcs or group by items are scalar functions.
+	// agg.buildProjectionIfNecessary()
+	return agg, nil
+}
+
+// pullUpCorrelatedConds pulls the conditions referencing the outer schema out of the join in the inner plan of an
+// apply, e.g. Apply(outer, Join(Selection{t.a > outer.a}(X), Y)) => Apply{t.a > outer.a}(outer, Join(X, Y)).
+// Only the conditions of inner joins and the selections on the sides whose rows are kept as they are, e.g. the
+// left side of a left outer join, can be pulled up.
+func pullUpCorrelatedConds(join *LogicalJoin, outerSchema *expression.Schema) []expression.Expression {
+	isCorrelated := func(expr expression.Expression) bool {
+		return isCorrelatedExpr(expr, outerSchema)
+	}
+	var conds, pulled []expression.Expression
+	if join.JoinType == InnerJoin {
+		join.LeftConditions, pulled = expression.FilterOutInPlace(join.LeftConditions, isCorrelated)
+		conds = append(conds, pulled...)
+		join.RightConditions, pulled = expression.FilterOutInPlace(join.RightConditions, isCorrelated)
+		conds = append(conds, pulled...)
+		join.OtherConditions, pulled = expression.FilterOutInPlace(join.OtherConditions, isCorrelated)
+		conds = append(conds, pulled...)
+	}
+	for i, child := range join.children {
+		if !canPullUpFromJoinChild(join.JoinType, i) {
+			continue
+		}
+		switch x := child.(type) {
+		case *LogicalSelection:
+			x.Conditions, pulled = expression.FilterOutInPlace(x.Conditions, isCorrelated)
+			conds = append(conds, pulled...)
+			if len(x.Conditions) == 0 {
+				join.SetChild(i, x.children[0])
+			}
+		case *LogicalJoin:
+			conds = append(conds, pullUpCorrelatedConds(x, outerSchema)...)
+		}
+	}
+	return conds
+}
+
+// unnestApply decorrelates the apply by pushing it down across the operator of the inner plan and pulling the
+// operator up, the operator works on the rows of each outer row with the help of the unique key of the outer
+// plan. If the outer plan has no unique key, the outer rows are numbered by row_number() to generate one.
+// It returns false if the apply can't be decorrelated or the decorrelated plan is more expensive.
+func (s *decorrelateSolver) unnestApply(ctx context.Context, apply *LogicalApply, opt *logicalOptimizeOp) (LogicalPlan, bool, error) {
+	if !apply.canUnnest() || !preferUnnest(apply) {
+		return nil, false, nil
+	}
+	outerPlan := apply.children[0]
+	originSchema := apply.Schema()
+	if len(outerPlan.Schema().Keys) == 0 {
+		window, rowNumber, err := newRowNumberWindow(outerPlan, nil, nil)
+		if err != nil {
+			return nil, false, err
+		}
+		window.schema.Keys = []expression.KeyInfo{{rowNumber}}
+		outerPlan = window
+	}
+	// The outer rows are distinct since the unique key is included, using all the columns rather than the key
+	// to group the rows allows the predicates on the outer columns to be pushed down across the operators.
+	key := outerPlan.Schema().Clone().Columns
+	// The semi apply is converted to an inner apply, the joined rows are filtered by the conditions and then
+	// deduplicated by the columns of the outer plan, e.g.
+	// SemiApply{conds}(outer, X) => Agg{group by outer}(Selection{conds}(Apply(outer, X))).
+	var (
+		semiSel *LogicalSelection
+		semiAgg *LogicalAggregation
+	)
+	if apply.JoinType == SemiJoin {
+		conds := expression.ScalarFuncs2Exprs(apply.EqualConditions)
+		conds = append(conds, apply.LeftConditions...)
+		conds = append(conds, apply.RightConditions...)
+		conds = append(conds, apply.OtherConditions...)
+		apply.JoinType = InnerJoin
+		apply.EqualConditions, apply.LeftConditions, apply.RightConditions, apply.OtherConditions = nil, nil, nil, nil
+		if len(conds) > 0 {
+			semiSel = LogicalSelection{Conditions: conds}.Init(apply.ctx, apply.blockOffset)
+		}
+		semiAgg = LogicalAggregation{GroupByItems: expression.Column2Exprs(key)}.Init(apply.ctx, apply.blockOffset)
+		aggSchema := expression.NewSchema(make([]*expression.Column, 0, outerPlan.Schema().Len())...)
+		for _, col := range outerPlan.Schema().Columns {
+			first, err := aggregation.NewAggFuncDesc(apply.ctx, ast.AggFuncFirstRow, []expression.Expression{col}, false)
+			if err != nil {
+				return nil, false, err
+			}
+			semiAgg.AggFuncs = append(semiAgg.AggFuncs, first)
+			newCol, _ := col.Clone().(*expression.Column)
+			newCol.RetType = first.RetTp
+			aggSchema.Append(newCol)
+		}
+		aggSchema.Keys = outerPlan.Schema().Clone().Keys
+		semiAgg.SetSchema(aggSchema)
+	}
+	apply.SetChildren(outerPlan, apply.children[1])
+	apply.SetSchema(buildLogicalJoinSchema(apply.JoinType, apply))
+
+	var (
+		np  LogicalPlan
+		err error
+	)
+	switch inner := apply.children[1].(type) {
+	case *LogicalAggregation:
+		np, err = s.pullUpAgg(ctx, apply, inner, key, opt)
+	case *LogicalLimit:
+		np, err = s.pullUpLimit(ctx, apply, inner, key, opt)
+	case *LogicalWindow:
+		np, err = s.pullUpWindow(ctx, apply, inner, key, opt)
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if semiSel != nil {
+		semiSel.SetChildren(np)
+		np = semiSel
+	}
+	if semiAgg != nil {
+		semiAgg.SetChildren(np)
+		np = semiAgg
+	}
+	if np.Schema().Len() != originSchema.Len() {
+		proj := LogicalProjection{Exprs: expression.Column2Exprs(originSchema.Columns)}.Init(apply.ctx, apply.blockOffset)
+		proj.SetSchema(originSchema)
+		proj.SetChildren(np)
+		np = proj
+	}
+	return np, true, nil
+}
+
+// canUnnest checks if the apply can be decorrelated by unnestApply.
+func (la *LogicalApply) canUnnest() bool {
+	joinType := la.JoinType
+	switch joinType {
+	case InnerJoin, LeftOuterJoin:
+		if len(la.EqualConditions)+len(la.LeftConditions)+len(la.RightConditions)+len(la.OtherConditions) > 0 {
+			return false
+		}
+	case SemiJoin:
+		joinType = InnerJoin
+	default:
+		return false
+	}
+	if len(la.children[0].Schema().Keys) == 0 && !la.ctx.GetSessionVars().EnableWindowFunction {
+		return false
+	}
+	return canUnnestInner(la.children[1], joinType, la.children[0].Schema())
+}
+
+// canUnnestInner checks if the operator of the inner plan can be pulled up across an apply with the join type,
+// and the apply pushed down can be decorrelated as well. Otherwise the decorrelated plan still executes the
+// inner plan for each outer row.
+func canUnnestInner(inner LogicalPlan, joinType JoinType, outerSchema *expression.Schema) bool {
+	var child LogicalPlan
+	switch x := inner.(type) {
+	case *LogicalAggregation:
+		if !x.canPullUpAcrossApply(joinType) {
+			return false
+		}
+		if len(x.GroupByItems) == 0 {
+			joinType = LeftOuterJoin
+		}
+		child = x.children[0]
+	case *LogicalLimit:
+		if !x.ctx.GetSessionVars().EnableWindowFunction || x.Count == 0 || (joinType == LeftOuterJoin && x.Offset > 0) {
+			return false
+		}
+		child = x.children[0]
+		if sort, ok := child.(*LogicalSort); ok {
+			for _, item := range sort.ByItems {
+				switch item.Expr.(type) {
+				case *expression.Column, *expression.CorrelatedColumn:
+				default:
+					return false
+				}
+			}
+			child = sort.children[0]
+		}
+	case *LogicalWindow:
+		if joinType != InnerJoin {
+			return false
+		}
+		child = x.children[0]
+	default:
+		return false
+	}
+	return canDecorrelate(child, joinType, outerSchema)
+}
+
+// canDecorrelate checks if the apply with the inner plan can be converted to a join, it follows the way the inner
+// plan is decorrelated by the rule without modifying the plan.
+func canDecorrelate(inner LogicalPlan, joinType JoinType, outerSchema *expression.Schema) bool {
+	if !hasCorrelatedCols(inner, outerSchema) {
+		return true
+	}
+	switch x := inner.(type) {
+	case *LogicalSelection:
+		return canDecorrelate(x.children[0], joinType, outerSchema)
+	case *LogicalSort:
+		return canDecorrelate(x.children[0], joinType, outerSchema)
+	case *LogicalMaxOneRow:
+		return x.children[0].MaxOneRow() && canDecorrelate(x.children[0], joinType, outerSchema)
+	case *LogicalProjection:
+		return x.canPullUpAcrossApply(joinType) && canDecorrelate(x.children[0], joinType, outerSchema)
+	case *LogicalJoin:
+		return canPullUpCorrelatedConds(x, outerSchema)
+	}
+	return canUnnestInner(inner, joinType, outerSchema)
+}
+
+// canPullUpCorrelatedConds checks if all the correlated conditions of the join can be pulled up by
+// pullUpCorrelatedConds.
+func canPullUpCorrelatedConds(join *LogicalJoin, outerSchema *expression.Schema) bool {
+	if join.JoinType != InnerJoin {
+		for _, conds := range [][]expression.Expression{join.LeftConditions, join.RightConditions, join.OtherConditions} {
+			for _, cond := range conds {
+				if isCorrelatedExpr(cond, outerSchema) {
+					return false
+				}
+			}
+		}
+	}
+	for i, child := range join.children {
+		if !canPullUpFromJoinChild(join.JoinType, i) {
+			if hasCorrelatedCols(child, outerSchema) {
+				return false
+			}
+			continue
+		}
+		switch x := child.(type) {
+		case *LogicalSelection:
+			if hasCorrelatedCols(x.children[0], outerSchema) {
+				return false
+			}
+		case *LogicalJoin:
+			if !canPullUpCorrelatedConds(x, outerSchema) {
+				return false
+			}
+		default:
+			if hasCorrelatedCols(child, outerSchema) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// canPullUpFromJoinChild checks if the conditions on the child of the join can be pulled up above the join, the
+// rows of the child must be kept as they are by the join.
+func canPullUpFromJoinChild(joinType JoinType, childIdx int) bool {
+	switch joinType {
+	case InnerJoin:
+		return true
+	case RightOuterJoin:
+		return childIdx == 1
+	}
+	return childIdx == 0
+}
+
+// hasCorrelatedCols checks if the plan references the columns of the outer schema. Unlike
+// extractCorColumnsBySchema4LogicalPlan, it doesn't rebind the data of the correlated columns.
+func hasCorrelatedCols(p LogicalPlan, outerSchema *expression.Schema) bool {
+	for _, corCol := range ExtractCorrelatedCols4LogicalPlan(p) {
+		if outerSchema.Contains(&corCol.Column) {
+			return true
+		}
+	}
+	return false
+}
+
+// isCorrelatedExpr checks if the expression references the columns of the outer schema.
+func isCorrelatedExpr(expr expression.Expression, outerSchema *expression.Schema) bool {
+	for _, corCol := range expression.ExtractCorColumns(expr) {
+		if outerSchema.Contains(&corCol.Column) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullUpLimit pulls the limit in the inner plan up above the apply, the rows of each outer row are numbered by
+// row_number() and the rows out of the limit are filtered out, e.g.
+// Apply(outer, Limit(Sort(X))) => Selection{rn <= count}(Window{row_number() over (partition by key order by ...)}(Apply(outer, X))).
+func (s *decorrelateSolver) pullUpLimit(ctx context.Context, apply *LogicalApply, limit *LogicalLimit, key []*expression.Column, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	outerPlan, innerPlan := apply.children[0], limit.children[0]
+	var orderBy []property.SortItem
+	if sort, ok := innerPlan.(*LogicalSort); ok {
+		for _, item := range sort.ByItems {
+			// The correlated columns are constants for the rows of each outer row.
+			if col, ok := item.Expr.(*expression.Column); ok {
+				orderBy = append(orderBy, property.SortItem{Col: col, Desc: item.Desc})
+			}
+		}
+		innerPlan = sort.children[0]
+	}
+	originSchema := apply.Schema()
+	apply.SetChildren(outerPlan, innerPlan)
+	apply.SetSchema(buildLogicalJoinSchema(apply.JoinType, apply))
+	window, rowNumber, err := newRowNumberWindow(apply, key, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	conds := []expression.Expression{
+		expression.NewFunctionInternal(apply.ctx, ast.LE, types.NewFieldType(mysql.TypeTiny), rowNumber, &expression.Constant{
+			Value:   types.NewUintDatum(limit.Offset + limit.Count),
+			RetType: types.NewFieldType(mysql.TypeLonglong),
+		}),
+	}
+	if limit.Offset > 0 {
+		conds = append(conds, expression.NewFunctionInternal(apply.ctx, ast.GT, types.NewFieldType(mysql.TypeTiny), rowNumber, &expression.Constant{
+			Value:   types.NewUintDatum(limit.Offset),
+			RetType: types.NewFieldType(mysql.TypeLonglong),
+		}))
+	}
+	sel := LogicalSelection{Conditions: conds}.Init(apply.ctx, apply.blockOffset)
+	sel.SetChildren(window)
+	proj := LogicalProjection{Exprs: expression.Column2Exprs(apply.Schema().Columns)}.Init(apply.ctx, apply.blockOffset)
+	proj.SetSchema(originSchema)
+	proj.SetChildren(sel)
+	np, err := s.optimize(ctx, apply, opt)
+	if err != nil {
+		return nil, err
+	}
+	window.SetChildren(np)
+	return proj, nil
+}
+
+// pullUpWindow pulls the window in the inner plan up above the apply, the window is partitioned by the key columns
+// identifying the outer rows in addition to its own partition by items, e.g.
+// Apply(outer, Window(X)) => Window{partition by key}(Apply(outer, X)).
+func (s *decorrelateSolver) pullUpWindow(ctx context.Context, apply *LogicalApply, window *LogicalWindow, key []*expression.Column, opt *logicalOptimizeOp) (LogicalPlan, error) {
+	outerPlan, innerPlan := apply.children[0], window.children[0]
+	window.SetSchema(apply.Schema())
+	apply.SetChildren(outerPlan, innerPlan)
+	apply.SetSchema(buildLogicalJoinSchema(apply.JoinType, apply))
+	partitionBy := make([]property.SortItem, 0, len(key)+len(window.PartitionBy))
+	for _, col := range key {
+		partitionBy = append(partitionBy, property.SortItem{Col: col})
+	}
+	window.PartitionBy = append(partitionBy, window.PartitionBy...)
+	for _, desc := range window.WindowFuncDescs {
+		for i, arg := range desc.Args {
+			desc.Args[i] = arg.Decorrelate(outerPlan.Schema())
+		}
+	}
+	np, err := s.optimize(ctx, apply, opt)
+	if err != nil {
+		return nil, err
+	}
+	window.SetChildren(np)
+	return window, nil
+}
+
+// newRowNumberWindow builds a window computing row_number() over the child, the column of row_number() is
+// appended to the schema of the child.
+func newRowNumberWindow(child LogicalPlan, partitionBy []*expression.Column, orderBy []property.SortItem) (*LogicalWindow, *expression.Column, error) {
+	sctx := child.SCtx()
+	desc, err := aggregation.NewWindowFuncDesc(sctx, ast.WindowFuncRowNumber, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	window := LogicalWindow{
+		WindowFuncDescs: []*aggregation.WindowFuncDesc{desc},
+		OrderBy:         orderBy,
+	}.Init(sctx, child.SelectBlockOffset())
+	for _, col := range partitionBy {
+		window.PartitionBy = append(window.PartitionBy, property.SortItem{Col: col})
+	}
+	rowNumber := &expression.Column{
+		UniqueID: sctx.GetSessionVars().AllocPlanColumnID(),
+		RetType:  desc.RetTp,
+	}
+	schema := child.Schema().Clone()
+	schema.Append(rowNumber)
+	window.SetSchema(schema)
+	window.SetChildren(child)
+	return window, rowNumber, nil
+}
+
+// preferUnnest is a heuristic deciding whether the apply is decorrelated by unnestApply, it's not a cost-based choice
+// because the statistics, the access paths and the physical plans are not derived during the logical optimization.
+// It roughly compares the rows read by executing the inner plan for each outer row, where the correlated equal
+// conditions on the handle or the first column of an index are regarded as index lookups, with the rows read and
+// processed by the decorrelated plan, including the operator pulled up above the join and the row_number() window
+// generating the key of the outer plan. The row counts are estimated from the statistics tables since the statistics
+// are cached in the plans before the predicates are pushed down. The NO_DECORRELATE hint of the subquery keeps the
+// apply if the heuristic chooses wrong.
+func preferUnnest(apply *LogicalApply) bool {
+	sessVars := apply.ctx.GetSessionVars()
+	outerPlan, innerPlan := apply.children[0], apply.children[1]
+	outerCnt := estimateRowCount(outerPlan)
+	scanCnt, seekCnt, hasEqCond := estimateInnerScanRowCount(innerPlan, outerPlan.Schema())
+	scanFactor := sessVars.GetScanFactor(nil)
+	if scanFactor <= 0 {
+		scanFactor = 1
+	}
+	cpuFactor := sessVars.CPUFactor / scanFactor
+	// Each execution of the inner plan costs at least a seek on the inner tables.
+	applyCost := outerCnt * (seekCnt + sessVars.GetSeekFactor(nil)/scanFactor)
+	// The decorrelated plan scans the inner tables once, and then joins the rows below the pulled up operator
+	// with the outer rows. Without the correlated equal conditions, each pair of the rows is checked by the join.
+	joinCnt := estimateRowCount(innerPlan.Children()[0])
+	if !hasEqCond {
+		joinCnt *= outerCnt * cpuFactor
+	}
+	unnestCost := scanCnt + outerCnt + joinCnt
+	// The pulled up limit and window sort the joined rows of each outer row, the aggregation groups them.
+	switch innerPlan.(type) {
+	case *LogicalLimit, *LogicalWindow:
+		unnestCost += joinCnt * math.Log2(math.Max(joinCnt, 2)) * cpuFactor
+	default:
+		unnestCost += joinCnt * cpuFactor
+	}
+	if len(outerPlan.Schema().Keys) == 0 {
+		// The outer rows are numbered by the row_number() window to generate the key.
+		unnestCost += outerCnt * cpuFactor
+	}
+	return unnestCost < applyCost
+}
+
+// estimateRowCount roughly estimates the row count of the plan.
+func estimateRowCount(p LogicalPlan) float64 {
+	switch x := p.(type) {
+	case *DataSource:
+		if x.statisticTable == nil {
+			return statistics.PseudoRowCount
+		}
+		return float64(x.statisticTable.Count)
+	case *LogicalTableDual:
+		return float64(x.RowCount)
+	case *LogicalSelection:
+		return estimateRowCount(x.children[0]) * SelectionFactor
+	case *LogicalLimit:
+		return math.Min(estimateRowCount(x.children[0]), float64(x.Count))
+	case *LogicalTopN:
+		return math.Min(estimateRowCount(x.children[0]), float64(x.Count))
+	case *LogicalAggregation:
+		if len(x.GroupByItems) == 0 {
+			return 1
+		}
+	case *LogicalApply:
+		return estimateRowCount(x.children[0])
+	case *LogicalJoin:
+		lCnt, rCnt := estimateRowCount(x.children[0]), estimateRowCount(x.children[1])
+		switch x.JoinType {
+		case SemiJoin, AntiSemiJoin, LeftOuterSemiJoin, AntiLeftOuterSemiJoin:
+			return lCnt
+		}
+		if len(x.EqualConditions) == 0 {
+			return lCnt * rCnt
+		}
+		return math.Max(lCnt, rCnt)
+	case *LogicalUnionAll:
+		cnt := 0.0
+		for _, child := range x.children {
+			cnt += estimateRowCount(child)
+		}
+		return cnt
+	}
+	if len(p.Children()) == 0 {
+		return statistics.PseudoRowCount
+	}
+	return estimateRowCount(p.Children()[0])
+}
+
+// estimateInnerScanRowCount estimates the row count scanned from the tables of the inner plan of an apply, both
+// for scanning the tables once and for each execution of the inner plan. The rows of an execution are read by the
+// index if the correlated equal condition is on the first column of the index. It also returns whether there is
+// any correlated equal condition.
+func estimateInnerScanRowCount(inner LogicalPlan, outerSchema *expression.Schema) (scanCnt, seekCnt float64, hasEqCond bool) {
+	var (
+		eqCols  []*expression.Column
+		collect func(p LogicalPlan)
+		scan    func(p LogicalPlan)
+	)
+	collect = func(p LogicalPlan) {
+		if sel, ok := p.(*LogicalSelection); ok {
+			for _, cond := range sel.Conditions {
+				if col := extractCorrelatedEqCol(cond, outerSchema); col != nil {
+					eqCols = append(eqCols, col)
+				}
+			}
+		}
+		for _, child := range p.Children() {
+			collect(child)
+		}
+	}
+	scan = func(p LogicalPlan) {
+		ds, ok := p.(*DataSource)
+		if !ok {
+			for _, child := range p.Children() {
+				scan(child)
+			}
+			return
+		}
+		cnt := estimateRowCount(ds)
+		scanCnt += cnt
+		seek := cnt
+		for _, col := range eqCols {
+			if ds.statisticTable != nil && ds.Schema().Contains(col) && ds.isIndexPrefixCol(col) {
+				seek = math.Min(seek, cnt/math.Max(ds.getColumnNDV(col.ID), 1))
+			}
+		}
+		seekCnt += seek
+	}
+	collect(inner)
+	scan(inner)
+	return scanCnt, seekCnt, len(eqCols) > 0
+}
+
+// extractCorrelatedEqCol returns the column of the condition in the form of `col = correlated col`, where the
+// correlated column comes from the outer schema.
+func extractCorrelatedEqCol(cond expression.Expression, outerSchema *expression.Schema) *expression.Column {
+	sf, ok := cond.(*expression.ScalarFunction)
+	if !ok || sf.FuncName.L != ast.EQ {
+		return nil
+	}
+	args := sf.GetArgs()
+	for i := range args {
+		col, ok1 := args[i].(*expression.Column)
+		corCol, ok2 := args[1-i].(*expression.CorrelatedColumn)
+		if ok1 && ok2 && outerSchema.Contains(&corCol.Column) {
+			return col
+		}
+	}
+	return nil
+}
+
+// isIndexPrefixCol checks if the column is the handle or the first column of an index of the data source.
+func (ds *DataSource) isIndexPrefixCol(col *expression.Column) bool {
+	for _, path := range ds.possibleAccessPaths {
+		if path.IsIntHandlePath {
+			if pkCol := ds.tableInfo.GetPkColInfo(); pkCol != nil && pkCol.ID == col.ID {
+				return true
+			}
+		} else if path.Index != nil && !path.IsSearchIndexPath() && len(path.Index.Columns) > 0 {
+			if ds.tableInfo.Columns[path.Index.Columns[0].Offset].ID == col.ID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (*decorrelateSolver) name() string {
 	return "decorrelate"
 }
@@ -58,7 +58,7 @@ func collectGenerateColumn(lp LogicalPlan, exprToColumn ExprColumnMap) {
 		return
 	}
 	for _, p := range ds.possibleAccessPaths {
-		if p.IsTablePath() {
+		if p.IsTablePath() || p.IsSearchIndexPath() {
 			continue
 		}
 		for _, idxPart := range p.Index.Columns {
@@ -16,35 +16,181 @@ package core
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx"
 )
 
+// joinGroupResult is the join group extracted by extractJoinGroup.
+type joinGroupResult struct {
+	group      []LogicalPlan
+	eqEdges    []*expression.ScalarFunction
+	otherConds []expression.Expression
+	// outerJoins are the outer joins whose inner side is a single node of the
+	// group, their conditions are kept in the outer joins rather than in
+	// eqEdges and otherConds.
+	outerJoins []*LogicalJoin
+	// hintInfos are the hint infos carrying the LEADING hints.
+	hintInfos []*tableHintInfo
+}
+
 // extractJoinGroup extracts all the join nodes connected with continuous
-// InnerJoins to construct a join group. This join group is further used to
-// construct a new join order based on a reorder algorithm.
+// InnerJoins and outer joins to construct a join group. This join group is
+// further used to construct a new join order based on a reorder algorithm.
 //
 // For example: "InnerJoin(InnerJoin(a, b), LeftJoin(c, d))"
-// results in a join group {a, b, LeftJoin(c, d)}.
-func extractJoinGroup(p LogicalPlan) (group []LogicalPlan, eqEdges []*expression.ScalarFunction, otherConds []expression.Expression) {
+// results in a join group {a, b, c, d}, in which d can only be joined by
+// the LeftJoin after c is joined.
+//
+// For the outer joins, only the outer side is flattened and the inner side
+// is kept as a single node, since "LeftJoin(InnerJoin(a, b), c)" equals to
+// "InnerJoin(LeftJoin(a, c), b)" if the conditions of the LeftJoin only
+// reference a and c, but "LeftJoin(a, InnerJoin(b, c))" can't be reordered.
+//
+// The joins with join method hints are not reordered unless there is a LEADING
+// hint in the same query block, the hint infos carrying the LEADING hints are
+// returned as hintInfos.
+func extractJoinGroup(p LogicalPlan) *joinGroupResult {
 	join, isJoin := p.(*LogicalJoin)
-	if !isJoin || join.preferJoinType > uint(0) || join.JoinType != InnerJoin || join.StraightJoin {
-		return []LogicalPlan{p}, nil, nil
+	if !isJoin || join.StraightJoin {
+		return &joinGroupResult{group: []LogicalPlan{p}}
+	}
+	var result *joinGroupResult
+	switch join.JoinType {
+	case InnerJoin:
+		if join.preferJoinType > uint(0) && (join.hintInfo == nil || join.hintInfo.leadingJoinOrder == nil) {
+			return &joinGroupResult{group: []LogicalPlan{p}}
+		}
+		result = extractInnerJoinGroup(join)
+	case LeftOuterJoin, RightOuterJoin:
+		// The outer joins without equal conditions are not reordered, since their inner side can't be
+		// connected with the outer side in the join group.
+		if join.preferJoinType > uint(0) || len(join.EqualConditions) == 0 || join.DefaultValues != nil {
+			return &joinGroupResult{group: []LogicalPlan{p}}
+		}
+		result = extractOuterJoinGroup(join)
+	default:
+		return &joinGroupResult{group: []LogicalPlan{p}}
+	}
+	if join.hintInfo != nil && join.hintInfo.leadingJoinOrder != nil {
+		result.hintInfos = appendLeadingHintInfo(result.hintInfos, join.hintInfo)
+	}
+	return result
+}
+
+func extractInnerJoinGroup(join *LogicalJoin) *joinGroupResult {
+	conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.OtherConditions))
+	conds = append(conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+	conds = append(conds, join.OtherConditions...)
+	lhs := extractJoinGroup(join.children[0])
+	// The conditions referencing the inner side of an outer join can't be evaluated before the outer join.
+	// The null-rejecting ones have turned the outer join into inner join in predicate push down, the others
+	// conflict with reordering the outer join, so the child is kept as a single node.
+	if lhs.referenceInnerSide(conds) {
+		lhs = &joinGroupResult{group: []LogicalPlan{join.children[0]}}
+	}
+	rhs := extractJoinGroup(join.children[1])
+	if rhs.referenceInnerSide(conds) {
+		rhs = &joinGroupResult{group: []LogicalPlan{join.children[1]}}
+	}
+	result := &joinGroupResult{
+		eqEdges:    join.EqualConditions,
+		otherConds: join.OtherConditions,
+	}
+	return result.merge(lhs).merge(rhs)
+}
+
+func extractOuterJoinGroup(join *LogicalJoin) *joinGroupResult {
+	outerIdx := 0
+	if join.JoinType == RightOuterJoin {
+		outerIdx = 1
+	}
+	conds := make([]expression.Expression, 0, len(join.EqualConditions)+len(join.LeftConditions)+len(join.RightConditions)+len(join.OtherConditions))
+	conds = append(conds, expression.ScalarFuncs2Exprs(join.EqualConditions)...)
+	conds = append(conds, join.LeftConditions...)
+	conds = append(conds, join.RightConditions...)
+	conds = append(conds, join.OtherConditions...)
+	outer := extractJoinGroup(join.children[outerIdx])
+	// The conditions of the outer join must reference only one node of the outer side, otherwise the outer
+	// join can't be done before the nodes of the outer side are joined together.
+	if outer.referenceInnerSide(conds) || outer.referencedNodeCount(conds) > 1 {
+		outer = &joinGroupResult{group: []LogicalPlan{join.children[outerIdx]}}
+	}
+	inner := &joinGroupResult{group: []LogicalPlan{join.children[1-outerIdx]}}
+	var result *joinGroupResult
+	if outerIdx == 0 {
+		result = outer.merge(inner)
+	} else {
+		result = inner.merge(outer)
+	}
+	result.outerJoins = append(result.outerJoins, join)
+	return result
+}
+
+// merge merges the two join groups.
+func (r *joinGroupResult) merge(other *joinGroupResult) *joinGroupResult {
+	result := &joinGroupResult{}
+	result.group = append(append(result.group, r.group...), other.group...)
+	result.eqEdges = append(append(result.eqEdges, r.eqEdges...), other.eqEdges...)
+	result.otherConds = append(append(result.otherConds, r.otherConds...), other.otherConds...)
+	result.outerJoins = append(append(result.outerJoins, r.outerJoins...), other.outerJoins...)
+	result.hintInfos = append(result.hintInfos, r.hintInfos...)
+	for _, hintInfo := range other.hintInfos {
+		result.hintInfos = appendLeadingHintInfo(result.hintInfos, hintInfo)
+	}
+	return result
+}
+
+// referenceInnerSide checks whether the expressions reference the inner side of the outer joins in the group.
+func (r *joinGroupResult) referenceInnerSide(exprs []expression.Expression) bool {
+	for _, join := range r.outerJoins {
+		if exprsReferenceSchema(exprs, outerJoinInnerSide(join).Schema()) {
+			return true
+		}
+	}
+	return false
+}
+
+// referencedNodeCount returns the number of the nodes in the group referenced by the expressions.
+func (r *joinGroupResult) referencedNodeCount(exprs []expression.Expression) int {
+	cnt := 0
+	for _, node := range r.group {
+		if exprsReferenceSchema(exprs, node.Schema()) {
+			cnt++
+		}
 	}
+	return cnt
+}
 
-	lhsGroup, lhsEqualConds, lhsOtherConds := extractJoinGroup(join.children[0])
-	rhsGroup, rhsEqualConds, rhsOtherConds := extractJoinGroup(join.children[1])
+// exprsReferenceSchema checks whether any of the expressions references the columns of the schema.
+func exprsReferenceSchema(exprs []expression.Expression, schema *expression.Schema) bool {
+	for _, col := range expression.ExtractColumnsFromExpressions(nil, exprs, nil) {
+		if schema.Contains(col) {
+			return true
+		}
+	}
+	return false
+}
 
-	group = append(group, lhsGroup...)
-	group = append(group, rhsGroup...)
-	eqEdges = append(eqEdges, join.EqualConditions...)
-	eqEdges = append(eqEdges, lhsEqualConds...)
-	eqEdges = append(eqEdges, rhsEqualConds...)
-	otherConds = append(otherConds, join.OtherConditions...)
-	otherConds = append(otherConds, lhsOtherConds...)
-	otherConds = append(otherConds, rhsOtherConds...)
-	return group, eqEdges, otherConds
+// outerJoinInnerSide returns the original inner child of the outer join.
+func outerJoinInnerSide(join *LogicalJoin) LogicalPlan {
+	if join.JoinType == RightOuterJoin {
+		return join.children[0]
+	}
+	return join.children[1]
+}
+
+// appendLeadingHintInfo appends the hint info if its LEADING hint is not in the list.
+func appendLeadingHintInfo(hintInfos []*tableHintInfo, hintInfo *tableHintInfo) []*tableHintInfo {
+	for _, info := range hintInfos {
+		if info.leadingJoinOrder == hintInfo.leadingJoinOrder {
+			return hintInfos
+		}
+	}
+	return append(hintInfos, hintInfo)
 }
 
 type joinReOrderSolver struct {
@@ -56,26 +202,64 @@ type jrNode struct {
 }
 
 func (s *joinReOrderSolver) optimize(ctx context.Context, p LogicalPlan, opt *logicalOptimizeOp) (LogicalPlan, error) {
-	return s.optimizeRecursive(p.SCtx(), p)
+	var leadingHints []*tableHintInfo
+	p, err := s.optimizeRecursive(p.SCtx(), p, &leadingHints)
+	if err != nil {
+		return nil, err
+	}
+	// The LEADING hints which are not applied to any join group, e.g. the tables in the hint
+	// are not connected by inner joins, are inapplicable.
+	for _, hintInfo := range leadingHints {
+		if !hintInfo.leadingJoinOrder.applied {
+			appendInapplicableLeadingHintWarning(p.SCtx(), hintInfo.leadingJoinOrder)
+		}
+	}
+	return p, nil
 }
 
 // optimizeRecursive recursively collects join groups and applies join reorder algorithm for each group.
-func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan) (LogicalPlan, error) {
+// The LEADING hints met during the recursion are collected in leadingHints.
+func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalPlan, leadingHints *[]*tableHintInfo) (LogicalPlan, error) {
 	var err error
-	curJoinGroup, eqEdges, otherConds := extractJoinGroup(p)
-	if len(curJoinGroup) > 1 {
+	if join, ok := p.(*LogicalJoin); ok && join.hintInfo != nil && join.hintInfo.leadingJoinOrder != nil {
+		*leadingHints = appendLeadingHintInfo(*leadingHints, join.hintInfo)
+	}
+	result := extractJoinGroup(p)
+	curJoinGroup, eqEdges, hintInfos := result.group, result.eqEdges, result.hintInfos
+	// A single outer join has nothing to reorder.
+	isSingleOuterJoin := len(curJoinGroup) == 2 && len(result.outerJoins) == 1
+	if len(curJoinGroup) > 1 && !isSingleOuterJoin {
+		for _, hintInfo := range hintInfos {
+			*leadingHints = appendLeadingHintInfo(*leadingHints, hintInfo)
+		}
 		for i := range curJoinGroup {
-			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i])
+			curJoinGroup[i], err = s.optimizeRecursive(ctx, curJoinGroup[i], leadingHints)
 			if err != nil {
 				return nil, err
 			}
 		}
+		originalJoinGroup := curJoinGroup
 		baseGroupSolver := &baseSingleGroupJoinOrderSolver{
 			ctx:        ctx,
-			otherConds: otherConds,
+			otherConds: result.otherConds,
+			outerJoins: result.outerJoins,
+		}
+		if len(hintInfos) > 1 {
+			for _, hintInfo := range hintInfos {
+				if !hintInfo.leadingJoinOrder.warned {
+					hintInfo.leadingJoinOrder.warned = true
+					errMsg := "We can only use one leading hint at most, when multiple leading hints are used, all leading hints will be invalid"
+					ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+				}
+			}
+		} else if len(hintInfos) == 1 {
+			curJoinGroup, eqEdges = baseGroupSolver.generateLeadingJoinGroup(curJoinGroup, eqEdges, hintInfos[0].leadingJoinOrder, p.SelectBlockOffset())
 		}
 		originalSchema := p.Schema()
-		if len(curJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
+		if len(curJoinGroup) == 0 {
+			// All the nodes are joined by the LEADING hint.
+			p = baseGroupSolver.leadingJoinGroup
+		} else if len(originalJoinGroup) > ctx.GetSessionVars().TiDBOptJoinReorderThreshold {
 			groupSolver := &joinReorderGreedySolver{
 				baseSingleGroupJoinOrderSolver: baseGroupSolver,
 				eqEdges:                        eqEdges,
@@ -91,6 +275,9 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 		if err != nil {
 			return nil, err
 		}
+		if len(hintInfos) > 0 {
+			setReorderedJoinHints(p, originalJoinGroup, hintInfos)
+		}
 		schemaChanged := false
 		if len(p.Schema().Columns) != len(originalSchema.Columns) {
 			schemaChanged = true
@@ -114,7 +301,7 @@ func (s *joinReOrderSolver) optimizeRecursive(ctx sessionctx.Context, p LogicalP
 	}
 	newChildren := make([]LogicalPlan, 0, len(p.Children()))
 	for _, child := range p.Children() {
-		newChild, err := s.optimizeRecursive(ctx, child)
+		newChild, err := s.optimizeRecursive(ctx, child, leadingHints)
 		if err != nil {
 			return nil, err
 		}
@@ -129,6 +316,149 @@ type baseSingleGroupJoinOrderSolver struct {
 	ctx          sessionctx.Context
 	curJoinGroup []*jrNode
 	otherConds   []expression.Expression
+	outerJoins   []*LogicalJoin
+	// leadingJoinGroup is the join tree built by the LEADING hint, it's
+	// treated as a single node of the join group by the solvers.
+	leadingJoinGroup LogicalPlan
+}
+
+// generateLeadingJoinGroup builds the join tree specified by the LEADING hint with the nodes of the
+// join group, and stores it in leadingJoinGroup. It returns the remaining nodes and equal edges of
+// the join group. The join group is returned unchanged if the hint can't be applied to it.
+func (s *baseSingleGroupJoinOrderSolver) generateLeadingJoinGroup(curJoinGroup []LogicalPlan, eqEdges []*expression.ScalarFunction,
+	hint *leadingHintInfo, blockOffset int) ([]LogicalPlan, []*expression.ScalarFunction) {
+	nodeIdx := make([]int, len(hint.tables))
+	used := make([]bool, len(curJoinGroup))
+	matched := 0
+	for i, table := range hint.tables {
+		nodeIdx[i] = -1
+		for j, node := range curJoinGroup {
+			if used[j] {
+				continue
+			}
+			alias := extractJoinGroupNodeAlias(node, blockOffset)
+			if alias != nil && alias.dbName.L == table.dbName.L && alias.tblName.L == table.tblName.L && alias.selectOffset == table.selectOffset {
+				nodeIdx[i] = j
+				used[j] = true
+				matched++
+				break
+			}
+		}
+	}
+	if matched == 0 {
+		// The hint is for other join groups.
+		return curJoinGroup, eqEdges
+	}
+	if matched < len(hint.tables) {
+		appendInapplicableLeadingHintWarning(s.ctx, hint)
+		return curJoinGroup, eqEdges
+	}
+	var buildJoinTree func(items []leadingHintItem) LogicalPlan
+	// The other conditions are consumed when building the join tree, keep a copy in case that
+	// the hint can't be applied.
+	originalOtherConds := make([]expression.Expression, len(s.otherConds))
+	copy(originalOtherConds, s.otherConds)
+	// buildJoinTree returns nil if the join tree can't be built, e.g. the inner side of an outer
+	// join is joined before its outer side.
+	buildJoinTree = func(items []leadingHintItem) LogicalPlan {
+		var curJoinTree LogicalPlan
+		for _, item := range items {
+			var node LogicalPlan
+			if item.tableIdx >= 0 {
+				node = curJoinGroup[nodeIdx[item.tableIdx]]
+			} else if node = buildJoinTree(item.items); node == nil {
+				return nil
+			}
+			if curJoinTree == nil {
+				curJoinTree = node
+				continue
+			}
+			outerJoin, canJoin := s.checkOuterJoinConnection(curJoinTree, node)
+			if !canJoin {
+				return nil
+			}
+			if outerJoin != nil {
+				curJoinTree = s.newOuterJoin(curJoinTree, node, outerJoin)
+				continue
+			}
+			usedEdges := s.checkConnection(eqEdges, curJoinTree, node)
+			var otherConds []expression.Expression
+			mergedSchema := expression.MergeSchema(curJoinTree.Schema(), node.Schema())
+			s.otherConds, otherConds = expression.FilterOutInPlace(s.otherConds, func(expr expression.Expression) bool {
+				return expression.ExprFromSchema(expr, mergedSchema)
+			})
+			curJoinTree = s.newJoinWithEdges(curJoinTree, node, usedEdges, otherConds)
+		}
+		return curJoinTree
+	}
+	s.leadingJoinGroup = buildJoinTree(hint.items)
+	if s.leadingJoinGroup == nil {
+		s.otherConds = originalOtherConds
+		appendInapplicableLeadingHintWarning(s.ctx, hint)
+		return curJoinGroup, eqEdges
+	}
+	hint.applied = true
+
+	remainGroup := make([]LogicalPlan, 0, len(curJoinGroup)-matched)
+	for i, node := range curJoinGroup {
+		if !used[i] {
+			remainGroup = append(remainGroup, node)
+		}
+	}
+	remainEdges := make([]*expression.ScalarFunction, 0, len(eqEdges))
+	leadingSchema := s.leadingJoinGroup.Schema()
+	for _, edge := range eqEdges {
+		if !expression.ExprFromSchema(edge, leadingSchema) {
+			remainEdges = append(remainEdges, edge)
+		}
+	}
+	return remainGroup, remainEdges
+}
+
+// extractJoinGroupNodeAlias returns the table alias of the join group node used to match the LEADING hint.
+func extractJoinGroupNodeAlias(node LogicalPlan, blockOffset int) *hintTableInfo {
+	// For sub-queries like `(select * from t) t1`, the projection with the alias may have been
+	// eliminated, so the alias is got from the select block of the node.
+	if offset := node.SelectBlockOffset(); offset != blockOffset && offset >= 0 {
+		blockAsNames := node.SCtx().GetSessionVars().PlannerSelectBlockAsName
+		if offset < len(blockAsNames) && blockAsNames[offset].TableName.L != "" {
+			dbName := blockAsNames[offset].DBName
+			if dbName.L == "" {
+				dbName = model.NewCIStr(node.SCtx().GetSessionVars().CurrentDB)
+			}
+			return &hintTableInfo{dbName: dbName, tblName: blockAsNames[offset].TableName, selectOffset: blockOffset}
+		}
+	}
+	return extractTableAlias(node, blockOffset)
+}
+
+// appendInapplicableLeadingHintWarning generates a warning for the LEADING hint if there isn't one.
+func appendInapplicableLeadingHintWarning(ctx sessionctx.Context, hint *leadingHintInfo) {
+	if hint.warned {
+		return
+	}
+	hint.warned = true
+	errMsg := fmt.Sprintf("Optimizer Hint %s is inapplicable, check whether the tables in the hint are connected by inner joins", hint)
+	ctx.GetSessionVars().StmtCtx.AppendWarning(ErrInternal.GenWithStack(errMsg))
+}
+
+// setReorderedJoinHints sets the join method hints for the joins generated by the join reorder, since the
+// joins with join method hints can be reordered when the join group has a LEADING hint. The nodes of the
+// join group are not visited.
+func setReorderedJoinHints(p LogicalPlan, joinGroup []LogicalPlan, hintInfos []*tableHintInfo) {
+	for _, node := range joinGroup {
+		if node == p {
+			return
+		}
+	}
+	if join, ok := p.(*LogicalJoin); ok {
+		for _, hintInfo := range hintInfos {
+			join.setPreferredJoinType(hintInfo)
+		}
+	}
+	for _, child := range p.Children() {
+		setReorderedJoinHints(child, joinGroup, hintInfos)
+	}
 }
 
 // baseNodeCumCost calculate the cumulative cost of the node in the join group.
@@ -165,6 +495,82 @@ func (s *baseSingleGroupJoinOrderSolver) makeBushyJoin(cartesianJoinGroup []Logi
 	return cartesianJoinGroup[0]
 }
 
+// checkConnection returns the equal edges connecting the two nodes, the left argument
+// of the returned edges is always from the left node.
+func (s *baseSingleGroupJoinOrderSolver) checkConnection(eqEdges []*expression.ScalarFunction, leftNode, rightNode LogicalPlan) (usedEdges []*expression.ScalarFunction) {
+	for _, edge := range eqEdges {
+		lCol := edge.GetArgs()[0].(*expression.Column)
+		rCol := edge.GetArgs()[1].(*expression.Column)
+		if leftNode.Schema().Contains(lCol) && rightNode.Schema().Contains(rCol) {
+			usedEdges = append(usedEdges, edge)
+		} else if rightNode.Schema().Contains(lCol) && leftNode.Schema().Contains(rCol) {
+			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
+			usedEdges = append(usedEdges, newSf)
+		}
+	}
+	return usedEdges
+}
+
+// checkOuterJoinConnection checks whether the two plans can be joined with regard to the outer joins in the
+// join group. The inner side of an outer join can only be joined with the plan containing its outer side by
+// the outer join itself. So if one plan is the inner side of an outer join, the outer join is returned when
+// the other plan contains its outer side, otherwise the plans can't be joined.
+func (s *baseSingleGroupJoinOrderSolver) checkOuterJoinConnection(leftPlan, rightPlan LogicalPlan) (outerJoin *LogicalJoin, canJoin bool) {
+	for _, join := range s.outerJoins {
+		innerSide := outerJoinInnerSide(join)
+		innerCol := innerSide.Schema().Columns[0]
+		outerCols := extractOuterJoinOuterCols(join)
+		lHasInner, rHasInner := leftPlan.Schema().Contains(innerCol), rightPlan.Schema().Contains(innerCol)
+		if !lHasInner && !rHasInner {
+			continue
+		}
+		lHasOuter := leftPlan.Schema().ColumnsIndices(outerCols) != nil
+		rHasOuter := rightPlan.Schema().ColumnsIndices(outerCols) != nil
+		// The outer join has been done in one of the plans.
+		if (lHasInner && lHasOuter) || (rHasInner && rHasOuter) {
+			continue
+		}
+		if outerJoin != nil || !((lHasInner && rHasOuter) || (rHasInner && lHasOuter)) {
+			return nil, false
+		}
+		outerJoin = join
+	}
+	return outerJoin, true
+}
+
+// extractOuterJoinOuterCols returns the columns of the outer side referenced by the conditions of the outer join.
+func extractOuterJoinOuterCols(join *LogicalJoin) []*expression.Column {
+	innerSchema := outerJoinInnerSide(join).Schema()
+	notInner := func(col *expression.Column) bool {
+		return !innerSchema.Contains(col)
+	}
+	cols := expression.ExtractColumnsFromExpressions(nil, expression.ScalarFuncs2Exprs(join.EqualConditions), notInner)
+	cols = expression.ExtractColumnsFromExpressions(cols, join.LeftConditions, notInner)
+	cols = expression.ExtractColumnsFromExpressions(cols, join.RightConditions, notInner)
+	return expression.ExtractColumnsFromExpressions(cols, join.OtherConditions, notInner)
+}
+
+// newOuterJoin builds the outer join of the two plans with the conditions of the original outer join,
+// one of the plans is the inner side of the outer join.
+func (s *baseSingleGroupJoinOrderSolver) newOuterJoin(lPlan, rPlan LogicalPlan, origin *LogicalJoin) LogicalPlan {
+	lChild, rChild := lPlan, rPlan
+	if lPlan.Schema().Contains(outerJoinInnerSide(origin).Schema().Columns[0]) {
+		lChild, rChild = rPlan, lPlan
+	}
+	// Now lChild is the outer side, swap them back for the right outer join.
+	if origin.JoinType == RightOuterJoin {
+		lChild, rChild = rChild, lChild
+	}
+	join := s.newCartesianJoin(lChild, rChild)
+	join.JoinType = origin.JoinType
+	join.EqualConditions = origin.EqualConditions
+	join.LeftConditions = origin.LeftConditions
+	join.RightConditions = origin.RightConditions
+	join.OtherConditions = origin.OtherConditions
+	join.SetSchema(buildLogicalJoinSchema(join.JoinType, join))
+	return join
+}
+
 func (s *baseSingleGroupJoinOrderSolver) newCartesianJoin(lChild, rChild LogicalPlan) *LogicalJoin {
 	offset := lChild.SelectBlockOffset()
 	if offset != rChild.SelectBlockOffset() {
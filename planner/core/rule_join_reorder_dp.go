@@ -38,6 +38,10 @@ type joinGroupNonEqEdge struct {
 }
 
 func (s *joinReorderDPSolver) solve(joinGroup []LogicalPlan, eqConds []expression.Expression) (LogicalPlan, error) {
+	// The join tree built by the LEADING hint is treated as a single node.
+	if s.leadingJoinGroup != nil {
+		joinGroup = append(joinGroup, s.leadingJoinGroup)
+	}
 	for _, node := range joinGroup {
 		_, err := node.recursiveDeriveStats(nil)
 		if err != nil {
@@ -73,6 +77,22 @@ func (s *joinReorderDPSolver) solve(joinGroup []LogicalPlan, eqConds []expressio
 		}
 		addEqEdge(lIdx, rIdx, sf)
 	}
+	// The outer joins connect their inner side with the outer side, their conditions are used
+	// only when the outer joins are built.
+	for _, join := range s.outerJoins {
+		for _, sf := range join.EqualConditions {
+			lIdx, err := findNodeIndexInGroup(joinGroup, sf.GetArgs()[0].(*expression.Column))
+			if err != nil {
+				return nil, err
+			}
+			rIdx, err := findNodeIndexInGroup(joinGroup, sf.GetArgs()[1].(*expression.Column))
+			if err != nil {
+				return nil, err
+			}
+			adjacents[lIdx] = append(adjacents[lIdx], rIdx)
+			adjacents[rIdx] = append(adjacents[rIdx], lIdx)
+		}
+	}
 	totalNonEqEdges := make([]joinGroupNonEqEdge, 0, len(s.otherConds))
 	for _, cond := range s.otherConds {
 		cols := expression.ExtractColumns(cond)
@@ -181,15 +201,28 @@ func (s *joinReorderDPSolver) dpGraph(visitID2NodeID, nodeID2VisitID []int, join
 			if bestPlan[sub] == nil || bestPlan[remain] == nil {
 				continue
 			}
-			// Get the edge connecting the two parts.
-			usedEdges, otherConds := s.nodesAreConnected(sub, remain, nodeID2VisitID, totalEqEdges, totalNonEqEdges)
-			// Here we only check equal condition currently.
-			if len(usedEdges) == 0 {
+			outerJoin, canJoin := s.checkOuterJoinConnection(bestPlan[sub].p, bestPlan[remain].p)
+			if !canJoin {
 				continue
 			}
-			join, err := s.newJoinWithEdge(bestPlan[sub].p, bestPlan[remain].p, usedEdges, otherConds)
-			if err != nil {
-				return nil, err
+			var join LogicalPlan
+			if outerJoin != nil {
+				join = s.newOuterJoin(bestPlan[sub].p, bestPlan[remain].p, outerJoin)
+				if _, err := join.recursiveDeriveStats(nil); err != nil {
+					return nil, err
+				}
+			} else {
+				// Get the edge connecting the two parts.
+				usedEdges, otherConds := s.nodesAreConnected(sub, remain, nodeID2VisitID, totalEqEdges, totalNonEqEdges)
+				// Here we only check equal condition currently.
+				if len(usedEdges) == 0 {
+					continue
+				}
+				var err error
+				join, err = s.newJoinWithEdge(bestPlan[sub].p, bestPlan[remain].p, usedEdges, otherConds)
+				if err != nil {
+					return nil, err
+				}
 			}
 			curCost := s.calcJoinCumCost(join, bestPlan[sub], bestPlan[remain])
 			if bestPlan[nodeBitmap] == nil {
@@ -19,7 +19,6 @@ import (
 	"sort"
 
 	"github.com/pingcap/tidb/expression"
-	"github.com/pingcap/tidb/parser/ast"
 )
 
 type joinReorderGreedySolver struct {
@@ -55,6 +54,18 @@ func (s *joinReorderGreedySolver) solve(joinNodePlans []LogicalPlan) (LogicalPla
 	sort.SliceStable(s.curJoinGroup, func(i, j int) bool {
 		return s.curJoinGroup[i].cumCost < s.curJoinGroup[j].cumCost
 	})
+	// The join tree built by the LEADING hint is always the start of the join order.
+	if s.leadingJoinGroup != nil {
+		_, err := s.leadingJoinGroup.recursiveDeriveStats(nil)
+		if err != nil {
+			return nil, err
+		}
+		leadingNode := &jrNode{
+			p:       s.leadingJoinGroup,
+			cumCost: s.baseNodeCumCost(s.leadingJoinGroup),
+		}
+		s.curJoinGroup = append([]*jrNode{leadingNode}, s.curJoinGroup...)
+	}
 
 	var cartesianGroup []LogicalPlan
 	for len(s.curJoinGroup) > 0 {
@@ -108,19 +119,17 @@ func (s *joinReorderGreedySolver) constructConnectedJoinTree() (*jrNode, error)
 }
 
 func (s *joinReorderGreedySolver) checkConnectionAndMakeJoin(leftNode, rightNode LogicalPlan) (LogicalPlan, []expression.Expression) {
-	var usedEdges []*expression.ScalarFunction
 	remainOtherConds := make([]expression.Expression, len(s.otherConds))
 	copy(remainOtherConds, s.otherConds)
-	for _, edge := range s.eqEdges {
-		lCol := edge.GetArgs()[0].(*expression.Column)
-		rCol := edge.GetArgs()[1].(*expression.Column)
-		if leftNode.Schema().Contains(lCol) && rightNode.Schema().Contains(rCol) {
-			usedEdges = append(usedEdges, edge)
-		} else if rightNode.Schema().Contains(lCol) && leftNode.Schema().Contains(rCol) {
-			newSf := expression.NewFunctionInternal(s.ctx, ast.EQ, edge.GetType(), rCol, lCol).(*expression.ScalarFunction)
-			usedEdges = append(usedEdges, newSf)
-		}
+	outerJoin, canJoin := s.checkOuterJoinConnection(leftNode, rightNode)
+	if !canJoin {
+		return nil, nil
+	}
+	if outerJoin != nil {
+		// The other conditions of the join group can't be put into the outer join.
+		return s.newOuterJoin(leftNode, rightNode, outerJoin), remainOtherConds
 	}
+	usedEdges := s.checkConnection(s.eqEdges, leftNode, rightNode)
 	if len(usedEdges) == 0 {
 		return nil, nil
 	}
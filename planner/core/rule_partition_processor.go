@@ -120,9 +120,8 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	return exprs[0], nil
 }
 
-func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, []expression.Expression, error) {
-	pi := tbl.Meta().Partition
 	pe, err := generateHashPartitionExpr(ctx, pi, columns, names)
 	if err != nil {
 		return nil, nil, err
@@ -232,6 +231,10 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 		or := partitionRangeOR{partitionRange{0, len(pi.Definitions)}}
 		return s.convertToIntSlice(or, pi, partitionNames), nil, nil
 	}
+	return sortAndDedupPartitions(used), detachedResult.RemainedConds, nil
+}
+
+func sortAndDedupPartitions(used []int) []int {
 	sort.Ints(used)
 	ret := used[:0]
 	for i := 0; i < len(used); i++ {
@@ -239,7 +242,51 @@ func (s *partitionProcessor) findUsedPartitions(ctx sessionctx.Context, tbl tabl
 			ret = append(ret, used[i])
 		}
 	}
-	return ret, detachedResult.RemainedConds, nil
+	return ret
+}
+
+// findUsedKeyPartitions finds the used partitions of a key partitioned table. The partition can only be
+// located when the conditions are points on all the partitioning columns.
+func (s *partitionProcessor) findUsedKeyPartitions(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	partCols := make([]*expression.Column, 0, len(pi.Columns))
+	colLen := make([]int, 0, len(pi.Columns))
+	for _, col := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, col.L)
+		if idx < 0 {
+			return nil, errors.Trace(fmt.Errorf("information of column %v is not found", col.O))
+		}
+		partCols = append(partCols, columns[idx])
+		colLen = append(colLen, types.UnspecifiedLength)
+	}
+	detachedResult, err := ranger.DetachCondAndBuildRangeForPartition(ctx, conds, partCols, colLen)
+	if err != nil {
+		return nil, err
+	}
+	sc := ctx.GetSessionVars().StmtCtx
+	kp := &tables.ForKeyPruning{KeyPartCols: partCols}
+	used := make([]int, 0, len(detachedResult.Ranges))
+	for _, r := range detachedResult.Ranges {
+		if !r.IsPointNullable(sc) || len(r.HighVal) != len(partCols) {
+			used = []int{FullRange}
+			break
+		}
+		idx, err := kp.LocateKeyPartition(sc, pi.Num, r.HighVal)
+		if err != nil {
+			// The value can't be converted to the column type, so the partition can't be located.
+			used = []int{FullRange}
+			break
+		}
+		if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[idx].Name.L) {
+			continue
+		}
+		used = append(used, idx)
+	}
+	if len(partitionNames) > 0 && len(used) == 1 && used[0] == FullRange {
+		or := partitionRangeOR{partitionRange{0, len(pi.Definitions)}}
+		return s.convertToIntSlice(or, pi, partitionNames), nil
+	}
+	return sortAndDedupPartitions(used), nil
 }
 
 func (s *partitionProcessor) convertToIntSlice(or partitionRangeOR, pi *model.PartitionInfo, partitionNames []model.CIStr) []int {
@@ -251,7 +298,7 @@ func (s *partitionProcessor) convertToIntSlice(or partitionRangeOR, pi *model.Pa
 	ret := make([]int, 0, len(or))
 	for i := 0; i < len(or); i++ {
 		for pos := or[i].start; pos < or[i].end; pos++ {
-			if len(partitionNames) > 0 && !s.findByName(partitionNames, pi.Definitions[pos].Name.L) {
+			if len(partitionNames) > 0 && !s.findDefinitionByName(partitionNames, pi, pos) {
 				continue
 			}
 			ret = append(ret, pos)
@@ -271,9 +318,9 @@ func convertToRangeOr(used []int, pi *model.PartitionInfo) partitionRangeOR {
 	return ret
 }
 
-func (s *partitionProcessor) pruneHashPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) pruneHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
-	used, _, err := s.findUsedPartitions(ctx, tbl, partitionNames, conds, columns, names)
+	used, _, err := s.findUsedPartitions(ctx, pi, partitionNames, conds, columns, names)
 	if err != nil {
 		return nil, err
 	}
@@ -329,7 +376,7 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	if err != nil {
 		return nil, err
 	}
-	used, err := s.pruneHashPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	used, err := s.pruneHashPartition(ds.SCtx(), pi, ds.partitionNames, ds.allConds, ds.TblCols, names)
 	if err != nil {
 		return nil, err
 	}
@@ -341,6 +388,72 @@ func (s *partitionProcessor) processHashPartition(ds *DataSource, pi *model.Part
 	return tableDual, nil
 }
 
+func (s *partitionProcessor) processKeyPartition(ds *DataSource, pi *model.PartitionInfo) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.findUsedKeyPartitions(ds.SCtx(), pi, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi))
+}
+
+// pruneSubPartition prunes the logical partitions and the subpartitions of a subpartitioned table separately,
+// and returns the offsets of the used subpartitions in pi.Definitions.
+func (s *partitionProcessor) pruneSubPartition(ctx sessionctx.Context, tbl table.PartitionedTable, pi *model.PartitionInfo, partitionNames []model.CIStr,
+	conds []expression.Expression, columns []*expression.Column, names types.NameSlice) ([]int, error) {
+	parentPi, subPi := pi.ParentView(), pi.SubView()
+	var parentOr partitionRangeOR
+	if parentPi.Type == model.PartitionTypeList {
+		used, err := s.pruneListPartition(ctx, tbl, parentPi, nil, conds)
+		if err != nil {
+			return nil, err
+		}
+		parentOr = convertToRangeOr(used, parentPi)
+	} else {
+		var err error
+		parentOr, _, err = s.pruneRangePartition(ctx, parentPi, tbl, conds, columns, names, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var subUsed []int
+	var err error
+	if subPi.Type == model.PartitionTypeKey {
+		subUsed, err = s.findUsedKeyPartitions(ctx, subPi, nil, conds, columns, names)
+	} else {
+		subUsed, err = s.pruneHashPartition(ctx, subPi, nil, conds, columns, names)
+	}
+	if err != nil {
+		return nil, err
+	}
+	subOr := convertToRangeOr(subUsed, subPi)
+	num := int(pi.Sub.Num)
+	or := make(partitionRangeOR, 0, len(parentOr)*len(subOr))
+	for _, r := range parentOr {
+		for i := r.start; i < r.end; i++ {
+			for _, sr := range subOr {
+				or = append(or, partitionRange{i*num + sr.start, i*num + sr.end})
+			}
+		}
+	}
+	return s.convertToIntSlice(or.simplify(), pi, partitionNames), nil
+}
+
+func (s *partitionProcessor) processSubPartition(ds *DataSource, pi *model.PartitionInfo) (LogicalPlan, error) {
+	names, err := s.reconstructTableColNames(ds)
+	if err != nil {
+		return nil, err
+	}
+	used, err := s.pruneSubPartition(ds.SCtx(), ds.table.(table.PartitionedTable), pi, ds.partitionNames, ds.allConds, ds.TblCols, names)
+	if err != nil {
+		return nil, err
+	}
+	return s.makeUnionAllChildren(ds, pi, convertToRangeOr(used, pi))
+}
+
 // listPartitionPruner uses to prune partition for list partition.
 type listPartitionPruner struct {
 	*partitionProcessor
@@ -352,7 +465,7 @@ type listPartitionPruner struct {
 	listPrune       *tables.ForListPruning
 }
 
-func newListPartitionPruner(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func newListPartitionPruner(ctx sessionctx.Context, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	s *partitionProcessor, conds []expression.Expression, pruneList *tables.ForListPruning) *listPartitionPruner {
 	colIDToUniqueID := make(map[int64]int64)
 	for _, cond := range conds {
@@ -366,7 +479,7 @@ func newListPartitionPruner(ctx sessionctx.Context, tbl table.Table, partitionNa
 	return &listPartitionPruner{
 		partitionProcessor: s,
 		ctx:                ctx,
-		pi:                 tbl.Meta().Partition,
+		pi:                 pi,
 		partitionNames:     partitionNames,
 		colIDToUniqueID:    colIDToUniqueID,
 		fullRange:          fullRange,
@@ -578,15 +691,14 @@ func (l *listPartitionPruner) findUsedListPartitions(conds []expression.Expressi
 	return used, nil
 }
 
-func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, tbl table.Table, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression) ([]int, error) {
-	pi := tbl.Meta().Partition
 	partExpr, err := tbl.(partitionTable).PartitionExpr()
 	if err != nil {
 		return nil, err
 	}
 
-	listPruner := newListPartitionPruner(ctx, tbl, partitionNames, s, conds, partExpr.ForListPruning)
+	listPruner := newListPartitionPruner(ctx, pi, partitionNames, s, conds, partExpr.ForListPruning)
 	var used map[int]struct{}
 	if partExpr.ForListPruning.ColPrunes == nil {
 		used, err = listPruner.findUsedListPartitions(conds)
@@ -608,9 +720,9 @@ func (s *partitionProcessor) findUsedListPartitions(ctx sessionctx.Context, tbl
 	return ret, nil
 }
 
-func (s *partitionProcessor) pruneListPartition(ctx sessionctx.Context, tbl table.Table, partitionNames []model.CIStr,
+func (s *partitionProcessor) pruneListPartition(ctx sessionctx.Context, tbl table.Table, pi *model.PartitionInfo, partitionNames []model.CIStr,
 	conds []expression.Expression) ([]int, error) {
-	used, err := s.findUsedListPartitions(ctx, tbl, partitionNames, conds)
+	used, err := s.findUsedListPartitions(ctx, tbl, pi, partitionNames, conds)
 	if err != nil {
 		return nil, err
 	}
@@ -628,12 +740,17 @@ func (s *partitionProcessor) prune(ds *DataSource) (LogicalPlan, error) {
 	for i, cond := range ds.allConds {
 		ds.allConds[i] = expression.PushDownNot(ds.ctx, cond)
 	}
+	if pi.Sub != nil {
+		return s.processSubPartition(ds, pi)
+	}
 	// Try to locate partition directly for hash partition.
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return s.processRangePartition(ds, pi)
 	case model.PartitionTypeHash:
 		return s.processHashPartition(ds, pi)
+	case model.PartitionTypeKey:
+		return s.processKeyPartition(ds, pi)
 	case model.PartitionTypeList:
 		return s.processListPartition(ds, pi)
 	}
@@ -652,6 +769,15 @@ func (s *partitionProcessor) findByName(partitionNames []model.CIStr, partitionN
 	return false
 }
 
+// findDefinitionByName checks whether the name of the i-th partition definition, or the name of the
+// logical partition it belongs to for a subpartitioned table, exists in list.
+func (s *partitionProcessor) findDefinitionByName(partitionNames []model.CIStr, pi *model.PartitionInfo, i int) bool {
+	if pi.Sub != nil && s.findByName(partitionNames, pi.Sub.Parents[i/int(pi.Sub.Num)].Name.L) {
+		return true
+	}
+	return s.findByName(partitionNames, pi.Definitions[i].Name.L)
+}
+
 func (*partitionProcessor) name() string {
 	return "partition_processor"
 }
@@ -884,7 +1010,7 @@ func (s *partitionProcessor) processRangePartition(ds *DataSource, pi *model.Par
 }
 
 func (s *partitionProcessor) processListPartition(ds *DataSource, pi *model.PartitionInfo) (LogicalPlan, error) {
-	used, err := s.pruneListPartition(ds.SCtx(), ds.table, ds.partitionNames, ds.allConds)
+	used, err := s.pruneListPartition(ds.SCtx(), ds.table, pi, ds.partitionNames, ds.allConds)
 	if err != nil {
 		return nil, err
 	}
@@ -1398,7 +1524,7 @@ func (s *partitionProcessor) makeUnionAllChildren(ds *DataSource, pi *model.Part
 		for i := r.start; i < r.end; i++ {
 			// This is for `table partition (p0,p1)` syntax, only union the specified partition if has specified partitions.
 			if len(ds.partitionNames) != 0 {
-				if !s.findByName(ds.partitionNames, pi.Definitions[i].Name.L) {
+				if !s.findDefinitionByName(ds.partitionNames, pi, i) {
 					continue
 				}
 			}
@@ -1447,10 +1573,6 @@ func (s *partitionProcessor) makeUnionAllChildren(ds *DataSource, pi *model.Part
 func (s *partitionProcessor) pruneRangeColumnsPartition(ctx sessionctx.Context, conds []expression.Expression, pi *model.PartitionInfo, pe *tables.PartitionExpr, columns []*expression.Column, names types.NameSlice) (partitionRangeOR, error) {
 	result := fullRange(len(pi.Definitions))
 
-	if len(pi.Columns) != 1 {
-		return result, nil
-	}
-
 	pruner, err := makeRangeColumnPruner(columns, names, pi, pe.ForRangeColumnsPruning)
 	if err == nil {
 		result = partitionRangeForCNFExpr(ctx, conds, pruner, result)
@@ -1465,6 +1587,9 @@ type rangeColumnsPruner struct {
 	data     []expression.Expression
 	partCol  *expression.Column
 	maxvalue bool
+	// multiCol indicates the table is partitioned by multiple columns and the partitions are pruned
+	// by the first one, so the values equal to the bound of a partition may also belong to it.
+	multiCol bool
 }
 
 func makeRangeColumnPruner(columns []*expression.Column, names types.NameSlice, pi *model.PartitionInfo, from *tables.ForRangeColumnsPruning) (*rangeColumnsPruner, error) {
@@ -1477,7 +1602,7 @@ func makeRangeColumnPruner(columns []*expression.Column, names types.NameSlice,
 			data[i] = from.LessThan[i].Clone()
 		}
 	}
-	return &rangeColumnsPruner{data, partCol, from.MaxValue}, nil
+	return &rangeColumnsPruner{data, partCol, from.MaxValue, len(pi.Columns) > 1}, nil
 }
 
 func (p *rangeColumnsPruner) fullRange() partitionRangeOR {
@@ -1532,6 +1657,10 @@ func (p *rangeColumnsPruner) pruneUseBinarySearch(sctx sessionctx.Context, op st
 				return true
 			}
 		}
+		if p.data[ith] == nil {
+			// The first column of a multiple columns bound is MAXVALUE.
+			return true
+		}
 		var expr expression.Expression
 		expr, err = expression.NewFunctionBase(sctx, op, types.NewFieldType(mysql.TypeLonglong), p.data[ith], v)
 		expr.SetCharsetAndCollation(f.CharsetAndCollation())
@@ -1545,10 +1674,20 @@ func (p *rangeColumnsPruner) pruneUseBinarySearch(sctx sessionctx.Context, op st
 	case ast.EQ:
 		pos := sort.Search(length, func(i int) bool { return compare(i, ast.GT, data) })
 		start, end = pos, pos+1
+		if p.multiCol {
+			start = sort.Search(length, func(i int) bool { return compare(i, ast.GE, data) })
+		}
 	case ast.LT:
 		pos := sort.Search(length, func(i int) bool { return compare(i, ast.GE, data) })
 		start, end = 0, pos+1
-	case ast.GE, ast.GT:
+	case ast.GE:
+		op := ast.GT
+		if p.multiCol {
+			op = ast.GE
+		}
+		pos := sort.Search(length, func(i int) bool { return compare(i, op, data) })
+		start, end = pos, length
+	case ast.GT:
 		pos := sort.Search(length, func(i int) bool { return compare(i, ast.GT, data) })
 		start, end = pos, length
 	case ast.LE:
@@ -56,6 +56,26 @@ func (p *LogicalTableDual) DeriveStats(childStats []*property.StatsInfo, selfSch
 	return p.stats, nil
 }
 
+// jsonTablePseudoRowCount is the estimated row count of JSON_TABLE, the rows are unknown until the
+// document is evaluated.
+const jsonTablePseudoRowCount = 10
+
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.stats != nil {
+		return p.stats, nil
+	}
+	profile := &property.StatsInfo{
+		RowCount: jsonTablePseudoRowCount,
+		ColNDVs:  make(map[int64]float64, selfSchema.Len()),
+	}
+	for _, col := range selfSchema.Columns {
+		profile.ColNDVs[col.UniqueID] = jsonTablePseudoRowCount
+	}
+	p.stats = profile
+	return p.stats, nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalMemTable) DeriveStats(childStats []*property.StatsInfo, selfSchema *expression.Schema, childSchema []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.stats != nil {
@@ -269,6 +289,10 @@ func (ds *DataSource) derivePathStatsAndTryHeuristics() error {
 		isRefinedPath                     bool
 	)
 	for _, path := range ds.possibleAccessPaths {
+		// The search index paths are chosen by the cost only.
+		if path.IsSearchIndexPath() {
+			continue
+		}
 		if path.IsTablePath() {
 			err := ds.deriveTablePathStats(path, ds.pushedDownConds, false)
 			if err != nil {
@@ -396,11 +420,16 @@ func (ds *DataSource) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		if path.IsTablePath() {
 			continue
 		}
+		if path.IsSearchIndexPath() {
+			ds.fillSearchIndexPath(path)
+			continue
+		}
 		err := ds.fillIndexPath(path, ds.pushedDownConds)
 		if err != nil {
 			return nil, err
 		}
 	}
+	ds.removeUnusableSearchIndexPaths()
 	// TODO: Can we move ds.deriveStatsByFilter after pruning by heuristics? In this way some computation can be avoided
 	// when ds.possibleAccessPaths are pruned.
 	ds.stats = ds.deriveStatsByFilter(ds.pushedDownConds, ds.possibleAccessPaths)
@@ -643,6 +672,9 @@ func (ds *DataSource) accessPathsForConds(conditions []expression.Expression, us
 				break
 			}
 		} else {
+			if ds.possibleAccessPaths[i].IsSearchIndexPath() {
+				continue
+			}
 			path.Index = ds.possibleAccessPaths[i].Index
 			if !ds.isInIndexMergeHints(path.Index.Name.L) {
 				continue
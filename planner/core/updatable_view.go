@@ -0,0 +1,543 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+)
+
+// updatableView is a view which is the target of UPDATE, DELETE or INSERT. It's built by merging
+// the view into the statement: the plan of the view outputs the columns of its base table ahead of
+// the view columns, so the rows can be written back to the base table. The base table columns are
+// not explicitly usable, the statement can only refer to the view columns.
+type updatableView struct {
+	plan LogicalPlan
+
+	dbName  model.CIStr
+	asName  model.CIStr
+	tblInfo *model.TableInfo
+
+	baseDBName  model.CIStr
+	baseTblInfo *model.TableInfo
+	// baseLen is the number of the base table columns at the head of the output of plan.
+	baseLen int
+	// baseRowSchema and baseRowNames are the columns of the base table in the order of the written row.
+	baseRowSchema *expression.Schema
+	baseRowNames  types.NameSlice
+	// baseExprs are the view columns expressed by the base table columns.
+	baseExprs []expression.Expression
+
+	// checks are the conditions of the views WITH CHECK OPTION, they are resolved by baseRowSchema.
+	checks []*table.ViewCheck
+}
+
+// baseColumn returns the base table column which the i-th view column refers to. It returns nil
+// if the view column is derived from an expression.
+func (v *updatableView) baseColumn(i int) *expression.Column {
+	col, ok := v.baseExprs[i].(*expression.Column)
+	if !ok || v.plan.Schema().ColumnIndex(col) >= v.baseLen {
+		return nil
+	}
+	return col
+}
+
+// baseColumnOf returns the base table column which the output view column refers to.
+func (v *updatableView) baseColumnOf(col *expression.Column) *expression.Column {
+	i := v.plan.Schema().ColumnIndex(col)
+	if i < v.baseLen {
+		return nil
+	}
+	return v.baseColumn(i - v.baseLen)
+}
+
+// outputBaseExprs returns all the output columns of the view plan expressed by the base table columns.
+func (v *updatableView) outputBaseExprs() []expression.Expression {
+	exprs := expression.Column2Exprs(v.plan.Schema().Columns[:v.baseLen])
+	return append(exprs, v.baseExprs...)
+}
+
+// viewNonUpdatableChecker finds the expressions which make a view not updatable.
+type viewNonUpdatableChecker struct {
+	found bool
+}
+
+// Enter implements Visitor interface.
+func (c *viewNonUpdatableChecker) Enter(in ast.Node) (ast.Node, bool) {
+	switch in.(type) {
+	case *ast.AggregateFuncExpr, *ast.WindowFuncExpr, *ast.SubqueryExpr:
+		c.found = true
+	}
+	return in, c.found
+}
+
+// Leave implements Visitor interface.
+func (c *viewNonUpdatableChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, !c.found
+}
+
+// updatableSelectSource returns the only table source of the select statement if the statement
+// can be merged into the statement writing through the view, otherwise it returns nil.
+// See https://dev.mysql.com/doc/refman/8.0/en/view-updatability.html
+func updatableSelectSource(node ast.Node) (*ast.SelectStmt, *ast.TableSource) {
+	sel, ok := node.(*ast.SelectStmt)
+	if !ok || sel.Kind != ast.SelectStmtKindSelect || sel.Distinct || sel.GroupBy != nil || sel.Having != nil ||
+		sel.Limit != nil || sel.With != nil || sel.WindowSpecs != nil || sel.From == nil || sel.From.TableRefs.Right != nil {
+		return nil, nil
+	}
+	source, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, nil
+	}
+	if _, ok := source.Source.(*ast.TableName); !ok {
+		return nil, nil
+	}
+	checker := &viewNonUpdatableChecker{}
+	for _, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			return nil, nil
+		}
+		field.Expr.Accept(checker)
+	}
+	if sel.Where != nil {
+		sel.Where.Accept(checker)
+	}
+	if checker.found {
+		return nil, nil
+	}
+	return sel, source
+}
+
+// updatableViewSource parses the select statement of the view, and returns it with its only
+// table source if the view is updatable, otherwise it returns nil.
+func updatableViewSource(sctx sessionctx.Context, tableInfo *model.TableInfo) (*ast.SelectStmt, *ast.TableSource, error) {
+	// The views of the old version record the column names of the select statement, they are
+	// not updatable.
+	if tableInfo.View.Algorithm == model.AlgorithmTemptable || tableInfo.View.Cols != nil {
+		return nil, nil, nil
+	}
+	charset, collation := sctx.GetSessionVars().GetCharsetInfo()
+	viewParser := parser.New()
+	viewParser.SetParserConfig(sctx.GetSessionVars().BuildParserConfig())
+	node, err := viewParser.ParseOneStmt(tableInfo.View.SelectStmt, charset, collation)
+	if err != nil {
+		return nil, nil, err
+	}
+	sel, source := updatableSelectSource(node)
+	if sel == nil || len(sel.Fields.Fields) != len(tableInfo.Columns) {
+		return nil, nil, nil
+	}
+	return sel, source, nil
+}
+
+// isUpdatableBaseTable checks whether the table can be the base table of an updatable view.
+func isUpdatableBaseTable(tbl table.Table) bool {
+	return !tbl.Meta().IsSequence() && !tbl.Type().IsVirtualTable()
+}
+
+// IsUpdatableView checks whether the rows can be written through the view.
+func IsUpdatableView(sctx sessionctx.Context, is infoschema.InfoSchema, dbName model.CIStr, tableInfo *model.TableInfo) bool {
+	visited := make(map[int64]struct{})
+	for {
+		if _, ok := visited[tableInfo.ID]; ok {
+			return false
+		}
+		visited[tableInfo.ID] = struct{}{}
+		_, source, err := updatableViewSource(sctx, tableInfo)
+		if err != nil || source == nil {
+			return false
+		}
+		tn := source.Source.(*ast.TableName)
+		if tn.Schema.L != "" {
+			dbName = tn.Schema
+		}
+		tbl, err := is.TableByName(dbName, tn.Name)
+		if err != nil {
+			return false
+		}
+		if !tbl.Meta().IsView() {
+			return isUpdatableBaseTable(tbl)
+		}
+		tableInfo = tbl.Meta()
+	}
+}
+
+// checkCreateViewCheckOption checks the select statement of a view defined WITH CHECK OPTION is updatable.
+func (b *PlanBuilder) checkCreateViewCheckOption(v *ast.CreateViewStmt) error {
+	if v.CheckOption == model.CheckOptionNone {
+		return nil
+	}
+	err := ErrViewNonupdCheck.GenWithStackByArgs(v.ViewName.Schema.O, v.ViewName.Name.O)
+	_, source := updatableSelectSource(v.Select)
+	if source == nil {
+		return err
+	}
+	tn := source.Source.(*ast.TableName)
+	dbName := tn.Schema
+	if dbName.L == "" {
+		dbName = v.ViewName.Schema
+	}
+	tbl, tblErr := b.is.TableByName(dbName, tn.Name)
+	if tblErr != nil {
+		return err
+	}
+	if tbl.Meta().IsView() && !IsUpdatableView(b.ctx, b.is, dbName, tbl.Meta()) || !isUpdatableBaseTable(tbl) {
+		return err
+	}
+	return nil
+}
+
+// collectUpdatableViews records the views in the FROM clause of UPDATE or DELETE, which are
+// built as updatable views by buildDataSource.
+func (b *PlanBuilder) collectUpdatableViews(node ast.ResultSetNode) {
+	switch x := node.(type) {
+	case *ast.Join:
+		b.collectUpdatableViews(x.Left)
+		if x.Right != nil {
+			b.collectUpdatableViews(x.Right)
+		}
+	case *ast.TableSource:
+		if tn, ok := x.Source.(*ast.TableName); ok && tn.TableInfo != nil && tn.TableInfo.IsView() {
+			if b.updatableViews == nil {
+				b.updatableViews = make(map[*ast.TableName]*updatableView)
+			}
+			b.updatableViews[tn] = nil
+		}
+	}
+}
+
+// updatableViewByName finds the updatable view referenced by the name.
+func (b *PlanBuilder) updatableViewByName(dbName, asName model.CIStr) *updatableView {
+	for _, view := range b.updatableViews {
+		if view != nil && view.dbName.L == dbName.L && view.asName.L == asName.L {
+			return view
+		}
+	}
+	return nil
+}
+
+// buildUpdatableView builds the view referenced as asName as an updatable view. The writePrivs
+// are the privileges required on the underlying tables. If cascaded is true, the conditions of
+// the view are checked though it's not defined WITH CHECK OPTION.
+// It returns nil if the view is not updatable.
+func (b *PlanBuilder) buildUpdatableView(ctx context.Context, dbName, asName model.CIStr, tableInfo *model.TableInfo,
+	writePrivs []mysql.PrivilegeType, cascaded bool) (*updatableView, error) {
+	deferFunc, err := b.checkRecursiveView(dbName, tableInfo.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer deferFunc()
+
+	sel, source, err := updatableViewSource(b.ctx, tableInfo)
+	if err != nil || sel == nil {
+		return nil, err
+	}
+	tn := source.Source.(*ast.TableName)
+	if tn.Schema.L == "" {
+		tn.Schema = dbName
+	}
+	srcAsName := source.AsName
+	if srcAsName.L == "" {
+		srcAsName = tn.Name
+	}
+	srcTbl, err := b.is.TableByName(tn.Schema, tn.Name)
+	if err != nil {
+		return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+	}
+	if !srcTbl.Meta().IsView() && !isUpdatableBaseTable(srcTbl) {
+		return nil, nil
+	}
+
+	originalVisitInfo := b.visitInfo
+	b.visitInfo = make([]visitInfo, 0)
+	view := &updatableView{dbName: dbName, asName: asName, tblInfo: tableInfo}
+	var (
+		p         LogicalPlan
+		baseExprs []expression.Expression
+	)
+	if srcTbl.Meta().IsView() {
+		checkSrc := cascaded || tableInfo.View.CheckOption == model.CheckOptionCascaded
+		inner, err := b.buildUpdatableView(ctx, tn.Schema, srcAsName, srcTbl.Meta(), writePrivs, checkSrc)
+		if err != nil || inner == nil {
+			b.visitInfo = originalVisitInfo
+			return nil, err
+		}
+		p, baseExprs = inner.plan, inner.outputBaseExprs()
+		view.baseDBName, view.baseTblInfo, view.baseLen = inner.baseDBName, inner.baseTblInfo, inner.baseLen
+		view.baseRowSchema, view.baseRowNames, view.checks = inner.baseRowSchema, inner.baseRowNames, inner.checks
+	} else {
+		p, err = b.buildDataSource(ctx, tn, &srcAsName)
+		if err != nil {
+			return nil, err
+		}
+		rowLen := len(srcTbl.Cols())
+		if b.inUpdateStmt {
+			rowLen = len(srcTbl.WritableCols())
+		} else if b.inDeleteStmt {
+			rowLen = len(srcTbl.DeletableCols())
+		}
+		baseExprs = expression.Column2Exprs(p.Schema().Columns)
+		view.baseDBName, view.baseTblInfo, view.baseLen = tn.Schema, srcTbl.Meta(), p.Schema().Len()
+		view.baseRowSchema = expression.NewSchema(append([]*expression.Column(nil), p.Schema().Columns[:rowLen]...)...)
+		view.baseRowNames = p.OutputNames()[:rowLen]
+	}
+	for _, priv := range writePrivs {
+		b.visitInfo = appendVisitInfo(b.visitInfo, priv, tn.Schema.L, srcTbl.Meta().Name.L, "", nil)
+	}
+
+	if sel.Where != nil {
+		if cascaded || tableInfo.View.CheckOption != model.CheckOptionNone {
+			cond, _, err := b.rewrite(ctx, sel.Where, p, nil, true)
+			if err != nil {
+				return nil, err
+			}
+			cond, err = expression.ColumnSubstitute(cond, p.Schema(), baseExprs).ResolveIndices(view.baseRowSchema)
+			if err != nil {
+				return nil, err
+			}
+			view.checks = append(view.checks, &table.ViewCheck{DBName: dbName, ViewName: tableInfo.Name, Cond: cond})
+		}
+		p, err = b.buildSelection(ctx, p, sel.Where, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	b.curClause = fieldList
+	proj := LogicalProjection{Exprs: make([]expression.Expression, 0, view.baseLen+len(sel.Fields.Fields))}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema(make([]*expression.Column, 0, view.baseLen+len(sel.Fields.Fields))...)
+	names := make(types.NameSlice, 0, view.baseLen+len(sel.Fields.Fields))
+	for i, col := range p.Schema().Columns[:view.baseLen] {
+		name := *p.OutputNames()[i]
+		name.DBName, name.TblName, name.OrigTblName = dbName, asName, tableInfo.Name
+		name.NotExplicitUsable = true
+		proj.Exprs = append(proj.Exprs, col)
+		schema.Append(col)
+		names = append(names, &name)
+	}
+	for i, field := range sel.Fields.Fields {
+		expr, _, err := b.rewrite(ctx, field.Expr, p, nil, true)
+		if err != nil {
+			return nil, err
+		}
+		proj.Exprs = append(proj.Exprs, expr)
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  expr.GetType(),
+		})
+		names = append(names, &types.FieldName{
+			DBName:      dbName,
+			TblName:     asName,
+			OrigTblName: tableInfo.Name,
+			ColName:     tableInfo.Columns[i].Name,
+			OrigColName: tableInfo.Columns[i].Name,
+		})
+		view.baseExprs = append(view.baseExprs, expression.ColumnSubstitute(expr, p.Schema(), baseExprs))
+	}
+	proj.SetChildren(p)
+	proj.SetSchema(schema)
+	proj.names = names
+	view.plan = proj
+
+	if tableInfo.View.Security == model.SecurityDefiner {
+		if pm := privilege.GetPrivilegeManager(b.ctx); pm != nil {
+			for _, v := range b.visitInfo {
+				if !pm.RequestVerificationWithUser(v.db, v.table, v.column, v.privilege, tableInfo.View.Definer) {
+					return nil, ErrViewInvalid.GenWithStackByArgs(dbName.O, tableInfo.Name.O)
+				}
+			}
+		}
+		b.visitInfo = b.visitInfo[:0]
+	}
+	if b.ctx.GetSessionVars().StmtCtx.InExplainStmt {
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ShowViewPriv, dbName.L, tableInfo.Name.L, "", ErrViewNoExplain)
+	}
+	// Keep the privilege of the view itself at last, DELETE without WHERE pops it.
+	b.visitInfo = append(b.visitInfo, originalVisitInfo...)
+	return view, nil
+}
+
+// buildViewGenerationPlan returns a plan with the same schema as p, in which only the hidden base
+// table columns of the view are usable, to resolve the generation expressions of the base table.
+func (b *PlanBuilder) buildViewGenerationPlan(p LogicalPlan, view *updatableView) LogicalPlan {
+	names := make(types.NameSlice, 0, len(p.OutputNames()))
+	for _, name := range p.OutputNames() {
+		newName := *name
+		newName.NotExplicitUsable = !(name.NotExplicitUsable && name.DBName.L == view.dbName.L && name.TblName.L == view.asName.L)
+		names = append(names, &newName)
+	}
+	dual := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	dual.SetSchema(p.Schema())
+	dual.names = names
+	return dual
+}
+
+// viewColumnRenamer renames the view columns referenced by the expressions of INSERT to the
+// columns of the base table in place, the renamed columns are recorded to be restored.
+type viewColumnRenamer struct {
+	view     *updatableView
+	colMap   map[string]model.CIStr
+	renamed  []*ast.ColumnName
+	original []ast.ColumnName
+}
+
+// Enter implements Visitor interface.
+func (r *viewColumnRenamer) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.SubqueryExpr:
+		return in, true
+	case *ast.ColumnName:
+		if (x.Schema.L != "" && x.Schema.L != r.view.dbName.L) || (x.Table.L != "" && x.Table.L != r.view.asName.L) {
+			return in, true
+		}
+		if baseName, ok := r.colMap[x.Name.L]; ok {
+			r.renamed = append(r.renamed, x)
+			r.original = append(r.original, *x)
+			x.Schema, x.Table, x.Name = model.CIStr{}, model.CIStr{}, baseName
+		}
+	}
+	return in, false
+}
+
+// Leave implements Visitor interface.
+func (r *viewColumnRenamer) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+func (r *viewColumnRenamer) restore() {
+	for i, col := range r.renamed {
+		*col = r.original[i]
+	}
+}
+
+// buildInsertView builds the view which is the target of INSERT or REPLACE, and returns the
+// statement inserting into the base table of the view. The returned function restores the
+// column names renamed in place, it should be called after the statement is built.
+func (b *PlanBuilder) buildInsertView(ctx context.Context, insert *ast.InsertStmt, tn *ast.TableName) (*updatableView, *ast.InsertStmt, func(), error) {
+	stmtName, writePrivs := "INSERT", []mysql.PrivilegeType{mysql.InsertPriv}
+	if insert.IsReplace {
+		stmtName, writePrivs = "REPLACE", append(writePrivs, mysql.DeletePriv)
+	} else if insert.OnDuplicate != nil {
+		writePrivs = append(writePrivs, mysql.UpdatePriv)
+	}
+	errNonInsertable := ErrNonInsertableTable.GenWithStackByArgs(tn.Name.O, stmtName)
+
+	b.pushSelectOffset(0)
+	b.pushTableHints(insert.TableHints, 0)
+	defer func() {
+		b.popSelectOffset()
+		b.popTableHints()
+	}()
+	originalVisitInfo := b.visitInfo
+	b.visitInfo = nil
+	view, err := b.buildUpdatableView(ctx, tn.DBInfo.Name, tn.Name, tn.TableInfo, writePrivs, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if view == nil {
+		return nil, nil, nil, errNonInsertable
+	}
+	b.handleHelper.popMap()
+	// Reading the underlying tables is not required to insert into the view.
+	visitInfo := make([]visitInfo, 0, len(b.visitInfo)+len(originalVisitInfo))
+	for _, v := range b.visitInfo {
+		if v.privilege != mysql.SelectPriv {
+			visitInfo = append(visitInfo, v)
+		}
+	}
+	b.visitInfo = append(visitInfo, originalVisitInfo...)
+
+	// All the view columns must refer to distinct base table columns.
+	colMap := make(map[string]model.CIStr, len(view.tblInfo.Columns))
+	used := make(map[string]struct{}, len(view.tblInfo.Columns))
+	for i, col := range view.tblInfo.Columns {
+		baseCol := view.baseColumn(i)
+		if baseCol == nil {
+			return nil, nil, nil, errNonInsertable
+		}
+		baseName := view.plan.OutputNames()[view.plan.Schema().ColumnIndex(baseCol)].ColName
+		if _, ok := used[baseName.L]; ok {
+			return nil, nil, nil, errNonInsertable
+		}
+		used[baseName.L] = struct{}{}
+		colMap[col.Name.L] = baseName
+	}
+	mapColumn := func(col *ast.ColumnName) (*ast.ColumnName, error) {
+		baseName, ok := colMap[col.Name.L]
+		if !ok || (col.Schema.L != "" && col.Schema.L != view.dbName.L) || (col.Table.L != "" && col.Table.L != view.asName.L) {
+			return nil, ErrUnknownColumn.GenWithStackByArgs(col.OrigColName(), clauseMsg[fieldList])
+		}
+		return &ast.ColumnName{Name: baseName}, nil
+	}
+	mapAssignments := func(list []*ast.Assignment) ([]*ast.Assignment, error) {
+		if len(list) == 0 {
+			return nil, nil
+		}
+		newList := make([]*ast.Assignment, 0, len(list))
+		for _, assign := range list {
+			col, err := mapColumn(assign.Column)
+			if err != nil {
+				return nil, err
+			}
+			newList = append(newList, &ast.Assignment{Column: col, Expr: assign.Expr})
+		}
+		return newList, nil
+	}
+
+	newInsert := *insert
+	newInsert.Columns = make([]*ast.ColumnName, 0, len(view.tblInfo.Columns))
+	if len(insert.Columns) == 0 && len(insert.Setlist) == 0 {
+		for _, col := range view.tblInfo.Columns {
+			newInsert.Columns = append(newInsert.Columns, &ast.ColumnName{Name: colMap[col.Name.L]})
+		}
+	}
+	for _, col := range insert.Columns {
+		newCol, err := mapColumn(col)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		newInsert.Columns = append(newInsert.Columns, newCol)
+	}
+	if newInsert.Setlist, err = mapAssignments(insert.Setlist); err != nil {
+		return nil, nil, nil, err
+	}
+	if newInsert.OnDuplicate, err = mapAssignments(insert.OnDuplicate); err != nil {
+		return nil, nil, nil, err
+	}
+
+	renamer := &viewColumnRenamer{view: view, colMap: colMap}
+	for _, list := range insert.Lists {
+		for _, expr := range list {
+			expr.Accept(renamer)
+		}
+	}
+	for _, assign := range insert.Setlist {
+		assign.Expr.Accept(renamer)
+	}
+	for _, assign := range insert.OnDuplicate {
+		assign.Expr.Accept(renamer)
+	}
+	return view, &newInsert, renamer.restore, nil
+}
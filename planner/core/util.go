@@ -354,15 +354,3 @@ func cloneRanges(ranges []*ranger.Range) []*ranger.Range {
 	}
 	return cloned
 }
-
-func clonePhysicalPlan(plans []PhysicalPlan) ([]PhysicalPlan, error) {
-	cloned := make([]PhysicalPlan, 0, len(plans))
-	for _, p := range plans {
-		c, err := p.Clone()
-		if err != nil {
-			return nil, err
-		}
-		cloned = append(cloned, c)
-	}
-	return cloned, nil
-}
@@ -164,6 +164,9 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 		sessVars.StmtCtx.AppendWarning(errors.New("sql_select_limit is set, ignore SQL bindings"))
 		useBinding = false
 	}
+	if sessVars.EnableGeneralPlanCache && !useBinding && plannercore.GeneralPlanCacheable(sctx, node, is) {
+		return optimizeWithGeneralPlanCache(ctx, sctx, stmtNode, is)
+	}
 
 	var names types.NameSlice
 	var bestPlan, bestPlanFromBind plannercore.Plan
@@ -264,6 +267,27 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	return bestPlan, names, nil
 }
 
+// optimizeWithGeneralPlanCache parameterizes the literals of the text protocol statement, and reuses
+// the plan cached for the parameterized statement if possible, otherwise optimizes and caches it.
+func optimizeWithGeneralPlanCache(ctx context.Context, sctx sessionctx.Context, node ast.StmtNode, is infoschema.InfoSchema) (plannercore.Plan, types.NameSlice, error) {
+	ps, err := plannercore.ParameterizeAST(node)
+	if err != nil {
+		bestPlan, names, _, err := optimize(ctx, sctx, node, is)
+		return bestPlan, names, err
+	}
+	defer ps.RestoreAST()
+	bestPlan, names, ok, err := plannercore.GetPlanFromGeneralPlanCache(ctx, sctx, is, ps)
+	if err != nil || ok {
+		return bestPlan, names, err
+	}
+	bestPlan, names, _, err = optimize(ctx, sctx, node, is)
+	if err != nil {
+		return nil, nil, err
+	}
+	plannercore.PutPlanIntoGeneralPlanCache(sctx, ps, bestPlan, names)
+	return bestPlan, names, nil
+}
+
 func allowInReadOnlyMode(sctx sessionctx.Context, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -698,7 +722,29 @@ func setFoundInBinding(sctx sessionctx.Context, opt bool) error {
 	return err
 }
 
+// getBindSQL4PlanCache returns the SQL of the bindings which the plan of the statement is built from,
+// it returns "" if the bindings are not used.
+func getBindSQL4PlanCache(sctx sessionctx.Context, stmt ast.StmtNode) string {
+	sessVars := sctx.GetSessionVars()
+	if !sessVars.UsePlanBaselines || sessVars.SelectLimit != math.MaxUint64 {
+		return ""
+	}
+	bindRecord, _, err := getBindRecord(sctx, stmt)
+	if err != nil || bindRecord == nil {
+		return ""
+	}
+	var bindSQL strings.Builder
+	for _, binding := range bindRecord.Bindings {
+		if binding.Status == bindinfo.Using {
+			bindSQL.WriteString(binding.BindSQL)
+			bindSQL.WriteByte(';')
+		}
+	}
+	return bindSQL.String()
+}
+
 func init() {
 	plannercore.OptimizeAstNode = Optimize
 	plannercore.IsReadOnly = IsReadOnly
+	plannercore.GetBindSQL4PlanCache = getBindSQL4PlanCache
 }
@@ -65,6 +65,9 @@ type AccessPath struct {
 	Forced bool
 	// IsSingleScan indicates whether the path is a single index/table scan or table access after index scan.
 	IsSingleScan bool
+	// SearchCond is the condition looked up through the index of the search index path, it's nil if the path
+	// can't be used.
+	SearchCond expression.Expression
 }
 
 // IsTablePath returns true if it's IntHandlePath or CommonHandlePath.
@@ -72,6 +75,12 @@ func (path *AccessPath) IsTablePath() bool {
 	return path.IsIntHandlePath || path.IsCommonHandlePath
 }
 
+// IsSearchIndexPath returns true if the path reads a FULLTEXT, SPATIAL or multi-valued index, whose entries are
+// looked up by the `MATCH ... AGAINST`, spatial relation or JSON array conditions instead of scanned by ranges.
+func (path *AccessPath) IsSearchIndexPath() bool {
+	return path.Index != nil && (path.Index.IsFulltext() || path.Index.IsSpatial() || path.Index.MVIndex)
+}
+
 // SplitCorColAccessCondFromFilters move the necessary filter in the form of index_col = corrlated_col to access conditions.
 // The function consider the `idx_col_1 = const and index_col_2 = cor_col and index_col_3 = const` case.
 // It enables more index columns to be considered. The range will be rebuilt in 'ResolveCorrelatedColumns'.
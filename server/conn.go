@@ -1411,6 +1411,9 @@ func (cc *clientConn) writeError(ctx context.Context, e error) error {
 		switch y := e.(type) {
 		case *terror.Error:
 			m = terror.ToSQLError(y)
+		case *mysql.SQLError:
+			// The error raised by SIGNAL keeps its error code and SQLSTATE.
+			m = y
 		default:
 			m = mysql.NewErrf(mysql.ErrUnknown, "%s", nil, e.Error())
 		}
@@ -0,0 +1,80 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// advisoryLock is the lock acquired by the GET_LOCK function.
+// Every lock is held by the pessimistic transaction of a dedicated internal session,
+// which locks the key of the lock name in mysql.advisory_locks and is never committed.
+// So the lock is visible to the whole cluster, and the locks of a session
+// can be released in any order by rolling back their own transactions.
+type advisoryLock struct {
+	ctx            context.Context
+	session        *session
+	referenceCount int
+}
+
+// IncrReferences increments the reference count of the lock.
+func (a *advisoryLock) IncrReferences() {
+	a.referenceCount++
+}
+
+// DecrReferences decrements the reference count of the lock.
+func (a *advisoryLock) DecrReferences() {
+	a.referenceCount--
+}
+
+// ReferenceCount returns the reference count of the lock.
+func (a *advisoryLock) ReferenceCount() int {
+	return a.referenceCount
+}
+
+// Close releases the lock by rolling back the transaction and closing the session.
+func (a *advisoryLock) Close() {
+	_, err := a.session.ExecuteInternal(a.ctx, "ROLLBACK")
+	terror.Log(err)
+	a.session.Close()
+}
+
+// GetLock acquires the lock, waiting for at most lockWaitTimeout milliseconds.
+// The lock wait timeout follows the conventions of the pessimistic lock,
+// see LockNoWait and LockAlwaysWait of the client-go kv package.
+func (a *advisoryLock) GetLock(lockName string, lockWaitTimeout int64) error {
+	_, err := a.session.ExecuteInternal(a.ctx, "BEGIN PESSIMISTIC")
+	if err != nil {
+		return err
+	}
+	// The global variables are loaded by the first statement of the session,
+	// so the lock wait timeout must be set after it.
+	a.session.GetSessionVars().LockWaitTimeout = lockWaitTimeout
+	rs, err := a.session.ExecuteInternal(a.ctx, "SELECT lock_name FROM mysql.advisory_locks WHERE lock_name = %? FOR UPDATE", lockName)
+	if err == nil {
+		_, err = sqlexec.DrainRecordSet(a.ctx, rs, 1)
+		terror.Log(rs.Close())
+	}
+	if err != nil {
+		// The lock is held by another session, or the waiting is interrupted.
+		_, rollbackErr := a.session.ExecuteInternal(a.ctx, "ROLLBACK")
+		terror.Log(rollbackErr)
+		return err
+	}
+	return nil
+}
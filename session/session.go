@@ -24,6 +24,7 @@ import (
 	"crypto/tls"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
 	"runtime/pprof"
 	"runtime/trace"
@@ -57,6 +58,7 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
@@ -79,6 +81,7 @@ import (
 	"github.com/pingcap/tidb/telemetry"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/advisorylock"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
@@ -212,6 +215,8 @@ type session struct {
 	ddlOwnerChecker owner.DDLOwnerChecker
 	// lockedTables use to record the table locks hold by the session.
 	lockedTables map[int64]model.TableLockTpInfo
+	// advisoryLocks use to record the advisory locks hold by the session.
+	advisoryLocks map[string]*advisoryLock
 
 	// client shared coprocessor client per session
 	client kv.Client
@@ -284,6 +289,147 @@ func (s *session) ReleaseAllTableLocks() {
 	s.lockedTables = make(map[int64]model.TableLockTpInfo)
 }
 
+// GetAdvisoryLock acquires an advisory lock of lockName.
+// Note that a lock can be acquired multiple times by the same session,
+// in which case we increment a reference count.
+// Each lock needs to be held in a unique session because
+// we need to be able to ROLLBACK in any arbitrary order
+// in order to release the locks.
+func (s *session) GetAdvisoryLock(lockName string, timeout int64) error {
+	if lock, ok := s.advisoryLocks[lockName]; ok {
+		lock.IncrReferences()
+		return nil
+	}
+	connID := s.sessionVars.ConnectionID
+	if !advisorylock.GlobalRegistry.Wait(lockName, connID) {
+		return expression.ErrUserLockDeadlock.GenWithStackByArgs()
+	}
+	se, err := createSession(s.store)
+	if err != nil {
+		advisorylock.GlobalRegistry.Cancel(connID)
+		return err
+	}
+	lock := &advisoryLock{session: se, ctx: context.TODO(), referenceCount: 1}
+	if err = s.waitAdvisoryLock(lock, lockName, timeout); err != nil {
+		advisorylock.GlobalRegistry.Cancel(connID)
+		se.Close()
+		return err
+	}
+	advisorylock.GlobalRegistry.Grant(lockName, connID)
+	s.advisoryLocks[lockName] = lock
+	return nil
+}
+
+// waitAdvisoryLock acquires the advisory lock in the session of the lock.
+// If the current session is killed during waiting, the waiting is interrupted too.
+func (s *session) waitAdvisoryLock(lock *advisoryLock, lockName string, timeout int64) error {
+	lockWaitTimeout := timeout * 1000
+	if timeout < 0 || timeout > math.MaxInt64/1000 {
+		lockWaitTimeout = tikvstore.LockAlwaysWait
+	} else if timeout == 0 {
+		lockWaitTimeout = tikvstore.LockNoWait
+	}
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(10 * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if atomic.LoadUint32(&s.sessionVars.Killed) == 1 {
+					atomic.StoreUint32(&lock.session.sessionVars.Killed, 1)
+					return
+				}
+			}
+		}
+	}()
+	defer close(done)
+	return lock.GetLock(lockName, lockWaitTimeout)
+}
+
+// ReleaseAdvisoryLock releases an advisory lock held by the session.
+// It returns false if the session doesn't hold the lock.
+func (s *session) ReleaseAdvisoryLock(lockName string) (released bool) {
+	lock, ok := s.advisoryLocks[lockName]
+	if !ok {
+		return false
+	}
+	lock.DecrReferences()
+	if lock.ReferenceCount() <= 0 {
+		lock.Close()
+		delete(s.advisoryLocks, lockName)
+		advisorylock.GlobalRegistry.Release(lockName, s.sessionVars.ConnectionID)
+	}
+	return true
+}
+
+// ReleaseAllAdvisoryLocks releases all advisory locks held by the session
+// and returns the number of the released references.
+func (s *session) ReleaseAllAdvisoryLocks() int {
+	var count int
+	for lockName, lock := range s.advisoryLocks {
+		lock.Close()
+		count += lock.ReferenceCount()
+		delete(s.advisoryLocks, lockName)
+		advisorylock.GlobalRegistry.Release(lockName, s.sessionVars.ConnectionID)
+	}
+	return count
+}
+
+// IsUsedAdvisoryLock checks whether the advisory lock is held by any session of the cluster.
+func (s *session) IsUsedAdvisoryLock(lockName string) (bool, error) {
+	if _, ok := s.advisoryLocks[lockName]; ok {
+		return true, nil
+	}
+	if _, ok := advisorylock.GlobalRegistry.Holder(lockName); ok {
+		return true, nil
+	}
+	// Try to acquire the lock without waiting, the lock is free if it succeeds.
+	se, err := createSession(s.store)
+	if err != nil {
+		return false, err
+	}
+	lock := &advisoryLock{session: se, ctx: context.TODO()}
+	defer lock.Close()
+	err = lock.GetLock(lockName, tikvstore.LockNoWait)
+	if err == nil {
+		return false, nil
+	}
+	if storeerr.ErrLockAcquireFailAndNoWaitSet.Equal(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+// AdvisoryLockHolder returns the connection ID of the session holding the advisory lock,
+// or 0 if the lock is free or its holder can't be found.
+func (s *session) AdvisoryLockHolder(lockName string) (uint64, error) {
+	if _, ok := s.advisoryLocks[lockName]; ok {
+		return s.sessionVars.ConnectionID, nil
+	}
+	if connID, ok := advisorylock.GlobalRegistry.Holder(lockName); ok {
+		return connID, nil
+	}
+	used, err := s.IsUsedAdvisoryLock(lockName)
+	if err != nil || !used {
+		return 0, err
+	}
+	// The lock is held by a session of another TiDB instance.
+	ctx := context.TODO()
+	stmt, err := s.ParseWithParams(ctx, "SELECT SESSION_ID FROM INFORMATION_SCHEMA.CLUSTER_ADVISORY_LOCKS WHERE LOCK_NAME = %? AND STATE = %?",
+		lockName, advisorylock.StateGranted)
+	if err != nil {
+		return 0, err
+	}
+	rows, _, err := s.ExecRestrictedStmt(ctx, stmt)
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	return rows[0].GetUint64(0), nil
+}
+
 // DDLOwnerChecker returns s.ddlOwnerChecker.
 func (s *session) DDLOwnerChecker() owner.DDLOwnerChecker {
 	return s.ddlOwnerChecker
@@ -910,7 +1056,7 @@ func (s *session) retry(ctx context.Context, maxCnt uint) (err error) {
 				// We do not have to log the query every time.
 				// We print the queries at the first try only.
 				sql := sqlForLog(st.GetTextToLog())
-				if !sessVars.EnableRedactLog {
+				if !sessVars.EnableRedactLog && !sessVars.StmtCtx.UseGeneralPlanCache {
 					sql += sessVars.PreparedParams.String()
 				}
 				logutil.Logger(ctx).Warn("retrying",
@@ -2174,6 +2320,7 @@ type inCloseSession struct{}
 // Close function does some clean work when session end.
 // Close should release the table locks which hold by the session.
 func (s *session) Close() {
+	s.ReleaseAllAdvisoryLocks()
 	// TODO: do clean table locks when session exited without execute Close.
 	// TODO: do clean table locks when tidb-server was `kill -9`.
 	if s.HasLockedTables() && config.TableLockEnabled() {
@@ -2626,6 +2773,7 @@ func createSessionWithOpt(store kv.Storage, opt *Opt) (*session, error) {
 	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
 	s.lockedTables = make(map[int64]model.TableLockTpInfo)
+	s.advisoryLocks = make(map[string]*advisoryLock)
 	domain.BindDomain(s, dom)
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
@@ -2655,6 +2803,7 @@ func CreateSessionWithDomain(store kv.Storage, dom *domain.Domain) (*session, er
 	}
 	s.mu.values = make(map[fmt.Stringer]interface{})
 	s.lockedTables = make(map[int64]model.TableLockTpInfo)
+	s.advisoryLocks = make(map[string]*advisoryLock)
 	domain.BindDomain(s, dom)
 	// session implements variable.GlobalVarAccessor. Bind it to ctx.
 	s.sessionVars.GlobalVarsAccessor = s
@@ -2897,7 +3046,7 @@ func logGeneralQuery(execStmt *executor.ExecStmt, s *session, isPrepared bool) {
 		}
 
 		query = executor.QueryReplacer.Replace(query)
-		if !vars.EnableRedactLog {
+		if !vars.EnableRedactLog && !vars.StmtCtx.UseGeneralPlanCache {
 			query += vars.PreparedParams.String()
 		}
 		logutil.BgLogger().Info("GENERAL_LOG",
@@ -126,6 +126,21 @@ type Context interface {
 	ReleaseAllTableLocks()
 	// HasLockedTables uses to check whether this session locked any tables.
 	HasLockedTables() bool
+	// GetAdvisoryLock acquires the advisory lock of lockName, waiting for at most timeout seconds.
+	// A negative timeout means waiting infinitely. The lock can be acquired multiple times by the session,
+	// in which case it holds a reference count.
+	GetAdvisoryLock(lockName string, timeout int64) error
+	// ReleaseAdvisoryLock releases a reference of the advisory lock held by the session.
+	// It returns false if the session doesn't hold the lock.
+	ReleaseAdvisoryLock(lockName string) (released bool)
+	// ReleaseAllAdvisoryLocks releases all advisory locks held by the session,
+	// and returns the number of the released references.
+	ReleaseAllAdvisoryLocks() int
+	// IsUsedAdvisoryLock checks whether the advisory lock is held by any session.
+	IsUsedAdvisoryLock(lockName string) (bool, error)
+	// AdvisoryLockHolder returns the connection ID of the session holding the advisory lock,
+	// or 0 if the lock is free or its holder can't be found.
+	AdvisoryLockHolder(lockName string) (uint64, error)
 	// PrepareTSFuture uses to prepare timestamp by future.
 	PrepareTSFuture(ctx context.Context)
 	// StoreIndexUsage stores the index usage information.
@@ -88,10 +88,18 @@ type StatementContext struct {
 	IgnoreNoPartition            bool
 	MaybeOverOptimized4PlanCache bool
 	IgnoreExplainIDSuffix        bool
+	// UseGeneralPlanCache indicates the literals of the text protocol statement are replaced by
+	// parameters to look up the general plan cache.
+	UseGeneralPlanCache bool
 	// If the select statement was like 'select * from t as of timestamp ...' or in a stale read transaction
 	// or is affected by the tidb_read_staleness session variable, then the statement will be makred as isStaleness
 	// in stmtCtx
 	IsStaleness bool
+	// InTrigger is true if the statement is executed by a trigger. It reads the changes made by the
+	// triggering statement, and it can't be retried alone.
+	InTrigger bool
+	// MultiSchemaInfo is used to collect the sub-jobs of the multi-schema change statement.
+	MultiSchemaInfo *model.MultiSchemaInfo
 	// mu struct holds variables that change during execution.
 	mu struct {
 		sync.Mutex
@@ -425,6 +433,46 @@ func (sc *StatementContext) AddTouchedRows(rows uint64) {
 	sc.mu.touched += rows
 }
 
+// DMLRowCounters holds the row counters and the message of DML statements.
+type DMLRowCounters struct {
+	AffectedRows uint64
+	FoundRows    uint64
+	Records      uint64
+	Updated      uint64
+	Copied       uint64
+	Touched      uint64
+	Message      string
+}
+
+// GetDMLRowCounters returns the current row counters and message.
+func (sc *StatementContext) GetDMLRowCounters() DMLRowCounters {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	return DMLRowCounters{
+		AffectedRows: sc.mu.affectedRows,
+		FoundRows:    sc.mu.foundRows,
+		Records:      sc.mu.records,
+		Updated:      sc.mu.updated,
+		Copied:       sc.mu.copied,
+		Touched:      sc.mu.touched,
+		Message:      sc.mu.message,
+	}
+}
+
+// SetDMLRowCounters restores the row counters and message, it is used to hide the
+// rows changed by internally executed statements, e.g. foreign key cascades.
+func (sc *StatementContext) SetDMLRowCounters(c DMLRowCounters) {
+	sc.mu.Lock()
+	defer sc.mu.Unlock()
+	sc.mu.affectedRows = c.AffectedRows
+	sc.mu.foundRows = c.FoundRows
+	sc.mu.records = c.Records
+	sc.mu.updated = c.Updated
+	sc.mu.copied = c.Copied
+	sc.mu.touched = c.Touched
+	sc.mu.message = c.Message
+}
+
 // GetMessage returns the extra message of the last executed command, if there is no message, it returns empty string
 func (sc *StatementContext) GetMessage() string {
 	sc.mu.Lock()
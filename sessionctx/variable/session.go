@@ -716,6 +716,10 @@ type SessionVars struct {
 	// EnablePlacementChecks indicates whether a user can check validation of placement.
 	EnablePlacementChecks bool
 
+	// ForeignKeyChecks indicates whether the foreign key constraints are checked
+	// and the referential actions are executed by DML statements.
+	ForeignKeyChecks bool
+
 	// WaitSplitRegionFinish defines the split region behaviour is sync or async.
 	WaitSplitRegionFinish bool
 
@@ -934,6 +938,9 @@ type SessionVars struct {
 	// EnablePseudoForOutdatedStats if using pseudo for outdated stats
 	EnablePseudoForOutdatedStats bool
 
+	// EnableGeneralPlanCache indicates whether to cache the plans of the text protocol statements.
+	EnableGeneralPlanCache bool
+
 	// LocalTemporaryTables is *infoschema.LocalTemporaryTables, use interface to avoid circle dependency.
 	// It's nil if there is no local temporary table.
 	LocalTemporaryTables interface{}
@@ -1188,6 +1195,7 @@ func NewSessionVars() *SessionVars {
 		MPPStoreLastFailTime:        make(map[string]time.Time),
 		MPPStoreFailTTL:             DefTiDBMPPStoreFailTTL,
 		EnablePlacementChecks:       DefEnablePlacementCheck,
+		ForeignKeyChecks:            DefForeignKeyChecks,
 	}
 	vars.KVVars = tikvstore.NewVariables(&vars.Killed)
 	vars.Concurrency = Concurrency{
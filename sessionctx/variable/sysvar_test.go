@@ -18,6 +18,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -404,14 +405,20 @@ func TestTimeZone(t *testing.T) {
 func TestForeignKeyChecks(t *testing.T) {
 	sv := GetSysVar(ForeignKeyChecks)
 	vars := NewSessionVars()
+	require.False(t, vars.ForeignKeyChecks)
 
 	val, err := sv.Validate(vars, "on", ScopeSession)
 	require.NoError(t, err)
-	require.Equal(t, "OFF", val) // warns and refuses to set ON.
-
-	warn := vars.StmtCtx.GetWarnings()[0].Err
-	require.Equal(t, "[variable:8047]variable 'foreign_key_checks' does not yet support value: on", warn.Error())
+	require.Equal(t, "ON", val)
+	require.NoError(t, sv.SetSessionFromHook(vars, val))
+	require.True(t, vars.ForeignKeyChecks)
+	require.Len(t, vars.StmtCtx.GetWarnings(), 0)
 
+	val, err = sv.Validate(vars, "0", ScopeSession)
+	require.NoError(t, err)
+	require.Equal(t, "OFF", val)
+	require.NoError(t, sv.SetSessionFromHook(vars, val))
+	require.False(t, vars.ForeignKeyChecks)
 }
 
 func TestTxnIsolation(t *testing.T) {
@@ -817,3 +824,56 @@ func TestDefaultCharsetAndCollation(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, val, mysql.DefaultCollationName)
 }
+
+func TestPlanAffectingSysVars(t *testing.T) {
+	// The tidb_enable_* variables which are never read when building the plans of the cacheable
+	// statements. A new tidb_opt_* or tidb_enable_* variable must either be marked with AffectsPlan
+	// or be added here, otherwise the plan caches may share a plan built with a different value.
+	planIndependent := map[string]struct{}{
+		TiDBEnable1PC:                      {},
+		TiDBEnableAlterPlacement:           {},
+		TiDBEnableAmendPessimisticTxn:      {},
+		TiDBEnableAsyncCommit:              {},
+		TiDBEnableAutoIncrementInGenerated: {},
+		TiDBEnableChangeMultiSchema:        {},
+		TiDBEnableChunkRPC:                 {},
+		TiDBEnableClusteredIndex:           {},
+		TiDBEnableCollectExecutionInfo:     {},
+		TiDBEnableEnhancedSecurity:         {},
+		TiDBEnableExchangePartition:        {},
+		TiDBEnableFastAnalyze:              {}, // only for the ANALYZE statements
+		TiDBEnableGeneralPlanCache:         {},
+		TiDBEnableInstancePlanCache:        {},
+		TiDBEnableLocalTxn:                 {},
+		TiDBEnablePointGetCache:            {},
+		TiDBEnableRateLimitAction:          {},
+		TiDBEnableSlowLog:                  {},
+		TiDBEnableStmtSummary:              {},
+		TiDBEnableStreaming:                {},
+		TiDBEnableStrictDoubleTypeCheck:    {},
+		TiDBEnableTelemetry:                {},
+		TiDBEnableTopSQL:                   {},
+		TiDBEnableTSOFollowerProxy:         {},
+		TiDBEnableVectorizedExpression:     {},
+	}
+	for name, sv := range GetSysVars() {
+		if strings.HasPrefix(name, "tidb_opt") {
+			require.True(t, sv.AffectsPlan, name)
+		}
+		if strings.HasPrefix(name, "tidb_enable_") {
+			_, ok := planIndependent[name]
+			require.True(t, sv.AffectsPlan != ok, name)
+		}
+	}
+
+	names := GetPlanAffectingSysVarNames()
+	require.True(t, sort.StringsAreSorted(names))
+	for _, name := range []string{TiDBEnableOrderedResultMode, TiDBEnableExtendedStats, TiDBExecutorConcurrency, TiDBPartitionPruneMode} {
+		require.Contains(t, names, name)
+	}
+
+	RegisterSysVar(&SysVar{Scope: ScopeSession, Name: "tidb_opt_mynewsysvar", Value: "1", AffectsPlan: true})
+	require.Contains(t, GetPlanAffectingSysVarNames(), "tidb_opt_mynewsysvar")
+	UnregisterSysVar("tidb_opt_mynewsysvar")
+	require.Equal(t, names, GetPlanAffectingSysVarNames())
+}
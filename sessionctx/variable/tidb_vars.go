@@ -595,6 +595,10 @@ const (
 	// TiDBEnablePseudoForOutdatedStats indicates whether use pseudo for outdated stats
 	TiDBEnablePseudoForOutdatedStats = "tidb_enable_pseudo_for_outdated_stats"
 
+	// TiDBEnableGeneralPlanCache indicates whether to cache the plans of the text protocol statements
+	// whose literals are parameterized.
+	TiDBEnableGeneralPlanCache = "tidb_enable_general_plan_cache"
+
 	// TiDBTmpTableMaxSize indicates the max memory size of temporary tables.
 	TiDBTmpTableMaxSize = "tidb_tmp_table_max_size"
 )
@@ -614,6 +618,11 @@ const (
 	TiDBGCScanLockMode = "tidb_gc_scan_lock_mode"
 	// TiDBEnableEnhancedSecurity restricts SUPER users from certain operations.
 	TiDBEnableEnhancedSecurity = "tidb_enable_enhanced_security"
+	// TiDBEnableInstancePlanCache indicates whether the prepared plans are cached in the instance-level
+	// plan cache shared by all the sessions instead of the session-level one.
+	TiDBEnableInstancePlanCache = "tidb_enable_instance_plan_cache"
+	// TiDBInstancePlanCacheMaxMemSize indicates the memory quota of the instance-level plan cache.
+	TiDBInstancePlanCacheMaxMemSize = "tidb_instance_plan_cache_max_mem_size"
 )
 
 // TiDB intentional limits
@@ -768,7 +777,11 @@ const (
 	DefTiDBEnableTSOFollowerProxy         = false
 	DefTiDBEnableOrderedResultMode        = false
 	DefTiDBEnablePseudoForOutdatedStats   = true
+	DefTiDBEnableGeneralPlanCache         = false
+	DefTiDBEnableInstancePlanCache        = false
+	DefTiDBInstancePlanCacheMaxMemSize    = 100 << 20 // 100MB.
 	DefEnablePlacementCheck               = true
+	DefForeignKeyChecks                   = false
 	DefTimestamp                          = "0"
 )
 
@@ -805,6 +818,9 @@ var (
 	MaxTSOBatchWaitInterval = atomic.NewFloat64(DefTiDBTSOClientBatchMaxWaitTime)
 	EnableTSOFollowerProxy  = atomic.NewBool(DefTiDBEnableTSOFollowerProxy)
 	RestrictedReadOnly      = atomic.NewBool(DefTiDBRestrictedReadOnly)
+	// EnableInstancePlanCache and InstancePlanCacheMaxMemSize control the instance-level plan cache.
+	EnableInstancePlanCache     = atomic.NewBool(DefTiDBEnableInstancePlanCache)
+	InstancePlanCacheMaxMemSize = atomic.NewInt64(DefTiDBInstancePlanCacheMaxMemSize)
 )
 
 // TopSQL is the variable for control top sql feature.
@@ -32,7 +32,7 @@ import (
 // HandleDDLEvent begins to process a ddl task.
 func (h *Handle) HandleDDLEvent(t *util.Event) error {
 	switch t.Tp {
-	case model.ActionCreateTable, model.ActionTruncateTable:
+	case model.ActionCreateTable, model.ActionTruncateTable, model.ActionRemovePartitioning:
 		ids := h.getInitStateTableIDs(t.TableInfo)
 		for _, id := range ids {
 			if err := h.insertTableStats2KV(t.TableInfo, id); err != nil {
@@ -46,7 +46,7 @@ func (h *Handle) HandleDDLEvent(t *util.Event) error {
 				return err
 			}
 		}
-	case model.ActionAddTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionAddTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition, model.ActionAlterTablePartitioning:
 		for _, def := range t.PartInfo.Definitions {
 			if err := h.insertTableStats2KV(t.TableInfo, def.ID); err != nil {
 				return err
@@ -1900,7 +1900,8 @@ func (w *GCWorker) doGCPlacementRules(dr util.DelRangeTask) (err error) {
 			return
 		}
 		physicalTableIDs = append(physicalTableIDs, historyJob.TableID)
-	case model.ActionDropSchema, model.ActionDropTablePartition, model.ActionTruncateTablePartition:
+	case model.ActionDropSchema, model.ActionDropTablePartition, model.ActionTruncateTablePartition, model.ActionReorganizePartition,
+		model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
 		if err = historyJob.DecodeArgs(&physicalTableIDs); err != nil {
 			return
 		}
@@ -51,6 +51,9 @@ type Column struct {
 	GeneratedExpr ast.ExprNode
 	// If this column has default expr value, this expression will be stored here.
 	DefaultExpr ast.ExprNode
+	// If this column is a stored generated column which is being added, the expression evaluating its value
+	// will be stored here. The writes fill the column with it until the column becomes public.
+	AddingGeneratedExpr expression.Expression
 }
 
 // String implements fmt.Stringer interface.
@@ -97,6 +100,7 @@ func ToColumn(col *model.ColumnInfo) *Column {
 		col,
 		nil,
 		nil,
+		nil,
 	}
 }
 
@@ -197,6 +201,27 @@ func handleWrongCharsetValue(ctx sessionctx.Context, col *model.ColumnInfo, cast
 	return truncateVal, err
 }
 
+// castArrayValue casts the JSON value to the array of the hidden column of a multi-valued index, the hidden
+// columns are named as `_V$_<index name>_<offset>`. The elements can't be truncated or ignored since every
+// element is an entry of the index.
+func castArrayValue(sc *stmtctx.StatementContext, val types.Datum, col *model.ColumnInfo) (types.Datum, error) {
+	casted, err := val.ConvertTo(sc, &col.FieldType)
+	if err == nil {
+		return casted, nil
+	}
+	idxName := strings.TrimPrefix(col.Name.O, "_V$_")
+	if pos := strings.LastIndexByte(idxName, '_'); pos >= 0 {
+		idxName = idxName[:pos]
+	}
+	switch {
+	case types.ErrOverflow.Equal(err):
+		return casted, ErrJSONValueOutOfRangeForFuncIndex.GenWithStackByArgs(idxName)
+	case types.ErrDataTooLong.Equal(err):
+		return casted, ErrFunctionalIndexDataIsTooLong.GenWithStackByArgs(idxName)
+	}
+	return casted, ErrInvalidJSONForFuncIndex.GenWithStackByArgs(idxName)
+}
+
 func handleZeroDatetime(ctx sessionctx.Context, col *model.ColumnInfo, casted types.Datum, str string, tmIsInvalid bool) (types.Datum, bool, error) {
 	sc := ctx.GetSessionVars().StmtCtx
 	tm := casted.GetMysqlTime()
@@ -283,6 +308,9 @@ func handleZeroDatetime(ctx sessionctx.Context, col *model.ColumnInfo, casted ty
 // TODO: change the third arg to TypeField. Not pass ColumnInfo.
 func CastValue(ctx sessionctx.Context, val types.Datum, col *model.ColumnInfo, returnErr, forceIgnoreTruncate bool) (casted types.Datum, err error) {
 	sc := ctx.GetSessionVars().StmtCtx
+	if col.FieldType.Array {
+		return castArrayValue(sc, val, col)
+	}
 	casted, err = val.ConvertTo(sc, &col.FieldType)
 	// TODO: make sure all truncate errors are handled by ConvertTo.
 	if returnErr && err != nil {
@@ -671,7 +699,8 @@ func GetZeroValue(col *model.ColumnInfo) types.Datum {
 		} else {
 			d.SetString("", col.Collate)
 		}
-	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob,
+		mysql.TypeGeometry:
 		d.SetString("", col.Collate)
 	case mysql.TypeDuration:
 		d.SetMysqlDuration(types.ZeroDuration)
@@ -0,0 +1,103 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package table
+
+import (
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// Constraint provides meta data and the expression of a check constraint.
+type Constraint struct {
+	*model.ConstraintInfo
+	ConstraintExpr expression.Expression
+}
+
+// ToConstraint builds the check constraint from the constraint info.
+func ToConstraint(ctx sessionctx.Context, constraintInfo *model.ConstraintInfo, tblInfo *model.TableInfo) (*Constraint, error) {
+	expr, err := expression.ParseSimpleExprWithTableInfo(ctx, constraintInfo.ExprString, tblInfo)
+	if err != nil {
+		return nil, err
+	}
+	return &Constraint{ConstraintInfo: constraintInfo, ConstraintExpr: expr}, nil
+}
+
+// BuildWritableConstraints builds the enforced check constraints which should be checked by the writes.
+// The constraints in write only or write reorganization state are also checked, so the rows written
+// during adding a constraint satisfy it.
+func BuildWritableConstraints(ctx sessionctx.Context, tblInfo *model.TableInfo) ([]*Constraint, error) {
+	var constraints []*Constraint
+	for _, constraintInfo := range tblInfo.Constraints {
+		if !constraintInfo.Enforced || constraintInfo.State == model.StateNone || constraintInfo.State == model.StateDeleteOnly {
+			continue
+		}
+		constraint, err := ToConstraint(ctx, constraintInfo, tblInfo)
+		if err != nil {
+			return nil, err
+		}
+		constraints = append(constraints, constraint)
+	}
+	return constraints, nil
+}
+
+// CheckRowConstraint checks whether the row satisfies the check constraints. A constraint is
+// satisfied if its expression is evaluated to TRUE or NULL.
+func CheckRowConstraint(ctx sessionctx.Context, constraints []*Constraint, row []types.Datum) error {
+	if len(constraints) == 0 {
+		return nil
+	}
+	r := chunk.MutRowFromDatums(row).ToRow()
+	for _, constraint := range constraints {
+		val, isNull, err := constraint.ConstraintExpr.EvalInt(ctx, r)
+		if err != nil {
+			return err
+		}
+		if !isNull && val == 0 {
+			return ErrCheckConstraintViolated.GenWithStackByArgs(constraint.Name.O)
+		}
+	}
+	return nil
+}
+
+// ViewCheck is the WHERE condition of a view defined WITH CHECK OPTION. The rows written
+// through the view must satisfy it, the columns in Cond are resolved to the offsets of the
+// table row.
+type ViewCheck struct {
+	DBName   model.CIStr
+	ViewName model.CIStr
+	Cond     expression.Expression
+}
+
+// CheckRowViewCondition checks whether the row satisfies the conditions of the views. Different
+// from the check constraints, a condition evaluated to NULL is not satisfied.
+func CheckRowViewCondition(ctx sessionctx.Context, checks []*ViewCheck, row []types.Datum) error {
+	if len(checks) == 0 {
+		return nil
+	}
+	r := chunk.MutRowFromDatums(row).ToRow()
+	for _, check := range checks {
+		val, isNull, err := check.Cond.EvalInt(ctx, r)
+		if err != nil {
+			return err
+		}
+		if isNull || val == 0 {
+			return ErrViewCheckFailed.GenWithStackByArgs(check.DBName.O, check.ViewName.O)
+		}
+	}
+	return nil
+}
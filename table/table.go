@@ -100,6 +100,16 @@ var (
 	ErrRowDoesNotMatchGivenPartitionSet = dbterror.ClassTable.NewStd(mysql.ErrRowDoesNotMatchGivenPartitionSet)
 	// ErrTempTableFull returns a table is full error, it's used by temporary table now.
 	ErrTempTableFull = dbterror.ClassTable.NewStd(mysql.ErrRecordFileFull)
+	// ErrCheckConstraintViolated returns when the row violates a check constraint.
+	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrViewCheckFailed returns when the row written through a view violates its WITH CHECK OPTION.
+	ErrViewCheckFailed = dbterror.ClassTable.NewStd(mysql.ErrViewCheckFailed)
+	// ErrInvalidJSONForFuncIndex returns when the JSON value can't be cast to the array of a multi-valued index.
+	ErrInvalidJSONForFuncIndex = dbterror.ClassTable.NewStd(mysql.ErrInvalidJSONValueForFuncIndex)
+	// ErrJSONValueOutOfRangeForFuncIndex returns when the JSON value is out of range of the array of a multi-valued index.
+	ErrJSONValueOutOfRangeForFuncIndex = dbterror.ClassTable.NewStd(mysql.ErrJSONValueOutOfRangeForFuncIndex)
+	// ErrFunctionalIndexDataIsTooLong returns when the JSON value is too long for the array of a multi-valued index.
+	ErrFunctionalIndexDataIsTooLong = dbterror.ClassTable.NewStd(mysql.ErrFunctionalIndexDataIsTooLong)
 )
 
 // RecordIterFunc is used for low-level record iteration.
@@ -30,6 +30,9 @@ import (
 	"github.com/pingcap/tidb/table"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
+	"github.com/pingcap/tidb/util/fulltext"
 	"github.com/pingcap/tidb/util/rowcodec"
 )
 
@@ -92,6 +95,8 @@ type index struct {
 	// the collation global variable is initialized *after* `NewIndex()`.
 	initNeedRestoreData sync.Once
 	needRestoredData    bool
+	// tokenizer splits the indexed values into the tokens of the FULLTEXT index.
+	tokenizer fulltext.Tokenizer
 }
 
 // NeedRestoredData checks whether the index columns needs restored data.
@@ -122,6 +127,9 @@ func NewIndex(physicalID int64, tblInfo *model.TableInfo, indexInfo *model.Index
 		prefix:   prefix,
 		phyTblID: physicalID,
 	}
+	if indexInfo.IsFulltext() {
+		index.tokenizer = fulltext.NewTokenizer(indexInfo.ParserName)
+	}
 	return index
 }
 
@@ -137,9 +145,33 @@ func (c *index) GenIndexKey(sc *stmtctx.StatementContext, indexedValues []types.
 	if c.idxInfo.Global {
 		idxTblID = c.tblInfo.ID
 	}
+	if c.idxInfo.IsSpatial() {
+		indexedValues, err = spatialIndexedValues(indexedValues)
+		if err != nil {
+			return nil, false, err
+		}
+	}
 	return tablecodec.GenIndexKey(sc, c.tblInfo, c.idxInfo, idxTblID, indexedValues, h, buf)
 }
 
+// spatialIndexedValues returns the indexed value of the SPATIAL index, which is the id of the smallest cell
+// containing the MBR of the geometry, so the geometries intersecting a rectangle are found by scanning the
+// ranges of the cells covering the rectangle.
+func spatialIndexedValues(indexedValues []types.Datum) ([]types.Datum, error) {
+	_, g, err := spatial.Decode(indexedValues[0].GetBytes())
+	if err != nil {
+		return nil, types.ErrCantCreateGeometryObject
+	}
+	// The empty geometry is indexed by the root cell since the MBR doesn't exist.
+	var cell spatial.CellID
+	if m, ok := g.MBR(); ok {
+		cell = spatial.CellIDForMBR(m)
+	} else {
+		cell = spatial.RootCellID
+	}
+	return []types.Datum{types.NewUintDatum(uint64(cell))}, nil
+}
+
 // Create creates a new entry in the kvIndex data.
 // If the index is unique and there is an existing entry with the same key,
 // Create will return the existing entry's handle as the first return value, ErrKeyExists as the second return value.
@@ -151,6 +183,20 @@ func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValue
 	for _, fn := range opts {
 		fn(&opt)
 	}
+	if c.idxInfo.IsFulltext() {
+		// The entries of the untouched FULLTEXT index are kept, nothing needs to be written.
+		if opt.Untouched {
+			return nil, nil
+		}
+		return nil, c.createFulltext(txn, indexedValues, h)
+	}
+	if c.idxInfo.MVIndex {
+		// The entries of the untouched multi-valued index are kept, nothing needs to be written.
+		if opt.Untouched {
+			return nil, nil
+		}
+		return nil, c.createMVIndex(sctx.GetSessionVars().StmtCtx, txn, indexedValues, h, handleRestoreData)
+	}
 	vars := sctx.GetSessionVars()
 	writeBufs := vars.GetWriteStmtBufs()
 	skipCheck := vars.StmtCtx.BatchCheck
@@ -229,6 +275,12 @@ func (c *index) Create(sctx sessionctx.Context, txn kv.Transaction, indexedValue
 
 // Delete removes the entry for handle h and indexedValues from KV index.
 func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	if c.idxInfo.IsFulltext() {
+		return c.deleteFulltext(txn, indexedValues, h)
+	}
+	if c.idxInfo.MVIndex {
+		return c.deleteMVIndex(sc, txn, indexedValues, h)
+	}
 	key, distinct, err := c.GenIndexKey(sc, indexedValues, h, nil)
 	if err != nil {
 		return err
@@ -241,6 +293,144 @@ func (c *index) Delete(sc *stmtctx.StatementContext, txn kv.Transaction, indexed
 	return err
 }
 
+// fulltextTokens returns the distinct tokens of the indexed values of the FULLTEXT index.
+func (c *index) fulltextTokens(indexedValues []types.Datum) ([]string, error) {
+	var tokens []string
+	seen := make(map[string]struct{})
+	for _, v := range indexedValues {
+		if v.IsNull() {
+			continue
+		}
+		text, err := v.ToString()
+		if err != nil {
+			return nil, err
+		}
+		for _, token := range c.tokenizer.Tokenize(text) {
+			if _, ok := seen[token]; ok {
+				continue
+			}
+			seen[token] = struct{}{}
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+// createFulltext creates an inverted index entry for every token of the indexed values.
+func (c *index) createFulltext(txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	tokens, err := c.fulltextTokens(indexedValues)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		key, err := tablecodec.GenFullTextIndexKey(c.phyTblID, c.idxInfo.ID, token, h, nil)
+		if err != nil {
+			return err
+		}
+		if err = txn.GetMemBuffer().Set(key, []byte{'0'}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteFulltext removes the inverted index entries of the indexed values.
+func (c *index) deleteFulltext(txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	tokens, err := c.fulltextTokens(indexedValues)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		key, err := tablecodec.GenFullTextIndexKey(c.phyTblID, c.idxInfo.ID, token, h, nil)
+		if err != nil {
+			return err
+		}
+		if err = txn.GetMemBuffer().Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mvIndexedValues returns the indexed values of the entries of the multi-valued index, there is an entry for every
+// distinct element of the JSON array of the array column, and an entry of NULL if the array is NULL.
+func (c *index) mvIndexedValues(indexedValues []types.Datum) [][]types.Datum {
+	offset := -1
+	var elemTp *types.FieldType
+	for i, ic := range c.idxInfo.Columns {
+		if col := c.tblInfo.Columns[ic.Offset]; col.FieldType.Array {
+			offset, elemTp = i, &col.FieldType
+			break
+		}
+	}
+	if indexedValues[offset].IsNull() {
+		return [][]types.Datum{indexedValues}
+	}
+	arr := indexedValues[offset].GetMysqlJSON()
+	entries := make([][]types.Datum, 0, arr.GetElemCount())
+	seen := make(map[string]struct{}, arr.GetElemCount())
+	for i := 0; i < arr.GetElemCount(); i++ {
+		elem := arr.ArrayGetElem(i)
+		if _, ok := seen[string(elem.Value)]; ok {
+			continue
+		}
+		seen[string(elem.Value)] = struct{}{}
+		vals := make([]types.Datum, len(indexedValues))
+		copy(vals, indexedValues)
+		vals[offset] = MVIndexElemDatum(elem, elemTp)
+		entries = append(entries, vals)
+	}
+	return entries
+}
+
+// MVIndexElemDatum returns the indexed value of the element of the array casted to the array type.
+func MVIndexElemDatum(elem json.BinaryJSON, arrayTp *types.FieldType) types.Datum {
+	switch elem.TypeCode {
+	case json.TypeCodeInt64:
+		return types.NewIntDatum(elem.GetInt64())
+	case json.TypeCodeUint64:
+		return types.NewUintDatum(elem.GetUint64())
+	case json.TypeCodeFloat64:
+		return types.NewFloat64Datum(elem.GetFloat64())
+	}
+	return types.NewCollationStringDatum(string(elem.GetString()), arrayTp.Collate)
+}
+
+// createMVIndex creates an index entry for every element of the array of the multi-valued index.
+func (c *index) createMVIndex(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle, handleRestoreData []types.Datum) error {
+	c.initNeedRestoreData.Do(func() {
+		c.needRestoredData = NeedRestoredData(c.idxInfo.Columns, c.tblInfo.Columns)
+	})
+	for _, vals := range c.mvIndexedValues(indexedValues) {
+		key, _, err := c.GenIndexKey(sc, vals, h, nil)
+		if err != nil {
+			return err
+		}
+		idxVal, err := tablecodec.GenIndexValuePortal(sc, c.tblInfo, c.idxInfo, c.needRestoredData, false, false, vals, h, c.phyTblID, handleRestoreData)
+		if err != nil {
+			return err
+		}
+		if err = txn.GetMemBuffer().Set(key, idxVal); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteMVIndex removes the index entries of the elements of the array of the multi-valued index.
+func (c *index) deleteMVIndex(sc *stmtctx.StatementContext, txn kv.Transaction, indexedValues []types.Datum, h kv.Handle) error {
+	for _, vals := range c.mvIndexedValues(indexedValues) {
+		key, _, err := c.GenIndexKey(sc, vals, h, nil)
+		if err != nil {
+			return err
+		}
+		if err = txn.GetMemBuffer().Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Drop removes the KV index from store.
 func (c *index) Drop(txn kv.Transaction) error {
 	it, err := txn.Iter(c.prefix, c.prefix.PrefixNext())
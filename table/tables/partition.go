@@ -17,8 +17,10 @@ package tables
 import (
 	"bytes"
 	"context"
+	"encoding/binary"
 	stderr "errors"
 	"fmt"
+	"math"
 	"sort"
 	"strconv"
 	"strings"
@@ -30,6 +32,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/charset"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
@@ -40,6 +43,7 @@ import (
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/hack"
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/mock"
@@ -81,6 +85,14 @@ type partitionedTable struct {
 	partitions      map[int64]*partition
 	evalBufferTypes []*types.FieldType
 	evalBufferPool  sync.Pool
+
+	// reorgTable is not nil when a REORGANIZE PARTITION job is running on the table.
+	// It locates the rows in the partition definitions on the other side of the job,
+	// see initReorganizingPartitions.
+	reorgTable *partitionedTable
+	// reorgPartitions are the partitions which must be kept in sync with the DML
+	// while the partitions are being reorganized.
+	reorgPartitions map[int64]struct{}
 }
 
 func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Table, error) {
@@ -110,9 +122,141 @@ func newPartitionedTable(tbl *TableCommon, tblInfo *model.TableInfo) (table.Tabl
 		partitions[p.ID] = &t
 	}
 	ret.partitions = partitions
+	if err := initReorganizingPartitions(ret, tblInfo); err != nil {
+		return nil, errors.Trace(err)
+	}
 	return ret, nil
 }
 
+// initReorganizingPartitions prepares the double writes of a running REORGANIZE PARTITION job.
+// Before the partition definitions are switched (delete only, write only and write reorganization),
+// the rows are read from the old partitions, and are also maintained in the adding partitions.
+// After the switch (delete reorganization), the rows are read from the new partitions, and are still
+// maintained in the dropping partitions, because the TiDB servers on the previous schema version may
+// read them.
+func initReorganizingPartitions(t *partitionedTable, tblInfo *model.TableInfo) error {
+	pi := tblInfo.GetPartitionInfo()
+	switch pi.DDLAction {
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+	default:
+		return nil
+	}
+	var reorgDefs, otherSideDefs []model.PartitionDefinition
+	switch pi.DDLState {
+	case model.StateDeleteOnly, model.StateWriteOnly, model.StateWriteReorganization:
+		reorgDefs = pi.AddingDefinitions
+		otherSideDefs = ReplacePartitionDefinitions(pi.Definitions, pi.DroppingDefinitions, pi.AddingDefinitions)
+	case model.StateDeleteReorganization:
+		reorgDefs = pi.DroppingDefinitions
+		otherSideDefs = ReplacePartitionDefinitions(pi.Definitions, pi.AddingDefinitions, pi.DroppingDefinitions)
+	default:
+		return nil
+	}
+
+	t.reorgPartitions = make(map[int64]struct{}, len(reorgDefs))
+	for _, def := range reorgDefs {
+		var p partition
+		err := initTableCommonWithIndices(&p.TableCommon, tblInfo, def.ID, t.Columns, t.allocs)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		t.partitions[def.ID] = &p
+		t.reorgPartitions[def.ID] = struct{}{}
+	}
+
+	otherSideTblInfo := tblInfo.Clone()
+	otherSidePi := *pi
+	otherSidePi.Definitions = otherSideDefs
+	SwapPartitionScheme(&otherSidePi)
+	otherSideTblInfo.Partition = &otherSidePi
+	partitionExpr, err := newPartitionExpr(otherSideTblInfo)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	reorgTable := &partitionedTable{
+		TableCommon:     t.TableCommon,
+		partitionExpr:   partitionExpr,
+		partitions:      t.partitions,
+		evalBufferTypes: t.evalBufferTypes,
+	}
+	reorgTable.meta = otherSideTblInfo
+	reorgTable.evalBufferPool = sync.Pool{
+		New: func() interface{} {
+			return initEvalBuffer(reorgTable)
+		},
+	}
+	t.reorgTable = reorgTable
+	return nil
+}
+
+// ReplacePartitionDefinitions replaces the consecutive partitions `from` in `defs` with `to`.
+func ReplacePartitionDefinitions(defs, from, to []model.PartitionDefinition) []model.PartitionDefinition {
+	if len(from) == 0 {
+		return defs
+	}
+	newDefs := make([]model.PartitionDefinition, 0, len(defs)-len(from)+len(to))
+	for i := range defs {
+		if defs[i].ID != from[0].ID {
+			continue
+		}
+		newDefs = append(newDefs, defs[:i]...)
+		newDefs = append(newDefs, to...)
+		if i+len(from) < len(defs) {
+			newDefs = append(newDefs, defs[i+len(from):]...)
+		}
+		return newDefs
+	}
+	return defs
+}
+
+// SwapPartitionScheme swaps the partitioning scheme with the one of the other side of a running
+// ALTER TABLE ... PARTITION BY or REMOVE PARTITIONING job, it does nothing for other jobs.
+// The Definitions of pi must be the ones of the scheme after the swap.
+func SwapPartitionScheme(pi *model.PartitionInfo) {
+	if pi.DDLAction != model.ActionAlterTablePartitioning && pi.DDLAction != model.ActionRemovePartitioning {
+		return
+	}
+	pi.Type, pi.DDLType = pi.DDLType, pi.Type
+	pi.Expr, pi.DDLExpr = pi.DDLExpr, pi.Expr
+	pi.Columns, pi.DDLColumns = pi.DDLColumns, pi.Columns
+	pi.Num = uint64(len(pi.Definitions))
+}
+
+// locateReorgPartition returns the partition which the row should also be written to, or deleted from,
+// when the partitions of the table are being reorganized.
+func (t *partitionedTable) locateReorgPartition(ctx sessionctx.Context, r []types.Datum) (int64, bool, error) {
+	if t.reorgTable == nil {
+		return 0, false, nil
+	}
+	pid, err := t.reorgTable.locatePartition(ctx, t.reorgTable.meta.Partition, r)
+	if err != nil {
+		// After the partition definitions are switched, the rows which are out of the range
+		// of the dropping partitions don't need to be maintained in them.
+		if t.meta.Partition.DDLState == model.StateDeleteReorganization && table.ErrNoPartitionForGivenValue.Equal(err) {
+			return 0, false, nil
+		}
+		return 0, false, errors.Trace(err)
+	}
+	_, ok := t.reorgPartitions[pid]
+	return pid, ok, nil
+}
+
+// canWriteReorgPartitions returns whether the rows can be added into the reorganizing partitions.
+func (t *partitionedTable) canWriteReorgPartitions() bool {
+	return t.reorgTable != nil && t.meta.Partition.DDLState != model.StateDeleteOnly
+}
+
+// withRecordHandle appends the handle to the row if it is a _tidb_rowid, so the row is written with the
+// same handle to the reorganizing partition.
+func (t *partitionedTable) withRecordHandle(r []types.Datum, h kv.Handle) []types.Datum {
+	if t.meta.PKIsHandle || t.meta.IsCommonHandle || len(r) > len(t.Cols()) {
+		return r
+	}
+	row := make([]types.Datum, 0, len(r)+1)
+	row = append(row, r...)
+	return append(row, types.NewIntDatum(h.IntValue()))
+}
+
 func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 	ctx := mock.NewContext()
 	dbName := model.NewCIStr(ctx.GetSessionVars().CurrentDB)
@@ -121,11 +265,35 @@ func newPartitionExpr(tblInfo *model.TableInfo) (*PartitionExpr, error) {
 		return nil, err
 	}
 	pi := tblInfo.GetPartitionInfo()
+	if pi.Sub == nil {
+		return generatePartitionExpr(ctx, tblInfo, columns, names)
+	}
+	// The partition expression of a subpartitioned table locates the logical partition,
+	// and its Sub locates the subpartition in the logical partition.
+	parentTblInfo, subTblInfo := *tblInfo, *tblInfo
+	parentTblInfo.Partition, subTblInfo.Partition = pi.ParentView(), pi.SubView()
+	ret, err := generatePartitionExpr(ctx, &parentTblInfo, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	ret.Sub, err = generatePartitionExpr(ctx, &subTblInfo, columns, names)
+	if err != nil {
+		return nil, err
+	}
+	ret.ColumnOffset = append(ret.ColumnOffset, ret.Sub.ColumnOffset...)
+	return ret, nil
+}
+
+func generatePartitionExpr(ctx sessionctx.Context, tblInfo *model.TableInfo,
+	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	pi := tblInfo.GetPartitionInfo()
 	switch pi.Type {
 	case model.PartitionTypeRange:
 		return generateRangePartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeHash:
 		return generateHashPartitionExpr(ctx, pi, columns, names)
+	case model.PartitionTypeKey:
+		return generateKeyPartitionExpr(ctx, pi, columns, names)
 	case model.PartitionTypeList:
 		return generateListPartitionExpr(ctx, tblInfo, columns, names)
 	}
@@ -149,6 +317,10 @@ type PartitionExpr struct {
 	// InValues: x in (1,2); x in (3,4); x in (5,6), used for list partition.
 	InValues []expression.Expression
 	*ForListPruning
+	// Used in the key partition locating and pruning process.
+	*ForKeyPruning
+	// Sub is the subpartition expression, it is nil if the table is not subpartitioned.
+	Sub *PartitionExpr
 }
 
 func initEvalBufferType(t *partitionedTable) {
@@ -467,8 +639,35 @@ func rangePartitionString(pi *model.PartitionInfo) string {
 		return "`" + pi.Columns[0].L + "`"
 	}
 
-	// partition by range columns (c1, c2, ...)
-	panic("create table assert len(columns) = 1")
+	// partition by range columns (c1, c2, ...) is located by the expressions built
+	// by rangeColumnsLessThanString.
+	return ""
+}
+
+// rangeColumnsLessThanString returns the expression of (c1, c2, ...) < (v1, v2, ...) for a range columns
+// partition. It is true when the column values are less than the bound values in the lexicographical order,
+// and a NULL value is less than any other values, so a row is never located by a NULL result.
+// For example, (c1, c2) < (v1, v2) is:
+//   (c1 IS NULL OR c1 < v1) OR (c1 = v1 AND (c2 IS NULL OR c2 < v2))
+// and (c1, c2) < (v1, MAXVALUE) is:
+//   (c1 IS NULL OR c1 < v1) OR (c1 = v1)
+func rangeColumnsLessThanString(cols []model.CIStr, lessThan []string) string {
+	var buf bytes.Buffer
+	for i := range cols {
+		if i > 0 {
+			buf.WriteString(" OR ")
+		}
+		buf.WriteString("(")
+		for j := 0; j < i; j++ {
+			fmt.Fprintf(&buf, "`%s` = (%s) AND ", cols[j].L, lessThan[j])
+		}
+		if strings.EqualFold(lessThan[i], "MAXVALUE") {
+			buf.WriteString("true)")
+			break
+		}
+		fmt.Fprintf(&buf, "(`%s` IS NULL OR `%s` < (%s)))", cols[i].L, cols[i].L, lessThan[i])
+	}
+	return buf.String()
 }
 
 func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
@@ -483,6 +682,8 @@ func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 		if strings.EqualFold(pi.Definitions[i].LessThan[0], "MAXVALUE") {
 			// Expr less than maxvalue is always true.
 			fmt.Fprintf(&buf, "true")
+		} else if len(pi.Columns) > 1 {
+			buf.WriteString(rangeColumnsLessThanString(pi.Columns, pi.Definitions[i].LessThan))
 		} else {
 			fmt.Fprintf(&buf, "((%s) < (%s))", partStr, pi.Definitions[i].LessThan[0])
 		}
@@ -501,6 +702,24 @@ func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}
 
 	// build column offset.
+	if len(pi.Columns) > 1 {
+		partitionCols := make([]*expression.Column, 0, len(pi.Columns))
+		for _, col := range pi.Columns {
+			idx := expression.FindFieldNameIdxByColName(names, col.L)
+			if idx < 0 {
+				return nil, errors.Trace(table.ErrUnknownColumn.GenWithStackByArgs(col.O, "partition function"))
+			}
+			partitionCols = append(partitionCols, columns[idx])
+		}
+		ret.ColumnOffset = getColumnsOffset(partitionCols, columns)
+		// The partitions are pruned by the first column only.
+		tmp, err := dataForRangeColumnsPruning(ctx, pi, schema, names, p)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		ret.ForRangeColumnsPruning = tmp
+		return ret, nil
+	}
 	partExp := pi.Expr
 	if len(pi.Columns) == 1 {
 		partExp = "`" + pi.Columns[0].L + "`"
@@ -520,22 +739,19 @@ func generateRangePartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}
 	ret.ColumnOffset = offset
 
-	switch len(pi.Columns) {
-	case 0:
+	if len(pi.Columns) == 0 {
 		tmp, err := dataForRangePruning(ctx, pi)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		ret.Expr = exprs
 		ret.ForRangePruning = tmp
-	case 1:
+	} else {
 		tmp, err := dataForRangeColumnsPruning(ctx, pi, schema, names, p)
 		if err != nil {
 			return nil, errors.Trace(err)
 		}
 		ret.ForRangeColumnsPruning = tmp
-	default:
-		panic("range column partition currently support only one column")
 	}
 	return ret, nil
 }
@@ -898,6 +1114,160 @@ func generateHashPartitionExpr(ctx sessionctx.Context, pi *model.PartitionInfo,
 	}, nil
 }
 
+func generateKeyPartitionExpr(_ sessionctx.Context, pi *model.PartitionInfo,
+	columns []*expression.Column, names types.NameSlice) (*PartitionExpr, error) {
+	// The caller should assure partition info is not nil.
+	partCols := make([]*expression.Column, 0, len(pi.Columns))
+	for _, col := range pi.Columns {
+		idx := expression.FindFieldNameIdxByColName(names, col.L)
+		if idx < 0 {
+			return nil, errors.Trace(table.ErrUnknownColumn.GenWithStackByArgs(col.O, "partition function"))
+		}
+		partCols = append(partCols, columns[idx])
+	}
+	return &PartitionExpr{
+		ColumnOffset:  getColumnsOffset(partCols, columns),
+		ForKeyPruning: &ForKeyPruning{KeyPartCols: partCols},
+	}, nil
+}
+
+// ForKeyPruning is used for key partition pruning.
+type ForKeyPruning struct {
+	// KeyPartCols are the partitioning columns, in the order they are hashed.
+	KeyPartCols []*expression.Column
+}
+
+// LocateKeyPartition locates the key partition by the values of the partitioning columns.
+func (kp *ForKeyPruning) LocateKeyPartition(sc *stmtctx.StatementContext, numParts uint64, vals []types.Datum) (int, error) {
+	hash, err := keyPartitionHash(sc, kp.KeyPartCols, vals)
+	if err != nil {
+		return 0, err
+	}
+	return int(uint64(hash) % numParts), nil
+}
+
+// IsKeyPartitionColumnType checks whether the column type can be used by the key partition,
+// the values of the type are hashed in the same way as MySQL, so the rows are placed in the
+// same partitions as MySQL does.
+func IsKeyPartitionColumnType(tp *types.FieldType) bool {
+	switch tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear,
+		mysql.TypeFloat, mysql.TypeDouble, mysql.TypeNewDecimal,
+		mysql.TypeDate, mysql.TypeDatetime,
+		mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString:
+		return true
+	}
+	return false
+}
+
+// keyPartitionHash derives from Field::hash and my_hash_sort_* of MySQL, the values are converted to
+// the storage format of MySQL before they are hashed.
+func keyPartitionHash(sc *stmtctx.StatementContext, cols []*expression.Column, vals []types.Datum) (uint32, error) {
+	nr1, nr2 := uint64(1), uint64(4)
+	for i, col := range cols {
+		if vals[i].IsNull() {
+			nr1 ^= (nr1 << 1) | 1
+			continue
+		}
+		b, err := keyPartitionColumnBytes(sc, col.RetType, vals[i])
+		if err != nil {
+			return 0, err
+		}
+		for _, c := range b {
+			nr1 ^= (((nr1 & 63) + nr2) * uint64(c)) + (nr1 << 8)
+			nr2 += 3
+		}
+	}
+	return uint32(nr1), nil
+}
+
+// keyPartitionColumnBytes returns the bytes of a value which are hashed by MySQL.
+func keyPartitionColumnBytes(sc *stmtctx.StatementContext, tp *types.FieldType, val types.Datum) ([]byte, error) {
+	val, err := val.ConvertTo(sc, tp)
+	if err != nil {
+		return nil, err
+	}
+	switch tp.Tp {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
+		// The integers are stored in little-endian with the pack length of the type.
+		packLen := map[byte]int{mysql.TypeTiny: 1, mysql.TypeShort: 2, mysql.TypeInt24: 3, mysql.TypeLong: 4, mysql.TypeLonglong: 8}[tp.Tp]
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], uint64(val.GetInt64()))
+		return buf[:packLen], nil
+	case mysql.TypeYear:
+		// The year is stored in one byte as the offset from 1900.
+		year := val.GetInt64()
+		if year != 0 {
+			year -= 1900
+		}
+		return []byte{byte(year)}, nil
+	case mysql.TypeFloat:
+		var buf [4]byte
+		binary.LittleEndian.PutUint32(buf[:], math.Float32bits(float32(val.GetFloat64())))
+		return buf[:], nil
+	case mysql.TypeDouble:
+		var buf [8]byte
+		binary.LittleEndian.PutUint64(buf[:], math.Float64bits(val.GetFloat64()))
+		return buf[:], nil
+	case mysql.TypeNewDecimal:
+		return val.GetMysqlDecimal().ToBin(tp.Flen, tp.Decimal)
+	case mysql.TypeDate:
+		// The date is stored in 3 bytes as year*16*32 + month*32 + day in little-endian.
+		t := val.GetMysqlTime()
+		v := uint32(t.Year()*16*32 + t.Month()*32 + t.Day())
+		return []byte{byte(v), byte(v >> 8), byte(v >> 16)}, nil
+	case mysql.TypeDatetime:
+		return datetimeToMySQLBinary(val.GetMysqlTime(), tp.Decimal), nil
+	default:
+		return keyPartitionStringBytes(tp.Collate, val.GetString()), nil
+	}
+}
+
+// datetimeToMySQLBinary derives from my_datetime_packed_to_binary of MySQL. The integer part
+// ((year*13+month)<<5|day)<<17 | hour<<12 | minute<<6 | second is stored in 5 bytes in big-endian,
+// followed by the fractional part which is stored in 0 to 3 bytes according to the fsp.
+func datetimeToMySQLBinary(t types.Time, fsp int) []byte {
+	const datetimeIntOffset = 0x8000000000
+	ymd := uint64((t.Year()*13+t.Month())<<5 | t.Day())
+	hms := uint64(t.Hour()<<12 | t.Minute()<<6 | t.Second())
+	intPart := (ymd<<17 | hms) + datetimeIntOffset
+	b := []byte{byte(intPart >> 32), byte(intPart >> 24), byte(intPart >> 16), byte(intPart >> 8), byte(intPart)}
+	frac := t.Microsecond()
+	switch fsp {
+	case 1, 2:
+		b = append(b, byte(frac/10000))
+	case 3, 4:
+		frac /= 100
+		b = append(b, byte(frac>>8), byte(frac))
+	case 5, 6:
+		b = append(b, byte(frac>>16), byte(frac>>8), byte(frac))
+	}
+	return b
+}
+
+// keyPartitionStringBytes returns the bytes of a string which are hashed by the collation of MySQL.
+// The binary strings are hashed as they are, the trailing spaces are removed for the _bin collations,
+// and the weights of the characters are hashed for the other collations. Note that the _general_ci
+// collations hash the low byte of a weight first. If the new collation framework is disabled, all
+// the collations are treated as binary, the same as the strings are compared.
+func keyPartitionStringBytes(collation, s string) []byte {
+	switch {
+	case collation == charset.CollationBin:
+		return hack.Slice(s)
+	case collate.IsBinCollation(collation) || !collate.NewCollationEnabled():
+		return hack.Slice(strings.TrimRight(s, " "))
+	}
+	key := collate.GetCollator(collation).Key(s)
+	if strings.HasSuffix(collation, "_general_ci") {
+		weights := make([]byte, len(key))
+		for i := 0; i+1 < len(key); i += 2 {
+			weights[i], weights[i+1] = key[i+1], key[i]
+		}
+		key = weights
+	}
+	return key
+}
+
 // PartitionExpr returns the partition expression.
 func (t *partitionedTable) PartitionExpr() (*PartitionExpr, error) {
 	return t.partitionExpr, nil
@@ -913,7 +1283,7 @@ func PartitionRecordKey(pid int64, handle int64) kv.Key {
 func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int64, error) {
 	var err error
 	var idx int
-	switch t.meta.Partition.Type {
+	switch pi.Type {
 	case model.PartitionTypeRange:
 		if len(pi.Columns) == 0 {
 			idx, err = t.locateRangePartition(ctx, pi, r)
@@ -921,16 +1291,40 @@ func (t *partitionedTable) locatePartition(ctx sessionctx.Context, pi *model.Par
 			idx, err = t.locateRangeColumnPartition(ctx, pi, r)
 		}
 	case model.PartitionTypeHash:
-		idx, err = t.locateHashPartition(ctx, pi, r)
+		idx, err = t.locateHashPartition(ctx, t.partitionExpr, pi.Num, r)
+	case model.PartitionTypeKey:
+		idx, err = locateKeyPartition(ctx, t.partitionExpr, pi.Num, r)
 	case model.PartitionTypeList:
 		idx, err = t.locateListPartition(ctx, pi, r)
 	}
 	if err != nil {
 		return 0, errors.Trace(err)
 	}
+	if pi.Sub != nil {
+		// The subpartitions of the idx-th logical partition are stored continuously.
+		var sub int
+		if pi.Sub.Type == model.PartitionTypeKey {
+			sub, err = locateKeyPartition(ctx, t.partitionExpr.Sub, pi.Sub.Num, r)
+		} else {
+			sub, err = t.locateHashPartition(ctx, t.partitionExpr.Sub, pi.Sub.Num, r)
+		}
+		if err != nil {
+			return 0, errors.Trace(err)
+		}
+		idx = idx*int(pi.Sub.Num) + sub
+	}
 	return pi.Definitions[idx].ID, nil
 }
 
+func locateKeyPartition(ctx sessionctx.Context, pe *PartitionExpr, num uint64, r []types.Datum) (int, error) {
+	kp := pe.ForKeyPruning
+	vals := make([]types.Datum, 0, len(kp.KeyPartCols))
+	for _, col := range kp.KeyPartCols {
+		vals = append(vals, r[col.Index])
+	}
+	return kp.LocateKeyPartition(ctx.GetSessionVars().StmtCtx, num, vals)
+}
+
 func (t *partitionedTable) locateRangeColumnPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
 	var err error
 	var isNull bool
@@ -1039,8 +1433,8 @@ func (t *partitionedTable) locateRangePartition(ctx sessionctx.Context, pi *mode
 }
 
 // TODO: supports linear hashing
-func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model.PartitionInfo, r []types.Datum) (int, error) {
-	if col, ok := t.partitionExpr.Expr.(*expression.Column); ok {
+func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pe *PartitionExpr, num uint64, r []types.Datum) (int, error) {
+	if col, ok := pe.Expr.(*expression.Column); ok {
 		var data types.Datum
 		switch r[col.Index].Kind() {
 		case types.KindInt64, types.KindUint64:
@@ -1053,7 +1447,7 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 			}
 		}
 		ret := data.GetInt64()
-		ret = ret % int64(t.meta.Partition.Num)
+		ret = ret % int64(num)
 		if ret < 0 {
 			ret = -ret
 		}
@@ -1062,14 +1456,14 @@ func (t *partitionedTable) locateHashPartition(ctx sessionctx.Context, pi *model
 	evalBuffer := t.evalBufferPool.Get().(*chunk.MutRow)
 	defer t.evalBufferPool.Put(evalBuffer)
 	evalBuffer.SetDatums(r...)
-	ret, isNull, err := t.partitionExpr.Expr.EvalInt(ctx, evalBuffer.ToRow())
+	ret, isNull, err := pe.Expr.EvalInt(ctx, evalBuffer.ToRow())
 	if err != nil {
 		return 0, err
 	}
 	if isNull {
 		return 0, nil
 	}
-	ret = ret % int64(t.meta.Partition.Num)
+	ret = ret % int64(num)
 	if ret < 0 {
 		ret = -ret
 	}
@@ -1126,7 +1520,23 @@ func partitionedTableAddRecord(ctx sessionctx.Context, t *partitionedTable, r []
 		}
 	}
 	tbl := t.GetPartition(pid)
-	return tbl.AddRecord(ctx, r, opts...)
+	recordID, err = tbl.AddRecord(ctx, r, opts...)
+	if err != nil || !t.canWriteReorgPartitions() {
+		return recordID, err
+	}
+	reorgPid, ok, err := t.locateReorgPartition(ctx, r)
+	if err != nil || !ok {
+		return recordID, errors.Trace(err)
+	}
+	reorgOpts := make([]table.AddRecordOption, 0, len(opts))
+	for _, opt := range opts {
+		// The handle is already allocated, don't allocate it again.
+		if opt != table.IsUpdate {
+			reorgOpts = append(reorgOpts, opt)
+		}
+	}
+	_, err = t.GetPartition(reorgPid).AddRecord(ctx, t.withRecordHandle(r, recordID), reorgOpts...)
+	return recordID, errors.Trace(err)
 }
 
 // partitionTableWithGivenSets is used for this kind of grammar: partition (p0,p1)
@@ -1170,12 +1580,23 @@ func (t *partitionedTable) RemoveRecord(ctx sessionctx.Context, h kv.Handle, r [
 	}
 
 	tbl := t.GetPartition(pid)
-	return tbl.RemoveRecord(ctx, h, r)
+	err = tbl.RemoveRecord(ctx, h, r)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	reorgPid, ok, err := t.locateReorgPartition(ctx, r)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	return t.GetPartition(reorgPid).RemoveRecord(ctx, h, r)
 }
 
 func (t *partitionedTable) GetAllPartitionIDs() []int64 {
 	ptIDs := make([]int64, 0, len(t.partitions))
 	for id := range t.partitions {
+		if _, ok := t.reorgPartitions[id]; ok {
+			continue
+		}
 		ptIDs = append(ptIDs, id)
 	}
 	return ptIDs
@@ -1214,8 +1635,9 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 
 	// The old and new data locate in different partitions.
 	// Remove record from old partition and add record to new partition.
+	newHandle := h
 	if from != to {
-		_, err = t.GetPartition(to).AddRecord(ctx, newData)
+		newHandle, err = t.GetPartition(to).AddRecord(ctx, newData)
 		if err != nil {
 			return errors.Trace(err)
 		}
@@ -1230,11 +1652,42 @@ func partitionedTableUpdateRecord(gctx context.Context, ctx sessionctx.Context,
 			logutil.BgLogger().Error("update partition record fails", zap.String("message", "new record inserted while old record is not removed"), zap.Error(err))
 			return errors.Trace(err)
 		}
-		return nil
+	} else {
+		tbl := t.GetPartition(to)
+		err = tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
+	return t.updateReorgPartitionRecord(ctx, h, newHandle, currData, newData)
+}
 
-	tbl := t.GetPartition(to)
-	return tbl.UpdateRecord(gctx, ctx, h, currData, newData, touched)
+// updateReorgPartitionRecord keeps the reorganizing partitions in sync with UpdateRecord.
+// The row is removed from the reorganizing partition first, then added back with the new data,
+// so it doesn't matter whether the row has been backfilled into the partition or not.
+func (t *partitionedTable) updateReorgPartitionRecord(ctx sessionctx.Context, h, newHandle kv.Handle, currData, newData []types.Datum) error {
+	if t.reorgTable == nil {
+		return nil
+	}
+	from, ok, err := t.locateReorgPartition(ctx, currData)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if ok {
+		err = t.GetPartition(from).RemoveRecord(ctx, h, currData)
+		if err != nil {
+			return errors.Trace(err)
+		}
+	}
+	if !t.canWriteReorgPartitions() {
+		return nil
+	}
+	to, ok, err := t.locateReorgPartition(ctx, newData)
+	if err != nil || !ok {
+		return errors.Trace(err)
+	}
+	_, err = t.GetPartition(to).AddRecord(ctx, t.withRecordHandle(newData, newHandle))
+	return errors.Trace(err)
 }
 
 // FindPartitionByName finds partition in table meta by name.
@@ -1249,6 +1702,28 @@ func FindPartitionByName(meta *model.TableInfo, parName string) (int64, error) {
 	return -1, errors.Trace(table.ErrUnknownPartition.GenWithStackByArgs(parName, meta.Name.O))
 }
 
+// FindPartitionIDsByName finds the physical partitions in table meta by name. The name of a
+// partition of a subpartitioned table refers to all its subpartitions.
+func FindPartitionIDsByName(meta *model.TableInfo, parName string) ([]int64, error) {
+	pi := meta.Partition
+	if pi.Sub != nil {
+		for i, def := range pi.Sub.Parents {
+			if def.Name.L == strings.ToLower(parName) {
+				ids := make([]int64, 0, pi.Sub.Num)
+				for _, sub := range pi.Definitions[i*int(pi.Sub.Num) : (i+1)*int(pi.Sub.Num)] {
+					ids = append(ids, sub.ID)
+				}
+				return ids, nil
+			}
+		}
+	}
+	pid, err := FindPartitionByName(meta, parName)
+	if err != nil {
+		return nil, err
+	}
+	return []int64{pid}, nil
+}
+
 func parseExpr(p *parser.Parser, exprStr string) (ast.ExprNode, error) {
 	exprStr = "select " + exprStr
 	stmts, _, err := p.ParseSQL(exprStr)
@@ -28,6 +28,7 @@ import (
 
 	"github.com/opentracing/opentracing-go"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/meta/autoid"
@@ -42,10 +43,12 @@ import (
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/codec"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/generatedexpr"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/mock"
 	"github.com/pingcap/tidb/util/stringutil"
 	"github.com/pingcap/tidb/util/tableutil"
 	"github.com/pingcap/tipb/go-binlog"
@@ -127,6 +130,12 @@ func TableFromMeta(allocs autoid.Allocators, tblInfo *model.TableInfo) (table.Ta
 				return nil, err
 			}
 			col.GeneratedExpr = expr
+			if col.GeneratedStored && col.State != model.StatePublic && col.ChangeStateInfo == nil {
+				col.AddingGeneratedExpr, err = expression.ParseSimpleExprWithTableInfo(mock.NewContext(), colInfo.GeneratedExprString, tblInfo)
+				if err != nil {
+					return nil, err
+				}
+			}
 		}
 		// default value is expr.
 		if col.DefaultIsExpr {
@@ -380,6 +389,13 @@ func (t *TableCommon) UpdateRecord(ctx context.Context, sctx sessionctx.Context,
 				}
 				newData[col.Offset] = value
 				touched[col.Offset] = touched[col.DependencyColumnOffset]
+			} else if col.AddingGeneratedExpr != nil {
+				value, err = evalAddingGeneratedColumn(sctx, col, newData)
+				if err != nil {
+					return err
+				}
+				newData[col.Offset] = value
+				touched[col.Offset] = true
 			}
 		} else {
 			value = newData[col.Offset]
@@ -751,7 +767,19 @@ func (t *TableCommon) AddRecord(sctx sessionctx.Context, r []types.Datum, opts .
 			colIDs = append(colIDs, col.ID)
 			continue
 		}
-		if col.State != model.StatePublic &&
+		if col.AddingGeneratedExpr != nil {
+			// The stored generated column being added is filled with its value, so the rows written
+			// during the reorganization needn't be backfilled.
+			value, err = evalAddingGeneratedColumn(sctx, col, r)
+			if err != nil {
+				return nil, err
+			}
+			if col.Offset < len(r) {
+				r[col.Offset] = value
+			} else {
+				r = append(r, value)
+			}
+		} else if col.State != model.StatePublic &&
 			// Update call `AddRecord` will already handle the write only column default value.
 			// Only insert should add default value for write only column.
 			!opt.IsUpdate {
@@ -1363,7 +1391,9 @@ func GetColDefaultValue(ctx sessionctx.Context, col *table.Column, defaultVals [
 	if col.GetOriginDefaultValue() == nil && mysql.HasNotNullFlag(col.Flag) {
 		return colVal, errors.New("Miss column")
 	}
-	if col.State != model.StatePublic {
+	// A write-reorganization column may be added by the same multi-schema change
+	// as an index being backfilled, so its origin default must be visible.
+	if col.State != model.StatePublic && col.State != model.StateWriteReorganization {
 		return colVal, nil
 	}
 	if defaultVals[col.Offset].IsNull() {
@@ -1379,6 +1409,15 @@ func GetColDefaultValue(ctx sessionctx.Context, col *table.Column, defaultVals [
 	return colVal, nil
 }
 
+// evalAddingGeneratedColumn evaluates the value of the stored generated column which is being added with the row.
+func evalAddingGeneratedColumn(ctx sessionctx.Context, col *table.Column, r []types.Datum) (types.Datum, error) {
+	val, err := col.AddingGeneratedExpr.Eval(chunk.MutRowFromDatums(r).ToRow())
+	if err != nil {
+		return types.Datum{}, err
+	}
+	return table.CastValue(ctx, val, col.ColumnInfo, false, false)
+}
+
 // AllocHandle allocate a new handle.
 // A statement could reserve some ID in the statement context, try those ones first.
 func AllocHandle(ctx context.Context, sctx sessionctx.Context, t table.Table) (kv.Handle, error) {
@@ -578,7 +578,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.Collate)
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -1079,6 +1079,40 @@ func GenIndexKey(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo
 	return
 }
 
+// GenFullTextIndexKey generates the key of an inverted index entry of the FULLTEXT index, which maps a token
+// to the handle of the row containing it. The layout is the same as a non-unique index on the token.
+func GenFullTextIndexKey(phyTblID, idxID int64, token string, h kv.Handle, buf []byte) (key []byte, err error) {
+	key = GetIndexKeyBuf(buf, RecordRowKeyLen+len(token)+18)
+	key = appendTableIndexPrefix(key, phyTblID)
+	key = codec.EncodeInt(key, idxID)
+	key, err = codec.EncodeKey(nil, key, types.NewBytesDatum([]byte(token)))
+	if err != nil {
+		return nil, err
+	}
+	if h.IsInt() {
+		return codec.EncodeKey(nil, key, types.NewDatum(h.IntValue()))
+	}
+	return append(key, h.Encoded()...), nil
+}
+
+// GenFullTextIndexRange generates the key range of the inverted index entries of the token.
+// If prefix is true, the range covers all the tokens starting with the token.
+func GenFullTextIndexRange(phyTblID, idxID int64, token string, prefix bool) (kv.KeyRange, error) {
+	encoded, err := codec.EncodeKey(nil, nil, types.NewBytesDatum([]byte(token)))
+	if err != nil {
+		return kv.KeyRange{}, err
+	}
+	startKey := EncodeIndexSeekKey(phyTblID, idxID, encoded)
+	if !prefix {
+		return kv.KeyRange{StartKey: startKey, EndKey: startKey.PrefixNext()}, nil
+	}
+	encoded, err = codec.EncodeKey(nil, nil, types.NewBytesDatum(kv.Key(token).PrefixNext()))
+	if err != nil {
+		return kv.KeyRange{}, err
+	}
+	return kv.KeyRange{StartKey: startKey, EndKey: EncodeIndexSeekKey(phyTblID, idxID, encoded)}, nil
+}
+
 // GenIndexValuePortal is the portal for generating index value.
 // Value layout:
 //		+-- IndexValueVersion0  (with restore data, or common handle, or index is global)
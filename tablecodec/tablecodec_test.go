@@ -515,6 +515,29 @@ func TestRange(t *testing.T) {
 	require.Less(t, string(s2), string(e2))
 }
 
+func TestFullTextIndexKey(t *testing.T) {
+	t.Parallel()
+	key, err := GenFullTextIndexKey(1, 2, "database", kv.IntHandle(100), nil)
+	require.NoError(t, err)
+	h, err := DecodeIndexHandle(key, []byte{'0'}, 1)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), h.IntValue())
+
+	exact, err := GenFullTextIndexRange(1, 2, "database", false)
+	require.NoError(t, err)
+	prefix, err := GenFullTextIndexRange(1, 2, "data", true)
+	require.NoError(t, err)
+	otherKey, err := GenFullTextIndexKey(1, 2, "databases", kv.IntHandle(1), nil)
+	require.NoError(t, err)
+	inRange := func(r kv.KeyRange, k kv.Key) bool {
+		return k.Cmp(r.StartKey) >= 0 && k.Cmp(r.EndKey) < 0
+	}
+	require.True(t, inRange(exact, key))
+	require.False(t, inRange(exact, otherKey))
+	require.True(t, inRange(prefix, key))
+	require.True(t, inRange(prefix, otherKey))
+}
+
 func TestDecodeAutoIDMeta(t *testing.T) {
 	t.Parallel()
 	keyBytes := []byte{0x6d, 0x44, 0x42, 0x3a, 0x35, 0x36, 0x0, 0x0, 0x0, 0xfc, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x0, 0x68, 0x54, 0x49, 0x44, 0x3a, 0x31, 0x30, 0x38, 0x0, 0xfe}
@@ -31,6 +31,7 @@ import (
 	"github.com/pingcap/tidb/parser/types"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types/json"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/hack"
 )
 
@@ -828,6 +829,9 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 	if d.k == KindNull {
 		return Datum{}, nil
 	}
+	if target.Array {
+		return d.convertToArray(sc, target)
+	}
 	switch target.Tp { // TODO: implement mysql types convert when "CAST() AS" syntax are supported.
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong:
 		unsigned := mysql.HasUnsignedFlag(target.Flag)
@@ -858,6 +862,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToGeometry(sc, target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1543,6 +1549,51 @@ func (d *Datum) convertToMysqlSet(sc *stmtctx.StatementContext, target *FieldTyp
 	return ret, err
 }
 
+// convertToArray converts the JSON document to the JSON array whose elements are converted to the element type
+// of the target, the document which is not an array is converted to the array of one element.
+func (d *Datum) convertToArray(sc *stmtctx.StatementContext, target *FieldType) (ret Datum, err error) {
+	doc, err := d.convertToMysqlJSON(sc, target)
+	if err != nil {
+		return ret, errors.Trace(err)
+	}
+	j := doc.GetMysqlJSON()
+	elems := []json.BinaryJSON{j}
+	if j.TypeCode == json.TypeCodeArray {
+		elems = make([]json.BinaryJSON, 0, j.GetElemCount())
+		for i := 0; i < j.GetElemCount(); i++ {
+			elems = append(elems, j.ArrayGetElem(i))
+		}
+	}
+	elemTp := target.Clone()
+	elemTp.Array = false
+	isString := elemTp.EvalType() == ETString
+	for i, elem := range elems {
+		var v Datum
+		switch {
+		case isString && elem.TypeCode == json.TypeCodeString:
+			v.SetString(string(elem.GetString()), elemTp.Collate)
+		case !isString && elem.TypeCode == json.TypeCodeInt64:
+			v.SetInt64(elem.GetInt64())
+		case !isString && elem.TypeCode == json.TypeCodeUint64:
+			v.SetUint64(elem.GetUint64())
+		case !isString && elem.TypeCode == json.TypeCodeFloat64:
+			v.SetFloat64(elem.GetFloat64())
+		default:
+			return ret, ErrTruncatedWrongVal.GenWithStackByArgs(elemTp.CompactStr(), elem.String())
+		}
+		if v, err = v.ConvertTo(sc, elemTp); err != nil {
+			return ret, errors.Trace(err)
+		}
+		if isString {
+			elems[i] = json.CreateBinary(v.GetString())
+		} else {
+			elems[i] = json.CreateBinary(v.GetValue())
+		}
+	}
+	ret.SetMysqlJSON(json.CreateBinaryArray(elems))
+	return ret, nil
+}
+
 func (d *Datum) convertToMysqlJSON(sc *stmtctx.StatementContext, target *FieldType) (ret Datum, err error) {
 	switch d.k {
 	case KindString, KindBytes:
@@ -1578,6 +1629,22 @@ func (d *Datum) convertToMysqlJSON(sc *stmtctx.StatementContext, target *FieldTy
 	return ret, errors.Trace(err)
 }
 
+func (d *Datum) convertToGeometry(sc *stmtctx.StatementContext, target *FieldType) (Datum, error) {
+	var ret Datum
+	switch d.k {
+	case KindString, KindBytes:
+		// The value must be a geometry in the storage format of the spatial types, and the subtype of the
+		// column restricts the geometry type unless it's GEOMETRY.
+		_, g, err := spatial.Decode(d.GetBytes())
+		if err != nil || (target.GeometryType != mysql.GeometryTypeGeometry && g.Tp != target.GeometryType) {
+			return ret, ErrCantCreateGeometryObject
+		}
+		ret.SetBytes(d.GetBytes())
+		return ret, nil
+	}
+	return ret, ErrCantCreateGeometryObject
+}
+
 // ToBool converts to a bool.
 // We will use 1 for true, and 0 for false.
 func (d *Datum) ToBool(sc *stmtctx.StatementContext) (int64, error) {
@@ -228,6 +228,12 @@ func (bj BinaryJSON) Extract(pathExprList []PathExpression) (ret BinaryJSON, fou
 	return
 }
 
+// ExtractAll returns all the values matched by the path expression in the order of the document, it's used to
+// enumerate the matched values rather than wrapping them as an array like Extract.
+func (bj BinaryJSON) ExtractAll(pathExpr PathExpression) []BinaryJSON {
+	return bj.extractTo(nil, pathExpr)
+}
+
 func (bj BinaryJSON) extractTo(buf []BinaryJSON, pathExpr PathExpression) []BinaryJSON {
 	if len(pathExpr.legs) == 0 {
 		return append(buf, bj)
@@ -288,6 +294,11 @@ func (bj BinaryJSON) objectSearchKey(key []byte) (BinaryJSON, bool) {
 	return BinaryJSON{}, false
 }
 
+// CreateBinaryArray creates the Array of the elements.
+func CreateBinaryArray(elems []BinaryJSON) BinaryJSON {
+	return buildBinaryArray(elems)
+}
+
 func buildBinaryArray(elems []BinaryJSON) BinaryJSON {
 	totalSize := headerSize + len(elems)*valEntrySize
 	for _, elem := range elems {
@@ -1021,6 +1032,57 @@ func ContainsBinary(obj, target BinaryJSON) bool {
 	}
 }
 
+// MemberOfBinary checks whether the target is an element of the JSON array, a JSON document which
+// is not an array is treated as the array of one element.
+func MemberOfBinary(target, arr BinaryJSON) bool {
+	if arr.TypeCode != TypeCodeArray {
+		return CompareBinary(target, arr) == 0
+	}
+	for i := 0; i < arr.GetElemCount(); i++ {
+		if CompareBinary(target, arr.arrayGetElem(i)) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// OverlapsBinary checks whether the two JSON documents overlap according the following rules:
+// 1) two objects overlap if and only if they share at least one key-value pair;
+// 2) otherwise the documents which are not arrays are treated as the arrays of one element, and two arrays
+// overlap if and only if they share at least one element.
+func OverlapsBinary(a, b BinaryJSON) bool {
+	if a.TypeCode == TypeCodeObject && b.TypeCode == TypeCodeObject {
+		for i := 0; i < a.GetElemCount(); i++ {
+			if val, exists := b.objectSearchKey(a.objectGetKey(i)); exists && CompareBinary(a.objectGetVal(i), val) == 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if a.TypeCode != TypeCodeArray {
+		return MemberOfBinary(a, b)
+	}
+	for i := 0; i < a.GetElemCount(); i++ {
+		if MemberOfBinary(a.arrayGetElem(i), b) {
+			return true
+		}
+	}
+	return false
+}
+
+// FlattenBinaryArray returns the elements of the JSON array with the nested arrays flattened, a JSON document
+// which is not an array is returned as the only element.
+func FlattenBinaryArray(bj BinaryJSON) []BinaryJSON {
+	if bj.TypeCode != TypeCodeArray {
+		return []BinaryJSON{bj}
+	}
+	var elems []BinaryJSON
+	for i := 0; i < bj.GetElemCount(); i++ {
+		elems = append(elems, FlattenBinaryArray(bj.arrayGetElem(i))...)
+	}
+	return elems
+}
+
 // GetElemDepth for JSON_DEPTH
 // Returns the maximum depth of a JSON document
 // rules referenced by MySQL JSON_DEPTH function
@@ -85,6 +85,32 @@ func TestBinaryJSONExtract(t *testing.T) {
 	}
 }
 
+func TestBinaryJSONExtractAll(t *testing.T) {
+	t.Parallel()
+
+	bj := mustParseBinaryFromString(t, `{"a": [1, [2, 3], {"b": 4}], "c": [5]}`)
+	var tests = []struct {
+		pathExprString string
+		expected       []string
+	}{
+		{"$.a", []string{`[1, [2, 3], {"b": 4}]`}},
+		{"$.a[*]", []string{`1`, `[2, 3]`, `{"b": 4}`}},
+		{"$.a[1]", []string{`[2, 3]`}},
+		{"$.*[0]", []string{`1`, `5`}},
+		{"$.a[*].b", []string{`4`}},
+		{"$.d", nil},
+	}
+	for _, test := range tests {
+		pe, err := ParseJSONPathExpr(test.pathExprString)
+		require.NoError(t, err)
+		var result []string
+		for _, elem := range bj.ExtractAll(pe) {
+			result = append(result, elem.String())
+		}
+		require.Equal(t, test.expected, result, test.pathExprString)
+	}
+}
+
 func TestBinaryJSONType(t *testing.T) {
 	t.Parallel()
 
@@ -224,6 +224,10 @@ var (
 	ErrInvalidJSONPathArrayCell = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONPathArrayCell)
 	// ErrUnsupportedSecondArgumentType means unsupported second argument type in json_objectagg
 	ErrUnsupportedSecondArgumentType = dbterror.ClassJSON.NewStd(mysql.ErrUnsupportedSecondArgumentType)
+	// ErrInvalidJSONType means the JSON argument of a function has an unexpected type.
+	ErrInvalidJSONType = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONType)
+	// ErrInvalidJSONSchema means the JSON schema is malformed or uses an unsupported keyword.
+	ErrInvalidJSONSchema = dbterror.ClassJSON.NewStd(mysql.ErrInvalidJSONSchema)
 )
 
 // json_contains_path function type choices
@@ -0,0 +1,437 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pingcap/tidb/util/hack"
+)
+
+// SchemaValidationReport is the result of validating a JSON document against a JSON schema,
+// the fields other than Valid describe the first failed requirement.
+type SchemaValidationReport struct {
+	Valid            bool
+	SchemaLocation   string
+	DocumentLocation string
+	FailedKeyword    string
+}
+
+// Reason returns the description of the failed requirement.
+func (r *SchemaValidationReport) Reason() string {
+	return fmt.Sprintf("The JSON document location '%s' failed requirement '%s' at JSON Schema location '%s'",
+		r.DocumentLocation, r.FailedKeyword, r.SchemaLocation)
+}
+
+// ToBinaryJSON returns the report in the format of JSON_SCHEMA_VALIDATION_REPORT.
+func (r *SchemaValidationReport) ToBinaryJSON() BinaryJSON {
+	if r.Valid {
+		return CreateBinary(map[string]interface{}{"valid": true})
+	}
+	return CreateBinary(map[string]interface{}{
+		"valid":                 false,
+		"reason":                r.Reason(),
+		"schema-location":       r.SchemaLocation,
+		"document-location":     r.DocumentLocation,
+		"schema-failed-keyword": r.FailedKeyword,
+	})
+}
+
+// ValidateSchema validates the JSON document against the JSON schema.
+// The keywords of JSON Schema draft 4 are supported except "format" and "dependencies", the unknown keywords
+// are ignored and "$ref" can only reference the definitions in the same schema, e.g. "#/definitions/a".
+func ValidateSchema(schema, doc BinaryJSON) (*SchemaValidationReport, error) {
+	if schema.TypeCode != TypeCodeObject {
+		return nil, ErrInvalidJSONSchema.GenWithStackByArgs("the schema must be an object")
+	}
+	v := &schemaValidator{root: schema}
+	return v.validate(schema, doc, "#", "#")
+}
+
+type schemaValidator struct {
+	root BinaryJSON
+}
+
+func (v *schemaValidator) validate(schema, doc BinaryJSON, schemaLoc, docLoc string) (*SchemaValidationReport, error) {
+	if schema.TypeCode != TypeCodeObject {
+		return nil, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the schema at '%s' must be an object", schemaLoc))
+	}
+	// The other keywords are ignored if "$ref" is specified.
+	if ref, ok := schema.objectSearchKey([]byte("$ref")); ok {
+		target, err := v.resolveRef(ref, schemaLoc)
+		if err != nil {
+			return nil, err
+		}
+		return v.validate(target, doc, schemaLoc+"/$ref", docLoc)
+	}
+	for i := 0; i < schema.GetElemCount(); i++ {
+		keyword := string(schema.objectGetKey(i))
+		report, err := v.validateKeyword(schema, keyword, schema.objectGetVal(i), doc, schemaLoc, docLoc)
+		if err != nil || !report.Valid {
+			return report, err
+		}
+	}
+	return &SchemaValidationReport{Valid: true}, nil
+}
+
+func (v *schemaValidator) validateKeyword(schema BinaryJSON, keyword string, val, doc BinaryJSON, schemaLoc, docLoc string) (*SchemaValidationReport, error) {
+	failed := &SchemaValidationReport{SchemaLocation: schemaLoc, DocumentLocation: docLoc, FailedKeyword: keyword}
+	valid := true
+	switch keyword {
+	case "type":
+		types, err := schemaStrings(val, keyword, schemaLoc)
+		if err != nil {
+			return nil, err
+		}
+		valid = false
+		for _, tp := range types {
+			if valid = matchSchemaType(tp, doc); valid {
+				break
+			}
+		}
+	case "enum":
+		if val.TypeCode != TypeCodeArray {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "an array")
+		}
+		valid = MemberOfBinary(doc, val)
+	case "allOf", "anyOf", "oneOf":
+		if val.TypeCode != TypeCodeArray || val.GetElemCount() == 0 {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "a non-empty array")
+		}
+		matched := 0
+		for i := 0; i < val.GetElemCount(); i++ {
+			report, err := v.validate(val.arrayGetElem(i), doc, fmt.Sprintf("%s/%s/%d", schemaLoc, keyword, i), docLoc)
+			if err != nil {
+				return nil, err
+			}
+			if report.Valid {
+				matched++
+			} else if keyword == "allOf" {
+				return report, nil
+			}
+		}
+		valid = keyword == "allOf" || (keyword == "anyOf" && matched > 0) || (keyword == "oneOf" && matched == 1)
+	case "not":
+		report, err := v.validate(val, doc, schemaLoc+"/not", docLoc)
+		if err != nil {
+			return nil, err
+		}
+		valid = !report.Valid
+	case "multipleOf", "maximum", "minimum":
+		limit, ok := schemaNumber(val)
+		if !ok || (keyword == "multipleOf" && limit <= 0) {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "a number")
+		}
+		num, isNumber := schemaNumber(doc)
+		if !isNumber {
+			break
+		}
+		switch keyword {
+		case "multipleOf":
+			quotient := num / limit
+			valid = !math.IsInf(quotient, 0) && quotient == math.Trunc(quotient)
+		case "maximum":
+			valid = num < limit || (num == limit && !schemaBool(schema, "exclusiveMaximum"))
+		case "minimum":
+			valid = num > limit || (num == limit && !schemaBool(schema, "exclusiveMinimum"))
+		}
+	case "maxLength", "minLength", "maxItems", "minItems", "maxProperties", "minProperties":
+		limit, ok := schemaNumber(val)
+		if !ok || limit < 0 || limit != math.Trunc(limit) {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "a non-negative integer")
+		}
+		var size int
+		switch {
+		case strings.HasSuffix(keyword, "Length") && doc.TypeCode == TypeCodeString:
+			size = utf8.RuneCount(doc.GetString())
+		case strings.HasSuffix(keyword, "Items") && doc.TypeCode == TypeCodeArray,
+			strings.HasSuffix(keyword, "Properties") && doc.TypeCode == TypeCodeObject:
+			size = doc.GetElemCount()
+		default:
+			return &SchemaValidationReport{Valid: true}, nil
+		}
+		if strings.HasPrefix(keyword, "max") {
+			valid = float64(size) <= limit
+		} else {
+			valid = float64(size) >= limit
+		}
+	case "pattern":
+		re, err := schemaRegexp(val, keyword, schemaLoc)
+		if err != nil {
+			return nil, err
+		}
+		if doc.TypeCode == TypeCodeString {
+			valid = re.Match(doc.GetString())
+		}
+	case "uniqueItems":
+		if val.TypeCode != TypeCodeLiteral || val.Value[0] == LiteralNil {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "a boolean")
+		}
+		if val.Value[0] == LiteralTrue && doc.TypeCode == TypeCodeArray {
+			for i := 0; i < doc.GetElemCount() && valid; i++ {
+				for j := 0; j < i && valid; j++ {
+					valid = CompareBinary(doc.arrayGetElem(i), doc.arrayGetElem(j)) != 0
+				}
+			}
+		}
+	case "required":
+		keys, err := schemaStrings(val, keyword, schemaLoc)
+		if err != nil {
+			return nil, err
+		}
+		if doc.TypeCode == TypeCodeObject {
+			for _, key := range keys {
+				if _, valid = doc.objectSearchKey(hack.Slice(key)); !valid {
+					break
+				}
+			}
+		}
+	case "items":
+		return v.validateItems(schema, val, doc, schemaLoc, docLoc)
+	case "properties", "patternProperties", "additionalProperties":
+		return v.validateProperties(schema, keyword, val, doc, schemaLoc, docLoc)
+	}
+	if valid {
+		return &SchemaValidationReport{Valid: true}, nil
+	}
+	return failed, nil
+}
+
+// validateItems validates the elements of the array by "items" and "additionalItems".
+func (v *schemaValidator) validateItems(schema, items, doc BinaryJSON, schemaLoc, docLoc string) (*SchemaValidationReport, error) {
+	if items.TypeCode != TypeCodeObject && items.TypeCode != TypeCodeArray {
+		return nil, invalidSchemaKeyword("items", schemaLoc, "an object or an array")
+	}
+	if doc.TypeCode != TypeCodeArray {
+		return &SchemaValidationReport{Valid: true}, nil
+	}
+	additional, hasAdditional := schema.objectSearchKey([]byte("additionalItems"))
+	for i := 0; i < doc.GetElemCount(); i++ {
+		elemLoc := fmt.Sprintf("%s/%d", docLoc, i)
+		var elemSchema BinaryJSON
+		var elemSchemaLoc string
+		switch {
+		case items.TypeCode == TypeCodeObject:
+			elemSchema, elemSchemaLoc = items, schemaLoc+"/items"
+		case i < items.GetElemCount():
+			elemSchema, elemSchemaLoc = items.arrayGetElem(i), fmt.Sprintf("%s/items/%d", schemaLoc, i)
+		case !hasAdditional:
+			continue
+		case additional.TypeCode == TypeCodeLiteral:
+			if additional.Value[0] == LiteralFalse {
+				return &SchemaValidationReport{SchemaLocation: schemaLoc, DocumentLocation: elemLoc, FailedKeyword: "additionalItems"}, nil
+			}
+			continue
+		default:
+			elemSchema, elemSchemaLoc = additional, schemaLoc+"/additionalItems"
+		}
+		report, err := v.validate(elemSchema, doc.arrayGetElem(i), elemSchemaLoc, elemLoc)
+		if err != nil || !report.Valid {
+			return report, err
+		}
+	}
+	return &SchemaValidationReport{Valid: true}, nil
+}
+
+// validateProperties validates the members of the object by "properties", "patternProperties" and
+// "additionalProperties", the members which don't match the first two keywords are checked by the last one.
+func (v *schemaValidator) validateProperties(schema BinaryJSON, keyword string, val, doc BinaryJSON, schemaLoc, docLoc string) (*SchemaValidationReport, error) {
+	if val.TypeCode != TypeCodeObject && !(keyword == "additionalProperties" && val.TypeCode == TypeCodeLiteral) {
+		return nil, invalidSchemaKeyword(keyword, schemaLoc, "an object")
+	}
+	if doc.TypeCode != TypeCodeObject {
+		return &SchemaValidationReport{Valid: true}, nil
+	}
+	properties, _ := schema.objectSearchKey([]byte("properties"))
+	patterns, _ := schema.objectSearchKey([]byte("patternProperties"))
+	for i := 0; i < doc.GetElemCount(); i++ {
+		key := doc.objectGetKey(i)
+		memberLoc := docLoc + "/" + escapeJSONPointer(string(key))
+		switch keyword {
+		case "properties":
+			if propSchema, ok := val.objectSearchKey(key); ok {
+				report, err := v.validate(propSchema, doc.objectGetVal(i), schemaLoc+"/properties/"+escapeJSONPointer(string(key)), memberLoc)
+				if err != nil || !report.Valid {
+					return report, err
+				}
+			}
+		case "patternProperties":
+			for j := 0; j < val.GetElemCount(); j++ {
+				pattern := string(val.objectGetKey(j))
+				re, err := schemaRegexp(CreateBinary(pattern), keyword, schemaLoc)
+				if err != nil {
+					return nil, err
+				}
+				if !re.Match(key) {
+					continue
+				}
+				report, err := v.validate(val.objectGetVal(j), doc.objectGetVal(i), schemaLoc+"/patternProperties/"+escapeJSONPointer(pattern), memberLoc)
+				if err != nil || !report.Valid {
+					return report, err
+				}
+			}
+		case "additionalProperties":
+			matched, err := matchSchemaProperties(properties, patterns, key, schemaLoc)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				continue
+			}
+			if val.TypeCode == TypeCodeLiteral {
+				if val.Value[0] == LiteralFalse {
+					return &SchemaValidationReport{SchemaLocation: schemaLoc, DocumentLocation: memberLoc, FailedKeyword: keyword}, nil
+				}
+				continue
+			}
+			report, err := v.validate(val, doc.objectGetVal(i), schemaLoc+"/additionalProperties", memberLoc)
+			if err != nil || !report.Valid {
+				return report, err
+			}
+		}
+	}
+	return &SchemaValidationReport{Valid: true}, nil
+}
+
+// resolveRef resolves the JSON pointer referencing the same schema.
+func (v *schemaValidator) resolveRef(ref BinaryJSON, schemaLoc string) (BinaryJSON, error) {
+	if ref.TypeCode != TypeCodeString || !strings.HasPrefix(string(ref.GetString()), "#") {
+		return ref, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("only the local '$ref' is supported at '%s'", schemaLoc))
+	}
+	target := v.root
+	pointer := strings.TrimPrefix(string(ref.GetString()), "#")
+	if pointer == "" {
+		return target, nil
+	}
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		found := false
+		switch target.TypeCode {
+		case TypeCodeObject:
+			target, found = target.objectSearchKey(hack.Slice(token))
+		case TypeCodeArray:
+			if idx, err := strconv.Atoi(token); err == nil && idx >= 0 && idx < target.GetElemCount() {
+				target, found = target.arrayGetElem(idx), true
+			}
+		}
+		if !found {
+			return target, ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the '$ref' at '%s' can't be resolved", schemaLoc))
+		}
+	}
+	return target, nil
+}
+
+func matchSchemaProperties(properties, patterns BinaryJSON, key []byte, schemaLoc string) (bool, error) {
+	if properties.TypeCode == TypeCodeObject {
+		if _, ok := properties.objectSearchKey(key); ok {
+			return true, nil
+		}
+	}
+	if patterns.TypeCode == TypeCodeObject {
+		for i := 0; i < patterns.GetElemCount(); i++ {
+			re, err := schemaRegexp(CreateBinary(string(patterns.objectGetKey(i))), "patternProperties", schemaLoc)
+			if err != nil {
+				return false, err
+			}
+			if re.Match(key) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func matchSchemaType(tp string, doc BinaryJSON) bool {
+	switch tp {
+	case "object":
+		return doc.TypeCode == TypeCodeObject
+	case "array":
+		return doc.TypeCode == TypeCodeArray
+	case "string":
+		return doc.TypeCode == TypeCodeString
+	case "number":
+		return doc.TypeCode == TypeCodeInt64 || doc.TypeCode == TypeCodeUint64 || doc.TypeCode == TypeCodeFloat64
+	case "integer":
+		return doc.TypeCode == TypeCodeInt64 || doc.TypeCode == TypeCodeUint64 ||
+			(doc.TypeCode == TypeCodeFloat64 && doc.GetFloat64() == math.Trunc(doc.GetFloat64()))
+	case "boolean":
+		return doc.TypeCode == TypeCodeLiteral && doc.Value[0] != LiteralNil
+	case "null":
+		return doc.TypeCode == TypeCodeLiteral && doc.Value[0] == LiteralNil
+	}
+	return false
+}
+
+func schemaNumber(bj BinaryJSON) (float64, bool) {
+	switch bj.TypeCode {
+	case TypeCodeInt64:
+		return float64(bj.GetInt64()), true
+	case TypeCodeUint64:
+		return float64(bj.GetUint64()), true
+	case TypeCodeFloat64:
+		return bj.GetFloat64(), true
+	}
+	return 0, false
+}
+
+// schemaBool returns whether the keyword of the schema is true.
+func schemaBool(schema BinaryJSON, keyword string) bool {
+	val, ok := schema.objectSearchKey(hack.Slice(keyword))
+	return ok && val.TypeCode == TypeCodeLiteral && val.Value[0] == LiteralTrue
+}
+
+// schemaStrings returns the value of the keyword which is a string or an array of strings.
+func schemaStrings(val BinaryJSON, keyword, schemaLoc string) ([]string, error) {
+	if val.TypeCode == TypeCodeString && keyword == "type" {
+		return []string{string(val.GetString())}, nil
+	}
+	if val.TypeCode != TypeCodeArray {
+		return nil, invalidSchemaKeyword(keyword, schemaLoc, "an array of strings")
+	}
+	strs := make([]string, 0, val.GetElemCount())
+	for i := 0; i < val.GetElemCount(); i++ {
+		elem := val.arrayGetElem(i)
+		if elem.TypeCode != TypeCodeString {
+			return nil, invalidSchemaKeyword(keyword, schemaLoc, "an array of strings")
+		}
+		strs = append(strs, string(elem.GetString()))
+	}
+	return strs, nil
+}
+
+func schemaRegexp(val BinaryJSON, keyword, schemaLoc string) (*regexp.Regexp, error) {
+	if val.TypeCode != TypeCodeString {
+		return nil, invalidSchemaKeyword(keyword, schemaLoc, "a regular expression")
+	}
+	re, err := regexp.Compile(string(val.GetString()))
+	if err != nil {
+		return nil, invalidSchemaKeyword(keyword, schemaLoc, "a regular expression")
+	}
+	return re, nil
+}
+
+func invalidSchemaKeyword(keyword, schemaLoc, expected string) error {
+	return ErrInvalidJSONSchema.GenWithStackByArgs(fmt.Sprintf("the value of '%s' at '%s' must be %s", keyword, schemaLoc, expected))
+}
+
+// escapeJSONPointer escapes the reference token of JSON pointer, see https://tools.ietf.org/html/rfc6901.
+func escapeJSONPointer(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,106 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package json
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		schema  string
+		doc     string
+		valid   bool
+		failed  string
+		schLoc  string
+		docLoc  string
+		invalid bool
+	}{
+		{schema: `{}`, doc: `[1, "a"]`, valid: true},
+		{schema: `{"type": "object"}`, doc: `{}`, valid: true},
+		{schema: `{"type": "object"}`, doc: `[]`, failed: "type", schLoc: "#", docLoc: "#"},
+		{schema: `{"type": ["string", "null"]}`, doc: `null`, valid: true},
+		{schema: `{"type": "integer"}`, doc: `1.0`, valid: true},
+		{schema: `{"type": "integer"}`, doc: `1.5`, failed: "type", schLoc: "#", docLoc: "#"},
+		{schema: `{"type": "number"}`, doc: `1.5`, valid: true},
+		{schema: `{"enum": [1, "a", [2]]}`, doc: `[2]`, valid: true},
+		{schema: `{"enum": [1, "a"]}`, doc: `"b"`, failed: "enum", schLoc: "#", docLoc: "#"},
+		{schema: `{"minimum": 1, "maximum": 3, "exclusiveMaximum": true}`, doc: `3`, failed: "maximum", schLoc: "#", docLoc: "#"},
+		{schema: `{"minimum": 1, "maximum": 3}`, doc: `3`, valid: true},
+		{schema: `{"minimum": 1}`, doc: `"a"`, valid: true},
+		{schema: `{"multipleOf": 0.5}`, doc: `2.5`, valid: true},
+		{schema: `{"multipleOf": 2}`, doc: `3`, failed: "multipleOf", schLoc: "#", docLoc: "#"},
+		{schema: `{"minLength": 2, "maxLength": 3}`, doc: `"你好"`, valid: true},
+		{schema: `{"maxLength": 3}`, doc: `"abcd"`, failed: "maxLength", schLoc: "#", docLoc: "#"},
+		{schema: `{"pattern": "^[a-z]+$"}`, doc: `"abc"`, valid: true},
+		{schema: `{"pattern": "^[a-z]+$"}`, doc: `"ab1"`, failed: "pattern", schLoc: "#", docLoc: "#"},
+		{schema: `{"items": {"type": "integer"}, "uniqueItems": true}`, doc: `[1, 2, 3]`, valid: true},
+		{schema: `{"items": {"type": "integer"}}`, doc: `[1, "a"]`, failed: "type", schLoc: "#/items", docLoc: "#/1"},
+		{schema: `{"uniqueItems": true}`, doc: `[1, 2, 1.0]`, failed: "uniqueItems", schLoc: "#", docLoc: "#"},
+		{schema: `{"items": [{"type": "integer"}], "additionalItems": false}`, doc: `[1, 2]`, failed: "additionalItems", schLoc: "#", docLoc: "#/1"},
+		{schema: `{"items": [{"type": "integer"}], "additionalItems": {"type": "string"}}`, doc: `[1, "a"]`, valid: true},
+		{schema: `{"minItems": 1, "maxItems": 2}`, doc: `[]`, failed: "minItems", schLoc: "#", docLoc: "#"},
+		{schema: `{"required": ["a", "b"]}`, doc: `{"a": 1}`, failed: "required", schLoc: "#", docLoc: "#"},
+		{schema: `{"properties": {"a": {"type": "string"}}}`, doc: `{"a": 1}`, failed: "type", schLoc: "#/properties/a", docLoc: "#/a"},
+		{schema: `{"properties": {"a/b": {"type": "string"}}}`, doc: `{"a/b": 1}`, failed: "type", schLoc: "#/properties/a~1b", docLoc: "#/a~1b"},
+		{schema: `{"patternProperties": {"^x-": {"type": "integer"}}}`, doc: `{"x-a": "s"}`, failed: "type", schLoc: "#/patternProperties/^x-", docLoc: "#/x-a"},
+		{schema: `{"properties": {"a": {}}, "patternProperties": {"^x-": {}}, "additionalProperties": false}`, doc: `{"a": 1, "x-b": 2}`, valid: true},
+		{schema: `{"properties": {"a": {}}, "additionalProperties": false}`, doc: `{"a": 1, "b": 2}`, failed: "additionalProperties", schLoc: "#", docLoc: "#/b"},
+		{schema: `{"additionalProperties": {"type": "integer"}}`, doc: `{"b": "x"}`, failed: "type", schLoc: "#/additionalProperties", docLoc: "#/b"},
+		{schema: `{"minProperties": 1}`, doc: `{}`, failed: "minProperties", schLoc: "#", docLoc: "#"},
+		{schema: `{"allOf": [{"type": "integer"}, {"minimum": 2}]}`, doc: `1`, failed: "minimum", schLoc: "#/allOf/1", docLoc: "#"},
+		{schema: `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`, doc: `"a"`, valid: true},
+		{schema: `{"anyOf": [{"type": "integer"}, {"type": "string"}]}`, doc: `null`, failed: "anyOf", schLoc: "#", docLoc: "#"},
+		{schema: `{"oneOf": [{"type": "integer"}, {"minimum": 0}]}`, doc: `1`, failed: "oneOf", schLoc: "#", docLoc: "#"},
+		{schema: `{"not": {"type": "null"}}`, doc: `null`, failed: "not", schLoc: "#", docLoc: "#"},
+		{schema: `{"definitions": {"pos": {"minimum": 0}}, "properties": {"a": {"$ref": "#/definitions/pos"}}}`, doc: `{"a": -1}`, failed: "minimum", schLoc: "#/properties/a/$ref", docLoc: "#/a"},
+		{schema: `{"unknown": 1, "format": "date"}`, doc: `1`, valid: true},
+		{schema: `{"type": 1}`, invalid: true},
+		{schema: `{"required": "a"}`, invalid: true},
+		{schema: `{"pattern": "("}`, invalid: true},
+		{schema: `{"$ref": "http://example.com/schema"}`, invalid: true},
+		{schema: `{"$ref": "#/definitions/none"}`, invalid: true},
+		{schema: `{"allOf": []}`, invalid: true},
+		{schema: `[]`, invalid: true},
+	}
+	for _, tt := range tests {
+		schema := mustParseBinaryFromString(t, tt.schema)
+		doc := CreateBinary(int64(1))
+		if tt.doc != "" {
+			doc = mustParseBinaryFromString(t, tt.doc)
+		}
+		report, err := ValidateSchema(schema, doc)
+		if tt.invalid {
+			require.Truef(t, ErrInvalidJSONSchema.Equal(err), "schema %s", tt.schema)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equalf(t, tt.valid, report.Valid, "schema %s, doc %s", tt.schema, tt.doc)
+		if !tt.valid {
+			require.Equal(t, tt.failed, report.FailedKeyword)
+			require.Equal(t, tt.schLoc, report.SchemaLocation)
+			require.Equal(t, tt.docLoc, report.DocumentLocation)
+		}
+	}
+
+	report := &SchemaValidationReport{SchemaLocation: "#", DocumentLocation: "#/a", FailedKeyword: "type"}
+	require.Equal(t, `{"document-location": "#/a", "reason": "The JSON document location '#/a' failed requirement 'type' at JSON Schema location '#'", "schema-failed-keyword": "type", "schema-location": "#", "valid": false}`,
+		report.ToBinaryJSON().String())
+	require.Equal(t, `{"valid": true}`, (&SchemaValidationReport{Valid: true}).ToBinaryJSON().String())
+}
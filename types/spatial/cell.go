@@ -0,0 +1,205 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+)
+
+// CellID identifies a cell of the quadtree which recursively splits the plane into four quadrants.
+// The cells are ordered by the Z-order curve, and the id of a cell at level l is the 2*l bits Z-order
+// of the cell followed by a 1 bit and the zero bits, like the cell ids of S2. So the ids of the descendant
+// cells of a cell are in the contiguous range [RangeMin, RangeMax] around the id of the cell.
+//
+// The plane is mapped to the grid of 2^MaxCellLevel * 2^MaxCellLevel by an order-preserving mapping of the
+// float64 coordinates, so the cells cover the whole plane and any rectangle is covered by a cell.
+type CellID uint64
+
+// MaxCellLevel is the level of the smallest cells.
+const MaxCellLevel = 31
+
+// RootCellID is the id of the cell at level 0 which covers the whole plane.
+const RootCellID CellID = 1 << (2 * MaxCellLevel)
+
+// gridCoord maps the coordinate to the grid order-preservingly, it's the high bits of the sortable
+// representation of the float64.
+func gridCoord(f float64) uint64 {
+	u := math.Float64bits(f)
+	if u&(1<<63) != 0 {
+		u = ^u
+	} else {
+		u |= 1 << 63
+	}
+	return u >> (64 - MaxCellLevel)
+}
+
+// interleave returns the Z-order of the grid coordinates.
+func interleave(x, y uint64) uint64 {
+	var z uint64
+	for i := 0; i < MaxCellLevel; i++ {
+		z |= (x>>i&1)<<(2*i+1) | (y>>i&1)<<(2*i)
+	}
+	return z
+}
+
+// newCellID returns the id of the cell at the level containing the grid coordinates.
+func newCellID(x, y uint64, level int) CellID {
+	shift := MaxCellLevel - level
+	z := interleave(x>>shift<<shift, y>>shift<<shift)
+	lsb := uint64(1) << (2 * shift)
+	return CellID(z<<1 | lsb)
+}
+
+func (c CellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+// Level returns the level of the cell, the root cell covering the whole plane is at level 0.
+func (c CellID) Level() int {
+	level := MaxCellLevel
+	for lsb := c.lsb(); lsb > 1; lsb >>= 2 {
+		level--
+	}
+	return level
+}
+
+// Parent returns the ancestor cell at the level, which must not be greater than the level of the cell.
+func (c CellID) Parent(level int) CellID {
+	lsb := uint64(1) << (2 * (MaxCellLevel - level))
+	return CellID(uint64(c)&-lsb | lsb)
+}
+
+// RangeMin returns the minimum id of the descendant cells.
+func (c CellID) RangeMin() CellID {
+	return CellID(uint64(c) - (c.lsb() - 1))
+}
+
+// RangeMax returns the maximum id of the descendant cells.
+func (c CellID) RangeMax() CellID {
+	return CellID(uint64(c) + (c.lsb() - 1))
+}
+
+type gridRect struct {
+	minX, minY, maxX, maxY uint64
+}
+
+func (m MBR) gridRect() gridRect {
+	return gridRect{minX: gridCoord(m.MinX), minY: gridCoord(m.MinY), maxX: gridCoord(m.MaxX), maxY: gridCoord(m.MaxY)}
+}
+
+// CellIDForMBR returns the smallest cell containing the rectangle, which is the cell indexing the geometry
+// of the rectangle in a SPATIAL index.
+func CellIDForMBR(m MBR) CellID {
+	r := m.gridRect()
+	level := MaxCellLevel
+	for shift := 0; r.minX>>shift != r.maxX>>shift || r.minY>>shift != r.maxY>>shift; shift++ {
+		level--
+	}
+	return newCellID(r.minX, r.minY, level)
+}
+
+// CellRange is an inclusive range of the cell ids.
+type CellRange struct {
+	Min, Max CellID
+}
+
+// contains returns whether the rectangle contains the cell at the level whose minimum grid coordinates are x and y.
+func (r gridRect) contains(x, y uint64, level int) bool {
+	size := uint64(1)<<(MaxCellLevel-level) - 1
+	return r.minX <= x && x+size <= r.maxX && r.minY <= y && y+size <= r.maxY
+}
+
+type gridCell struct {
+	x, y  uint64
+	level int
+}
+
+// CoveringRanges returns the ranges of the ids of all the cells intersecting the rectangle, so the indexed
+// geometries whose MBR intersects the rectangle are in the ranges. The rectangle is covered by at most
+// maxCells cells, which starts from the cells at the same level and refines the cells partially covered by
+// the rectangle like the region coverer of S2. The descendants of the covering cells and their ancestors
+// are returned.
+func CoveringRanges(m MBR, maxCells int) []CellRange {
+	r := m.gridRect()
+	level := MaxCellLevel
+	for ; level > 0; level-- {
+		shift := MaxCellLevel - level
+		cnt := (r.maxX>>shift - r.minX>>shift + 1) * (r.maxY>>shift - r.minY>>shift + 1)
+		if cnt <= uint64(maxCells) {
+			break
+		}
+	}
+	shift := MaxCellLevel - level
+	var queue, covering []gridCell
+	for x := r.minX >> shift; x <= r.maxX>>shift; x++ {
+		for y := r.minY >> shift; y <= r.maxY>>shift; y++ {
+			queue = append(queue, gridCell{x: x << shift, y: y << shift, level: level})
+		}
+	}
+	// The coarser cells are refined first since the queue is in the order of the levels.
+	cnt := len(queue)
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		if c.level == MaxCellLevel || r.contains(c.x, c.y, c.level) {
+			covering = append(covering, c)
+			continue
+		}
+		half := uint64(1) << (MaxCellLevel - c.level - 1)
+		var children []gridCell
+		for _, x := range []uint64{c.x, c.x + half} {
+			for _, y := range []uint64{c.y, c.y + half} {
+				if x <= r.maxX && r.minX <= x+half-1 && y <= r.maxY && r.minY <= y+half-1 {
+					children = append(children, gridCell{x: x, y: y, level: c.level + 1})
+				}
+			}
+		}
+		if cnt-1+len(children) > maxCells {
+			covering = append(covering, c)
+			continue
+		}
+		cnt += len(children) - 1
+		queue = append(queue, children...)
+	}
+
+	ranges := make([]CellRange, 0, len(covering))
+	ancestors := make(map[CellID]struct{})
+	for _, gc := range covering {
+		c := newCellID(gc.x, gc.y, gc.level)
+		ranges = append(ranges, CellRange{Min: c.RangeMin(), Max: c.RangeMax()})
+		for l := 0; l < gc.level; l++ {
+			ancestors[c.Parent(l)] = struct{}{}
+		}
+	}
+	for c := range ancestors {
+		ranges = append(ranges, CellRange{Min: c, Max: c})
+	}
+	sort.Slice(ranges, func(i, j int) bool {
+		return ranges[i].Min < ranges[j].Min
+	})
+	merged := ranges[:1]
+	for _, rg := range ranges[1:] {
+		last := &merged[len(merged)-1]
+		if rg.Min <= last.Max+1 {
+			if rg.Max > last.Max {
+				last.Max = rg.Max
+			}
+			continue
+		}
+		merged = append(merged, rg)
+	}
+	return merged
+}
@@ -0,0 +1,297 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// Point is a coordinate of the cartesian plane.
+type Point struct {
+	X, Y float64
+}
+
+// Geometry is a geometry value of the spatial types.
+type Geometry struct {
+	// Tp is the geometry type, like mysql.GeometryTypePoint.
+	Tp byte
+	// Points are the coordinates of a Point or a LineString.
+	Points []Point
+	// Rings are the exterior ring and the interior rings of a Polygon.
+	Rings [][]Point
+	// Geoms are the elements of a MultiPoint, MultiLineString, MultiPolygon or GeometryCollection.
+	Geoms []*Geometry
+}
+
+// IsEmpty returns whether the geometry has no point, which is only possible for the empty collections.
+func (g *Geometry) IsEmpty() bool {
+	switch g.Tp {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString, mysql.GeometryTypePolygon:
+		return false
+	}
+	for _, sub := range g.Geoms {
+		if !sub.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// MBR is the minimum bounding rectangle of a geometry.
+type MBR struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// Intersects returns whether the two rectangles share at least one point.
+func (m MBR) Intersects(o MBR) bool {
+	return m.MinX <= o.MaxX && o.MinX <= m.MaxX && m.MinY <= o.MaxY && o.MinY <= m.MaxY
+}
+
+func (m *MBR) extend(p Point) {
+	m.MinX, m.MaxX = math.Min(m.MinX, p.X), math.Max(m.MaxX, p.X)
+	m.MinY, m.MaxY = math.Min(m.MinY, p.Y), math.Max(m.MaxY, p.Y)
+}
+
+// MBR returns the minimum bounding rectangle of the geometry, ok is false if the geometry is empty.
+func (g *Geometry) MBR() (m MBR, ok bool) {
+	m = MBR{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+	g.walkPoints(func(p Point) {
+		m.extend(p)
+		ok = true
+	})
+	return m, ok
+}
+
+func (g *Geometry) walkPoints(fn func(Point)) {
+	for _, p := range g.Points {
+		fn(p)
+	}
+	for _, ring := range g.Rings {
+		for _, p := range ring {
+			fn(p)
+		}
+	}
+	for _, sub := range g.Geoms {
+		sub.walkPoints(fn)
+	}
+}
+
+const (
+	wkbBigEndian    = 0
+	wkbLittleEndian = 1
+	// sridLen is the length of the SRID prefixed to the WKB of the stored geometry values.
+	sridLen = 4
+)
+
+var errInvalidWKB = errors.New("invalid WKB")
+
+// Encode encodes the geometry to the storage format of the spatial types, which is the same as MySQL:
+// a 4-byte little-endian SRID followed by the little-endian WKB of the geometry.
+func Encode(srid uint32, g *Geometry) []byte {
+	buf := make([]byte, sridLen, 32)
+	binary.LittleEndian.PutUint32(buf, srid)
+	return appendWKB(buf, g)
+}
+
+// Decode decodes the geometry from the storage format of the spatial types.
+func Decode(b []byte) (srid uint32, g *Geometry, err error) {
+	if len(b) < sridLen {
+		return 0, nil, errInvalidWKB
+	}
+	g, err = DecodeWKB(b[sridLen:])
+	return binary.LittleEndian.Uint32(b), g, err
+}
+
+// EncodeWKB encodes the geometry to the little-endian WKB.
+func EncodeWKB(g *Geometry) []byte {
+	return appendWKB(nil, g)
+}
+
+func appendWKB(buf []byte, g *Geometry) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = appendUint32(buf, uint32(g.Tp))
+	switch g.Tp {
+	case mysql.GeometryTypePoint:
+		buf = appendPoints(buf, g.Points)
+	case mysql.GeometryTypeLineString:
+		buf = appendUint32(buf, uint32(len(g.Points)))
+		buf = appendPoints(buf, g.Points)
+	case mysql.GeometryTypePolygon:
+		buf = appendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendUint32(buf, uint32(len(ring)))
+			buf = appendPoints(buf, ring)
+		}
+	default:
+		buf = appendUint32(buf, uint32(len(g.Geoms)))
+		for _, sub := range g.Geoms {
+			buf = appendWKB(buf, sub)
+		}
+	}
+	return buf
+}
+
+func appendPoints(buf []byte, points []Point) []byte {
+	for _, p := range points {
+		buf = appendUint64(buf, math.Float64bits(p.X))
+		buf = appendUint64(buf, math.Float64bits(p.Y))
+	}
+	return buf
+}
+
+func appendUint32(buf []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(buf, b[:]...)
+}
+
+func appendUint64(buf []byte, v uint64) []byte {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], v)
+	return append(buf, b[:]...)
+}
+
+// DecodeWKB decodes the geometry from the WKB in either byte order.
+func DecodeWKB(b []byte) (*Geometry, error) {
+	d := &wkbDecoder{b: b}
+	g := d.geometry(0)
+	if d.err == nil && len(d.b) > 0 {
+		d.err = errInvalidWKB
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	return g, nil
+}
+
+// maxWKBDepth limits the nesting of the geometry collections.
+const maxWKBDepth = 32
+
+type wkbDecoder struct {
+	b     []byte
+	order binary.ByteOrder
+	err   error
+}
+
+func (d *wkbDecoder) uint32() uint32 {
+	if d.err != nil || len(d.b) < 4 {
+		d.err = errInvalidWKB
+		return 0
+	}
+	v := d.order.Uint32(d.b)
+	d.b = d.b[4:]
+	return v
+}
+
+func (d *wkbDecoder) points(n uint32) []Point {
+	if d.err != nil || uint64(len(d.b)) < uint64(n)*16 {
+		d.err = errInvalidWKB
+		return nil
+	}
+	points := make([]Point, n)
+	for i := range points {
+		points[i].X = math.Float64frombits(d.order.Uint64(d.b))
+		points[i].Y = math.Float64frombits(d.order.Uint64(d.b[8:]))
+		d.b = d.b[16:]
+		if math.IsNaN(points[i].X) || math.IsInf(points[i].X, 0) || math.IsNaN(points[i].Y) || math.IsInf(points[i].Y, 0) {
+			d.err = errInvalidWKB
+			return nil
+		}
+	}
+	return points
+}
+
+func (d *wkbDecoder) geometry(depth int) *Geometry {
+	if d.err != nil || len(d.b) < 1 || depth > maxWKBDepth {
+		d.err = errInvalidWKB
+		return nil
+	}
+	switch d.b[0] {
+	case wkbBigEndian:
+		d.order = binary.BigEndian
+	case wkbLittleEndian:
+		d.order = binary.LittleEndian
+	default:
+		d.err = errInvalidWKB
+		return nil
+	}
+	d.b = d.b[1:]
+	tp := d.uint32()
+	g := &Geometry{Tp: byte(tp)}
+	switch tp {
+	case uint32(mysql.GeometryTypePoint):
+		g.Points = d.points(1)
+	case uint32(mysql.GeometryTypeLineString):
+		g.Points = d.points(d.uint32())
+	case uint32(mysql.GeometryTypePolygon):
+		n := d.uint32()
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			g.Rings = append(g.Rings, d.points(d.uint32()))
+		}
+	case uint32(mysql.GeometryTypeMultiPoint), uint32(mysql.GeometryTypeMultiLineString),
+		uint32(mysql.GeometryTypeMultiPolygon), uint32(mysql.GeometryTypeGeometryCollection):
+		n := d.uint32()
+		for i := uint32(0); i < n && d.err == nil; i++ {
+			sub := d.geometry(depth + 1)
+			if d.err == nil && !isValidElement(g.Tp, sub.Tp) {
+				d.err = errInvalidWKB
+			}
+			g.Geoms = append(g.Geoms, sub)
+		}
+	default:
+		d.err = errInvalidWKB
+	}
+	if d.err == nil && !g.isValid() {
+		d.err = errInvalidWKB
+	}
+	return g
+}
+
+// isValidElement returns whether the geometry of the type can be an element of the collection.
+func isValidElement(collection, element byte) bool {
+	switch collection {
+	case mysql.GeometryTypeMultiPoint:
+		return element == mysql.GeometryTypePoint
+	case mysql.GeometryTypeMultiLineString:
+		return element == mysql.GeometryTypeLineString
+	case mysql.GeometryTypeMultiPolygon:
+		return element == mysql.GeometryTypePolygon
+	}
+	return true
+}
+
+// isValid checks the number of points of the line strings and the polygon rings.
+func (g *Geometry) isValid() bool {
+	switch g.Tp {
+	case mysql.GeometryTypeLineString:
+		return len(g.Points) >= 2
+	case mysql.GeometryTypePolygon:
+		if len(g.Rings) == 0 {
+			return false
+		}
+		for _, ring := range g.Rings {
+			if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+				return false
+			}
+		}
+	case mysql.GeometryTypeMultiPoint, mysql.GeometryTypeMultiLineString, mysql.GeometryTypeMultiPolygon:
+		return len(g.Geoms) > 0
+	}
+	return true
+}
@@ -0,0 +1,368 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"sort"
+
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// The relations of the geometries are computed on the cartesian plane.
+
+type segment struct {
+	a, b Point
+}
+
+// parts is a geometry flattened into its points, line segments and polygons.
+type parts struct {
+	points   []Point
+	segments []segment
+	// endpoints are the boundary points of the line strings.
+	endpoints []Point
+	polygons  [][][]Point
+}
+
+func (g *Geometry) parts() *parts {
+	ps := &parts{}
+	ps.add(g)
+	return ps
+}
+
+func (ps *parts) add(g *Geometry) {
+	switch g.Tp {
+	case mysql.GeometryTypePoint:
+		ps.points = append(ps.points, g.Points[0])
+	case mysql.GeometryTypeLineString:
+		for i := 1; i < len(g.Points); i++ {
+			ps.segments = append(ps.segments, segment{g.Points[i-1], g.Points[i]})
+		}
+		if first, last := g.Points[0], g.Points[len(g.Points)-1]; first != last {
+			ps.endpoints = append(ps.endpoints, first, last)
+		}
+	case mysql.GeometryTypePolygon:
+		ps.polygons = append(ps.polygons, g.Rings)
+	default:
+		for _, sub := range g.Geoms {
+			ps.add(sub)
+		}
+	}
+}
+
+// boundary returns the edges of all the polygon rings.
+func (ps *parts) boundary() []segment {
+	var edges []segment
+	for _, rings := range ps.polygons {
+		for _, ring := range rings {
+			for i := 1; i < len(ring); i++ {
+				edges = append(edges, segment{ring[i-1], ring[i]})
+			}
+		}
+	}
+	return edges
+}
+
+// allSegments returns the segments of the line strings and the edges of the polygon rings.
+func (ps *parts) allSegments() []segment {
+	return append(append([]segment(nil), ps.segments...), ps.boundary()...)
+}
+
+func cross(o, a, b Point) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+// tolerance is the relative error tolerated when testing whether a computed point is on a segment.
+const tolerance = 1e-12
+
+func onSegment(p Point, s segment) bool {
+	if cross(s.a, s.b, p) == 0 {
+		return math.Min(s.a.X, s.b.X) <= p.X && p.X <= math.Max(s.a.X, s.b.X) &&
+			math.Min(s.a.Y, s.b.Y) <= p.Y && p.Y <= math.Max(s.a.Y, s.b.Y)
+	}
+	scale := math.Max(math.Max(math.Abs(s.a.X), math.Abs(s.a.Y)), math.Max(math.Abs(s.b.X), math.Abs(s.b.Y)))
+	return pointSegmentDistance(p, s) <= tolerance*(1+scale)
+}
+
+func segmentsIntersect(s, t segment) bool {
+	d1, d2 := cross(t.a, t.b, s.a), cross(t.a, t.b, s.b)
+	d3, d4 := cross(s.a, s.b, t.a), cross(s.a, s.b, t.b)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return onSegment(s.a, t) || onSegment(s.b, t) || onSegment(t.a, s) || onSegment(t.b, s)
+}
+
+const (
+	outside = iota
+	onBoundary
+	inside
+)
+
+// locateInPolygon locates the point in the polygon, the points in the holes are outside.
+func locateInPolygon(p Point, rings [][]Point) int {
+	in := false
+	for _, ring := range rings {
+		for i := 1; i < len(ring); i++ {
+			a, b := ring[i-1], ring[i]
+			if onSegment(p, segment{a, b}) {
+				return onBoundary
+			}
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+				in = !in
+			}
+		}
+	}
+	if in {
+		return inside
+	}
+	return outside
+}
+
+// locate locates the point in the geometry parts, it returns the maximum location of all the polygons.
+func (ps *parts) locate(p Point) int {
+	loc := outside
+	for _, rings := range ps.polygons {
+		if l := locateInPolygon(p, rings); l > loc {
+			loc = l
+		}
+	}
+	return loc
+}
+
+// covers returns whether the point is a point of the geometry parts.
+func (ps *parts) covers(p Point) bool {
+	for _, q := range ps.points {
+		if p == q {
+			return true
+		}
+	}
+	for _, s := range ps.segments {
+		if onSegment(p, s) {
+			return true
+		}
+	}
+	return ps.locate(p) != outside
+}
+
+// interiorCovers returns whether the point is in the interior of the geometry parts. The interior is
+// determined by the parts of the highest dimension.
+func (ps *parts) interiorCovers(p Point) bool {
+	if len(ps.polygons) > 0 {
+		return ps.locate(p) == inside
+	}
+	if len(ps.segments) > 0 {
+		for _, e := range ps.endpoints {
+			if p == e {
+				return false
+			}
+		}
+		for _, s := range ps.segments {
+			if onSegment(p, s) {
+				return true
+			}
+		}
+		return false
+	}
+	for _, q := range ps.points {
+		if p == q {
+			return true
+		}
+	}
+	return false
+}
+
+// splitSegment splits the segment at its intersections with the segments, then every piece is either inside
+// or outside of a geometry bounded by the segments. It returns the endpoints and the midpoints of the pieces,
+// the split points are omitted since they are on the segments.
+func splitSegment(s segment, segments []segment) []Point {
+	ts := []float64{0, 1}
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	param := func(p Point) float64 {
+		if math.Abs(dx) >= math.Abs(dy) {
+			return (p.X - s.a.X) / dx
+		}
+		return (p.Y - s.a.Y) / dy
+	}
+	for _, t := range segments {
+		if !segmentsIntersect(s, t) {
+			continue
+		}
+		if cross(s.a, s.b, t.a) == 0 && cross(s.a, s.b, t.b) == 0 {
+			// The collinear segments split s at the endpoints of t.
+			ts = append(ts, param(t.a), param(t.b))
+			continue
+		}
+		d1, d2 := cross(t.a, t.b, s.a), cross(t.a, t.b, s.b)
+		ts = append(ts, d1/(d1-d2))
+	}
+	sort.Float64s(ts)
+	points := make([]Point, 0, len(ts)+1)
+	points = append(points, s.a, s.b)
+	for i := 1; i < len(ts); i++ {
+		lo, hi := math.Max(ts[i-1], 0), math.Min(ts[i], 1)
+		if lo < hi {
+			mid := (lo + hi) / 2
+			points = append(points, Point{s.a.X + mid*dx, s.a.Y + mid*dy})
+		}
+	}
+	return points
+}
+
+// samples returns the points which represent the geometry parts when testing whether they are covered
+// by the other geometry parts, the line segments are split at their intersections with the other.
+func (ps *parts) samples(other *parts) []Point {
+	splitters := other.allSegments()
+	points := append([]Point(nil), ps.points...)
+	for _, s := range ps.allSegments() {
+		if s.a == s.b {
+			points = append(points, s.a)
+			continue
+		}
+		points = append(points, splitSegment(s, splitters)...)
+	}
+	return points
+}
+
+// Intersects returns whether the two geometries share at least one point.
+func Intersects(g1, g2 *Geometry) bool {
+	m1, ok1 := g1.MBR()
+	m2, ok2 := g2.MBR()
+	if !ok1 || !ok2 || !m1.Intersects(m2) {
+		return false
+	}
+	return g1.parts().intersects(g2.parts())
+}
+
+func (ps *parts) intersects(other *parts) bool {
+	for _, p := range ps.points {
+		if other.covers(p) {
+			return true
+		}
+	}
+	for _, p := range other.points {
+		if ps.covers(p) {
+			return true
+		}
+	}
+	segs, otherSegs := ps.allSegments(), other.allSegments()
+	for _, s := range segs {
+		for _, t := range otherSegs {
+			if segmentsIntersect(s, t) {
+				return true
+			}
+		}
+	}
+	// Without crossing boundaries, a geometry intersects a polygon only if it's contained by the polygon.
+	for _, s := range segs {
+		if other.locate(s.a) != outside {
+			return true
+		}
+	}
+	for _, t := range otherSegs {
+		if ps.locate(t.a) != outside {
+			return true
+		}
+	}
+	return false
+}
+
+// Within returns whether g1 is spatially within g2, which means that no point of g1 is outside of g2,
+// and at least one point of the interior of g1 is in the interior of g2.
+func Within(g1, g2 *Geometry) bool {
+	m1, ok1 := g1.MBR()
+	m2, ok2 := g2.MBR()
+	if !ok1 || !ok2 || m1.MinX < m2.MinX || m1.MinY < m2.MinY || m1.MaxX > m2.MaxX || m1.MaxY > m2.MaxY {
+		return false
+	}
+	ps1, ps2 := g1.parts(), g2.parts()
+	// A polygon can't be covered by the geometries without area.
+	if len(ps1.polygons) > 0 && len(ps2.polygons) == 0 {
+		return false
+	}
+	samples := ps1.samples(ps2)
+	for _, p := range samples {
+		if !ps2.covers(p) {
+			return false
+		}
+	}
+	// The boundary of g2 passing through the interior of a polygon of g1 means a part of the polygon is out of g2.
+	for _, t := range ps2.boundary() {
+		if ps1.locate(t.a) == inside {
+			return false
+		}
+	}
+	if len(ps1.polygons) > 0 {
+		return true
+	}
+	for _, p := range samples {
+		if ps2.interiorCovers(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Contains returns whether g1 spatially contains g2, which is the same as g2 is within g1.
+func Contains(g1, g2 *Geometry) bool {
+	return Within(g2, g1)
+}
+
+func distance(p, q Point) float64 {
+	return math.Hypot(p.X-q.X, p.Y-q.Y)
+}
+
+func pointSegmentDistance(p Point, s segment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	if dx == 0 && dy == 0 {
+		return distance(p, s.a)
+	}
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return distance(p, Point{s.a.X + t*dx, s.a.Y + t*dy})
+}
+
+// Distance returns the minimum cartesian distance between the two geometries, ok is false if any geometry is empty.
+func Distance(g1, g2 *Geometry) (d float64, ok bool) {
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, false
+	}
+	ps1, ps2 := g1.parts(), g2.parts()
+	if ps1.intersects(ps2) {
+		return 0, true
+	}
+	d = math.Inf(1)
+	segs1, segs2 := ps1.allSegments(), ps2.allSegments()
+	for _, p := range ps1.points {
+		for _, q := range ps2.points {
+			d = math.Min(d, distance(p, q))
+		}
+		for _, t := range segs2 {
+			d = math.Min(d, pointSegmentDistance(p, t))
+		}
+	}
+	for _, s := range segs1 {
+		for _, q := range ps2.points {
+			d = math.Min(d, pointSegmentDistance(q, s))
+		}
+		// The segments don't intersect, so the distance is between an endpoint and the other segment.
+		for _, t := range segs2 {
+			d = math.Min(d, math.Min(
+				math.Min(pointSegmentDistance(s.a, t), pointSegmentDistance(s.b, t)),
+				math.Min(pointSegmentDistance(t.a, s), pointSegmentDistance(t.b, s))))
+		}
+	}
+	return d, true
+}
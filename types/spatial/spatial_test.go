@@ -0,0 +1,193 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseWKT(t *testing.T, wkt string) *Geometry {
+	g, err := ParseWKT(wkt)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestWKT(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		wkt      string
+		expected string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,3 2,3 3,2 2))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,3 2,3 3,2 2))"},
+		{"MULTIPOINT(0 0, 1 1)", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTIPOINT((0 0), (1 1))", "MULTIPOINT((0 0),(1 1))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+		{"GEOMETRYCOLLECTION()", "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		g := mustParseWKT(t, tt.wkt)
+		require.Equal(t, tt.expected, g.WKT())
+
+		b := Encode(4326, g)
+		srid, decoded, err := Decode(b)
+		require.NoError(t, err)
+		require.Equal(t, uint32(4326), srid)
+		require.Equal(t, tt.expected, decoded.WKT())
+	}
+
+	for _, wkt := range []string{
+		"", "POINT", "POINT(1)", "POINT(1 2", "POINT(1 2) x", "LINESTRING(0 0)", "POLYGON((0 0,1 0,1 1))",
+		"POLYGON((0 0,1 0,1 1,0 1))", "MULTIPOINT()", "CIRCLE(0 0)", "POINT(1e400 0)",
+	} {
+		_, err := ParseWKT(wkt)
+		require.Error(t, err, wkt)
+	}
+}
+
+func TestWKB(t *testing.T) {
+	t.Parallel()
+	// POINT(1 2) in the big-endian WKB.
+	b := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	g, err := DecodeWKB(b)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	require.Equal(t, []byte{1, 1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f, 0, 0, 0, 0, 0, 0, 0, 0x40}, EncodeWKB(g))
+
+	for _, b := range [][]byte{nil, {2}, b[:20], append(b, 0), {1, 9, 0, 0, 0}} {
+		_, err = DecodeWKB(b)
+		require.Error(t, err)
+	}
+	_, _, err = Decode([]byte{0, 0})
+	require.Error(t, err)
+}
+
+func TestRelations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		g1, g2     string
+		intersects bool
+		within     bool
+		distance   float64
+	}{
+		{"POINT(1 1)", "POINT(1 1)", true, true, 0},
+		{"POINT(0 0)", "POINT(3 4)", false, false, 5},
+		{"POINT(5 5)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, true, 0},
+		{"POINT(0 5)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, false, 0},
+		{"POINT(15 10)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", false, false, 5},
+		{"POINT(3 3)", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,4 2,4 4,2 4,2 2))", false, false, 1},
+		{"LINESTRING(1 1,9 9)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, true, 0},
+		{"LINESTRING(0 0,10 0)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, false, 0},
+		{"LINESTRING(5 5,15 5)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, false, 0},
+		{"LINESTRING(0 0,2 2)", "LINESTRING(0 2,2 0)", true, false, 0},
+		{"LINESTRING(0 0,1 1)", "LINESTRING(0 0,2 2)", true, true, 0},
+		{"POLYGON((1 1,2 1,2 2,1 1))", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, true, 0},
+		{"POLYGON((1 1,12 1,12 2,1 1))", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, false, 0},
+		{"POLYGON((20 0,30 0,30 10,20 0))", "POLYGON((0 0,10 0,10 10,0 10,0 0))", false, false, 10},
+		{"POLYGON((0 0,10 0,10 10,0 10,0 0))", "POLYGON((0 0,10 0,10 10,0 10,0 0),(2 2,4 2,4 4,2 4,2 2))", true, false, 0},
+		{"MULTIPOINT(1 1,20 20)", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, false, 0},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(2 2,3 3))", "POLYGON((0 0,10 0,10 10,0 10,0 0))", true, true, 0},
+	}
+	for _, tt := range tests {
+		g1, g2 := mustParseWKT(t, tt.g1), mustParseWKT(t, tt.g2)
+		require.Equal(t, tt.intersects, Intersects(g1, g2), "%s intersects %s", tt.g1, tt.g2)
+		require.Equal(t, tt.intersects, Intersects(g2, g1), "%s intersects %s", tt.g2, tt.g1)
+		require.Equal(t, tt.within, Within(g1, g2), "%s within %s", tt.g1, tt.g2)
+		require.Equal(t, tt.within, Contains(g2, g1), "%s contains %s", tt.g2, tt.g1)
+		d, ok := Distance(g1, g2)
+		require.True(t, ok)
+		require.InDelta(t, tt.distance, d, 1e-9, "distance of %s and %s", tt.g1, tt.g2)
+	}
+
+	empty := mustParseWKT(t, "GEOMETRYCOLLECTION EMPTY")
+	pt := mustParseWKT(t, "POINT(0 0)")
+	require.False(t, Intersects(empty, pt))
+	require.False(t, Within(empty, pt))
+	_, ok := Distance(empty, pt)
+	require.False(t, ok)
+}
+
+func TestCellID(t *testing.T) {
+	t.Parallel()
+	for _, f := range [][2]float64{{-1e300, -1}, {-1, -0.5}, {-0.5, 0}, {0, 1e-300}, {1, 2}, {2, 1e300}} {
+		require.Less(t, gridCoord(f[0]), gridCoord(f[1]))
+	}
+
+	c := CellIDForMBR(MBR{MinX: 1, MinY: 1, MaxX: 1, MaxY: 1})
+	require.Equal(t, MaxCellLevel, c.Level())
+	require.Equal(t, c, c.RangeMin())
+	require.Equal(t, c, c.RangeMax())
+
+	c = CellIDForMBR(MBR{MinX: -1, MinY: -1, MaxX: 1, MaxY: 1})
+	require.Equal(t, RootCellID, c)
+	require.Equal(t, 0, c.Level())
+
+	c = CellIDForMBR(MBR{MinX: 1, MinY: 1, MaxX: 1.5, MaxY: 1.5})
+	level := c.Level()
+	require.Greater(t, level, 0)
+	require.Less(t, level, MaxCellLevel)
+	parent := c.Parent(level - 1)
+	require.Equal(t, level-1, parent.Level())
+	require.True(t, parent.RangeMin() <= c.RangeMin() && c.RangeMax() <= parent.RangeMax())
+	require.Equal(t, c, c.Parent(level))
+}
+
+func TestCoveringRanges(t *testing.T) {
+	t.Parallel()
+	inRanges := func(ranges []CellRange, c CellID) bool {
+		for _, r := range ranges {
+			if r.Min <= c && c <= r.Max {
+				return true
+			}
+		}
+		return false
+	}
+	query := MBR{MinX: 0, MinY: 0, MaxX: 10, MaxY: 10}
+	ranges := CoveringRanges(query, 8)
+	require.NotEmpty(t, ranges)
+	for i := 1; i < len(ranges); i++ {
+		require.Less(t, uint64(ranges[i-1].Max)+1, uint64(ranges[i].Min))
+	}
+
+	// The cells of the geometries intersecting the query rectangle must be in the ranges.
+	for _, wkt := range []string{
+		"POINT(0 0)", "POINT(10 10)", "POINT(5 3)", "LINESTRING(-5 -5,5 5)", "POLYGON((-100 -100,100 -100,100 100,-100 -100))",
+		"LINESTRING(9 9,1e10 1e10)",
+	} {
+		m, ok := mustParseWKT(t, wkt).MBR()
+		require.True(t, ok)
+		require.True(t, inRanges(ranges, CellIDForMBR(m)), wkt)
+	}
+	// The small geometries far away from the query rectangle are filtered out.
+	for _, wkt := range []string{"POINT(1e100 1e100)", "POINT(-3 -3)", "LINESTRING(1e50 1e50,1e51 1e51)"} {
+		m, ok := mustParseWKT(t, wkt).MBR()
+		require.True(t, ok)
+		require.False(t, inRanges(ranges, CellIDForMBR(m)), wkt)
+	}
+
+	ranges = CoveringRanges(MBR{MinX: -1e300, MinY: -1e300, MaxX: 1e300, MaxY: 1e300}, 8)
+	for _, wkt := range []string{"POINT(0 0)", "POINT(-1e300 1e300)", "LINESTRING(-1 -1,1 1)"} {
+		m, ok := mustParseWKT(t, wkt).MBR()
+		require.True(t, ok)
+		require.True(t, inRanges(ranges, CellIDForMBR(m)), wkt)
+	}
+}
@@ -0,0 +1,294 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spatial
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
+)
+
+var errInvalidWKT = errors.New("invalid WKT")
+
+var wktTypes = map[string]byte{
+	"POINT":              mysql.GeometryTypePoint,
+	"LINESTRING":         mysql.GeometryTypeLineString,
+	"POLYGON":            mysql.GeometryTypePolygon,
+	"MULTIPOINT":         mysql.GeometryTypeMultiPoint,
+	"MULTILINESTRING":    mysql.GeometryTypeMultiLineString,
+	"MULTIPOLYGON":       mysql.GeometryTypeMultiPolygon,
+	"GEOMETRYCOLLECTION": mysql.GeometryTypeGeometryCollection,
+}
+
+// ParseWKT parses the geometry from the well-known text, like `POINT(1 2)`.
+func ParseWKT(wkt string) (*Geometry, error) {
+	p := &wktParser{s: wkt}
+	g := p.geometry(0)
+	p.skipSpaces()
+	if p.err == nil && p.pos < len(p.s) {
+		p.err = errInvalidWKT
+	}
+	if p.err != nil {
+		return nil, p.err
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+	err error
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && strings.IndexByte(" \t\n\r", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// peek returns the next non-space character, or 0 at the end of the text.
+func (p *wktParser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *wktParser) expect(c byte) {
+	if p.peek() != c {
+		p.err = errInvalidWKT
+		return
+	}
+	p.pos++
+}
+
+func (p *wktParser) word() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToUpper(p.s[start:p.pos])
+}
+
+func (p *wktParser) number() float64 {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("+-.0123456789eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil || math.IsInf(f, 0) {
+		p.err = errInvalidWKT
+	}
+	return f
+}
+
+func (p *wktParser) point() Point {
+	return Point{X: p.number(), Y: p.number()}
+}
+
+// points parses the coordinate list in the parentheses, like `(0 0, 1 1)`.
+func (p *wktParser) points() []Point {
+	var points []Point
+	p.expect('(')
+	for p.err == nil {
+		points = append(points, p.point())
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.expect(')')
+	return points
+}
+
+func (p *wktParser) rings() [][]Point {
+	var rings [][]Point
+	p.expect('(')
+	for p.err == nil {
+		rings = append(rings, p.points())
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.expect(')')
+	return rings
+}
+
+// list parses the comma separated elements in the parentheses.
+func (p *wktParser) list(fn func()) {
+	p.expect('(')
+	for p.err == nil {
+		fn()
+		if p.peek() != ',' {
+			break
+		}
+		p.pos++
+	}
+	p.expect(')')
+}
+
+func (p *wktParser) geometry(depth int) *Geometry {
+	if depth > maxWKBDepth {
+		p.err = errInvalidWKT
+		return nil
+	}
+	tp, ok := wktTypes[p.word()]
+	if !ok {
+		p.err = errInvalidWKT
+		return nil
+	}
+	g := &Geometry{Tp: tp}
+	switch tp {
+	case mysql.GeometryTypePoint:
+		p.expect('(')
+		g.Points = []Point{p.point()}
+		p.expect(')')
+	case mysql.GeometryTypeLineString:
+		g.Points = p.points()
+	case mysql.GeometryTypePolygon:
+		g.Rings = p.rings()
+	case mysql.GeometryTypeMultiPoint:
+		p.list(func() {
+			// Both `MULTIPOINT(0 0, 1 1)` and `MULTIPOINT((0 0), (1 1))` are accepted.
+			var pt Point
+			if p.peek() == '(' {
+				p.pos++
+				pt = p.point()
+				p.expect(')')
+			} else {
+				pt = p.point()
+			}
+			g.Geoms = append(g.Geoms, &Geometry{Tp: mysql.GeometryTypePoint, Points: []Point{pt}})
+		})
+	case mysql.GeometryTypeMultiLineString:
+		p.list(func() {
+			g.Geoms = append(g.Geoms, &Geometry{Tp: mysql.GeometryTypeLineString, Points: p.points()})
+		})
+	case mysql.GeometryTypeMultiPolygon:
+		p.list(func() {
+			g.Geoms = append(g.Geoms, &Geometry{Tp: mysql.GeometryTypePolygon, Rings: p.rings()})
+		})
+	case mysql.GeometryTypeGeometryCollection:
+		// The empty geometry collection is written as `GEOMETRYCOLLECTION EMPTY` or `GEOMETRYCOLLECTION()`.
+		if p.peek() != '(' {
+			if p.word() != "EMPTY" {
+				p.err = errInvalidWKT
+			}
+			return g
+		}
+		if p.pos++; p.peek() == ')' {
+			p.pos++
+			return g
+		}
+		for p.err == nil {
+			g.Geoms = append(g.Geoms, p.geometry(depth+1))
+			if p.peek() != ',' {
+				break
+			}
+			p.pos++
+		}
+		p.expect(')')
+	}
+	if p.err == nil && !g.isValid() {
+		p.err = errInvalidWKT
+	}
+	for _, sub := range g.Geoms {
+		if p.err == nil && !sub.isValid() {
+			p.err = errInvalidWKT
+		}
+	}
+	return g
+}
+
+// WKT returns the well-known text of the geometry in the format of MySQL, like `POLYGON((0 0,1 0,1 1,0 0))`.
+func (g *Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb)
+	return sb.String()
+}
+
+func (g *Geometry) writeWKT(sb *strings.Builder) {
+	sb.WriteString(strings.ToUpper(types.GeometryTypeToStr(g.Tp)))
+	switch g.Tp {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString:
+		writePoints(sb, g.Points)
+	case mysql.GeometryTypePolygon:
+		writeRings(sb, g.Rings)
+	default:
+		if len(g.Geoms) == 0 {
+			sb.WriteString(" EMPTY")
+			return
+		}
+		sb.WriteByte('(')
+		for i, sub := range g.Geoms {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			switch g.Tp {
+			case mysql.GeometryTypeMultiPoint, mysql.GeometryTypeMultiLineString:
+				writePoints(sb, sub.Points)
+			case mysql.GeometryTypeMultiPolygon:
+				writeRings(sb, sub.Rings)
+			default:
+				sub.writeWKT(sb)
+			}
+		}
+		sb.WriteByte(')')
+	}
+}
+
+func writePoints(sb *strings.Builder, points []Point) {
+	sb.WriteByte('(')
+	for i, pt := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(formatCoordinate(pt.X))
+		sb.WriteByte(' ')
+		sb.WriteString(formatCoordinate(pt.Y))
+	}
+	sb.WriteByte(')')
+}
+
+func writeRings(sb *strings.Builder, rings [][]Point) {
+	sb.WriteByte('(')
+	for i, ring := range rings {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writePoints(sb, ring)
+	}
+	sb.WriteByte(')')
+}
+
+// formatCoordinate formats the coordinate in the shortest representation, the exponent is only used
+// for the very large or small numbers.
+func formatCoordinate(f float64) string {
+	if f == 0 {
+		return "0"
+	}
+	if abs := math.Abs(f); abs >= 1e-5 && abs < 1e17 {
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	return strings.Replace(strconv.FormatFloat(f, 'g', -1, 64), "e+", "e", 1)
+}
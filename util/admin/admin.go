@@ -19,6 +19,7 @@ import (
 	"encoding/json"
 	"math"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -111,13 +112,19 @@ func IsJobRollbackable(job *model.Job) bool {
 		}
 	case model.ActionAddTablePartition:
 		return job.SchemaState == model.StateNone || job.SchemaState == model.StateReplicaOnly
+	case model.ActionReorganizePartition, model.ActionAlterTablePartitioning, model.ActionRemovePartitioning:
+		// The partition definitions are already switched in StateDeleteReorganization.
+		return job.SchemaState != model.StateDeleteReorganization
+	case model.ActionMultiSchemaChange:
+		return job.MultiSchemaInfo != nil && job.MultiSchemaInfo.Revertible
 	case model.ActionDropColumn, model.ActionDropColumns, model.ActionDropTablePartition,
 		model.ActionRebaseAutoID, model.ActionShardRowID,
 		model.ActionTruncateTable, model.ActionAddForeignKey,
 		model.ActionDropForeignKey, model.ActionRenameTable,
 		model.ActionModifyTableCharsetAndCollate, model.ActionTruncateTablePartition,
 		model.ActionModifySchemaCharsetAndCollate, model.ActionRepairTable,
-		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement:
+		model.ActionModifyTableAutoIdCache, model.ActionModifySchemaDefaultPlacement,
+		model.ActionDropCheckConstraint, model.ActionAlterCheckConstraint:
 		return job.SchemaState == model.StateNone
 	}
 	return true
@@ -125,7 +132,8 @@ func IsJobRollbackable(job *model.Job) bool {
 
 // MayNeedBackfill returns whether the action type may need to backfill the data.
 func MayNeedBackfill(tp model.ActionType) bool {
-	return tp == model.ActionAddIndex || tp == model.ActionAddPrimaryKey || tp == model.ActionModifyColumn
+	return tp == model.ActionAddIndex || tp == model.ActionAddPrimaryKey || tp == model.ActionModifyColumn ||
+		tp == model.ActionMultiSchemaChange
 }
 
 // CancelJobs cancels the DDL jobs.
@@ -320,15 +328,23 @@ const (
 // It returns the count greater type, the index offset and an error.
 // It returns nil if the count from the index is equal to the count from the table columns,
 // otherwise it returns an error and the corresponding index's offset.
-func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices []string) (byte, int, error) {
+// Only the given partitions are counted if partitions is not empty.
+func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, partitions []string, indices []string) (byte, int, error) {
 	// Here we need check all indexes, includes invisible index
 	ctx.GetSessionVars().OptimizerUseInvisibleIndexes = true
 	defer func() {
 		ctx.GetSessionVars().OptimizerUseInvisibleIndexes = false
 	}()
 	// Add `` for some names like `table name`.
+	tblSQL, tblArgs := "%n.%n", []interface{}{dbName, tableName}
+	if len(partitions) > 0 {
+		tblSQL += " PARTITION(%n" + strings.Repeat(", %n", len(partitions)-1) + ")"
+		for _, p := range partitions {
+			tblArgs = append(tblArgs, p)
+		}
+	}
 	exec := ctx.(sqlexec.RestrictedSQLExecutor)
-	stmt, err := exec.ParseWithParams(context.Background(), "SELECT COUNT(*) FROM %n.%n USE INDEX()", dbName, tableName)
+	stmt, err := exec.ParseWithParams(context.Background(), "SELECT COUNT(*) FROM "+tblSQL+" USE INDEX()", tblArgs...)
 	if err != nil {
 		return 0, 0, errors.Trace(err)
 	}
@@ -350,7 +366,7 @@ func CheckIndicesCount(ctx sessionctx.Context, dbName, tableName string, indices
 		return 0, 0, errors.Trace(err)
 	}
 	for i, idx := range indices {
-		stmt, err := exec.ParseWithParams(context.Background(), "SELECT COUNT(*) FROM %n.%n USE INDEX(%n)", dbName, tableName, idx)
+		stmt, err := exec.ParseWithParams(context.Background(), "SELECT COUNT(*) FROM "+tblSQL+" USE INDEX(%n)", append(tblArgs, idx)...)
 		if err != nil {
 			return 0, i, errors.Trace(err)
 		}
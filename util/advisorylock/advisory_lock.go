@@ -0,0 +1,166 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisorylock
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+)
+
+const (
+	// ColLockNameStr is the name of the LOCK_NAME column in INFORMATION_SCHEMA.ADVISORY_LOCKS and INFORMATION_SCHEMA.CLUSTER_ADVISORY_LOCKS table.
+	ColLockNameStr = "LOCK_NAME"
+	// ColSessionIDStr is the name of the SESSION_ID column in INFORMATION_SCHEMA.ADVISORY_LOCKS and INFORMATION_SCHEMA.CLUSTER_ADVISORY_LOCKS table.
+	ColSessionIDStr = "SESSION_ID"
+	// ColStateStr is the name of the STATE column in INFORMATION_SCHEMA.ADVISORY_LOCKS and INFORMATION_SCHEMA.CLUSTER_ADVISORY_LOCKS table.
+	ColStateStr = "STATE"
+	// ColStartTimeStr is the name of the START_TIME column in INFORMATION_SCHEMA.ADVISORY_LOCKS and INFORMATION_SCHEMA.CLUSTER_ADVISORY_LOCKS table.
+	ColStartTimeStr = "START_TIME"
+)
+
+const (
+	// StateGranted means the lock is held by the session.
+	StateGranted = "GRANTED"
+	// StatePending means the session is waiting for the lock.
+	StatePending = "PENDING"
+)
+
+// LockRecord represents an advisory lock held or waited for by a session of this instance.
+type LockRecord struct {
+	Name      string
+	SessionID uint64
+	State     string
+	// StartTime is the time when the lock was granted, or when the session began to wait for it.
+	StartTime time.Time
+}
+
+// ToDatums converts the record to a row of INFORMATION_SCHEMA.ADVISORY_LOCKS table.
+func (r *LockRecord) ToDatums() []types.Datum {
+	return types.MakeDatums(
+		r.Name,
+		r.SessionID,
+		r.State,
+		types.NewTime(types.FromGoTime(r.StartTime), mysql.TypeTimestamp, types.MaxFsp),
+	)
+}
+
+// Registry records the advisory locks held or waited for by the sessions of this instance. The locks themselves
+// are kept in the storage, the registry only serves the lookups of the lock holders and the detection of the
+// deadlocks among the local sessions. All its public APIs are thread safe.
+type Registry struct {
+	mu sync.Mutex
+	// granted maps the lock names to the records of the sessions holding them.
+	granted map[string]*LockRecord
+	// pending maps the session IDs to the records of the locks they are waiting for.
+	pending map[uint64]*LockRecord
+}
+
+// NewRegistry creates an instance of Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		granted: make(map[string]*LockRecord),
+		pending: make(map[uint64]*LockRecord),
+	}
+}
+
+// GlobalRegistry is the global instance of Registry, which is used to maintain the advisory locks of this instance.
+var GlobalRegistry = NewRegistry()
+
+// Wait marks that the session begins to wait for the lock. It returns false without marking anything if waiting
+// would cause a deadlock, that is, the lock is held by a session which is waiting for a lock held by this session,
+// directly or through a chain of the other sessions.
+func (r *Registry) Wait(name string, sessionID uint64) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	lockName := name
+	for i := 0; i <= len(r.pending); i++ {
+		holder, ok := r.granted[lockName]
+		if !ok {
+			break
+		}
+		if holder.SessionID == sessionID {
+			return false
+		}
+		waiting, ok := r.pending[holder.SessionID]
+		if !ok {
+			break
+		}
+		lockName = waiting.Name
+	}
+	r.pending[sessionID] = &LockRecord{Name: name, SessionID: sessionID, State: StatePending, StartTime: time.Now()}
+	return true
+}
+
+// Cancel marks that the session stops waiting for its lock.
+func (r *Registry) Cancel(sessionID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, sessionID)
+}
+
+// Grant marks that the session has acquired the lock.
+func (r *Registry) Grant(name string, sessionID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, sessionID)
+	r.granted[name] = &LockRecord{Name: name, SessionID: sessionID, State: StateGranted, StartTime: time.Now()}
+}
+
+// Release marks that the session has released the lock.
+func (r *Registry) Release(name string, sessionID uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if holder, ok := r.granted[name]; ok && holder.SessionID == sessionID {
+		delete(r.granted, name)
+	}
+}
+
+// Holder returns the ID of the session of this instance holding the lock.
+func (r *Registry) Holder(name string) (sessionID uint64, ok bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	holder, ok := r.granted[name]
+	if !ok {
+		return 0, false
+	}
+	return holder.SessionID, true
+}
+
+// GetAll gets all the held and waited locks, ordered by the lock names and the states.
+func (r *Registry) GetAll() []LockRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	res := make([]LockRecord, 0, len(r.granted)+len(r.pending))
+	for _, rec := range r.granted {
+		res = append(res, *rec)
+	}
+	for _, rec := range r.pending {
+		res = append(res, *rec)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Name != res[j].Name {
+			return res[i].Name < res[j].Name
+		}
+		if res[i].State != res[j].State {
+			return res[i].State < res[j].State
+		}
+		return res[i].SessionID < res[j].SessionID
+	})
+	return res
+}
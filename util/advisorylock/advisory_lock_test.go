@@ -0,0 +1,79 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisorylock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	require.True(t, r.Wait("a", 1))
+	r.Grant("a", 1)
+	require.True(t, r.Wait("b", 2))
+	r.Grant("b", 2)
+	holder, ok := r.Holder("a")
+	require.True(t, ok)
+	require.Equal(t, uint64(1), holder)
+	_, ok = r.Holder("c")
+	require.False(t, ok)
+
+	// Session 1 waits for session 2, so session 2 can't wait for session 1.
+	require.True(t, r.Wait("b", 1))
+	require.False(t, r.Wait("a", 2))
+	// The deadlock is also detected through a chain of sessions.
+	require.True(t, r.Wait("c", 3))
+	r.Grant("c", 3)
+	require.True(t, r.Wait("c", 2))
+	require.False(t, r.Wait("a", 3))
+
+	locks := r.GetAll()
+	require.Len(t, locks, 5)
+	expected := []struct {
+		name      string
+		sessionID uint64
+		state     string
+	}{
+		{"a", 1, StateGranted},
+		{"b", 2, StateGranted},
+		{"b", 1, StatePending},
+		{"c", 3, StateGranted},
+		{"c", 2, StatePending},
+	}
+	for i, e := range expected {
+		require.Equal(t, e.name, locks[i].Name)
+		require.Equal(t, e.sessionID, locks[i].SessionID)
+		require.Equal(t, e.state, locks[i].State)
+	}
+	datums := locks[0].ToDatums()
+	require.Len(t, datums, 4)
+	require.Equal(t, "a", datums[0].GetString())
+	require.Equal(t, uint64(1), datums[1].GetUint64())
+
+	r.Cancel(1)
+	r.Cancel(2)
+	// Only the holder can release the lock.
+	r.Release("a", 2)
+	_, ok = r.Holder("a")
+	require.True(t, ok)
+	r.Release("a", 1)
+	_, ok = r.Holder("a")
+	require.False(t, ok)
+	require.Len(t, r.GetAll(), 2)
+}
@@ -170,6 +170,9 @@ func bytesToI64Slice(b []byte) (i64s []int64) {
 const varElemLen = -1
 
 func getFixedLen(colType *types.FieldType) int {
+	if colType.Array {
+		return varElemLen
+	}
 	switch colType.Tp {
 	case mysql.TypeFloat:
 		return 4
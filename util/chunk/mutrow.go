@@ -82,6 +82,9 @@ func MutRowFromTypes(types []*types.FieldType) MutRow {
 }
 
 func zeroValForType(tp *types.FieldType) interface{} {
+	if tp.Array {
+		return json.CreateBinary(nil)
+	}
 	switch tp.Tp {
 	case mysql.TypeFloat:
 		return float32(0)
@@ -94,7 +97,7 @@ func zeroValForType(tp *types.FieldType) interface{} {
 		return int64(0)
 	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar:
 		return ""
-	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob:
+	case mysql.TypeBlob, mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		return []byte{}
 	case mysql.TypeDuration:
 		return types.ZeroDuration
@@ -0,0 +1,74 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenize(t *testing.T) {
+	t.Parallel()
+	tk := NewTokenizer("")
+	require.Equal(t, []string{"quick", "brown", "fox", "jumps", "over", "lazy", "dog_2"}, tk.Tokenize("The quick-brown FOX jumps over a lazy dog_2."))
+	require.Len(t, tk.Tokenize("数据库"), 1)
+
+	tk = NewTokenizer("NGRAM")
+	require.Equal(t, []string{"数据", "据库", "ab", "bc"}, tk.Tokenize("数据库，abc d"))
+
+	require.True(t, IsSupportedParser(""))
+	require.True(t, IsSupportedParser("ngram"))
+	require.False(t, IsSupportedParser("mecab"))
+}
+
+func TestQuery(t *testing.T) {
+	t.Parallel()
+	tk := NewTokenizer("")
+	doc := "MySQL is a database, TiDB is a distributed database"
+	tests := []struct {
+		query       string
+		booleanMode bool
+		relevance   float64
+		terms       []LookupTerm
+	}{
+		{"database", false, 2, []LookupTerm{{Token: "database"}}},
+		{"oracle tidb", false, 1, []LookupTerm{{Token: "oracle"}, {Token: "tidb"}}},
+		{"the oracle", false, 0, []LookupTerm{{Token: "oracle"}}},
+		{"+mysql -oracle", true, 1, []LookupTerm{{Token: "mysql"}}},
+		{"+mysql -tidb", true, 0, []LookupTerm{{Token: "mysql"}}},
+		{"-mysql", true, 0, nil},
+		{"data* ~tidb", true, 2.5, []LookupTerm{{Token: "data", Prefix: true}, {Token: "tidb"}}},
+		{`"distributed database"`, true, 1, []LookupTerm{{Token: "distributed"}}},
+		{`"tidb mysql"`, true, 0, []LookupTerm{{Token: "tidb"}}},
+		{"+(oracle tidb) +mysql", true, 2, []LookupTerm{{Token: "oracle"}, {Token: "tidb"}}},
+		{"+(oracle postgres) mysql", true, 0, []LookupTerm{{Token: "oracle"}, {Token: "postgres"}}},
+	}
+	for _, tt := range tests {
+		q := ParseQuery(tk, tt.query, tt.booleanMode)
+		require.Equal(t, tt.relevance, q.Relevance(doc), tt.query)
+		require.Equal(t, tt.terms, q.LookupTerms(), tt.query)
+	}
+
+	tk = NewTokenizer(ParserNgram)
+	q := ParseQuery(tk, "+数据库", true)
+	require.Equal(t, float64(1), q.Relevance("分布式数据库"))
+	require.Equal(t, float64(0), q.Relevance("数据和仓库"))
+	require.Equal(t, []LookupTerm{{Token: "数据"}}, q.LookupTerms())
+	q = ParseQuery(tk, "数据库", false)
+	require.Equal(t, float64(1), q.Relevance("数据和仓库"))
+	q = ParseQuery(tk, "分*", true)
+	require.Equal(t, float64(1), q.Relevance("分布式数据库"))
+}
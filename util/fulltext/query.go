@@ -0,0 +1,283 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+)
+
+type operator byte
+
+const (
+	opOptional operator = iota
+	// opRequired is the `+` operator, the word must be present in the matched rows.
+	opRequired
+	// opExcluded is the `-` operator, the word must not be present in the matched rows.
+	opExcluded
+	// opNegation is the `~` operator, the word decreases the relevance of the matched rows.
+	opNegation
+)
+
+// item is a word, a phrase or a group of a search string.
+type item struct {
+	op operator
+	// tokens are the tokens of the word or the phrase, they must be adjacent in the matched rows.
+	tokens []string
+	// prefix indicates the tokens[0] is the prefix of the token to match, like `word*`.
+	prefix bool
+	// group is the sub-expression in the parentheses.
+	group []*item
+}
+
+// Query is a parsed full-text search string.
+type Query struct {
+	tk    Tokenizer
+	items []*item
+}
+
+// ParseQuery parses the search string of `MATCH ... AGAINST`.
+// In the natural language mode, the rows containing any token of the search string are matched.
+// In the boolean mode, the operators `+`, `-`, `~`, `*`, `""` and `()` are supported,
+// `<` and `>` are accepted but don't change the relevance.
+func ParseQuery(tk Tokenizer, text string, booleanMode bool) *Query {
+	q := &Query{tk: tk}
+	if booleanMode {
+		p := &booleanParser{tk: tk, s: []rune(text)}
+		q.items = p.parseList(false)
+		return q
+	}
+	seen := make(map[string]struct{})
+	for _, token := range tk.Tokenize(text) {
+		if _, ok := seen[token]; ok {
+			continue
+		}
+		seen[token] = struct{}{}
+		q.items = append(q.items, &item{tokens: []string{token}})
+	}
+	return q
+}
+
+type booleanParser struct {
+	tk  Tokenizer
+	s   []rune
+	pos int
+}
+
+func (p *booleanParser) parseList(inGroup bool) []*item {
+	var items []*item
+	for p.pos < len(p.s) {
+		if p.s[p.pos] == ')' {
+			p.pos++
+			if inGroup {
+				return items
+			}
+			continue
+		}
+		op := opOptional
+	opLoop:
+		for ; p.pos < len(p.s); p.pos++ {
+			switch p.s[p.pos] {
+			case '+':
+				op = opRequired
+			case '-':
+				op = opExcluded
+			case '~':
+				op = opNegation
+			case '<', '>':
+				op = opOptional
+			default:
+				break opLoop
+			}
+		}
+		if p.pos >= len(p.s) {
+			break
+		}
+		var it *item
+		switch r := p.s[p.pos]; {
+		case r == '(':
+			p.pos++
+			it = &item{group: p.parseList(true)}
+		case r == '"':
+			p.pos++
+			end := p.pos
+			for end < len(p.s) && p.s[end] != '"' {
+				end++
+			}
+			it = &item{tokens: p.tk.Tokenize(string(p.s[p.pos:end]))}
+			p.pos = end + 1
+		case isWordRune(r):
+			start := p.pos
+			for p.pos < len(p.s) && isWordRune(p.s[p.pos]) {
+				p.pos++
+			}
+			word := string(p.s[start:p.pos])
+			if p.pos < len(p.s) && p.s[p.pos] == '*' {
+				p.pos++
+				tokens, exact := p.tk.PrefixTokens(word)
+				it = &item{tokens: tokens, prefix: !exact && len(tokens) > 0}
+			} else {
+				it = &item{tokens: p.tk.Tokenize(word)}
+			}
+		default:
+			p.pos++
+			continue
+		}
+		// The stopwords and the too short or too long words are ignored.
+		if len(it.tokens) == 0 && len(it.group) == 0 {
+			continue
+		}
+		it.op = op
+		items = append(items, it)
+	}
+	return items
+}
+
+// document is the tokenized text of a row.
+type document struct {
+	tokens []string
+	tf     map[string]int
+}
+
+func newDocument(tk Tokenizer, text string) *document {
+	d := &document{tokens: tk.Tokenize(text), tf: make(map[string]int)}
+	for _, token := range d.tokens {
+		d.tf[token]++
+	}
+	return d
+}
+
+// count returns the occurrence count of the word or the phrase in the document.
+func (d *document) count(it *item) int {
+	if it.prefix {
+		cnt := 0
+		for token, tf := range d.tf {
+			if strings.HasPrefix(token, it.tokens[0]) {
+				cnt += tf
+			}
+		}
+		return cnt
+	}
+	if len(it.tokens) == 1 {
+		return d.tf[it.tokens[0]]
+	}
+	cnt := 0
+	for i := 0; i+len(it.tokens) <= len(d.tokens); i++ {
+		matched := true
+		for j, token := range it.tokens {
+			if d.tokens[i+j] != token {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			cnt++
+		}
+	}
+	return cnt
+}
+
+// relevance returns the relevance of the items and whether the document is matched.
+func (d *document) relevance(items []*item) (float64, bool) {
+	var score float64
+	hasRequired, optionalMatched := false, false
+	for _, it := range items {
+		var s float64
+		var matched bool
+		if len(it.group) > 0 {
+			s, matched = d.relevance(it.group)
+		} else if cnt := d.count(it); cnt > 0 {
+			s, matched = float64(cnt), true
+		}
+		switch it.op {
+		case opRequired:
+			if !matched {
+				return 0, false
+			}
+			hasRequired = true
+			score += s
+		case opExcluded:
+			if matched {
+				return 0, false
+			}
+		case opNegation:
+			if matched {
+				optionalMatched = true
+				score += s / 2
+			}
+		default:
+			if matched {
+				optionalMatched = true
+				score += s
+			}
+		}
+	}
+	if !hasRequired && !optionalMatched {
+		return 0, false
+	}
+	return score, true
+}
+
+// Relevance returns the relevance of the text to the search string. It's positive if the text is matched,
+// otherwise it's 0. The relevance is based on the term frequency of the searched words in the text only.
+func (q *Query) Relevance(text string) float64 {
+	score, _ := newDocument(q.tk, text).relevance(q.items)
+	return score
+}
+
+// LookupTerm is a token, or the prefix of the tokens, to look up in a FULLTEXT index.
+type LookupTerm struct {
+	Token  string
+	Prefix bool
+}
+
+// LookupTerms returns the terms to look up in the FULLTEXT index. Every matched row contains
+// at least one of the terms. Nothing can be matched if no term is returned.
+func (q *Query) LookupTerms() []LookupTerm {
+	terms := lookupTerms(q.items)
+	seen := make(map[LookupTerm]struct{}, len(terms))
+	res := terms[:0]
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+		seen[term] = struct{}{}
+		res = append(res, term)
+	}
+	return res
+}
+
+func lookupTerms(items []*item) []LookupTerm {
+	// All the matched rows contain the required item, use it if there is one.
+	for _, it := range items {
+		if it.op == opRequired {
+			return itemLookupTerms(it)
+		}
+	}
+	var terms []LookupTerm
+	for _, it := range items {
+		if it.op != opExcluded {
+			terms = append(terms, itemLookupTerms(it)...)
+		}
+	}
+	return terms
+}
+
+func itemLookupTerms(it *item) []LookupTerm {
+	if len(it.group) > 0 {
+		return lookupTerms(it.group)
+	}
+	// The rows matching the phrase contain its first token.
+	return []LookupTerm{{Token: it.tokens[0], Prefix: it.prefix}}
+}
@@ -0,0 +1,129 @@
+// Copyright 2021 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fulltext
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// ParserNgram is the name of the ngram full-text parser.
+	ParserNgram = "ngram"
+
+	// MinTokenSize is the minimum length of the tokens stored by the built-in parser, like innodb_ft_min_token_size.
+	MinTokenSize = 3
+	// MaxTokenSize is the maximum length of the tokens stored by the built-in parser, like innodb_ft_max_token_size.
+	MaxTokenSize = 84
+	// NgramTokenSize is the length of the tokens stored by the ngram parser, like ngram_token_size.
+	NgramTokenSize = 2
+)
+
+// stopwords is the default stopword list of InnoDB.
+var stopwords = map[string]struct{}{
+	"a": {}, "about": {}, "an": {}, "are": {}, "as": {}, "at": {}, "be": {}, "by": {}, "com": {}, "de": {},
+	"en": {}, "for": {}, "from": {}, "how": {}, "i": {}, "in": {}, "is": {}, "it": {}, "la": {}, "of": {},
+	"on": {}, "or": {}, "that": {}, "the": {}, "this": {}, "to": {}, "was": {}, "what": {}, "when": {},
+	"where": {}, "who": {}, "will": {}, "with": {}, "und": {}, "www": {},
+}
+
+// Tokenizer splits the text into the tokens stored in a FULLTEXT index.
+type Tokenizer interface {
+	// Tokenize returns the tokens of the text in order. A token may appear more than once.
+	Tokenize(text string) []string
+	// PrefixTokens returns the tokens to match for the prefix of a truncated word `prefix*`.
+	// If exact is true, the returned tokens must be matched as a phrase instead of as a prefix.
+	PrefixTokens(prefix string) (tokens []string, exact bool)
+}
+
+// IsSupportedParser returns whether the full-text parser is supported.
+// The empty name stands for the built-in parser.
+func IsSupportedParser(name string) bool {
+	return name == "" || strings.EqualFold(name, ParserNgram)
+}
+
+// NewTokenizer returns the tokenizer of the full-text parser.
+func NewTokenizer(parser string) Tokenizer {
+	if strings.EqualFold(parser, ParserNgram) {
+		return ngramTokenizer{n: NgramTokenSize}
+	}
+	return wordTokenizer{}
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+// splitWords splits the text into the lower case words made up of letters, digits and underscores.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !isWordRune(r)
+	})
+}
+
+// wordTokenizer is the built-in parser, it splits the text at the non-word characters,
+// and skips the stopwords and the words whose length are out of [MinTokenSize, MaxTokenSize].
+type wordTokenizer struct{}
+
+func (wordTokenizer) Tokenize(text string) []string {
+	words := splitWords(text)
+	tokens := words[:0]
+	for _, w := range words {
+		l := utf8.RuneCountInString(w)
+		if l < MinTokenSize || l > MaxTokenSize {
+			continue
+		}
+		if _, ok := stopwords[w]; ok {
+			continue
+		}
+		tokens = append(tokens, w)
+	}
+	return tokens
+}
+
+func (wordTokenizer) PrefixTokens(prefix string) ([]string, bool) {
+	words := splitWords(prefix)
+	if len(words) != 1 {
+		return words, true
+	}
+	return words, false
+}
+
+// ngramTokenizer is the ngram parser, it splits the text at the non-word characters
+// and stores every contiguous sequence of n characters of the words, which is suitable for
+// the CJK text without word delimiters.
+type ngramTokenizer struct {
+	n int
+}
+
+func (t ngramTokenizer) Tokenize(text string) []string {
+	var tokens []string
+	for _, w := range splitWords(text) {
+		runes := []rune(w)
+		for i := 0; i+t.n <= len(runes); i++ {
+			tokens = append(tokens, string(runes[i:i+t.n]))
+		}
+	}
+	return tokens
+}
+
+func (t ngramTokenizer) PrefixTokens(prefix string) ([]string, bool) {
+	words := splitWords(prefix)
+	if len(words) == 1 && utf8.RuneCountInString(words[0]) <= t.n {
+		return words, false
+	}
+	return t.Tokenize(prefix), true
+}
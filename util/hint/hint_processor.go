@@ -290,6 +290,9 @@ func ParseHintsSet(p *parser.Parser, sql, charset, collation, db string) (*Hints
 					tblHint.Tables[i].DBName = model.NewCIStr(db)
 				}
 			}
+			if list, ok := tblHint.HintData.(*ast.LeadingList); ok {
+				setLeadingListDBName(list, db)
+			}
 			newHints = append(newHints, tblHint)
 		}
 		hs.tableHints[i] = newHints
@@ -297,6 +300,20 @@ func ParseHintsSet(p *parser.Parser, sql, charset, collation, db string) (*Hints
 	return hs, stmtNodes[0], extractHintWarns(warns), nil
 }
 
+// setLeadingListDBName sets the database name of the tables without it in the LEADING hint.
+func setLeadingListDBName(list *ast.LeadingList, db string) {
+	for _, item := range list.Items {
+		switch x := item.(type) {
+		case *ast.HintTable:
+			if x.DBName.String() == "" {
+				x.DBName = model.NewCIStr(db)
+			}
+		case *ast.LeadingList:
+			setLeadingListDBName(x, db)
+		}
+	}
+}
+
 func extractHintWarns(warns []error) []error {
 	for _, w := range warns {
 		if parser.ErrParse.Equal(w) ||
@@ -552,4 +552,6 @@ const (
 	LabelForSimpleTask int = -18
 	// LabelForCTEStorage represents the label of CTE storage
 	LabelForCTEStorage int = -19
+	// LabelForInstancePlanCache represents the label of the instance-level prepared plan cache
+	LabelForInstancePlanCache int = -20
 )
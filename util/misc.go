@@ -422,6 +422,10 @@ func ColumnToProto(c *model.ColumnInfo) *tipb.ColumnInfo {
 		Elems:     c.Elems,
 	}
 	pc.Tp = int32(c.FieldType.Tp)
+	if c.FieldType.Array {
+		// The value of the array type is a JSON array.
+		pc.Tp = int32(mysql.TypeJSON)
+	}
 	return pc
 }
 
@@ -53,6 +53,8 @@ type Context struct {
 	cancel      context.CancelFunc
 	sm          util.SessionManager
 	pcache      *kvcache.SimpleLRUCache
+	// advisoryLocks maps the names of the held advisory locks to their reference counts.
+	advisoryLocks map[string]int
 }
 
 type wrapTxn struct {
@@ -335,6 +337,50 @@ func (c *Context) HasLockedTables() bool {
 	return false
 }
 
+// GetAdvisoryLock implements the sessionctx.Context interface.
+func (c *Context) GetAdvisoryLock(lockName string, timeout int64) error {
+	c.advisoryLocks[lockName]++
+	return nil
+}
+
+// ReleaseAdvisoryLock implements the sessionctx.Context interface.
+func (c *Context) ReleaseAdvisoryLock(lockName string) bool {
+	count, ok := c.advisoryLocks[lockName]
+	if !ok {
+		return false
+	}
+	if count > 1 {
+		c.advisoryLocks[lockName]--
+	} else {
+		delete(c.advisoryLocks, lockName)
+	}
+	return true
+}
+
+// ReleaseAllAdvisoryLocks implements the sessionctx.Context interface.
+func (c *Context) ReleaseAllAdvisoryLocks() int {
+	released := 0
+	for lockName, count := range c.advisoryLocks {
+		released += count
+		delete(c.advisoryLocks, lockName)
+	}
+	return released
+}
+
+// IsUsedAdvisoryLock implements the sessionctx.Context interface.
+func (c *Context) IsUsedAdvisoryLock(lockName string) (bool, error) {
+	_, ok := c.advisoryLocks[lockName]
+	return ok, nil
+}
+
+// AdvisoryLockHolder implements the sessionctx.Context interface.
+func (c *Context) AdvisoryLockHolder(lockName string) (uint64, error) {
+	if _, ok := c.advisoryLocks[lockName]; ok {
+		return c.sessionVars.ConnectionID, nil
+	}
+	return 0, nil
+}
+
 // PrepareTSFuture implements the sessionctx.Context interface.
 func (c *Context) PrepareTSFuture(ctx context.Context) {
 }
@@ -347,10 +393,11 @@ func (c *Context) Close() {
 func NewContext() *Context {
 	ctx, cancel := context.WithCancel(context.Background())
 	sctx := &Context{
-		values:      make(map[fmt.Stringer]interface{}),
-		sessionVars: variable.NewSessionVars(),
-		ctx:         ctx,
-		cancel:      cancel,
+		values:        make(map[fmt.Stringer]interface{}),
+		sessionVars:   variable.NewSessionVars(),
+		ctx:           ctx,
+		cancel:        cancel,
+		advisoryLocks: make(map[string]int),
 	}
 	sctx.sessionVars.InitChunkSize = 2
 	sctx.sessionVars.MaxChunkSize = 32
@@ -127,6 +127,18 @@ const (
 	TypeCTE = "CTEFullScan"
 	// TypeCTEDefinition is the type of CTE definition
 	TypeCTEDefinition = "CTE"
+	// TypeForeignKeyCheck is the type of FKCheck.
+	TypeForeignKeyCheck = "Foreign_Key_Check"
+	// TypeForeignKeyCascade is the type of FKCascade.
+	TypeForeignKeyCascade = "Foreign_Key_Cascade"
+	// TypeFullTextIndexLookUp is the type of FullTextIndexLookUp.
+	TypeFullTextIndexLookUp = "FullTextIndexLookUp"
+	// TypeSpatialIndexLookUp is the type of SpatialIndexLookUp.
+	TypeSpatialIndexLookUp = "SpatialIndexLookUp"
+	// TypeMVIndexLookUp is the type of MVIndexLookUp.
+	TypeMVIndexLookUp = "MVIndexLookUp"
+	// TypeJSONTable is the type of JSONTable.
+	TypeJSONTable = "JSONTable"
 )
 
 // plan id.
@@ -184,6 +196,12 @@ const (
 	typeCTE                   int = 50
 	typeCTEDefinition         int = 51
 	typeCTETable              int = 52
+	typeForeignKeyCheck       int = 53
+	typeForeignKeyCascade     int = 54
+	typeFullTextIndexLookUp   int = 55
+	typeSpatialIndexLookUp    int = 56
+	typeMVIndexLookUp         int = 57
+	typeJSONTable             int = 58
 )
 
 // TypeStringToPhysicalID converts the plan type string to plan id.
@@ -293,6 +311,18 @@ func TypeStringToPhysicalID(tp string) int {
 		return typeCTEDefinition
 	case TypeCTETable:
 		return typeCTETable
+	case TypeForeignKeyCheck:
+		return typeForeignKeyCheck
+	case TypeForeignKeyCascade:
+		return typeForeignKeyCascade
+	case TypeFullTextIndexLookUp:
+		return typeFullTextIndexLookUp
+	case TypeSpatialIndexLookUp:
+		return typeSpatialIndexLookUp
+	case TypeMVIndexLookUp:
+		return typeMVIndexLookUp
+	case TypeJSONTable:
+		return typeJSONTable
 	}
 	// Should never reach here.
 	return 0
@@ -403,6 +433,18 @@ func PhysicalIDToTypeString(id int) string {
 		return TypeCTEDefinition
 	case typeCTETable:
 		return TypeCTETable
+	case typeForeignKeyCheck:
+		return TypeForeignKeyCheck
+	case typeForeignKeyCascade:
+		return TypeForeignKeyCascade
+	case typeFullTextIndexLookUp:
+		return TypeFullTextIndexLookUp
+	case typeSpatialIndexLookUp:
+		return TypeSpatialIndexLookUp
+	case typeMVIndexLookUp:
+		return TypeMVIndexLookUp
+	case typeJSONTable:
+		return TypeJSONTable
 	}
 
 	// Should never reach here.
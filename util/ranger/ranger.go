@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/types/spatial"
 	"github.com/pingcap/tidb/util/codec"
 )
 
@@ -337,6 +338,23 @@ func BuildColumnRange(conds []expression.Expression, sc *stmtctx.StatementContex
 	return buildColumnRange(conds, sc, tp, false, colLen)
 }
 
+// maxSpatialCoveringCells limits the number of the cells covering the rectangle of a spatial range.
+const maxSpatialCoveringCells = 16
+
+// BuildSpatialRanges builds the ranges of the SPATIAL index for the geometries whose MBR intersects the rectangle.
+// The indexed values of the SPATIAL index are the ids of the cells containing the geometries.
+func BuildSpatialRanges(m spatial.MBR) []*Range {
+	cellRanges := spatial.CoveringRanges(m, maxSpatialCoveringCells)
+	ranges := make([]*Range, 0, len(cellRanges))
+	for _, cr := range cellRanges {
+		ranges = append(ranges, &Range{
+			LowVal:  []types.Datum{types.NewUintDatum(uint64(cr.Min))},
+			HighVal: []types.Datum{types.NewUintDatum(uint64(cr.Max))},
+		})
+	}
+	return ranges
+}
+
 // buildCNFIndexRange builds the range for index where the top layer is CNF.
 func (d *rangeDetacher) buildCNFIndexRange(newTp []*types.FieldType,
 	eqAndInCount int, accessCondition []expression.Expression) ([]*Range, error) {
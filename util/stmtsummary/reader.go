@@ -308,6 +308,7 @@ const (
 	LastSeenStr                     = "LAST_SEEN"
 	PlanInCacheStr                  = "PLAN_IN_CACHE"
 	PlanCacheHitsStr                = "PLAN_CACHE_HITS"
+	PlanCacheMissesStr              = "PLAN_CACHE_MISSES"
 	PlanInBindingStr                = "PLAN_IN_BINDING"
 	QuerySampleTextStr              = "QUERY_SAMPLE_TEXT"
 	PrevSampleTextStr               = "PREV_SAMPLE_TEXT"
@@ -581,6 +582,9 @@ var columnValueFactoryMap = map[string]columnValueFactory{
 	PlanCacheHitsStr: func(ssElement *stmtSummaryByDigestElement, _ *stmtSummaryByDigest) interface{} {
 		return ssElement.planCacheHits
 	},
+	PlanCacheMissesStr: func(ssElement *stmtSummaryByDigestElement, _ *stmtSummaryByDigest) interface{} {
+		return ssElement.planCacheMisses
+	},
 	PlanInBindingStr: func(ssElement *stmtSummaryByDigestElement, _ *stmtSummaryByDigest) interface{} {
 		return ssElement.planInBinding
 	},
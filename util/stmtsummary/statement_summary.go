@@ -195,9 +195,10 @@ type stmtSummaryByDigestElement struct {
 	// The last time this type of SQL executes.
 	lastSeen time.Time
 	// plan cache
-	planInCache   bool
-	planCacheHits int64
-	planInBinding bool
+	planInCache     bool
+	planCacheHits   int64
+	planCacheMisses int64
+	planInBinding   bool
 	// pessimistic execution retry information.
 	execRetryCount uint
 	execRetryTime  time.Duration
@@ -229,6 +230,7 @@ type StmtExecInfo struct {
 	IsInternal     bool
 	Succeed        bool
 	PlanInCache    bool
+	PlanCacheMiss  bool
 	PlanInBinding  bool
 	ExecRetryCount uint
 	ExecRetryTime  time.Duration
@@ -780,6 +782,9 @@ func (ssElement *stmtSummaryByDigestElement) add(sei *StmtExecInfo, intervalSeco
 	} else {
 		ssElement.planInCache = false
 	}
+	if sei.PlanCacheMiss {
+		ssElement.planCacheMisses += 1
+	}
 
 	// SPM
 	if sei.PlanInBinding {